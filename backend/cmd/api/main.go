@@ -27,6 +27,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -44,13 +45,20 @@ import (
 	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/handler"
 	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
 	"github.com/nmn3m/pulsar/backend/internal/adapter/outbound/postgres"
+	"github.com/nmn3m/pulsar/backend/internal/adapter/outbound/provider"
 	"github.com/nmn3m/pulsar/backend/internal/config"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/service"
 	"github.com/nmn3m/pulsar/backend/internal/pkg/logger"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/prommetrics"
 	"github.com/nmn3m/pulsar/backend/internal/pkg/telemetry"
 	"github.com/nmn3m/pulsar/backend/internal/pkg/tokenblacklist"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/worker"
 )
 
+// version is overridden at build time with -ldflags "-X main.version=..."
+var version = "dev"
+
 func main() {
 	// Load configuration
 	cfg, err := config.Load()
@@ -91,6 +99,7 @@ func main() {
 	defer log.Sync()
 
 	log.Info("Starting Pulsar API server",
+		zap.String("version", version),
 		zap.String("env", cfg.Server.Env),
 		zap.String("port", cfg.Server.Port),
 	)
@@ -106,7 +115,7 @@ func main() {
 	}
 
 	// Connect to database
-	db, err := postgres.NewDB(cfg.Database.URL)
+	db, err := postgres.NewDB(&cfg.Database)
 	if err != nil {
 		log.Fatal("Failed to connect to database", zap.Error(err))
 	}
@@ -118,25 +127,34 @@ func main() {
 	userRepo := postgres.NewUserRepository(db)
 	orgRepo := postgres.NewOrganizationRepository(db)
 	alertRepo := postgres.NewAlertRepository(db)
+	alertAckTokenRepo := postgres.NewAlertAckTokenRepository(db)
 	teamRepo := postgres.NewTeamRepository(db)
 	scheduleRepo := postgres.NewScheduleRepository(db)
 	escalationRepo := postgres.NewEscalationPolicyRepository(db)
-	notificationRepo := postgres.NewNotificationRepository(db.DB)
-	incidentRepo := postgres.NewIncidentRepository(db.DB)
-	webhookRepo := postgres.NewWebhookRepository(db.DB)
+	notificationRepo := postgres.NewNotificationRepository(db)
+	incidentRepo := postgres.NewIncidentRepository(db)
+	webhookRepo := postgres.NewWebhookRepository(db)
+	outboxRepo := postgres.NewOutboxRepository(db)
 	apiKeyRepo := postgres.NewAPIKeyRepository(db.DB)
-	metricsRepo := postgres.NewMetricsRepository(db.DB)
+	metricsRepo := postgres.NewMetricsRepository(db.Reader())
 	emailVerificationRepo := postgres.NewEmailVerificationRepository(db)
 	routingRepo := postgres.NewRoutingRuleRepository(db)
+	orgImportRepo := postgres.NewOrganizationImportRepository(db)
 	dndRepo := postgres.NewDNDSettingsRepository(db)
+	availabilityRepo := postgres.NewUserAvailabilityRepository(db)
 	invitationRepo := postgres.NewTeamInvitationRepo(db)
+	maintenanceRepo := postgres.NewMaintenanceWindowRepository(db)
+	alertSourceRepo := postgres.NewAlertSourceRepository(db)
+	loginAttemptRepo := postgres.NewLoginAttemptRepository(db)
+	sessionRepo := postgres.NewSessionRepository(db)
 
 	// Initialize email service (for OTP verification and team invitations)
 	var emailSvc *service.EmailService
 	var emailVerificationService *service.EmailVerificationService
 	if cfg.Email.Enabled || cfg.SMTP.Enabled {
 		emailSvc = service.NewEmailService(&cfg.Email, &cfg.SMTP)
-		emailVerificationService = service.NewEmailVerificationService(emailVerificationRepo, userRepo, emailSvc)
+		emailVerificationService = service.NewEmailVerificationService(emailVerificationRepo, userRepo, emailSvc, log)
+		emailVerificationService.SetEnvironment(cfg.Server.Env)
 		if cfg.Email.Provider == "resend" {
 			log.Info("Email service enabled with Resend provider",
 				zap.String("from", cfg.Email.From),
@@ -158,33 +176,89 @@ func main() {
 	authService := service.NewAuthService(userRepo, orgRepo, service.AuthConfig{
 		JWTSecret:        cfg.JWT.Secret,
 		JWTRefreshSecret: cfg.JWT.RefreshSecret,
-		AccessTTLMinutes: cfg.JWT.AccessTTL,
-		RefreshTTLDays:   cfg.JWT.RefreshTTL,
+		AccessTTL:        cfg.JWT.AccessTTL,
+		RefreshTTL:       cfg.JWT.RefreshTTL,
+		PasswordPolicy: domain.PasswordPolicy{
+			MinLength:      cfg.Password.MinLength,
+			RequireUpper:   cfg.Password.RequireUpper,
+			RequireLower:   cfg.Password.RequireLower,
+			RequireDigit:   cfg.Password.RequireDigit,
+			RequireSpecial: cfg.Password.RequireSpecial,
+			Denylist:       cfg.Password.Denylist,
+		},
+		LoginPolicy: domain.LoginPolicy{
+			MaxAccountFailures: cfg.Login.MaxAccountFailures,
+			MaxIPFailures:      cfg.Login.MaxIPFailures,
+			FailureWindow:      cfg.Login.FailureWindow,
+			LockoutDuration:    cfg.Login.LockoutDuration,
+		},
 	}, emailVerificationService, tokenBlacklist, log)
+	authService.SetLoginAttemptRepo(loginAttemptRepo)
+	authService.SetSessionRepo(sessionRepo)
 	teamService := service.NewTeamService(teamRepo, userRepo)
 	teamService.SetInvitationRepo(invitationRepo)
+	teamService.SetOrganizationRepo(orgRepo)
+	teamService.SetInvitationSignup(authService)
 	if emailSvc != nil {
 		teamService.SetEmailService(emailSvc)
 	}
 	userService := service.NewUserService(orgRepo, userRepo)
+	userService.SetLoginAttemptRepo(loginAttemptRepo)
+	userService.SetSessionRepo(sessionRepo)
 	scheduleService := service.NewScheduleService(scheduleRepo, userRepo)
-	notificationService := service.NewNotificationService(notificationRepo)
+	scheduleService.SetOrganizationRepo(orgRepo)
+	scheduleService.SetTeamRepo(teamRepo)
+	scheduleService.SetAvailabilityRepo(availabilityRepo)
+	teamService.SetMembershipSync(scheduleService)
+	notificationService := service.NewNotificationService(notificationRepo, log)
 	wsService := service.NewWebSocketService(log)
 	incidentService := service.NewIncidentService(incidentRepo, wsService)
+	incidentService.SetAlertRepo(alertRepo)
+	incidentService.SetOrganizationRepo(orgRepo)
+	incidentService.SetEscalationPolicyRepo(escalationRepo)
+	incidentService.SetOnCallResolver(scheduleService)
+	incidentService.SetWarRoomCreator(provider.NewSlackWarRoomClient(provider.SlackAPIBaseURL))
+	incidentService.SetIssueTracker(provider.NewJiraClient())
+	incidentService.SetStatuspagePublisher(provider.NewStatuspageClient(provider.StatuspageAPIBaseURL))
 	webhookService := service.NewWebhookService(webhookRepo, log)
+	webhookService.SetDeliveryConcurrency(cfg.Workers.WebhookConcurrency)
+	incidentService.SetWebhookDispatcher(webhookService)
 	apiKeyService := service.NewAPIKeyService(apiKeyRepo)
 	metricsService := service.NewMetricsService(metricsRepo)
+	workloadService := service.NewWorkloadService(alertRepo, incidentRepo, scheduleService)
 
 	// Initialize DND and routing services
 	dndService := service.NewDNDService(dndRepo)
+	availabilityService := service.NewAvailabilityService(availabilityRepo)
 	routingService := service.NewRoutingService(routingRepo)
+	routingService.SetEscalationPolicyRepo(escalationRepo)
+	maintenanceService := service.NewMaintenanceWindowService(maintenanceRepo, routingService)
+	alertSourceService := service.NewAlertSourceService(alertSourceRepo)
 
 	// Initialize alert notifier with dependencies (including DND service for quiet hours)
 	alertNotifier := service.NewAlertNotifier(notificationService, userRepo, teamRepo, scheduleService, dndService)
+	alertNotifier.SetOrganizationRepo(orgRepo)
+	handoffNotifier := service.NewHandoffNotifier(scheduleRepo, userRepo, scheduleService, notificationService)
 
 	// Initialize alert and escalation services with notifier
-	alertService := service.NewAlertService(alertRepo, alertNotifier, wsService, webhookService)
-	escalationService := service.NewEscalationService(escalationRepo, alertRepo, alertNotifier)
+	outboxRelay := service.NewOutboxRelay(outboxRepo, alertRepo, wsService, webhookService, log)
+	alertService := service.NewAlertService(alertRepo, alertNotifier, wsService, webhookService, log)
+	alertService.SetOutboxPublisher(outboxRelay)
+	alertService.SetOrganizationRepo(orgRepo)
+	alertService.SetMaintenanceMatcher(maintenanceService)
+	alertService.SetSourceNormalizer(alertSourceService)
+	alertService.SetEscalationPolicySelector(routingService)
+	alertService.SetEscalationPolicyRepo(escalationRepo)
+	alertService.SetOnCallResolver(scheduleService)
+	alertService.SetAckTokens(alertAckTokenRepo, userRepo, service.AckTokenConfig{
+		Secret: cfg.AckLinks.Secret,
+		TTL:    cfg.AckLinks.TTL,
+	})
+	alertNotifier.SetAckLinks(alertService, cfg.AckLinks.BaseURL)
+	incidentService.SetAlertCloser(alertService)
+	escalationService := service.NewEscalationService(escalationRepo, alertRepo, orgRepo, teamRepo, scheduleRepo, alertNotifier)
+	escalationService.SetTargetPreviewer(alertNotifier)
+	orgService := service.NewOrganizationService(orgRepo, teamRepo, scheduleRepo, escalationRepo, routingRepo, notificationRepo, alertRepo, incidentRepo, orgImportRepo)
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authService, emailVerificationService, tokenBlacklist)
@@ -196,15 +270,24 @@ func main() {
 	notificationHandler := handler.NewNotificationHandler(notificationService)
 	incidentHandler := handler.NewIncidentHandler(incidentService)
 	wsHandler := handler.NewWebSocketHandler(wsService, log, cfg.CORS.AllowedOrigins)
+	eventStreamHandler := handler.NewEventStreamHandler(wsService, log)
+	metaHandler := handler.NewMetaHandler()
+	workloadHandler := handler.NewWorkloadHandler(workloadService)
 	webhookHandler := handler.NewWebhookHandler(webhookService)
 	incomingWebhookHandler := handler.NewIncomingWebhookHandler(webhookService, alertService, log)
 	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
 	metricsHandler := handler.NewMetricsHandler(metricsService)
 	routingHandler := handler.NewRoutingHandler(routingService)
+	maintenanceHandler := handler.NewMaintenanceWindowHandler(maintenanceService)
+	alertSourceHandler := handler.NewAlertSourceHandler(alertSourceService)
 	dndHandler := handler.NewDNDHandler(dndService)
+	availabilityHandler := handler.NewAvailabilityHandler(availabilityService)
+	healthHandler := handler.NewHealthHandler(db, version, log)
+	orgHandler := handler.NewOrganizationHandler(orgService)
 
 	// Initialize middleware
 	authMiddleware := middleware.NewAuthMiddleware(cfg.JWT.Secret, tokenBlacklist)
+	authMiddleware.SetMembershipResolver(orgRepo)
 	apiKeyMiddleware := middleware.NewAPIKeyMiddleware(apiKeyService)
 	combinedAuth := middleware.NewCombinedAuthMiddleware(authMiddleware, apiKeyMiddleware)
 
@@ -214,17 +297,66 @@ func main() {
 	}
 
 	router := gin.New()
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatal("Invalid trusted proxies", zap.Error(err))
+	}
 	router.Use(gin.Recovery())
+	router.Use(middleware.RequestID())
 	router.Use(middleware.Logger(log))
-	router.Use(middleware.CORS(cfg.CORS.AllowedOrigins))
+	router.Use(middleware.CORS(middleware.CORSOptions{
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
+		AllowedMethods:   cfg.CORS.AllowedMethods,
+		AllowedHeaders:   cfg.CORS.AllowedHeaders,
+		ExposedHeaders:   cfg.CORS.ExposedHeaders,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		MaxAge:           cfg.CORS.MaxAge,
+	}))
 	router.Use(middleware.SecurityHeaders())
 
 	// Add OpenTelemetry middleware if enabled
 	if cfg.Telemetry.Enabled {
 		router.Use(middleware.OTelMiddleware(cfg.Telemetry.ServiceName))
+		router.Use(middleware.OTelRequestIDMiddleware())
 		router.Use(middleware.OTelMetricsMiddleware())
 	}
 
+	// Prometheus metrics for Pulsar itself (served outside /api/v1 so it
+	// doesn't collide with the dashboard metrics routes). Queue depths are
+	// measured by a worker rather than on scrape, so scrapes never query the
+	// database.
+	var queueDepths []*prommetrics.QueueDepth
+	if cfg.Metrics.Enabled {
+		router.Use(middleware.PrometheusMiddleware())
+
+		if err := prommetrics.RegisterDBStats(db.DB.DB); err != nil {
+			log.Error("Failed to register database pool metrics", zap.Error(err))
+		}
+		if depth, err := prommetrics.RegisterQueueDepth("webhook_deliveries_pending", "Number of webhook deliveries waiting to be sent.", webhookRepo.CountPendingDeliveries); err != nil {
+			log.Error("Failed to register webhook queue metric", zap.Error(err))
+		} else {
+			queueDepths = append(queueDepths, depth)
+		}
+		if depth, err := prommetrics.RegisterQueueDepth("escalations_pending", "Number of escalation events that are due but not yet processed.", func(ctx context.Context) (int, error) {
+			return escalationRepo.CountPendingEscalations(ctx, time.Now())
+		}); err != nil {
+			log.Error("Failed to register escalation backlog metric", zap.Error(err))
+		} else {
+			queueDepths = append(queueDepths, depth)
+		}
+
+		router.GET("/metrics", middleware.MetricsAuth(cfg.Metrics.Token), gin.WrapH(prommetrics.Handler()))
+	}
+	refreshQueueDepths := func(ctx context.Context) error {
+		var errs []error
+		for _, depth := range queueDepths {
+			errs = append(errs, depth.Refresh(ctx))
+		}
+		return errors.Join(errs...)
+	}
+	if err := refreshQueueDepths(context.Background()); err != nil {
+		log.Warn("Failed to measure queue depths", zap.Error(err))
+	}
+
 	// Swagger documentation
 	if cfg.Server.Env != "production" {
 		router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -233,13 +365,10 @@ func main() {
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
-		// Health check
-		v1.GET("/health", func(c *gin.Context) {
-			c.JSON(http.StatusOK, gin.H{
-				"status": "healthy",
-				"time":   time.Now().UTC(),
-			})
-		})
+		// Health checks: /health for liveness, /health/ready for readiness
+		v1.GET("/health", healthHandler.Live)
+		v1.GET("/health/ready", healthHandler.Ready)
+
 		// Auth routes (public) with rate limiting
 		authRateLimiter := middleware.NewRateLimiter(5.0/60.0, 10) // 5 req/min, burst 10
 		auth := v1.Group("/auth")
@@ -253,11 +382,20 @@ func main() {
 			auth.POST("/resend-otp", authHandler.ResendOTP)
 		}
 
+		// Invitation links work signed in or out so new users can create an account
+		v1.POST("/teams/invitations/accept", authRateLimiter.Limit(), authMiddleware.OptionalAuth(), teamHandler.AcceptInvitation)
+
 		// Protected routes
 		protected := v1.Group("")
 		protected.Use(authMiddleware.RequireAuth())
 		{
 			protected.GET("/auth/me", authHandler.GetMe)
+			protected.GET("/auth/sessions", authHandler.ListSessions)
+			protected.DELETE("/auth/sessions", authHandler.RevokeAllSessions)
+			protected.DELETE("/auth/sessions/:id", authHandler.RevokeSession)
+			protected.POST("/auth/switch-organization", authHandler.SwitchOrganization)
+			protected.GET("/me/organizations", authHandler.ListOrganizations)
+			protected.PATCH("/auth/me", userHandler.UpdateProfile)
 
 			// API Key routes
 			apiKeys := protected.Group("/api-keys")
@@ -275,19 +413,38 @@ func main() {
 			// User routes
 			protected.GET("/users", userHandler.ListOrganizationUsers)
 			protected.PATCH("/users/me", userHandler.UpdateProfile)
+			protected.GET("/me/workload", workloadHandler.Get)
+			protected.POST("/users/:id/deactivate", userHandler.DeactivateUser)
+			protected.POST("/users/:id/reactivate", userHandler.ReactivateUser)
+			protected.POST("/users/:id/unlock", userHandler.UnlockUser)
+			protected.GET("/users/:id/sessions", userHandler.ListUserSessions)
+			protected.DELETE("/users/:id/sessions", userHandler.RevokeUserSessions)
+
+			// Organization routes
+			protected.GET("/organizations/export", orgHandler.Export)
+			protected.GET("/organizations/settings", orgHandler.GetSettings)
+			protected.PATCH("/organizations/settings", orgHandler.UpdateSettings)
+			protected.POST("/organizations/import", orgHandler.Import)
 
 			// Alert routes
 			alerts := protected.Group("/alerts")
 			{
 				alerts.GET("", alertHandler.List)
+				alerts.GET("/export.csv", alertHandler.Export)
+				alerts.GET("/grouped", alertHandler.Grouped)
 				alerts.POST("", alertHandler.Create)
+				alerts.POST("/tags", alertHandler.BulkTags)
 				alerts.GET("/:id", alertHandler.Get)
+				alerts.GET("/:id/oncall-at-creation", alertHandler.GetOnCallAtCreation)
 				alerts.PATCH("/:id", alertHandler.Update)
 				alerts.DELETE("/:id", alertHandler.Delete)
 				alerts.POST("/:id/acknowledge", alertHandler.Acknowledge)
+				alerts.POST("/:id/unacknowledge", alertHandler.Unacknowledge)
 				alerts.POST("/:id/close", alertHandler.Close)
 				alerts.POST("/:id/snooze", alertHandler.Snooze)
 				alerts.POST("/:id/assign", alertHandler.Assign)
+				alerts.POST("/:id/notes", alertHandler.AddNote)
+				alerts.GET("/:id/notes", alertHandler.ListNotes)
 			}
 
 			// Team routes
@@ -309,14 +466,18 @@ func main() {
 			}
 
 			// Schedule routes
+			protected.GET("/oncall", scheduleHandler.ListOnCall)
 			schedules := protected.Group("/schedules")
 			{
 				schedules.GET("", scheduleHandler.List)
 				schedules.POST("", scheduleHandler.Create)
+				schedules.POST("/preview-oncall", scheduleHandler.PreviewOnCall)
 				schedules.GET("/:id", scheduleHandler.Get)
 				schedules.PATCH("/:id", scheduleHandler.Update)
 				schedules.DELETE("/:id", scheduleHandler.Delete)
+				schedules.PUT("/:id/config", scheduleHandler.ApplyConfig)
 				schedules.GET("/:id/oncall", scheduleHandler.GetOnCall)
+				schedules.GET("/:id/coverage", scheduleHandler.GetCoverage)
 
 				// Rotation routes
 				schedules.GET("/:id/rotations", scheduleHandler.ListRotations)
@@ -330,6 +491,7 @@ func main() {
 				schedules.POST("/:id/rotations/:rotationId/participants", scheduleHandler.AddParticipant)
 				schedules.DELETE("/:id/rotations/:rotationId/participants/:userId", scheduleHandler.RemoveParticipant)
 				schedules.PUT("/:id/rotations/:rotationId/participants/reorder", scheduleHandler.ReorderParticipants)
+				schedules.POST("/:id/rotations/:rotationId/participants/bulk", scheduleHandler.SetParticipants)
 
 				// Override routes
 				schedules.GET("/:id/overrides", scheduleHandler.ListOverrides)
@@ -347,6 +509,9 @@ func main() {
 				escalations.GET("/:id", escalationHandler.Get)
 				escalations.PATCH("/:id", escalationHandler.Update)
 				escalations.DELETE("/:id", escalationHandler.Delete)
+				escalations.PUT("/:id/config", escalationHandler.ApplyConfig)
+				escalations.POST("/:id/clone", escalationHandler.Clone)
+				escalations.GET("/:id/preview", escalationHandler.Preview)
 
 				// Rule routes
 				escalations.GET("/:id/rules", escalationHandler.ListRules)
@@ -372,6 +537,25 @@ func main() {
 				routing.DELETE("/:id", routingHandler.Delete)
 			}
 
+			// Maintenance window routes
+			maintenance := protected.Group("/maintenance-windows")
+			{
+				maintenance.GET("", maintenanceHandler.List)
+				maintenance.POST("", maintenanceHandler.Create)
+				maintenance.GET("/:id", maintenanceHandler.Get)
+				maintenance.PATCH("/:id", maintenanceHandler.Update)
+				maintenance.DELETE("/:id", maintenanceHandler.Delete)
+			}
+
+			// Alert source registry routes
+			sources := protected.Group("/sources")
+			{
+				sources.GET("", alertSourceHandler.List)
+				sources.POST("", alertSourceHandler.Create)
+				sources.PATCH("/:id", alertSourceHandler.Update)
+				sources.DELETE("/:id", alertSourceHandler.Delete)
+			}
+
 			// User DND (Do Not Disturb) routes
 			usersDND := protected.Group("/users/me/dnd")
 			{
@@ -383,6 +567,14 @@ func main() {
 				usersDND.DELETE("/overrides/:index", dndHandler.RemoveDNDOverride)
 			}
 
+			// User availability routes
+			usersUnavailability := protected.Group("/users/me/unavailability")
+			{
+				usersUnavailability.GET("", availabilityHandler.ListUnavailability)
+				usersUnavailability.POST("", availabilityHandler.AddUnavailability)
+				usersUnavailability.DELETE("/:id", availabilityHandler.RemoveUnavailability)
+			}
+
 			// Notification routes
 			notifications := protected.Group("/notifications")
 			{
@@ -392,6 +584,7 @@ func main() {
 				notifications.GET("/channels/:id", notificationHandler.GetChannel)
 				notifications.PATCH("/channels/:id", notificationHandler.UpdateChannel)
 				notifications.DELETE("/channels/:id", notificationHandler.DeleteChannel)
+				notifications.POST("/channels/:id/test", notificationHandler.TestChannel)
 
 				// User preference routes
 				notifications.GET("/preferences", notificationHandler.ListUserPreferences)
@@ -414,6 +607,7 @@ func main() {
 			incidents := protected.Group("/incidents")
 			{
 				incidents.GET("", incidentHandler.List)
+				incidents.GET("/export.csv", incidentHandler.Export)
 				incidents.POST("", incidentHandler.Create)
 				incidents.GET("/:id", incidentHandler.GetWithDetails)
 				incidents.PATCH("/:id", incidentHandler.Update)
@@ -428,9 +622,12 @@ func main() {
 				// Timeline routes
 				incidents.GET("/:id/timeline", incidentHandler.GetTimeline)
 				incidents.POST("/:id/notes", incidentHandler.AddNote)
+				incidents.PATCH("/:id/notes/:eventId", incidentHandler.UpdateNote)
+				incidents.DELETE("/:id/notes/:eventId", incidentHandler.DeleteNote)
 
 				// Alert linking routes
 				incidents.GET("/:id/alerts", incidentHandler.ListAlerts)
+				incidents.GET("/:id/suggested-alerts", incidentHandler.SuggestAlerts)
 				incidents.POST("/:id/alerts", incidentHandler.LinkAlert)
 				incidents.DELETE("/:id/alerts/:alertId", incidentHandler.UnlinkAlert)
 			}
@@ -450,6 +647,17 @@ func main() {
 			protected.GET("/ws", wsHandler.HandleWebSocket)
 			protected.GET("/ws/stats", wsHandler.GetStats)
 
+			// Server-Sent Events route
+			protected.GET("/events/stream", eventStreamHandler.Stream)
+
+			// Enum metadata routes
+			meta := protected.Group("/meta")
+			{
+				meta.GET("/priorities", metaHandler.Priorities)
+				meta.GET("/severities", metaHandler.Severities)
+				meta.GET("/statuses", metaHandler.Statuses)
+			}
+
 			// Webhook routes
 			webhooks := protected.Group("/webhooks")
 			{
@@ -458,15 +666,21 @@ func main() {
 				webhooks.GET("/endpoints/:id", webhookHandler.GetEndpoint)
 				webhooks.PATCH("/endpoints/:id", webhookHandler.UpdateEndpoint)
 				webhooks.DELETE("/endpoints/:id", webhookHandler.DeleteEndpoint)
+				webhooks.POST("/endpoints/:id/rotate-secret", webhookHandler.RotateEndpointSecret)
 
 				webhooks.GET("/deliveries", webhookHandler.ListDeliveries)
 
 				webhooks.GET("/incoming", webhookHandler.ListIncomingTokens)
 				webhooks.POST("/incoming", webhookHandler.CreateIncomingToken)
 				webhooks.DELETE("/incoming/:id", webhookHandler.DeleteIncomingToken)
+				webhooks.GET("/incoming/:id/requests", webhookHandler.ListIncomingRequests)
+				webhooks.POST("/incoming/:id/rotate", webhookHandler.RotateIncomingToken)
 			}
 		}
 
+		// Acknowledge links in escalation notifications carry their own token
+		v1.POST("/alerts/ack/:token", authRateLimiter.Limit(), alertHandler.AcknowledgeWithToken)
+
 		// Public incoming webhook route (no auth required)
 		v1.POST("/webhook/:token", incomingWebhookHandler.ReceiveWebhook)
 
@@ -518,59 +732,73 @@ func main() {
 		}
 	}()
 
-	// Start background worker for processing escalations
-	escalationWorkerQuit := make(chan bool)
-	go func() {
-		ticker := time.NewTicker(30 * time.Second) // Process escalations every 30 seconds
-		defer ticker.Stop()
-
-		log.Info("Escalation worker started")
-
-		for {
-			select {
-			case <-ticker.C:
-				ctx := context.Background()
-				if err := escalationService.ProcessPendingEscalations(ctx); err != nil {
-					log.Error("Failed to process pending escalations", zap.Error(err))
-				}
-			case <-escalationWorkerQuit:
-				log.Info("Escalation worker stopped")
-				return
+	// Start background workers. They share a group so shutdown can wait for
+	// an in-flight batch to finish instead of abandoning it half-sent, and take
+	// an advisory lock so only one replica processes each queue at a time.
+	workers := worker.NewGroup(log)
+	workerLock := postgres.NewAdvisoryLocker(db.DB)
+	if cfg.Workers.Escalation.Enabled {
+		workers.Go("escalation", cfg.Workers.Escalation.Interval, worker.Exclusive(workerLock, "escalation", func(ctx context.Context) error {
+			return escalationService.ProcessPendingEscalations(ctx, cfg.Workers.Escalation.BatchSize)
+		}))
+	} else {
+		log.Info("Escalation worker disabled")
+	}
+	if cfg.Workers.Webhook.Enabled {
+		workers.Go("webhook_delivery", cfg.Workers.Webhook.Interval, worker.Exclusive(workerLock, "webhook_delivery", func(ctx context.Context) error {
+			if err := outboxRelay.ProcessPending(ctx, cfg.Workers.Webhook.BatchSize); err != nil {
+				return err
 			}
-		}
-	}()
+			return webhookService.ProcessPendingDeliveries(ctx, cfg.Workers.Webhook.BatchSize)
+		}))
+	} else {
+		log.Info("Webhook delivery worker disabled")
+	}
+	if cfg.Workers.Handoff.Enabled {
+		workers.Go("handoff", cfg.Workers.Handoff.Interval, worker.Exclusive(workerLock, "handoff", func(ctx context.Context) error {
+			return handoffNotifier.ProcessHandoffs(ctx, time.Now(), cfg.Workers.Handoff.BatchSize)
+		}))
+	} else {
+		log.Info("Handoff notification worker disabled")
+	}
+	if cfg.Workers.Snooze.Enabled {
+		workers.Go("snooze_expiry", cfg.Workers.Snooze.Interval, worker.Exclusive(workerLock, "snooze_expiry", func(ctx context.Context) error {
+			return alertService.ProcessExpiredSnoozes(ctx, time.Now(), cfg.Workers.Snooze.BatchSize)
+		}))
+	} else {
+		log.Info("Snooze expiry worker disabled")
+	}
+	if cfg.Workers.AutoClose.Enabled {
+		workers.Go("alert_auto_close", cfg.Workers.AutoClose.Interval, worker.Exclusive(workerLock, "alert_auto_close", func(ctx context.Context) error {
+			return alertService.AutoCloseStaleAlerts(ctx, time.Now(), cfg.Workers.AutoClose.BatchSize)
+		}))
+	} else {
+		log.Info("Alert auto-close worker disabled")
+	}
+	if cfg.Workers.Retention.Enabled {
+		workers.Go("alert_retention", cfg.Workers.Retention.Interval, worker.Exclusive(workerLock, "alert_retention", func(ctx context.Context) error {
+			return alertService.ApplyRetention(ctx, time.Now(), cfg.Workers.Retention.BatchSize)
+		}))
+	} else {
+		log.Info("Alert retention worker disabled")
+	}
+	if cfg.Workers.NotificationRetry.Enabled {
+		workers.Go("notification_retry", cfg.Workers.NotificationRetry.Interval, worker.Exclusive(workerLock, "notification_retry", func(ctx context.Context) error {
+			return notificationService.RetryFailedNotifications(ctx, time.Now(), cfg.Workers.NotificationRetry.BatchSize)
+		}))
+	} else {
+		log.Info("Notification retry worker disabled")
+	}
 
-	// Start background worker for processing webhook deliveries
-	webhookWorkerQuit := make(chan bool)
-	go func() {
-		ticker := time.NewTicker(30 * time.Second) // Process webhook deliveries every 30 seconds
-		defer ticker.Stop()
-
-		log.Info("Webhook delivery worker started")
-
-		for {
-			select {
-			case <-ticker.C:
-				ctx := context.Background()
-				if err := webhookService.ProcessPendingDeliveries(ctx); err != nil {
-					log.Error("Failed to process pending webhook deliveries", zap.Error(err))
-				}
-			case <-webhookWorkerQuit:
-				log.Info("Webhook delivery worker stopped")
-				return
-			}
-		}
-	}()
+	if len(queueDepths) > 0 {
+		workers.Go("metrics_queue_depth", cfg.Metrics.RefreshInterval, refreshQueueDepths)
+	}
 
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	// Stop background workers
-	escalationWorkerQuit <- true
-	webhookWorkerQuit <- true
-
 	log.Info("Shutting down server...")
 
 	// Graceful shutdown with 5 second timeout
@@ -581,5 +809,15 @@ func main() {
 		log.Fatal("Server forced to shutdown", zap.Error(err))
 	}
 
+	// Drain background workers within the remaining shutdown budget
+	if err := workers.Shutdown(ctx); err != nil {
+		log.Error("Background workers did not drain before timeout", zap.Error(err))
+	} else {
+		log.Info("Background workers drained")
+	}
+	if err := incidentService.WaitForIntegrations(ctx); err != nil {
+		log.Error("Incident integrations did not finish before timeout", zap.Error(err))
+	}
+
 	log.Info("Server stopped")
 }
@@ -42,7 +42,7 @@ func main() {
 	}
 
 	// Connect to database
-	db, err := postgres.NewDB(cfg.Database.URL)
+	db, err := postgres.NewDB(&cfg.Database)
 	if err != nil {
 		fmt.Printf("❌ Failed to connect to database: %v\n", err)
 		os.Exit(1)
@@ -59,10 +59,10 @@ func main() {
 	scheduleRepo := postgres.NewScheduleRepository(db)
 	escalationRepo := postgres.NewEscalationPolicyRepository(db)
 	alertRepo := postgres.NewAlertRepository(db)
-	incidentRepo := postgres.NewIncidentRepository(db.DB)
+	incidentRepo := postgres.NewIncidentRepository(db)
 	emailVerificationRepo := postgres.NewEmailVerificationRepository(db)
-	notificationRepo := postgres.NewNotificationRepository(db.DB)
-	webhookRepo := postgres.NewWebhookRepository(db.DB)
+	notificationRepo := postgres.NewNotificationRepository(db)
+	webhookRepo := postgres.NewWebhookRepository(db)
 	apiKeyRepo := postgres.NewAPIKeyRepository(db.DB)
 	routingRepo := postgres.NewRoutingRuleRepository(db)
 	dndRepo := postgres.NewDNDSettingsRepository(db)
@@ -484,10 +484,11 @@ func seedEscalationPolicies(ctx context.Context, repo *postgres.EscalationPolicy
 
 	// Target: Platform On-Call Schedule
 	target1 := &domain.EscalationTarget{
-		ID:         uuid.New(),
-		RuleID:     rule1.ID,
-		TargetType: domain.EscalationTargetTypeSchedule,
-		TargetID:   schedules["platform"].ID,
+		ID:           uuid.New(),
+		RuleID:       rule1.ID,
+		TargetType:   domain.EscalationTargetTypeSchedule,
+		TargetID:     schedules["platform"].ID,
+		ScheduleMode: domain.ScheduleTargetModeOnCall,
 	}
 	if err := repo.AddTarget(ctx, target1); err != nil {
 		return nil, fmt.Errorf("add target 1: %w", err)
@@ -566,10 +567,11 @@ func seedEscalationPolicies(ctx context.Context, repo *postgres.EscalationPolicy
 	}
 
 	backendTarget1 := &domain.EscalationTarget{
-		ID:         uuid.New(),
-		RuleID:     backendRule1.ID,
-		TargetType: domain.EscalationTargetTypeSchedule,
-		TargetID:   schedules["backend"].ID,
+		ID:           uuid.New(),
+		RuleID:       backendRule1.ID,
+		TargetType:   domain.EscalationTargetTypeSchedule,
+		TargetID:     schedules["backend"].ID,
+		ScheduleMode: domain.ScheduleTargetModeOnCall,
 	}
 	if err := repo.AddTarget(ctx, backendTarget1); err != nil {
 		return nil, fmt.Errorf("add backend target 1: %w", err)
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"log"
 	"net/http"
 
@@ -8,6 +10,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/dto"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/inbound"
 )
@@ -91,9 +94,48 @@ func (h *AlertHandler) Get(c *gin.Context) {
 	c.JSON(http.StatusOK, alert)
 }
 
+// GetOnCallAtCreation godoc
+// @Summary      Get on-call users when an alert fired
+// @Description  Resolves who was on call, at the moment the alert was created, for every schedule targeted by the alert's escalation policy. Schedules without anyone on call have a null OnCall.
+// @Tags         Alerts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Alert ID" format(uuid)
+// @Success      200 {object} map[string][]domain.ScheduleOnCall
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /alerts/{id}/oncall-at-creation [get]
+func (h *AlertHandler) GetOnCallAtCreation(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert ID"})
+		return
+	}
+
+	oncall, err := h.alertService.GetOnCallAtCreation(c.Request.Context(), id, orgID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "alert not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"oncall": oncall})
+}
+
 // Update godoc
 // @Summary      Update an alert
-// @Description  Update an alert by ID
+// @Description  Update an alert by ID. Only the given fields change; tags are kept unless replaced with tags or changed with add_tags and remove_tags.
 // @Tags         Alerts
 // @Accept       json
 // @Produce      json
@@ -132,6 +174,49 @@ func (h *AlertHandler) Update(c *gin.Context) {
 	c.JSON(http.StatusOK, alert)
 }
 
+// BulkTags godoc
+// @Summary      Bulk add and remove tags
+// @Description  Adds and removes tags across several alerts at once. Tags are normalized, and either every alert is updated or none is.
+// @Tags         Alerts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.BulkTagAlertsRequest true "Alerts and tag changes"
+// @Success      200 {object} map[string]interface{}
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /alerts/tags [post]
+func (h *AlertHandler) BulkTags(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.BulkTagAlertsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	alerts, err := h.alertService.BulkUpdateTags(c.Request.Context(), orgID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAlertNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrTooManyTags), errors.Is(err, domain.ErrTagTooLong):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			log.Printf("ERROR updating alert tags: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"alerts": alerts})
+}
+
 // Delete godoc
 // @Summary      Delete an alert
 // @Description  Delete an alert by ID
@@ -215,6 +300,79 @@ func (h *AlertHandler) List(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// Grouped godoc
+// @Summary      Group open alerts
+// @Description  Clusters open alerts by source, by the value of a key:value tag or by a prefix of the dedup key, largest groups first. Each group has its count, highest priority, first and last alert times and up to three representative alerts.
+// @Tags         Alerts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        by query string true "Grouping key" Enums(source, tag, fingerprint)
+// @Param        tag query string false "Tag key to group by, e.g. service for service:api; required with by=tag"
+// @Param        prefix_length query int false "Dedup key characters to group by; the whole key when omitted"
+// @Param        limit query int false "Maximum number of groups" default(50)
+// @Success      200 {object} dto.GroupAlertsResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /alerts/grouped [get]
+func (h *AlertHandler) Grouped(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.GroupAlertsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.alertService.GroupAlerts(c.Request.Context(), orgID, &req)
+	if err != nil {
+		log.Printf("ERROR grouping alerts: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Export godoc
+// @Summary      Export alerts as CSV
+// @Description  Streams every alert matching the list filters as CSV, newest first
+// @Tags         Alerts
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        status query []string false "Filter by status" collectionFormat(multi)
+// @Param        priority query []string false "Filter by priority" collectionFormat(multi)
+// @Param        assigned_to_user query string false "Filter by assigned user ID" format(uuid)
+// @Param        assigned_to_team query string false "Filter by assigned team ID" format(uuid)
+// @Param        source query string false "Filter by source"
+// @Param        search query string false "Search in message and description"
+// @Success      200 {string} string "CSV file"
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /alerts/export.csv [get]
+func (h *AlertHandler) Export(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.ListAlertsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	streamCSV(c, "alerts", func(w io.Writer) error {
+		return h.alertService.ExportAlertsCSV(c.Request.Context(), orgID, &req, w)
+	})
+}
+
 // Acknowledge godoc
 // @Summary      Acknowledge an alert
 // @Description  Acknowledge an alert by ID
@@ -255,6 +413,79 @@ func (h *AlertHandler) Acknowledge(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "alert acknowledged successfully"})
 }
 
+// AcknowledgeWithToken godoc
+// @Summary      Acknowledge an alert from a notification
+// @Description  Acknowledges the alert an escalation notification was sent for, attributed to the notified user, without signing in. Each link works once and expires.
+// @Tags         Alerts
+// @Produce      json
+// @Param        token path string true "Acknowledge token from the notification"
+// @Success      200 {object} domain.Alert
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Router       /alerts/ack/{token} [post]
+func (h *AlertHandler) AcknowledgeWithToken(c *gin.Context) {
+	alert, err := h.alertService.AcknowledgeWithToken(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAckToken) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, alert)
+}
+
+// Unacknowledge godoc
+// @Summary      Unacknowledge an alert
+// @Description  Return an acknowledged alert to open and restart escalation from the first rule
+// @Tags         Alerts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Alert ID" format(uuid)
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Router       /alerts/{id}/unacknowledge [post]
+func (h *AlertHandler) Unacknowledge(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert ID"})
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.alertService.UnacknowledgeAlert(c.Request.Context(), id, orgID, userID); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAlertNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "alert not found"})
+		case errors.Is(err, domain.ErrAlertNotAcked):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			log.Printf("ERROR unacknowledging alert: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "alert unacknowledged successfully"})
+}
+
 // Close godoc
 // @Summary      Close an alert
 // @Description  Close an alert by ID with a reason
@@ -304,7 +535,7 @@ func (h *AlertHandler) Close(c *gin.Context) {
 
 // Snooze godoc
 // @Summary      Snooze an alert
-// @Description  Snooze an alert until a specified time
+// @Description  Snooze an alert until a specified time with an optional reason. The snoozing user is notified when the alert reopens.
 // @Tags         Alerts
 // @Accept       json
 // @Produce      json
@@ -313,6 +544,7 @@ func (h *AlertHandler) Close(c *gin.Context) {
 // @Param        request body dto.SnoozeAlertRequest true "Snooze alert request"
 // @Success      200 {object} map[string]string
 // @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
 // @Router       /alerts/{id}/snooze [post]
 func (h *AlertHandler) Snooze(c *gin.Context) {
 	orgID, ok := middleware.GetOrganizationID(c)
@@ -328,13 +560,19 @@ func (h *AlertHandler) Snooze(c *gin.Context) {
 		return
 	}
 
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
 	var req dto.SnoozeAlertRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if err := h.alertService.SnoozeAlert(c.Request.Context(), id, orgID, req.Until); err != nil {
+	if err := h.alertService.SnoozeAlert(c.Request.Context(), id, orgID, userID, req.Until, req.Reason); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -368,16 +606,113 @@ func (h *AlertHandler) Assign(c *gin.Context) {
 		return
 	}
 
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
 	var req dto.AssignAlertRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	if err := h.alertService.AssignAlert(c.Request.Context(), id, orgID, req.UserID, req.TeamID); err != nil {
+	if err := h.alertService.AssignAlert(c.Request.Context(), id, orgID, userID, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "alert assigned successfully"})
 }
+
+// AddNote godoc
+// @Summary      Add a note to an alert
+// @Description  Adds an investigation note to an alert, attributed to the current user
+// @Tags         Alerts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Alert ID" format(uuid)
+// @Param        request body dto.AddNoteRequest true "Add note request"
+// @Success      201 {object} domain.AlertNote
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /alerts/{id}/notes [post]
+func (h *AlertHandler) AddNote(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert ID"})
+		return
+	}
+
+	var req dto.AddNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	note, err := h.alertService.AddNote(c.Request.Context(), id, orgID, userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAlertNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrEmptyAlertNote):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, note)
+}
+
+// ListNotes godoc
+// @Summary      List alert notes
+// @Description  Lists the notes on an alert oldest first, with author details
+// @Tags         Alerts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Alert ID" format(uuid)
+// @Success      200 {array} domain.AlertNoteWithUser
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /alerts/{id}/notes [get]
+func (h *AlertHandler) ListNotes(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert ID"})
+		return
+	}
+
+	notes, err := h.alertService.ListNotes(c.Request.Context(), id, orgID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAlertNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notes)
+}
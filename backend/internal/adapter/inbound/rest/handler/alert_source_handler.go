@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/internal/core/port/inbound"
+)
+
+type AlertSourceHandler struct {
+	sourceService inbound.AlertSourceService
+}
+
+func NewAlertSourceHandler(sourceService inbound.AlertSourceService) *AlertSourceHandler {
+	return &AlertSourceHandler{
+		sourceService: sourceService,
+	}
+}
+
+// List godoc
+// @Summary      List alert sources
+// @Description  Lists the organization's registered alert sources ordered by name, each with the number of alerts filed under its name or any of its aliases
+// @Tags         Alert Sources
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  map[string][]domain.RegisteredSourceWithCount  "List of alert sources"
+// @Failure      401  {object}  map[string]string                              "Unauthorized"
+// @Failure      500  {object}  map[string]string                              "Internal server error"
+// @Router       /sources [get]
+func (h *AlertSourceHandler) List(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	sources, err := h.sourceService.ListSources(c.Request.Context(), orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sources": sources})
+}
+
+// Create godoc
+// @Summary      Register alert source
+// @Description  Registers an alert source. Alerts created afterwards with its name or one of its aliases, ignoring case, are filed under its name.
+// @Tags         Alert Sources
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      dto.CreateAlertSourceRequest  true  "Alert source"
+// @Success      201      {object}  domain.RegisteredSource       "Registered alert source"
+// @Failure      400      {object}  map[string]string             "Bad request"
+// @Failure      401      {object}  map[string]string             "Unauthorized"
+// @Failure      409      {object}  map[string]string             "Name or alias already registered"
+// @Router       /sources [post]
+func (h *AlertSourceHandler) Create(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.CreateAlertSourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	source, err := h.sourceService.CreateSource(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, source)
+}
+
+// Update godoc
+// @Summary      Update alert source
+// @Description  Renames an alert source or replaces its aliases. Existing alerts keep the source they were filed under.
+// @Tags         Alert Sources
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                        true  "Alert source ID"  format(uuid)
+// @Param        request  body      dto.UpdateAlertSourceRequest  true  "Alert source update"
+// @Success      200      {object}  domain.RegisteredSource       "Updated alert source"
+// @Failure      400      {object}  map[string]string             "Invalid request or source ID"
+// @Failure      404      {object}  map[string]string             "Source not found"
+// @Failure      409      {object}  map[string]string             "Name or alias already registered"
+// @Router       /sources/{id} [patch]
+func (h *AlertSourceHandler) Update(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert source id"})
+		return
+	}
+
+	var req dto.UpdateAlertSourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	source, err := h.sourceService.UpdateSource(c.Request.Context(), id, orgID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, source)
+}
+
+// Delete godoc
+// @Summary      Delete alert source
+// @Description  Removes an alert source from the registry. Alerts filed under it keep their source.
+// @Tags         Alert Sources
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Alert source ID"  format(uuid)
+// @Success      200  {object}  map[string]string  "Source deleted"
+// @Failure      400  {object}  map[string]string  "Invalid source ID"
+// @Failure      404  {object}  map[string]string  "Source not found"
+// @Router       /sources/{id} [delete]
+func (h *AlertSourceHandler) Delete(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert source id"})
+		return
+	}
+
+	if err := h.sourceService.DeleteSource(c.Request.Context(), id, orgID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "alert source deleted successfully"})
+}
+
+func (h *AlertSourceHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAlertSourceNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidAlertSource):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrAlertSourceConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
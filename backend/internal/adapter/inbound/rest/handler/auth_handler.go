@@ -1,14 +1,19 @@
 package handler
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 
 	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/dto"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/inbound"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
@@ -55,7 +60,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.authService.Register(c.Request.Context(), &req)
+	resp, err := h.authService.Register(c.Request.Context(), &req, c.ClientIP(), c.Request.UserAgent())
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -74,6 +79,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Success      200 {object} dto.AuthResponse
 // @Failure      400 {object} map[string]string
 // @Failure      401 {object} map[string]string
+// @Failure      429 {object} map[string]string
 // @Router       /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
@@ -82,8 +88,19 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.authService.Login(c.Request.Context(), &req)
+	resp, err := h.authService.Login(c.Request.Context(), &req, c.ClientIP(), c.Request.UserAgent())
 	if err != nil {
+		var locked *domain.LoginLockedError
+		if errors.As(err, &locked) {
+			retryAfter := int(math.Ceil(time.Until(locked.Until).Seconds()))
+			c.Header("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": domain.ErrLoginLocked.Error()})
+			return
+		}
+		if errors.Is(err, domain.ErrNotOrganizationMember) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
@@ -112,7 +129,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.authService.RefreshToken(c.Request.Context(), req.RefreshToken)
+	resp, err := h.authService.RefreshToken(c.Request.Context(), req.RefreshToken, c.ClientIP(), c.Request.UserAgent())
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
@@ -148,6 +165,164 @@ func (h *AuthHandler) GetMe(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
+// SwitchOrganization godoc
+// @Summary      Switch organization
+// @Description  Issues tokens scoped to another organization the user is a member of. The session is kept; its previous refresh token stops working.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.SwitchOrganizationRequest true "Organization to switch to"
+// @Success      200 {object} dto.AuthResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Router       /auth/switch-organization [post]
+func (h *AuthHandler) SwitchOrganization(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	sessionID, _ := middleware.GetSessionID(c)
+
+	var req dto.SwitchOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authService.SwitchOrganization(c.Request.Context(), userID, sessionID, req.OrganizationID, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if errors.Is(err, domain.ErrNotOrganizationMember) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListOrganizations godoc
+// @Summary      List my organizations
+// @Description  Lists the organizations the current user is a member of with their role in each. The organization the request is scoped to is marked as current.
+// @Tags         Auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} dto.OrganizationMembershipResponse
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /me/organizations [get]
+func (h *AuthHandler) ListOrganizations(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	orgID, _ := middleware.GetOrganizationID(c)
+
+	organizations, err := h.authService.ListOrganizations(c.Request.Context(), userID, orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, organizations)
+}
+
+// ListSessions godoc
+// @Summary      List active sessions
+// @Description  Lists the devices signed in to the current user's account, most recently used first. The session of the calling token is marked as current.
+// @Tags         Auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {array} dto.SessionResponse
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /auth/sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	sessionID, _ := middleware.GetSessionID(c)
+
+	sessions, err := h.authService.ListSessions(c.Request.Context(), userID, sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession godoc
+// @Summary      Revoke a session
+// @Description  Signs one device out. Its refresh token stops working; access tokens already issued to it stay valid until they expire.
+// @Tags         Auth
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Session ID" format(uuid)
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session ID"})
+		return
+	}
+
+	if err := h.authService.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "session revoked successfully"})
+}
+
+// RevokeAllSessions godoc
+// @Summary      Log out everywhere
+// @Description  Revokes every session of the current user, including the calling one. Access tokens already issued stay valid until they expire.
+// @Tags         Auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} dto.RevokeSessionsResponse
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /auth/sessions [delete]
+func (h *AuthHandler) RevokeAllSessions(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	revoked, err := h.authService.RevokeAllSessions(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.RevokeSessionsResponse{Revoked: revoked})
+}
+
 // Logout godoc
 // @Summary      Logout user
 // @Description  Logout the current user (client should discard tokens)
@@ -185,6 +360,7 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 // @Param        request body VerifyEmailRequest true "Verify email request"
 // @Success      200 {object} map[string]string
 // @Failure      400 {object} map[string]string
+// @Failure      429 {object} map[string]string
 // @Router       /auth/verify-email [post]
 func (h *AuthHandler) VerifyEmail(c *gin.Context) {
 	if h.emailVerificationService == nil {
@@ -199,6 +375,10 @@ func (h *AuthHandler) VerifyEmail(c *gin.Context) {
 	}
 
 	if err := h.emailVerificationService.VerifyOTP(c.Request.Context(), req.Email, req.OTP); err != nil {
+		if errors.Is(err, domain.ErrOTPLocked) {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -215,6 +395,8 @@ func (h *AuthHandler) VerifyEmail(c *gin.Context) {
 // @Param        request body ResendOTPRequest true "Resend OTP request"
 // @Success      200 {object} map[string]string
 // @Failure      400 {object} map[string]string
+// @Failure      429 {object} map[string]string
+// @Failure      502 {object} map[string]string
 // @Router       /auth/resend-otp [post]
 func (h *AuthHandler) ResendOTP(c *gin.Context) {
 	if h.emailVerificationService == nil {
@@ -229,7 +411,15 @@ func (h *AuthHandler) ResendOTP(c *gin.Context) {
 	}
 
 	if err := h.emailVerificationService.ResendOTP(c.Request.Context(), req.Email); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		switch {
+		case errors.Is(err, domain.ErrOTPResendCooldown):
+			c.Header("Retry-After", strconv.Itoa(int(domain.OTPResendCooldown.Seconds())))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrOTPDeliveryFailed):
+			c.JSON(http.StatusBadGateway, gin.H{"error": "failed to send verification email, please try again shortly"})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
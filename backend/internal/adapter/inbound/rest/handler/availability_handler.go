@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/internal/core/port/inbound"
+)
+
+type AvailabilityHandler struct {
+	availabilityService inbound.AvailabilityService
+}
+
+func NewAvailabilityHandler(availabilityService inbound.AvailabilityService) *AvailabilityHandler {
+	return &AvailabilityHandler{availabilityService: availabilityService}
+}
+
+// ListUnavailability godoc
+// @Summary List user's unavailability
+// @Description List the current user's current and upcoming unavailability periods
+// @Tags availability
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/me/unavailability [get]
+// @Security BearerAuth
+func (h *AvailabilityHandler) ListUnavailability(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	periods, err := h.availabilityService.ListUnavailability(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"unavailability": periods})
+}
+
+// AddUnavailability godoc
+// @Summary Mark the user unavailable
+// @Description Add a period (e.g., vacation) during which schedules skip the current user and page the next participant instead
+// @Tags availability
+// @Accept json
+// @Produce json
+// @Param request body dto.AddUnavailabilityRequest true "Unavailability period"
+// @Success 201 {object} domain.UserUnavailability
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/me/unavailability [post]
+// @Security BearerAuth
+func (h *AvailabilityHandler) AddUnavailability(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.AddUnavailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	period, err := h.availabilityService.AddUnavailability(c.Request.Context(), userID.(uuid.UUID), &req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidUnavailability) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, period)
+}
+
+// RemoveUnavailability godoc
+// @Summary Remove an unavailability period
+// @Description Delete one of the current user's unavailability periods
+// @Tags availability
+// @Accept json
+// @Produce json
+// @Param id path string true "Unavailability ID" format(uuid)
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/me/unavailability/{id} [delete]
+// @Security BearerAuth
+func (h *AvailabilityHandler) RemoveUnavailability(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid unavailability ID"})
+		return
+	}
+
+	if err := h.availabilityService.RemoveUnavailability(c.Request.Context(), userID.(uuid.UUID), id); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "unavailability not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
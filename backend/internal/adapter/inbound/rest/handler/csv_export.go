@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// streamCSV sends the output of export as a CSV attachment named after name
+// and the current time
+func streamCSV(c *gin.Context, name string, export func(w io.Writer) error) {
+	filename := fmt.Sprintf("pulsar-%s-%s.csv", name, time.Now().UTC().Format("20060102-150405"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	// Exports aren't capped in size, so they may outlast the server's write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	if err := export(c.Writer); err != nil {
+		// Once streaming has started the status is already sent and the client
+		// is left with a truncated file.
+		if c.Writer.Written() {
+			_ = c.Error(err)
+			return
+		}
+		c.Header("Content-Type", "")
+		c.Header("Content-Disposition", "")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
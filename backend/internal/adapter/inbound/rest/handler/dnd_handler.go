@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -75,6 +77,10 @@ func (h *DNDHandler) UpdateDNDSettings(c *gin.Context) {
 
 	settings, err := h.dndService.UpdateSettings(c.Request.Context(), userID.(uuid.UUID), &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidDNDSchedule) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -108,14 +114,12 @@ func (h *DNDHandler) AddDNDOverride(c *gin.Context) {
 		return
 	}
 
-	// Validate that end is after start
-	if !req.End.After(req.Start) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "end time must be after start time"})
-		return
-	}
-
 	settings, err := h.dndService.AddOverride(c.Request.Context(), userID.(uuid.UUID), &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidDNDOverride) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -133,6 +137,7 @@ func (h *DNDHandler) AddDNDOverride(c *gin.Context) {
 // @Success 200 {object} domain.UserDNDSettings
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /users/me/dnd/overrides/{index} [delete]
 // @Security BearerAuth
@@ -152,6 +157,10 @@ func (h *DNDHandler) RemoveDNDOverride(c *gin.Context) {
 
 	settings, err := h.dndService.RemoveOverride(c.Request.Context(), userID.(uuid.UUID), index)
 	if err != nil {
+		if errors.Is(err, domain.ErrDNDOverrideNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -211,14 +220,15 @@ func (h *DNDHandler) CheckDNDStatus(c *gin.Context) {
 		priority = domain.AlertPriority(p)
 	}
 
-	inDND, err := h.dndService.IsInDNDMode(c.Request.Context(), userID.(uuid.UUID), priority)
+	decision, err := h.dndService.IsInDND(c.Request.Context(), userID.(uuid.UUID), time.Now(), priority)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"in_dnd_mode": inDND,
+		"in_dnd_mode": decision.InDND,
+		"reason":      decision.Reason,
 		"priority":    priority,
 	})
 }
@@ -1,13 +1,17 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
 	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/dto"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/inbound"
 )
@@ -179,6 +183,145 @@ func (h *EscalationHandler) Delete(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "policy deleted"})
 }
 
+// ApplyConfig godoc
+// @Summary      Apply escalation policy config
+// @Description  Replaces a policy's settings, including its conditions and business hours, and its rules and targets with the given desired state in a single transaction. Omitted conditions or business hours are removed. Rules are matched by position; rules at unlisted positions are deleted.
+// @Tags         Escalation Policies
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                             true  "Escalation policy ID"  format(uuid)
+// @Param        request  body      dto.EscalationPolicyConfigRequest  true  "Desired policy state"
+// @Success      200      {object}  domain.EscalationPolicyWithRules   "Reconciled policy with rules and targets"
+// @Failure      400      {object}  map[string]string                  "Bad request"
+// @Failure      401      {object}  map[string]string                  "Unauthorized"
+// @Router       /escalation-policies/{id}/config [put]
+func (h *EscalationHandler) ApplyConfig(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid policy id"})
+		return
+	}
+
+	var req dto.EscalationPolicyConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.escalationService.ApplyPolicyConfig(c.Request.Context(), orgID, id, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// Clone godoc
+// @Summary      Clone escalation policy
+// @Description  Copies a policy with its rules and targets into a new policy. Targets keep referencing the same users, teams and schedules.
+// @Tags         Escalation Policies
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                            true   "Escalation policy ID"  format(uuid)
+// @Param        request  body      dto.CloneEscalationPolicyRequest  false  "Name of the copy"
+// @Success      201      {object}  domain.EscalationPolicyWithRules  "Cloned policy with rules and targets"
+// @Failure      400      {object}  map[string]string                 "Bad request"
+// @Failure      401      {object}  map[string]string                 "Unauthorized"
+// @Failure      404      {object}  map[string]string                 "Policy not found"
+// @Router       /escalation-policies/{id}/clone [post]
+func (h *EscalationHandler) Clone(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid policy id"})
+		return
+	}
+
+	// The body is optional
+	var req dto.CloneEscalationPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy, err := h.escalationService.ClonePolicy(c.Request.Context(), orgID, id, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEscalationPolicyNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidEscalationPolicy):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, policy)
+}
+
+// Preview godoc
+// @Summary      Preview escalation policy
+// @Description  Expands each rule of the policy to the users its targets would page if an alert started escalating at the given time (now by default), resolving schedules to whoever is on call, with the channels each user would be paged through and when. Rules limited to business or after hours are skipped when they don't apply at that time. The first rule is only paged when the policy repeats. Do Not Disturb is not applied.
+// @Tags         Escalation Policies
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string                       true  "Escalation policy ID"  format(uuid)
+// @Param        at   query     string                       false "Start of escalation (RFC3339), defaults to now"
+// @Success      200  {object}  dto.EscalationPolicyPreview  "Expanded escalation chain"
+// @Failure      400  {object}  map[string]string            "Invalid policy ID or time"
+// @Failure      401  {object}  map[string]string            "Unauthorized"
+// @Failure      404  {object}  map[string]string            "Policy not found"
+// @Router       /escalation-policies/{id}/preview [get]
+func (h *EscalationHandler) Preview(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid policy id"})
+		return
+	}
+
+	at := time.Now()
+	if atStr := c.Query("at"); atStr != "" {
+		at, err = time.Parse(time.RFC3339, atStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid time format"})
+			return
+		}
+	}
+
+	preview, err := h.escalationService.PreviewPolicy(c.Request.Context(), orgID, id, at)
+	if err != nil {
+		if errors.Is(err, domain.ErrEscalationPolicyNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
 // Rule handlers
 
 // ListRules godoc
@@ -211,7 +354,7 @@ func (h *EscalationHandler) ListRules(c *gin.Context) {
 
 // CreateRule godoc
 // @Summary      Create escalation rule
-// @Description  Creates a new escalation rule for a specific policy
+// @Description  Creates a new escalation rule for a specific policy. A rule with min_priority or only_if_unassigned is skipped when escalation reaches it and the alert is below that priority or has been assigned.
 // @Tags         Escalation Policies
 // @Accept       json
 // @Produce      json
@@ -368,7 +511,7 @@ func (h *EscalationHandler) ListTargets(c *gin.Context) {
 
 // AddTarget godoc
 // @Summary      Add escalation target
-// @Description  Adds a new target (user, team, or schedule) to an escalation rule
+// @Description  Adds a new target (user, team, or schedule) to an escalation rule. The target must exist in the organization.
 // @Tags         Escalation Policies
 // @Accept       json
 // @Produce      json
@@ -378,8 +521,15 @@ func (h *EscalationHandler) ListTargets(c *gin.Context) {
 // @Param        request  body      dto.AddEscalationTargetRequest  true  "Escalation target request"
 // @Success      201      {object}  domain.EscalationTarget             "Created escalation target"
 // @Failure      400      {object}  map[string]string                   "Invalid request or rule ID"
+// @Failure      404      {object}  map[string]string                   "Rule or target not found in the organization"
 // @Router       /escalation-policies/{id}/rules/{ruleId}/targets [post]
 func (h *EscalationHandler) AddTarget(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
 	ruleID, err := uuid.Parse(c.Param("ruleId"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid rule id"})
@@ -392,8 +542,12 @@ func (h *EscalationHandler) AddTarget(c *gin.Context) {
 		return
 	}
 
-	target, err := h.escalationService.AddTarget(c.Request.Context(), ruleID, &req)
+	target, err := h.escalationService.AddTarget(c.Request.Context(), orgID, ruleID, &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrEscalationPolicyNotFound) || errors.Is(err, domain.ErrEscalationTargetNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/port/inbound"
+)
+
+// Send a heartbeat comment with this period so proxies keep the stream open
+const streamHeartbeatPeriod = 15 * time.Second
+
+// EventStreamHandler serves the real-time events of the WebSocket hub as
+// Server-Sent Events, for clients and proxies that can't use WebSockets
+type EventStreamHandler struct {
+	wsService inbound.WebSocketService
+	logger    *zap.Logger
+}
+
+func NewEventStreamHandler(wsService inbound.WebSocketService, logger *zap.Logger) *EventStreamHandler {
+	return &EventStreamHandler{
+		wsService: wsService,
+		logger:    logger,
+	}
+}
+
+// Stream godoc
+// @Summary      Stream events
+// @Description  Streams the organization's alert and incident events as Server-Sent Events. Reconnecting clients can pass the Last-Event-ID header, last_event_id or since to have missed events replayed first.
+// @Tags         Events
+// @Produce      text/event-stream
+// @Security     BearerAuth
+// @Param        last_event_id  query     string  false  "ID of the last event received"
+// @Param        since          query     string  false  "Replay events after this time (RFC3339)"
+// @Success      200            {string}  string  "Event stream"
+// @Failure      400            {object}  map[string]string
+// @Failure      401            {object}  map[string]string
+// @Router       /events/stream [get]
+func (h *EventStreamHandler) Stream(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	lastEventID, since, err := replayCursor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid time format"})
+		return
+	}
+	// EventSource sends the id of the last event it saw when it reconnects
+	if header := c.GetHeader("Last-Event-ID"); header != "" && lastEventID == "" {
+		lastEventID = header
+	}
+
+	// The stream outlives the server's write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.logger.Debug("Failed to clear write deadline for event stream", zap.Error(err))
+	}
+
+	// Subscribe through the hub exactly like a WebSocket connection
+	client := &domain.WSClient{
+		ID:             uuid.New(),
+		UserID:         userID,
+		OrganizationID: orgID,
+		Send:           make(chan *domain.WSMessage, 256),
+		LastEventID:    lastEventID,
+		Since:          since,
+	}
+	h.wsService.GetHub().Register <- client
+	defer func() {
+		h.wsService.GetHub().Unregister <- client
+	}()
+
+	// Headers go out only once the client is subscribed, so nothing broadcast
+	// after the response starts is missed
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatPeriod)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case message, ok := <-client.Send:
+			if !ok {
+				// Hub dropped the client
+				return
+			}
+			if err := writeStreamEvent(c.Writer, message); err != nil {
+				h.logger.Error("Failed to write event stream message", zap.Error(err))
+				return
+			}
+			c.Writer.Flush()
+
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// writeStreamEvent writes a message in text/event-stream framing
+func writeStreamEvent(w http.ResponseWriter, message *domain.WSMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", message.ID, message.Type, data)
+	return err
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/nmn3m/pulsar/backend/internal/pkg/logger"
+)
+
+// DBPinger is implemented by *sql.DB and *sqlx.DB.
+type DBPinger interface {
+	PingContext(ctx context.Context) error
+}
+
+type HealthHandler struct {
+	db          DBPinger
+	version     string
+	pingTimeout time.Duration
+	logger      *zap.Logger
+}
+
+func NewHealthHandler(db DBPinger, version string, logger *zap.Logger) *HealthHandler {
+	return &HealthHandler{
+		db:          db,
+		version:     version,
+		pingTimeout: 2 * time.Second,
+		logger:      logger,
+	}
+}
+
+// Live godoc
+// @Summary      Liveness check
+// @Description  Reports that the process is up. Does not check dependencies.
+// @Tags         Health
+// @Produce      json
+// @Success      200 {object} map[string]interface{}
+// @Router       /health [get]
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "healthy",
+		"version": h.version,
+		"time":    time.Now().UTC(),
+	})
+}
+
+// Ready godoc
+// @Summary      Readiness check
+// @Description  Reports whether the instance can serve traffic. Returns 503 if the database is unreachable.
+// @Tags         Health
+// @Produce      json
+// @Success      200 {object} map[string]interface{}
+// @Failure      503 {object} map[string]interface{}
+// @Router       /health/ready [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), h.pingTimeout)
+	defer cancel()
+
+	status := http.StatusOK
+	checks := gin.H{"database": "ok"}
+
+	// The endpoint is public, so the error is logged rather than returned
+	if err := h.db.PingContext(ctx); err != nil {
+		logger.WithContext(c.Request.Context(), h.logger).Warn("Readiness check failed to ping database", zap.Error(err))
+		status = http.StatusServiceUnavailable
+		checks["database"] = "unavailable"
+	}
+
+	body := gin.H{
+		"status":  "ready",
+		"version": h.version,
+		"checks":  checks,
+		"time":    time.Now().UTC(),
+	}
+	if status != http.StatusOK {
+		body["status"] = "unavailable"
+	}
+
+	c.JSON(status, body)
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"log"
 	"net/http"
 
@@ -25,7 +27,7 @@ func NewIncidentHandler(incidentService inbound.IncidentService) *IncidentHandle
 
 // Create godoc
 // @Summary      Create a new incident
-// @Description  Creates a new incident with the provided details. The incident is created in the investigating status.
+// @Description  Creates a new incident with the provided details. The incident is created in the investigating status. An incident declared from an alert (alert_id) is linked to it and, when the organization enables incident_auto_assign_on_call, gets the on-call user of the alert's escalation schedule as a responder.
 // @Tags         Incidents
 // @Accept       json
 // @Produce      json
@@ -33,6 +35,7 @@ func NewIncidentHandler(incidentService inbound.IncidentService) *IncidentHandle
 // @Param        request body dto.CreateIncidentRequest true "Incident creation request"
 // @Success      201 {object} domain.Incident
 // @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /incidents [post]
 func (h *IncidentHandler) Create(c *gin.Context) {
@@ -46,6 +49,10 @@ func (h *IncidentHandler) Create(c *gin.Context) {
 	userID, _ := middleware.GetUserID(c)
 
 	incident, err := h.incidentService.CreateIncident(c.Request.Context(), orgID, userID, &req)
+	if errors.Is(err, domain.ErrAlertNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "alert not found"})
+		return
+	}
 	if err != nil {
 		log.Printf("ERROR creating incident: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
@@ -86,6 +93,7 @@ func (h *IncidentHandler) Get(c *gin.Context) {
 // @Param        id path string true "Incident ID" format(uuid)
 // @Success      200 {object} domain.IncidentWithDetails
 // @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /incidents/{id} [get]
 func (h *IncidentHandler) GetWithDetails(c *gin.Context) {
@@ -100,6 +108,10 @@ func (h *IncidentHandler) GetWithDetails(c *gin.Context) {
 
 	incident, err := h.incidentService.GetIncidentWithDetails(c.Request.Context(), id, orgID)
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "incident not found"})
+			return
+		}
 		log.Printf("ERROR getting incident with details: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
@@ -222,9 +234,37 @@ func (h *IncidentHandler) List(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// Export godoc
+// @Summary      Export incidents as CSV
+// @Description  Streams every incident matching the list filters as CSV, newest first
+// @Tags         Incidents
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        status query []string false "Filter by status (investigating, identified, monitoring, resolved)"
+// @Param        severity query []string false "Filter by severity (critical, high, medium, low)"
+// @Param        assigned_to_team_id query string false "Filter by assigned team ID" format(uuid)
+// @Param        search query string false "Search term for title and description"
+// @Success      200 {string} string "CSV file"
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /incidents/export.csv [get]
+func (h *IncidentHandler) Export(c *gin.Context) {
+	var req dto.ListIncidentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgID, _ := middleware.GetOrganizationID(c)
+
+	streamCSV(c, "incidents", func(w io.Writer) error {
+		return h.incidentService.ExportIncidentsCSV(c.Request.Context(), orgID, &req, w)
+	})
+}
+
 // AddResponder godoc
 // @Summary      Add a responder to an incident
-// @Description  Assigns a user as a responder to an incident with a specific role
+// @Description  Assigns a user as a responder to an incident with a specific role. Assigning an incident commander demotes the current one.
 // @Tags         Incidents
 // @Accept       json
 // @Produce      json
@@ -233,6 +273,7 @@ func (h *IncidentHandler) List(c *gin.Context) {
 // @Param        request body dto.AddResponderRequest true "Add responder request"
 // @Success      201 {object} domain.IncidentResponder
 // @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /incidents/{id}/responders [post]
 func (h *IncidentHandler) AddResponder(c *gin.Context) {
@@ -249,10 +290,15 @@ func (h *IncidentHandler) AddResponder(c *gin.Context) {
 		return
 	}
 
+	orgID, _ := middleware.GetOrganizationID(c)
 	userID, _ := middleware.GetUserID(c)
 
-	responder, err := h.incidentService.AddResponder(c.Request.Context(), id, userID, &req)
+	responder, err := h.incidentService.AddResponder(c.Request.Context(), id, orgID, userID, &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "incident not found"})
+			return
+		}
 		log.Printf("ERROR adding responder: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
@@ -304,7 +350,7 @@ func (h *IncidentHandler) RemoveResponder(c *gin.Context) {
 
 // UpdateResponderRole godoc
 // @Summary      Update a responder's role
-// @Description  Updates the role of a responder assigned to an incident
+// @Description  Updates the role of a responder assigned to an incident. Making a responder incident commander demotes the current commander.
 // @Tags         Incidents
 // @Accept       json
 // @Produce      json
@@ -338,8 +384,9 @@ func (h *IncidentHandler) UpdateResponderRole(c *gin.Context) {
 	}
 
 	orgID, _ := middleware.GetOrganizationID(c)
+	userID, _ := middleware.GetUserID(c)
 
-	if err := h.incidentService.UpdateResponderRole(c.Request.Context(), id, orgID, responderID, &req); err != nil {
+	if err := h.incidentService.UpdateResponderRole(c.Request.Context(), id, orgID, responderID, userID, &req); err != nil {
 		log.Printf("ERROR updating responder role: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
@@ -358,6 +405,7 @@ func (h *IncidentHandler) UpdateResponderRole(c *gin.Context) {
 // @Param        id path string true "Incident ID" format(uuid)
 // @Success      200 {array} domain.ResponderWithUser
 // @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /incidents/{id}/responders [get]
 func (h *IncidentHandler) ListResponders(c *gin.Context) {
@@ -372,6 +420,10 @@ func (h *IncidentHandler) ListResponders(c *gin.Context) {
 
 	responders, err := h.incidentService.ListResponders(c.Request.Context(), id, orgID)
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "incident not found"})
+			return
+		}
 		log.Printf("ERROR listing responders: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
@@ -396,6 +448,7 @@ func (h *IncidentHandler) ListResponders(c *gin.Context) {
 // @Param        request body dto.AddNoteRequest true "Add note request"
 // @Success      201 {object} domain.IncidentTimelineEvent
 // @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /incidents/{id}/notes [post]
 func (h *IncidentHandler) AddNote(c *gin.Context) {
@@ -417,6 +470,10 @@ func (h *IncidentHandler) AddNote(c *gin.Context) {
 
 	event, err := h.incidentService.AddNote(c.Request.Context(), id, orgID, userID, &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "incident not found"})
+			return
+		}
 		log.Printf("ERROR adding note: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
@@ -425,6 +482,112 @@ func (h *IncidentHandler) AddNote(c *gin.Context) {
 	c.JSON(http.StatusCreated, event)
 }
 
+// UpdateNote godoc
+// @Summary      Edit an incident note
+// @Description  Changes the text of a note on the incident timeline and marks it as edited. Only the note's author or an admin can edit it; system events can't be edited.
+// @Tags         Incidents
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Incident ID" format(uuid)
+// @Param        eventId path string true "Timeline event ID" format(uuid)
+// @Param        request body dto.UpdateNoteRequest true "Update note request"
+// @Success      200 {object} domain.IncidentTimelineEvent
+// @Failure      400 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /incidents/{id}/notes/{eventId} [patch]
+func (h *IncidentHandler) UpdateNote(c *gin.Context) {
+	id, eventID, ok := parseNoteParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orgID, _ := middleware.GetOrganizationID(c)
+	userID, _ := middleware.GetUserID(c)
+
+	event, err := h.incidentService.UpdateNote(c.Request.Context(), id, orgID, eventID, userID, isOrgAdmin(c), &req)
+	if err != nil {
+		respondNoteError(c, "updating", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// DeleteNote godoc
+// @Summary      Delete an incident note
+// @Description  Removes a note from the incident timeline. Only the note's author or an admin can delete it; system events can't be deleted.
+// @Tags         Incidents
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Incident ID" format(uuid)
+// @Param        eventId path string true "Timeline event ID" format(uuid)
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /incidents/{id}/notes/{eventId} [delete]
+func (h *IncidentHandler) DeleteNote(c *gin.Context) {
+	id, eventID, ok := parseNoteParams(c)
+	if !ok {
+		return
+	}
+
+	orgID, _ := middleware.GetOrganizationID(c)
+	userID, _ := middleware.GetUserID(c)
+
+	if err := h.incidentService.DeleteNote(c.Request.Context(), id, orgID, eventID, userID, isOrgAdmin(c)); err != nil {
+		respondNoteError(c, "deleting", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "note deleted successfully"})
+}
+
+// parseNoteParams parses the incident and timeline event IDs of a note route
+func parseNoteParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid incident ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return id, eventID, true
+}
+
+func respondNoteError(c *gin.Context, action string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "note not found"})
+	case errors.Is(err, domain.ErrTimelineEventNotNote), errors.Is(err, domain.ErrNotNoteAuthor):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		log.Printf("ERROR %s note: %v", action, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// isOrgAdmin reports whether the caller is an owner or admin of their organization
+func isOrgAdmin(c *gin.Context) bool {
+	role, _ := middleware.GetRole(c)
+	return role == "owner" || role == "admin"
+}
+
 // GetTimeline godoc
 // @Summary      Get incident timeline
 // @Description  Retrieves all timeline events for an incident including status changes, notes, and responder actions
@@ -433,8 +596,10 @@ func (h *IncidentHandler) AddNote(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path string true "Incident ID" format(uuid)
+// @Param        event_type query []string false "Only return events of these types" collectionFormat(multi)
 // @Success      200 {array} domain.TimelineEventWithUser
 // @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /incidents/{id}/timeline [get]
 func (h *IncidentHandler) GetTimeline(c *gin.Context) {
@@ -445,10 +610,23 @@ func (h *IncidentHandler) GetTimeline(c *gin.Context) {
 		return
 	}
 
+	var eventTypes []domain.TimelineEventType
+	for _, eventType := range c.QueryArray("event_type") {
+		eventTypes = append(eventTypes, domain.TimelineEventType(eventType))
+	}
+
 	orgID, _ := middleware.GetOrganizationID(c)
 
-	timeline, err := h.incidentService.GetTimeline(c.Request.Context(), id, orgID)
+	timeline, err := h.incidentService.GetTimeline(c.Request.Context(), id, orgID, eventTypes)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTimelineEventType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "incident not found"})
+			return
+		}
 		log.Printf("ERROR getting timeline: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
@@ -473,6 +651,7 @@ func (h *IncidentHandler) GetTimeline(c *gin.Context) {
 // @Param        request body dto.LinkAlertRequest true "Link alert request"
 // @Success      201 {object} domain.IncidentAlert
 // @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /incidents/{id}/alerts [post]
 func (h *IncidentHandler) LinkAlert(c *gin.Context) {
@@ -494,6 +673,14 @@ func (h *IncidentHandler) LinkAlert(c *gin.Context) {
 
 	link, err := h.incidentService.LinkAlert(c.Request.Context(), id, orgID, userID, &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "incident not found"})
+			return
+		}
+		if errors.Is(err, domain.ErrAlertNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "alert not found"})
+			return
+		}
 		log.Printf("ERROR linking alert: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
@@ -578,3 +765,38 @@ func (h *IncidentHandler) ListAlerts(c *gin.Context) {
 
 	c.JSON(http.StatusOK, alerts)
 }
+
+// SuggestAlerts godoc
+// @Summary      Suggest alerts for an incident
+// @Description  Lists unclosed alerts created while the incident was running, or up to an hour before it started, that share tags or a source with the alerts already linked to it. Alerts sharing more are listed first.
+// @Tags         Incidents
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Incident ID" format(uuid)
+// @Success      200 {array} dto.SuggestedAlert
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /incidents/{id}/suggested-alerts [get]
+func (h *IncidentHandler) SuggestAlerts(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid incident ID"})
+		return
+	}
+
+	orgID, _ := middleware.GetOrganizationID(c)
+
+	suggestions, err := h.incidentService.SuggestAlerts(c.Request.Context(), id, orgID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "incident not found"})
+			return
+		}
+		log.Printf("ERROR suggesting incident alerts: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
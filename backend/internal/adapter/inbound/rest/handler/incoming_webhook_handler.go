@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -13,6 +16,8 @@ import (
 	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/dto"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/inbound"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/jsonpath"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/logger"
 )
 
 type IncomingWebhookHandler struct {
@@ -31,6 +36,8 @@ func NewIncomingWebhookHandler(webhookService inbound.WebhookService, alertServi
 
 // ReceiveWebhook handles incoming webhooks from external sources
 func (h *IncomingWebhookHandler) ReceiveWebhook(c *gin.Context) {
+	log := logger.WithContext(c.Request.Context(), h.logger)
+
 	token := c.Param("token")
 	if token == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Token is required"})
@@ -55,7 +62,7 @@ func (h *IncomingWebhookHandler) ReceiveWebhook(c *gin.Context) {
 
 	// Update usage stats
 	if err := h.webhookService.UpdateIncomingTokenUsage(c.Request.Context(), webhookToken.ID); err != nil {
-		h.logger.Warn("Failed to update webhook token usage", zap.Error(err))
+		log.Warn("Failed to update webhook token usage", zap.Error(err))
 	}
 
 	// Read request body
@@ -65,29 +72,59 @@ func (h *IncomingWebhookHandler) ReceiveWebhook(c *gin.Context) {
 		return
 	}
 
-	// Parse based on integration type
+	// Log the request for debugging the integration
+	logged := &domain.IncomingWebhookRequest{
+		TokenID:        webhookToken.ID,
+		OrganizationID: webhookToken.OrganizationID,
+		Headers:        make(map[string]string, len(c.Request.Header)),
+		Body:           string(body),
+	}
+	for name, values := range c.Request.Header {
+		logged.Headers[name] = strings.Join(values, ", ")
+	}
+	defer func() {
+		if err := h.webhookService.RecordIncomingRequest(c.Request.Context(), logged); err != nil {
+			log.Warn("Failed to record incoming webhook request", zap.Error(err))
+		}
+	}()
+
+	// Parse based on integration type. Resolved holds the dedup keys of
+	// alerts the source reports as resolved.
 	var alerts []*dto.CreateAlertRequest
+	var resolved []string
 
 	switch webhookToken.IntegrationType {
 	case domain.IncomingWebhookPrometheus:
-		alerts, err = h.parsePrometheusWebhook(body)
+		alerts, resolved, err = h.parsePrometheusWebhook(body)
 	case domain.IncomingWebhookGrafana:
-		alerts, err = h.parseGrafanaWebhook(body)
+		alerts, resolved, err = h.parseGrafanaWebhook(body)
 	case domain.IncomingWebhookGeneric:
-		alerts, err = h.parseGenericWebhook(body)
+		if webhookToken.FieldMapping != nil {
+			alerts, err = h.parseMappedWebhook(body, webhookToken.FieldMapping)
+		} else {
+			alerts, err = h.parseGenericWebhook(body)
+		}
+	case domain.IncomingWebhookOpsGenie:
+		alerts, err = h.parseOpsGenieWebhook(body)
 	default:
+		logged.Error = stringPtr("unsupported integration type")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported integration type"})
 		return
 	}
 
 	if err != nil {
-		h.logger.Error("Failed to parse webhook payload",
+		log.Error("Failed to parse webhook payload",
 			zap.String("integration_type", string(webhookToken.IntegrationType)),
 			zap.Error(err),
 		)
+		logged.Error = stringPtr(fmt.Sprintf("failed to parse webhook: %v", err))
 		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Failed to parse webhook: %v", err)})
 		return
 	}
+	logged.AlertsReceived = len(alerts) + len(resolved)
+
+	// Failures of individual alerts are logged with the request
+	var failures []string
 
 	// Create alerts
 	createdAlerts := []string{}
@@ -97,60 +134,100 @@ func (h *IncomingWebhookHandler) ReceiveWebhook(c *gin.Context) {
 			alertReq.Priority = webhookToken.DefaultPriority
 		}
 
-		// Merge default tags
-		tagMap := make(map[string]bool)
-		for _, tag := range webhookToken.DefaultTags {
-			tagMap[tag] = true
-		}
-		for _, tag := range alertReq.Tags {
-			tagMap[tag] = true
-		}
-		finalTags := make([]string, 0, len(tagMap))
-		for tag := range tagMap {
-			finalTags = append(finalTags, tag)
+		// Merge default tags. Tags come from the sender's labels, so any over
+		// the limits are dropped rather than losing the alert.
+		tags, dropped := domain.FitTags(append(append([]string{}, webhookToken.DefaultTags...), alertReq.Tags...))
+		if len(dropped) > 0 {
+			log.Warn("Dropped alert tags over the limits",
+				zap.String("message", alertReq.Message),
+				zap.Int("dropped", len(dropped)),
+				zap.Strings("tags", dropped),
+			)
 		}
-		alertReq.Tags = finalTags
+		alertReq.Tags = tags
 
 		// Create alert
 		alert, err := h.alertService.CreateAlert(c.Request.Context(), webhookToken.OrganizationID, alertReq)
 		if err != nil {
-			h.logger.Error("Failed to create alert from webhook",
+			log.Error("Failed to create alert from webhook",
 				zap.Error(err),
 				zap.String("message", alertReq.Message),
 			)
+			failures = append(failures, fmt.Sprintf("failed to create alert %q: %v", alertReq.Message, err))
 			continue
 		}
 
 		createdAlerts = append(createdAlerts, alert.ID.String())
+		logged.AlertIDs = append(logged.AlertIDs, alert.ID)
+	}
+
+	// Close alerts resolved at the source
+	resolvedAlerts := []string{}
+	for _, dedupKey := range resolved {
+		alert, err := h.alertService.ResolveAlertByDedupKey(c.Request.Context(), webhookToken.OrganizationID, dedupKey)
+		if err != nil {
+			log.Error("Failed to resolve alert from webhook",
+				zap.Error(err),
+				zap.String("dedup_key", dedupKey),
+			)
+			failures = append(failures, fmt.Sprintf("failed to resolve alert %q: %v", dedupKey, err))
+			continue
+		}
+		if alert != nil {
+			resolvedAlerts = append(resolvedAlerts, alert.ID.String())
+			logged.ResolvedAlertIDs = append(logged.ResolvedAlertIDs, alert.ID)
+		}
+	}
+
+	logged.Success = len(failures) == 0
+	if len(failures) > 0 {
+		logged.Error = stringPtr(strings.Join(failures, "; "))
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"message":         "Alerts created successfully",
-		"alerts_created":  len(createdAlerts),
-		"alerts_received": len(alerts),
-		"alert_ids":       createdAlerts,
+		"message":            "Alerts created successfully",
+		"alerts_created":     len(createdAlerts),
+		"alerts_received":    len(alerts) + len(resolved),
+		"alert_ids":          createdAlerts,
+		"alerts_resolved":    len(resolvedAlerts),
+		"resolved_alert_ids": resolvedAlerts,
 	})
 }
 
-func (h *IncomingWebhookHandler) parsePrometheusWebhook(body []byte) ([]*dto.CreateAlertRequest, error) {
+// parsePrometheusWebhook parses an Alertmanager webhook, which batches any
+// number of alerts. Each alert is keyed by its fingerprint: firing alerts are
+// created (or deduplicated) and the fingerprints of resolved alerts are
+// returned so their Pulsar alerts can be closed.
+func (h *IncomingWebhookHandler) parsePrometheusWebhook(body []byte) ([]*dto.CreateAlertRequest, []string, error) {
 	var payload struct {
 		Alerts []struct {
-			Status      string            `json:"status"`
-			Labels      map[string]string `json:"labels"`
-			Annotations map[string]string `json:"annotations"`
-			StartsAt    string            `json:"startsAt"`
-			EndsAt      string            `json:"endsAt"`
+			Status       string            `json:"status"`
+			Labels       map[string]string `json:"labels"`
+			Annotations  map[string]string `json:"annotations"`
+			StartsAt     string            `json:"startsAt"`
+			EndsAt       string            `json:"endsAt"`
+			GeneratorURL string            `json:"generatorURL"`
+			Fingerprint  string            `json:"fingerprint"`
 		} `json:"alerts"`
 	}
 
 	if err := json.Unmarshal(body, &payload); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var alerts []*dto.CreateAlertRequest
+	var resolved []string
 	for _, prometheusAlert := range payload.Alerts {
-		// Skip resolved alerts
+		var fingerprint *string
+		if prometheusAlert.Fingerprint != "" {
+			fingerprint = &prometheusAlert.Fingerprint
+		}
+
 		if prometheusAlert.Status == "resolved" {
+			// Without a fingerprint there is no alert to match
+			if fingerprint != nil {
+				resolved = append(resolved, *fingerprint)
+			}
 			continue
 		}
 
@@ -162,44 +239,179 @@ func (h *IncomingWebhookHandler) parsePrometheusWebhook(body []byte) ([]*dto.Cre
 			message = "Alert from Prometheus"
 		}
 
-		description := prometheusAlert.Annotations["description"]
-
-		// Determine priority based on severity label
-		priority := "P3"
-		if severity, ok := prometheusAlert.Labels["severity"]; ok {
-			switch strings.ToLower(severity) {
-			case "critical":
-				priority = "P1"
-			case "error", "high":
-				priority = "P2"
-			case "warning", "medium":
-				priority = "P3"
-			case "info", "low":
-				priority = "P4"
-			}
-		}
+		description := annotationsDescription(prometheusAlert.Annotations, prometheusAlert.GeneratorURL)
 
-		// Convert labels to tags
-		tags := []string{"prometheus"}
-		for key, value := range prometheusAlert.Labels {
-			tags = append(tags, fmt.Sprintf("%s:%s", key, value))
-		}
+		priority := severityPriority(prometheusAlert.Labels, "P3")
+		tags := labelTags("prometheus", prometheusAlert.Labels)
 
 		alerts = append(alerts, &dto.CreateAlertRequest{
 			Source:      "prometheus",
+			SourceID:    fingerprint,
 			Priority:    priority,
 			Message:     message,
 			Description: &description,
 			Tags:        tags,
+			DedupKey:    fingerprint,
+		})
+	}
+
+	return alerts, resolved, nil
+}
+
+// severityPriority maps an alert's severity label to a priority, or returns
+// fallback when the label is missing or unknown
+func severityPriority(labels map[string]string, fallback string) string {
+	switch strings.ToLower(labels["severity"]) {
+	case "critical":
+		return "P1"
+	case "error", "high":
+		return "P2"
+	case "warning", "medium":
+		return "P3"
+	case "info", "low":
+		return "P4"
+	}
+	return fallback
+}
+
+// labelTags converts alert labels to sorted "key:value" tags after the
+// source tag
+func labelTags(source string, labels map[string]string) []string {
+	return append([]string{source}, keyValueTags(labels)...)
+}
+
+// keyValueTags converts labels to "key:value" tags sorted by key
+func keyValueTags(labels map[string]string) []string {
+	names := make([]string, 0, len(labels))
+	for key := range labels {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+
+	tags := make([]string, 0, len(names))
+	for _, key := range names {
+		tags = append(tags, fmt.Sprintf("%s:%s", key, labels[key]))
+	}
+	return tags
+}
+
+// annotationsDescription builds an alert description from the description
+// annotation followed by the remaining annotations (other than the summary,
+// which is usually the message) and the generator URL
+func annotationsDescription(annotations map[string]string, generatorURL string) string {
+	var lines []string
+	if description := annotations["description"]; description != "" {
+		lines = append(lines, description)
+	}
+
+	names := make([]string, 0, len(annotations))
+	for key := range annotations {
+		if key != "summary" && key != "description" {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+
+	var details []string
+	for _, key := range names {
+		details = append(details, fmt.Sprintf("%s: %s", key, annotations[key]))
+	}
+	if generatorURL != "" {
+		details = append(details, "source: "+generatorURL)
+	}
+	if len(details) > 0 {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, details...)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// parseGrafanaWebhook parses both Grafana webhook formats. Unified alerting
+// (Grafana 9+) batches alerts in an alerts array like Alertmanager; legacy
+// dashboard alerting sends a single rule state.
+func (h *IncomingWebhookHandler) parseGrafanaWebhook(body []byte) ([]*dto.CreateAlertRequest, []string, error) {
+	var probe struct {
+		Alerts json.RawMessage `json:"alerts"`
+	}
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return nil, nil, err
+	}
+
+	if len(probe.Alerts) > 0 && string(probe.Alerts) != "null" {
+		return h.parseGrafanaUnifiedWebhook(body)
+	}
+	return h.parseGrafanaLegacyWebhook(body)
+}
+
+// parseGrafanaUnifiedWebhook parses a Grafana unified alerting webhook. Each
+// alert is keyed by its fingerprint, named after its rule, and closed when
+// its status is resolved.
+func (h *IncomingWebhookHandler) parseGrafanaUnifiedWebhook(body []byte) ([]*dto.CreateAlertRequest, []string, error) {
+	var payload struct {
+		Alerts []struct {
+			Status       string            `json:"status"`
+			Labels       map[string]string `json:"labels"`
+			Annotations  map[string]string `json:"annotations"`
+			GeneratorURL string            `json:"generatorURL"`
+			Fingerprint  string            `json:"fingerprint"`
+		} `json:"alerts"`
+	}
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, nil, err
+	}
+
+	var alerts []*dto.CreateAlertRequest
+	var resolved []string
+	for _, grafanaAlert := range payload.Alerts {
+		var fingerprint *string
+		if grafanaAlert.Fingerprint != "" {
+			fingerprint = &grafanaAlert.Fingerprint
+		}
+
+		if grafanaAlert.Status == "resolved" {
+			if fingerprint != nil {
+				resolved = append(resolved, *fingerprint)
+			}
+			continue
+		}
+
+		message := grafanaAlert.Labels["alertname"]
+		if message == "" {
+			message = "Alert from Grafana"
+		}
+
+		description := annotationsDescription(grafanaAlert.Annotations, grafanaAlert.GeneratorURL)
+		if summary := grafanaAlert.Annotations["summary"]; summary != "" {
+			if description != "" {
+				summary += "\n\n" + description
+			}
+			description = summary
+		}
+
+		alerts = append(alerts, &dto.CreateAlertRequest{
+			Source:      "grafana",
+			SourceID:    fingerprint,
+			Priority:    severityPriority(grafanaAlert.Labels, "P2"),
+			Message:     message,
+			Description: &description,
+			Tags:        labelTags("grafana", grafanaAlert.Labels),
+			DedupKey:    fingerprint,
 		})
 	}
 
-	return alerts, nil
+	return alerts, resolved, nil
 }
 
-func (h *IncomingWebhookHandler) parseGrafanaWebhook(body []byte) ([]*dto.CreateAlertRequest, error) {
+// parseGrafanaLegacyWebhook parses a legacy Grafana alerting webhook. Alerts
+// are keyed by rule ID, so the ok state closes the alert the rule opened.
+func (h *IncomingWebhookHandler) parseGrafanaLegacyWebhook(body []byte) ([]*dto.CreateAlertRequest, []string, error) {
 	var payload struct {
 		Title   string            `json:"title"`
+		RuleID  int64             `json:"ruleId"`
 		State   string            `json:"state"`
 		Message string            `json:"message"`
 		RuleURL string            `json:"ruleUrl"`
@@ -207,12 +419,21 @@ func (h *IncomingWebhookHandler) parseGrafanaWebhook(body []byte) ([]*dto.Create
 	}
 
 	if err := json.Unmarshal(body, &payload); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// Skip resolved alerts
+	var dedupKey *string
+	if payload.RuleID != 0 {
+		key := fmt.Sprintf("grafana-rule-%d", payload.RuleID)
+		dedupKey = &key
+	}
+
+	// Resolved alerts close the rule's alert
 	if payload.State == "ok" {
-		return []*dto.CreateAlertRequest{}, nil
+		if dedupKey != nil {
+			return []*dto.CreateAlertRequest{}, []string{*dedupKey}, nil
+		}
+		return []*dto.CreateAlertRequest{}, nil, nil
 	}
 
 	message := payload.Title
@@ -234,21 +455,16 @@ func (h *IncomingWebhookHandler) parseGrafanaWebhook(body []byte) ([]*dto.Create
 		priority = "P3"
 	}
 
-	// Convert tags
-	tags := []string{"grafana"}
-	for key, value := range payload.Tags {
-		tags = append(tags, fmt.Sprintf("%s:%s", key, value))
-	}
-
 	return []*dto.CreateAlertRequest{
 		{
 			Source:      "grafana",
 			Priority:    priority,
 			Message:     message,
 			Description: &description,
-			Tags:        tags,
+			Tags:        labelTags("grafana", payload.Tags),
+			DedupKey:    dedupKey,
 		},
-	}, nil
+	}, nil, nil
 }
 
 func (h *IncomingWebhookHandler) parseGenericWebhook(body []byte) ([]*dto.CreateAlertRequest, error) {
@@ -293,3 +509,188 @@ func (h *IncomingWebhookHandler) parseGenericWebhook(body []byte) ([]*dto.Create
 		},
 	}, nil
 }
+
+// parseOpsGenieWebhook accepts the OpsGenie Alert API create payload so
+// existing OpsGenie integrations can be repointed at Pulsar. The alias is
+// used as the dedup key.
+func (h *IncomingWebhookHandler) parseOpsGenieWebhook(body []byte) ([]*dto.CreateAlertRequest, error) {
+	var payload struct {
+		Message     string            `json:"message"`
+		Alias       string            `json:"alias"`
+		Description string            `json:"description"`
+		Priority    string            `json:"priority"`
+		Source      string            `json:"source"`
+		Entity      string            `json:"entity"`
+		Tags        []string          `json:"tags"`
+		Details     map[string]string `json:"details"`
+	}
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	if payload.Message == "" {
+		return nil, fmt.Errorf("message is required")
+	}
+
+	// OpsGenie priorities match ours; anything else falls back to the
+	// token's default priority
+	priority := strings.ToUpper(payload.Priority)
+	if !domain.AlertPriority(priority).IsValid() {
+		priority = ""
+	}
+
+	source := payload.Source
+	if source == "" {
+		source = "opsgenie"
+	}
+
+	var description *string
+	if payload.Description != "" {
+		description = &payload.Description
+	}
+
+	var dedupKey *string
+	if payload.Alias != "" {
+		dedupKey = &payload.Alias
+	}
+
+	customFields := make(map[string]interface{}, len(payload.Details)+1)
+	for key, value := range payload.Details {
+		customFields[key] = value
+	}
+	if payload.Entity != "" {
+		customFields["entity"] = payload.Entity
+	}
+
+	tags := append([]string{"opsgenie"}, payload.Tags...)
+
+	return []*dto.CreateAlertRequest{
+		{
+			Source:       source,
+			Priority:     priority,
+			Message:      payload.Message,
+			Description:  description,
+			Tags:         tags,
+			CustomFields: customFields,
+			DedupKey:     dedupKey,
+		},
+	}, nil
+}
+
+// parseMappedWebhook parses an arbitrary JSON payload for a generic webhook
+// with a field mapping. Fields whose path is unset or missing from the payload
+// fall back to the generic webhook defaults.
+func (h *IncomingWebhookHandler) parseMappedWebhook(body []byte, mapping *domain.IncomingWebhookFieldMapping) ([]*dto.CreateAlertRequest, error) {
+	var payload interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, err
+	}
+
+	lookup := func(path string) (interface{}, bool) {
+		if path == "" {
+			return nil, false
+		}
+		parsed, err := jsonpath.Parse(path)
+		if err != nil {
+			return nil, false
+		}
+		value, ok := parsed.Lookup(payload)
+		if !ok || value == nil {
+			return nil, false
+		}
+		return value, true
+	}
+	lookupString := func(path string) string {
+		value, ok := lookup(path)
+		if !ok {
+			return ""
+		}
+		return mappedString(value)
+	}
+
+	alert := &dto.CreateAlertRequest{
+		Source:  "webhook",
+		Message: lookupString(mapping.Message),
+		Tags:    []string{},
+	}
+	if alert.Message == "" {
+		alert.Message = "Alert from generic webhook"
+	}
+	if source := lookupString(mapping.Source); source != "" {
+		alert.Source = source
+	}
+	if description := lookupString(mapping.Description); description != "" {
+		alert.Description = &description
+	}
+	if dedupKey := lookupString(mapping.DedupKey); dedupKey != "" {
+		alert.DedupKey = &dedupKey
+	}
+
+	// Translate the priority value, falling back to the token's default
+	if value := lookupString(mapping.Priority); value != "" {
+		if priority, ok := mapping.PriorityValues[value]; ok {
+			value = priority
+		} else if priority, ok := mapping.PriorityValues[strings.ToLower(value)]; ok {
+			value = priority
+		}
+		if priority := strings.ToUpper(value); domain.AlertPriority(priority).IsValid() {
+			alert.Priority = priority
+		}
+	}
+
+	if value, ok := lookup(mapping.Tags); ok {
+		switch tags := value.(type) {
+		case []interface{}:
+			for _, tag := range tags {
+				if tag := mappedString(tag); tag != "" {
+					alert.Tags = append(alert.Tags, tag)
+				}
+			}
+		case map[string]interface{}:
+			alert.Tags = append(alert.Tags, keyValueTags(mappedStrings(tags))...)
+		default:
+			if tag := mappedString(tags); tag != "" {
+				alert.Tags = append(alert.Tags, tag)
+			}
+		}
+	}
+	alert.Tags = append(alert.Tags, "webhook")
+
+	return []*dto.CreateAlertRequest{alert}, nil
+}
+
+// mappedString renders a JSON value found by a field mapping as text. Objects
+// and arrays are rendered as JSON.
+func mappedString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(encoded)
+	}
+}
+
+func mappedStrings(values map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		result[key] = mappedString(value)
+	}
+	return result
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
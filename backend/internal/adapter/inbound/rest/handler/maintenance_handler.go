@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/internal/core/port/inbound"
+)
+
+type MaintenanceWindowHandler struct {
+	maintenanceService inbound.MaintenanceWindowService
+}
+
+func NewMaintenanceWindowHandler(maintenanceService inbound.MaintenanceWindowService) *MaintenanceWindowHandler {
+	return &MaintenanceWindowHandler{
+		maintenanceService: maintenanceService,
+	}
+}
+
+// List godoc
+// @Summary      List maintenance windows
+// @Description  Retrieves a paginated list of maintenance windows for the organization, latest start first
+// @Tags         Maintenance Windows
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page       query    int  false  "Page number"  default(1)
+// @Param        page_size  query    int  false  "Page size"    default(50)
+// @Success      200  {object}  map[string][]domain.MaintenanceWindow  "List of maintenance windows"
+// @Failure      401  {object}  map[string]string                      "Unauthorized"
+// @Failure      500  {object}  map[string]string                      "Internal server error"
+// @Router       /maintenance-windows [get]
+func (h *MaintenanceWindowHandler) List(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "50"))
+
+	windows, err := h.maintenanceService.ListWindows(c.Request.Context(), orgID, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"windows": windows})
+}
+
+// Create godoc
+// @Summary      Create maintenance window
+// @Description  Creates a maintenance window. Alerts created while it is active and matching its conditions are recorded but not paged.
+// @Tags         Maintenance Windows
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      dto.CreateMaintenanceWindowRequest  true  "Maintenance window creation request"
+// @Success      201      {object}  domain.MaintenanceWindow            "Created maintenance window"
+// @Failure      400      {object}  map[string]string                   "Bad request"
+// @Failure      401      {object}  map[string]string                   "Unauthorized"
+// @Router       /maintenance-windows [post]
+func (h *MaintenanceWindowHandler) Create(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req dto.CreateMaintenanceWindowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	window, err := h.maintenanceService.CreateWindow(c.Request.Context(), orgID, userID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, window)
+}
+
+// Get godoc
+// @Summary      Get maintenance window
+// @Description  Retrieves a maintenance window by ID
+// @Tags         Maintenance Windows
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Maintenance window ID"  format(uuid)
+// @Success      200  {object}  domain.MaintenanceWindow  "Maintenance window"
+// @Failure      400  {object}  map[string]string         "Invalid window ID"
+// @Failure      404  {object}  map[string]string         "Window not found"
+// @Router       /maintenance-windows/{id} [get]
+func (h *MaintenanceWindowHandler) Get(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid maintenance window id"})
+		return
+	}
+
+	window, err := h.maintenanceService.GetWindow(c.Request.Context(), id, orgID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, window)
+}
+
+// Update godoc
+// @Summary      Update maintenance window
+// @Description  Updates a maintenance window; omitted fields are left unchanged
+// @Tags         Maintenance Windows
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                              true  "Maintenance window ID"  format(uuid)
+// @Param        request  body      dto.UpdateMaintenanceWindowRequest  true  "Maintenance window update request"
+// @Success      200      {object}  domain.MaintenanceWindow            "Updated maintenance window"
+// @Failure      400      {object}  map[string]string                   "Invalid request or window ID"
+// @Failure      404      {object}  map[string]string                   "Window not found"
+// @Router       /maintenance-windows/{id} [patch]
+func (h *MaintenanceWindowHandler) Update(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid maintenance window id"})
+		return
+	}
+
+	var req dto.UpdateMaintenanceWindowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	window, err := h.maintenanceService.UpdateWindow(c.Request.Context(), id, orgID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, window)
+}
+
+// Delete godoc
+// @Summary      Delete maintenance window
+// @Description  Deletes a maintenance window. Alerts it suppressed keep their history but lose the reference.
+// @Tags         Maintenance Windows
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Maintenance window ID"  format(uuid)
+// @Success      200  {object}  map[string]string  "Window deleted"
+// @Failure      400  {object}  map[string]string  "Invalid window ID"
+// @Failure      404  {object}  map[string]string  "Window not found"
+// @Router       /maintenance-windows/{id} [delete]
+func (h *MaintenanceWindowHandler) Delete(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid maintenance window id"})
+		return
+	}
+
+	if err := h.maintenanceService.DeleteWindow(c.Request.Context(), id, orgID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "maintenance window deleted successfully"})
+}
+
+func (h *MaintenanceWindowHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrMaintenanceWindowNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidMaintenanceWindow):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+)
+
+// MetaHandler serves the canonical enum values with their display labels and
+// colors, so clients don't have to hard-code them
+type MetaHandler struct{}
+
+func NewMetaHandler() *MetaHandler {
+	return &MetaHandler{}
+}
+
+// displayable is an enum value that knows how it should be shown
+type displayable interface {
+	~string
+	Label() string
+	Color() string
+}
+
+func enumValues[T displayable](values []T) []dto.EnumValue {
+	result := make([]dto.EnumValue, 0, len(values))
+	for _, v := range values {
+		result = append(result, dto.EnumValue{
+			Value: string(v),
+			Label: v.Label(),
+			Color: v.Color(),
+		})
+	}
+	return result
+}
+
+// Priorities godoc
+// @Summary      List alert priorities
+// @Description  Returns the alert priorities from most to least urgent, with display labels and suggested colors
+// @Tags         Meta
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]string
+// @Router       /meta/priorities [get]
+func (h *MetaHandler) Priorities(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"priorities": enumValues(domain.AlertPriorities),
+	})
+}
+
+// Severities godoc
+// @Summary      List incident severities
+// @Description  Returns the incident severities from most to least severe, with display labels and suggested colors
+// @Tags         Meta
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]string
+// @Router       /meta/severities [get]
+func (h *MetaHandler) Severities(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"severities": enumValues(domain.IncidentSeverities),
+	})
+}
+
+// Statuses godoc
+// @Summary      List alert and incident statuses
+// @Description  Returns the alert and incident statuses in lifecycle order, with display labels and suggested colors
+// @Tags         Meta
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  map[string]interface{}
+// @Failure      401  {object}  map[string]string
+// @Router       /meta/statuses [get]
+func (h *MetaHandler) Statuses(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"alert_statuses":    enumValues(domain.AlertStatuses),
+		"incident_statuses": enumValues(domain.IncidentStatuses),
+	})
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -8,6 +10,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/dto"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/inbound"
 )
@@ -55,12 +58,12 @@ func (h *NotificationHandler) CreateChannel(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, channel)
+	c.JSON(http.StatusCreated, channel.Redacted())
 }
 
 // GetChannel godoc
 // @Summary      Get a notification channel
-// @Description  Retrieves a notification channel by its ID
+// @Description  Retrieves a notification channel by its ID. Credentials in the config are redacted.
 // @Tags         Notifications
 // @Accept       json
 // @Produce      json
@@ -84,12 +87,12 @@ func (h *NotificationHandler) GetChannel(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, channel)
+	c.JSON(http.StatusOK, channel.Redacted())
 }
 
 // ListChannels godoc
 // @Summary      List notification channels
-// @Description  Lists all notification channels for the organization
+// @Description  Lists all notification channels for the organization. Credentials in the configs are redacted.
 // @Tags         Notifications
 // @Accept       json
 // @Produce      json
@@ -111,15 +114,20 @@ func (h *NotificationHandler) ListChannels(c *gin.Context) {
 		return
 	}
 
+	redacted := make([]domain.NotificationChannel, len(channels))
+	for i := range channels {
+		redacted[i] = channels[i].Redacted()
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"channels": channels,
-		"total":    len(channels),
+		"channels": redacted,
+		"total":    len(redacted),
 	})
 }
 
 // UpdateChannel godoc
 // @Summary      Update a notification channel
-// @Description  Updates an existing notification channel by its ID
+// @Description  Updates an existing notification channel by its ID. Config credentials sent back as "[REDACTED]" keep their stored value; send a new value to rotate one.
 // @Tags         Notifications
 // @Accept       json
 // @Produce      json
@@ -149,7 +157,7 @@ func (h *NotificationHandler) UpdateChannel(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, channel)
+	c.JSON(http.StatusOK, channel.Redacted())
 }
 
 // DeleteChannel godoc
@@ -336,6 +344,56 @@ func (h *NotificationHandler) DeletePreference(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "notification preference deleted successfully"})
 }
 
+// TestChannel godoc
+// @Summary      Test a notification channel
+// @Description  Sends a canned test notification through the channel and reports whether the provider accepted it. The send is recorded in the notification log flagged as a test. A recipient is required for email channels.
+// @Tags         Notifications
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path     string                              true   "Channel ID" format(uuid)
+// @Param        request body     dto.TestNotificationChannelRequest  false  "Test recipient"
+// @Success      200 {object} dto.TestNotificationChannelResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /notifications/channels/{id}/test [post]
+func (h *NotificationHandler) TestChannel(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid channel ID"})
+		return
+	}
+
+	// The body is optional
+	var req dto.TestNotificationChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.notificationService.TestChannel(c.Request.Context(), id, orgID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvalidChannelConfig):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // ==================== Sending Notifications ====================
 
 // SendNotification godoc
@@ -405,14 +463,19 @@ func (h *NotificationHandler) GetLog(c *gin.Context) {
 
 // ListLogs godoc
 // @Summary      List notification logs
-// @Description  Lists all notification logs for the organization with pagination
+// @Description  Lists notification logs for the organization, newest first, optionally filtered by delivery status, channel and time range
 // @Tags         Notifications
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        limit query int false "Number of logs to return" default(50)
+// @Param        status query []string false "Delivery status (pending, sent, failed, suppressed_dnd, skipped_unavailable)" collectionFormat(multi)
+// @Param        channel_id query string false "Only logs sent through this channel" format(uuid)
+// @Param        from query string false "Created at or after (RFC 3339)" format(date-time)
+// @Param        to query string false "Created before (RFC 3339)" format(date-time)
+// @Param        limit query int false "Number of logs to return, at most 200" default(50)
 // @Param        offset query int false "Number of logs to skip" default(0)
-// @Success      200 {object} map[string]interface{}
+// @Success      200 {object} dto.ListNotificationLogsResponse
+// @Failure      400 {object} map[string]string
 // @Failure      401 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /notifications/logs [get]
@@ -423,21 +486,32 @@ func (h *NotificationHandler) ListLogs(c *gin.Context) {
 		return
 	}
 
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
-	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	var req dto.ListNotificationLogsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if channelIDStr := c.Query("channel_id"); channelIDStr != "" {
+		channelID, err := uuid.Parse(channelIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid channel ID"})
+			return
+		}
+		req.ChannelID = &channelID
+	}
 
-	logs, err := h.notificationService.ListLogs(c.Request.Context(), orgID, limit, offset)
+	response, err := h.notificationService.ListLogs(c.Request.Context(), orgID, &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidNotificationLogFilter) || errors.Is(err, domain.ErrNegativeOffset) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"logs":   logs,
-		"total":  len(logs),
-		"limit":  limit,
-		"offset": offset,
-	})
+	c.JSON(http.StatusOK, response)
 }
 
 // ListLogsByAlert godoc
@@ -479,9 +553,10 @@ func (h *NotificationHandler) ListLogsByAlert(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        limit query int false "Number of logs to return" default(50)
+// @Param        limit query int false "Number of logs to return, at most 200" default(50)
 // @Param        offset query int false "Number of logs to skip" default(0)
 // @Success      200 {object} map[string]interface{}
+// @Failure      400 {object} map[string]string
 // @Failure      401 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /notifications/logs/user/me [get]
@@ -493,10 +568,15 @@ func (h *NotificationHandler) ListLogsByUser(c *gin.Context) {
 	}
 
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	limit = domain.ClampLimit(limit, 50)
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
 	logs, err := h.notificationService.ListLogsByUser(c.Request.Context(), userID, limit, offset)
 	if err != nil {
+		if errors.Is(err, domain.ErrNegativeOffset) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
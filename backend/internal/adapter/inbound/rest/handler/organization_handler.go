@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/internal/core/port/inbound"
+)
+
+type OrganizationHandler struct {
+	orgService inbound.OrganizationService
+}
+
+func NewOrganizationHandler(orgService inbound.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		orgService: orgService,
+	}
+}
+
+// Export godoc
+// @Summary      Export organization data
+// @Description  Streams a JSON archive of the organization's teams, schedules, escalation policies, routing rules, notification channels (secrets redacted), alerts and incidents. Requires admin access.
+// @Tags         Organizations
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} dto.OrganizationExport
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /organizations/export [get]
+func (h *OrganizationHandler) Export(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	role, _ := middleware.GetRole(c)
+	if role != "owner" && role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+		return
+	}
+
+	filename := fmt.Sprintf("pulsar-export-%s.json", time.Now().UTC().Format("20060102-150405"))
+	c.Header("Content-Type", "application/json")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	// A large organization takes longer to stream than the server's write timeout
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	if err := h.orgService.ExportOrganization(c.Request.Context(), orgID, c.Writer); err != nil {
+		// Once streaming has started the status is already sent and the client
+		// is left with a truncated, invalid document.
+		if c.Writer.Written() {
+			_ = c.Error(err)
+			return
+		}
+		log.Printf("ERROR exporting organization: %v", err)
+		c.Header("Content-Disposition", "")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+	}
+}
+
+// Import godoc
+// @Summary      Import organization data
+// @Description  Recreates teams, schedules, escalation policies, routing rules and notification channels from an export archive with fresh IDs. References must resolve within the archive or the organization; the import is all-or-nothing. Channels with redacted secrets are created disabled. Requires admin access.
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.OrganizationExport true "Export archive"
+// @Success      201 {object} dto.OrganizationImportResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /organizations/import [post]
+func (h *OrganizationHandler) Import(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	role, _ := middleware.GetRole(c)
+	if role != "owner" && role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+		return
+	}
+
+	var archive dto.OrganizationExport
+	if err := c.ShouldBindJSON(&archive); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.orgService.ImportOrganization(c.Request.Context(), orgID, &archive)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidImport) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetSettings godoc
+// @Summary      Get organization settings
+// @Description  Returns the organization-wide settings
+// @Tags         Organizations
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} map[string]interface{}
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /organizations/settings [get]
+func (h *OrganizationHandler) GetSettings(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	settings, err := h.orgService.GetSettings(c.Request.Context(), orgID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"settings": settings})
+}
+
+// UpdateSettings godoc
+// @Summary      Update organization settings
+// @Description  Updates organization-wide settings such as the override membership policy (off, warn or strict). Requires admin access.
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.UpdateOrganizationSettingsRequest true "Settings to update"
+// @Success      200 {object} map[string]interface{}
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Router       /organizations/settings [patch]
+func (h *OrganizationHandler) UpdateSettings(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	role, _ := middleware.GetRole(c)
+	if role != "owner" && role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+		return
+	}
+
+	var req dto.UpdateOrganizationSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings, err := h.orgService.UpdateSettings(c.Request.Context(), orgID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"settings": settings})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/dto"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/inbound"
 )
@@ -180,6 +182,47 @@ func (h *ScheduleHandler) Delete(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "schedule deleted"})
 }
 
+// ApplyConfig godoc
+// @Summary      Apply schedule config
+// @Description  Replaces a schedule's settings, rotations and participants with the given desired state in a single transaction. Rotations are matched by id or name; unlisted rotations are deleted.
+// @Tags         Schedules
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                     true  "Schedule ID"  format(uuid)
+// @Param        request  body      dto.ScheduleConfigRequest  true  "Desired schedule state"
+// @Success      200      {object}  domain.ScheduleWithParticipants
+// @Failure      400      {object}  map[string]string
+// @Failure      401      {object}  map[string]string
+// @Router       /schedules/{id}/config [put]
+func (h *ScheduleHandler) ApplyConfig(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule id"})
+		return
+	}
+
+	var req dto.ScheduleConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	schedule, err := h.scheduleService.ApplyScheduleConfig(c.Request.Context(), orgID, id, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
 // Rotation handlers
 
 // ListRotations godoc
@@ -471,6 +514,59 @@ func (h *ScheduleHandler) ReorderParticipants(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "participants reordered"})
 }
 
+// SetParticipants godoc
+// @Summary      Bulk set participants
+// @Description  Replaces the participants of a rotation with an ordered list of users in one transaction. Positions follow the order of user_ids. Every user must exist and belong to the organization.
+// @Tags         Schedules
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id          path      string                      true  "Schedule ID"   format(uuid)
+// @Param        rotationId  path      string                      true  "Rotation ID"   format(uuid)
+// @Param        request     body      dto.SetParticipantsRequest  true  "Ordered participant user IDs"
+// @Success      200         {object}  map[string][]domain.ParticipantWithUser
+// @Failure      400         {object}  map[string]string
+// @Failure      401         {object}  map[string]string
+// @Failure      404         {object}  map[string]string
+// @Router       /schedules/{id}/rotations/{rotationId}/participants/bulk [post]
+func (h *ScheduleHandler) SetParticipants(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	scheduleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule id"})
+		return
+	}
+
+	rotationID, err := uuid.Parse(c.Param("rotationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid rotation id"})
+		return
+	}
+
+	var req dto.SetParticipantsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	participants, err := h.scheduleService.SetParticipants(c.Request.Context(), orgID, scheduleID, rotationID, &req)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"participants": participants})
+}
+
 // Override handlers
 
 // ListOverrides godoc
@@ -506,7 +602,7 @@ func (h *ScheduleHandler) ListOverrides(c *gin.Context) {
 			return
 		}
 	} else {
-		start = time.Now()
+		start = time.Now().UTC()
 	}
 
 	if endStr != "" {
@@ -530,7 +626,7 @@ func (h *ScheduleHandler) ListOverrides(c *gin.Context) {
 
 // CreateOverride godoc
 // @Summary      Create override
-// @Description  Creates a new schedule override
+// @Description  Creates a new schedule override. When the organization's override membership policy is "warn", a user outside the schedule is accepted and reported in a Warning header.
 // @Tags         Schedules
 // @Accept       json
 // @Produce      json
@@ -553,12 +649,16 @@ func (h *ScheduleHandler) CreateOverride(c *gin.Context) {
 		return
 	}
 
-	override, err := h.scheduleService.CreateOverride(c.Request.Context(), scheduleID, &req)
+	override, warnings, err := h.scheduleService.CreateOverride(c.Request.Context(), scheduleID, &req)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	for _, warning := range warnings {
+		c.Writer.Header().Add("Warning", "299 - "+strconv.Quote(warning))
+	}
+
 	c.JSON(http.StatusCreated, override)
 }
 
@@ -691,9 +791,141 @@ func (h *ScheduleHandler) GetOnCall(c *gin.Context) {
 
 	onCallUser, err := h.scheduleService.GetOnCallUser(c.Request.Context(), scheduleID, at)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		if errors.Is(err, domain.ErrNoOnCall) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, onCallUser)
 }
+
+// ListOnCall godoc
+// @Summary      List on-call users across schedules
+// @Description  Resolves the on-call user of every schedule in the current organization. Schedules without anyone on call have a null OnCall.
+// @Tags         Schedules
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        at   query     string  false  "Point in time (RFC3339), defaults to now"
+// @Success      200  {object}  map[string][]domain.ScheduleOnCall
+// @Failure      400  {object}  map[string]string
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /oncall [get]
+func (h *ScheduleHandler) ListOnCall(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	at := time.Now()
+	if atStr := c.Query("at"); atStr != "" {
+		var err error
+		at, err = time.Parse(time.RFC3339, atStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid time format"})
+			return
+		}
+	}
+
+	oncall, err := h.scheduleService.ListOnCall(c.Request.Context(), orgID, at)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"oncall": oncall})
+}
+
+// PreviewOnCall godoc
+// @Summary      Preview on-call shifts
+// @Description  Computes the on-call shifts a proposed rotation would produce over a time range without saving anything
+// @Tags         Schedules
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      dto.PreviewOnCallRequest  true  "Rotation spec, participants and range"
+// @Success      200      {object}  map[string][]domain.OnCallUser
+// @Failure      400      {object}  map[string]string
+// @Router       /schedules/preview-oncall [post]
+func (h *ScheduleHandler) PreviewOnCall(c *gin.Context) {
+	var req dto.PreviewOnCallRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	shifts, err := h.scheduleService.PreviewOnCall(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"shifts": shifts})
+}
+
+// GetCoverage godoc
+// @Summary      Get schedule coverage
+// @Description  Walks the computed shifts and overrides of a schedule over a time range and reports gaps where no one is on call and overlaps where several people are
+// @Tags         Schedules
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      string  true   "Schedule ID"                                   format(uuid)
+// @Param        start  query     string  false  "Start time (RFC3339 format), defaults to now"  format(date-time)
+// @Param        end    query     string  false  "End time (RFC3339 format), defaults to start plus one month"  format(date-time)
+// @Success      200    {object}  domain.ScheduleCoverage
+// @Failure      400    {object}  map[string]string
+// @Failure      401    {object}  map[string]string
+// @Failure      404    {object}  map[string]string
+// @Router       /schedules/{id}/coverage [get]
+func (h *ScheduleHandler) GetCoverage(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	scheduleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid schedule id"})
+		return
+	}
+
+	start := time.Now().UTC()
+	if startStr := c.Query("start"); startStr != "" {
+		start, err = time.Parse(time.RFC3339, startStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start time"})
+			return
+		}
+	}
+
+	end := start.AddDate(0, 1, 0)
+	if endStr := c.Query("end"); endStr != "" {
+		end, err = time.Parse(time.RFC3339, endStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end time"})
+			return
+		}
+	}
+
+	coverage, err := h.scheduleService.GetCoverage(c.Request.Context(), orgID, scheduleID, start, end)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTimeRange):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "schedule not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, coverage)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/dto"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/inbound"
 )
@@ -122,14 +124,16 @@ func (h *TeamHandler) Update(c *gin.Context) {
 
 // Delete godoc
 // @Summary      Delete a team
-// @Description  Delete a team by ID
+// @Description  Delete a team by ID. Teams referenced by schedules or escalation targets are only deleted with force=true, which detaches the schedules and removes the targets.
 // @Tags         Teams
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path string true "Team ID" format(uuid)
+// @Param        force query bool false "Detach dependent schedules and escalation targets"
 // @Success      200 {object} map[string]string
 // @Failure      400 {object} map[string]string
+// @Failure      409 {object} map[string]interface{}
 // @Router       /teams/{id} [delete]
 func (h *TeamHandler) Delete(c *gin.Context) {
 	idStr := c.Param("id")
@@ -139,7 +143,14 @@ func (h *TeamHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.teamService.DeleteTeam(c.Request.Context(), id); err != nil {
+	force, _ := strconv.ParseBool(c.DefaultQuery("force", "false"))
+
+	if err := h.teamService.DeleteTeam(c.Request.Context(), id, force); err != nil {
+		var inUse *domain.TeamInUseError
+		if errors.As(err, &inUse) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "dependencies": inUse.Dependencies})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -449,3 +460,44 @@ func (h *TeamHandler) ResendInvitation(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "invitation resent"})
 }
+
+// AcceptInvitation godoc
+// @Summary      Accept a team invitation
+// @Description  Redeem an invitation token from the invitation email. Signed-in users must own the invited email; invitees without an account get one in the inviting organization by providing a username and password.
+// @Tags         Teams
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body dto.AcceptInvitationRequest true "Invitation token"
+// @Success      200 {object} dto.AcceptInvitationResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Router       /teams/invitations/accept [post]
+func (h *TeamHandler) AcceptInvitation(c *gin.Context) {
+	var req dto.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var callerID *uuid.UUID
+	if userID, ok := middleware.GetUserID(c); ok {
+		callerID = &userID
+	}
+
+	resp, err := h.teamService.RedeemInvitation(c.Request.Context(), &req, callerID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvitationLoginRequired):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrInvitationEmailMismatch):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
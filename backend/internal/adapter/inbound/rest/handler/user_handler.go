@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 
 	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/dto"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/inbound"
 )
@@ -22,12 +25,18 @@ func NewUserHandler(userService inbound.UserService) *UserHandler {
 
 // ListOrganizationUsers godoc
 // @Summary      List organization users
-// @Description  List all users in the organization
+// @Description  List users in the organization, optionally filtered for assignment pickers
 // @Tags         Users
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200 {object} map[string][]domain.User
+// @Param        search query string false "Match username, email or full name"
+// @Param        team_id query string false "Only members of this team" format(uuid)
+// @Param        is_active query bool false "Filter by active status"
+// @Param        page query int false "Page number" default(1)
+// @Param        page_size query int false "Page size" default(100)
+// @Success      200 {object} dto.ListUsersResponse
+// @Failure      400 {object} map[string]string
 // @Failure      401 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /users [get]
@@ -38,18 +47,274 @@ func (h *UserHandler) ListOrganizationUsers(c *gin.Context) {
 		return
 	}
 
-	users, err := h.userService.ListOrganizationUsers(c.Request.Context(), orgID)
+	var req dto.ListUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if teamIDStr := c.Query("team_id"); teamIDStr != "" {
+		teamID, err := uuid.Parse(teamIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team ID"})
+			return
+		}
+		req.TeamID = &teamID
+	}
+
+	response, err := h.userService.ListOrganizationUsers(c.Request.Context(), orgID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeactivateUser godoc
+// @Summary      Deactivate a user
+// @Description  Block the user from signing in to the organization and remove them from its teams, rotations and upcoming overrides. Open alerts assigned to them are unassigned with a note. History is kept, and the user keeps access to their other organizations. Admin only.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "User ID" format(uuid)
+// @Success      200 {object} domain.UserDeactivation
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /users/{id}/deactivate [post]
+func (h *UserHandler) DeactivateUser(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	actorID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	role, _ := middleware.GetRole(c)
+	if role != "owner" && role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	deactivation, err := h.userService.DeactivateUser(c.Request.Context(), orgID, userID, actorID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case errors.Is(err, domain.ErrCannotDeactivateSelf), errors.Is(err, domain.ErrCannotDeactivateOwner):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, deactivation)
+}
+
+// ReactivateUser godoc
+// @Summary      Reactivate a user
+// @Description  Restore sign-in to the organization for a deactivated user. Team and rotation memberships are not restored. Admin only.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "User ID" format(uuid)
+// @Success      200 {object} domain.User
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /users/{id}/reactivate [post]
+func (h *UserHandler) ReactivateUser(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	role, _ := middleware.GetRole(c)
+	if role != "owner" && role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	user, err := h.userService.ReactivateUser(c.Request.Context(), orgID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UnlockUser godoc
+// @Summary      Unlock a user
+// @Description  Lift the temporary lockout applied after repeated failed logins and clear the user's failed attempts. Admin only.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "User ID" format(uuid)
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /users/{id}/unlock [post]
+func (h *UserHandler) UnlockUser(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	role, _ := middleware.GetRole(c)
+	if role != "owner" && role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	if err := h.userService.UnlockUser(c.Request.Context(), orgID, userID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "user unlocked successfully"})
+}
+
+// ListUserSessions godoc
+// @Summary      List a user's sessions
+// @Description  Lists the devices a member has signed in to the organization from, most recently used first. Sessions in their other organizations are not listed. Admin only.
+// @Tags         Users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "User ID" format(uuid)
+// @Success      200 {array} dto.SessionResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /users/{id}/sessions [get]
+func (h *UserHandler) ListUserSessions(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	role, _ := middleware.GetRole(c)
+	if role != "owner" && role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	sessions, err := h.userService.ListUserSessions(c.Request.Context(), orgID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeUserSessions godoc
+// @Summary      Log a user out everywhere
+// @Description  Revokes every session a member has in the organization, e.g. after a suspected credential compromise. Sessions in their other organizations are not affected. Access tokens already issued stay valid until they expire. Admin only.
+// @Tags         Users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "User ID" format(uuid)
+// @Success      200 {object} dto.RevokeSessionsResponse
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /users/{id}/sessions [delete]
+func (h *UserHandler) RevokeUserSessions(c *gin.Context) {
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	role, _ := middleware.GetRole(c)
+	if role != "owner" && role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	revoked, err := h.userService.RevokeUserSessions(c.Request.Context(), orgID, userID)
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"users": users})
+	c.JSON(http.StatusOK, dto.RevokeSessionsResponse{Revoked: revoked})
 }
 
 // UpdateProfile godoc
 // @Summary      Update current user's profile
-// @Description  Update the authenticated user's profile (full name, phone, timezone)
+// @Description  Update the authenticated user's profile (full name, phone, timezone) and the priority below which they are paged through their primary channel only. Email and role can't be changed here.
 // @Tags         Users
 // @Accept       json
 // @Produce      json
@@ -60,6 +325,7 @@ func (h *UserHandler) ListOrganizationUsers(c *gin.Context) {
 // @Failure      401 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /users/me [patch]
+// @Router       /auth/me [patch]
 func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	userID, ok := middleware.GetUserID(c)
 	if !ok {
@@ -75,6 +341,10 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 
 	user, err := h.userService.UpdateProfile(c.Request.Context(), userID, &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPhone) || errors.Is(err, domain.ErrInvalidTimezone) || errors.Is(err, domain.ErrInvalidPriority) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -27,7 +28,7 @@ func NewWebhookHandler(webhookService inbound.WebhookService) *WebhookHandler {
 
 // CreateEndpoint godoc
 // @Summary      Create a webhook endpoint
-// @Description  Create a new outgoing webhook endpoint
+// @Description  Create a new outgoing webhook endpoint. The response carries the signing secret, which is masked on later reads.
 // @Tags         Webhooks
 // @Accept       json
 // @Produce      json
@@ -48,6 +49,10 @@ func (h *WebhookHandler) CreateEndpoint(c *gin.Context) {
 
 	endpoint, err := h.webhookService.CreateEndpoint(c.Request.Context(), orgID, &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrUnknownWebhookEvent) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create webhook endpoint"})
 		return
 	}
@@ -57,7 +62,7 @@ func (h *WebhookHandler) CreateEndpoint(c *gin.Context) {
 
 // GetEndpoint godoc
 // @Summary      Get a webhook endpoint
-// @Description  Get a webhook endpoint by ID
+// @Description  Get a webhook endpoint by ID. The signing secret is masked to its last four characters.
 // @Tags         Webhooks
 // @Accept       json
 // @Produce      json
@@ -91,7 +96,7 @@ func (h *WebhookHandler) GetEndpoint(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, endpoint)
+	c.JSON(http.StatusOK, endpoint.Redacted())
 }
 
 // ListEndpoints godoc
@@ -114,11 +119,12 @@ func (h *WebhookHandler) ListEndpoints(c *gin.Context) {
 	}
 
 	// Ensure we return an empty array instead of null
-	if endpoints == nil {
-		endpoints = []*domain.WebhookEndpoint{}
+	redacted := make([]*domain.WebhookEndpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		redacted = append(redacted, endpoint.Redacted())
 	}
 
-	c.JSON(http.StatusOK, endpoints)
+	c.JSON(http.StatusOK, redacted)
 }
 
 // UpdateEndpoint godoc
@@ -155,10 +161,56 @@ func (h *WebhookHandler) UpdateEndpoint(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "Webhook endpoint not found"})
 			return
 		}
+		if errors.Is(err, domain.ErrUnknownWebhookEvent) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update webhook endpoint"})
 		return
 	}
 
+	c.JSON(http.StatusOK, endpoint.Redacted())
+}
+
+// RotateEndpointSecret godoc
+// @Summary      Rotate a webhook endpoint secret
+// @Description  Replaces the endpoint's HMAC signing secret and returns the endpoint with the new secret. This is the only way to obtain a secret after creation. Requires admin access.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Endpoint ID" format(uuid)
+// @Success      200 {object} domain.WebhookEndpoint
+// @Failure      400 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /webhooks/endpoints/{id}/rotate-secret [post]
+func (h *WebhookHandler) RotateEndpointSecret(c *gin.Context) {
+	orgID, _ := middleware.GetOrganizationID(c)
+
+	role, _ := middleware.GetRole(c)
+	if role != "owner" && role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid endpoint ID"})
+		return
+	}
+
+	endpoint, err := h.webhookService.RotateEndpointSecret(c.Request.Context(), id, orgID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Webhook endpoint not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rotate webhook endpoint secret"})
+		return
+	}
+
 	c.JSON(http.StatusOK, endpoint)
 }
 
@@ -204,9 +256,10 @@ func (h *WebhookHandler) DeleteEndpoint(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        limit query int false "Limit" default(20)
+// @Param        limit query int false "Limit, at most 200" default(20)
 // @Param        offset query int false "Offset" default(0)
 // @Success      200 {object} map[string]interface{}
+// @Failure      400 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /webhooks/deliveries [get]
 func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
@@ -227,8 +280,14 @@ func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
 		}
 	}
 
+	limit = domain.ClampLimit(limit, 20)
+
 	deliveries, err := h.webhookService.ListDeliveries(c.Request.Context(), orgID, limit, offset)
 	if err != nil {
+		if errors.Is(err, domain.ErrNegativeOffset) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list webhook deliveries"})
 		return
 	}
@@ -265,6 +324,10 @@ func (h *WebhookHandler) CreateIncomingToken(c *gin.Context) {
 
 	token, err := h.webhookService.CreateIncomingToken(c.Request.Context(), orgID, &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidFieldMapping) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create incoming webhook token"})
 		return
 	}
@@ -299,6 +362,48 @@ func (h *WebhookHandler) ListIncomingTokens(c *gin.Context) {
 	c.JSON(http.StatusOK, tokens)
 }
 
+// RotateIncomingToken godoc
+// @Summary      Rotate an incoming webhook token
+// @Description  Replaces the token string while keeping the integration type, defaults and field mapping, and returns the token with its new value. The old token stops working immediately. Requires admin access.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Token ID" format(uuid)
+// @Success      200 {object} domain.IncomingWebhookToken
+// @Failure      400 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /webhooks/incoming/{id}/rotate [post]
+func (h *WebhookHandler) RotateIncomingToken(c *gin.Context) {
+	orgID, _ := middleware.GetOrganizationID(c)
+
+	role, _ := middleware.GetRole(c)
+	if role != "owner" && role != "admin" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "admin access required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	token, err := h.webhookService.RotateIncomingToken(c.Request.Context(), id, orgID)
+	if err != nil {
+		if err == domain.ErrNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Incoming webhook token not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rotate incoming webhook token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
 // DeleteIncomingToken godoc
 // @Summary      Delete an incoming webhook token
 // @Description  Delete an incoming webhook token by ID
@@ -331,3 +436,37 @@ func (h *WebhookHandler) DeleteIncomingToken(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Incoming webhook token deleted successfully"})
 }
+
+// ListIncomingRequests godoc
+// @Summary      List incoming webhook requests
+// @Description  List the recent raw requests received by an incoming webhook token and how they were processed
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "Token ID" format(uuid)
+// @Success      200 {array} domain.IncomingWebhookRequest
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /webhooks/incoming/{id}/requests [get]
+func (h *WebhookHandler) ListIncomingRequests(c *gin.Context) {
+	orgID, _ := middleware.GetOrganizationID(c)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token ID"})
+		return
+	}
+
+	requests, err := h.webhookService.ListIncomingRequests(c.Request.Context(), id, orgID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Incoming webhook token not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list incoming webhook requests"})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
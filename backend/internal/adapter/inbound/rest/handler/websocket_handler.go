@@ -59,12 +59,19 @@ func NewWebSocketHandler(wsService inbound.WebSocketService, logger *zap.Logger,
 	return h
 }
 
-// HandleWebSocket handles WebSocket connections
+// HandleWebSocket handles WebSocket connections. Reconnecting clients can pass
+// last_event_id or since (RFC3339) to have missed events replayed first.
 func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 	// Get user and organization from context (set by auth middleware)
 	userID, _ := middleware.GetUserID(c)
 	orgID, _ := middleware.GetOrganizationID(c)
 
+	lastEventID, since, err := replayCursor(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid time format"})
+		return
+	}
+
 	// Upgrade HTTP connection to WebSocket
 	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
@@ -78,6 +85,8 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 		UserID:         userID,
 		OrganizationID: orgID,
 		Send:           make(chan *domain.WSMessage, 256),
+		LastEventID:    lastEventID,
+		Since:          since,
 	}
 
 	// Register client with hub
@@ -88,6 +97,21 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 	go h.readPump(conn, client)
 }
 
+// replayCursor parses the optional last_event_id and since (RFC3339) query
+// parameters a reconnecting client uses to ask for missed events
+func replayCursor(c *gin.Context) (string, *time.Time, error) {
+	sinceStr := c.Query("since")
+	if sinceStr == "" {
+		return c.Query("last_event_id"), nil, nil
+	}
+
+	since, err := time.Parse(time.RFC3339, sinceStr)
+	if err != nil {
+		return "", nil, err
+	}
+	return c.Query("last_event_id"), &since, nil
+}
+
 // readPump pumps messages from the WebSocket connection to the hub
 func (h *WebSocketHandler) readPump(conn *websocket.Conn, client *domain.WSClient) {
 	defer func() {
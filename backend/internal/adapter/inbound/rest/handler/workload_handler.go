@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
+	"github.com/nmn3m/pulsar/backend/internal/core/port/inbound"
+)
+
+type WorkloadHandler struct {
+	workloadService inbound.WorkloadService
+}
+
+func NewWorkloadHandler(workloadService inbound.WorkloadService) *WorkloadHandler {
+	return &WorkloadHandler{
+		workloadService: workloadService,
+	}
+}
+
+// Get godoc
+// @Summary      Get my workload
+// @Description  Returns, in one call, the unclosed alerts assigned to the authenticated user, the unresolved incidents they are a responder on, and the schedules they are on call for right now
+// @Tags         Users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  dto.WorkloadResponse
+// @Failure      401  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /me/workload [get]
+func (h *WorkloadHandler) Get(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	orgID, ok := middleware.GetOrganizationID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	workload, err := h.workloadService.GetWorkload(c.Request.Context(), orgID, userID)
+	if err != nil {
+		log.Printf("ERROR getting workload: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, workload)
+}
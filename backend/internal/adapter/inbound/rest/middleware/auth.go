@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -10,20 +12,32 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
 )
 
+// OrganizationHeader scopes a request to another organization the user is a
+// member of
+const OrganizationHeader = "X-Organization-ID"
+
+// MembershipResolver looks up a user's role in an organization
+type MembershipResolver interface {
+	GetUserRole(ctx context.Context, orgID, userID uuid.UUID) (domain.UserRole, error)
+}
+
 type Claims struct {
 	UserID         uuid.UUID `json:"user_id"`
 	Email          string    `json:"email"`
 	OrganizationID uuid.UUID `json:"organization_id"`
 	Role           string    `json:"role"`
+	SessionID      uuid.UUID `json:"session_id"`
 	jwt.RegisteredClaims
 }
 
 type AuthMiddleware struct {
-	jwtSecret string
-	blacklist outbound.TokenRevoker
+	jwtSecret   string
+	blacklist   outbound.TokenRevoker
+	memberships MembershipResolver
 }
 
 func NewAuthMiddleware(jwtSecret string, blacklist outbound.TokenRevoker) *AuthMiddleware {
@@ -33,6 +47,12 @@ func NewAuthMiddleware(jwtSecret string, blacklist outbound.TokenRevoker) *AuthM
 	}
 }
 
+// SetMembershipResolver enables the X-Organization-ID header. Without it only
+// the organization of the token is accepted.
+func (m *AuthMiddleware) SetMembershipResolver(memberships MembershipResolver) {
+	m.memberships = memberships
+}
+
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -87,16 +107,56 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
+		orgID, role := claims.OrganizationID, claims.Role
+		if header := c.GetHeader(OrganizationHeader); header != "" {
+			requested, err := uuid.Parse(header)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + OrganizationHeader + " header"})
+				c.Abort()
+				return
+			}
+
+			if requested != orgID {
+				memberRole, err := m.resolveMembership(c.Request.Context(), requested, claims.UserID)
+				if errors.Is(err, domain.ErrNotOrganizationMember) {
+					c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+					c.Abort()
+					return
+				}
+				if err != nil {
+					c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resolve organization"})
+					c.Abort()
+					return
+				}
+				orgID, role = requested, string(memberRole)
+			}
+		}
+
 		// Set user context
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
-		c.Set("organization_id", claims.OrganizationID)
-		c.Set("role", claims.Role)
+		c.Set("organization_id", orgID)
+		c.Set("role", role)
+		c.Set("session_id", claims.SessionID)
 
 		c.Next()
 	}
 }
 
+// resolveMembership returns the user's role in the organization, or
+// domain.ErrNotOrganizationMember when they don't belong to it
+func (m *AuthMiddleware) resolveMembership(ctx context.Context, orgID, userID uuid.UUID) (domain.UserRole, error) {
+	if m.memberships == nil {
+		return "", domain.ErrNotOrganizationMember
+	}
+
+	role, err := m.memberships.GetUserRole(ctx, orgID, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", domain.ErrNotOrganizationMember
+	}
+	return role, err
+}
+
 func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -160,6 +220,17 @@ func GetOrganizationID(c *gin.Context) (uuid.UUID, bool) {
 	return oid, ok
 }
 
+// GetSessionID returns the session the access token was issued to. Tokens
+// issued before sessions were tracked carry uuid.Nil.
+func GetSessionID(c *gin.Context) (uuid.UUID, bool) {
+	sessionID, exists := c.Get("session_id")
+	if !exists {
+		return uuid.Nil, false
+	}
+	sid, ok := sessionID.(uuid.UUID)
+	return sid, ok
+}
+
 func GetRole(c *gin.Context) (string, bool) {
 	role, exists := c.Get("role")
 	if !exists {
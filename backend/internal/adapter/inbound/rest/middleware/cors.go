@@ -7,15 +7,43 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func CORS(allowedOrigins []string) gin.HandlerFunc {
+// CORSOptions configures the CORS middleware
+type CORSOptions struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+func CORS(opts CORSOptions) gin.HandlerFunc {
 	config := cors.Config{
-		AllowOrigins:     allowedOrigins,
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           2 * time.Hour, // Cache preflight requests for 2 hours
+		AllowMethods:     opts.AllowedMethods,
+		AllowHeaders:     opts.AllowedHeaders,
+		ExposeHeaders:    opts.ExposedHeaders,
+		AllowCredentials: opts.AllowCredentials,
+		MaxAge:           opts.MaxAge,
+	}
+
+	if isWildcardOrigin(opts.AllowedOrigins) {
+		// Browsers reject "Access-Control-Allow-Origin: *" combined with
+		// credentials, so never send both. Config validation rejects this
+		// combination up front; this is a last line of defence.
+		config.AllowAllOrigins = true
+		config.AllowCredentials = false
+	} else {
+		config.AllowOrigins = opts.AllowedOrigins
 	}
 
 	return cors.New(config)
 }
+
+func isWildcardOrigin(origins []string) bool {
+	for _, origin := range origins {
+		if origin == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -56,6 +56,7 @@ func Logger(logger *zap.Logger) gin.HandlerFunc {
 			zap.Int("status", c.Writer.Status()),
 			zap.Duration("duration", duration),
 			zap.String("client_ip", c.ClientIP()),
+			zap.String("request_id", GetRequestID(c)),
 		)
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -43,6 +44,17 @@ func OTelMiddleware(serviceName string) gin.HandlerFunc {
 	return otelgin.Middleware(serviceName)
 }
 
+// OTelRequestIDMiddleware tags the active span with the request ID.
+// It must be registered after both RequestID and OTelMiddleware.
+func OTelRequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if id := GetRequestID(c); id != "" {
+			trace.SpanFromContext(c.Request.Context()).SetAttributes(attribute.String("request.id", id))
+		}
+		c.Next()
+	}
+}
+
 // OTelMetricsMiddleware returns a Gin middleware for OpenTelemetry metrics
 func OTelMetricsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/pkg/requestid"
+)
+
+// maxRequestIDLength bounds inbound request IDs so clients can't bloat logs
+const maxRequestIDLength = 128
+
+// RequestID assigns every request a correlation ID. An inbound X-Request-ID is
+// reused when it looks sane; otherwise a new UUID is generated. The ID is
+// echoed in the response header and stored in both the gin and request
+// contexts for downstream logging.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !validRequestID(id) {
+			id = uuid.New().String()
+		}
+
+		c.Set("request_id", id)
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+
+		c.Next()
+	}
+}
+
+// GetRequestID retrieves the request ID from the gin context
+func GetRequestID(c *gin.Context) string {
+	return c.GetString("request_id")
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		// Printable ASCII only; rejects CR/LF header and log injection
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
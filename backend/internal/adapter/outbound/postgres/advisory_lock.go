@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AdvisoryLocker provides cross-instance mutual exclusion using Postgres
+// session-level advisory locks. Each lock is held on a dedicated connection,
+// so it is released automatically if the holding instance dies.
+type AdvisoryLocker struct {
+	db *sqlx.DB
+}
+
+func NewAdvisoryLocker(db *sqlx.DB) *AdvisoryLocker {
+	return &AdvisoryLocker{db: db}
+}
+
+// TryWithLock runs fn only if the named lock is free, returning whether it ran.
+// It never blocks waiting for another holder.
+func (l *AdvisoryLocker) TryWithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire connection for lock %q: %w", name, err)
+	}
+	defer conn.Close()
+
+	key := advisoryLockKey(name)
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, key).Scan(&acquired); err != nil {
+		return false, fmt.Errorf("failed to try lock %q: %w", name, err)
+	}
+	if !acquired {
+		return false, nil
+	}
+
+	defer func() {
+		// Use a fresh context so the lock is released even if ctx was cancelled.
+		// If unlocking fails, discard the connection rather than returning a
+		// lock-holding session to the pool.
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, key); err != nil {
+			_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+	}()
+
+	return true, fn(ctx)
+}
+
+// advisoryLockKey maps a lock name onto the bigint keyspace used by
+// pg_advisory_lock.
+func advisoryLockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("pulsar:" + name))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+type AlertAckTokenRepository struct {
+	db *DB
+}
+
+func NewAlertAckTokenRepository(db *DB) *AlertAckTokenRepository {
+	return &AlertAckTokenRepository{db: db}
+}
+
+func (r *AlertAckTokenRepository) Create(ctx context.Context, token *domain.AlertAckToken) error {
+	query := `
+		INSERT INTO alert_ack_tokens (id, alert_id, organization_id, user_id, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		token.ID,
+		token.AlertID,
+		token.OrganizationID,
+		token.UserID,
+		token.ExpiresAt,
+	).Scan(&token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create ack token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AlertAckTokenRepository) Consume(ctx context.Context, id uuid.UUID) (*domain.AlertAckToken, error) {
+	query := `
+		UPDATE alert_ack_tokens
+		SET used_at = NOW()
+		WHERE id = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING id, alert_id, organization_id, user_id, expires_at, used_at, created_at
+	`
+
+	var token domain.AlertAckToken
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&token.ID,
+		&token.AlertID,
+		&token.OrganizationID,
+		&token.UserID,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume ack token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (r *AlertAckTokenRepository) DeleteSpent(ctx context.Context, now time.Time, limit int) (int, error) {
+	query := `
+		DELETE FROM alert_ack_tokens
+		WHERE id IN (
+			SELECT id FROM alert_ack_tokens
+			WHERE used_at IS NOT NULL OR expires_at <= $1
+			LIMIT $2
+		)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, now, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete spent ack tokens: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete spent ack tokens: %w", err)
+	}
+
+	return int(deleted), nil
+}
@@ -5,10 +5,12 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 )
@@ -21,16 +23,19 @@ func NewAlertRepository(db *DB) *AlertRepository {
 	return &AlertRepository{db: db}
 }
 
-func (r *AlertRepository) Create(ctx context.Context, alert *domain.Alert) error {
+// Create inserts the alert together with any outbox events about it, in one
+// transaction.
+func (r *AlertRepository) Create(ctx context.Context, alert *domain.Alert, events ...*domain.OutboxEvent) error {
 	query := `
 		INSERT INTO alerts (
 			id, organization_id, source, source_id, priority, status,
 			message, description, tags, custom_fields,
 			assigned_to_user_id, assigned_to_team_id,
 			escalation_policy_id, escalation_level,
-			dedup_key, dedup_count, first_occurrence_at, last_occurrence_at
+			dedup_key, dedup_count, first_occurrence_at, last_occurrence_at,
+			maintenance_window_id
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 		RETURNING created_at, updated_at
 	`
 
@@ -44,7 +49,13 @@ func (r *AlertRepository) Create(ctx context.Context, alert *domain.Alert) error
 		return fmt.Errorf("failed to marshal custom_fields: %w", err)
 	}
 
-	err = r.db.QueryRowContext(
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		alert.ID,
@@ -65,12 +76,23 @@ func (r *AlertRepository) Create(ctx context.Context, alert *domain.Alert) error
 		alert.DedupCount,
 		alert.FirstOccurrenceAt,
 		alert.LastOccurrenceAt,
+		alert.MaintenanceWindowID,
 	).Scan(&alert.CreatedAt, &alert.UpdatedAt)
 
 	if err != nil {
 		return fmt.Errorf("failed to create alert: %w", err)
 	}
 
+	for _, event := range events {
+		if err := insertOutboxEvent(ctx, tx, event); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
@@ -82,7 +104,8 @@ func (r *AlertRepository) GetByID(ctx context.Context, id, orgID uuid.UUID) (*do
 			assigned_to_user_id, assigned_to_team_id,
 			acknowledged_by, acknowledged_at,
 			closed_by, closed_at, close_reason,
-			snoozed_until,
+			snoozed_until, snoozed_by, snooze_reason,
+			maintenance_window_id,
 			escalation_policy_id, escalation_level, last_escalated_at,
 			dedup_key, dedup_count, first_occurrence_at, last_occurrence_at,
 			created_at, updated_at
@@ -112,6 +135,9 @@ func (r *AlertRepository) GetByID(ctx context.Context, id, orgID uuid.UUID) (*do
 		&alert.ClosedAt,
 		&alert.CloseReason,
 		&alert.SnoozedUntil,
+		&alert.SnoozedBy,
+		&alert.SnoozeReason,
+		&alert.MaintenanceWindowID,
 		&alert.EscalationPolicyID,
 		&alert.EscalationLevel,
 		&alert.LastEscalatedAt,
@@ -124,7 +150,7 @@ func (r *AlertRepository) GetByID(ctx context.Context, id, orgID uuid.UUID) (*do
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("alert not found")
+		return nil, domain.ErrAlertNotFound
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get alert: %w", err)
@@ -150,9 +176,9 @@ func (r *AlertRepository) Update(ctx context.Context, alert *domain.Alert) error
 			assigned_to_user_id = $10, assigned_to_team_id = $11,
 			acknowledged_by = $12, acknowledged_at = $13,
 			closed_by = $14, closed_at = $15, close_reason = $16,
-			snoozed_until = $17,
-			escalation_policy_id = $18, escalation_level = $19, last_escalated_at = $20
-		WHERE id = $1 AND organization_id = $21
+			snoozed_until = $17, snoozed_by = $18, snooze_reason = $19,
+			escalation_policy_id = $20, escalation_level = $21, last_escalated_at = $22
+		WHERE id = $1 AND organization_id = $23
 		RETURNING updated_at
 	`
 
@@ -186,6 +212,8 @@ func (r *AlertRepository) Update(ctx context.Context, alert *domain.Alert) error
 		alert.ClosedAt,
 		alert.CloseReason,
 		alert.SnoozedUntil,
+		alert.SnoozedBy,
+		alert.SnoozeReason,
 		alert.EscalationPolicyID,
 		alert.EscalationLevel,
 		alert.LastEscalatedAt,
@@ -219,6 +247,7 @@ func (r *AlertRepository) Delete(ctx context.Context, id, orgID uuid.UUID) error
 	return nil
 }
 
+// List runs on the read replica when one is configured
 func (r *AlertRepository) List(ctx context.Context, filter *domain.AlertFilter) ([]*domain.Alert, int, error) {
 	// Build WHERE clause
 	where := []string{"organization_id = $1"}
@@ -278,11 +307,19 @@ func (r *AlertRepository) List(ctx context.Context, filter *domain.AlertFilter)
 	// Count total
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM alerts WHERE %s", whereClause)
 	var total int
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	err := r.db.Reader().QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count alerts: %w", err)
 	}
 
+	// The cursor narrows the page, not the total
+	if filter.After != nil {
+		where = append(where, fmt.Sprintf("(created_at, id) < ($%d, $%d)", argCount+1, argCount+2))
+		args = append(args, filter.After.CreatedAt, filter.After.ID)
+		argCount += 2
+		whereClause = strings.Join(where, " AND ")
+	}
+
 	// Query alerts
 	query := fmt.Sprintf(`
 		SELECT
@@ -291,19 +328,20 @@ func (r *AlertRepository) List(ctx context.Context, filter *domain.AlertFilter)
 			assigned_to_user_id, assigned_to_team_id,
 			acknowledged_by, acknowledged_at,
 			closed_by, closed_at, close_reason,
-			snoozed_until,
+			snoozed_until, snoozed_by, snooze_reason,
+			maintenance_window_id,
 			escalation_policy_id, escalation_level, last_escalated_at,
 			dedup_key, dedup_count, first_occurrence_at, last_occurrence_at,
 			created_at, updated_at
 		FROM alerts
 		WHERE %s
-		ORDER BY created_at DESC
+		ORDER BY created_at DESC, id DESC
 		LIMIT $%d OFFSET $%d
 	`, whereClause, argCount+1, argCount+2)
 
 	args = append(args, filter.Limit, filter.Offset)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.db.Reader().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list alerts: %w", err)
 	}
@@ -333,6 +371,9 @@ func (r *AlertRepository) List(ctx context.Context, filter *domain.AlertFilter)
 			&alert.ClosedAt,
 			&alert.CloseReason,
 			&alert.SnoozedUntil,
+			&alert.SnoozedBy,
+			&alert.SnoozeReason,
+			&alert.MaintenanceWindowID,
 			&alert.EscalationPolicyID,
 			&alert.EscalationLevel,
 			&alert.LastEscalatedAt,
@@ -362,6 +403,12 @@ func (r *AlertRepository) List(ctx context.Context, filter *domain.AlertFilter)
 }
 
 func (r *AlertRepository) Acknowledge(ctx context.Context, id, orgID, userID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE alerts
 		SET
@@ -373,7 +420,7 @@ func (r *AlertRepository) Acknowledge(ctx context.Context, id, orgID, userID uui
 	`
 
 	var updatedAt time.Time
-	err := r.db.QueryRowContext(
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		id,
@@ -390,10 +437,104 @@ func (r *AlertRepository) Acknowledge(ctx context.Context, id, orgID, userID uui
 		return fmt.Errorf("failed to acknowledge alert: %w", err)
 	}
 
+	if err := haltEscalation(ctx, tx, id, domain.EscalationEventAcknowledged); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Unacknowledge returns an acknowledged alert to open. If escalation is given
+// it replaces any previous escalation and the note is recorded alongside.
+func (r *AlertRepository) Unacknowledge(ctx context.Context, id, orgID uuid.UUID, escalation *domain.AlertEscalationEvent, note *domain.AlertNote) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE alerts
+		SET
+			status = $2,
+			acknowledged_by = NULL,
+			acknowledged_at = NULL,
+			escalation_level = CASE WHEN $4 THEN 0 ELSE escalation_level END,
+			last_escalated_at = CASE WHEN $4 THEN NULL ELSE last_escalated_at END
+		WHERE id = $1 AND organization_id = $3 AND status = 'acknowledged'
+		RETURNING updated_at
+	`
+
+	var updatedAt time.Time
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		id,
+		domain.AlertStatusOpen.String(),
+		orgID,
+		escalation != nil,
+	).Scan(&updatedAt)
+
+	if err == sql.ErrNoRows {
+		return domain.ErrAlertNotAcked
+	}
+	if err != nil {
+		return fmt.Errorf("failed to unacknowledge alert: %w", err)
+	}
+
+	if escalation != nil {
+		if err := haltEscalation(ctx, tx, id, domain.EscalationEventStopped); err != nil {
+			return err
+		}
+		if err := insertEscalationEvent(ctx, tx, escalation); err != nil {
+			return err
+		}
+	}
+
+	if note != nil {
+		err = tx.QueryRowContext(ctx,
+			`INSERT INTO alert_notes (id, alert_id, user_id, note) VALUES ($1, $2, $3, $4) RETURNING created_at`,
+			note.ID, note.AlertID, note.UserID, note.Note,
+		).Scan(&note.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to add note: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// haltEscalation ends the alert's running escalation so the worker stops
+// paging for it.
+func haltEscalation(ctx context.Context, tx *sql.Tx, alertID uuid.UUID, eventType domain.EscalationEventType) error {
+	query := `
+		UPDATE alert_escalation_events
+		SET event_type = $2, next_escalation_at = NULL
+		WHERE alert_id = $1 AND event_type = 'triggered'
+	`
+
+	if _, err := tx.ExecContext(ctx, query, alertID, eventType.String()); err != nil {
+		return fmt.Errorf("failed to halt escalation: %w", err)
+	}
+
 	return nil
 }
 
 func (r *AlertRepository) Close(ctx context.Context, id, orgID, userID uuid.UUID, reason string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE alerts
 		SET
@@ -406,7 +547,7 @@ func (r *AlertRepository) Close(ctx context.Context, id, orgID, userID uuid.UUID
 	`
 
 	var updatedAt time.Time
-	err := r.db.QueryRowContext(
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		id,
@@ -424,16 +565,26 @@ func (r *AlertRepository) Close(ctx context.Context, id, orgID, userID uuid.UUID
 		return fmt.Errorf("failed to close alert: %w", err)
 	}
 
+	if err := haltEscalation(ctx, tx, id, domain.EscalationEventStopped); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
-func (r *AlertRepository) Snooze(ctx context.Context, id, orgID uuid.UUID, until time.Time) error {
+func (r *AlertRepository) Snooze(ctx context.Context, id, orgID, userID uuid.UUID, until time.Time, reason *string) error {
 	query := `
 		UPDATE alerts
 		SET
 			status = $2,
-			snoozed_until = $3
-		WHERE id = $1 AND organization_id = $4 AND status != 'closed'
+			snoozed_until = $3,
+			snoozed_by = $4,
+			snooze_reason = $5
+		WHERE id = $1 AND organization_id = $6 AND status != 'closed'
 		RETURNING updated_at
 	`
 
@@ -444,6 +595,8 @@ func (r *AlertRepository) Snooze(ctx context.Context, id, orgID uuid.UUID, until
 		id,
 		domain.AlertStatusSnoozed.String(),
 		until,
+		userID,
+		reason,
 		orgID,
 	).Scan(&updatedAt)
 
@@ -457,23 +610,131 @@ func (r *AlertRepository) Snooze(ctx context.Context, id, orgID uuid.UUID, until
 	return nil
 }
 
-func (r *AlertRepository) Assign(ctx context.Context, id, orgID uuid.UUID, userID, teamID *uuid.UUID) error {
+// ReopenExpiredSnoozes reopens up to limit snoozed alerts whose snooze ended
+// at or before now and returns them. The snoozer and reason are kept on the
+// alert as a record of the last snooze. Rows locked by a concurrent caller
+// are skipped.
+func (r *AlertRepository) ReopenExpiredSnoozes(ctx context.Context, now time.Time, limit int) ([]*domain.Alert, error) {
+	query := `
+		UPDATE alerts
+		SET status = $1, snoozed_until = NULL
+		WHERE id IN (
+			SELECT id FROM alerts
+			WHERE status = $2 AND snoozed_until <= $3
+			ORDER BY snoozed_until
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + alertColumns
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		query,
+		domain.AlertStatusOpen.String(),
+		domain.AlertStatusSnoozed.String(),
+		now,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reopen snoozed alerts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAlertRows(rows)
+}
+
+// UpdateTags adds and removes tags on the given alerts in one transaction and
+// returns the alerts. Alerts whose tags don't change aren't written. Nothing
+// is changed if any alert is missing from the organization or would end up
+// with invalid tags.
+func (r *AlertRepository) UpdateTags(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID, add, remove []string) ([]*domain.Alert, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+alertColumns+`
+		FROM alerts
+		WHERE organization_id = $1 AND id = ANY($2)
+		ORDER BY created_at DESC
+		FOR UPDATE
+	`, orgID, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock alerts: %w", err)
+	}
+	alerts, err := scanAlertRows(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	if len(alerts) != len(ids) {
+		return nil, domain.ErrAlertNotFound
+	}
+
+	for _, alert := range alerts {
+		tags, err := domain.ApplyTagChanges(alert.Tags, add, remove)
+		if err != nil {
+			return nil, fmt.Errorf("alert %s: %w", alert.ID, err)
+		}
+		if slices.Equal(tags, alert.Tags) {
+			continue
+		}
+
+		tagsJSON, err := json.Marshal(tags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal tags: %w", err)
+		}
+		err = tx.QueryRowContext(ctx, `
+			UPDATE alerts SET tags = $2
+			WHERE id = $1
+			RETURNING updated_at
+		`, alert.ID, tagsJSON).Scan(&alert.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update tags: %w", err)
+		}
+		alert.Tags = tags
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return alerts, nil
+}
+
+// Assign applies an assignment in one transaction. Switching escalation
+// policy resets the alert's escalation level, stops its running escalation
+// and starts the new one.
+func (r *AlertRepository) Assign(ctx context.Context, id, orgID uuid.UUID, assignment *domain.AlertAssignment) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE alerts
 		SET
-			assigned_to_user_id = $2,
-			assigned_to_team_id = $3
-		WHERE id = $1 AND organization_id = $4
+			assigned_to_user_id = CASE WHEN $2 THEN NULL ELSE COALESCE($3, assigned_to_user_id) END,
+			assigned_to_team_id = CASE WHEN $4 THEN NULL ELSE COALESCE($5, assigned_to_team_id) END,
+			escalation_policy_id = COALESCE($6, escalation_policy_id),
+			escalation_level = CASE WHEN $6::uuid IS NULL THEN escalation_level ELSE 0 END,
+			last_escalated_at = CASE WHEN $6::uuid IS NULL THEN last_escalated_at ELSE NULL END
+		WHERE id = $1 AND organization_id = $7
 		RETURNING updated_at
 	`
 
 	var updatedAt time.Time
-	err := r.db.QueryRowContext(
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		id,
-		userID,
-		teamID,
+		assignment.ClearUser,
+		assignment.UserID,
+		assignment.ClearTeam,
+		assignment.TeamID,
+		assignment.EscalationPolicyID,
 		orgID,
 	).Scan(&updatedAt)
 
@@ -484,6 +745,46 @@ func (r *AlertRepository) Assign(ctx context.Context, id, orgID uuid.UUID, userI
 		return fmt.Errorf("failed to assign alert: %w", err)
 	}
 
+	if assignment.EscalationPolicyID != nil {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE alert_escalation_events
+			SET event_type = $2, next_escalation_at = NULL
+			WHERE alert_id = $1 AND event_type = $3
+		`, id, domain.EscalationEventStopped.String(), domain.EscalationEventTriggered.String())
+		if err != nil {
+			return fmt.Errorf("failed to stop escalation: %w", err)
+		}
+	}
+
+	if event := assignment.Escalation; event != nil {
+		if err := insertEscalationEvent(ctx, tx, event); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// insertEscalationEvent starts a new escalation within the transaction
+func insertEscalationEvent(ctx context.Context, tx *sql.Tx, event *domain.AlertEscalationEvent) error {
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO alert_escalation_events (id, alert_id, policy_id, rule_id, event_type, current_level, repeat_count, next_escalation_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at
+	`,
+		event.ID,
+		event.AlertID,
+		event.PolicyID,
+		event.RuleID,
+		event.EventType.String(),
+		event.CurrentLevel,
+		event.RepeatCount,
+		event.NextEscalationAt,
+	).Scan(&event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to start escalation: %w", err)
+	}
+
 	return nil
 }
 
@@ -496,7 +797,8 @@ func (r *AlertRepository) FindByDedupKey(ctx context.Context, orgID uuid.UUID, d
 			assigned_to_user_id, assigned_to_team_id,
 			acknowledged_by, acknowledged_at,
 			closed_by, closed_at, close_reason,
-			snoozed_until,
+			snoozed_until, snoozed_by, snooze_reason,
+			maintenance_window_id,
 			escalation_policy_id, escalation_level, last_escalated_at,
 			dedup_key, dedup_count, first_occurrence_at, last_occurrence_at,
 			created_at, updated_at
@@ -528,6 +830,9 @@ func (r *AlertRepository) FindByDedupKey(ctx context.Context, orgID uuid.UUID, d
 		&alert.ClosedAt,
 		&alert.CloseReason,
 		&alert.SnoozedUntil,
+		&alert.SnoozedBy,
+		&alert.SnoozeReason,
+		&alert.MaintenanceWindowID,
 		&alert.EscalationPolicyID,
 		&alert.EscalationLevel,
 		&alert.LastEscalatedAt,
@@ -580,3 +885,451 @@ func (r *AlertRepository) IncrementDedupCount(ctx context.Context, id uuid.UUID)
 
 	return nil
 }
+
+// AddNote adds an investigation note to an alert
+func (r *AlertRepository) AddNote(ctx context.Context, note *domain.AlertNote) error {
+	query := `
+		INSERT INTO alert_notes (id, alert_id, user_id, note)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, note.ID, note.AlertID, note.UserID, note.Note).Scan(&note.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add alert note: %w", err)
+	}
+
+	return nil
+}
+
+// ListNotes lists the notes on an alert oldest first, with author details
+func (r *AlertRepository) ListNotes(ctx context.Context, alertID, orgID uuid.UUID) ([]*domain.AlertNoteWithUser, error) {
+	query := `
+		SELECT
+			n.id, n.alert_id, n.user_id, n.note, n.created_at,
+			u.id, u.email, u.username, u.full_name, u.created_at, u.updated_at
+		FROM alert_notes n
+		LEFT JOIN users u ON n.user_id = u.id
+		WHERE n.alert_id = $1 AND EXISTS (SELECT 1 FROM alerts WHERE id = $1 AND organization_id = $2)
+		ORDER BY n.created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, alertID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert notes: %w", err)
+	}
+	defer rows.Close()
+
+	notes := []*domain.AlertNoteWithUser{}
+	for rows.Next() {
+		var note domain.AlertNoteWithUser
+		var userID sql.NullString
+		var userEmail sql.NullString
+		var userUsername sql.NullString
+		var userFullName sql.NullString
+		var userCreatedAt sql.NullTime
+		var userUpdatedAt sql.NullTime
+
+		err := rows.Scan(
+			&note.ID, &note.AlertID, &note.UserID, &note.Note, &note.CreatedAt,
+			&userID, &userEmail, &userUsername, &userFullName,
+			&userCreatedAt, &userUpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alert note: %w", err)
+		}
+
+		// Attach the author if they still exist
+		if userID.Valid {
+			note.User = &domain.User{
+				Email:     userEmail.String,
+				Username:  userUsername.String,
+				CreatedAt: userCreatedAt.Time,
+				UpdatedAt: userUpdatedAt.Time,
+			}
+			if userFullName.Valid {
+				note.User.FullName = &userFullName.String
+			}
+			if id, err := uuid.Parse(userID.String); err == nil {
+				note.User.ID = id
+			}
+		}
+
+		notes = append(notes, &note)
+	}
+
+	return notes, rows.Err()
+}
+
+// GetStaleOpenAlerts returns up to limit open alerts of the organization with
+// one of the given priorities that have not been updated or noted on since
+// untouchedSince, oldest first.
+func (r *AlertRepository) GetStaleOpenAlerts(ctx context.Context, orgID uuid.UUID, priorities []domain.AlertPriority, untouchedSince time.Time, limit int) ([]*domain.Alert, error) {
+	query := `
+		SELECT ` + alertColumns + `
+		FROM alerts
+		WHERE organization_id = $1
+			AND status = 'open'
+			AND priority = ANY($2)
+			AND updated_at < $3
+			AND NOT EXISTS (
+				SELECT 1 FROM alert_notes
+				WHERE alert_notes.alert_id = alerts.id AND alert_notes.created_at >= $3
+			)
+		ORDER BY updated_at ASC
+		LIMIT $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID, pq.Array(priorityNames(priorities)), untouchedSince, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stale alerts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAlertRows(rows)
+}
+
+// ListSuggestionCandidates returns up to limit alerts of the organization that
+// aren't closed, were created between since and until, are not yet linked to
+// the incident and carry one of the tags or come from one of the sources,
+// newest first.
+func (r *AlertRepository) ListSuggestionCandidates(ctx context.Context, orgID, incidentID uuid.UUID, since, until time.Time, tags, sources []string, limit int) ([]*domain.Alert, error) {
+	query := `
+		SELECT ` + alertColumns + `
+		FROM alerts
+		WHERE organization_id = $1
+			AND status <> 'closed'
+			AND created_at BETWEEN $3 AND $4
+			AND (tags ?| $5 OR source = ANY($6))
+			AND NOT EXISTS (
+				SELECT 1 FROM incident_alerts
+				WHERE incident_alerts.alert_id = alerts.id AND incident_alerts.incident_id = $2
+			)
+		ORDER BY created_at DESC
+		LIMIT $7
+	`
+
+	rows, err := r.db.Reader().QueryContext(ctx, query, orgID, incidentID, since, until, pq.Array(tags), pq.Array(sources), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list suggestion candidates: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAlertRows(rows)
+}
+
+// AutoClose closes an open alert without a closing user, provided it has not
+// been updated since untouchedSince. It reports whether the alert was closed.
+func (r *AlertRepository) AutoClose(ctx context.Context, id, orgID uuid.UUID, reason string, untouchedSince time.Time) (bool, error) {
+	query := `
+		UPDATE alerts
+		SET
+			status = $3,
+			closed_at = $4,
+			close_reason = $5
+		WHERE id = $1 AND organization_id = $2 AND status = 'open' AND updated_at < $6
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		id,
+		orgID,
+		domain.AlertStatusClosed.String(),
+		time.Now(),
+		reason,
+		untouchedSince,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to auto-close alert: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// purgeableAlerts matches the closed alerts of organization $1 that a retention
+// policy removes: closed before $2, not of a priority in $3 and not linked to
+// an incident.
+const purgeableAlerts = `
+		organization_id = $1
+			AND status = 'closed'
+			AND closed_at < $2
+			AND priority <> ALL($3)
+			AND NOT EXISTS (SELECT 1 FROM incident_alerts WHERE incident_alerts.alert_id = alerts.id)`
+
+// PurgeClosedAlerts deletes up to limit alerts matched by purgeableAlerts,
+// oldest first, copying them to alerts_archive in the same statement when
+// archive is set. It returns the number of alerts removed.
+func (r *AlertRepository) PurgeClosedAlerts(ctx context.Context, orgID uuid.UUID, closedBefore time.Time, exempt []domain.AlertPriority, archive bool, limit int) (int, error) {
+	purge := `
+		DELETE FROM alerts
+		WHERE id IN (
+			SELECT id FROM alerts
+			WHERE ` + purgeableAlerts + `
+			ORDER BY closed_at ASC
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)`
+
+	var query string
+	if archive {
+		query = `
+		WITH purged AS (` + purge + `
+			RETURNING ` + alertColumns + `
+		)
+		INSERT INTO alerts_archive (` + alertColumns + `)
+		SELECT ` + alertColumns + ` FROM purged
+	`
+	} else {
+		query = purge
+	}
+
+	result, err := r.db.ExecContext(ctx, query, orgID, closedBefore, pq.Array(priorityNames(exempt)), limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge closed alerts: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rows), nil
+}
+
+// CountPurgeableAlerts counts the alerts PurgeClosedAlerts would remove
+func (r *AlertRepository) CountPurgeableAlerts(ctx context.Context, orgID uuid.UUID, closedBefore time.Time, exempt []domain.AlertPriority) (int, error) {
+	query := `SELECT COUNT(*) FROM alerts WHERE ` + purgeableAlerts
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, orgID, closedBefore, pq.Array(priorityNames(exempt))).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count purgeable alerts: %w", err)
+	}
+
+	return count, nil
+}
+
+func priorityNames(priorities []domain.AlertPriority) []string {
+	names := make([]string, len(priorities))
+	for i, priority := range priorities {
+		names[i] = priority.String()
+	}
+	return names
+}
+
+// alertColumns lists the alert columns in the order scanAlertRows reads them.
+const alertColumns = `
+			id, organization_id, source, source_id, priority, status,
+			message, description, tags, custom_fields,
+			assigned_to_user_id, assigned_to_team_id,
+			acknowledged_by, acknowledged_at,
+			closed_by, closed_at, close_reason,
+			snoozed_until, snoozed_by, snooze_reason,
+			maintenance_window_id,
+			escalation_policy_id, escalation_level, last_escalated_at,
+			dedup_key, dedup_count, first_occurrence_at, last_occurrence_at,
+			created_at, updated_at`
+
+// scanAlertRows reads alerts selected with alertColumns.
+func scanAlertRows(rows *sql.Rows) ([]*domain.Alert, error) {
+	var alerts []*domain.Alert
+	for rows.Next() {
+		alert, err := scanAlert(rows)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, rows.Err()
+}
+
+// scanAlert reads one alert selected with alertColumns, followed by any extra
+// columns into extra
+func scanAlert(rows *sql.Rows, extra ...interface{}) (*domain.Alert, error) {
+	var alert domain.Alert
+	var tagsJSON, customFieldsJSON []byte
+
+	dest := []interface{}{
+		&alert.ID,
+		&alert.OrganizationID,
+		&alert.Source,
+		&alert.SourceID,
+		&alert.Priority,
+		&alert.Status,
+		&alert.Message,
+		&alert.Description,
+		&tagsJSON,
+		&customFieldsJSON,
+		&alert.AssignedToUserID,
+		&alert.AssignedToTeamID,
+		&alert.AcknowledgedBy,
+		&alert.AcknowledgedAt,
+		&alert.ClosedBy,
+		&alert.ClosedAt,
+		&alert.CloseReason,
+		&alert.SnoozedUntil,
+		&alert.SnoozedBy,
+		&alert.SnoozeReason,
+		&alert.MaintenanceWindowID,
+		&alert.EscalationPolicyID,
+		&alert.EscalationLevel,
+		&alert.LastEscalatedAt,
+		&alert.DedupKey,
+		&alert.DedupCount,
+		&alert.FirstOccurrenceAt,
+		&alert.LastOccurrenceAt,
+		&alert.CreatedAt,
+		&alert.UpdatedAt,
+	}
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return nil, fmt.Errorf("failed to scan alert: %w", err)
+	}
+
+	if err := json.Unmarshal(tagsJSON, &alert.Tags); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
+	}
+
+	if err := json.Unmarshal(customFieldsJSON, &alert.CustomFields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal custom_fields: %w", err)
+	}
+
+	return &alert, nil
+}
+
+// GroupOpenAlerts clusters the organization's open alerts by the grouping
+// key, largest groups first, and picks each group's representatives: its
+// highest-priority alerts, newest first.
+func (r *AlertRepository) GroupOpenAlerts(ctx context.Context, grouping *domain.AlertGrouping) ([]*domain.AlertGroup, error) {
+	args := []interface{}{grouping.OrganizationID}
+	var key string
+	switch grouping.By {
+	case domain.AlertGroupBySource:
+		key = "source"
+	case domain.AlertGroupByTag:
+		// The value of the first key:value tag with the key
+		args = append(args, grouping.TagKey+":")
+		key = `COALESCE((
+			SELECT substr(tag, length($2) + 1) FROM jsonb_array_elements_text(tags) AS tag
+			WHERE left(tag, length($2)) = $2 ORDER BY tag LIMIT 1
+		), '')`
+	case domain.AlertGroupByFingerprint:
+		if grouping.PrefixLength > 0 {
+			args = append(args, grouping.PrefixLength)
+			key = "COALESCE(left(dedup_key, $2), '')"
+		} else {
+			key = "COALESCE(dedup_key, '')"
+		}
+	default:
+		return nil, fmt.Errorf("unknown alert grouping %q", grouping.By)
+	}
+
+	keyed := fmt.Sprintf(`
+		SELECT alerts.*, %s AS group_key
+		FROM alerts
+		WHERE organization_id = $1 AND status = 'open'
+	`, key)
+
+	groupQuery := fmt.Sprintf(`
+		SELECT group_key, COUNT(*), MIN(priority), MIN(created_at), MAX(created_at)
+		FROM (%s) keyed
+		GROUP BY group_key
+		ORDER BY COUNT(*) DESC, MIN(priority) ASC, MAX(created_at) DESC
+		LIMIT $%d
+	`, keyed, len(args)+1)
+
+	rows, err := r.db.Reader().QueryContext(ctx, groupQuery, append(args, grouping.Limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to group alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []*domain.AlertGroup
+	byKey := make(map[string]*domain.AlertGroup)
+	for rows.Next() {
+		var group domain.AlertGroup
+		if err := rows.Scan(&group.Key, &group.Count, &group.HighestPriority, &group.FirstAlertAt, &group.LastAlertAt); err != nil {
+			return nil, fmt.Errorf("failed to scan alert group: %w", err)
+		}
+		groups = append(groups, &group)
+		byKey[group.Key] = &group
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to group alerts: %w", err)
+	}
+	if len(groups) == 0 {
+		return groups, nil
+	}
+
+	keys := make([]string, 0, len(groups))
+	for _, group := range groups {
+		keys = append(keys, group.Key)
+	}
+	representativeQuery := fmt.Sprintf(`
+		SELECT %s, group_key
+		FROM (
+			SELECT keyed.*,
+				ROW_NUMBER() OVER (PARTITION BY group_key ORDER BY priority ASC, created_at DESC) AS group_rank
+			FROM (%s) keyed
+		) ranked
+		WHERE group_rank <= $%d AND group_key = ANY($%d)
+		ORDER BY group_key, group_rank
+	`, alertColumns, keyed, len(args)+1, len(args)+2)
+
+	rows, err = r.db.Reader().QueryContext(ctx, representativeQuery, append(args, domain.AlertGroupRepresentatives, pq.Array(keys))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list representative alerts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var groupKey string
+		alert, err := scanAlert(rows, &groupKey)
+		if err != nil {
+			return nil, err
+		}
+		if group, ok := byKey[groupKey]; ok {
+			group.Representatives = append(group.Representatives, alert)
+		}
+	}
+
+	return groups, rows.Err()
+}
+
+// CloseFromSource closes an alert that its monitoring source reported as
+// resolved. There is no closing user. It reports whether the alert was closed.
+func (r *AlertRepository) CloseFromSource(ctx context.Context, id, orgID uuid.UUID, reason string) (bool, error) {
+	query := `
+		UPDATE alerts
+		SET
+			status = $3,
+			closed_at = $4,
+			close_reason = $5
+		WHERE id = $1 AND organization_id = $2 AND status != 'closed'
+	`
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		id,
+		orgID,
+		domain.AlertStatusClosed.String(),
+		time.Now(),
+		reason,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to close alert: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
@@ -0,0 +1,234 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+type AlertSourceRepository struct {
+	db *DB
+}
+
+func NewAlertSourceRepository(db *DB) *AlertSourceRepository {
+	return &AlertSourceRepository{db: db}
+}
+
+func (r *AlertSourceRepository) Create(ctx context.Context, source *domain.RegisteredSource) error {
+	query := `
+		INSERT INTO alert_sources (id, organization_id, name, aliases)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		source.ID,
+		source.OrganizationID,
+		source.Name,
+		pq.StringArray(source.Aliases),
+	).Scan(&source.CreatedAt, &source.UpdatedAt)
+
+	if isUniqueViolation(err) {
+		return domain.ErrAlertSourceConflict
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create alert source: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AlertSourceRepository) GetByID(ctx context.Context, id, orgID uuid.UUID) (*domain.RegisteredSource, error) {
+	query := `
+		SELECT id, organization_id, name, aliases, created_at, updated_at
+		FROM alert_sources
+		WHERE id = $1 AND organization_id = $2
+	`
+
+	return r.getSource(ctx, query, id, orgID)
+}
+
+// FindByKey returns the source whose name or one of whose aliases matches
+// key, which must already be normalized
+func (r *AlertSourceRepository) FindByKey(ctx context.Context, orgID uuid.UUID, key string) (*domain.RegisteredSource, error) {
+	query := `
+		SELECT id, organization_id, name, aliases, created_at, updated_at
+		FROM alert_sources
+		WHERE organization_id = $1 AND (LOWER(name) = $2 OR $2 = ANY(aliases))
+		ORDER BY created_at ASC
+		LIMIT 1
+	`
+
+	return r.getSource(ctx, query, orgID, key)
+}
+
+func (r *AlertSourceRepository) getSource(ctx context.Context, query string, args ...interface{}) (*domain.RegisteredSource, error) {
+	var source domain.RegisteredSource
+	var aliases pq.StringArray
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&source.ID,
+		&source.OrganizationID,
+		&source.Name,
+		&aliases,
+		&source.CreatedAt,
+		&source.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrAlertSourceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert source: %w", err)
+	}
+
+	source.Aliases = []string(aliases)
+	return &source, nil
+}
+
+func (r *AlertSourceRepository) Update(ctx context.Context, source *domain.RegisteredSource) error {
+	query := `
+		UPDATE alert_sources
+		SET name = $3, aliases = $4
+		WHERE id = $1 AND organization_id = $2
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		source.ID,
+		source.OrganizationID,
+		source.Name,
+		pq.StringArray(source.Aliases),
+	).Scan(&source.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return domain.ErrAlertSourceNotFound
+	}
+	if isUniqueViolation(err) {
+		return domain.ErrAlertSourceConflict
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update alert source: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AlertSourceRepository) Delete(ctx context.Context, id, orgID uuid.UUID) error {
+	query := `DELETE FROM alert_sources WHERE id = $1 AND organization_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to delete alert source: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.ErrAlertSourceNotFound
+	}
+
+	return nil
+}
+
+// List returns the organization's sources ordered by name
+func (r *AlertSourceRepository) List(ctx context.Context, orgID uuid.UUID) ([]*domain.RegisteredSource, error) {
+	query := `
+		SELECT id, organization_id, name, aliases, created_at, updated_at
+		FROM alert_sources
+		WHERE organization_id = $1
+		ORDER BY LOWER(name) ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert sources: %w", err)
+	}
+	defer rows.Close()
+
+	sources := []*domain.RegisteredSource{}
+	for rows.Next() {
+		var source domain.RegisteredSource
+		var aliases pq.StringArray
+		err := rows.Scan(
+			&source.ID,
+			&source.OrganizationID,
+			&source.Name,
+			&aliases,
+			&source.CreatedAt,
+			&source.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alert source: %w", err)
+		}
+		source.Aliases = []string(aliases)
+		sources = append(sources, &source)
+	}
+
+	return sources, rows.Err()
+}
+
+// ListWithCounts returns the organization's sources ordered by name, each
+// with the number of alerts whose source matches its name or an alias.
+// Alerts created before an alias was registered are counted too.
+func (r *AlertSourceRepository) ListWithCounts(ctx context.Context, orgID uuid.UUID) ([]*domain.RegisteredSourceWithCount, error) {
+	query := `
+		SELECT s.id, s.organization_id, s.name, s.aliases, s.created_at, s.updated_at,
+			(
+				SELECT COUNT(*)
+				FROM alerts a
+				WHERE a.organization_id = s.organization_id
+				  AND (LOWER(a.source) = LOWER(s.name) OR LOWER(a.source) = ANY(s.aliases))
+			) AS alert_count
+		FROM alert_sources s
+		WHERE s.organization_id = $1
+		ORDER BY LOWER(s.name) ASC
+	`
+
+	rows, err := r.db.Reader().QueryContext(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert sources: %w", err)
+	}
+	defer rows.Close()
+
+	sources := []*domain.RegisteredSourceWithCount{}
+	for rows.Next() {
+		var source domain.RegisteredSourceWithCount
+		var aliases pq.StringArray
+		err := rows.Scan(
+			&source.ID,
+			&source.OrganizationID,
+			&source.Name,
+			&aliases,
+			&source.CreatedAt,
+			&source.UpdatedAt,
+			&source.AlertCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan alert source: %w", err)
+		}
+		source.Aliases = []string(aliases)
+		sources = append(sources, &source)
+	}
+
+	return sources, rows.Err()
+}
+
+// isUniqueViolation reports whether err is a Postgres unique constraint
+// violation
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
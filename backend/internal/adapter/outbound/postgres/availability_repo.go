@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+type UserAvailabilityRepository struct {
+	db *DB
+}
+
+func NewUserAvailabilityRepository(db *DB) *UserAvailabilityRepository {
+	return &UserAvailabilityRepository{db: db}
+}
+
+func (r *UserAvailabilityRepository) Create(ctx context.Context, period *domain.UserUnavailability) error {
+	query := `
+		INSERT INTO user_unavailability (id, user_id, start_time, end_time, reason)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		period.ID,
+		period.UserID,
+		period.StartTime,
+		period.EndTime,
+		period.Reason,
+	).Scan(&period.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create unavailability: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser returns the user's unavailability periods that end after
+// endingAfter, earliest first
+func (r *UserAvailabilityRepository) ListByUser(ctx context.Context, userID uuid.UUID, endingAfter time.Time) ([]*domain.UserUnavailability, error) {
+	query := `
+		SELECT id, user_id, start_time, end_time, reason, created_at
+		FROM user_unavailability
+		WHERE user_id = $1 AND end_time > $2
+		ORDER BY start_time
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, endingAfter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unavailability: %w", err)
+	}
+	defer rows.Close()
+
+	periods := []*domain.UserUnavailability{}
+	for rows.Next() {
+		var period domain.UserUnavailability
+		if err := rows.Scan(
+			&period.ID,
+			&period.UserID,
+			&period.StartTime,
+			&period.EndTime,
+			&period.Reason,
+			&period.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan unavailability: %w", err)
+		}
+		periods = append(periods, &period)
+	}
+
+	return periods, rows.Err()
+}
+
+func (r *UserAvailabilityRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM user_unavailability WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete unavailability: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *UserAvailabilityRepository) UnavailableAt(ctx context.Context, userIDs []uuid.UUID, at time.Time) (map[uuid.UUID]*domain.UserUnavailability, error) {
+	unavailable := make(map[uuid.UUID]*domain.UserUnavailability)
+	if len(userIDs) == 0 {
+		return unavailable, nil
+	}
+
+	ids := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
+	}
+
+	query := `
+		SELECT id, user_id, start_time, end_time, reason, created_at
+		FROM user_unavailability
+		WHERE user_id = ANY($1::uuid[]) AND start_time <= $2 AND end_time > $2
+		ORDER BY end_time DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids), at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check unavailability: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var period domain.UserUnavailability
+		if err := rows.Scan(
+			&period.ID,
+			&period.UserID,
+			&period.StartTime,
+			&period.EndTime,
+			&period.Reason,
+			&period.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan unavailability: %w", err)
+		}
+		// Keep the period ending last when several overlap
+		if _, ok := unavailable[period.UserID]; !ok {
+			unavailable[period.UserID] = &period
+		}
+	}
+
+	return unavailable, rows.Err()
+}
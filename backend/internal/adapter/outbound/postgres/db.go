@@ -2,33 +2,94 @@ package postgres
 
 import (
 	"fmt"
+	"strings"
+	"unicode"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
+
+	"github.com/nmn3m/pulsar/backend/internal/config"
 )
 
+func init() {
+	// Domain structs carry no db tags; map fields like OrganizationID to
+	// organization_id so sqlx can scan SELECT * results into them.
+	sqlx.NameMapper = snakeCase
+}
+
+// snakeCase converts a Go field name to its snake_case column name, keeping
+// acronyms together (InvitedByID -> invited_by_id, DNDEnabled -> dnd_enabled).
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// DB is the primary database, plus an optional read replica that serves
+// read-only list and metrics queries
 type DB struct {
 	*sqlx.DB
+	Replica *sqlx.DB
 }
 
-func NewDB(databaseURL string) (*DB, error) {
-	db, err := sqlx.Connect("postgres", databaseURL)
+// NewDB connects to the database, and to the read replica when one is
+// configured, applying the pool settings in cfg to both. Zero pool settings
+// follow database/sql: no limit on open connections, no idle connections
+// kept, and connections never expire.
+func NewDB(cfg *config.DatabaseConfig) (*DB, error) {
+	db, err := connect(cfg.URL, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
+	if cfg.ReplicaURL == "" {
+		return &DB{DB: db}, nil
+	}
 
-	// Set connection pool settings
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
+	replica, err := connect(cfg.ReplicaURL, cfg)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to read replica: %w", err)
+	}
+	return &DB{DB: db, Replica: replica}, nil
+}
 
-	// Verify connection
-	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+func connect(databaseURL string, cfg *config.DatabaseConfig) (*sqlx.DB, error) {
+	db, err := sqlx.Connect("postgres", databaseURL)
+	if err != nil {
+		return nil, err
 	}
 
-	return &DB{db}, nil
+	// Set connection pool settings
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	return db, nil
+}
+
+// Reader returns the handle for read-only queries that can tolerate
+// replication lag: the replica when there is one, otherwise the primary
+func (db *DB) Reader() *sqlx.DB {
+	if db.Replica != nil {
+		return db.Replica
+	}
+	return db.DB
 }
 
 func (db *DB) Close() error {
+	if db.Replica != nil {
+		db.Replica.Close()
+	}
 	return db.DB.Close()
 }
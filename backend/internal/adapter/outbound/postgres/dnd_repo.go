@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -150,3 +151,84 @@ func (r *DNDSettingsRepository) Upsert(ctx context.Context, settings *domain.Use
 
 	return nil
 }
+
+// AppendOverride atomically appends an override to the user's settings, so
+// concurrent additions don't overwrite each other. Users without settings get
+// the given defaults with the override as their only entry.
+func (r *DNDSettingsRepository) AppendOverride(ctx context.Context, defaults *domain.UserDNDSettings, override domain.DNDOverride) (*domain.UserDNDSettings, error) {
+	overrideJSON, err := json.Marshal([]domain.DNDOverride{override})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal override: %w", err)
+	}
+
+	query := `
+		INSERT INTO user_dnd_settings (id, user_id, enabled, schedule, overrides, allow_p1_override)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE SET
+			overrides = CASE
+				WHEN jsonb_typeof(user_dnd_settings.overrides) = 'array' THEN user_dnd_settings.overrides
+				ELSE '[]'::jsonb
+			END || EXCLUDED.overrides,
+			updated_at = NOW()
+		RETURNING id, user_id, enabled, schedule, overrides, allow_p1_override, created_at, updated_at
+	`
+
+	var settings domain.UserDNDSettings
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		defaults.ID,
+		defaults.UserID,
+		defaults.Enabled,
+		defaults.Schedule,
+		overrideJSON,
+		defaults.AllowP1Override,
+	).Scan(
+		&settings.ID,
+		&settings.UserID,
+		&settings.Enabled,
+		&settings.Schedule,
+		&settings.Overrides,
+		&settings.AllowP1Override,
+		&settings.CreatedAt,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to append DND override: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// RemoveOverride atomically removes the override at index. It returns
+// domain.ErrNotFound if the user has no override at that index.
+func (r *DNDSettingsRepository) RemoveOverride(ctx context.Context, userID uuid.UUID, index int) (*domain.UserDNDSettings, error) {
+	query := `
+		UPDATE user_dnd_settings
+		SET overrides = overrides - $2::int, updated_at = NOW()
+		WHERE user_id = $1
+			AND jsonb_typeof(overrides) = 'array'
+			AND jsonb_array_length(overrides) > $2
+		RETURNING id, user_id, enabled, schedule, overrides, allow_p1_override, created_at, updated_at
+	`
+
+	var settings domain.UserDNDSettings
+	err := r.db.QueryRowContext(ctx, query, userID, index).Scan(
+		&settings.ID,
+		&settings.UserID,
+		&settings.Enabled,
+		&settings.Schedule,
+		&settings.Overrides,
+		&settings.AllowP1Override,
+		&settings.CreatedAt,
+		&settings.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove DND override: %w", err)
+	}
+
+	return &settings, nil
+}
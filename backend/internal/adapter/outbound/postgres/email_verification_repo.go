@@ -53,7 +53,8 @@ func (r *EmailVerificationRepository) Create(ctx context.Context, verification *
 
 func (r *EmailVerificationRepository) GetByEmail(ctx context.Context, email string) (*domain.EmailVerification, error) {
 	query := `
-		SELECT id, user_id, email, otp, expires_at, verified, created_at
+		SELECT id, user_id, email, otp, expires_at, verified, failed_attempts,
+		       send_attempts, last_sent_at, last_send_error, created_at
 		FROM email_verifications
 		WHERE email = $1 AND verified = FALSE
 		ORDER BY created_at DESC
@@ -68,6 +69,10 @@ func (r *EmailVerificationRepository) GetByEmail(ctx context.Context, email stri
 		&verification.OTP,
 		&verification.ExpiresAt,
 		&verification.Verified,
+		&verification.FailedAttempts,
+		&verification.SendAttempts,
+		&verification.LastSentAt,
+		&verification.LastSendError,
 		&verification.CreatedAt,
 	)
 
@@ -83,7 +88,8 @@ func (r *EmailVerificationRepository) GetByEmail(ctx context.Context, email stri
 
 func (r *EmailVerificationRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.EmailVerification, error) {
 	query := `
-		SELECT id, user_id, email, otp, expires_at, verified, created_at
+		SELECT id, user_id, email, otp, expires_at, verified, failed_attempts,
+		       send_attempts, last_sent_at, last_send_error, created_at
 		FROM email_verifications
 		WHERE user_id = $1 AND verified = FALSE
 		ORDER BY created_at DESC
@@ -98,6 +104,10 @@ func (r *EmailVerificationRepository) GetByUserID(ctx context.Context, userID uu
 		&verification.OTP,
 		&verification.ExpiresAt,
 		&verification.Verified,
+		&verification.FailedAttempts,
+		&verification.SendAttempts,
+		&verification.LastSentAt,
+		&verification.LastSendError,
 		&verification.CreatedAt,
 	)
 
@@ -112,7 +122,7 @@ func (r *EmailVerificationRepository) GetByUserID(ctx context.Context, userID uu
 }
 
 func (r *EmailVerificationRepository) MarkVerified(ctx context.Context, id uuid.UUID) error {
-	query := `UPDATE email_verifications SET verified = TRUE WHERE id = $1`
+	query := `UPDATE email_verifications SET verified = TRUE, failed_attempts = 0 WHERE id = $1`
 
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
@@ -131,6 +141,58 @@ func (r *EmailVerificationRepository) MarkVerified(ctx context.Context, id uuid.
 	return nil
 }
 
+// ClaimAttempt counts a verification attempt against a code and returns the
+// new number of attempts. The check and the increment are one statement, so
+// concurrent guesses can't get past maxAttempts; once it is reached
+// domain.ErrOTPLocked is returned and the attempt must be rejected.
+func (r *EmailVerificationRepository) ClaimAttempt(ctx context.Context, id uuid.UUID, maxAttempts int) (int, error) {
+	query := `
+		UPDATE email_verifications
+		SET failed_attempts = failed_attempts + 1
+		WHERE id = $1 AND failed_attempts < $2
+		RETURNING failed_attempts
+	`
+
+	var attempts int
+	err := r.db.QueryRowContext(ctx, query, id, maxAttempts).Scan(&attempts)
+	if err == sql.ErrNoRows {
+		return 0, domain.ErrOTPLocked
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to record attempt: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// RecordSendResult adds the delivery attempts made for a code and stores the
+// outcome. A nil sendErr marks the code as sent and clears any earlier error.
+func (r *EmailVerificationRepository) RecordSendResult(ctx context.Context, id uuid.UUID, attempts int, sendErr *string) error {
+	query := `
+		UPDATE email_verifications
+		SET send_attempts = send_attempts + $2,
+		    last_sent_at = CASE WHEN $3::text IS NULL THEN NOW() ELSE last_sent_at END,
+		    last_send_error = $3
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, attempts, sendErr)
+	if err != nil {
+		return fmt.Errorf("failed to record send result: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("verification not found")
+	}
+
+	return nil
+}
+
 func (r *EmailVerificationRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
 	query := `DELETE FROM email_verifications WHERE user_id = $1`
 
@@ -7,10 +7,15 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 )
 
+// escalationClaimLease is how long a claimed escalation event is hidden from
+// other workers while it is being processed.
+const escalationClaimLease = 5 * time.Minute
+
 type EscalationPolicyRepository struct {
 	db *DB
 }
@@ -23,8 +28,8 @@ func NewEscalationPolicyRepository(db *DB) *EscalationPolicyRepository {
 
 func (r *EscalationPolicyRepository) Create(ctx context.Context, policy *domain.EscalationPolicy) error {
 	query := `
-		INSERT INTO escalation_policies (id, organization_id, name, description, repeat_enabled, repeat_count)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO escalation_policies (id, organization_id, name, description, repeat_enabled, repeat_count, conditions, business_hours)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING created_at, updated_at
 	`
 
@@ -37,6 +42,8 @@ func (r *EscalationPolicyRepository) Create(ctx context.Context, policy *domain.
 		policy.Description,
 		policy.RepeatEnabled,
 		policy.RepeatCount,
+		policyConditions(policy),
+		policyBusinessHours(policy),
 	).Scan(&policy.CreatedAt, &policy.UpdatedAt)
 
 	if err != nil {
@@ -48,7 +55,7 @@ func (r *EscalationPolicyRepository) Create(ctx context.Context, policy *domain.
 
 func (r *EscalationPolicyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.EscalationPolicy, error) {
 	query := `
-		SELECT id, organization_id, name, description, repeat_enabled, repeat_count, created_at, updated_at
+		SELECT id, organization_id, name, description, repeat_enabled, repeat_count, COALESCE(conditions, 'null'::jsonb), COALESCE(business_hours, 'null'::jsonb), created_at, updated_at
 		FROM escalation_policies
 		WHERE id = $1
 	`
@@ -61,6 +68,8 @@ func (r *EscalationPolicyRepository) GetByID(ctx context.Context, id uuid.UUID)
 		&policy.Description,
 		&policy.RepeatEnabled,
 		&policy.RepeatCount,
+		&policy.Conditions,
+		&policy.BusinessHours,
 		&policy.CreatedAt,
 		&policy.UpdatedAt,
 	)
@@ -71,6 +80,12 @@ func (r *EscalationPolicyRepository) GetByID(ctx context.Context, id uuid.UUID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get escalation policy: %w", err)
 	}
+	if string(policy.Conditions) == "null" {
+		policy.Conditions = nil
+	}
+	if string(policy.BusinessHours) == "null" {
+		policy.BusinessHours = nil
+	}
 
 	return &policy, nil
 }
@@ -78,7 +93,7 @@ func (r *EscalationPolicyRepository) GetByID(ctx context.Context, id uuid.UUID)
 func (r *EscalationPolicyRepository) Update(ctx context.Context, policy *domain.EscalationPolicy) error {
 	query := `
 		UPDATE escalation_policies
-		SET name = $2, description = $3, repeat_enabled = $4, repeat_count = $5
+		SET name = $2, description = $3, repeat_enabled = $4, repeat_count = $5, conditions = $6, business_hours = $7
 		WHERE id = $1
 		RETURNING updated_at
 	`
@@ -91,6 +106,8 @@ func (r *EscalationPolicyRepository) Update(ctx context.Context, policy *domain.
 		policy.Description,
 		policy.RepeatEnabled,
 		policy.RepeatCount,
+		policyConditions(policy),
+		policyBusinessHours(policy),
 	).Scan(&policy.UpdatedAt)
 
 	if err != nil {
@@ -122,14 +139,31 @@ func (r *EscalationPolicyRepository) Delete(ctx context.Context, id uuid.UUID) e
 
 func (r *EscalationPolicyRepository) List(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]*domain.EscalationPolicy, error) {
 	query := `
-		SELECT id, organization_id, name, description, repeat_enabled, repeat_count, created_at, updated_at
+		SELECT id, organization_id, name, description, repeat_enabled, repeat_count, COALESCE(conditions, 'null'::jsonb), COALESCE(business_hours, 'null'::jsonb), created_at, updated_at
 		FROM escalation_policies
 		WHERE organization_id = $1
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, orgID, limit, offset)
+	return r.queryPolicies(ctx, query, orgID, limit, offset)
+}
+
+// ListWithConditions returns the organization's policies that declare their
+// own matching conditions, oldest first
+func (r *EscalationPolicyRepository) ListWithConditions(ctx context.Context, orgID uuid.UUID) ([]*domain.EscalationPolicy, error) {
+	query := `
+		SELECT id, organization_id, name, description, repeat_enabled, repeat_count, COALESCE(conditions, 'null'::jsonb), COALESCE(business_hours, 'null'::jsonb), created_at, updated_at
+		FROM escalation_policies
+		WHERE organization_id = $1 AND conditions IS NOT NULL
+		ORDER BY created_at ASC
+	`
+
+	return r.queryPolicies(ctx, query, orgID)
+}
+
+func (r *EscalationPolicyRepository) queryPolicies(ctx context.Context, query string, args ...interface{}) ([]*domain.EscalationPolicy, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list escalation policies: %w", err)
 	}
@@ -145,17 +179,43 @@ func (r *EscalationPolicyRepository) List(ctx context.Context, orgID uuid.UUID,
 			&policy.Description,
 			&policy.RepeatEnabled,
 			&policy.RepeatCount,
+			&policy.Conditions,
+			&policy.BusinessHours,
 			&policy.CreatedAt,
 			&policy.UpdatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan escalation policy: %w", err)
 		}
+		if string(policy.Conditions) == "null" {
+			policy.Conditions = nil
+		}
+		if string(policy.BusinessHours) == "null" {
+			policy.BusinessHours = nil
+		}
 
 		policies = append(policies, &policy)
 	}
 
-	return policies, nil
+	return policies, rows.Err()
+}
+
+// policyConditions returns the policy's conditions as a query argument,
+// using NULL for a policy without conditions
+func policyConditions(policy *domain.EscalationPolicy) interface{} {
+	if len(policy.Conditions) == 0 || string(policy.Conditions) == "null" {
+		return nil
+	}
+	return policy.Conditions
+}
+
+// policyBusinessHours returns the policy's business hours as a query
+// argument, using NULL for a policy without them
+func policyBusinessHours(policy *domain.EscalationPolicy) interface{} {
+	if len(policy.BusinessHours) == 0 || string(policy.BusinessHours) == "null" {
+		return nil
+	}
+	return policy.BusinessHours
 }
 
 func (r *EscalationPolicyRepository) GetWithRules(ctx context.Context, id uuid.UUID) (*domain.EscalationPolicyWithRules, error) {
@@ -193,8 +253,8 @@ func (r *EscalationPolicyRepository) GetWithRules(ctx context.Context, id uuid.U
 
 func (r *EscalationPolicyRepository) CreateRule(ctx context.Context, rule *domain.EscalationRule) error {
 	query := `
-		INSERT INTO escalation_rules (id, policy_id, position, escalation_delay)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO escalation_rules (id, policy_id, position, escalation_delay, delay_seconds, hours, min_priority, only_if_unassigned)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING created_at, updated_at
 	`
 
@@ -205,6 +265,10 @@ func (r *EscalationPolicyRepository) CreateRule(ctx context.Context, rule *domai
 		rule.PolicyID,
 		rule.Position,
 		rule.EscalationDelay,
+		rule.DelaySeconds,
+		ruleHours(rule),
+		rule.MinPriority,
+		rule.OnlyIfUnassigned,
 	).Scan(&rule.CreatedAt, &rule.UpdatedAt)
 
 	if err != nil {
@@ -214,9 +278,18 @@ func (r *EscalationPolicyRepository) CreateRule(ctx context.Context, rule *domai
 	return nil
 }
 
+// ruleHours returns the hours a rule applies in as a query argument; rules
+// built without them apply at all hours
+func ruleHours(rule *domain.EscalationRule) string {
+	if rule.Hours == "" {
+		return string(domain.EscalationRuleHoursAlways)
+	}
+	return string(rule.Hours)
+}
+
 func (r *EscalationPolicyRepository) GetRule(ctx context.Context, id uuid.UUID) (*domain.EscalationRule, error) {
 	query := `
-		SELECT id, policy_id, position, escalation_delay, created_at, updated_at
+		SELECT id, policy_id, position, escalation_delay, delay_seconds, hours, min_priority, only_if_unassigned, created_at, updated_at
 		FROM escalation_rules
 		WHERE id = $1
 	`
@@ -227,6 +300,10 @@ func (r *EscalationPolicyRepository) GetRule(ctx context.Context, id uuid.UUID)
 		&rule.PolicyID,
 		&rule.Position,
 		&rule.EscalationDelay,
+		&rule.DelaySeconds,
+		&rule.Hours,
+		&rule.MinPriority,
+		&rule.OnlyIfUnassigned,
 		&rule.CreatedAt,
 		&rule.UpdatedAt,
 	)
@@ -244,7 +321,8 @@ func (r *EscalationPolicyRepository) GetRule(ctx context.Context, id uuid.UUID)
 func (r *EscalationPolicyRepository) UpdateRule(ctx context.Context, rule *domain.EscalationRule) error {
 	query := `
 		UPDATE escalation_rules
-		SET position = $2, escalation_delay = $3
+		SET position = $2, escalation_delay = $3, delay_seconds = $4, hours = $5,
+		    min_priority = $6, only_if_unassigned = $7
 		WHERE id = $1
 		RETURNING updated_at
 	`
@@ -255,6 +333,10 @@ func (r *EscalationPolicyRepository) UpdateRule(ctx context.Context, rule *domai
 		rule.ID,
 		rule.Position,
 		rule.EscalationDelay,
+		rule.DelaySeconds,
+		ruleHours(rule),
+		rule.MinPriority,
+		rule.OnlyIfUnassigned,
 	).Scan(&rule.UpdatedAt)
 
 	if err != nil {
@@ -286,7 +368,7 @@ func (r *EscalationPolicyRepository) DeleteRule(ctx context.Context, id uuid.UUI
 
 func (r *EscalationPolicyRepository) ListRules(ctx context.Context, policyID uuid.UUID) ([]*domain.EscalationRule, error) {
 	query := `
-		SELECT id, policy_id, position, escalation_delay, created_at, updated_at
+		SELECT id, policy_id, position, escalation_delay, delay_seconds, hours, min_priority, only_if_unassigned, created_at, updated_at
 		FROM escalation_rules
 		WHERE policy_id = $1
 		ORDER BY position ASC
@@ -306,6 +388,10 @@ func (r *EscalationPolicyRepository) ListRules(ctx context.Context, policyID uui
 			&rule.PolicyID,
 			&rule.Position,
 			&rule.EscalationDelay,
+			&rule.DelaySeconds,
+			&rule.Hours,
+			&rule.MinPriority,
+			&rule.OnlyIfUnassigned,
 			&rule.CreatedAt,
 			&rule.UpdatedAt,
 		)
@@ -323,8 +409,8 @@ func (r *EscalationPolicyRepository) ListRules(ctx context.Context, policyID uui
 
 func (r *EscalationPolicyRepository) AddTarget(ctx context.Context, target *domain.EscalationTarget) error {
 	query := `
-		INSERT INTO escalation_targets (id, rule_id, target_type, target_id, notification_channels)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO escalation_targets (id, rule_id, target_type, target_id, schedule_mode, notification_channels)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)
 		RETURNING created_at
 	`
 
@@ -341,6 +427,7 @@ func (r *EscalationPolicyRepository) AddTarget(ctx context.Context, target *doma
 		target.RuleID,
 		target.TargetType.String(),
 		target.TargetID,
+		string(target.ScheduleMode),
 		notificationChannels,
 	).Scan(&target.CreatedAt)
 
@@ -373,7 +460,7 @@ func (r *EscalationPolicyRepository) RemoveTarget(ctx context.Context, id uuid.U
 
 func (r *EscalationPolicyRepository) ListTargets(ctx context.Context, ruleID uuid.UUID) ([]*domain.EscalationTarget, error) {
 	query := `
-		SELECT id, rule_id, target_type, target_id, COALESCE(notification_channels, 'null'::jsonb), created_at
+		SELECT id, rule_id, target_type, target_id, COALESCE(schedule_mode, ''), COALESCE(notification_channels, 'null'::jsonb), created_at
 		FROM escalation_targets
 		WHERE rule_id = $1
 		ORDER BY created_at ASC
@@ -388,13 +475,14 @@ func (r *EscalationPolicyRepository) ListTargets(ctx context.Context, ruleID uui
 	var targets []*domain.EscalationTarget
 	for rows.Next() {
 		var target domain.EscalationTarget
-		var targetType string
+		var targetType, scheduleMode string
 
 		err := rows.Scan(
 			&target.ID,
 			&target.RuleID,
 			&targetType,
 			&target.TargetID,
+			&scheduleMode,
 			&target.NotificationChannels,
 			&target.CreatedAt,
 		)
@@ -403,6 +491,7 @@ func (r *EscalationPolicyRepository) ListTargets(ctx context.Context, ruleID uui
 		}
 
 		target.TargetType = domain.EscalationTargetType(targetType)
+		target.ScheduleMode = domain.ScheduleTargetMode(scheduleMode)
 		// Clear notification channels if it's just "null"
 		if string(target.NotificationChannels) == "null" {
 			target.NotificationChannels = nil
@@ -413,6 +502,144 @@ func (r *EscalationPolicyRepository) ListTargets(ctx context.Context, ruleID uui
 	return targets, nil
 }
 
+// ApplyConfig reconciles a policy with its desired state in one transaction:
+// the policy is updated, unlisted rules are deleted, listed rules are created
+// or updated, and each rule's targets are replaced.
+func (r *EscalationPolicyRepository) ApplyConfig(ctx context.Context, config *domain.EscalationPolicyWithRules) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	policy := &config.EscalationPolicy
+	err = tx.QueryRowContext(ctx, `
+		UPDATE escalation_policies
+		SET name = $2, description = $3, repeat_enabled = $4, repeat_count = $5, conditions = $6, business_hours = $7
+		WHERE id = $1
+		RETURNING updated_at
+	`, policy.ID, policy.Name, policy.Description, policy.RepeatEnabled, policy.RepeatCount,
+		policyConditions(policy), policyBusinessHours(policy),
+	).Scan(&policy.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("escalation policy not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update escalation policy: %w", err)
+	}
+
+	keep := make([]string, 0, len(config.Rules))
+	for _, rule := range config.Rules {
+		keep = append(keep, rule.ID.String())
+	}
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM escalation_rules
+		WHERE policy_id = $1 AND id <> ALL($2::uuid[])
+	`, policy.ID, pq.Array(keep))
+	if err != nil {
+		return fmt.Errorf("failed to delete escalation rules: %w", err)
+	}
+
+	for _, rule := range config.Rules {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO escalation_rules (id, policy_id, position, escalation_delay, delay_seconds, hours, min_priority, only_if_unassigned)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (id) DO UPDATE
+			SET position = EXCLUDED.position, escalation_delay = EXCLUDED.escalation_delay,
+			    delay_seconds = EXCLUDED.delay_seconds, hours = EXCLUDED.hours,
+			    min_priority = EXCLUDED.min_priority, only_if_unassigned = EXCLUDED.only_if_unassigned
+			RETURNING created_at, updated_at
+		`, rule.ID, policy.ID, rule.Position, rule.EscalationDelay, rule.DelaySeconds, ruleHours(&rule.EscalationRule),
+			rule.MinPriority, rule.OnlyIfUnassigned).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to apply escalation rule at position %d: %w", rule.Position, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM escalation_targets WHERE rule_id = $1`, rule.ID); err != nil {
+			return fmt.Errorf("failed to clear escalation targets: %w", err)
+		}
+		if err := insertTargets(ctx, tx, rule); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CreateWithRules inserts a new policy together with its rules and their
+// targets in a single transaction
+func (r *EscalationPolicyRepository) CreateWithRules(ctx context.Context, config *domain.EscalationPolicyWithRules) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	policy := &config.EscalationPolicy
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO escalation_policies (id, organization_id, name, description, repeat_enabled, repeat_count, conditions, business_hours)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at, updated_at
+	`,
+		policy.ID,
+		policy.OrganizationID,
+		policy.Name,
+		policy.Description,
+		policy.RepeatEnabled,
+		policy.RepeatCount,
+		policyConditions(policy),
+		policyBusinessHours(policy),
+	).Scan(&policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create escalation policy: %w", err)
+	}
+
+	for _, rule := range config.Rules {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO escalation_rules (id, policy_id, position, escalation_delay, delay_seconds, hours, min_priority, only_if_unassigned)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING created_at, updated_at
+		`, rule.ID, policy.ID, rule.Position, rule.EscalationDelay, rule.DelaySeconds, ruleHours(&rule.EscalationRule),
+			rule.MinPriority, rule.OnlyIfUnassigned).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create escalation rule at position %d: %w", rule.Position, err)
+		}
+
+		if err := insertTargets(ctx, tx, rule); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func insertTargets(ctx context.Context, tx *sql.Tx, rule *domain.EscalationRuleWithTargets) error {
+	for _, target := range rule.Targets {
+		// Handle nil or empty notification channels - use nil for NULL in PostgreSQL
+		var notificationChannels interface{}
+		if len(target.NotificationChannels) > 0 {
+			notificationChannels = target.NotificationChannels
+		}
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO escalation_targets (id, rule_id, target_type, target_id, schedule_mode, notification_channels)
+			VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)
+			RETURNING created_at
+		`, target.ID, rule.ID, target.TargetType.String(), target.TargetID, string(target.ScheduleMode), notificationChannels).Scan(&target.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to add escalation target: %w", err)
+		}
+	}
+	return nil
+}
+
 // Escalation event operations
 
 func (r *EscalationPolicyRepository) CreateEvent(ctx context.Context, event *domain.AlertEscalationEvent) error {
@@ -503,19 +730,62 @@ func (r *EscalationPolicyRepository) UpdateEvent(ctx context.Context, event *dom
 	return nil
 }
 
-func (r *EscalationPolicyRepository) ListPendingEscalations(ctx context.Context, before time.Time) ([]*domain.AlertEscalationEvent, error) {
+// RecordNotifiedStep records that the targets of the event's current step
+// are being paged. It reports false if the step had already been recorded.
+func (r *EscalationPolicyRepository) RecordNotifiedStep(ctx context.Context, event *domain.AlertEscalationEvent) (bool, error) {
+	if event.RuleID == nil {
+		return false, fmt.Errorf("escalation event %s has no rule", event.ID)
+	}
+
 	query := `
-		SELECT id, alert_id, policy_id, rule_id, event_type, current_level, repeat_count, next_escalation_at, created_at
-		FROM alert_escalation_events
-		WHERE next_escalation_at IS NOT NULL
-		  AND next_escalation_at <= $1
-		  AND event_type = 'triggered'
-		ORDER BY next_escalation_at ASC
+		INSERT INTO alert_escalation_notified_steps (escalation_event_id, alert_id, rule_id, repeat_count)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, event.ID, event.AlertID, *event.RuleID, event.RepeatCount)
+	if err != nil {
+		return false, fmt.Errorf("failed to record notified escalation step: %w", err)
+	}
+
+	recorded, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to record notified escalation step: %w", err)
+	}
+
+	return recorded == 1, nil
+}
+
+// ClaimPendingEscalations atomically claims up to limit triggered events due
+// before the given time whose alert is still open; snoozed alerts resume
+// escalating once they reopen. Claiming pushes next_escalation_at out by
+// escalationClaimLease so concurrent workers skip the row; processing then
+// sets the real next escalation time. If the worker dies mid-way the event
+// becomes due again once the lease expires.
+func (r *EscalationPolicyRepository) ClaimPendingEscalations(ctx context.Context, before time.Time, limit int) ([]*domain.AlertEscalationEvent, error) {
+	query := `
+		WITH claimable AS (
+			SELECT e.id
+			FROM alert_escalation_events e
+			JOIN alerts a ON a.id = e.alert_id
+			WHERE e.next_escalation_at IS NOT NULL
+			  AND e.next_escalation_at <= $1
+			  AND e.event_type = 'triggered'
+			  AND a.status = 'open'
+			ORDER BY e.next_escalation_at ASC
+			LIMIT $2
+			FOR UPDATE OF e SKIP LOCKED
+		)
+		UPDATE alert_escalation_events e
+		SET next_escalation_at = $3
+		FROM claimable
+		WHERE e.id = claimable.id
+		RETURNING e.id, e.alert_id, e.policy_id, e.rule_id, e.event_type, e.current_level, e.repeat_count, e.next_escalation_at, e.created_at
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, before)
+	rows, err := r.db.QueryContext(ctx, query, before, limit, before.Add(escalationClaimLease))
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pending escalations: %w", err)
+		return nil, fmt.Errorf("failed to claim pending escalations: %w", err)
 	}
 	defer rows.Close()
 
@@ -545,3 +815,24 @@ func (r *EscalationPolicyRepository) ListPendingEscalations(ctx context.Context,
 
 	return events, nil
 }
+
+// CountPendingEscalations returns the number of triggered escalation events
+// for open alerts that are due before the given time and not yet processed.
+func (r *EscalationPolicyRepository) CountPendingEscalations(ctx context.Context, before time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM alert_escalation_events e
+		JOIN alerts a ON a.id = e.alert_id
+		WHERE e.next_escalation_at IS NOT NULL
+		  AND e.next_escalation_at <= $1
+		  AND e.event_type = 'triggered'
+		  AND a.status = 'open'
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, before).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count pending escalations: %w", err)
+	}
+
+	return count, nil
+}
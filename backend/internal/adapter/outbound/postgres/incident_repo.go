@@ -9,16 +9,17 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 
 	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 )
 
 type incidentRepository struct {
-	db *sqlx.DB
+	db *DB
 }
 
 // NewIncidentRepository creates a new incident repository
-func NewIncidentRepository(db *sqlx.DB) *incidentRepository {
+func NewIncidentRepository(db *DB) *incidentRepository {
 	return &incidentRepository{db: db}
 }
 
@@ -81,6 +82,75 @@ func (r *incidentRepository) Update(ctx context.Context, incident *domain.Incide
 	).Scan(&incident.UpdatedAt)
 }
 
+// SetWarRoom stores the war room channel opened for an incident
+func (r *incidentRepository) SetWarRoom(ctx context.Context, id, orgID uuid.UUID, room *domain.WarRoom) error {
+	query := `
+		UPDATE incidents SET slack_channel_id = $1, slack_channel_url = $2
+		WHERE id = $3 AND organization_id = $4
+	`
+	result, err := r.db.ExecContext(ctx, query, room.ChannelID, room.URL, id, orgID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// SetIssueLink stores the Jira issue opened for an incident
+func (r *incidentRepository) SetIssueLink(ctx context.Context, id, orgID uuid.UUID, link *domain.IssueLink) error {
+	query := `
+		UPDATE incidents SET jira_issue_key = $1, jira_issue_url = $2
+		WHERE id = $3 AND organization_id = $4
+	`
+	result, err := r.db.ExecContext(ctx, query, link.Key, link.URL, id, orgID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// SetStatuspageIncident stores the Statuspage incident mirroring an incident
+func (r *incidentRepository) SetStatuspageIncident(ctx context.Context, id, orgID uuid.UUID, statuspageIncidentID string) error {
+	query := `
+		UPDATE incidents SET statuspage_incident_id = $1
+		WHERE id = $2 AND organization_id = $3
+	`
+	result, err := r.db.ExecContext(ctx, query, statuspageIncidentID, id, orgID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
 // Delete deletes an incident
 func (r *incidentRepository) Delete(ctx context.Context, id uuid.UUID, orgID uuid.UUID) error {
 	query := `DELETE FROM incidents WHERE id = $1 AND organization_id = $2`
@@ -101,7 +171,8 @@ func (r *incidentRepository) Delete(ctx context.Context, id uuid.UUID, orgID uui
 	return nil
 }
 
-// List retrieves incidents with filtering and pagination
+// List retrieves incidents with filtering and pagination. It runs on the
+// read replica when one is configured.
 func (r *incidentRepository) List(ctx context.Context, filter *domain.IncidentFilter) ([]*domain.Incident, int, error) {
 	if err := filter.Validate(); err != nil {
 		return nil, 0, err
@@ -142,6 +213,13 @@ func (r *incidentRepository) List(ctx context.Context, filter *domain.IncidentFi
 		args = append(args, *filter.AssignedToTeamID)
 	}
 
+	// Filter by responder
+	if filter.ResponderUserID != nil {
+		argCount++
+		where = append(where, fmt.Sprintf("EXISTS (SELECT 1 FROM incident_responders ir WHERE ir.incident_id = incidents.id AND ir.user_id = $%d)", argCount))
+		args = append(args, *filter.ResponderUserID)
+	}
+
 	// Search in title and description
 	if filter.Search != nil && *filter.Search != "" {
 		argCount++
@@ -153,10 +231,17 @@ func (r *incidentRepository) List(ctx context.Context, filter *domain.IncidentFi
 	// Count total
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM incidents WHERE %s", strings.Join(where, " AND "))
 	var total int
-	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+	if err := r.db.Reader().GetContext(ctx, &total, countQuery, args...); err != nil {
 		return nil, 0, err
 	}
 
+	// The cursor narrows the page, not the total
+	if filter.After != nil {
+		where = append(where, fmt.Sprintf("(created_at, id) < ($%d, $%d)", argCount+1, argCount+2))
+		args = append(args, filter.After.CreatedAt, filter.After.ID)
+		argCount += 2
+	}
+
 	// Get incidents
 	argCount++
 	args = append(args, filter.Limit)
@@ -166,12 +251,12 @@ func (r *incidentRepository) List(ctx context.Context, filter *domain.IncidentFi
 	query := fmt.Sprintf(`
 		SELECT * FROM incidents
 		WHERE %s
-		ORDER BY created_at DESC
+		ORDER BY created_at DESC, id DESC
 		LIMIT $%d OFFSET $%d
 	`, strings.Join(where, " AND "), argCount-1, argCount)
 
 	var incidents []*domain.Incident
-	if err := r.db.SelectContext(ctx, &incidents, query, args...); err != nil {
+	if err := r.db.Reader().SelectContext(ctx, &incidents, query, args...); err != nil {
 		return nil, 0, err
 	}
 
@@ -192,6 +277,100 @@ func (r *incidentRepository) AddResponder(ctx context.Context, responder *domain
 	).Scan(&responder.AddedAt)
 }
 
+// AddCommander adds a responder as the incident commander, demoting the
+// current commander in the same transaction. It returns the demoted users.
+func (r *incidentRepository) AddCommander(ctx context.Context, responder *domain.IncidentResponder) ([]uuid.UUID, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	demoted, err := demoteCommanders(ctx, tx, responder.IncidentID, responder.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO incident_responders (id, incident_id, user_id, role)
+		VALUES ($1, $2, $3, $4)
+		RETURNING added_at
+	`, responder.ID, responder.IncidentID, responder.UserID, domain.ResponderRoleIncidentCommander).Scan(&responder.AddedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return demoted, nil
+}
+
+// PromoteToCommander makes an existing responder the incident commander,
+// demoting the current commander in the same transaction. It returns the
+// demoted users.
+func (r *incidentRepository) PromoteToCommander(ctx context.Context, incidentID, orgID, userID uuid.UUID) ([]uuid.UUID, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	demoted, err := demoteCommanders(ctx, tx, incidentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE incident_responders SET role = $1
+		WHERE incident_id = $2 AND user_id = $3 AND EXISTS (SELECT 1 FROM incidents WHERE id = $2 AND organization_id = $4)
+	`, domain.ResponderRoleIncidentCommander, incidentID, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if rows == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return demoted, nil
+}
+
+// demoteCommanders turns every commander of the incident except the given
+// user into a plain responder and returns who was demoted
+func demoteCommanders(ctx context.Context, tx *sqlx.Tx, incidentID, exceptUserID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := tx.QueryContext(ctx, `
+		UPDATE incident_responders SET role = $1
+		WHERE incident_id = $2 AND role = $3 AND user_id <> $4
+		RETURNING user_id
+	`, domain.ResponderRoleResponder, incidentID, domain.ResponderRoleIncidentCommander, exceptUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to demote incident commander: %w", err)
+	}
+	defer rows.Close()
+
+	var demoted []uuid.UUID
+	for rows.Next() {
+		var userID uuid.UUID
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		demoted = append(demoted, userID)
+	}
+
+	return demoted, rows.Err()
+}
+
 // RemoveResponder removes a responder from an incident
 func (r *incidentRepository) RemoveResponder(ctx context.Context, incidentID, orgID, userID uuid.UUID) error {
 	query := `DELETE FROM incident_responders WHERE incident_id = $1 AND user_id = $2 AND EXISTS (SELECT 1 FROM incidents WHERE id = $1 AND organization_id = $3)`
@@ -292,19 +471,96 @@ func (r *incidentRepository) AddTimelineEvent(ctx context.Context, event *domain
 	).Scan(&event.CreatedAt)
 }
 
-// GetTimeline retrieves the timeline for an incident with user details
-func (r *incidentRepository) GetTimeline(ctx context.Context, incidentID uuid.UUID, orgID uuid.UUID) ([]*domain.TimelineEventWithUser, error) {
+// GetTimelineEvent retrieves a single timeline event of an incident
+func (r *incidentRepository) GetTimelineEvent(ctx context.Context, eventID, incidentID, orgID uuid.UUID) (*domain.IncidentTimelineEvent, error) {
+	query := `
+		SELECT t.id, t.incident_id, t.event_type, t.user_id, t.description, t.metadata, t.created_at, t.edited_at
+		FROM incident_timeline t
+		JOIN incidents i ON i.id = t.incident_id
+		WHERE t.id = $1 AND t.incident_id = $2 AND i.organization_id = $3
+	`
+
+	var event domain.IncidentTimelineEvent
+	var metadataJSON []byte
+	err := r.db.QueryRowContext(ctx, query, eventID, incidentID, orgID).Scan(
+		&event.ID, &event.IncidentID, &event.EventType, &event.UserID,
+		&event.Description, &metadataJSON, &event.CreatedAt, &event.EditedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(metadataJSON, &event.Metadata); err != nil {
+		event.Metadata = make(map[string]interface{})
+	}
+
+	return &event, nil
+}
+
+// UpdateTimelineEventDescription replaces an event's description and marks it
+// as edited
+func (r *incidentRepository) UpdateTimelineEventDescription(ctx context.Context, event *domain.IncidentTimelineEvent) error {
 	query := `
+		UPDATE incident_timeline
+		SET description = $3, edited_at = NOW()
+		WHERE id = $1 AND incident_id = $2
+		RETURNING edited_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, event.ID, event.IncidentID, event.Description).Scan(&event.EditedAt)
+	if err == sql.ErrNoRows {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+// DeleteTimelineEvent removes an event from the incident timeline
+func (r *incidentRepository) DeleteTimelineEvent(ctx context.Context, eventID, incidentID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM incident_timeline WHERE id = $1 AND incident_id = $2`, eventID, incidentID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetTimeline retrieves the timeline for an incident with user details,
+// limited to the given event types when any are passed
+func (r *incidentRepository) GetTimeline(ctx context.Context, incidentID uuid.UUID, orgID uuid.UUID, eventTypes []domain.TimelineEventType) ([]*domain.TimelineEventWithUser, error) {
+	where := "t.incident_id = $1 AND EXISTS (SELECT 1 FROM incidents WHERE id = $1 AND organization_id = $2)"
+	args := []interface{}{incidentID, orgID}
+	if len(eventTypes) > 0 {
+		types := make([]string, len(eventTypes))
+		for i, eventType := range eventTypes {
+			types[i] = eventType.String()
+		}
+		where += " AND t.event_type = ANY($3)"
+		args = append(args, pq.Array(types))
+	}
+
+	query := fmt.Sprintf(`
 		SELECT
-			t.id, t.incident_id, t.event_type, t.user_id, t.description, t.metadata, t.created_at,
+			t.id, t.incident_id, t.event_type, t.user_id, t.description, t.metadata, t.created_at, t.edited_at,
 			u.id, u.email, u.username, u.full_name, u.created_at, u.updated_at
 		FROM incident_timeline t
 		LEFT JOIN users u ON t.user_id = u.id
-		WHERE t.incident_id = $1 AND EXISTS (SELECT 1 FROM incidents WHERE id = $1 AND organization_id = $2)
+		WHERE %s
 		ORDER BY t.created_at ASC
-	`
+	`, where)
 
-	rows, err := r.db.QueryContext(ctx, query, incidentID, orgID)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -323,7 +579,7 @@ func (r *incidentRepository) GetTimeline(ctx context.Context, incidentID uuid.UU
 
 		err := rows.Scan(
 			&event.ID, &event.IncidentID, &event.EventType, &event.UserID,
-			&event.Description, &metadataJSON, &event.CreatedAt,
+			&event.Description, &metadataJSON, &event.CreatedAt, &event.EditedAt,
 			&userID, &userEmail, &userUsername, &userFullName,
 			&userCreatedAt, &userUpdatedAt,
 		)
@@ -478,7 +734,7 @@ func (r *incidentRepository) GetWithDetails(ctx context.Context, id uuid.UUID, o
 	result.Alerts = alerts
 
 	// Get timeline
-	timeline, err := r.GetTimeline(ctx, id, orgID)
+	timeline, err := r.GetTimeline(ctx, id, orgID, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -80,10 +80,10 @@ func (r *TeamInvitationRepo) GetByEmailAndTeam(ctx context.Context, email string
 func (r *TeamInvitationRepo) Update(ctx context.Context, invitation *domain.TeamInvitation) error {
 	query := `
 		UPDATE team_invitations
-		SET status = $1, updated_at = NOW()
-		WHERE id = $2
+		SET status = $1, expires_at = $2, updated_at = NOW()
+		WHERE id = $3
 	`
-	_, err := r.db.ExecContext(ctx, query, invitation.Status, invitation.ID)
+	_, err := r.db.ExecContext(ctx, query, invitation.Status, invitation.ExpiresAt, invitation.ID)
 	return err
 }
 
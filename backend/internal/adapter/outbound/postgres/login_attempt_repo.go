@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+type LoginAttemptRepository struct {
+	db *DB
+}
+
+func NewLoginAttemptRepository(db *DB) *LoginAttemptRepository {
+	return &LoginAttemptRepository{db: db}
+}
+
+func (r *LoginAttemptRepository) Create(ctx context.Context, attempt *domain.LoginAttempt) error {
+	query := `
+		INSERT INTO login_attempts (id, email, user_id, ip_address, user_agent, succeeded, failure_reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING attempted_at
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		attempt.ID,
+		attempt.Email,
+		attempt.UserID,
+		attempt.IPAddress,
+		attempt.UserAgent,
+		attempt.Succeeded,
+		attempt.FailureReason,
+	).Scan(&attempt.AttemptedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record login attempt: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LoginAttemptRepository) CountIPFailures(ctx context.Context, ipAddress string, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM login_attempts
+		WHERE ip_address = $1 AND NOT succeeded AND failure_reason = $2 AND attempted_at >= $3
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, ipAddress, domain.LoginFailureInvalidCredentials, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count login failures: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *LoginAttemptRepository) GetLockout(ctx context.Context, userID uuid.UUID) (*domain.LoginLockout, error) {
+	query := `
+		SELECT user_id, failed_attempts, locked_until, updated_at
+		FROM login_lockouts
+		WHERE user_id = $1
+	`
+
+	var lockout domain.LoginLockout
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&lockout.UserID,
+		&lockout.FailedAttempts,
+		&lockout.LockedUntil,
+		&lockout.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login lockout: %w", err)
+	}
+
+	return &lockout, nil
+}
+
+func (r *LoginAttemptRepository) ClaimAttempt(ctx context.Context, userID uuid.UUID, since time.Time, maxFailures int) (int, error) {
+	query := `
+		INSERT INTO login_lockouts (user_id, failed_attempts, updated_at)
+		VALUES ($1, 1, NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET failed_attempts = CASE
+		        WHEN login_lockouts.updated_at < $2 THEN 1
+		        ELSE login_lockouts.failed_attempts + 1
+		    END,
+		    updated_at = NOW()
+		WHERE (login_lockouts.locked_until IS NULL OR login_lockouts.locked_until <= NOW())
+		  AND (login_lockouts.updated_at < $2 OR login_lockouts.failed_attempts < $3)
+		RETURNING failed_attempts
+	`
+
+	var attempts int
+	err := r.db.QueryRowContext(ctx, query, userID, since, maxFailures).Scan(&attempts)
+	if err == sql.ErrNoRows {
+		return 0, domain.ErrLoginLocked
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to record login attempt: %w", err)
+	}
+
+	return attempts, nil
+}
+
+func (r *LoginAttemptRepository) Lock(ctx context.Context, userID uuid.UUID, until time.Time) error {
+	query := `
+		INSERT INTO login_lockouts (user_id, failed_attempts, locked_until, updated_at)
+		VALUES ($1, 0, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE
+		SET failed_attempts = 0, locked_until = $2, updated_at = NOW()
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, until); err != nil {
+		return fmt.Errorf("failed to lock account: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LoginAttemptRepository) ResetLockout(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM login_lockouts WHERE user_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to reset login lockout: %w", err)
+	}
+
+	return nil
+}
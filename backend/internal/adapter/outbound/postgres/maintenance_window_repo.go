@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+type MaintenanceWindowRepository struct {
+	db *DB
+}
+
+func NewMaintenanceWindowRepository(db *DB) *MaintenanceWindowRepository {
+	return &MaintenanceWindowRepository{db: db}
+}
+
+func (r *MaintenanceWindowRepository) Create(ctx context.Context, window *domain.MaintenanceWindow) error {
+	query := `
+		INSERT INTO maintenance_windows (id, organization_id, name, description, starts_at, ends_at, conditions, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		window.ID,
+		window.OrganizationID,
+		window.Name,
+		window.Description,
+		window.StartsAt,
+		window.EndsAt,
+		window.Conditions,
+		window.CreatedBy,
+	).Scan(&window.CreatedAt, &window.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create maintenance window: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MaintenanceWindowRepository) GetByID(ctx context.Context, id, orgID uuid.UUID) (*domain.MaintenanceWindow, error) {
+	query := `
+		SELECT id, organization_id, name, description, starts_at, ends_at, conditions, created_by, created_at, updated_at
+		FROM maintenance_windows
+		WHERE id = $1 AND organization_id = $2
+	`
+
+	var window domain.MaintenanceWindow
+	err := r.db.QueryRowContext(ctx, query, id, orgID).Scan(
+		&window.ID,
+		&window.OrganizationID,
+		&window.Name,
+		&window.Description,
+		&window.StartsAt,
+		&window.EndsAt,
+		&window.Conditions,
+		&window.CreatedBy,
+		&window.CreatedAt,
+		&window.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrMaintenanceWindowNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance window: %w", err)
+	}
+
+	return &window, nil
+}
+
+func (r *MaintenanceWindowRepository) Update(ctx context.Context, window *domain.MaintenanceWindow) error {
+	query := `
+		UPDATE maintenance_windows
+		SET name = $3, description = $4, starts_at = $5, ends_at = $6, conditions = $7
+		WHERE id = $1 AND organization_id = $2
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		window.ID,
+		window.OrganizationID,
+		window.Name,
+		window.Description,
+		window.StartsAt,
+		window.EndsAt,
+		window.Conditions,
+	).Scan(&window.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return domain.ErrMaintenanceWindowNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update maintenance window: %w", err)
+	}
+
+	return nil
+}
+
+func (r *MaintenanceWindowRepository) Delete(ctx context.Context, id, orgID uuid.UUID) error {
+	query := `DELETE FROM maintenance_windows WHERE id = $1 AND organization_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to delete maintenance window: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.ErrMaintenanceWindowNotFound
+	}
+
+	return nil
+}
+
+// List returns the organization's windows, latest start first
+func (r *MaintenanceWindowRepository) List(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]*domain.MaintenanceWindow, error) {
+	query := `
+		SELECT id, organization_id, name, description, starts_at, ends_at, conditions, created_by, created_at, updated_at
+		FROM maintenance_windows
+		WHERE organization_id = $1
+		ORDER BY starts_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	return r.queryWindows(ctx, query, orgID, limit, offset)
+}
+
+// ListActive returns the organization's windows covering the given time,
+// earliest start first
+func (r *MaintenanceWindowRepository) ListActive(ctx context.Context, orgID uuid.UUID, at time.Time) ([]*domain.MaintenanceWindow, error) {
+	query := `
+		SELECT id, organization_id, name, description, starts_at, ends_at, conditions, created_by, created_at, updated_at
+		FROM maintenance_windows
+		WHERE organization_id = $1 AND starts_at <= $2 AND ends_at > $2
+		ORDER BY starts_at ASC
+	`
+
+	return r.queryWindows(ctx, query, orgID, at)
+}
+
+func (r *MaintenanceWindowRepository) queryWindows(ctx context.Context, query string, args ...interface{}) ([]*domain.MaintenanceWindow, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list maintenance windows: %w", err)
+	}
+	defer rows.Close()
+
+	windows := []*domain.MaintenanceWindow{}
+	for rows.Next() {
+		var window domain.MaintenanceWindow
+		err := rows.Scan(
+			&window.ID,
+			&window.OrganizationID,
+			&window.Name,
+			&window.Description,
+			&window.StartsAt,
+			&window.EndsAt,
+			&window.Conditions,
+			&window.CreatedBy,
+			&window.CreatedAt,
+			&window.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan maintenance window: %w", err)
+		}
+		windows = append(windows, &window)
+	}
+
+	return windows, rows.Err()
+}
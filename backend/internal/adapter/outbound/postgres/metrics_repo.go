@@ -26,7 +26,7 @@ func (r *metricsRepository) GetAlertMetrics(ctx context.Context, orgID uuid.UUID
 	}
 
 	// Build time filter
-	startTime := time.Now().AddDate(0, 0, -30) // Default: last 30 days
+	startTime := time.Now().UTC().AddDate(0, 0, -30) // Default: last 30 days
 	endTime := time.Now()
 	if filter != nil {
 		if filter.StartTime != nil {
@@ -145,7 +145,7 @@ func (r *metricsRepository) GetIncidentMetrics(ctx context.Context, orgID uuid.U
 	}
 
 	// Build time filter
-	startTime := time.Now().AddDate(0, 0, -30)
+	startTime := time.Now().UTC().AddDate(0, 0, -30)
 	endTime := time.Now()
 	if filter != nil {
 		if filter.StartTime != nil {
@@ -230,7 +230,7 @@ func (r *metricsRepository) GetNotificationMetrics(ctx context.Context, orgID uu
 	}
 
 	// Build time filter
-	startTime := time.Now().AddDate(0, 0, -30)
+	startTime := time.Now().UTC().AddDate(0, 0, -30)
 	endTime := time.Now()
 	if filter != nil {
 		if filter.StartTime != nil {
@@ -249,7 +249,7 @@ func (r *metricsRepository) GetNotificationMetrics(ctx context.Context, orgID uu
 			COUNT(*) FILTER (WHERE status = 'pending') as pending,
 			COUNT(*) FILTER (WHERE status = 'failed') as failed
 		FROM notification_logs
-		WHERE organization_id = $1 AND created_at >= $2 AND created_at <= $3
+		WHERE organization_id = $1 AND created_at >= $2 AND created_at <= $3 AND NOT is_test
 	`
 
 	err := r.db.QueryRowContext(ctx, query, orgID, startTime, endTime).Scan(
@@ -267,7 +267,7 @@ func (r *metricsRepository) GetNotificationMetrics(ctx context.Context, orgID uu
 		SELECT nc.channel_type, COUNT(*) as count
 		FROM notification_logs nl
 		JOIN notification_channels nc ON nl.channel_id = nc.id
-		WHERE nl.organization_id = $1 AND nl.created_at >= $2 AND nl.created_at <= $3
+		WHERE nl.organization_id = $1 AND nl.created_at >= $2 AND nl.created_at <= $3 AND NOT nl.is_test
 		GROUP BY nc.channel_type
 	`
 	rows, err := r.db.QueryContext(ctx, channelQuery, orgID, startTime, endTime)
@@ -295,7 +295,7 @@ func (r *metricsRepository) GetAlertTrend(ctx context.Context, orgID uuid.UUID,
 	}
 
 	// Build time filter
-	startTime := time.Now().AddDate(0, 0, -30)
+	startTime := time.Now().UTC().AddDate(0, 0, -30)
 	endTime := time.Now()
 	if filter != nil {
 		if filter.StartTime != nil {
@@ -370,7 +370,7 @@ func (r *metricsRepository) GetAlertTrend(ctx context.Context, orgID uuid.UUID,
 
 func (r *metricsRepository) GetTeamMetrics(ctx context.Context, orgID uuid.UUID, filter *domain.MetricsFilter) ([]domain.TeamMetrics, error) {
 	// Build time filter
-	startTime := time.Now().AddDate(0, 0, -30)
+	startTime := time.Now().UTC().AddDate(0, 0, -30)
 	endTime := time.Now()
 	if filter != nil {
 		if filter.StartTime != nil {
@@ -4,18 +4,19 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
 
 	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 )
 
 type NotificationRepository struct {
-	db *sqlx.DB
+	db *DB
 }
 
-func NewNotificationRepository(db *sqlx.DB) *NotificationRepository {
+func NewNotificationRepository(db *DB) *NotificationRepository {
 	return &NotificationRepository{db: db}
 }
 
@@ -237,8 +238,8 @@ func (r *NotificationRepository) DeletePreference(ctx context.Context, id uuid.U
 func (r *NotificationRepository) CreateLog(ctx context.Context, log *domain.NotificationLog) error {
 	query := `
 		INSERT INTO notification_logs
-		(organization_id, channel_id, user_id, alert_id, recipient, subject, message, status)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		(organization_id, channel_id, user_id, alert_id, recipient, subject, message, status, error_message, is_test)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING id, created_at
 	`
 	return r.db.QueryRowContext(
@@ -252,6 +253,8 @@ func (r *NotificationRepository) CreateLog(ctx context.Context, log *domain.Noti
 		log.Subject,
 		log.Message,
 		log.Status,
+		log.ErrorMessage,
+		log.IsTest,
 	).Scan(&log.ID, &log.CreatedAt)
 }
 
@@ -270,25 +273,61 @@ func (r *NotificationRepository) GetLogByID(ctx context.Context, id uuid.UUID) (
 	return &log, nil
 }
 
-func (r *NotificationRepository) ListLogs(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]domain.NotificationLog, error) {
-	var logs []domain.NotificationLog
-	query := `
+// ListLogs runs on the read replica when one is configured
+func (r *NotificationRepository) ListLogs(ctx context.Context, filter *domain.NotificationLogFilter) ([]domain.NotificationLog, int, error) {
+	where := []string{"organization_id = $1"}
+	args := []interface{}{filter.OrganizationID}
+
+	if len(filter.Status) > 0 {
+		placeholders := make([]string, len(filter.Status))
+		for i, status := range filter.Status {
+			args = append(args, status)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		where = append(where, fmt.Sprintf("status IN (%s)", strings.Join(placeholders, ",")))
+	}
+
+	if filter.ChannelID != nil {
+		args = append(args, *filter.ChannelID)
+		where = append(where, fmt.Sprintf("channel_id = $%d", len(args)))
+	}
+
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		where = append(where, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		where = append(where, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	whereClause := strings.Join(where, " AND ")
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM notification_logs WHERE %s", whereClause)
+	if err := r.db.Reader().GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count notification logs: %w", err)
+	}
+
+	query := fmt.Sprintf(`
 		SELECT * FROM notification_logs
-		WHERE organization_id = $1
+		WHERE %s
 		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
-	`
+		LIMIT $%d OFFSET $%d
+	`, whereClause, len(args)+1, len(args)+2)
 
-	err := r.db.SelectContext(ctx, &logs, query, orgID, limit, offset)
+	var logs []domain.NotificationLog
+	err := r.db.Reader().SelectContext(ctx, &logs, query, append(args, filter.Limit, filter.Offset)...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	if logs == nil {
 		logs = []domain.NotificationLog{}
 	}
 
-	return logs, nil
+	return logs, total, nil
 }
 
 func (r *NotificationRepository) ListLogsByAlert(ctx context.Context, alertID uuid.UUID) ([]domain.NotificationLog, error) {
@@ -360,14 +399,14 @@ func (r *NotificationRepository) UpdateLogStatus(ctx context.Context, id uuid.UU
 	if status == domain.NotificationStatusSent {
 		query = `
 			UPDATE notification_logs
-			SET status = $1, sent_at = NOW(), error_message = NULL
+			SET status = $1, sent_at = NOW(), error_message = NULL, next_retry_at = NULL
 			WHERE id = $2
 		`
 		_, err = r.db.ExecContext(ctx, query, status, id)
 	} else {
 		query = `
 			UPDATE notification_logs
-			SET status = $1, error_message = $2
+			SET status = $1, error_message = $2, next_retry_at = NULL
 			WHERE id = $3
 		`
 		_, err = r.db.ExecContext(ctx, query, status, errorMsg, id)
@@ -380,6 +419,63 @@ func (r *NotificationRepository) UpdateLogStatus(ctx context.Context, id uuid.UU
 	return err
 }
 
+// RecordDeliveryAttempt counts a send attempt. A nil errorMsg marks the
+// notification as sent. Otherwise it is marked failed and, when nextRetryAt is
+// set, picked up again by GetRetriableFailures from then on.
+func (r *NotificationRepository) RecordDeliveryAttempt(ctx context.Context, id uuid.UUID, errorMsg *string, nextRetryAt *time.Time) error {
+	query := `
+		UPDATE notification_logs
+		SET attempts = attempts + 1,
+		    status = CASE WHEN $2::text IS NULL THEN 'sent' ELSE 'failed' END,
+		    sent_at = CASE WHEN $2::text IS NULL THEN NOW() ELSE sent_at END,
+		    error_message = $2,
+		    next_retry_at = $3
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, errorMsg, nextRetryAt)
+	if err != nil {
+		return fmt.Errorf("failed to record delivery attempt: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetRetriableFailures returns failed notifications whose retry is due,
+// pages for P1 alerts first, along with the current state of their alert
+func (r *NotificationRepository) GetRetriableFailures(ctx context.Context, now time.Time, limit int) ([]domain.NotificationRetry, error) {
+	var retries []domain.NotificationRetry
+	query := `
+		SELECT l.*, a.priority AS alert_priority, a.status AS alert_status
+		FROM notification_logs l
+		LEFT JOIN alerts a ON a.id = l.alert_id
+		WHERE l.status = $1
+		  AND l.next_retry_at IS NOT NULL
+		  AND l.next_retry_at <= $2
+		ORDER BY (a.priority = 'P1') DESC NULLS LAST, l.next_retry_at ASC
+		LIMIT $3
+	`
+
+	err := r.db.SelectContext(ctx, &retries, query, domain.NotificationStatusFailed, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get retriable notifications: %w", err)
+	}
+
+	if retries == nil {
+		retries = []domain.NotificationRetry{}
+	}
+
+	return retries, nil
+}
+
 func (r *NotificationRepository) CountLogsByStatus(ctx context.Context, orgID uuid.UUID, status domain.NotificationStatus) (int, error) {
 	var count int
 	query := `
@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+type OrganizationImportRepository struct {
+	db *DB
+}
+
+func NewOrganizationImportRepository(db *DB) *OrganizationImportRepository {
+	return &OrganizationImportRepository{db: db}
+}
+
+// Import inserts all entities of an organization import in a single transaction.
+// Entities are inserted parents first so foreign keys are satisfied.
+func (r *OrganizationImportRepository) Import(ctx context.Context, data *domain.OrganizationImport) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, team := range data.Teams {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO teams (id, organization_id, name, description)
+			VALUES ($1, $2, $3, $4)
+		`, team.ID, team.OrganizationID, team.Name, team.Description)
+		if err != nil {
+			return fmt.Errorf("failed to import team %q: %w", team.Name, err)
+		}
+	}
+
+	for _, member := range data.TeamMembers {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO team_members (team_id, user_id, role)
+			VALUES ($1, $2, $3)
+		`, member.TeamID, member.UserID, member.Role)
+		if err != nil {
+			return fmt.Errorf("failed to import team member: %w", err)
+		}
+	}
+
+	for _, schedule := range data.Schedules {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO schedules (id, organization_id, team_id, name, description, timezone)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, schedule.ID, schedule.OrganizationID, schedule.TeamID, schedule.Name, schedule.Description, schedule.Timezone)
+		if err != nil {
+			return fmt.Errorf("failed to import schedule %q: %w", schedule.Name, err)
+		}
+	}
+
+	for _, rotation := range data.Rotations {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO schedule_rotations (
+				id, schedule_id, team_id, name, rotation_type, rotation_length,
+				start_date, start_time, end_time, handoff_day, handoff_time
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		`,
+			rotation.ID,
+			rotation.ScheduleID,
+			rotation.TeamID,
+			rotation.Name,
+			rotation.RotationType.String(),
+			rotation.RotationLength,
+			rotation.StartDate,
+			rotation.StartTime,
+			rotation.EndTime,
+			rotation.HandoffDay,
+			rotation.HandoffTime,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to import rotation %q: %w", rotation.Name, err)
+		}
+	}
+
+	for _, participant := range data.Participants {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO schedule_rotation_participants (id, rotation_id, user_id, position)
+			VALUES ($1, $2, $3, $4)
+		`, participant.ID, participant.RotationID, participant.UserID, participant.Position)
+		if err != nil {
+			return fmt.Errorf("failed to import rotation participant: %w", err)
+		}
+	}
+
+	for _, override := range data.Overrides {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO schedule_overrides (id, schedule_id, user_id, start_time, end_time, note)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, override.ID, override.ScheduleID, override.UserID, override.StartTime, override.EndTime, override.Note)
+		if err != nil {
+			return fmt.Errorf("failed to import schedule override: %w", err)
+		}
+	}
+
+	for _, policy := range data.EscalationPolicies {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO escalation_policies (id, organization_id, name, description, repeat_enabled, repeat_count, business_hours)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+		`, policy.ID, policy.OrganizationID, policy.Name, policy.Description, policy.RepeatEnabled, policy.RepeatCount, policyBusinessHours(policy))
+		if err != nil {
+			return fmt.Errorf("failed to import escalation policy %q: %w", policy.Name, err)
+		}
+	}
+
+	for _, rule := range data.EscalationRules {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO escalation_rules (id, policy_id, position, escalation_delay, delay_seconds, hours, min_priority, only_if_unassigned)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, rule.ID, rule.PolicyID, rule.Position, rule.EscalationDelay, rule.DelaySeconds, ruleHours(rule),
+			rule.MinPriority, rule.OnlyIfUnassigned)
+		if err != nil {
+			return fmt.Errorf("failed to import escalation rule: %w", err)
+		}
+	}
+
+	for _, target := range data.EscalationTargets {
+		// Handle nil or empty notification channels - use nil for NULL in PostgreSQL
+		var notificationChannels interface{}
+		if len(target.NotificationChannels) > 0 {
+			notificationChannels = target.NotificationChannels
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO escalation_targets (id, rule_id, target_type, target_id, schedule_mode, notification_channels)
+			VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)
+		`, target.ID, target.RuleID, target.TargetType.String(), target.TargetID, string(target.ScheduleMode), notificationChannels)
+		if err != nil {
+			return fmt.Errorf("failed to import escalation target: %w", err)
+		}
+	}
+
+	for _, rule := range data.RoutingRules {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO alert_routing_rules (id, organization_id, name, description, priority, conditions, actions, enabled)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, rule.ID, rule.OrganizationID, rule.Name, rule.Description, rule.Priority, rule.Conditions, rule.Actions, rule.Enabled)
+		if err != nil {
+			return fmt.Errorf("failed to import routing rule %q: %w", rule.Name, err)
+		}
+	}
+
+	for _, channel := range data.NotificationChannels {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO notification_channels (id, organization_id, name, channel_type, is_enabled, config)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, channel.ID, channel.OrganizationID, channel.Name, channel.ChannelType, channel.IsEnabled, channel.Config)
+		if err != nil {
+			return fmt.Errorf("failed to import notification channel %q: %w", channel.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit import: %w", err)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 
@@ -242,13 +243,15 @@ func (r *OrganizationRepository) RemoveUser(ctx context.Context, orgID, userID u
 	return nil
 }
 
+// GetUserRole returns the role of an active member. Members deactivated in the
+// organization are not found.
 func (r *OrganizationRepository) GetUserRole(ctx context.Context, orgID, userID uuid.UUID) (domain.UserRole, error) {
-	query := `SELECT role FROM organization_users WHERE organization_id = $1 AND user_id = $2`
+	query := `SELECT role FROM organization_users WHERE organization_id = $1 AND user_id = $2 AND is_active`
 
 	var role string
 	err := r.db.QueryRowContext(ctx, query, orgID, userID).Scan(&role)
 	if err == sql.ErrNoRows {
-		return "", fmt.Errorf("user not found in organization")
+		return "", fmt.Errorf("%w: user not found in organization", domain.ErrNotFound)
 	}
 	if err != nil {
 		return "", fmt.Errorf("failed to get user role: %w", err)
@@ -257,6 +260,49 @@ func (r *OrganizationRepository) GetUserRole(ctx context.Context, orgID, userID
 	return domain.UserRole(role), nil
 }
 
+// GetMembership returns the user's role in the organization and whether their
+// membership is active, including for members deactivated in it
+func (r *OrganizationRepository) GetMembership(ctx context.Context, orgID, userID uuid.UUID) (domain.UserRole, bool, error) {
+	query := `SELECT role, is_active FROM organization_users WHERE organization_id = $1 AND user_id = $2`
+
+	var role string
+	var active bool
+	err := r.db.QueryRowContext(ctx, query, orgID, userID).Scan(&role, &active)
+	if err == sql.ErrNoRows {
+		return "", false, fmt.Errorf("%w: user not found in organization", domain.ErrNotFound)
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get membership: %w", err)
+	}
+
+	return domain.UserRole(role), active, nil
+}
+
+// SetMemberActive deactivates or reactivates the user's membership
+func (r *OrganizationRepository) SetMemberActive(ctx context.Context, orgID, userID uuid.UUID, active bool) error {
+	query := `
+		UPDATE organization_users
+		SET is_active = $3
+		WHERE organization_id = $1 AND user_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, orgID, userID, active)
+	if err != nil {
+		return fmt.Errorf("failed to update membership: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("%w: user not found in organization", domain.ErrNotFound)
+	}
+
+	return nil
+}
+
 func (r *OrganizationRepository) UpdateUserRole(ctx context.Context, orgID, userID uuid.UUID, role domain.UserRole) error {
 	query := `
 		UPDATE organization_users
@@ -284,7 +330,7 @@ func (r *OrganizationRepository) UpdateUserRole(ctx context.Context, orgID, user
 func (r *OrganizationRepository) ListUsers(ctx context.Context, orgID uuid.UUID) ([]*domain.UserWithOrganization, error) {
 	query := `
 		SELECT u.id, u.email, u.username, u.full_name, u.phone, u.timezone,
-		       u.notification_preferences, u.is_active, u.created_at, u.updated_at,
+		       u.notification_preferences, u.is_active AND ou.is_active, u.created_at, u.updated_at,
 		       ou.organization_id, ou.role
 		FROM users u
 		JOIN organization_users ou ON u.id = ou.user_id
@@ -298,6 +344,75 @@ func (r *OrganizationRepository) ListUsers(ctx context.Context, orgID uuid.UUID)
 	}
 	defer rows.Close()
 
+	return scanOrganizationUsers(rows)
+}
+
+func (r *OrganizationRepository) SearchUsers(ctx context.Context, filter *domain.UserFilter) ([]*domain.UserWithOrganization, int, error) {
+	// Build WHERE clause
+	where := []string{"ou.organization_id = $1"}
+	args := []interface{}{filter.OrganizationID}
+	argCount := 1
+
+	if filter.Search != nil && *filter.Search != "" {
+		argCount++
+		where = append(where, fmt.Sprintf("(u.username ILIKE $%d OR u.email ILIKE $%d OR u.full_name ILIKE $%d)", argCount, argCount, argCount))
+		args = append(args, "%"+*filter.Search+"%")
+	}
+
+	if filter.TeamID != nil {
+		argCount++
+		where = append(where, fmt.Sprintf("EXISTS (SELECT 1 FROM team_members tm WHERE tm.user_id = u.id AND tm.team_id = $%d)", argCount))
+		args = append(args, *filter.TeamID)
+	}
+
+	if filter.IsActive != nil {
+		argCount++
+		where = append(where, fmt.Sprintf("(u.is_active AND ou.is_active) = $%d", argCount))
+		args = append(args, *filter.IsActive)
+	}
+
+	whereClause := strings.Join(where, " AND ")
+
+	// Count total
+	countQuery := fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM users u
+		JOIN organization_users ou ON u.id = ou.user_id
+		WHERE %s
+	`, whereClause)
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count organization users: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT u.id, u.email, u.username, u.full_name, u.phone, u.timezone,
+		       u.notification_preferences, u.is_active AND ou.is_active, u.created_at, u.updated_at,
+		       ou.organization_id, ou.role
+		FROM users u
+		JOIN organization_users ou ON u.id = ou.user_id
+		WHERE %s
+		ORDER BY u.username ASC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argCount+1, argCount+2)
+
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search organization users: %w", err)
+	}
+	defer rows.Close()
+
+	users, err := scanOrganizationUsers(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func scanOrganizationUsers(rows *sql.Rows) ([]*domain.UserWithOrganization, error) {
 	var users []*domain.UserWithOrganization
 	for rows.Next() {
 		var user domain.UserWithOrganization
@@ -338,7 +453,7 @@ func (r *OrganizationRepository) ListUserOrganizations(ctx context.Context, user
 		SELECT o.id, o.name, o.slug, o.plan, o.settings, o.created_at, o.updated_at
 		FROM organizations o
 		JOIN organization_users ou ON o.id = ou.organization_id
-		WHERE ou.user_id = $1
+		WHERE ou.user_id = $1 AND ou.is_active
 		ORDER BY o.created_at DESC
 	`
 
@@ -375,3 +490,48 @@ func (r *OrganizationRepository) ListUserOrganizations(ctx context.Context, user
 
 	return orgs, nil
 }
+
+func (r *OrganizationRepository) ListUserMemberships(ctx context.Context, userID uuid.UUID) ([]*domain.OrganizationMembership, error) {
+	query := `
+		SELECT o.id, o.name, o.slug, o.plan, o.settings, o.created_at, o.updated_at, ou.role, ou.joined_at
+		FROM organizations o
+		JOIN organization_users ou ON o.id = ou.organization_id
+		WHERE ou.user_id = $1 AND ou.is_active
+		ORDER BY o.name, o.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user memberships: %w", err)
+	}
+	defer rows.Close()
+
+	var memberships []*domain.OrganizationMembership
+	for rows.Next() {
+		var m domain.OrganizationMembership
+		var settingsJSON []byte
+
+		err := rows.Scan(
+			&m.ID,
+			&m.Name,
+			&m.Slug,
+			&m.Plan,
+			&settingsJSON,
+			&m.CreatedAt,
+			&m.UpdatedAt,
+			&m.Role,
+			&m.JoinedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan membership: %w", err)
+		}
+
+		if err := json.Unmarshal(settingsJSON, &m.Settings); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal settings: %w", err)
+		}
+
+		memberships = append(memberships, &m)
+	}
+
+	return memberships, nil
+}
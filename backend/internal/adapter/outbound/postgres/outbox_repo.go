@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+type OutboxRepository struct {
+	db *DB
+}
+
+func NewOutboxRepository(db *DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// insertOutboxEvent records event as part of tx, so it is only published if
+// the change it describes commits.
+func insertOutboxEvent(ctx context.Context, tx *sql.Tx, event *domain.OutboxEvent) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox payload: %w", err)
+	}
+
+	query := `
+		INSERT INTO event_outbox (id, organization_id, event_type, alert_id, payload)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at
+	`
+
+	if err := tx.QueryRowContext(ctx, query,
+		event.ID, event.OrganizationID, event.EventType, event.AlertID, payload,
+	).Scan(&event.CreatedAt); err != nil {
+		return fmt.Errorf("failed to insert outbox event: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimUnpublished claims up to limit unpublished events created before
+// createdBefore, or whose publishing already failed, oldest first. Rows locked by a concurrent claim are skipped,
+// and a claim older than domain.OutboxClaimLease (e.g. after a crash) can be
+// taken over.
+func (r *OutboxRepository) ClaimUnpublished(ctx context.Context, createdBefore time.Time, limit int) ([]*domain.OutboxEvent, error) {
+	query := `
+		WITH claimable AS (
+			SELECT id
+			FROM event_outbox
+			WHERE published_at IS NULL
+			  AND (created_at <= $2 OR publish_failed_at IS NOT NULL)
+			  AND (claimed_at IS NULL OR claimed_at <= $3)
+			ORDER BY created_at ASC
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE event_outbox o
+		SET claimed_at = $1
+		FROM claimable
+		WHERE o.id = claimable.id
+		RETURNING o.id, o.organization_id, o.event_type, o.alert_id, o.payload,
+			o.claimed_at, o.published_at, o.created_at
+	`
+
+	now := time.Now()
+	rows, err := r.db.QueryContext(ctx, query, now, createdBefore, now.Add(-domain.OutboxClaimLease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*domain.OutboxEvent
+	for rows.Next() {
+		event := &domain.OutboxEvent{}
+		var payload []byte
+		if err := rows.Scan(
+			&event.ID, &event.OrganizationID, &event.EventType, &event.AlertID, &payload,
+			&event.ClaimedAt, &event.PublishedAt, &event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		if err := json.Unmarshal(payload, &event.Payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal outbox payload: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbox events: %w", err)
+	}
+
+	// RETURNING doesn't keep the claim order; publish the oldest first
+	slices.SortFunc(events, func(a, b *domain.OutboxEvent) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return events, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE event_outbox SET published_at = NOW() WHERE id = $1 AND published_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark outbox event published: %w", err)
+	}
+
+	return nil
+}
+
+// MarkPublishFailed records that publishing the event failed, so the next
+// claim picks it up without waiting for it to age
+func (r *OutboxRepository) MarkPublishFailed(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE event_outbox SET publish_failed_at = NOW() WHERE id = $1 AND published_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark outbox event failed: %w", err)
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 
 	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 )
@@ -182,9 +183,9 @@ func (r *ScheduleRepository) CreateRotation(ctx context.Context, rotation *domai
 	query := `
 		INSERT INTO schedule_rotations (
 			id, schedule_id, name, rotation_type, rotation_length,
-			start_date, start_time, end_time, handoff_day, handoff_time
+			start_date, start_time, end_time, handoff_day, handoff_time, team_id
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING created_at, updated_at
 	`
 
@@ -201,6 +202,7 @@ func (r *ScheduleRepository) CreateRotation(ctx context.Context, rotation *domai
 		rotation.EndTime,
 		rotation.HandoffDay,
 		rotation.HandoffTime,
+		rotation.TeamID,
 	).Scan(&rotation.CreatedAt, &rotation.UpdatedAt)
 
 	if err != nil {
@@ -214,7 +216,7 @@ func (r *ScheduleRepository) GetRotation(ctx context.Context, id uuid.UUID) (*do
 	query := `
 		SELECT id, schedule_id, name, rotation_type, rotation_length,
 		       start_date, start_time, end_time, handoff_day, handoff_time,
-		       created_at, updated_at
+		       team_id, created_at, updated_at
 		FROM schedule_rotations
 		WHERE id = $1
 	`
@@ -233,6 +235,7 @@ func (r *ScheduleRepository) GetRotation(ctx context.Context, id uuid.UUID) (*do
 		&rotation.EndTime,
 		&rotation.HandoffDay,
 		&rotation.HandoffTime,
+		&rotation.TeamID,
 		&rotation.CreatedAt,
 		&rotation.UpdatedAt,
 	)
@@ -254,7 +257,7 @@ func (r *ScheduleRepository) UpdateRotation(ctx context.Context, rotation *domai
 		UPDATE schedule_rotations
 		SET name = $2, rotation_type = $3, rotation_length = $4,
 		    start_date = $5, start_time = $6, end_time = $7,
-		    handoff_day = $8, handoff_time = $9
+		    handoff_day = $8, handoff_time = $9, team_id = $10
 		WHERE id = $1
 		RETURNING updated_at
 	`
@@ -271,6 +274,7 @@ func (r *ScheduleRepository) UpdateRotation(ctx context.Context, rotation *domai
 		rotation.EndTime,
 		rotation.HandoffDay,
 		rotation.HandoffTime,
+		rotation.TeamID,
 	).Scan(&rotation.UpdatedAt)
 
 	if err != nil {
@@ -304,7 +308,7 @@ func (r *ScheduleRepository) ListRotations(ctx context.Context, scheduleID uuid.
 	query := `
 		SELECT id, schedule_id, name, rotation_type, rotation_length,
 		       start_date, start_time, end_time, handoff_day, handoff_time,
-		       created_at, updated_at
+		       team_id, created_at, updated_at
 		FROM schedule_rotations
 		WHERE schedule_id = $1
 		ORDER BY created_at ASC
@@ -332,6 +336,103 @@ func (r *ScheduleRepository) ListRotations(ctx context.Context, scheduleID uuid.
 			&rotation.EndTime,
 			&rotation.HandoffDay,
 			&rotation.HandoffTime,
+			&rotation.TeamID,
+			&rotation.CreatedAt,
+			&rotation.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rotation: %w", err)
+		}
+
+		rotation.RotationType = domain.RotationType(rotationType)
+		rotations = append(rotations, &rotation)
+	}
+
+	return rotations, nil
+}
+
+// ListRotationsByTeam returns the rotations whose participants follow the team.
+func (r *ScheduleRepository) ListRotationsByTeam(ctx context.Context, teamID uuid.UUID) ([]*domain.ScheduleRotation, error) {
+	query := `
+		SELECT id, schedule_id, name, rotation_type, rotation_length,
+		       start_date, start_time, end_time, handoff_day, handoff_time,
+		       team_id, created_at, updated_at
+		FROM schedule_rotations
+		WHERE team_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rotations: %w", err)
+	}
+	defer rows.Close()
+
+	var rotations []*domain.ScheduleRotation
+	for rows.Next() {
+		var rotation domain.ScheduleRotation
+		var rotationType string
+
+		err := rows.Scan(
+			&rotation.ID,
+			&rotation.ScheduleID,
+			&rotation.Name,
+			&rotationType,
+			&rotation.RotationLength,
+			&rotation.StartDate,
+			&rotation.StartTime,
+			&rotation.EndTime,
+			&rotation.HandoffDay,
+			&rotation.HandoffTime,
+			&rotation.TeamID,
+			&rotation.CreatedAt,
+			&rotation.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rotation: %w", err)
+		}
+
+		rotation.RotationType = domain.RotationType(rotationType)
+		rotations = append(rotations, &rotation)
+	}
+
+	return rotations, nil
+}
+
+// ListAllRotations pages through the rotations of every schedule.
+func (r *ScheduleRepository) ListAllRotations(ctx context.Context, limit, offset int) ([]*domain.ScheduleRotation, error) {
+	query := `
+		SELECT id, schedule_id, name, rotation_type, rotation_length,
+		       start_date, start_time, end_time, handoff_day, handoff_time,
+		       team_id, created_at, updated_at
+		FROM schedule_rotations
+		ORDER BY created_at ASC, id ASC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rotations: %w", err)
+	}
+	defer rows.Close()
+
+	var rotations []*domain.ScheduleRotation
+	for rows.Next() {
+		var rotation domain.ScheduleRotation
+		var rotationType string
+
+		err := rows.Scan(
+			&rotation.ID,
+			&rotation.ScheduleID,
+			&rotation.Name,
+			&rotationType,
+			&rotation.RotationLength,
+			&rotation.StartDate,
+			&rotation.StartTime,
+			&rotation.EndTime,
+			&rotation.HandoffDay,
+			&rotation.HandoffTime,
+			&rotation.TeamID,
 			&rotation.CreatedAt,
 			&rotation.UpdatedAt,
 		)
@@ -475,6 +576,33 @@ func (r *ScheduleRepository) ReorderParticipants(ctx context.Context, rotationID
 	return nil
 }
 
+func (r *ScheduleRepository) SetParticipants(ctx context.Context, rotationID uuid.UUID, userIDs []uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schedule_rotation_participants WHERE rotation_id = $1`, rotationID); err != nil {
+		return fmt.Errorf("failed to clear participants: %w", err)
+	}
+	for position, userID := range userIDs {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO schedule_rotation_participants (id, rotation_id, user_id, position)
+			VALUES ($1, $2, $3, $4)
+		`, uuid.New(), rotationID, userID, position)
+		if err != nil {
+			return fmt.Errorf("failed to add participant: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 // Override operations
 
 func (r *ScheduleRepository) CreateOverride(ctx context.Context, override *domain.ScheduleOverride) error {
@@ -654,3 +782,123 @@ func (r *ScheduleRepository) GetOnCallUser(ctx context.Context, scheduleID uuid.
 	// For now, return nil to indicate no one is on-call (will be implemented in service layer)
 	return nil, fmt.Errorf("rotation-based on-call calculation not yet implemented in repository")
 }
+
+// ApplyConfig reconciles a schedule with its desired state in one transaction:
+// the schedule is updated, unlisted rotations are deleted, listed rotations are
+// created or updated, and each rotation's participants are replaced.
+func (r *ScheduleRepository) ApplyConfig(ctx context.Context, config *domain.ScheduleConfig) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	schedule := config.Schedule
+	err = tx.QueryRowContext(ctx, `
+		UPDATE schedules
+		SET name = $2, description = $3, timezone = $4, team_id = $5
+		WHERE id = $1
+		RETURNING updated_at
+	`, schedule.ID, schedule.Name, schedule.Description, schedule.Timezone, schedule.TeamID).Scan(&schedule.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("schedule not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update schedule: %w", err)
+	}
+
+	keep := make([]string, 0, len(config.Rotations))
+	for _, rotation := range config.Rotations {
+		keep = append(keep, rotation.ID.String())
+	}
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM schedule_rotations
+		WHERE schedule_id = $1 AND id <> ALL($2::uuid[])
+	`, schedule.ID, pq.Array(keep))
+	if err != nil {
+		return fmt.Errorf("failed to delete rotations: %w", err)
+	}
+
+	for _, rotation := range config.Rotations {
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO schedule_rotations (
+				id, schedule_id, name, rotation_type, rotation_length,
+				start_date, start_time, end_time, handoff_day, handoff_time, team_id
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			ON CONFLICT (id) DO UPDATE
+			SET name = EXCLUDED.name, rotation_type = EXCLUDED.rotation_type,
+			    rotation_length = EXCLUDED.rotation_length, start_date = EXCLUDED.start_date,
+			    start_time = EXCLUDED.start_time, end_time = EXCLUDED.end_time,
+			    handoff_day = EXCLUDED.handoff_day, handoff_time = EXCLUDED.handoff_time,
+			    team_id = EXCLUDED.team_id
+			RETURNING created_at, updated_at
+		`,
+			rotation.ID,
+			schedule.ID,
+			rotation.Name,
+			rotation.RotationType.String(),
+			rotation.RotationLength,
+			rotation.StartDate,
+			rotation.StartTime,
+			rotation.EndTime,
+			rotation.HandoffDay,
+			rotation.HandoffTime,
+			rotation.TeamID,
+		).Scan(&rotation.CreatedAt, &rotation.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to apply rotation %q: %w", rotation.Name, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM schedule_rotation_participants WHERE rotation_id = $1`, rotation.ID); err != nil {
+			return fmt.Errorf("failed to clear participants: %w", err)
+		}
+		for position, userID := range rotation.UserIDs {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO schedule_rotation_participants (id, rotation_id, user_id, position)
+				VALUES ($1, $2, $3, $4)
+			`, uuid.New(), rotation.ID, userID, position)
+			if err != nil {
+				return fmt.Errorf("failed to add participant: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Handoff operations
+
+func (r *ScheduleRepository) ClaimHandoff(ctx context.Context, handoff *domain.ScheduleHandoff) (bool, error) {
+	query := `
+		INSERT INTO schedule_handoff_notifications (rotation_id, user_id, shift_start, shift_end)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (rotation_id) DO UPDATE
+		SET user_id = EXCLUDED.user_id, shift_start = EXCLUDED.shift_start,
+		    shift_end = EXCLUDED.shift_end, notified_at = NOW()
+		WHERE schedule_handoff_notifications.shift_start < EXCLUDED.shift_start
+		RETURNING notified_at
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		handoff.RotationID,
+		handoff.UserID,
+		handoff.ShiftStart,
+		handoff.ShiftEnd,
+	).Scan(&handoff.NotifiedAt)
+
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim handoff: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+type SessionRepository struct {
+	db *DB
+}
+
+func NewSessionRepository(db *DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	query := `
+		INSERT INTO user_sessions (id, user_id, organization_id, refresh_token_hash, user_agent, ip_address, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at, last_used_at
+	`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		session.ID,
+		session.UserID,
+		session.OrganizationID,
+		session.RefreshTokenHash,
+		session.UserAgent,
+		session.IPAddress,
+		session.ExpiresAt,
+	).Scan(&session.CreatedAt, &session.LastUsedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) Rotate(ctx context.Context, id uuid.UUID, oldHash, newHash, ipAddress, userAgent string, expiresAt time.Time) error {
+	query := `
+		UPDATE user_sessions
+		SET refresh_token_hash = $3,
+		    ip_address = COALESCE(NULLIF($4, ''), ip_address),
+		    user_agent = COALESCE(NULLIF($5, ''), user_agent),
+		    last_used_at = NOW(),
+		    expires_at = $6
+		WHERE id = $1 AND refresh_token_hash = $2 AND revoked_at IS NULL AND expires_at > NOW()
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, oldHash, newHash, ipAddress, userAgent, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to rotate session: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) SwitchOrganization(ctx context.Context, userID, id, orgID uuid.UUID, newHash string, expiresAt time.Time) error {
+	query := `
+		UPDATE user_sessions
+		SET organization_id = $3,
+		    refresh_token_hash = $4,
+		    last_used_at = NOW(),
+		    expires_at = $5
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > NOW()
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID, orgID, newHash, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to switch session organization: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) ListActive(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error) {
+	query := `
+		SELECT id, user_id, organization_id, refresh_token_hash, user_agent, ip_address,
+		       created_at, last_used_at, expires_at, revoked_at
+		FROM user_sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_used_at DESC
+	`
+
+	return r.listSessions(ctx, query, userID)
+}
+
+func (r *SessionRepository) ListActiveInOrganization(ctx context.Context, userID, orgID uuid.UUID) ([]*domain.Session, error) {
+	query := `
+		SELECT id, user_id, organization_id, refresh_token_hash, user_agent, ip_address,
+		       created_at, last_used_at, expires_at, revoked_at
+		FROM user_sessions
+		WHERE user_id = $1 AND organization_id = $2 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_used_at DESC
+	`
+
+	return r.listSessions(ctx, query, userID, orgID)
+}
+
+func (r *SessionRepository) listSessions(ctx context.Context, query string, args ...interface{}) ([]*domain.Session, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*domain.Session
+	for rows.Next() {
+		var session domain.Session
+		if err := rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.OrganizationID,
+			&session.RefreshTokenHash,
+			&session.UserAgent,
+			&session.IPAddress,
+			&session.CreatedAt,
+			&session.LastUsedAt,
+			&session.ExpiresAt,
+			&session.RevokedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, &session)
+	}
+
+	return sessions, rows.Err()
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, userID, id uuid.UUID) error {
+	query := `
+		UPDATE user_sessions SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > NOW()
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) RevokeAll(ctx context.Context, userID uuid.UUID) (int, error) {
+	query := `
+		UPDATE user_sessions SET revoked_at = NOW()
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+	`
+
+	return r.revokeSessions(ctx, query, userID)
+}
+
+func (r *SessionRepository) RevokeAllInOrganization(ctx context.Context, userID, orgID uuid.UUID) (int, error) {
+	query := `
+		UPDATE user_sessions SET revoked_at = NOW()
+		WHERE user_id = $1 AND organization_id = $2 AND revoked_at IS NULL AND expires_at > NOW()
+	`
+
+	return r.revokeSessions(ctx, query, userID, orgID)
+}
+
+func (r *SessionRepository) revokeSessions(ctx context.Context, query string, args ...interface{}) (int, error) {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return int(rows), nil
+}
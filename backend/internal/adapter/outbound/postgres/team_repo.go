@@ -112,6 +112,85 @@ func (r *TeamRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// ForceDelete detaches schedules from the team, drops escalation targets that
+// point at it and deletes the team in a single transaction.
+func (r *TeamRepository) ForceDelete(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE schedules SET team_id = NULL WHERE team_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to detach schedules: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM escalation_targets
+		WHERE target_type = $1 AND target_id = $2
+	`, domain.EscalationTargetTypeTeam.String(), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete escalation targets: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM teams WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete team: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("team not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListDependencies returns the schedules and escalation policies that reference the team.
+func (r *TeamRepository) ListDependencies(ctx context.Context, id uuid.UUID) ([]domain.TeamDependency, error) {
+	query := `
+		SELECT $2::text, s.id, s.name
+		FROM schedules s
+		WHERE s.team_id = $1
+		UNION
+		SELECT $3::text, p.id, p.name
+		FROM escalation_targets t
+		JOIN escalation_rules er ON er.id = t.rule_id
+		JOIN escalation_policies p ON p.id = er.policy_id
+		WHERE t.target_type = $4 AND t.target_id = $1
+		ORDER BY 1, 3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, id,
+		domain.TeamDependencySchedule,
+		domain.TeamDependencyEscalationPolicy,
+		domain.EscalationTargetTypeTeam.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list team dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	dependencies := []domain.TeamDependency{}
+	for rows.Next() {
+		var dependency domain.TeamDependency
+		if err := rows.Scan(&dependency.Type, &dependency.ID, &dependency.Name); err != nil {
+			return nil, fmt.Errorf("failed to scan team dependency: %w", err)
+		}
+		dependencies = append(dependencies, dependency)
+	}
+
+	return dependencies, rows.Err()
+}
+
 func (r *TeamRepository) List(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]*domain.Team, error) {
 	query := `
 		SELECT id, organization_id, name, description, created_at, updated_at
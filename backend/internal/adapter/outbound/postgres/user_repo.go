@@ -324,3 +324,143 @@ func (r *UserRepository) ListByTeam(ctx context.Context, teamID uuid.UUID) ([]*d
 
 	return users, nil
 }
+
+func (r *UserRepository) Deactivate(ctx context.Context, orgID, userID, actorID uuid.UUID, note string) (*domain.UserDeactivation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE organization_users SET is_active = false WHERE organization_id = $1 AND user_id = $2`,
+		orgID, userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deactivate user: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return nil, fmt.Errorf("user not found in organization")
+	}
+
+	deactivation := &domain.UserDeactivation{UserID: userID}
+
+	result, err = tx.ExecContext(ctx, `
+		DELETE FROM team_members tm
+		USING teams t
+		WHERE tm.team_id = t.id AND t.organization_id = $1 AND tm.user_id = $2
+	`, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove team memberships: %w", err)
+	}
+	teams, _ := result.RowsAffected()
+	deactivation.TeamsLeft = int(teams)
+
+	rows, err := tx.QueryContext(ctx, `
+		DELETE FROM schedule_rotation_participants p
+		USING schedule_rotations r, schedules s
+		WHERE p.rotation_id = r.id AND r.schedule_id = s.id
+		  AND s.organization_id = $1 AND p.user_id = $2
+		RETURNING p.rotation_id
+	`, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove rotation participation: %w", err)
+	}
+	var rotationIDs []uuid.UUID
+	for rows.Next() {
+		var rotationID uuid.UUID
+		if err := rows.Scan(&rotationID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan rotation: %w", err)
+		}
+		rotationIDs = append(rotationIDs, rotationID)
+	}
+	rows.Close()
+	deactivation.RotationsLeft = len(rotationIDs)
+
+	// Keep positions contiguous. Participants move down one at a time in
+	// position order so UNIQUE(rotation_id, position) always holds.
+	for _, rotationID := range rotationIDs {
+		if err := renumberParticipants(ctx, tx, rotationID); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err = tx.ExecContext(ctx, `
+		DELETE FROM schedule_overrides o
+		USING schedules s
+		WHERE o.schedule_id = s.id AND s.organization_id = $1
+		  AND o.user_id = $2 AND o.end_time > NOW()
+	`, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove schedule overrides: %w", err)
+	}
+	overrides, _ := result.RowsAffected()
+	deactivation.OverridesRemoved = int(overrides)
+
+	rows, err = tx.QueryContext(ctx, `
+		UPDATE alerts
+		SET assigned_to_user_id = NULL
+		WHERE organization_id = $1 AND assigned_to_user_id = $2 AND status != 'closed'
+		RETURNING id
+	`, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unassign alerts: %w", err)
+	}
+	for rows.Next() {
+		var alertID uuid.UUID
+		if err := rows.Scan(&alertID); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan alert: %w", err)
+		}
+		deactivation.UnassignedAlertIDs = append(deactivation.UnassignedAlertIDs, alertID)
+	}
+	rows.Close()
+
+	// Flag the unassigned alerts so responders notice they need an owner
+	for _, alertID := range deactivation.UnassignedAlertIDs {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO alert_notes (alert_id, user_id, note) VALUES ($1, $2, $3)`,
+			alertID, actorID, note,
+		); err != nil {
+			return nil, fmt.Errorf("failed to note unassigned alert: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return deactivation, nil
+}
+
+func renumberParticipants(ctx context.Context, tx *sql.Tx, rotationID uuid.UUID) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id FROM schedule_rotation_participants
+		WHERE rotation_id = $1
+		ORDER BY position
+	`, rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to list participants: %w", err)
+	}
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan participant: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	for i, id := range ids {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE schedule_rotation_participants SET position = $1 WHERE id = $2`, i, id,
+		); err != nil {
+			return fmt.Errorf("failed to update participant position: %w", err)
+		}
+	}
+
+	return nil
+}
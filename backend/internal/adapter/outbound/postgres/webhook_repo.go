@@ -7,16 +7,19 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/jmoiron/sqlx"
 
 	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 )
 
+// deliveryClaimLease is how long a claimed delivery may stay in processing
+// before another worker is allowed to reclaim it.
+const deliveryClaimLease = 5 * time.Minute
+
 type webhookRepository struct {
-	db *sqlx.DB
+	db *DB
 }
 
-func NewWebhookRepository(db *sqlx.DB) *webhookRepository {
+func NewWebhookRepository(db *DB) *webhookRepository {
 	return &webhookRepository{db: db}
 }
 
@@ -29,13 +32,13 @@ func (r *webhookRepository) CreateEndpoint(ctx context.Context, endpoint *domain
 			alert_created, alert_updated, alert_acknowledged, alert_closed, alert_escalated,
 			incident_created, incident_updated, incident_resolved,
 			headers, timeout_seconds, max_retries, retry_delay_seconds,
-			created_at, updated_at
+			created_at, updated_at, events
 		) VALUES (
 			$1, $2, $3, $4, $5, $6,
 			$7, $8, $9, $10, $11,
 			$12, $13, $14,
 			$15, $16, $17, $18,
-			$19, $20
+			$19, $20, $21
 		)
 	`
 
@@ -44,6 +47,11 @@ func (r *webhookRepository) CreateEndpoint(ctx context.Context, endpoint *domain
 		return err
 	}
 
+	eventsJSON, err := marshalEndpointEvents(endpoint.Events)
+	if err != nil {
+		return err
+	}
+
 	now := time.Now()
 	endpoint.CreatedAt = now
 	endpoint.UpdatedAt = now
@@ -69,6 +77,7 @@ func (r *webhookRepository) CreateEndpoint(ctx context.Context, endpoint *domain
 		endpoint.RetryDelaySeconds,
 		endpoint.CreatedAt,
 		endpoint.UpdatedAt,
+		eventsJSON,
 	)
 
 	return err
@@ -80,13 +89,13 @@ func (r *webhookRepository) GetEndpointByID(ctx context.Context, id uuid.UUID) (
 			alert_created, alert_updated, alert_acknowledged, alert_closed, alert_escalated,
 			incident_created, incident_updated, incident_resolved,
 			headers, timeout_seconds, max_retries, retry_delay_seconds,
-			created_at, updated_at
+			created_at, updated_at, events
 		FROM webhook_endpoints
 		WHERE id = $1
 	`
 
 	var endpoint domain.WebhookEndpoint
-	var headersJSON []byte
+	var headersJSON, eventsJSON []byte
 
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&endpoint.ID,
@@ -109,6 +118,7 @@ func (r *webhookRepository) GetEndpointByID(ctx context.Context, id uuid.UUID) (
 		&endpoint.RetryDelaySeconds,
 		&endpoint.CreatedAt,
 		&endpoint.UpdatedAt,
+		&eventsJSON,
 	)
 
 	if err != nil {
@@ -123,6 +133,9 @@ func (r *webhookRepository) GetEndpointByID(ctx context.Context, id uuid.UUID) (
 			return nil, err
 		}
 	}
+	if err := json.Unmarshal(eventsJSON, &endpoint.Events); err != nil {
+		return nil, err
+	}
 
 	return &endpoint, nil
 }
@@ -133,7 +146,7 @@ func (r *webhookRepository) ListEndpoints(ctx context.Context, orgID uuid.UUID)
 			alert_created, alert_updated, alert_acknowledged, alert_closed, alert_escalated,
 			incident_created, incident_updated, incident_resolved,
 			headers, timeout_seconds, max_retries, retry_delay_seconds,
-			created_at, updated_at
+			created_at, updated_at, events
 		FROM webhook_endpoints
 		WHERE organization_id = $1
 		ORDER BY created_at DESC
@@ -148,7 +161,7 @@ func (r *webhookRepository) ListEndpoints(ctx context.Context, orgID uuid.UUID)
 	var endpoints []*domain.WebhookEndpoint
 	for rows.Next() {
 		var endpoint domain.WebhookEndpoint
-		var headersJSON []byte
+		var headersJSON, eventsJSON []byte
 
 		err := rows.Scan(
 			&endpoint.ID,
@@ -171,6 +184,7 @@ func (r *webhookRepository) ListEndpoints(ctx context.Context, orgID uuid.UUID)
 			&endpoint.RetryDelaySeconds,
 			&endpoint.CreatedAt,
 			&endpoint.UpdatedAt,
+			&eventsJSON,
 		)
 
 		if err != nil {
@@ -182,6 +196,9 @@ func (r *webhookRepository) ListEndpoints(ctx context.Context, orgID uuid.UUID)
 				return nil, err
 			}
 		}
+		if err := json.Unmarshal(eventsJSON, &endpoint.Events); err != nil {
+			return nil, err
+		}
 
 		endpoints = append(endpoints, &endpoint)
 	}
@@ -189,6 +206,15 @@ func (r *webhookRepository) ListEndpoints(ctx context.Context, orgID uuid.UUID)
 	return endpoints, rows.Err()
 }
 
+// marshalEndpointEvents encodes subscribed event types, storing no
+// subscriptions as an empty array rather than null
+func marshalEndpointEvents(events []string) ([]byte, error) {
+	if events == nil {
+		events = []string{}
+	}
+	return json.Marshal(events)
+}
+
 func (r *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
 	query := `
 		UPDATE webhook_endpoints
@@ -197,7 +223,7 @@ func (r *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *domain
 			alert_closed = $7, alert_escalated = $8,
 			incident_created = $9, incident_updated = $10, incident_resolved = $11,
 			headers = $12, timeout_seconds = $13, max_retries = $14,
-			retry_delay_seconds = $15, updated_at = $16
+			retry_delay_seconds = $15, updated_at = $16, events = $19
 		WHERE id = $17 AND organization_id = $18
 	`
 
@@ -206,6 +232,11 @@ func (r *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *domain
 		return err
 	}
 
+	eventsJSON, err := marshalEndpointEvents(endpoint.Events)
+	if err != nil {
+		return err
+	}
+
 	endpoint.UpdatedAt = time.Now()
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -227,6 +258,7 @@ func (r *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *domain
 		endpoint.UpdatedAt,
 		endpoint.ID,
 		endpoint.OrganizationID,
+		eventsJSON,
 	)
 
 	if err != nil {
@@ -245,6 +277,30 @@ func (r *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *domain
 	return nil
 }
 
+func (r *webhookRepository) UpdateEndpointSecret(ctx context.Context, id, orgID uuid.UUID, secret string) error {
+	query := `
+		UPDATE webhook_endpoints
+		SET secret = $1, updated_at = NOW()
+		WHERE id = $2 AND organization_id = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, secret, id, orgID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
 func (r *webhookRepository) DeleteEndpoint(ctx context.Context, id, orgID uuid.UUID) error {
 	query := `DELETE FROM webhook_endpoints WHERE id = $1 AND organization_id = $2`
 
@@ -334,19 +390,40 @@ func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *domain
 	return err
 }
 
-func (r *webhookRepository) GetPendingDeliveries(ctx context.Context, limit int) ([]*domain.WebhookDelivery, error) {
+// ClaimPendingDeliveries atomically moves up to limit due deliveries to the
+// processing status and returns them. Rows locked by a concurrent claim are
+// skipped, so overlapping workers never receive the same delivery. Deliveries
+// left in processing longer than deliveryClaimLease (e.g. after a crash) are
+// treated as abandoned and can be claimed again.
+func (r *webhookRepository) ClaimPendingDeliveries(ctx context.Context, limit int) ([]*domain.WebhookDelivery, error) {
 	query := `
-		SELECT id, webhook_endpoint_id, organization_id, event_type, payload,
-			status, attempts, last_attempt_at, next_retry_at,
-			response_status_code, response_body, error_message,
-			created_at, updated_at
-		FROM webhook_deliveries
-		WHERE status = $1 AND (next_retry_at IS NULL OR next_retry_at <= $2)
-		ORDER BY created_at ASC
-		LIMIT $3
+		WITH claimable AS (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE (status = $1 AND (next_retry_at IS NULL OR next_retry_at <= $3))
+			   OR (status = $2 AND updated_at <= $4)
+			ORDER BY created_at ASC
+			LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET status = $2, updated_at = $3
+		FROM claimable
+		WHERE d.id = claimable.id
+		RETURNING d.id, d.webhook_endpoint_id, d.organization_id, d.event_type, d.payload,
+			d.status, d.attempts, d.last_attempt_at, d.next_retry_at,
+			d.response_status_code, d.response_body, d.error_message,
+			d.created_at, d.updated_at
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, domain.WebhookDeliveryPending, time.Now(), limit)
+	now := time.Now()
+	rows, err := r.db.QueryContext(ctx, query,
+		domain.WebhookDeliveryPending,
+		domain.WebhookDeliveryProcessing,
+		now,
+		now.Add(-deliveryClaimLease),
+		limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -390,6 +467,39 @@ func (r *webhookRepository) GetPendingDeliveries(ctx context.Context, limit int)
 	return deliveries, rows.Err()
 }
 
+// CountPendingDeliveries returns the number of deliveries waiting to be sent,
+// including ones currently claimed by a worker.
+func (r *webhookRepository) CountPendingDeliveries(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM webhook_deliveries WHERE status IN ($1, $2)`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, domain.WebhookDeliveryPending, domain.WebhookDeliveryProcessing).Scan(&count)
+	return count, err
+}
+
+// GetPendingRetryAt returns the latest retry time among the endpoint's
+// pending deliveries created before the given time, or nil when there are
+// none. Deliveries pending without a retry time count as due at createdBefore.
+func (r *webhookRepository) GetPendingRetryAt(ctx context.Context, endpointID uuid.UUID, createdBefore time.Time) (*time.Time, error) {
+	query := `
+		SELECT COALESCE(MAX(next_retry_at), $3)
+		FROM webhook_deliveries
+		WHERE webhook_endpoint_id = $1 AND status = $2 AND created_at < $3
+		HAVING COUNT(*) > 0
+	`
+
+	var retryAt time.Time
+	err := r.db.QueryRowContext(ctx, query, endpointID, domain.WebhookDeliveryPending, createdBefore).Scan(&retryAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &retryAt, nil
+}
+
+// ListDeliveries runs on the read replica when one is configured
 func (r *webhookRepository) ListDeliveries(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]*domain.WebhookDelivery, error) {
 	query := `
 		SELECT id, webhook_endpoint_id, organization_id, event_type, payload,
@@ -402,7 +512,7 @@ func (r *webhookRepository) ListDeliveries(ctx context.Context, orgID uuid.UUID,
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, orgID, limit, offset)
+	rows, err := r.db.Reader().QueryContext(ctx, query, orgID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -452,10 +562,10 @@ func (r *webhookRepository) CreateIncomingToken(ctx context.Context, token *doma
 	query := `
 		INSERT INTO incoming_webhook_tokens (
 			id, organization_id, name, token, enabled, integration_type,
-			default_priority, default_tags, last_used_at, request_count,
+			default_priority, default_tags, field_mapping, last_used_at, request_count,
 			created_at, updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
 		)
 	`
 
@@ -464,6 +574,14 @@ func (r *webhookRepository) CreateIncomingToken(ctx context.Context, token *doma
 		return err
 	}
 
+	var mappingJSON []byte
+	if token.FieldMapping != nil {
+		mappingJSON, err = json.Marshal(token.FieldMapping)
+		if err != nil {
+			return err
+		}
+	}
+
 	now := time.Now()
 	token.CreatedAt = now
 	token.UpdatedAt = now
@@ -477,6 +595,7 @@ func (r *webhookRepository) CreateIncomingToken(ctx context.Context, token *doma
 		token.IntegrationType,
 		token.DefaultPriority,
 		tagsJSON,
+		mappingJSON,
 		token.LastUsedAt,
 		token.RequestCount,
 		token.CreatedAt,
@@ -487,18 +606,25 @@ func (r *webhookRepository) CreateIncomingToken(ctx context.Context, token *doma
 }
 
 func (r *webhookRepository) GetIncomingTokenByToken(ctx context.Context, tokenStr string) (*domain.IncomingWebhookToken, error) {
+	return r.getIncomingToken(ctx, `token = $1`, tokenStr)
+}
+
+func (r *webhookRepository) GetIncomingTokenByID(ctx context.Context, id, orgID uuid.UUID) (*domain.IncomingWebhookToken, error) {
+	return r.getIncomingToken(ctx, `id = $1 AND organization_id = $2`, id, orgID)
+}
+
+func (r *webhookRepository) getIncomingToken(ctx context.Context, where string, args ...interface{}) (*domain.IncomingWebhookToken, error) {
 	query := `
 		SELECT id, organization_id, name, token, enabled, integration_type,
-			default_priority, default_tags, last_used_at, request_count,
+			default_priority, default_tags, field_mapping, last_used_at, request_count,
 			created_at, updated_at
 		FROM incoming_webhook_tokens
-		WHERE token = $1
-	`
+		WHERE ` + where
 
 	var token domain.IncomingWebhookToken
-	var tagsJSON []byte
+	var tagsJSON, mappingJSON []byte
 
-	err := r.db.QueryRowContext(ctx, query, tokenStr).Scan(
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
 		&token.ID,
 		&token.OrganizationID,
 		&token.Name,
@@ -507,6 +633,7 @@ func (r *webhookRepository) GetIncomingTokenByToken(ctx context.Context, tokenSt
 		&token.IntegrationType,
 		&token.DefaultPriority,
 		&tagsJSON,
+		&mappingJSON,
 		&token.LastUsedAt,
 		&token.RequestCount,
 		&token.CreatedAt,
@@ -526,13 +653,19 @@ func (r *webhookRepository) GetIncomingTokenByToken(ctx context.Context, tokenSt
 		}
 	}
 
+	if len(mappingJSON) > 0 {
+		if err := json.Unmarshal(mappingJSON, &token.FieldMapping); err != nil {
+			return nil, err
+		}
+	}
+
 	return &token, nil
 }
 
 func (r *webhookRepository) ListIncomingTokens(ctx context.Context, orgID uuid.UUID) ([]*domain.IncomingWebhookToken, error) {
 	query := `
 		SELECT id, organization_id, name, token, enabled, integration_type,
-			default_priority, default_tags, last_used_at, request_count,
+			default_priority, default_tags, field_mapping, last_used_at, request_count,
 			created_at, updated_at
 		FROM incoming_webhook_tokens
 		WHERE organization_id = $1
@@ -548,7 +681,7 @@ func (r *webhookRepository) ListIncomingTokens(ctx context.Context, orgID uuid.U
 	var tokens []*domain.IncomingWebhookToken
 	for rows.Next() {
 		var token domain.IncomingWebhookToken
-		var tagsJSON []byte
+		var tagsJSON, mappingJSON []byte
 
 		err := rows.Scan(
 			&token.ID,
@@ -559,6 +692,7 @@ func (r *webhookRepository) ListIncomingTokens(ctx context.Context, orgID uuid.U
 			&token.IntegrationType,
 			&token.DefaultPriority,
 			&tagsJSON,
+			&mappingJSON,
 			&token.LastUsedAt,
 			&token.RequestCount,
 			&token.CreatedAt,
@@ -575,12 +709,42 @@ func (r *webhookRepository) ListIncomingTokens(ctx context.Context, orgID uuid.U
 			}
 		}
 
+		if len(mappingJSON) > 0 {
+			if err := json.Unmarshal(mappingJSON, &token.FieldMapping); err != nil {
+				return nil, err
+			}
+		}
+
 		tokens = append(tokens, &token)
 	}
 
 	return tokens, rows.Err()
 }
 
+func (r *webhookRepository) UpdateIncomingTokenValue(ctx context.Context, id, orgID uuid.UUID, token string) error {
+	query := `
+		UPDATE incoming_webhook_tokens
+		SET token = $1, updated_at = NOW()
+		WHERE id = $2 AND organization_id = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, token, id, orgID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
+
 func (r *webhookRepository) UpdateIncomingTokenUsage(ctx context.Context, id uuid.UUID) error {
 	query := `
 		UPDATE incoming_webhook_tokens
@@ -612,3 +776,123 @@ func (r *webhookRepository) DeleteIncomingToken(ctx context.Context, id, orgID u
 
 	return nil
 }
+
+// Incoming Webhook Requests
+
+// CreateIncomingRequest stores a received request and prunes the token's
+// request log down to the latest keep requests
+func (r *webhookRepository) CreateIncomingRequest(ctx context.Context, request *domain.IncomingWebhookRequest, keep int) error {
+	headersJSON, err := json.Marshal(request.Headers)
+	if err != nil {
+		return err
+	}
+	alertIDsJSON, err := json.Marshal(request.AlertIDs)
+	if err != nil {
+		return err
+	}
+	resolvedJSON, err := json.Marshal(request.ResolvedAlertIDs)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO incoming_webhook_requests (
+			id, token_id, organization_id, headers, body, body_truncated,
+			success, error, alerts_received, alert_ids, resolved_alert_ids, received_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+		)
+	`,
+		request.ID,
+		request.TokenID,
+		request.OrganizationID,
+		headersJSON,
+		request.Body,
+		request.BodyTruncated,
+		request.Success,
+		request.Error,
+		request.AlertsReceived,
+		alertIDsJSON,
+		resolvedJSON,
+		request.ReceivedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM incoming_webhook_requests
+		WHERE token_id = $1 AND id NOT IN (
+			SELECT id FROM incoming_webhook_requests
+			WHERE token_id = $1
+			ORDER BY received_at DESC
+			LIMIT $2
+		)
+	`, request.TokenID, keep)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ListIncomingRequests returns the token's logged requests, newest first
+func (r *webhookRepository) ListIncomingRequests(ctx context.Context, tokenID, orgID uuid.UUID) ([]*domain.IncomingWebhookRequest, error) {
+	query := `
+		SELECT id, token_id, organization_id, headers, body, body_truncated,
+			success, error, alerts_received, alert_ids, resolved_alert_ids, received_at
+		FROM incoming_webhook_requests
+		WHERE token_id = $1 AND organization_id = $2
+		ORDER BY received_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tokenID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := []*domain.IncomingWebhookRequest{}
+	for rows.Next() {
+		var request domain.IncomingWebhookRequest
+		var headersJSON, alertIDsJSON, resolvedJSON []byte
+
+		err := rows.Scan(
+			&request.ID,
+			&request.TokenID,
+			&request.OrganizationID,
+			&headersJSON,
+			&request.Body,
+			&request.BodyTruncated,
+			&request.Success,
+			&request.Error,
+			&request.AlertsReceived,
+			&alertIDsJSON,
+			&resolvedJSON,
+			&request.ReceivedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(headersJSON, &request.Headers); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(alertIDsJSON, &request.AlertIDs); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(resolvedJSON, &request.ResolvedAlertIDs); err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, &request)
+	}
+
+	return requests, rows.Err()
+}
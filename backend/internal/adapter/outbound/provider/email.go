@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"net/mail"
 	"net/smtp"
 	"strings"
 
@@ -232,7 +233,7 @@ func (p *EmailProvider) ValidateConfig(config json.RawMessage) error {
 		return fmt.Errorf("from_address is required")
 	}
 
-	if !strings.Contains(emailConfig.FromAddress, "@") {
+	if addr, err := mail.ParseAddress(emailConfig.FromAddress); err != nil || addr.Address != emailConfig.FromAddress {
 		return fmt.Errorf("from_address must be a valid email address")
 	}
 
@@ -242,12 +243,12 @@ func (p *EmailProvider) ValidateConfig(config json.RawMessage) error {
 		provider = "smtp" // Default to SMTP
 	}
 
-	if provider == "resend" {
+	switch provider {
+	case "resend":
 		if emailConfig.ResendAPIKey == "" {
 			return fmt.Errorf("resend_api_key is required when using Resend provider")
 		}
-	} else {
-		// SMTP validation
+	case "smtp":
 		if emailConfig.SMTPHost == "" {
 			return fmt.Errorf("smtp_host is required when using SMTP provider")
 		}
@@ -255,6 +256,12 @@ func (p *EmailProvider) ValidateConfig(config json.RawMessage) error {
 		if emailConfig.SMTPPort == 0 {
 			return fmt.Errorf("smtp_port is required when using SMTP provider")
 		}
+
+		if emailConfig.SMTPPort < 1 || emailConfig.SMTPPort > 65535 {
+			return fmt.Errorf("smtp_port must be between 1 and 65535")
+		}
+	default:
+		return fmt.Errorf("provider must be \"smtp\" or \"resend\"")
 	}
 
 	return nil
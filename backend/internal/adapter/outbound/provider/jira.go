@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+// JiraClient creates incident follow-up issues through the Jira REST API,
+// using the site and credentials from the organization's Jira settings
+type JiraClient struct {
+	httpClient *http.Client
+}
+
+// NewJiraClient creates a Jira REST API client
+func NewJiraClient() *JiraClient {
+	return &JiraClient{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// CreateIssue creates an issue for the incident in the configured project and
+// returns its key and browse URL
+func (c *JiraClient) CreateIssue(ctx context.Context, settings domain.JiraSettings, incident *domain.Incident) (*domain.IssueLink, error) {
+	baseURL := strings.TrimRight(settings.BaseURL, "/")
+
+	body, err := json.Marshal(map[string]interface{}{
+		"fields": jiraIssueFields(settings, incident),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Jira issue: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/rest/api/2/issue", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(settings.Email, settings.APIToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call Jira: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("jira returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("failed to decode Jira response: %w", err)
+	}
+	if created.Key == "" {
+		return nil, fmt.Errorf("jira response did not include an issue key")
+	}
+
+	return &domain.IssueLink{
+		Key: created.Key,
+		URL: baseURL + "/browse/" + created.Key,
+	}, nil
+}
+
+// jiraIssueFields maps the incident onto Jira issue fields. Configured extra
+// fields are applied first so the mapped fields always win.
+func jiraIssueFields(settings domain.JiraSettings, incident *domain.Incident) map[string]interface{} {
+	fields := make(map[string]interface{}, len(settings.Fields)+6)
+	for name, value := range settings.Fields {
+		fields[name] = value
+	}
+
+	fields["project"] = map[string]string{"key": settings.ProjectKey}
+	fields["issuetype"] = map[string]string{"name": settings.IssueType}
+	fields["summary"] = fmt.Sprintf("[%s] %s", strings.ToUpper(incident.Severity.String()), incident.Title)
+	fields["description"] = jiraIssueDescription(incident)
+	if priority, ok := settings.PriorityMap[incident.Severity]; ok {
+		fields["priority"] = map[string]string{"name": priority}
+	}
+	if len(settings.Labels) > 0 {
+		fields["labels"] = settings.Labels
+	}
+
+	return fields
+}
+
+func jiraIssueDescription(incident *domain.Incident) string {
+	description := fmt.Sprintf("Incident %s\nSeverity: %s\nPriority: %s\nStarted: %s",
+		incident.ID, incident.Severity, incident.Priority, incident.StartedAt.UTC().Format(time.RFC3339))
+	if incident.Description != nil && *incident.Description != "" {
+		description += "\n\n" + *incident.Description
+	}
+	return description
+}
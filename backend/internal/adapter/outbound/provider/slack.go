@@ -99,8 +99,8 @@ func (p *SlackProvider) ValidateConfig(config json.RawMessage) error {
 	}
 
 	// Validate webhook URL format
-	if len(slackConfig.WebhookURL) < 10 || slackConfig.WebhookURL[:8] != "https://" {
-		return fmt.Errorf("webhook_url must be a valid HTTPS URL")
+	if err := validateURL("webhook_url", slackConfig.WebhookURL, "https"); err != nil {
+		return err
 	}
 
 	return nil
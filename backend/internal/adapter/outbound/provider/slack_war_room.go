@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+// SlackAPIBaseURL is the root of the Slack Web API
+const SlackAPIBaseURL = "https://slack.com/api"
+
+// slackChannelNameLimit is the maximum length of a Slack channel name
+const slackChannelNameLimit = 80
+
+var slackChannelNameInvalid = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// SlackWarRoomClient opens incident war room channels through the Slack Web
+// API, authenticating with the organization's bot token
+type SlackWarRoomClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewSlackWarRoomClient creates a client for the Slack Web API at baseURL
+func NewSlackWarRoomClient(baseURL string) *SlackWarRoomClient {
+	return &SlackWarRoomClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// CreateWarRoom creates a public channel for the incident and posts its
+// summary. If only the summary fails, the channel is returned with the error.
+func (c *SlackWarRoomClient) CreateWarRoom(ctx context.Context, botToken string, incident *domain.Incident) (*domain.WarRoom, error) {
+	var created struct {
+		Channel struct {
+			ID string `json:"id"`
+		} `json:"channel"`
+	}
+	err := c.call(ctx, botToken, "conversations.create", map[string]interface{}{
+		"name": warRoomChannelName(incident),
+	}, &created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Slack channel: %w", err)
+	}
+
+	room := &domain.WarRoom{
+		ChannelID: created.Channel.ID,
+		URL:       "https://slack.com/app_redirect?channel=" + url.QueryEscape(created.Channel.ID),
+	}
+
+	err = c.call(ctx, botToken, "chat.postMessage", map[string]interface{}{
+		"channel": room.ChannelID,
+		"text":    warRoomSummary(incident),
+	}, nil)
+	if err != nil {
+		return room, fmt.Errorf("failed to post incident summary: %w", err)
+	}
+
+	return room, nil
+}
+
+// call invokes a Slack Web API method. Slack reports most failures with a 200
+// response whose ok field is false.
+func (c *SlackWarRoomClient) call(ctx context.Context, botToken, method string, payload map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Slack payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+botToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack API %s returned status %d", method, resp.StatusCode)
+	}
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+	if !result.OK {
+		return fmt.Errorf("slack API %s failed: %s", method, result.Error)
+	}
+
+	if out != nil {
+		if err := json.Unmarshal(raw, out); err != nil {
+			return fmt.Errorf("failed to decode %s response: %w", method, err)
+		}
+	}
+
+	return nil
+}
+
+// warRoomChannelName builds a unique, Slack-compatible channel name such as
+// "inc-1a2b3c4d-database-outage"
+func warRoomChannelName(incident *domain.Incident) string {
+	slug := slackChannelNameInvalid.ReplaceAllString(strings.ToLower(incident.Title), "-")
+	slug = strings.Trim(slug, "-_")
+
+	name := "inc-" + incident.ID.String()[:8]
+	if slug != "" {
+		name += "-" + slug
+	}
+	if len(name) > slackChannelNameLimit {
+		name = strings.TrimRight(name[:slackChannelNameLimit], "-_")
+	}
+	return name
+}
+
+func warRoomSummary(incident *domain.Incident) string {
+	summary := fmt.Sprintf("*[%s] %s*\nStatus: %s\nPriority: %s",
+		strings.ToUpper(incident.Severity.String()), incident.Title, incident.Status, incident.Priority)
+	if incident.Description != nil && *incident.Description != "" {
+		summary += "\n\n" + *incident.Description
+	}
+	return summary
+}
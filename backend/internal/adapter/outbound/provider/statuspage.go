@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+// StatuspageAPIBaseURL is the root of the Statuspage REST API
+const StatuspageAPIBaseURL = "https://api.statuspage.io/v1"
+
+// statuspageStatuses maps incident statuses to Statuspage incident statuses
+var statuspageStatuses = map[domain.IncidentStatus]string{
+	domain.IncidentStatusInvestigating: "investigating",
+	domain.IncidentStatusIdentified:    "identified",
+	domain.IncidentStatusMonitoring:    "monitoring",
+	domain.IncidentStatusResolved:      "resolved",
+}
+
+// statuspageUpdateBodies is the public update posted for each status
+var statuspageUpdateBodies = map[domain.IncidentStatus]string{
+	domain.IncidentStatusInvestigating: "We are currently investigating this issue.",
+	domain.IncidentStatusIdentified:    "The issue has been identified and a fix is being implemented.",
+	domain.IncidentStatusMonitoring:    "A fix has been implemented and we are monitoring the results.",
+	domain.IncidentStatusResolved:      "This incident has been resolved.",
+}
+
+// StatuspageClient publishes incidents through the Statuspage REST API,
+// authenticating with the organization's API key
+type StatuspageClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewStatuspageClient creates a client for the Statuspage REST API at baseURL
+func NewStatuspageClient(baseURL string) *StatuspageClient {
+	return &StatuspageClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// OpenIncident creates a Statuspage incident for the incident and returns its ID
+func (c *StatuspageClient) OpenIncident(ctx context.Context, settings domain.StatuspageSettings, incident *domain.Incident) (string, error) {
+	payload := statuspageIncidentPayload(settings, incident)
+	payload["name"] = incident.Title
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	path := "/pages/" + url.PathEscape(settings.PageID) + "/incidents"
+	if err := c.call(ctx, settings.APIKey, http.MethodPost, path, payload, &created); err != nil {
+		return "", err
+	}
+	if created.ID == "" {
+		return "", fmt.Errorf("statuspage response did not include an incident id")
+	}
+
+	return created.ID, nil
+}
+
+// UpdateIncident posts the incident's current status to its Statuspage incident
+func (c *StatuspageClient) UpdateIncident(ctx context.Context, settings domain.StatuspageSettings, statuspageIncidentID string, incident *domain.Incident) error {
+	path := "/pages/" + url.PathEscape(settings.PageID) + "/incidents/" + url.PathEscape(statuspageIncidentID)
+	return c.call(ctx, settings.APIKey, http.MethodPatch, path, statuspageIncidentPayload(settings, incident), nil)
+}
+
+func (c *StatuspageClient) call(ctx context.Context, apiKey, method, path string, incident map[string]interface{}, out interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"incident": incident})
+	if err != nil {
+		return fmt.Errorf("failed to marshal Statuspage payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "OAuth "+apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call Statuspage: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("statuspage returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode Statuspage response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// statuspageIncidentPayload describes the incident's current status. Affected
+// components are marked operational once it is resolved.
+func statuspageIncidentPayload(settings domain.StatuspageSettings, incident *domain.Incident) map[string]interface{} {
+	payload := map[string]interface{}{
+		"status": statuspageStatuses[incident.Status],
+		"body":   statuspageUpdateBodies[incident.Status],
+	}
+
+	if len(settings.ComponentIDs) > 0 {
+		componentStatus := statuspageComponentStatus(incident)
+		components := make(map[string]string, len(settings.ComponentIDs))
+		for _, id := range settings.ComponentIDs {
+			components[id] = componentStatus
+		}
+		payload["component_ids"] = settings.ComponentIDs
+		payload["components"] = components
+	}
+
+	return payload
+}
+
+// statuspageComponentStatus is the status of affected components while the
+// incident is at its current severity and status
+func statuspageComponentStatus(incident *domain.Incident) string {
+	switch {
+	case incident.Status == domain.IncidentStatusResolved:
+		return "operational"
+	case incident.Status == domain.IncidentStatusMonitoring:
+		return "degraded_performance"
+	case incident.Severity == domain.IncidentSeverityCritical:
+		return "major_outage"
+	case incident.Severity == domain.IncidentSeverityHigh:
+		return "partial_outage"
+	default:
+		return "degraded_performance"
+	}
+}
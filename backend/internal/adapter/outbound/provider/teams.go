@@ -97,8 +97,8 @@ func (p *TeamsProvider) ValidateConfig(config json.RawMessage) error {
 	}
 
 	// Validate webhook URL format
-	if len(teamsConfig.WebhookURL) < 10 || teamsConfig.WebhookURL[:8] != "https://" {
-		return fmt.Errorf("webhook_url must be a valid HTTPS URL")
+	if err := validateURL("webhook_url", teamsConfig.WebhookURL, "https"); err != nil {
+		return err
 	}
 
 	// Validate theme color format if provided (should be hex color without #)
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// validateURL checks that a config value is an absolute URL with a host and
+// one of the allowed schemes
+func validateURL(field, raw string, schemes ...string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("%s must be a valid URL", field)
+	}
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return nil
+		}
+	}
+	if len(schemes) == 1 {
+		return fmt.Errorf("%s must be a valid %s URL", field, strings.ToUpper(schemes[0]))
+	}
+	return fmt.Errorf("%s must start with %s://", field, strings.Join(schemes, ":// or "))
+}
@@ -112,12 +112,8 @@ func (p *WebhookProvider) ValidateConfig(config json.RawMessage) error {
 	}
 
 	// Validate URL format (should be HTTP or HTTPS)
-	if len(webhookConfig.URL) < 8 {
-		return fmt.Errorf("url must be a valid HTTP or HTTPS URL")
-	}
-
-	if webhookConfig.URL[:7] != "http://" && webhookConfig.URL[:8] != "https://" {
-		return fmt.Errorf("url must start with http:// or https://")
+	if err := validateURL("url", webhookConfig.URL, "http", "https"); err != nil {
+		return err
 	}
 
 	// Validate HTTP method if provided
@@ -6,16 +6,54 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Config struct {
 	Server    ServerConfig
 	Database  DatabaseConfig
 	JWT       JWTConfig
+	Password  PasswordConfig
+	Login     LoginConfig
+	AckLinks  AckLinkConfig
 	CORS      CORSConfig
 	SMTP      SMTPConfig
 	Email     EmailConfig
 	Telemetry TelemetryConfig
+	Workers   WorkersConfig
+	Metrics   MetricsConfig
+}
+
+// MetricsConfig controls the Prometheus /metrics endpoint
+type MetricsConfig struct {
+	Enabled         bool
+	Token           string        // Bearer token scrapers must send; required in production
+	RefreshInterval time.Duration // How often queue depth gauges are re-measured
+}
+
+// WorkersConfig holds background worker configuration
+type WorkersConfig struct {
+	Escalation WorkerConfig
+	Webhook    WorkerConfig
+	Handoff    WorkerConfig
+	Snooze     WorkerConfig
+	AutoClose  WorkerConfig
+	// Retention removes closed alerts past their organization's retention
+	Retention WorkerConfig
+	// NotificationRetry resends failed notifications
+	NotificationRetry WorkerConfig
+	// WebhookConcurrency caps how many endpoints the webhook worker delivers
+	// to at once; deliveries to one endpoint are always sent in order
+	WebhookConcurrency int
+}
+
+// WorkerConfig controls how often a background worker runs and how much work
+// it picks up per iteration. Disabled workers are not started at all, which
+// is useful for read-replica deployments.
+type WorkerConfig struct {
+	Enabled   bool
+	Interval  time.Duration
+	BatchSize int
 }
 
 // TelemetryConfig holds OpenTelemetry configuration
@@ -53,40 +91,126 @@ type EmailConfig struct {
 type ServerConfig struct {
 	Port string
 	Env  string
+	// Proxies (IPs or CIDRs) whose X-Forwarded-For header is trusted for the
+	// client IP. Empty trusts none and uses the connection's address, so
+	// clients can't spoof their IP to dodge login throttling.
+	TrustedProxies []string
 }
 
 type DatabaseConfig struct {
 	URL string
+	// Optional read replica for list and metrics queries; they may lag behind
+	// the primary by the replication delay
+	ReplicaURL string
+
+	// Connection pool tuning. Keep MaxOpenConns below the server's (or
+	// pgbouncer's) connection limit divided by the number of replicas.
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration // Recycle connections before proxies drop them; 0 keeps them forever
+	ConnMaxIdleTime time.Duration // Close connections idle this long; 0 keeps them forever
 }
 
+// Token lifetime bounds enforced by Validate
+const (
+	minAccessTTL  = time.Minute
+	maxAccessTTL  = 24 * time.Hour
+	maxRefreshTTL = 90 * 24 * time.Hour
+)
+
 type JWTConfig struct {
 	Secret        string
 	RefreshSecret string
-	AccessTTL     int // in minutes
-	RefreshTTL    int // in days
+	AccessTTL     time.Duration
+	RefreshTTL    time.Duration
+}
+
+// Lowest minimum password length Validate accepts
+const minPasswordLength = 8
+
+// PasswordConfig is the password policy enforced when users set a password
+type PasswordConfig struct {
+	MinLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+	Denylist       []string // Passwords rejected on top of the built-in common password list
+}
+
+// LoginConfig limits failed logins per account and per client IP
+type LoginConfig struct {
+	MaxAccountFailures int           // Consecutive failures before the account is locked
+	MaxIPFailures      int           // Failures from one IP within FailureWindow before its logins are refused
+	FailureWindow      time.Duration // How long a failure counts towards either limit
+	LockoutDuration    time.Duration // How long a locked account stays locked
+}
+
+// AckLinkConfig controls the links in escalation notifications that
+// acknowledge an alert without signing in
+type AckLinkConfig struct {
+	Secret  string        // Signs the link tokens; defaults to JWT_SECRET
+	TTL     time.Duration // How long a link works
+	BaseURL string        // Public URL of the API the links point at
 }
 
 type CORSConfig struct {
-	AllowedOrigins []string
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration // How long browsers may cache preflight responses
 }
 
 func Load() (*Config, error) {
 	cfg := &Config{
 		Server: ServerConfig{
-			Port: getEnv("SERVER_PORT", "8080"),
-			Env:  getEnv("ENV", "development"),
+			Port:           getEnv("SERVER_PORT", "8080"),
+			Env:            getEnv("ENV", "development"),
+			TrustedProxies: parseList(getEnv("TRUSTED_PROXIES", "")),
 		},
 		Database: DatabaseConfig{
-			URL: getEnv("DATABASE_URL", ""),
+			URL:             getEnv("DATABASE_URL", ""),
+			ReplicaURL:      getEnv("DATABASE_REPLICA_URL", ""),
+			MaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 5),
+			ConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+			ConnMaxIdleTime: getEnvDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
 		},
 		JWT: JWTConfig{
 			Secret:        getEnv("JWT_SECRET", ""),
 			RefreshSecret: getEnv("JWT_REFRESH_SECRET", ""),
-			AccessTTL:     getEnvInt("JWT_ACCESS_TTL", 60), // 60 minutes default
-			RefreshTTL:    getEnvInt("JWT_REFRESH_TTL", 7), // 7 days
+			// A bare integer keeps the historical units: minutes and days
+			AccessTTL:  getEnvDurationUnit("JWT_ACCESS_TTL", time.Minute, 60*time.Minute),
+			RefreshTTL: getEnvDurationUnit("JWT_REFRESH_TTL", 24*time.Hour, 7*24*time.Hour),
+		},
+		Password: PasswordConfig{
+			MinLength:      getEnvInt("PASSWORD_MIN_LENGTH", 10),
+			RequireUpper:   getEnv("PASSWORD_REQUIRE_UPPER", "true") == "true",
+			RequireLower:   getEnv("PASSWORD_REQUIRE_LOWER", "true") == "true",
+			RequireDigit:   getEnv("PASSWORD_REQUIRE_DIGIT", "true") == "true",
+			RequireSpecial: getEnv("PASSWORD_REQUIRE_SPECIAL", "true") == "true",
+			Denylist:       parseList(getEnv("PASSWORD_DENYLIST", "")),
+		},
+		Login: LoginConfig{
+			MaxAccountFailures: getEnvInt("LOGIN_MAX_FAILED_ATTEMPTS", 5),
+			MaxIPFailures:      getEnvInt("LOGIN_MAX_FAILED_ATTEMPTS_PER_IP", 20),
+			FailureWindow:      getEnvDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+			LockoutDuration:    getEnvDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+		},
+		AckLinks: AckLinkConfig{
+			Secret:  getEnv("ACK_LINK_SECRET", getEnv("JWT_SECRET", "")),
+			TTL:     getEnvDuration("ACK_LINK_TTL", 24*time.Hour),
+			BaseURL: getEnv("API_PUBLIC_URL", "http://localhost:8080"),
 		},
 		CORS: CORSConfig{
-			AllowedOrigins: parseAllowedOrigins(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:3000")),
+			AllowedOrigins:   parseList(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:3000")),
+			AllowedMethods:   parseList(getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS")),
+			AllowedHeaders:   parseList(getEnv("CORS_ALLOWED_HEADERS", "Origin,Content-Type,Accept,Authorization,X-API-Key,X-Request-ID,Idempotency-Key,X-Organization-ID")),
+			ExposedHeaders:   parseList(getEnv("CORS_EXPOSED_HEADERS", "Content-Length,X-Request-ID")),
+			AllowCredentials: getEnv("CORS_ALLOW_CREDENTIALS", "true") == "true",
+			MaxAge:           getEnvDuration("CORS_MAX_AGE", 2*time.Hour),
 		},
 		SMTP: SMTPConfig{
 			Host:     getEnv("SMTP_HOST", "localhost"),
@@ -114,6 +238,49 @@ func Load() (*Config, error) {
 			Insecure:     getEnv("OTEL_INSECURE", "false") == "true",
 			SampleRate:   getEnvFloat("OTEL_SAMPLE_RATE", 1.0),
 		},
+		Workers: WorkersConfig{
+			Escalation: WorkerConfig{
+				Enabled:   getEnv("WORKER_ESCALATION_ENABLED", "true") == "true",
+				Interval:  getEnvDuration("WORKER_ESCALATION_INTERVAL", 5*time.Second),
+				BatchSize: getEnvInt("WORKER_ESCALATION_BATCH_SIZE", 100),
+			},
+			Webhook: WorkerConfig{
+				Enabled:   getEnv("WORKER_WEBHOOK_ENABLED", "true") == "true",
+				Interval:  getEnvDuration("WORKER_WEBHOOK_INTERVAL", 30*time.Second),
+				BatchSize: getEnvInt("WORKER_WEBHOOK_BATCH_SIZE", 100),
+			},
+			Handoff: WorkerConfig{
+				Enabled:   getEnv("WORKER_HANDOFF_ENABLED", "true") == "true",
+				Interval:  getEnvDuration("WORKER_HANDOFF_INTERVAL", time.Minute),
+				BatchSize: getEnvInt("WORKER_HANDOFF_BATCH_SIZE", 100),
+			},
+			Snooze: WorkerConfig{
+				Enabled:   getEnv("WORKER_SNOOZE_ENABLED", "true") == "true",
+				Interval:  getEnvDuration("WORKER_SNOOZE_INTERVAL", time.Minute),
+				BatchSize: getEnvInt("WORKER_SNOOZE_BATCH_SIZE", 100),
+			},
+			AutoClose: WorkerConfig{
+				Enabled:   getEnv("WORKER_AUTO_CLOSE_ENABLED", "true") == "true",
+				Interval:  getEnvDuration("WORKER_AUTO_CLOSE_INTERVAL", 5*time.Minute),
+				BatchSize: getEnvInt("WORKER_AUTO_CLOSE_BATCH_SIZE", 100),
+			},
+			Retention: WorkerConfig{
+				Enabled:   getEnv("WORKER_RETENTION_ENABLED", "true") == "true",
+				Interval:  getEnvDuration("WORKER_RETENTION_INTERVAL", time.Hour),
+				BatchSize: getEnvInt("WORKER_RETENTION_BATCH_SIZE", 500),
+			},
+			NotificationRetry: WorkerConfig{
+				Enabled:   getEnv("WORKER_NOTIFICATION_RETRY_ENABLED", "true") == "true",
+				Interval:  getEnvDuration("WORKER_NOTIFICATION_RETRY_INTERVAL", 30*time.Second),
+				BatchSize: getEnvInt("WORKER_NOTIFICATION_RETRY_BATCH_SIZE", 100),
+			},
+			WebhookConcurrency: getEnvInt("WORKER_WEBHOOK_CONCURRENCY", 10),
+		},
+		Metrics: MetricsConfig{
+			Enabled:         getEnv("METRICS_ENABLED", "true") == "true",
+			Token:           getEnv("METRICS_TOKEN", ""),
+			RefreshInterval: getEnvDuration("METRICS_REFRESH_INTERVAL", 30*time.Second),
+		},
 	}
 
 	// Validate required fields
@@ -129,6 +296,18 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("DATABASE_URL is required")
 	}
 
+	if c.Database.MaxOpenConns <= 0 {
+		return fmt.Errorf("DB_MAX_OPEN_CONNS must be greater than zero")
+	}
+
+	if c.Database.MaxIdleConns < 0 || c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		return fmt.Errorf("DB_MAX_IDLE_CONNS must be between 0 and DB_MAX_OPEN_CONNS")
+	}
+
+	if c.Database.ConnMaxLifetime < 0 || c.Database.ConnMaxIdleTime < 0 {
+		return fmt.Errorf("DB_CONN_MAX_LIFETIME and DB_CONN_MAX_IDLE_TIME must not be negative")
+	}
+
 	if c.JWT.Secret == "" {
 		return fmt.Errorf("JWT_SECRET is required")
 	}
@@ -145,6 +324,106 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("JWT_REFRESH_SECRET must be at least 32 characters")
 	}
 
+	if c.JWT.AccessTTL < minAccessTTL || c.JWT.AccessTTL > maxAccessTTL {
+		return fmt.Errorf("JWT_ACCESS_TTL must be between %s and %s", minAccessTTL, maxAccessTTL)
+	}
+
+	if c.JWT.RefreshTTL <= c.JWT.AccessTTL {
+		return fmt.Errorf("JWT_REFRESH_TTL must be longer than JWT_ACCESS_TTL")
+	}
+
+	if c.JWT.RefreshTTL > maxRefreshTTL {
+		return fmt.Errorf("JWT_REFRESH_TTL must be at most %s", maxRefreshTTL)
+	}
+
+	if c.Password.MinLength < minPasswordLength {
+		return fmt.Errorf("PASSWORD_MIN_LENGTH must be at least %d", minPasswordLength)
+	}
+
+	if c.Login.MaxAccountFailures <= 0 || c.Login.MaxIPFailures <= 0 {
+		return fmt.Errorf("LOGIN_MAX_FAILED_ATTEMPTS and LOGIN_MAX_FAILED_ATTEMPTS_PER_IP must be greater than zero")
+	}
+
+	if c.Login.FailureWindow <= 0 || c.Login.LockoutDuration <= 0 {
+		return fmt.Errorf("LOGIN_FAILURE_WINDOW and LOGIN_LOCKOUT_DURATION must be greater than zero")
+	}
+
+	if len(c.AckLinks.Secret) < 32 {
+		return fmt.Errorf("ACK_LINK_SECRET must be at least 32 characters")
+	}
+
+	if c.AckLinks.TTL <= 0 {
+		return fmt.Errorf("ACK_LINK_TTL must be greater than zero")
+	}
+
+	if c.CORS.AllowCredentials {
+		for _, origin := range c.CORS.AllowedOrigins {
+			if origin == "*" {
+				return fmt.Errorf("CORS_ALLOWED_ORIGINS cannot be \"*\" when CORS_ALLOW_CREDENTIALS is true")
+			}
+		}
+	}
+
+	if c.CORS.MaxAge < 0 {
+		return fmt.Errorf("CORS_MAX_AGE must not be negative")
+	}
+
+	if c.Metrics.Enabled && c.Metrics.Token == "" && c.Server.Env == "production" {
+		return fmt.Errorf("METRICS_TOKEN is required in production when METRICS_ENABLED is true")
+	}
+
+	if c.Metrics.Enabled && c.Metrics.RefreshInterval <= 0 {
+		return fmt.Errorf("METRICS_REFRESH_INTERVAL must be greater than zero")
+	}
+
+	if err := c.Workers.Escalation.validate("WORKER_ESCALATION"); err != nil {
+		return err
+	}
+
+	if err := c.Workers.Webhook.validate("WORKER_WEBHOOK"); err != nil {
+		return err
+	}
+
+	if c.Workers.Webhook.Enabled && c.Workers.WebhookConcurrency <= 0 {
+		return fmt.Errorf("WORKER_WEBHOOK_CONCURRENCY must be greater than zero")
+	}
+
+	if err := c.Workers.Handoff.validate("WORKER_HANDOFF"); err != nil {
+		return err
+	}
+
+	if err := c.Workers.Snooze.validate("WORKER_SNOOZE"); err != nil {
+		return err
+	}
+
+	if err := c.Workers.AutoClose.validate("WORKER_AUTO_CLOSE"); err != nil {
+		return err
+	}
+
+	if err := c.Workers.Retention.validate("WORKER_RETENTION"); err != nil {
+		return err
+	}
+
+	if err := c.Workers.NotificationRetry.validate("WORKER_NOTIFICATION_RETRY"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (w WorkerConfig) validate(prefix string) error {
+	if !w.Enabled {
+		return nil
+	}
+
+	if w.Interval <= 0 {
+		return fmt.Errorf("%s_INTERVAL must be greater than zero", prefix)
+	}
+
+	if w.BatchSize <= 0 {
+		return fmt.Errorf("%s_BATCH_SIZE must be greater than zero", prefix)
+	}
+
 	return nil
 }
 
@@ -164,6 +443,25 @@ func getEnvInt(key string, fallback int) int {
 	return fallback
 }
 
+// getEnvDuration parses a Go duration string (e.g. "30s", "2m"); a bare
+// integer is treated as a number of seconds.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	return getEnvDurationUnit(key, time.Second, fallback)
+}
+
+// getEnvDurationUnit is getEnvDuration with a bare integer counted in unit.
+func getEnvDurationUnit(key string, unit, fallback time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+		if n, err := strconv.Atoi(value); err == nil {
+			return time.Duration(n) * unit
+		}
+	}
+	return fallback
+}
+
 func getEnvFloat(key string, fallback float64) float64 {
 	if value := os.Getenv(key); value != "" {
 		if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
@@ -173,9 +471,14 @@ func getEnvFloat(key string, fallback float64) float64 {
 	return fallback
 }
 
-func parseAllowedOrigins(origins string) []string {
-	if origins == "" {
-		return []string{}
+// parseList splits a comma-separated value, trimming whitespace and
+// dropping empty entries.
+func parseList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
-	return strings.Split(origins, ",")
+	return items
 }
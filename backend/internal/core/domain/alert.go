@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -33,6 +36,11 @@ type Alert struct {
 
 	// Snooze
 	SnoozedUntil *time.Time
+	SnoozedBy    *uuid.UUID
+	SnoozeReason *string
+
+	// Suppression: the maintenance window that kept this alert from paging
+	MaintenanceWindowID *uuid.UUID
 
 	// Escalation
 	EscalationPolicyID *uuid.UUID
@@ -49,6 +57,21 @@ type Alert struct {
 	UpdatedAt time.Time
 }
 
+// AlertNote is an investigation note left on an alert
+type AlertNote struct {
+	ID        uuid.UUID
+	AlertID   uuid.UUID
+	UserID    *uuid.UUID
+	Note      string
+	CreatedAt time.Time
+}
+
+// AlertNoteWithUser extends AlertNote with the author's details
+type AlertNoteWithUser struct {
+	AlertNote
+	User *User
+}
+
 type AlertPriority string
 
 const (
@@ -71,6 +94,20 @@ func (p AlertPriority) IsValid() bool {
 	return false
 }
 
+// priorityRank orders priorities from least to most urgent
+var priorityRank = map[AlertPriority]int{
+	PriorityP5: 1,
+	PriorityP4: 2,
+	PriorityP3: 3,
+	PriorityP2: 4,
+	PriorityP1: 5,
+}
+
+// AtLeast reports whether the priority is as urgent as min or more
+func (p AlertPriority) AtLeast(min AlertPriority) bool {
+	return priorityRank[p] >= priorityRank[min]
+}
+
 type AlertStatus string
 
 const (
@@ -92,6 +129,81 @@ func (s AlertStatus) IsValid() bool {
 	return false
 }
 
+const (
+	// MaxAlertTags caps how many tags an alert can carry
+	MaxAlertTags = 100
+	// MaxAlertTagLength caps the length of a single tag in characters
+	MaxAlertTagLength = 255
+)
+
+// NormalizeTag returns the canonical form of a tag, so "Production" and
+// " production " are the same tag
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// NormalizeTags normalizes each tag, dropping empty tags and duplicates while
+// keeping the first occurrence's position. Returns ErrTooManyTags or
+// ErrTagTooLong when the result exceeds the caps.
+func NormalizeTags(tags []string) ([]string, error) {
+	result := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > MaxAlertTagLength {
+			return nil, fmt.Errorf("%w: %q is longer than %d characters", ErrTagTooLong, tag, MaxAlertTagLength)
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	if len(result) > MaxAlertTags {
+		return nil, fmt.Errorf("%w: %d tags, at most %d allowed", ErrTooManyTags, len(result), MaxAlertTags)
+	}
+	return result, nil
+}
+
+// FitTags normalizes tags like NormalizeTags but drops tags longer than
+// MaxAlertTagLength and any beyond the first MaxAlertTags instead of failing.
+// It is for tags derived from external payloads, where a noisy label set
+// must not cost the alert. The dropped tags are returned normalized.
+func FitTags(tags []string) (kept, dropped []string) {
+	kept = make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		if utf8.RuneCountInString(tag) > MaxAlertTagLength || len(kept) == MaxAlertTags {
+			dropped = append(dropped, tag)
+			continue
+		}
+		kept = append(kept, tag)
+	}
+	return kept, dropped
+}
+
+// ApplyTagChanges returns tags with add appended and remove taken out, all
+// normalized. A tag in both add and remove ends up removed.
+func ApplyTagChanges(tags, add, remove []string) ([]string, error) {
+	removed := make(map[string]bool, len(remove))
+	for _, tag := range remove {
+		removed[NormalizeTag(tag)] = true
+	}
+
+	next := make([]string, 0, len(tags)+len(add))
+	for _, tag := range append(append([]string{}, tags...), add...) {
+		if !removed[NormalizeTag(tag)] {
+			next = append(next, tag)
+		}
+	}
+	return NormalizeTags(next)
+}
+
 type AlertSource string
 
 const (
@@ -113,6 +225,20 @@ type AlertWithAssignees struct {
 	AssignedTeam *Team
 }
 
+// AlertAssignment changes who owns an alert. A nil UserID or TeamID keeps the
+// current assignee unless the matching Clear flag is set. Setting
+// EscalationPolicyID switches the alert to that policy and restarts
+// escalation with Escalation, the policy's initial escalation event (nil when
+// the policy has no rules).
+type AlertAssignment struct {
+	UserID             *uuid.UUID
+	TeamID             *uuid.UUID
+	ClearUser          bool
+	ClearTeam          bool
+	EscalationPolicyID *uuid.UUID
+	Escalation         *AlertEscalationEvent
+}
+
 // AlertFilter for filtering and pagination
 type AlertFilter struct {
 	OrganizationID uuid.UUID
@@ -124,4 +250,41 @@ type AlertFilter struct {
 	Search         *string // Search in message and description
 	Limit          int
 	Offset         int
+	After          *ListCursor // Only records after the cursor, newest first
+}
+
+// AlertGroupBy is the key open alerts are clustered by in the grouped view
+type AlertGroupBy string
+
+const (
+	AlertGroupBySource AlertGroupBy = "source"
+	// AlertGroupByTag groups by the value of a key:value tag, e.g. service:api
+	AlertGroupByTag AlertGroupBy = "tag"
+	// AlertGroupByFingerprint groups by a prefix of the dedup key
+	AlertGroupByFingerprint AlertGroupBy = "fingerprint"
+)
+
+// AlertGroupRepresentatives is how many alerts are returned with each group
+const AlertGroupRepresentatives = 3
+
+// AlertGrouping selects how open alerts are clustered
+type AlertGrouping struct {
+	OrganizationID uuid.UUID
+	By             AlertGroupBy
+	TagKey         string // for AlertGroupByTag
+	PrefixLength   int    // for AlertGroupByFingerprint; 0 uses the whole key
+	Limit          int
+}
+
+// AlertGroup summarizes the open alerts sharing a key. Alerts without the tag
+// or dedup key being grouped by share a group with an empty key.
+type AlertGroup struct {
+	Key             string
+	Count           int
+	HighestPriority AlertPriority
+	FirstAlertAt    time.Time
+	LastAlertAt     time.Time
+	// Representatives are the group's most urgent alerts, newest first within
+	// a priority
+	Representatives []*Alert
 }
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AlertAckToken lets a paged user acknowledge an alert straight from the
+// notification, without signing in. A token is scoped to one alert and the
+// user it was sent to, expires, and works once.
+type AlertAckToken struct {
+	ID             uuid.UUID
+	AlertID        uuid.UUID
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	ExpiresAt      time.Time
+	UsedAt         *time.Time
+	CreatedAt      time.Time
+}
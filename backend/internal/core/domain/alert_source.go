@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RegisteredSource is a known alert source in an organization's registry.
+// Alerts whose source matches its name or one of its aliases, ignoring case,
+// are filed under Name.
+type RegisteredSource struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Name           string
+	Aliases        []string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// RegisteredSourceWithCount extends RegisteredSource with the number of
+// alerts filed under its name or any of its aliases
+type RegisteredSourceWithCount struct {
+	RegisteredSource
+	AlertCount int
+}
+
+// NormalizeSourceAlias returns the form aliases are stored and matched in
+func NormalizeSourceAlias(alias string) string {
+	return strings.ToLower(strings.TrimSpace(alias))
+}
+
+// Keys returns every spelling the source matches, normalized
+func (s *RegisteredSource) Keys() []string {
+	keys := []string{NormalizeSourceAlias(s.Name)}
+	for _, alias := range s.Aliases {
+		if key := NormalizeSourceAlias(alias); key != keys[0] {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
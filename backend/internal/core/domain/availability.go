@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserUnavailability is a period during which a user cannot be paged at all,
+// such as vacation or sick leave. Unlike Do Not Disturb, which only withholds
+// notifications, schedules pass over an unavailable user and put the next
+// participant on call instead.
+type UserUnavailability struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	StartTime time.Time
+	EndTime   time.Time
+	Reason    *string
+	CreatedAt time.Time
+}
+
+// Covers reports whether the period includes at
+func (u *UserUnavailability) Covers(at time.Time) bool {
+	return !at.Before(u.StartTime) && at.Before(u.EndTime)
+}
@@ -0,0 +1,84 @@
+package domain
+
+// Canonical display metadata for the enums clients render, so every UI uses
+// the same labels and colors. Colors are hex codes.
+
+// AlertPriorities lists alert priorities from most to least urgent
+var AlertPriorities = []AlertPriority{PriorityP1, PriorityP2, PriorityP3, PriorityP4, PriorityP5}
+
+// AlertStatuses lists alert statuses in lifecycle order
+var AlertStatuses = []AlertStatus{AlertStatusOpen, AlertStatusAcknowledged, AlertStatusSnoozed, AlertStatusClosed}
+
+// IncidentSeverities lists incident severities from most to least severe
+var IncidentSeverities = []IncidentSeverity{IncidentSeverityCritical, IncidentSeverityHigh, IncidentSeverityMedium, IncidentSeverityLow}
+
+// IncidentStatuses lists incident statuses in lifecycle order
+var IncidentStatuses = []IncidentStatus{IncidentStatusInvestigating, IncidentStatusIdentified, IncidentStatusMonitoring, IncidentStatusResolved}
+
+const (
+	colorRed    = "#dc2626"
+	colorOrange = "#ea580c"
+	colorYellow = "#ca8a04"
+	colorBlue   = "#2563eb"
+	colorPurple = "#9333ea"
+	colorGreen  = "#16a34a"
+	colorGray   = "#4b5563"
+)
+
+type displayInfo struct {
+	label string
+	color string
+}
+
+var priorityDisplay = map[AlertPriority]displayInfo{
+	PriorityP1: {"Critical", colorRed},
+	PriorityP2: {"High", colorOrange},
+	PriorityP3: {"Medium", colorYellow},
+	PriorityP4: {"Low", colorBlue},
+	PriorityP5: {"Informational", colorGray},
+}
+
+var alertStatusDisplay = map[AlertStatus]displayInfo{
+	AlertStatusOpen:         {"Open", colorRed},
+	AlertStatusAcknowledged: {"Acknowledged", colorYellow},
+	AlertStatusSnoozed:      {"Snoozed", colorPurple},
+	AlertStatusClosed:       {"Closed", colorGreen},
+}
+
+var severityDisplay = map[IncidentSeverity]displayInfo{
+	IncidentSeverityCritical: {"Critical", colorRed},
+	IncidentSeverityHigh:     {"High", colorOrange},
+	IncidentSeverityMedium:   {"Medium", colorYellow},
+	IncidentSeverityLow:      {"Low", colorBlue},
+}
+
+var incidentStatusDisplay = map[IncidentStatus]displayInfo{
+	IncidentStatusInvestigating: {"Investigating", colorYellow},
+	IncidentStatusIdentified:    {"Identified", colorBlue},
+	IncidentStatusMonitoring:    {"Monitoring", colorPurple},
+	IncidentStatusResolved:      {"Resolved", colorGreen},
+}
+
+// Label returns the human-readable name of the priority
+func (p AlertPriority) Label() string { return priorityDisplay[p].label }
+
+// Color returns the suggested color for the priority
+func (p AlertPriority) Color() string { return priorityDisplay[p].color }
+
+// Label returns the human-readable name of the status
+func (s AlertStatus) Label() string { return alertStatusDisplay[s].label }
+
+// Color returns the suggested color for the status
+func (s AlertStatus) Color() string { return alertStatusDisplay[s].color }
+
+// Label returns the human-readable name of the severity
+func (s IncidentSeverity) Label() string { return severityDisplay[s].label }
+
+// Color returns the suggested color for the severity
+func (s IncidentSeverity) Color() string { return severityDisplay[s].color }
+
+// Label returns the human-readable name of the status
+func (s IncidentStatus) Label() string { return incidentStatusDisplay[s].label }
+
+// Color returns the suggested color for the status
+func (s IncidentStatus) Color() string { return incidentStatusDisplay[s].color }
@@ -39,6 +39,24 @@ type DNDOverride struct {
 	Reason string    `json:"reason,omitempty"`
 }
 
+// DNDReason explains a Do Not Disturb decision
+type DNDReason string
+
+const (
+	DNDReasonNotConfigured  DNDReason = "not_configured"
+	DNDReasonDisabled       DNDReason = "disabled"
+	DNDReasonP1Override     DNDReason = "p1_override"
+	DNDReasonOverridePeriod DNDReason = "override_period"
+	DNDReasonWeeklySchedule DNDReason = "weekly_schedule"
+	DNDReasonOutsideWindow  DNDReason = "outside_window"
+)
+
+// DNDDecision is the outcome of checking whether a user may be paged
+type DNDDecision struct {
+	InDND  bool
+	Reason DNDReason
+}
+
 // ParseSchedule parses the raw JSON schedule into a structured format
 func (s *UserDNDSettings) ParseSchedule() (*DNDSchedule, error) {
 	if len(s.Schedule) == 0 {
@@ -6,12 +6,45 @@ import (
 	"github.com/google/uuid"
 )
 
+// Resend limits for verification codes. A failed delivery only holds the next
+// resend back briefly so users can retry once the email provider recovers.
+const (
+	OTPResendCooldown       = time.Minute
+	OTPFailedResendCooldown = 10 * time.Second
+)
+
+// OTPMaxAttempts is the number of wrong guesses after which a code is locked
+// and a new one has to be requested
+const OTPMaxAttempts = 5
+
 type EmailVerification struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	Email     string
-	OTP       string
-	ExpiresAt time.Time
-	Verified  bool
-	CreatedAt time.Time
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	Email          string
+	OTP            string
+	ExpiresAt      time.Time
+	Verified       bool
+	FailedAttempts int
+	SendAttempts   int
+	LastSentAt     *time.Time
+	LastSendError  *string
+	CreatedAt      time.Time
+}
+
+// DeliveryFailed reports whether the last attempt to email the code failed
+func (v *EmailVerification) DeliveryFailed() bool {
+	return v.LastSendError != nil
+}
+
+// Locked reports whether the code has used up its verification attempts
+func (v *EmailVerification) Locked() bool {
+	return v.FailedAttempts >= OTPMaxAttempts
+}
+
+// ResendAvailableAt returns the earliest time another code may be sent
+func (v *EmailVerification) ResendAvailableAt() time.Time {
+	if v.DeliveryFailed() {
+		return v.CreatedAt.Add(OTPFailedResendCooldown)
+	}
+	return v.CreatedAt.Add(OTPResendCooldown)
 }
@@ -4,18 +4,92 @@ import "errors"
 
 var (
 	// General errors
-	ErrNotFound     = errors.New("resource not found")
-	ErrUnauthorized = errors.New("unauthorized")
+	ErrNotFound       = errors.New("resource not found")
+	ErrUnauthorized   = errors.New("unauthorized")
+	ErrNegativeOffset = errors.New("offset must not be negative")
 
 	// Alert errors
 	ErrInvalidPriority = errors.New("invalid alert priority")
 	ErrInvalidStatus   = errors.New("invalid alert status")
+	ErrAlertNotFound   = errors.New("alert not found")
+	ErrEmptyAlertNote  = errors.New("note must not be empty")
+	ErrAlertNotAcked   = errors.New("alert is not acknowledged")
+	ErrTooManyTags     = errors.New("too many tags")
+	ErrTagTooLong      = errors.New("tag is too long")
+	ErrInvalidAckToken = errors.New("acknowledge link is invalid, expired or already used")
+
+	// User errors
+	ErrInvalidPhone          = errors.New("invalid phone number, expected E.164 format")
+	ErrCannotDeactivateSelf  = errors.New("you can't deactivate your own account")
+	ErrCannotDeactivateOwner = errors.New("the organization owner can't be deactivated")
+	ErrWeakPassword          = errors.New("password does not meet the password policy")
+
+	// Login errors
+	ErrLoginLocked = errors.New("too many failed login attempts, try again later")
+
+	// Organization membership errors
+	ErrNotOrganizationMember = errors.New("you are not a member of this organization")
+
+	// Email verification errors
+	ErrOTPResendCooldown = errors.New("a verification code was sent recently")
+	ErrOTPDeliveryFailed = errors.New("failed to deliver verification code")
+	ErrOTPLocked         = errors.New("too many incorrect attempts, request a new verification code")
+
+	// Team errors
+	ErrTeamInUse               = errors.New("team is referenced by other resources")
+	ErrInvitationEmailMismatch = errors.New("invitation was sent to a different email address")
+	ErrInvitationLoginRequired = errors.New("an account already exists for this email, log in to accept the invitation")
 
 	// Schedule errors
-	ErrInvalidRotationType = errors.New("invalid rotation type")
-	ErrInvalidTimezone     = errors.New("invalid timezone")
-	ErrOverlapOverride     = errors.New("override overlaps with existing override")
+	ErrInvalidRotationType   = errors.New("invalid rotation type")
+	ErrInvalidRotationLength = errors.New("invalid rotation length")
+	ErrInvalidTimezone       = errors.New("invalid timezone")
+	ErrOverlapOverride       = errors.New("override overlaps with existing override")
+	ErrOverrideNotMember     = errors.New("override user is not a participant of the schedule or a member of its team")
+	ErrInvalidTimeRange      = errors.New("invalid time range")
+	ErrNoOnCall              = errors.New("no on-call configured")
+
+	// Incident errors
+	ErrInvalidTimelineEventType = errors.New("invalid timeline event type")
+	ErrTimelineEventNotNote     = errors.New("only notes can be edited or deleted")
+	ErrNotNoteAuthor            = errors.New("only the note's author or an admin can change it")
+
+	// DND errors
+	ErrInvalidDNDSchedule  = errors.New("invalid DND schedule")
+	ErrInvalidDNDOverride  = errors.New("invalid DND override")
+	ErrDNDOverrideNotFound = errors.New("DND override not found")
+
+	// Availability errors
+	ErrInvalidUnavailability = errors.New("invalid unavailability period")
+
+	// Notification errors
+	ErrInvalidChannelConfig         = errors.New("invalid channel configuration")
+	ErrInvalidNotificationLogFilter = errors.New("invalid notification log filter")
+	ErrInvalidNotificationTemplate  = errors.New("invalid notification template")
+
+	// Maintenance window errors
+	ErrInvalidMaintenanceWindow  = errors.New("invalid maintenance window")
+	ErrMaintenanceWindowNotFound = errors.New("maintenance window not found")
+
+	// Alert source errors
+	ErrInvalidAlertSource  = errors.New("invalid alert source")
+	ErrAlertSourceNotFound = errors.New("alert source not found")
+	ErrAlertSourceConflict = errors.New("alert source name or alias already registered")
 
 	// Escalation errors
-	ErrInvalidEscalationTarget = errors.New("invalid escalation target type")
+	ErrInvalidEscalationTarget        = errors.New("invalid escalation target type")
+	ErrInvalidScheduleTargetMode      = errors.New("invalid schedule target mode")
+	ErrInvalidEscalationPolicy        = errors.New("invalid escalation policy")
+	ErrInvalidBusinessHours           = errors.New("invalid business hours")
+	ErrInvalidEscalationRuleHours     = errors.New("invalid escalation rule hours")
+	ErrInvalidEscalationRuleCondition = errors.New("invalid escalation rule condition")
+	ErrEscalationPolicyNotFound       = errors.New("escalation policy not found")
+	ErrEscalationTargetNotFound       = errors.New("escalation target not found in organization")
+
+	// Webhook errors
+	ErrInvalidFieldMapping = errors.New("invalid webhook field mapping")
+	ErrUnknownWebhookEvent = errors.New("unknown webhook event type")
+
+	// Organization import errors
+	ErrInvalidImport = errors.New("invalid import archive")
 )
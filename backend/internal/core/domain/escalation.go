@@ -2,6 +2,8 @@ package domain
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,18 +15,179 @@ type EscalationPolicy struct {
 	Name           string
 	Description    *string
 	RepeatEnabled  bool
-	RepeatCount    *int // NULL = infinite
+	RepeatCount    *int            // NULL = infinite
+	Conditions     json.RawMessage // NULL = only assigned explicitly or by routing rules
+	BusinessHours  json.RawMessage // NULL = every rule applies at all hours
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
 
+// ParseConditions parses the alerts the policy declares it applies to.
+// It returns nil for a policy without conditions.
+func (p *EscalationPolicy) ParseConditions() (*RoutingConditions, error) {
+	if len(p.Conditions) == 0 || string(p.Conditions) == "null" {
+		return nil, nil
+	}
+	var conditions RoutingConditions
+	if err := json.Unmarshal(p.Conditions, &conditions); err != nil {
+		return nil, err
+	}
+	return &conditions, nil
+}
+
+// ParseBusinessHours parses the policy's business-hours window. It returns
+// nil for a policy without one.
+func (p *EscalationPolicy) ParseBusinessHours() (*BusinessHours, error) {
+	if len(p.BusinessHours) == 0 || string(p.BusinessHours) == "null" {
+		return nil, nil
+	}
+	var hours BusinessHours
+	if err := json.Unmarshal(p.BusinessHours, &hours); err != nil {
+		return nil, err
+	}
+	return &hours, nil
+}
+
+// BusinessHours is the weekly window an escalation policy treats as business
+// hours; any other time is after hours
+type BusinessHours struct {
+	Weekly   []BusinessHoursSlot `json:"weekly"`
+	Timezone string              `json:"timezone"` // IANA timezone string
+}
+
+// BusinessHoursSlot is one day's business hours. Unlike DND slots they can't
+// run overnight; split them across two days instead.
+type BusinessHoursSlot struct {
+	Day   string `json:"day"`   // monday, tuesday, wednesday, thursday, friday, saturday, sunday
+	Start string `json:"start"` // HH:MM format (24-hour)
+	End   string `json:"end"`   // HH:MM format (24-hour), exclusive
+}
+
+// Validate checks the timezone and every weekly slot
+func (h *BusinessHours) Validate() error {
+	if _, err := time.LoadLocation(h.Timezone); err != nil || h.Timezone == "" {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidBusinessHours, h.Timezone)
+	}
+
+	for i, slot := range h.Weekly {
+		if !IsValidDay(slot.Day) {
+			return fmt.Errorf("%w: slot %d: invalid day %q", ErrInvalidBusinessHours, i, slot.Day)
+		}
+		if !isClockTime(slot.Start) {
+			return fmt.Errorf("%w: slot %d: start %q must be in HH:MM format", ErrInvalidBusinessHours, i, slot.Start)
+		}
+		if !isClockTime(slot.End) {
+			return fmt.Errorf("%w: slot %d: end %q must be in HH:MM format", ErrInvalidBusinessHours, i, slot.End)
+		}
+		if slot.End <= slot.Start {
+			return fmt.Errorf("%w: slot %d: end must be after start", ErrInvalidBusinessHours, i)
+		}
+	}
+
+	return nil
+}
+
+// Contains reports whether at falls within business hours, in the window's
+// timezone
+func (h *BusinessHours) Contains(at time.Time) bool {
+	loc, err := time.LoadLocation(h.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := at.In(loc)
+	day := strings.ToLower(local.Weekday().String())
+	clock := local.Format("15:04")
+	for _, slot := range h.Weekly {
+		if slot.Day == day && clock >= slot.Start && clock < slot.End {
+			return true
+		}
+	}
+	return false
+}
+
+// isClockTime reports whether value is a zero-padded 24-hour HH:MM time, as
+// slots are compared as strings
+func isClockTime(value string) bool {
+	t, err := time.Parse("15:04", value)
+	return err == nil && t.Format("15:04") == value
+}
+
+// EscalationRuleHours decides when a rule takes part in escalation, relative
+// to its policy's business hours
+type EscalationRuleHours string
+
+const (
+	EscalationRuleHoursAlways        EscalationRuleHours = "always"
+	EscalationRuleHoursBusinessHours EscalationRuleHours = "business_hours"
+	EscalationRuleHoursAfterHours    EscalationRuleHours = "after_hours"
+)
+
+// ParseEscalationRuleHours returns the hours a rule applies in, defaulting to
+// always
+func ParseEscalationRuleHours(hours string) (EscalationRuleHours, error) {
+	switch EscalationRuleHours(hours) {
+	case "", EscalationRuleHoursAlways:
+		return EscalationRuleHoursAlways, nil
+	case EscalationRuleHoursBusinessHours, EscalationRuleHoursAfterHours:
+		return EscalationRuleHours(hours), nil
+	default:
+		return "", ErrInvalidEscalationRuleHours
+	}
+}
+
+// ParseEscalationRuleMinPriority returns the lowest priority an alert must
+// have for a rule to page, or nil when the rule pages at any priority
+func ParseEscalationRuleMinPriority(priority string) (*AlertPriority, error) {
+	if priority == "" {
+		return nil, nil
+	}
+	min := AlertPriority(priority)
+	if !min.IsValid() {
+		return nil, fmt.Errorf("%w: unknown min_priority %q", ErrInvalidEscalationRuleCondition, priority)
+	}
+	return &min, nil
+}
+
 type EscalationRule struct {
 	ID              uuid.UUID
 	PolicyID        uuid.UUID
 	Position        int
 	EscalationDelay int // minutes
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	// DelaySeconds replaces EscalationDelay when set, for delays that aren't
+	// whole minutes
+	DelaySeconds *int
+	// Hours limits the rule to its policy's business hours or to after hours.
+	// It is ignored while the policy has no business hours.
+	Hours EscalationRuleHours
+	// MinPriority and OnlyIfUnassigned are checked against the alert when
+	// escalation reaches the rule; a rule whose conditions the alert no longer
+	// meets is skipped. A nil MinPriority matches every priority.
+	MinPriority      *AlertPriority
+	OnlyIfUnassigned bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Delay returns how long to wait on the rule before escalating to the next one
+func (r *EscalationRule) Delay() time.Duration {
+	if r.DelaySeconds != nil {
+		return time.Duration(*r.DelaySeconds) * time.Second
+	}
+	return time.Duration(r.EscalationDelay) * time.Minute
+}
+
+// MatchesAlert reports whether the alert meets the rule's conditions: its
+// priority is at least MinPriority and, for OnlyIfUnassigned, nobody has
+// taken it
+func (r *EscalationRule) MatchesAlert(alert *Alert) bool {
+	if r.MinPriority != nil && !alert.Priority.AtLeast(*r.MinPriority) {
+		return false
+	}
+	if r.OnlyIfUnassigned && (alert.AssignedToUserID != nil || alert.AssignedToTeamID != nil) {
+		return false
+	}
+	return true
 }
 
 type EscalationTarget struct {
@@ -32,6 +195,7 @@ type EscalationTarget struct {
 	RuleID               uuid.UUID
 	TargetType           EscalationTargetType
 	TargetID             uuid.UUID
+	ScheduleMode         ScheduleTargetMode // who a schedule target pages; empty for other target types
 	NotificationChannels json.RawMessage
 	CreatedAt            time.Time
 }
@@ -92,6 +256,37 @@ func (t EscalationTargetType) Validate() error {
 	}
 }
 
+// ScheduleTargetMode decides who a schedule target pages
+type ScheduleTargetMode string
+
+const (
+	// ScheduleTargetModeOnCall pages whoever is on call when the target is reached
+	ScheduleTargetModeOnCall ScheduleTargetMode = "on_call"
+	// ScheduleTargetModeAllMembers pages every participant of the schedule's rotations
+	ScheduleTargetModeAllMembers ScheduleTargetMode = "all_members"
+)
+
+// ParseScheduleTargetMode returns the mode for a target of the given type.
+// Schedule targets default to paging the current on-call user; other target
+// types can't have a mode.
+func ParseScheduleTargetMode(targetType EscalationTargetType, mode string) (ScheduleTargetMode, error) {
+	if targetType != EscalationTargetTypeSchedule {
+		if mode != "" {
+			return "", ErrInvalidScheduleTargetMode
+		}
+		return "", nil
+	}
+
+	switch ScheduleTargetMode(mode) {
+	case "", ScheduleTargetModeOnCall:
+		return ScheduleTargetModeOnCall, nil
+	case ScheduleTargetModeAllMembers:
+		return ScheduleTargetModeAllMembers, nil
+	default:
+		return "", ErrInvalidScheduleTargetMode
+	}
+}
+
 type EscalationEventType string
 
 const (
@@ -116,3 +311,86 @@ type EscalationRuleWithTargets struct {
 	EscalationRule
 	Targets []*EscalationTarget
 }
+
+// AppliesAt reports whether the rule takes part in escalation at the given
+// time. Rules apply at all hours while the policy has no business hours.
+func (p *EscalationPolicyWithRules) AppliesAt(rule *EscalationRule, at time.Time) bool {
+	if rule.Hours == "" || rule.Hours == EscalationRuleHoursAlways {
+		return true
+	}
+	hours, err := p.ParseBusinessHours()
+	if err != nil || hours == nil {
+		return true
+	}
+	return hours.Contains(at) == (rule.Hours == EscalationRuleHoursBusinessHours)
+}
+
+// NextRuleAt returns the index of the first rule after the one at index after
+// that applies at the given time, or -1 when there is none. Pass -1 to start
+// from the first rule.
+func (p *EscalationPolicyWithRules) NextRuleAt(after int, at time.Time) int {
+	for i := after + 1; i < len(p.Rules); i++ {
+		if p.AppliesAt(&p.Rules[i].EscalationRule, at) {
+			return i
+		}
+	}
+	return -1
+}
+
+// NextRuleFor is NextRuleAt that also skips rules whose conditions the alert
+// doesn't meet
+func (p *EscalationPolicyWithRules) NextRuleFor(alert *Alert, after int, at time.Time) int {
+	for i := p.NextRuleAt(after, at); i >= 0; i = p.NextRuleAt(i, at) {
+		if p.Rules[i].MatchesAlert(alert) {
+			return i
+		}
+	}
+	return -1
+}
+
+// PageOffsets returns, for each rule, how long after escalation starting at at
+// its targets are first paged. Escalation starts on the first rule that
+// applies and pages each later one when the delay of the one before it runs
+// out, so the first is only paged once the policy repeats. The offset is nil
+// for a rule that isn't paged, including rules that don't apply at at; the
+// business hours are taken as they are at at for the whole escalation.
+func (p *EscalationPolicyWithRules) PageOffsets(at time.Time) []*time.Duration {
+	offsets := make([]*time.Duration, len(p.Rules))
+	var elapsed time.Duration
+	first := p.NextRuleAt(-1, at)
+	for i := first; i >= 0; i = p.NextRuleAt(i, at) {
+		if i != first {
+			offset := elapsed
+			offsets[i] = &offset
+		}
+		elapsed += p.Rules[i].Delay()
+	}
+
+	if first >= 0 && p.RepeatEnabled && (p.RepeatCount == nil || *p.RepeatCount > 0) {
+		offsets[first] = &elapsed
+	}
+	return offsets
+}
+
+// NewEscalationEvent starts escalating an alert under policy from its first
+// rule that applies at now. It returns nil when there is no such rule.
+func NewEscalationEvent(alertID uuid.UUID, policy *EscalationPolicyWithRules, now time.Time) *AlertEscalationEvent {
+	level := policy.NextRuleAt(-1, now)
+	if level < 0 {
+		return nil
+	}
+
+	firstRule := policy.Rules[level]
+	nextEscalationTime := now.Add(firstRule.Delay())
+
+	return &AlertEscalationEvent{
+		ID:               uuid.New(),
+		AlertID:          alertID,
+		PolicyID:         policy.ID,
+		RuleID:           &firstRule.ID,
+		EventType:        EscalationEventTriggered,
+		CurrentLevel:     level,
+		RepeatCount:      0,
+		NextEscalationAt: &nextEscalationTime,
+	}
+}
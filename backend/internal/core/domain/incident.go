@@ -30,6 +30,19 @@ func (s IncidentSeverity) String() string {
 	return string(s)
 }
 
+// severityRank orders severities from least to most severe
+var severityRank = map[IncidentSeverity]int{
+	IncidentSeverityLow:      1,
+	IncidentSeverityMedium:   2,
+	IncidentSeverityHigh:     3,
+	IncidentSeverityCritical: 4,
+}
+
+// AtLeast reports whether the severity is as severe as min or more
+func (s IncidentSeverity) AtLeast(min IncidentSeverity) bool {
+	return severityRank[s] >= severityRank[min]
+}
+
 // IncidentStatus represents the current status of an incident
 type IncidentStatus string
 
@@ -68,6 +81,29 @@ type Incident struct {
 	ResolvedAt       *time.Time
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
+
+	// Slack war room opened for the incident, if any
+	SlackChannelID  *string
+	SlackChannelURL *string
+
+	// Jira issue tracking follow-up work for the incident, if any
+	JiraIssueKey *string
+	JiraIssueURL *string
+
+	// Statuspage incident mirroring the incident publicly, if any
+	StatuspageIncidentID *string
+}
+
+// WarRoom is a chat channel dedicated to an incident
+type WarRoom struct {
+	ChannelID string
+	URL       string
+}
+
+// IssueLink is an issue opened in an external tracker for an incident
+type IssueLink struct {
+	Key string
+	URL string
 }
 
 // ResponderRole represents the role of an incident responder
@@ -119,6 +155,8 @@ const (
 	TimelineEventAlertLinked      TimelineEventType = "alert_linked"
 	TimelineEventAlertUnlinked    TimelineEventType = "alert_unlinked"
 	TimelineEventResolved         TimelineEventType = "resolved"
+	// TimelineEventCommanderChanged records an incident commander handoff
+	TimelineEventCommanderChanged TimelineEventType = "commander_changed"
 )
 
 // IsValid checks if the timeline event type is valid
@@ -126,7 +164,8 @@ func (t TimelineEventType) IsValid() bool {
 	switch t {
 	case TimelineEventCreated, TimelineEventStatusChanged, TimelineEventSeverityChanged,
 		TimelineEventResponderAdded, TimelineEventResponderRemoved, TimelineEventNoteAdded,
-		TimelineEventAlertLinked, TimelineEventAlertUnlinked, TimelineEventResolved:
+		TimelineEventAlertLinked, TimelineEventAlertUnlinked, TimelineEventResolved,
+		TimelineEventCommanderChanged:
 		return true
 	}
 	return false
@@ -145,6 +184,9 @@ type IncidentTimelineEvent struct {
 	Description string
 	Metadata    map[string]interface{}
 	CreatedAt   time.Time
+
+	// EditedAt is set once a note has been changed after it was added
+	EditedAt *time.Time
 }
 
 // TimelineEventWithUser extends IncidentTimelineEvent with user details
@@ -168,15 +210,24 @@ type IncidentAlertWithDetails struct {
 	Alert *Alert
 }
 
+// SuggestedAlertLookback is how long before an incident started an alert may
+// have been created and still be suggested for it
+const SuggestedAlertLookback = time.Hour
+
+// SuggestedAlertLimit caps how many alerts are suggested for an incident
+const SuggestedAlertLimit = 20
+
 // IncidentFilter represents filters for listing incidents
 type IncidentFilter struct {
 	OrganizationID   uuid.UUID
 	Status           []IncidentStatus
 	Severity         []IncidentSeverity
 	AssignedToTeamID *uuid.UUID
+	ResponderUserID  *uuid.UUID // Only incidents the user is a responder on
 	Search           *string
 	Limit            int
 	Offset           int
+	After            *ListCursor // Only records after the cursor, newest first
 }
 
 // Validate validates the incident filter
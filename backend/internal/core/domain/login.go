@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginPolicy limits how many failed logins an account or a client IP may
+// make before further attempts are refused
+type LoginPolicy struct {
+	// MaxAccountFailures is the number of consecutive failed logins after
+	// which the account is locked for LockoutDuration
+	MaxAccountFailures int
+	// MaxIPFailures is the number of failed logins from one IP address
+	// within FailureWindow after which logins from that address are refused
+	MaxIPFailures int
+	// FailureWindow is how long a failure counts towards either limit
+	FailureWindow   time.Duration
+	LockoutDuration time.Duration
+}
+
+// DefaultLoginPolicy returns the policy used when none is configured
+func DefaultLoginPolicy() LoginPolicy {
+	return LoginPolicy{
+		MaxAccountFailures: 5,
+		MaxIPFailures:      20,
+		FailureWindow:      15 * time.Minute,
+		LockoutDuration:    15 * time.Minute,
+	}
+}
+
+// Reasons a login attempt failed
+const (
+	LoginFailureInvalidCredentials = "invalid_credentials"
+	LoginFailureAccountLocked      = "account_locked"
+	LoginFailureAccountDisabled    = "account_disabled"
+	LoginFailureIPThrottled        = "ip_throttled"
+)
+
+// LoginAttempt records a login, successful or not. UserID is nil when the
+// email doesn't belong to an account.
+type LoginAttempt struct {
+	ID            uuid.UUID
+	Email         string
+	UserID        *uuid.UUID
+	IPAddress     string
+	UserAgent     string
+	Succeeded     bool
+	FailureReason *string
+	AttemptedAt   time.Time
+}
+
+// LoginLockout tracks an account's consecutive failed logins
+type LoginLockout struct {
+	UserID         uuid.UUID
+	FailedAttempts int
+	LockedUntil    *time.Time
+	UpdatedAt      time.Time
+}
+
+// Locked reports whether the account is locked at the given time
+func (l *LoginLockout) Locked(at time.Time) bool {
+	return l.LockedUntil != nil && at.Before(*l.LockedUntil)
+}
+
+// LoginLockedError is returned when a login is refused because the account
+// or the client IP has failed too often. Until is when it may retry.
+type LoginLockedError struct {
+	Until time.Time
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("%s (retry after %s)", ErrLoginLocked, e.Until.UTC().Format(time.RFC3339))
+}
+
+func (e *LoginLockedError) Unwrap() error {
+	return ErrLoginLocked
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaintenanceWindow suppresses paging for alerts matching its conditions
+// while it is active. Conditions use the routing rule condition format.
+type MaintenanceWindow struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Name           string
+	Description    *string
+	StartsAt       time.Time
+	EndsAt         time.Time
+	Conditions     json.RawMessage
+	CreatedBy      *uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// IsActive reports whether the window covers the given time
+func (w *MaintenanceWindow) IsActive(at time.Time) bool {
+	return !at.Before(w.StartsAt) && at.Before(w.EndsAt)
+}
+
+// ParseConditions parses the raw JSON conditions into a structured format
+func (w *MaintenanceWindow) ParseConditions() (*RoutingConditions, error) {
+	var conditions RoutingConditions
+	if len(w.Conditions) == 0 {
+		return &conditions, nil
+	}
+	if err := json.Unmarshal(w.Conditions, &conditions); err != nil {
+		return nil, err
+	}
+	return &conditions, nil
+}
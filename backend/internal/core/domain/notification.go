@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,8 +24,24 @@ const (
 	NotificationStatusPending NotificationStatus = "pending"
 	NotificationStatusSent    NotificationStatus = "sent"
 	NotificationStatusFailed  NotificationStatus = "failed"
+	// NotificationStatusSuppressedDND marks a notification that was not sent
+	// because the recipient was in Do Not Disturb
+	NotificationStatusSuppressedDND NotificationStatus = "suppressed_dnd"
+	// NotificationStatusSkippedUnavailable marks a notification that was not
+	// sent because the recipient was unavailable and skipped in the rotation
+	NotificationStatusSkippedUnavailable NotificationStatus = "skipped_unavailable"
 )
 
+// IsValid checks if the notification status is valid
+func (s NotificationStatus) IsValid() bool {
+	switch s {
+	case NotificationStatusPending, NotificationStatusSent, NotificationStatusFailed,
+		NotificationStatusSuppressedDND, NotificationStatusSkippedUnavailable:
+		return true
+	}
+	return false
+}
+
 // NotificationChannel represents a notification delivery channel
 type NotificationChannel struct {
 	ID             uuid.UUID
@@ -37,6 +54,74 @@ type NotificationChannel struct {
 	UpdatedAt      time.Time
 }
 
+// RedactedValue replaces secret values in channel configs that leave the server.
+const RedactedValue = "[REDACTED]"
+
+// sensitiveConfigKeys are channel config keys holding credentials. Slack and
+// Teams incoming webhook URLs embed their auth token, so they count as secrets.
+var sensitiveConfigKeys = map[string]bool{
+	"smtp_password":  true,
+	"resend_api_key": true,
+	"webhook_url":    true,
+	"headers":        true,
+}
+
+// RedactedConfig returns the channel config with credential values replaced by
+// RedactedValue. A config that is not a JSON object is redacted as a whole.
+func (c *NotificationChannel) RedactedConfig() json.RawMessage {
+	var config map[string]interface{}
+	if err := json.Unmarshal(c.Config, &config); err != nil {
+		redacted, _ := json.Marshal(RedactedValue)
+		return redacted
+	}
+	for key, value := range config {
+		if sensitiveConfigKeys[key] && value != nil && value != "" {
+			config[key] = RedactedValue
+		}
+	}
+	redacted, _ := json.Marshal(config)
+	return redacted
+}
+
+// Redacted returns a copy of the channel safe to return from read endpoints,
+// with credentials in its config replaced by RedactedValue
+func (c *NotificationChannel) Redacted() NotificationChannel {
+	redacted := *c
+	redacted.Config = c.RedactedConfig()
+	return redacted
+}
+
+// MergeRedactedConfig returns config with every credential still set to
+// RedactedValue replaced by the channel's stored value, so a config read back
+// from the API can be saved without rotating secrets it did not change
+func (c *NotificationChannel) MergeRedactedConfig(config json.RawMessage) (json.RawMessage, error) {
+	var incoming map[string]interface{}
+	if err := json.Unmarshal(config, &incoming); err != nil {
+		// Not an object; left for config validation to reject
+		return config, nil
+	}
+	var stored map[string]interface{}
+	if err := json.Unmarshal(c.Config, &stored); err != nil {
+		stored = map[string]interface{}{}
+	}
+
+	merged := false
+	for key, value := range incoming {
+		if sensitiveConfigKeys[key] && value == RedactedValue {
+			if previous, ok := stored[key]; ok {
+				incoming[key] = previous
+			} else {
+				delete(incoming, key)
+			}
+			merged = true
+		}
+	}
+	if !merged {
+		return config, nil
+	}
+	return json.Marshal(incoming)
+}
+
 // UserNotificationPreference represents a user's notification preferences for a specific channel
 type UserNotificationPreference struct {
 	ID           uuid.UUID
@@ -64,5 +149,64 @@ type NotificationLog struct {
 	Status         NotificationStatus
 	ErrorMessage   *string
 	SentAt         *time.Time
+	IsTest         bool // Sent by a channel test rather than for an alert
+	Attempts       int
+	NextRetryAt    *time.Time // Set while a failed notification is waiting to be retried
 	CreatedAt      time.Time
 }
+
+// Failed notifications are retried with exponential backoff until they reach
+// their attempt limit. Pages for P1 alerts get more attempts before they are
+// given up on.
+const (
+	NotificationMaxAttempts         = 5
+	NotificationMaxAttemptsCritical = 10
+	NotificationRetryBaseDelay      = 30 * time.Second
+	NotificationRetryMaxDelay       = 15 * time.Minute
+)
+
+// NotificationRetry is a failed notification that is due for another attempt
+type NotificationRetry struct {
+	NotificationLog
+	AlertPriority *AlertPriority // Priority of the alert being paged, if any
+	AlertStatus   *AlertStatus   // Status of the alert being paged, if any
+}
+
+// AlertHandled reports whether the alert being paged has been acknowledged,
+// closed or snoozed since, so paging about it again would be noise
+func (r *NotificationRetry) AlertHandled() bool {
+	return r.AlertStatus != nil && *r.AlertStatus != AlertStatusOpen
+}
+
+// MaxAttempts returns how many delivery attempts the notification gets
+func (r *NotificationRetry) MaxAttempts() int {
+	if r.AlertPriority != nil && *r.AlertPriority == PriorityP1 {
+		return NotificationMaxAttemptsCritical
+	}
+	return NotificationMaxAttempts
+}
+
+// NotificationRetryDelay returns how long to wait after the given number of
+// failed attempts before trying again
+func NotificationRetryDelay(attempts int) time.Duration {
+	delay := NotificationRetryBaseDelay
+	for i := 1; i < attempts && delay < NotificationRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > NotificationRetryMaxDelay {
+		delay = NotificationRetryMaxDelay
+	}
+	return delay
+}
+
+// NotificationLogFilter for filtering and pagination of notification logs.
+// From is inclusive and To exclusive.
+type NotificationLogFilter struct {
+	OrganizationID uuid.UUID
+	Status         []NotificationStatus
+	ChannelID      *uuid.UUID
+	From           *time.Time
+	To             *time.Time
+	Limit          int
+	Offset         int
+}
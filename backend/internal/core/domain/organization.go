@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +17,14 @@ type Organization struct {
 	UpdatedAt time.Time
 }
 
+// OrganizationMembership is an organization a user belongs to with their role
+// in it
+type OrganizationMembership struct {
+	Organization
+	Role     UserRole
+	JoinedAt time.Time
+}
+
 // PlanType represents the organization's subscription plan
 type PlanType string
 
@@ -36,3 +45,494 @@ func (p PlanType) IsValid() bool {
 	}
 	return false
 }
+
+// SettingOverrideMembershipPolicy is the organization settings key holding the
+// OverrideMembershipPolicy applied when schedule overrides are created.
+const SettingOverrideMembershipPolicy = "override_membership_policy"
+
+// OverrideMembershipPolicy controls whether override users must be a participant
+// of one of the schedule's rotations or a member of the schedule's team.
+type OverrideMembershipPolicy string
+
+const (
+	OverrideMembershipOff    OverrideMembershipPolicy = "off"
+	OverrideMembershipWarn   OverrideMembershipPolicy = "warn"
+	OverrideMembershipStrict OverrideMembershipPolicy = "strict"
+)
+
+func (p OverrideMembershipPolicy) IsValid() bool {
+	switch p {
+	case OverrideMembershipOff, OverrideMembershipWarn, OverrideMembershipStrict:
+		return true
+	}
+	return false
+}
+
+// OverrideMembershipPolicy returns the organization's override membership
+// policy, defaulting to off when unset or invalid.
+func (o *Organization) OverrideMembershipPolicy() OverrideMembershipPolicy {
+	value, _ := o.Settings[SettingOverrideMembershipPolicy].(string)
+	policy := OverrideMembershipPolicy(value)
+	if !policy.IsValid() {
+		return OverrideMembershipOff
+	}
+	return policy
+}
+
+// SettingDefaultTimezone is the organization settings key holding the IANA
+// timezone given to new schedules that don't name one.
+const SettingDefaultTimezone = "default_timezone"
+
+// FallbackTimezone is used when the organization has no valid default timezone.
+const FallbackTimezone = "UTC"
+
+// DefaultTimezone returns the organization's default timezone, falling back to
+// UTC when unset or not a known IANA zone.
+func (o *Organization) DefaultTimezone() string {
+	value, _ := o.Settings[SettingDefaultTimezone].(string)
+	if value == "" {
+		return FallbackTimezone
+	}
+	if _, err := time.LoadLocation(value); err != nil {
+		return FallbackTimezone
+	}
+	return value
+}
+
+// SettingIncidentAutoAssignOnCall is the organization settings key enabling
+// the on-call user of an alert's escalation schedule to be added as a
+// responder to incidents created from the alert.
+const SettingIncidentAutoAssignOnCall = "incident_auto_assign_on_call"
+
+// IncidentAutoAssignOnCall reports whether incidents created from an alert get
+// its on-call user as a responder. Off unless enabled.
+func (o *Organization) IncidentAutoAssignOnCall() bool {
+	enabled, _ := o.Settings[SettingIncidentAutoAssignOnCall].(bool)
+	return enabled
+}
+
+// SettingIncidentCloseLinkedAlerts is the organization settings key enabling
+// the alerts linked to an incident to be closed when it is resolved.
+const SettingIncidentCloseLinkedAlerts = "incident_close_linked_alerts"
+
+// IncidentCloseLinkedAlerts reports whether resolving an incident closes the
+// alerts linked to it, unless the request says otherwise. Off unless enabled.
+func (o *Organization) IncidentCloseLinkedAlerts() bool {
+	enabled, _ := o.Settings[SettingIncidentCloseLinkedAlerts].(bool)
+	return enabled
+}
+
+// SettingAlertAutoClose is the organization settings key holding the
+// AlertAutoClosePolicy applied by the auto-close worker.
+const SettingAlertAutoClose = "alert_auto_close"
+
+// AutoCloseReason is the close reason written on alerts closed for inactivity.
+const AutoCloseReason = "auto-closed after inactivity"
+
+// SourceResolvedReason is the close reason written on alerts closed because
+// their monitoring source reported them resolved.
+const SourceResolvedReason = "resolved at source"
+
+// DefaultAutoClosePriorities are the priorities auto-closed when a policy does
+// not list any. P1 and P2 are exempt unless listed explicitly.
+var DefaultAutoClosePriorities = []AlertPriority{PriorityP3, PriorityP4, PriorityP5}
+
+// AlertAutoClosePolicy closes open alerts of the listed priorities once they
+// have gone AfterHours without being touched. Zero AfterHours disables it.
+type AlertAutoClosePolicy struct {
+	AfterHours int
+	Priorities []AlertPriority
+}
+
+func (p AlertAutoClosePolicy) Enabled() bool {
+	return p.AfterHours > 0 && len(p.Priorities) > 0
+}
+
+// Settings returns the policy in its organization settings representation.
+func (p AlertAutoClosePolicy) Settings() map[string]interface{} {
+	priorities := make([]string, len(p.Priorities))
+	for i, priority := range p.Priorities {
+		priorities[i] = priority.String()
+	}
+	return map[string]interface{}{
+		"after_hours": p.AfterHours,
+		"priorities":  priorities,
+	}
+}
+
+// AlertAutoClosePolicy returns the organization's alert auto-close policy,
+// disabled when unset or malformed. Invalid priorities are ignored.
+func (o *Organization) AlertAutoClosePolicy() AlertAutoClosePolicy {
+	policy := AlertAutoClosePolicy{Priorities: DefaultAutoClosePriorities}
+
+	value, ok := o.Settings[SettingAlertAutoClose]
+	if !ok {
+		return policy
+	}
+
+	// Settings hold decoded JSON or values set in memory; normalize through JSON
+	var stored struct {
+		AfterHours int       `json:"after_hours"`
+		Priorities *[]string `json:"priorities"`
+	}
+	raw, err := json.Marshal(value)
+	if err != nil || json.Unmarshal(raw, &stored) != nil {
+		return policy
+	}
+
+	if stored.AfterHours > 0 {
+		policy.AfterHours = stored.AfterHours
+	}
+	if stored.Priorities != nil {
+		policy.Priorities = []AlertPriority{}
+		for _, name := range *stored.Priorities {
+			if priority := AlertPriority(name); priority.IsValid() {
+				policy.Priorities = append(policy.Priorities, priority)
+			}
+		}
+	}
+
+	return policy
+}
+
+// SettingAlertRetention is the organization settings key holding the
+// AlertRetentionPolicy applied by the retention worker.
+const SettingAlertRetention = "alert_retention"
+
+// AlertRetentionPolicy removes alerts that were closed more than AfterDays
+// ago from the alerts table, copying them to the archive first when Archive
+// is set. Alerts of the exempt priorities and alerts linked to an incident
+// are kept. In a dry run nothing is removed, the worker only reports what it
+// would remove. Zero AfterDays disables it.
+type AlertRetentionPolicy struct {
+	AfterDays        int
+	Archive          bool
+	ExemptPriorities []AlertPriority
+	DryRun           bool
+}
+
+func (p AlertRetentionPolicy) Enabled() bool {
+	return p.AfterDays > 0
+}
+
+// Settings returns the policy in its organization settings representation.
+func (p AlertRetentionPolicy) Settings() map[string]interface{} {
+	exempt := make([]string, len(p.ExemptPriorities))
+	for i, priority := range p.ExemptPriorities {
+		exempt[i] = priority.String()
+	}
+	return map[string]interface{}{
+		"after_days":        p.AfterDays,
+		"archive":           p.Archive,
+		"exempt_priorities": exempt,
+		"dry_run":           p.DryRun,
+	}
+}
+
+// AlertRetentionPolicy returns the organization's alert retention policy,
+// disabled when unset or malformed. Alerts are archived unless the policy
+// says otherwise. Invalid priorities are ignored.
+func (o *Organization) AlertRetentionPolicy() AlertRetentionPolicy {
+	policy := AlertRetentionPolicy{Archive: true, ExemptPriorities: []AlertPriority{}}
+
+	value, ok := o.Settings[SettingAlertRetention]
+	if !ok {
+		return policy
+	}
+
+	// Settings hold decoded JSON or values set in memory; normalize through JSON
+	var stored struct {
+		AfterDays        int      `json:"after_days"`
+		Archive          *bool    `json:"archive"`
+		ExemptPriorities []string `json:"exempt_priorities"`
+		DryRun           bool     `json:"dry_run"`
+	}
+	raw, err := json.Marshal(value)
+	if err != nil || json.Unmarshal(raw, &stored) != nil {
+		return policy
+	}
+
+	if stored.AfterDays > 0 {
+		policy.AfterDays = stored.AfterDays
+	}
+	if stored.Archive != nil {
+		policy.Archive = *stored.Archive
+	}
+	for _, name := range stored.ExemptPriorities {
+		if priority := AlertPriority(name); priority.IsValid() {
+			policy.ExemptPriorities = append(policy.ExemptPriorities, priority)
+		}
+	}
+	policy.DryRun = stored.DryRun
+
+	return policy
+}
+
+// SettingSlackWarRoom is the organization settings key holding the
+// SlackWarRoomSettings used when incidents are declared.
+const SettingSlackWarRoom = "slack_war_room"
+
+// SlackWarRoomSettings opens a dedicated Slack channel for incidents of at
+// least MinSeverity, using the bot token of the organization's Slack app. An
+// empty BotToken disables it.
+type SlackWarRoomSettings struct {
+	BotToken    string
+	MinSeverity IncidentSeverity
+}
+
+func (s SlackWarRoomSettings) Enabled() bool {
+	return s.BotToken != ""
+}
+
+// Settings returns the war room settings in their organization settings
+// representation. The bot token is redacted unless withSecrets is set.
+func (s SlackWarRoomSettings) Settings(withSecrets bool) map[string]interface{} {
+	token := s.BotToken
+	if token != "" && !withSecrets {
+		token = RedactedValue
+	}
+	return map[string]interface{}{
+		"bot_token":    token,
+		"min_severity": s.MinSeverity.String(),
+	}
+}
+
+// SlackWarRoom returns the organization's Slack war room settings, disabled
+// when unset or malformed. The minimum severity defaults to critical.
+func (o *Organization) SlackWarRoom() SlackWarRoomSettings {
+	settings := SlackWarRoomSettings{MinSeverity: IncidentSeverityCritical}
+
+	value, ok := o.Settings[SettingSlackWarRoom]
+	if !ok {
+		return settings
+	}
+
+	var stored struct {
+		BotToken    string `json:"bot_token"`
+		MinSeverity string `json:"min_severity"`
+	}
+	raw, err := json.Marshal(value)
+	if err != nil || json.Unmarshal(raw, &stored) != nil {
+		return settings
+	}
+
+	settings.BotToken = stored.BotToken
+	if severity := IncidentSeverity(stored.MinSeverity); severity.IsValid() {
+		settings.MinSeverity = severity
+	}
+
+	return settings
+}
+
+// SettingJira is the organization settings key holding the JiraSettings used
+// when incidents are declared.
+const SettingJira = "jira"
+
+// DefaultJiraIssueType is the issue type used when none is configured
+const DefaultJiraIssueType = "Task"
+
+// JiraSettings creates a Jira issue for incidents of the listed severities,
+// authenticating as Email with an API token. PriorityMap maps incident
+// severities to Jira priority names, and Fields are extra issue fields (such
+// as custom fields) sent as-is.
+type JiraSettings struct {
+	BaseURL     string
+	Email       string
+	APIToken    string
+	ProjectKey  string
+	IssueType   string
+	Severities  []IncidentSeverity
+	PriorityMap map[IncidentSeverity]string
+	Labels      []string
+	Fields      map[string]interface{}
+}
+
+func (s JiraSettings) Enabled() bool {
+	return s.BaseURL != "" && s.Email != "" && s.APIToken != "" && s.ProjectKey != ""
+}
+
+// Applies reports whether an incident of the given severity gets an issue
+func (s JiraSettings) Applies(severity IncidentSeverity) bool {
+	for _, sev := range s.Severities {
+		if sev == severity {
+			return true
+		}
+	}
+	return false
+}
+
+// Settings returns the Jira settings in their organization settings
+// representation. The API token is redacted unless withSecrets is set.
+func (s JiraSettings) Settings(withSecrets bool) map[string]interface{} {
+	token := s.APIToken
+	if token != "" && !withSecrets {
+		token = RedactedValue
+	}
+	severities := make([]string, len(s.Severities))
+	for i, sev := range s.Severities {
+		severities[i] = sev.String()
+	}
+	priorityMap := make(map[string]string, len(s.PriorityMap))
+	for sev, priority := range s.PriorityMap {
+		priorityMap[sev.String()] = priority
+	}
+	labels := s.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	fields := s.Fields
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"base_url":     s.BaseURL,
+		"email":        s.Email,
+		"api_token":    token,
+		"project_key":  s.ProjectKey,
+		"issue_type":   s.IssueType,
+		"severities":   severities,
+		"priority_map": priorityMap,
+		"labels":       labels,
+		"fields":       fields,
+	}
+}
+
+// Jira returns the organization's Jira settings, disabled when unset or
+// malformed. Issues default to the Task type for critical and high incidents.
+func (o *Organization) Jira() JiraSettings {
+	settings := JiraSettings{
+		IssueType:   DefaultJiraIssueType,
+		Severities:  []IncidentSeverity{IncidentSeverityCritical, IncidentSeverityHigh},
+		PriorityMap: map[IncidentSeverity]string{},
+	}
+
+	value, ok := o.Settings[SettingJira]
+	if !ok {
+		return settings
+	}
+
+	var stored struct {
+		BaseURL     string                 `json:"base_url"`
+		Email       string                 `json:"email"`
+		APIToken    string                 `json:"api_token"`
+		ProjectKey  string                 `json:"project_key"`
+		IssueType   string                 `json:"issue_type"`
+		Severities  []string               `json:"severities"`
+		PriorityMap map[string]string      `json:"priority_map"`
+		Labels      []string               `json:"labels"`
+		Fields      map[string]interface{} `json:"fields"`
+	}
+	raw, err := json.Marshal(value)
+	if err != nil || json.Unmarshal(raw, &stored) != nil {
+		return settings
+	}
+
+	settings.BaseURL = stored.BaseURL
+	settings.Email = stored.Email
+	settings.APIToken = stored.APIToken
+	settings.ProjectKey = stored.ProjectKey
+	settings.Labels = stored.Labels
+	settings.Fields = stored.Fields
+	if stored.IssueType != "" {
+		settings.IssueType = stored.IssueType
+	}
+	if stored.Severities != nil {
+		settings.Severities = []IncidentSeverity{}
+		for _, sev := range stored.Severities {
+			if severity := IncidentSeverity(sev); severity.IsValid() {
+				settings.Severities = append(settings.Severities, severity)
+			}
+		}
+	}
+	for sev, priority := range stored.PriorityMap {
+		if severity := IncidentSeverity(sev); severity.IsValid() && priority != "" {
+			settings.PriorityMap[severity] = priority
+		}
+	}
+
+	return settings
+}
+
+// SettingStatuspage is the organization settings key holding the
+// StatuspageSettings used to publish incidents.
+const SettingStatuspage = "statuspage"
+
+// StatuspageSettings publishes incidents of at least MinSeverity to a
+// Statuspage page, marking ComponentIDs as affected until resolution. An
+// empty APIKey disables it.
+type StatuspageSettings struct {
+	APIKey       string
+	PageID       string
+	ComponentIDs []string
+	MinSeverity  IncidentSeverity
+}
+
+func (s StatuspageSettings) Enabled() bool {
+	return s.APIKey != "" && s.PageID != ""
+}
+
+// Settings returns the Statuspage settings in their organization settings
+// representation. The API key is redacted unless withSecrets is set.
+func (s StatuspageSettings) Settings(withSecrets bool) map[string]interface{} {
+	key := s.APIKey
+	if key != "" && !withSecrets {
+		key = RedactedValue
+	}
+	componentIDs := s.ComponentIDs
+	if componentIDs == nil {
+		componentIDs = []string{}
+	}
+	return map[string]interface{}{
+		"api_key":       key,
+		"page_id":       s.PageID,
+		"component_ids": componentIDs,
+		"min_severity":  s.MinSeverity.String(),
+	}
+}
+
+// Statuspage returns the organization's Statuspage settings, disabled when
+// unset or malformed. The minimum severity defaults to critical.
+func (o *Organization) Statuspage() StatuspageSettings {
+	settings := StatuspageSettings{MinSeverity: IncidentSeverityCritical}
+
+	value, ok := o.Settings[SettingStatuspage]
+	if !ok {
+		return settings
+	}
+
+	var stored struct {
+		APIKey       string   `json:"api_key"`
+		PageID       string   `json:"page_id"`
+		ComponentIDs []string `json:"component_ids"`
+		MinSeverity  string   `json:"min_severity"`
+	}
+	raw, err := json.Marshal(value)
+	if err != nil || json.Unmarshal(raw, &stored) != nil {
+		return settings
+	}
+
+	settings.APIKey = stored.APIKey
+	settings.PageID = stored.PageID
+	settings.ComponentIDs = stored.ComponentIDs
+	if severity := IncidentSeverity(stored.MinSeverity); severity.IsValid() {
+		settings.MinSeverity = severity
+	}
+
+	return settings
+}
+
+// OrganizationImport is a set of configuration entities created together in a
+// single transaction. IDs and cross references are assigned before insertion.
+type OrganizationImport struct {
+	Teams                []*Team
+	TeamMembers          []*TeamMember
+	Schedules            []*Schedule
+	Rotations            []*ScheduleRotation
+	Participants         []*ScheduleRotationParticipant
+	Overrides            []*ScheduleOverride
+	EscalationPolicies   []*EscalationPolicy
+	EscalationRules      []*EscalationRule
+	EscalationTargets    []*EscalationTarget
+	RoutingRules         []*AlertRoutingRule
+	NotificationChannels []*NotificationChannel
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxClaimLease is how long a relay may hold a claimed outbox event before
+// another relay is allowed to publish it instead.
+const OutboxClaimLease = 5 * time.Minute
+
+// OutboxEvent is an event recorded in the same transaction as the change that
+// raised it, so it is published if and only if that change commits. EventType
+// is the webhook event type; AlertID is the alert the event is about.
+type OutboxEvent struct {
+	ID             uuid.UUID              `json:"id"`
+	OrganizationID uuid.UUID              `json:"organization_id"`
+	EventType      string                 `json:"event_type"`
+	AlertID        *uuid.UUID             `json:"alert_id,omitempty"`
+	Payload        map[string]interface{} `json:"payload"`
+	ClaimedAt      *time.Time             `json:"claimed_at,omitempty"`
+	PublishedAt    *time.Time             `json:"published_at,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+
+// NewAlertOutboxEvent returns an unsaved event about alert
+func NewAlertOutboxEvent(eventType string, alert *Alert, payload map[string]interface{}) *OutboxEvent {
+	return &OutboxEvent{
+		ID:             uuid.New(),
+		OrganizationID: alert.OrganizationID,
+		EventType:      eventType,
+		AlertID:        &alert.ID,
+		Payload:        payload,
+	}
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxListLimit caps how many records a limit/offset list endpoint returns at
+// once, however large a limit the client asks for
+const MaxListLimit = 200
+
+// ClampLimit returns limit capped at MaxListLimit, or def when no positive
+// limit was given
+func ClampLimit(limit, def int) int {
+	if limit <= 0 {
+		return def
+	}
+	if limit > MaxListLimit {
+		return MaxListLimit
+	}
+	return limit
+}
+
+// ListCursor is a keyset position in a list ordered by created_at and id,
+// newest first. Paging by cursor rather than offset keeps rows from being
+// skipped or repeated when records are inserted between pages.
+type ListCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
@@ -27,6 +27,18 @@ type RoutingConditions struct {
 	Conditions []RoutingCondition `json:"conditions"`
 }
 
+// Specificity ranks how narrowly the conditions select alerts: the number of
+// conditions that must all hold, 1 for an "any" match and 0 when empty
+func (c *RoutingConditions) Specificity() int {
+	if len(c.Conditions) == 0 {
+		return 0
+	}
+	if c.Match != "all" {
+		return 1
+	}
+	return len(c.Conditions)
+}
+
 // RoutingCondition represents a single condition to evaluate
 type RoutingCondition struct {
 	Field    string `json:"field"`    // source, priority, tags, message
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -28,6 +29,7 @@ type ScheduleRotation struct {
 	EndTime        *time.Time
 	HandoffDay     *int
 	HandoffTime    time.Time
+	TeamID         *uuid.UUID // participants follow this team's membership when set
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
@@ -72,12 +74,39 @@ func (r RotationType) Validate() error {
 	}
 }
 
+// MaxLength is the longest rotation_length accepted for the rotation type, in
+// the type's unit (days for daily and custom rotations, weeks for weekly ones)
+func (r RotationType) MaxLength() int {
+	switch r {
+	case RotationTypeDaily:
+		return 31
+	case RotationTypeWeekly:
+		return 52
+	default:
+		return 365
+	}
+}
+
+// ValidateLength checks that length is a usable rotation_length for the type
+func (r RotationType) ValidateLength(length int) error {
+	if length < 1 || length > r.MaxLength() {
+		return fmt.Errorf("%w: %s rotations must be between 1 and %d long, got %d", ErrInvalidRotationLength, r, r.MaxLength(), length)
+	}
+	return nil
+}
+
 // ScheduleWithRotations includes the schedule and its rotations
 type ScheduleWithRotations struct {
 	Schedule
 	Rotations []*ScheduleRotation
 }
 
+// ScheduleWithParticipants includes the schedule and its rotations with participants
+type ScheduleWithParticipants struct {
+	Schedule
+	Rotations []*RotationWithParticipants
+}
+
 // RotationWithParticipants includes the rotation and its participants
 type RotationWithParticipants struct {
 	ScheduleRotation
@@ -90,6 +119,30 @@ type ParticipantWithUser struct {
 	User User
 }
 
+// ScheduleConfig is the full desired state of a schedule, applied atomically.
+// Rotations not listed are removed and each rotation's participants are
+// replaced by UserIDs in order.
+type ScheduleConfig struct {
+	Schedule  *Schedule
+	Rotations []*ScheduleRotationConfig
+}
+
+// ScheduleRotationConfig is a rotation with its participants' user IDs in order.
+type ScheduleRotationConfig struct {
+	*ScheduleRotation
+	UserIDs []uuid.UUID
+}
+
+// ScheduleHandoff records the most recent shift of a rotation whose incoming
+// on-call user was notified.
+type ScheduleHandoff struct {
+	RotationID uuid.UUID
+	UserID     uuid.UUID
+	ShiftStart time.Time
+	ShiftEnd   time.Time
+	NotifiedAt time.Time
+}
+
 // OnCallUser represents who is on-call at a specific time
 type OnCallUser struct {
 	UserID     uuid.UUID
@@ -98,4 +151,38 @@ type OnCallUser struct {
 	StartTime  time.Time
 	EndTime    time.Time
 	IsOverride bool
+
+	// SkippedUserIDs lists users who would have been on call but were
+	// unavailable, in the order they were passed over
+	SkippedUserIDs []uuid.UUID
+}
+
+// ScheduleCoverage reports the parts of a window in which a schedule has no
+// one on call, or more than one person
+type ScheduleCoverage struct {
+	ScheduleID uuid.UUID
+	Start      time.Time
+	End        time.Time
+	Gaps       []CoverageGap
+	Overlaps   []CoverageOverlap
+}
+
+// CoverageGap is a period with no one on call
+type CoverageGap struct {
+	Start time.Time
+	End   time.Time
+}
+
+// CoverageOverlap is a period in which several users are on call at once
+type CoverageOverlap struct {
+	Start   time.Time
+	End     time.Time
+	UserIDs []uuid.UUID
+}
+
+// ScheduleOnCall pairs a schedule with its current on-call user. OnCall is nil
+// when the schedule has no one on call, e.g. because it has no rotations.
+type ScheduleOnCall struct {
+	Schedule *Schedule
+	OnCall   *OnCallUser
 }
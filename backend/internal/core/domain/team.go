@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -38,3 +39,29 @@ type UserWithTeamRole struct {
 	Role     string
 	JoinedAt time.Time
 }
+
+// TeamDependency is a resource that references a team and would be left
+// dangling if the team were deleted.
+type TeamDependency struct {
+	Type string
+	ID   uuid.UUID
+	Name string
+}
+
+const (
+	TeamDependencySchedule         = "schedule"
+	TeamDependencyEscalationPolicy = "escalation_policy"
+)
+
+// TeamInUseError is returned when deleting a team that is still referenced.
+type TeamInUseError struct {
+	Dependencies []TeamDependency
+}
+
+func (e *TeamInUseError) Error() string {
+	return fmt.Sprintf("%s: %d dependent resource(s), delete with force=true to detach them", ErrTeamInUse, len(e.Dependencies))
+}
+
+func (e *TeamInUseError) Unwrap() error {
+	return ErrTeamInUse
+}
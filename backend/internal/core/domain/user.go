@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"regexp"
 	"time"
 
 	"github.com/google/uuid"
@@ -21,6 +22,36 @@ type User struct {
 	UpdatedAt               time.Time
 }
 
+// e164Phone matches an E.164 number: a plus sign and up to 15 digits
+var e164Phone = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// IsValidPhone reports whether phone is in E.164 format, e.g. +14155550123
+func IsValidPhone(phone string) bool {
+	return e164Phone.MatchString(phone)
+}
+
+// UserPrefPrimaryChannelOnlyBelow is the notification preferences key holding
+// the priority below which the user is paged through their primary channel only
+const UserPrefPrimaryChannelOnlyBelow = "primary_channel_only_below"
+
+// PrimaryChannelOnlyBelow returns the priority below which the user only wants
+// to be paged through their primary channel, or "" when they want every
+// channel for every alert
+func (u *User) PrimaryChannelOnlyBelow() AlertPriority {
+	value, _ := u.NotificationPreferences[UserPrefPrimaryChannelOnlyBelow].(string)
+	if priority := AlertPriority(value); priority.IsValid() {
+		return priority
+	}
+	return ""
+}
+
+// PrimaryChannelOnly reports whether the user should be paged through their
+// primary channel only for an alert of the given priority
+func (u *User) PrimaryChannelOnly(priority AlertPriority) bool {
+	below := u.PrimaryChannelOnlyBelow()
+	return below != "" && !priority.AtLeast(below)
+}
+
 type OrganizationUser struct {
 	OrganizationID uuid.UUID
 	UserID         uuid.UUID
@@ -56,3 +87,24 @@ type UserWithOrganization struct {
 	OrganizationID uuid.UUID
 	Role           UserRole
 }
+
+// UserDeactivation summarizes what was cleaned up when a user was
+// deactivated. Historical references (notes, timeline events, acknowledgements)
+// are kept.
+type UserDeactivation struct {
+	UserID             uuid.UUID
+	TeamsLeft          int
+	RotationsLeft      int
+	OverridesRemoved   int
+	UnassignedAlertIDs []uuid.UUID
+}
+
+// UserFilter narrows a listing of organization users
+type UserFilter struct {
+	OrganizationID uuid.UUID
+	Search         *string // Search in username, email and full name
+	TeamID         *uuid.UUID
+	IsActive       *bool
+	Limit          int
+	Offset         int
+}
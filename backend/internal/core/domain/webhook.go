@@ -1,20 +1,60 @@
 package domain
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Outgoing webhook event types
+const (
+	WebhookEventAlertCreated            = "alert.created"
+	WebhookEventAlertUpdated            = "alert.updated"
+	WebhookEventAlertAcknowledged       = "alert.acknowledged"
+	WebhookEventAlertClosed             = "alert.closed"
+	WebhookEventAlertEscalated          = "alert.escalated"
+	WebhookEventAlertSnoozed            = "alert.snoozed"
+	WebhookEventAlertAssigned           = "alert.assigned"
+	WebhookEventIncidentCreated         = "incident.created"
+	WebhookEventIncidentUpdated         = "incident.updated"
+	WebhookEventIncidentResolved        = "incident.resolved"
+	WebhookEventIncidentSeverityChanged = "incident.severity_changed"
+)
+
+// WebhookEventTypes lists every event type an endpoint can subscribe to
+var WebhookEventTypes = []string{
+	WebhookEventAlertCreated,
+	WebhookEventAlertUpdated,
+	WebhookEventAlertAcknowledged,
+	WebhookEventAlertClosed,
+	WebhookEventAlertEscalated,
+	WebhookEventAlertSnoozed,
+	WebhookEventAlertAssigned,
+	WebhookEventIncidentCreated,
+	WebhookEventIncidentUpdated,
+	WebhookEventIncidentResolved,
+	WebhookEventIncidentSeverityChanged,
+}
+
+// IsWebhookEventType reports whether eventType is a known webhook event type
+func IsWebhookEventType(eventType string) bool {
+	return slices.Contains(WebhookEventTypes, eventType)
+}
+
 // WebhookEndpoint represents an outgoing webhook configuration
 type WebhookEndpoint struct {
 	ID             uuid.UUID
 	OrganizationID uuid.UUID
 	Name           string
 	URL            string
-	Secret         string // Never expose in JSON
+	Secret         string // Only returned on creation and rotation; see Redacted
 	Enabled        bool
 
+	// Events is the set of event types the endpoint subscribes to. The
+	// boolean filters below are derived from it for backward compatibility.
+	Events []string
+
 	// Event filters
 	AlertCreated      bool
 	AlertUpdated      bool
@@ -37,13 +77,31 @@ type WebhookEndpoint struct {
 	UpdatedAt time.Time
 }
 
+// Redacted returns a copy of the endpoint safe to return from read endpoints,
+// with the signing secret masked down to its last four characters
+func (e *WebhookEndpoint) Redacted() *WebhookEndpoint {
+	redacted := *e
+	redacted.Secret = MaskSecret(e.Secret)
+	return &redacted
+}
+
+// MaskSecret hides a secret except for its last four characters, enough to
+// tell which one is deployed without revealing it
+func MaskSecret(secret string) string {
+	if len(secret) <= 8 {
+		return RedactedValue
+	}
+	return "****" + secret[len(secret)-4:]
+}
+
 // WebhookDeliveryStatus represents the delivery status of a webhook
 type WebhookDeliveryStatus string
 
 const (
-	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
-	WebhookDeliverySuccess WebhookDeliveryStatus = "success"
-	WebhookDeliveryFailed  WebhookDeliveryStatus = "failed"
+	WebhookDeliveryPending    WebhookDeliveryStatus = "pending"
+	WebhookDeliveryProcessing WebhookDeliveryStatus = "processing"
+	WebhookDeliverySuccess    WebhookDeliveryStatus = "success"
+	WebhookDeliveryFailed     WebhookDeliveryStatus = "failed"
 )
 
 // WebhookDelivery represents a webhook delivery attempt
@@ -72,6 +130,7 @@ const (
 	IncomingWebhookPrometheus IncomingWebhookIntegrationType = "prometheus"
 	IncomingWebhookGrafana    IncomingWebhookIntegrationType = "grafana"
 	IncomingWebhookDatadog    IncomingWebhookIntegrationType = "datadog"
+	IncomingWebhookOpsGenie   IncomingWebhookIntegrationType = "opsgenie"
 )
 
 // IncomingWebhookToken represents a token for receiving webhooks from external sources
@@ -84,12 +143,65 @@ type IncomingWebhookToken struct {
 	IntegrationType IncomingWebhookIntegrationType
 	DefaultPriority string
 	DefaultTags     []string
+	FieldMapping    *IncomingWebhookFieldMapping
 	LastUsedAt      *time.Time
 	RequestCount    int
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
 
+// IncomingWebhookRequestRetention is the number of recent requests kept per
+// incoming webhook token
+const IncomingWebhookRequestRetention = 50
+
+// IncomingWebhookRequestBodyLimit is the largest request body stored in the
+// request log; longer bodies are truncated
+const IncomingWebhookRequestBodyLimit = 64 << 10
+
+// IncomingWebhookRequest is a raw request received by an incoming webhook
+// token and the outcome of processing it, kept to debug integrations
+type IncomingWebhookRequest struct {
+	ID               uuid.UUID
+	TokenID          uuid.UUID
+	OrganizationID   uuid.UUID
+	Headers          map[string]string
+	Body             string
+	BodyTruncated    bool
+	Success          bool
+	Error            *string
+	AlertsReceived   int
+	AlertIDs         []uuid.UUID
+	ResolvedAlertIDs []uuid.UUID
+	ReceivedAt       time.Time
+}
+
+// IncomingWebhookFieldMapping locates alert fields in the payloads of a
+// generic incoming webhook. Each field is a JSONPath-style path such as
+// "$.data.level"; an empty or missing path falls back to the field's default.
+// PriorityValues translates values found at Priority (such as "critical")
+// into alert priorities.
+type IncomingWebhookFieldMapping struct {
+	Message        string
+	Description    string
+	Priority       string
+	PriorityValues map[string]string
+	Tags           string
+	DedupKey       string
+	Source         string
+}
+
+// Paths returns the mapped paths keyed by alert field
+func (m *IncomingWebhookFieldMapping) Paths() map[string]string {
+	return map[string]string{
+		"message":     m.Message,
+		"description": m.Description,
+		"priority":    m.Priority,
+		"tags":        m.Tags,
+		"dedup_key":   m.DedupKey,
+		"source":      m.Source,
+	}
+}
+
 // WebhookPayload represents the payload sent in outgoing webhooks
 type WebhookPayload struct {
 	EventType      string                 `json:"event_type"`
@@ -101,24 +213,56 @@ type WebhookPayload struct {
 
 // ShouldTriggerEvent checks if the webhook should trigger for the given event type
 func (w *WebhookEndpoint) ShouldTriggerEvent(eventType string) bool {
-	switch eventType {
-	case "alert.created":
-		return w.AlertCreated
-	case "alert.updated":
-		return w.AlertUpdated
-	case "alert.acknowledged":
-		return w.AlertAcknowledged
-	case "alert.closed":
-		return w.AlertClosed
-	case "alert.escalated":
-		return w.AlertEscalated
-	case "incident.created":
-		return w.IncidentCreated
-	case "incident.updated":
-		return w.IncidentUpdated
-	case "incident.resolved":
-		return w.IncidentResolved
-	default:
-		return false
+	return slices.Contains(w.Events, eventType)
+}
+
+// eventFlags pairs each event type that predates Events with its boolean filter
+func (w *WebhookEndpoint) eventFlags() map[string]*bool {
+	return map[string]*bool{
+		WebhookEventAlertCreated:      &w.AlertCreated,
+		WebhookEventAlertUpdated:      &w.AlertUpdated,
+		WebhookEventAlertAcknowledged: &w.AlertAcknowledged,
+		WebhookEventAlertClosed:       &w.AlertClosed,
+		WebhookEventAlertEscalated:    &w.AlertEscalated,
+		WebhookEventIncidentCreated:   &w.IncidentCreated,
+		WebhookEventIncidentUpdated:   &w.IncidentUpdated,
+		WebhookEventIncidentResolved:  &w.IncidentResolved,
+	}
+}
+
+// SetEvents replaces the subscribed events, in WebhookEventTypes order and
+// without duplicates, and derives the boolean filters from them
+func (w *WebhookEndpoint) SetEvents(events []string) {
+	w.Events = make([]string, 0, len(events))
+	for _, eventType := range WebhookEventTypes {
+		if slices.Contains(events, eventType) {
+			w.Events = append(w.Events, eventType)
+		}
+	}
+	for eventType, flag := range w.eventFlags() {
+		*flag = slices.Contains(w.Events, eventType)
+	}
+}
+
+// Subscribe adds or removes a single event type from the subscribed events
+func (w *WebhookEndpoint) Subscribe(eventType string, subscribed bool) {
+	events := slices.DeleteFunc(slices.Clone(w.Events), func(e string) bool {
+		return e == eventType
+	})
+	if subscribed {
+		events = append(events, eventType)
+	}
+	w.SetEvents(events)
+}
+
+// EventsFromFlags returns the event types selected by the boolean filters
+func (w *WebhookEndpoint) EventsFromFlags() []string {
+	flags := w.eventFlags()
+	var events []string
+	for _, eventType := range WebhookEventTypes {
+		if flag, ok := flags[eventType]; ok && *flag {
+			events = append(events, eventType)
+		}
 	}
+	return events
 }
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// WebhookFieldChange is the previous and current value of a field modified
+// by an update, as sent in the changes of an update webhook
+type WebhookFieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// AlertSnapshot returns the alert as sent in webhook payloads, keyed by the
+// same snake_case field names the API uses
+func AlertSnapshot(a *Alert) map[string]interface{} {
+	tags := a.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return map[string]interface{}{
+		"id":                    a.ID.String(),
+		"organization_id":       a.OrganizationID.String(),
+		"source":                a.Source,
+		"source_id":             derefOrNil(a.SourceID),
+		"priority":              string(a.Priority),
+		"status":                string(a.Status),
+		"message":               a.Message,
+		"description":           derefOrNil(a.Description),
+		"tags":                  tags,
+		"custom_fields":         a.CustomFields,
+		"assigned_to_user_id":   derefOrNil(a.AssignedToUserID),
+		"assigned_to_team_id":   derefOrNil(a.AssignedToTeamID),
+		"acknowledged_by":       derefOrNil(a.AcknowledgedBy),
+		"acknowledged_at":       derefOrNil(a.AcknowledgedAt),
+		"closed_by":             derefOrNil(a.ClosedBy),
+		"closed_at":             derefOrNil(a.ClosedAt),
+		"close_reason":          derefOrNil(a.CloseReason),
+		"snoozed_until":         derefOrNil(a.SnoozedUntil),
+		"snoozed_by":            derefOrNil(a.SnoozedBy),
+		"snooze_reason":         derefOrNil(a.SnoozeReason),
+		"maintenance_window_id": derefOrNil(a.MaintenanceWindowID),
+		"escalation_policy_id":  derefOrNil(a.EscalationPolicyID),
+		"escalation_level":      a.EscalationLevel,
+		"dedup_key":             derefOrNil(a.DedupKey),
+		"dedup_count":           a.DedupCount,
+		"created_at":            a.CreatedAt,
+		"updated_at":            a.UpdatedAt,
+	}
+}
+
+// IncidentSnapshot returns the incident as sent in webhook payloads, keyed by
+// the same snake_case field names the API uses
+func IncidentSnapshot(i *Incident) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                  i.ID.String(),
+		"organization_id":     i.OrganizationID.String(),
+		"title":               i.Title,
+		"description":         derefOrNil(i.Description),
+		"severity":            string(i.Severity),
+		"status":              string(i.Status),
+		"priority":            string(i.Priority),
+		"created_by_user_id":  i.CreatedByUserID.String(),
+		"assigned_to_team_id": derefOrNil(i.AssignedToTeamID),
+		"started_at":          i.StartedAt,
+		"resolved_at":         derefOrNil(i.ResolvedAt),
+		"created_at":          i.CreatedAt,
+		"updated_at":          i.UpdatedAt,
+	}
+}
+
+// WebhookChanges compares two snapshots of an entity and returns the fields
+// whose value differs. Values are compared as they encode to JSON, so a
+// receiver sees a change exactly when the field in the payload changed.
+// updated_at is left out since every update moves it.
+func WebhookChanges(before, after map[string]interface{}) map[string]WebhookFieldChange {
+	changes := make(map[string]WebhookFieldChange)
+	for field, newValue := range after {
+		if field == "updated_at" {
+			continue
+		}
+		oldValue := before[field]
+		if jsonEqual(oldValue, newValue) {
+			continue
+		}
+		changes[field] = WebhookFieldChange{Old: oldValue, New: newValue}
+	}
+	return changes
+}
+
+func jsonEqual(a, b interface{}) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return string(encodedA) == string(encodedB)
+}
+
+// derefOrNil returns the value p points to, or nil so the field encodes as
+// JSON null
+func derefOrNil[T any](p *T) interface{} {
+	if p == nil {
+		return nil
+	}
+	return *p
+}
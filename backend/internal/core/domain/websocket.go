@@ -17,6 +17,8 @@ const (
 	WSEventAlertAcknowledged WSEventType = "alert.acknowledged"
 	WSEventAlertClosed       WSEventType = "alert.closed"
 	WSEventAlertEscalated    WSEventType = "alert.escalated"
+	WSEventAlertSnoozed      WSEventType = "alert.snoozed"
+	WSEventAlertAssigned     WSEventType = "alert.assigned"
 
 	// Incident events
 	WSEventIncidentCreated          WSEventType = "incident.created"
@@ -33,8 +35,13 @@ const (
 	WSEventError     WSEventType = "connection.error"
 	WSEventPing      WSEventType = "connection.ping"
 	WSEventPong      WSEventType = "connection.pong"
+	WSEventResync    WSEventType = "connection.resync"
 )
 
+// WSReplayBufferSize is the number of recent events kept per organization for
+// replay to clients that reconnect after a dropped connection
+const WSReplayBufferSize = 200
+
 // WSMessage represents a WebSocket message
 type WSMessage struct {
 	ID             string
@@ -61,6 +68,11 @@ type WSClient struct {
 	UserID         uuid.UUID
 	OrganizationID uuid.UUID
 	Send           chan *WSMessage
+
+	// Replay cursor sent on reconnect. When set, buffered events newer than
+	// it are sent before live streaming resumes.
+	LastEventID string
+	Since       *time.Time
 }
 
 // WSHub manages WebSocket client connections
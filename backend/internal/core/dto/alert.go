@@ -17,16 +17,30 @@ type CreateAlertRequest struct {
 	Tags         []string               `json:"tags"`
 	CustomFields map[string]interface{} `json:"custom_fields"`
 	DedupKey     *string                `json:"dedup_key"` // Optional deduplication key
+	// Optional; without it the policy is chosen by routing rules or policy conditions
+	EscalationPolicyID *uuid.UUID `json:"escalation_policy_id"`
 }
 
+// UpdateAlertRequest changes the given fields only. Omitted tags are left
+// unchanged and an empty list clears them; AddTags and RemoveTags are then
+// applied on top, so tags can be changed without resending the whole set.
 type UpdateAlertRequest struct {
 	Priority     *string                `json:"priority"`
 	Message      *string                `json:"message"`
 	Description  *string                `json:"description"`
 	Tags         []string               `json:"tags"`
+	AddTags      []string               `json:"add_tags"`
+	RemoveTags   []string               `json:"remove_tags"`
 	CustomFields map[string]interface{} `json:"custom_fields"`
 }
 
+// BulkTagAlertsRequest adds and removes tags across several alerts at once
+type BulkTagAlertsRequest struct {
+	IDs    []uuid.UUID `json:"ids" binding:"required,min=1,max=500"`
+	Add    []string    `json:"add"`
+	Remove []string    `json:"remove"`
+}
+
 type AcknowledgeAlertRequest struct {
 	UserID uuid.UUID
 }
@@ -37,12 +51,16 @@ type CloseAlertRequest struct {
 }
 
 type SnoozeAlertRequest struct {
-	Until time.Time `json:"until" binding:"required"`
+	Until  time.Time `json:"until" binding:"required"`
+	Reason *string   `json:"reason"`
 }
 
 type AssignAlertRequest struct {
-	UserID *uuid.UUID `json:"user_id"`
-	TeamID *uuid.UUID `json:"team_id"`
+	UserID             *uuid.UUID `json:"user_id"`
+	TeamID             *uuid.UUID `json:"team_id"`
+	ClearUser          bool       `json:"clear_user"`           // Unassign the current user, e.g. when handing over to a team
+	ClearTeam          bool       `json:"clear_team"`           // Unassign the current team
+	EscalationPolicyID *uuid.UUID `json:"escalation_policy_id"` // Switch policy and restart escalation from the first rule
 }
 
 type ListAlertsRequest struct {
@@ -62,3 +80,29 @@ type ListAlertsResponse struct {
 	Page     int             `json:"page"`
 	PageSize int             `json:"page_size"`
 }
+
+// GroupAlertsRequest clusters open alerts by source, by the value of a
+// key:value tag (Tag names the key) or by the first PrefixLength characters of
+// the dedup key, the whole key when omitted.
+type GroupAlertsRequest struct {
+	By           string `form:"by" binding:"required,oneof=source tag fingerprint"`
+	Tag          string `form:"tag" binding:"required_if=By tag"`
+	PrefixLength int    `form:"prefix_length" binding:"omitempty,min=1"`
+	Limit        int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type GroupAlertsResponse struct {
+	By     string        `json:"by"`
+	Groups []*AlertGroup `json:"groups"`
+}
+
+// AlertGroup summarizes the open alerts sharing a key. Representatives are
+// the group's highest-priority alerts, newest first.
+type AlertGroup struct {
+	Key             string          `json:"key"`
+	Count           int             `json:"count"`
+	HighestPriority string          `json:"highest_priority"`
+	FirstAlertAt    time.Time       `json:"first_alert_at"`
+	LastAlertAt     time.Time       `json:"last_alert_at"`
+	Representatives []*domain.Alert `json:"representatives"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+// CreateAlertSourceRequest registers an alert source. Alerts created with the
+// name or one of the aliases, ignoring case, are filed under the name.
+type CreateAlertSourceRequest struct {
+	Name    string   `json:"name" binding:"required"`
+	Aliases []string `json:"aliases"`
+}
+
+// UpdateAlertSourceRequest updates an alert source; aliases, when given,
+// replace the existing ones
+type UpdateAlertSourceRequest struct {
+	Name    *string  `json:"name"`
+	Aliases []string `json:"aliases"`
+}
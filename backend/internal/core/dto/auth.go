@@ -1,6 +1,10 @@
 package dto
 
 import (
+	"time"
+
+	"github.com/google/uuid"
+
 	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 )
 
@@ -15,6 +19,14 @@ type RegisterRequest struct {
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
+	// OrganizationID selects the organization to sign in to. When omitted
+	// the most recently created organization of the user is used.
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+}
+
+// SwitchOrganizationRequest selects the active organization of the session
+type SwitchOrganizationRequest struct {
+	OrganizationID uuid.UUID `json:"organization_id" binding:"required"`
 }
 
 type AuthResponse struct {
@@ -23,4 +35,36 @@ type AuthResponse struct {
 	AccessToken               string               `json:"access_token"`
 	RefreshToken              string               `json:"refresh_token"`
 	RequiresEmailVerification bool                 `json:"requires_email_verification,omitempty"`
+	// VerificationEmailFailed is set when the verification code couldn't be
+	// emailed during sign-up and the client should offer a resend
+	VerificationEmailFailed bool `json:"verification_email_failed,omitempty"`
+}
+
+// SessionResponse is a signed-in device of the user
+type SessionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	// Current is set on the session the request was made from
+	Current bool `json:"current"`
+}
+
+// RevokeSessionsResponse reports how many sessions were signed out
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
+
+// OrganizationMembershipResponse is an organization the user belongs to
+type OrganizationMembershipResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Slug     string    `json:"slug"`
+	Plan     string    `json:"plan"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+	// Current is set on the organization the request was scoped to
+	Current bool `json:"current"`
 }
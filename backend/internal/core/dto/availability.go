@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// AddUnavailabilityRequest represents a request to mark the current user
+// unavailable for a period, e.g. vacation
+type AddUnavailabilityRequest struct {
+	Start  time.Time `json:"start" binding:"required"`
+	End    time.Time `json:"end" binding:"required"`
+	Reason *string   `json:"reason"`
+}
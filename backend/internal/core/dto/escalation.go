@@ -2,36 +2,139 @@ package dto
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// CreateEscalationPolicyRequest creates an escalation policy. Conditions use
+// the routing rule condition format; a policy with conditions is selected for
+// new alerts that match them and have no policy assigned otherwise.
+// BusinessHours is the weekly window rules limited to business or after hours
+// are evaluated against; without a timezone it uses the organization's default.
 type CreateEscalationPolicyRequest struct {
-	Name          string  `json:"name" binding:"required"`
-	Description   *string `json:"description"`
-	RepeatEnabled bool    `json:"repeat_enabled"`
-	RepeatCount   *int    `json:"repeat_count"`
+	Name          string          `json:"name" binding:"required"`
+	Description   *string         `json:"description"`
+	RepeatEnabled bool            `json:"repeat_enabled"`
+	RepeatCount   *int            `json:"repeat_count"`
+	Conditions    json.RawMessage `json:"conditions"`
+	BusinessHours json.RawMessage `json:"business_hours"`
 }
 
+// UpdateEscalationPolicyRequest updates an escalation policy. Omitted
+// conditions and business hours are left unchanged; null removes them.
 type UpdateEscalationPolicyRequest struct {
-	Name          *string `json:"name"`
-	Description   *string `json:"description"`
-	RepeatEnabled *bool   `json:"repeat_enabled"`
-	RepeatCount   *int    `json:"repeat_count"`
+	Name          *string         `json:"name"`
+	Description   *string         `json:"description"`
+	RepeatEnabled *bool           `json:"repeat_enabled"`
+	RepeatCount   *int            `json:"repeat_count"`
+	Conditions    json.RawMessage `json:"conditions"`
+	BusinessHours json.RawMessage `json:"business_hours"`
 }
 
+// CloneEscalationPolicyRequest names the copy; without a name it is called
+// "<original name> (copy)"
+type CloneEscalationPolicyRequest struct {
+	Name *string `json:"name"`
+}
+
+// CreateEscalationRuleRequest takes the delay either in minutes or, for
+// sub-minute delays, in seconds; delay_seconds wins when both are given.
+// Hours limits the rule to the policy's business hours or to after hours.
+// MinPriority and OnlyIfUnassigned make escalation skip the rule when the
+// alert is below that priority or has been assigned by the time it gets there.
 type CreateEscalationRuleRequest struct {
-	Position        int `json:"position" binding:"required"`
-	EscalationDelay int `json:"escalation_delay" binding:"required"`
+	Position         int    `json:"position" binding:"required"`
+	EscalationDelay  int    `json:"escalation_delay" binding:"required_without=DelaySeconds,min=0"`
+	DelaySeconds     *int   `json:"delay_seconds" binding:"omitempty,min=0"`
+	Hours            string `json:"hours,omitempty"`        // always (default), business_hours or after_hours
+	MinPriority      string `json:"min_priority,omitempty"` // P1-P5; empty matches every priority
+	OnlyIfUnassigned bool   `json:"only_if_unassigned"`
 }
 
+// UpdateEscalationRuleRequest clears the rule's minimum priority when
+// MinPriority is an empty string
 type UpdateEscalationRuleRequest struct {
-	Position        *int `json:"position"`
-	EscalationDelay *int `json:"escalation_delay"`
+	Position         *int    `json:"position"`
+	EscalationDelay  *int    `json:"escalation_delay" binding:"omitempty,min=0"`
+	DelaySeconds     *int    `json:"delay_seconds" binding:"omitempty,min=0"`
+	Hours            *string `json:"hours"`
+	MinPriority      *string `json:"min_priority"`
+	OnlyIfUnassigned *bool   `json:"only_if_unassigned"`
 }
 
 type AddEscalationTargetRequest struct {
 	TargetType           string          `json:"target_type" binding:"required"`
 	TargetID             uuid.UUID       `json:"target_id" binding:"required"`
+	ScheduleMode         string          `json:"schedule_mode,omitempty"`         // on_call (default) or all_members, schedule targets only
 	NotificationChannels json.RawMessage `json:"notification_channels,omitempty"` // Optional channel override
 }
+
+// EscalationPolicyConfigRequest is the complete desired state of an escalation
+// policy. Rules are matched to existing ones by position so in-flight
+// escalations keep their place; existing rules at unlisted positions are
+// deleted. Each rule's targets are replaced by the listed ones.
+type EscalationPolicyConfigRequest struct {
+	Name          string                 `json:"name" binding:"required"`
+	Description   *string                `json:"description"`
+	RepeatEnabled bool                   `json:"repeat_enabled"`
+	RepeatCount   *int                   `json:"repeat_count"`
+	Conditions    json.RawMessage        `json:"conditions"`
+	BusinessHours json.RawMessage        `json:"business_hours"`
+	Rules         []EscalationRuleConfig `json:"rules" binding:"dive"`
+}
+
+type EscalationRuleConfig struct {
+	Position         int                          `json:"position"`
+	EscalationDelay  int                          `json:"escalation_delay" binding:"min=0"`
+	DelaySeconds     *int                         `json:"delay_seconds" binding:"omitempty,min=0"`
+	Hours            string                       `json:"hours,omitempty"`
+	MinPriority      string                       `json:"min_priority,omitempty"`
+	OnlyIfUnassigned bool                         `json:"only_if_unassigned"`
+	Targets          []AddEscalationTargetRequest `json:"targets" binding:"dive"`
+}
+
+// EscalationPolicyPreview expands a policy's rules to the people they would
+// page if an alert started escalating at At
+type EscalationPolicyPreview struct {
+	PolicyID      uuid.UUID `json:"policy_id"`
+	At            time.Time `json:"at"`
+	RepeatEnabled bool      `json:"repeat_enabled"`
+	RepeatCount   *int      `json:"repeat_count"`
+	// InBusinessHours tells whether At is within the policy's business hours.
+	// It is null for a policy without business hours.
+	InBusinessHours *bool                    `json:"in_business_hours"`
+	Rules           []*EscalationRulePreview `json:"rules"`
+}
+
+type EscalationRulePreview struct {
+	RuleID   uuid.UUID `json:"rule_id"`
+	Position int       `json:"position"`
+	Hours    string    `json:"hours"`
+	// Applies is false for a rule skipped at At because of its hours
+	Applies bool `json:"applies"`
+	// DelaySeconds is how long escalation stays on the rule before moving on
+	DelaySeconds int `json:"delay_seconds"`
+	// PageAfterSeconds is when the rule's targets are first paged, counted from
+	// the start of escalation. It is null when they are never paged.
+	PageAfterSeconds *int                          `json:"page_after_seconds"`
+	Recipients       []*EscalationPreviewRecipient `json:"recipients"`
+}
+
+// EscalationPreviewRecipient is a user a target resolves to
+type EscalationPreviewRecipient struct {
+	TargetID     uuid.UUID `json:"target_id"`
+	TargetType   string    `json:"target_type"`
+	ScheduleMode string    `json:"schedule_mode,omitempty"`
+	UserID       uuid.UUID `json:"user_id"`
+	Email        string    `json:"email"`
+	// Unavailable recipients were skipped in a rotation and aren't paged
+	Unavailable bool                        `json:"unavailable"`
+	Channels    []*EscalationPreviewChannel `json:"channels"`
+}
+
+type EscalationPreviewChannel struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	ChannelType string    `json:"channel_type"`
+}
@@ -12,6 +12,7 @@ type CreateIncidentRequest struct {
 	Severity         string     `json:"severity" binding:"required"`
 	Priority         string     `json:"priority" binding:"required"`
 	AssignedToTeamID *uuid.UUID `json:"assigned_to_team_id"`
+	AlertID          *uuid.UUID `json:"alert_id"` // Alert the incident is declared from; it is linked to the incident
 }
 
 type UpdateIncidentRequest struct {
@@ -21,6 +22,9 @@ type UpdateIncidentRequest struct {
 	Status           *string    `json:"status"`
 	Priority         *string    `json:"priority"`
 	AssignedToTeamID *uuid.UUID `json:"assigned_to_team_id"`
+	// CloseLinkedAlerts closes the incident's linked alerts when this update
+	// resolves it. Defaults to the organization's incident_close_linked_alerts.
+	CloseLinkedAlerts *bool `json:"close_linked_alerts"`
 }
 
 type AddResponderRequest struct {
@@ -36,8 +40,14 @@ type AddNoteRequest struct {
 	Note string `json:"note" binding:"required"`
 }
 
+type UpdateNoteRequest struct {
+	Note string `json:"note" binding:"required"`
+}
+
 type LinkAlertRequest struct {
 	AlertID uuid.UUID `json:"alert_id" binding:"required"`
+	// CopyNotes copies the alert's notes into the incident timeline
+	CopyNotes bool `json:"copy_notes"`
 }
 
 type ListIncidentsRequest struct {
@@ -55,3 +65,14 @@ type ListIncidentsResponse struct {
 	Page      int                `json:"page"`
 	PageSize  int                `json:"page_size"`
 }
+
+// SuggestedAlert is an alert that isn't linked to an incident but looks
+// related to it
+type SuggestedAlert struct {
+	Alert *domain.Alert `json:"alert"`
+	// Score is the number of tags shared with the linked alerts, plus one when
+	// the source matches one of theirs
+	Score      int      `json:"score"`
+	SharedTags []string `json:"shared_tags"`
+	SameSource bool     `json:"same_source"`
+}
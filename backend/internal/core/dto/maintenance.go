@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CreateMaintenanceWindowRequest creates a maintenance window. Conditions use
+// the routing rule condition format; omitted conditions match every alert.
+type CreateMaintenanceWindowRequest struct {
+	Name        string          `json:"name" binding:"required"`
+	Description *string         `json:"description"`
+	StartsAt    time.Time       `json:"starts_at" binding:"required"`
+	EndsAt      time.Time       `json:"ends_at" binding:"required"`
+	Conditions  json.RawMessage `json:"conditions"`
+}
+
+type UpdateMaintenanceWindowRequest struct {
+	Name        *string         `json:"name"`
+	Description *string         `json:"description"`
+	StartsAt    *time.Time      `json:"starts_at"`
+	EndsAt      *time.Time      `json:"ends_at"`
+	Conditions  json.RawMessage `json:"conditions"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+// EnumValue describes one value of an enum for display
+type EnumValue struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Color string `json:"color"`
+}
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -17,6 +18,20 @@ type SendNotificationRequest struct {
 	Message   string     `json:"message" binding:"required"`
 }
 
+// TestNotificationChannelRequest sends a canned notification through a
+// channel. Recipient is required for email channels.
+type TestNotificationChannelRequest struct {
+	Recipient string `json:"recipient"`
+}
+
+// TestNotificationChannelResponse reports the outcome of a channel test. Error
+// carries the provider's response when the send failed.
+type TestNotificationChannelResponse struct {
+	Success bool                    `json:"success"`
+	Error   *string                 `json:"error,omitempty"`
+	Log     *domain.NotificationLog `json:"log"`
+}
+
 type CreateNotificationChannelRequest struct {
 	Name        string             `json:"name" binding:"required"`
 	ChannelType domain.ChannelType `json:"channel_type" binding:"required"`
@@ -47,3 +62,21 @@ type UpdateUserNotificationPreferenceRequest struct {
 	DNDEndTime   *string `json:"dnd_end_time,omitempty"`
 	MinPriority  *string `json:"min_priority,omitempty"`
 }
+
+// ListNotificationLogsRequest filters the organization's notification logs.
+// From and To are RFC 3339 timestamps, From is inclusive and To exclusive.
+type ListNotificationLogsRequest struct {
+	Status    []string   `form:"status"`
+	ChannelID *uuid.UUID `form:"-"` // parsed from the channel_id query by the handler
+	From      *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To        *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit     int        `form:"limit"`
+	Offset    int        `form:"offset"`
+}
+
+type ListNotificationLogsResponse struct {
+	Logs   []domain.NotificationLog `json:"logs"`
+	Total  int                      `json:"total"`
+	Limit  int                      `json:"limit"`
+	Offset int                      `json:"offset"`
+}
@@ -0,0 +1,226 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+// OrganizationExportVersion is the current version of the export archive format.
+const OrganizationExportVersion = 1
+
+// OrganizationExport is the layout of the archive produced by GET /organizations/export.
+// The export is streamed section by section in this field order; the struct is used
+// to document the format and to decode archives.
+type OrganizationExport struct {
+	Version              int                           `json:"version"`
+	ExportedAt           time.Time                     `json:"exported_at"`
+	Organization         ExportedOrganization          `json:"organization"`
+	Teams                []ExportedTeam                `json:"teams"`
+	Schedules            []ExportedSchedule            `json:"schedules"`
+	EscalationPolicies   []ExportedEscalationPolicy    `json:"escalation_policies"`
+	RoutingRules         []ExportedRoutingRule         `json:"routing_rules"`
+	NotificationChannels []ExportedNotificationChannel `json:"notification_channels"`
+	Alerts               []*domain.Alert               `json:"alerts"`
+	Incidents            []*domain.Incident            `json:"incidents"`
+}
+
+type ExportedOrganization struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	Slug string    `json:"slug"`
+	Plan string    `json:"plan"`
+}
+
+type ExportedTeam struct {
+	ID          uuid.UUID            `json:"id"`
+	Name        string               `json:"name"`
+	Description *string              `json:"description,omitempty"`
+	Members     []ExportedTeamMember `json:"members"`
+}
+
+type ExportedTeamMember struct {
+	UserID uuid.UUID `json:"user_id"`
+	Email  string    `json:"email"`
+	Role   string    `json:"role"`
+}
+
+type ExportedSchedule struct {
+	ID          uuid.UUID                  `json:"id"`
+	TeamID      *uuid.UUID                 `json:"team_id,omitempty"`
+	Name        string                     `json:"name"`
+	Description *string                    `json:"description,omitempty"`
+	Timezone    string                     `json:"timezone"`
+	Rotations   []ExportedScheduleRotation `json:"rotations"`
+	Overrides   []ExportedScheduleOverride `json:"overrides"`
+}
+
+// ExportedScheduleRotation uses the same date/time formats as CreateRotationRequest.
+type ExportedScheduleRotation struct {
+	ID             uuid.UUID             `json:"id"`
+	TeamID         *uuid.UUID            `json:"team_id,omitempty"`
+	Name           string                `json:"name"`
+	RotationType   string                `json:"rotation_type"`
+	RotationLength int                   `json:"rotation_length"`
+	StartDate      string                `json:"start_date"`
+	StartTime      string                `json:"start_time"`
+	EndTime        *string               `json:"end_time,omitempty"`
+	HandoffDay     *int                  `json:"handoff_day,omitempty"`
+	HandoffTime    string                `json:"handoff_time"`
+	Participants   []ExportedParticipant `json:"participants"`
+}
+
+type ExportedParticipant struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Email    string    `json:"email"`
+	Position int       `json:"position"`
+}
+
+type ExportedScheduleOverride struct {
+	UserID    uuid.UUID `json:"user_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Note      *string   `json:"note,omitempty"`
+}
+
+type ExportedEscalationPolicy struct {
+	ID            uuid.UUID                `json:"id"`
+	Name          string                   `json:"name"`
+	Description   *string                  `json:"description,omitempty"`
+	RepeatEnabled bool                     `json:"repeat_enabled"`
+	RepeatCount   *int                     `json:"repeat_count,omitempty"`
+	Conditions    json.RawMessage          `json:"conditions,omitempty"`
+	BusinessHours json.RawMessage          `json:"business_hours,omitempty"`
+	Rules         []ExportedEscalationRule `json:"rules"`
+}
+
+type ExportedEscalationRule struct {
+	Position         int                        `json:"position"`
+	EscalationDelay  int                        `json:"escalation_delay"`
+	DelaySeconds     *int                       `json:"delay_seconds,omitempty"`
+	Hours            string                     `json:"hours,omitempty"`
+	MinPriority      string                     `json:"min_priority,omitempty"`
+	OnlyIfUnassigned bool                       `json:"only_if_unassigned,omitempty"`
+	Targets          []ExportedEscalationTarget `json:"targets"`
+}
+
+type ExportedEscalationTarget struct {
+	TargetType           string          `json:"target_type"`
+	TargetID             uuid.UUID       `json:"target_id"`
+	ScheduleMode         string          `json:"schedule_mode,omitempty"`
+	NotificationChannels json.RawMessage `json:"notification_channels,omitempty"`
+}
+
+type ExportedRoutingRule struct {
+	ID          uuid.UUID       `json:"id"`
+	Name        string          `json:"name"`
+	Description *string         `json:"description,omitempty"`
+	Priority    int             `json:"priority"`
+	Conditions  json.RawMessage `json:"conditions"`
+	Actions     json.RawMessage `json:"actions"`
+	Enabled     bool            `json:"enabled"`
+}
+
+// ExportedNotificationChannel carries the channel config with secrets redacted.
+type ExportedNotificationChannel struct {
+	ID          uuid.UUID       `json:"id"`
+	Name        string          `json:"name"`
+	ChannelType string          `json:"channel_type"`
+	IsEnabled   bool            `json:"is_enabled"`
+	Config      json.RawMessage `json:"config"`
+}
+
+type OrganizationImportResponse struct {
+	Teams                int `json:"teams"`
+	Schedules            int `json:"schedules"`
+	EscalationPolicies   int `json:"escalation_policies"`
+	RoutingRules         int `json:"routing_rules"`
+	NotificationChannels int `json:"notification_channels"`
+	// IDMap maps IDs from the archive to the IDs of the newly created entities.
+	IDMap map[uuid.UUID]uuid.UUID `json:"id_map"`
+	// DisabledChannels are imported channels whose secrets were redacted in the
+	// archive. They are created disabled until their credentials are re-entered.
+	DisabledChannels []uuid.UUID `json:"disabled_channels"`
+}
+
+// UpdateOrganizationSettingsRequest updates organization-wide settings; omitted
+// fields are left unchanged.
+type UpdateOrganizationSettingsRequest struct {
+	OverrideMembershipPolicy  *string                                 `json:"override_membership_policy" binding:"omitempty,oneof=off warn strict"`
+	DefaultTimezone           *string                                 `json:"default_timezone"` // IANA zone for new schedules without one
+	IncidentAutoAssignOnCall  *bool                                   `json:"incident_auto_assign_on_call"`
+	IncidentCloseLinkedAlerts *bool                                   `json:"incident_close_linked_alerts"`
+	AlertAutoClose            *AlertAutoCloseSettings                 `json:"alert_auto_close"`
+	AlertRetention            *AlertRetentionSettings                 `json:"alert_retention"`
+	SlackWarRoom              *SlackWarRoomSettings                   `json:"slack_war_room"`
+	Jira                      *JiraSettings                           `json:"jira"`
+	Statuspage                *StatuspageSettings                     `json:"statuspage"`
+	NotificationTemplates     map[string]NotificationTemplateSettings `json:"notification_templates" binding:"omitempty,dive,keys,oneof=email slack teams webhook,endkeys"`
+}
+
+// AlertAutoCloseSettings closes open alerts of the given priorities after
+// AfterHours without activity. Zero AfterHours disables auto-close; omitted
+// priorities default to P3-P5.
+type AlertAutoCloseSettings struct {
+	AfterHours int      `json:"after_hours" binding:"min=0"`
+	Priorities []string `json:"priorities" binding:"omitempty,dive,oneof=P1 P2 P3 P4 P5"`
+}
+
+// AlertRetentionSettings removes alerts closed more than AfterDays ago, copying
+// them to the archive first unless Archive is false. Alerts of the exempt
+// priorities are kept; a dry run only reports what would be removed. Zero
+// AfterDays disables retention.
+type AlertRetentionSettings struct {
+	AfterDays        int      `json:"after_days" binding:"min=0"`
+	Archive          *bool    `json:"archive"`
+	ExemptPriorities []string `json:"exempt_priorities" binding:"omitempty,dive,oneof=P1 P2 P3 P4 P5"`
+	DryRun           bool     `json:"dry_run"`
+}
+
+// NotificationTemplateSettings overrides the text/template subject and body of
+// alert notifications sent through one channel type. The listed channel types
+// replace the stored overrides; an empty subject or body keeps the built-in
+// default.
+type NotificationTemplateSettings struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// SlackWarRoomSettings opens a Slack channel for incidents of at least
+// MinSeverity (default critical). An empty bot token disables it; the redacted
+// placeholder returned by GET keeps the stored token.
+type SlackWarRoomSettings struct {
+	BotToken    string `json:"bot_token"`
+	MinSeverity string `json:"min_severity" binding:"omitempty,oneof=critical high medium low"`
+}
+
+// JiraSettings creates a Jira issue for incidents of the listed severities
+// (default critical and high). PriorityMap maps incident severities to Jira
+// priority names; Fields are extra issue fields sent as-is. An empty API
+// token disables it; the redacted placeholder returned by GET keeps the
+// stored token.
+type JiraSettings struct {
+	BaseURL     string                 `json:"base_url" binding:"omitempty,url"`
+	Email       string                 `json:"email" binding:"omitempty,email"`
+	APIToken    string                 `json:"api_token"`
+	ProjectKey  string                 `json:"project_key"`
+	IssueType   string                 `json:"issue_type"`
+	Severities  []string               `json:"severities" binding:"omitempty,dive,oneof=critical high medium low"`
+	PriorityMap map[string]string      `json:"priority_map" binding:"omitempty,dive,keys,oneof=critical high medium low,endkeys,required"`
+	Labels      []string               `json:"labels" binding:"omitempty,dive,required"`
+	Fields      map[string]interface{} `json:"fields"`
+}
+
+// StatuspageSettings publishes incidents of at least MinSeverity (default
+// critical) to a Statuspage page and keeps their status in sync. An empty API
+// key disables it; the redacted placeholder returned by GET keeps the stored
+// key.
+type StatuspageSettings struct {
+	APIKey       string   `json:"api_key"`
+	PageID       string   `json:"page_id"`
+	ComponentIDs []string `json:"component_ids" binding:"omitempty,dive,required"`
+	MinSeverity  string   `json:"min_severity" binding:"omitempty,oneof=critical high medium low"`
+}
@@ -27,17 +27,21 @@ type CreateRotationRequest struct {
 	EndTime        *string `json:"end_time"`
 	HandoffDay     *int    `json:"handoff_day"`
 	HandoffTime    string  `json:"handoff_time"`
+	// TeamID backs the rotation by a team: its members become participants and
+	// later membership changes are mirrored.
+	TeamID *uuid.UUID `json:"team_id"`
 }
 
 type UpdateRotationRequest struct {
-	Name           *string `json:"name"`
-	RotationType   *string `json:"rotation_type"`
-	RotationLength *int    `json:"rotation_length"`
-	StartDate      *string `json:"start_date"`
-	StartTime      *string `json:"start_time"`
-	EndTime        *string `json:"end_time"`
-	HandoffDay     *int    `json:"handoff_day"`
-	HandoffTime    *string `json:"handoff_time"`
+	Name           *string    `json:"name"`
+	RotationType   *string    `json:"rotation_type"`
+	RotationLength *int       `json:"rotation_length"`
+	StartDate      *string    `json:"start_date"`
+	StartTime      *string    `json:"start_time"`
+	EndTime        *string    `json:"end_time"`
+	HandoffDay     *int       `json:"handoff_day"`
+	HandoffTime    *string    `json:"handoff_time"`
+	TeamID         *uuid.UUID `json:"team_id"`
 }
 
 type AddParticipantRequest struct {
@@ -49,6 +53,12 @@ type ReorderParticipantsRequest struct {
 	UserIDs []uuid.UUID `json:"user_ids" binding:"required"`
 }
 
+// SetParticipantsRequest replaces a rotation's participants. Users are given
+// positions in the order they are listed.
+type SetParticipantsRequest struct {
+	UserIDs []uuid.UUID `json:"user_ids" binding:"required"`
+}
+
 type CreateOverrideRequest struct {
 	UserID    uuid.UUID `json:"user_id" binding:"required"`
 	StartTime string    `json:"start_time" binding:"required"`
@@ -62,3 +72,30 @@ type UpdateOverrideRequest struct {
 	EndTime   *string    `json:"end_time"`
 	Note      *string    `json:"note"`
 }
+
+// PreviewOnCallRequest describes an unsaved rotation whose shifts are computed
+// between StartTime and EndTime (RFC3339).
+type PreviewOnCallRequest struct {
+	Rotation     CreateRotationRequest `json:"rotation"`
+	Participants []uuid.UUID           `json:"participants" binding:"required,min=1"`
+	StartTime    string                `json:"start_time" binding:"required"`
+	EndTime      string                `json:"end_time" binding:"required"`
+}
+
+// ScheduleConfigRequest is the complete desired state of a schedule. Rotations
+// are matched to existing ones by id, or by name when no id is given; existing
+// rotations that are not listed are deleted. Participants are user IDs in
+// rotation order.
+type ScheduleConfigRequest struct {
+	TeamID      *uuid.UUID       `json:"team_id"`
+	Name        string           `json:"name" binding:"required"`
+	Description *string          `json:"description"`
+	Timezone    string           `json:"timezone"`
+	Rotations   []RotationConfig `json:"rotations" binding:"dive"`
+}
+
+type RotationConfig struct {
+	ID *uuid.UUID `json:"id"`
+	CreateRotationRequest
+	Participants []uuid.UUID `json:"participants"`
+}
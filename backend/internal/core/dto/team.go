@@ -34,6 +34,21 @@ type InvitationResponse struct {
 	Message    string                 `json:"message"`
 }
 
+// AcceptInvitationRequest redeems an invitation token. Username and password
+// are only used to create an account when the invited email has none.
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Username string `json:"username" binding:"omitempty,min=3,max=50"`
+	Password string `json:"password" binding:"omitempty,min=8"`
+	FullName string `json:"full_name"`
+}
+
+type AcceptInvitationResponse struct {
+	TeamID        uuid.UUID     `json:"team_id"`
+	AlreadyMember bool          `json:"already_member"`
+	Auth          *AuthResponse `json:"auth,omitempty"` // Set when an account was created
+}
+
 type UpdateTeamMemberRoleRequest struct {
 	Role string `json:"role" binding:"required"`
 }
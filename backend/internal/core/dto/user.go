@@ -1,7 +1,31 @@
 package dto
 
+import (
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
 type UpdateProfileRequest struct {
 	FullName *string `json:"full_name,omitempty"`
 	Phone    *string `json:"phone,omitempty"`
 	Timezone *string `json:"timezone,omitempty"`
+	// Alerts less urgent than this priority page only the user's primary
+	// channel; an empty value pages every channel again
+	PrimaryChannelOnlyBelow *string `json:"primary_channel_only_below,omitempty"`
+}
+
+type ListUsersRequest struct {
+	Search   *string    `form:"search"`
+	TeamID   *uuid.UUID `form:"-"` // parsed from the team_id query by the handler
+	IsActive *bool      `form:"is_active"`
+	Page     int        `form:"page"`
+	PageSize int        `form:"page_size"`
+}
+
+type ListUsersResponse struct {
+	Users    []*domain.UserWithOrganization `json:"users"`
+	Total    int                            `json:"total"`
+	Page     int                            `json:"page"`
+	PageSize int                            `json:"page_size"`
 }
@@ -12,6 +12,7 @@ type CreateWebhookEndpointRequest struct {
 	IncidentCreated   bool              `json:"incident_created"`
 	IncidentUpdated   bool              `json:"incident_updated"`
 	IncidentResolved  bool              `json:"incident_resolved"`
+	Events            []string          `json:"events"` // Subscribed event types, in addition to the flags above
 	Headers           map[string]string `json:"headers"`
 	TimeoutSeconds    *int              `json:"timeout_seconds"`
 	MaxRetries        *int              `json:"max_retries"`
@@ -30,6 +31,7 @@ type UpdateWebhookEndpointRequest struct {
 	IncidentCreated   *bool             `json:"incident_created"`
 	IncidentUpdated   *bool             `json:"incident_updated"`
 	IncidentResolved  *bool             `json:"incident_resolved"`
+	Events            []string          `json:"events"` // Replaces the subscribed event types; flags above then toggle single events
 	Headers           map[string]string `json:"headers"`
 	TimeoutSeconds    *int              `json:"timeout_seconds"`
 	MaxRetries        *int              `json:"max_retries"`
@@ -37,8 +39,21 @@ type UpdateWebhookEndpointRequest struct {
 }
 
 type CreateIncomingWebhookTokenRequest struct {
-	Name            string   `json:"name" binding:"required"`
-	IntegrationType string   `json:"integration_type" binding:"required"`
-	DefaultPriority *string  `json:"default_priority"`
-	DefaultTags     []string `json:"default_tags"`
+	Name            string                       `json:"name" binding:"required"`
+	IntegrationType string                       `json:"integration_type" binding:"required"`
+	DefaultPriority *string                      `json:"default_priority"`
+	DefaultTags     []string                     `json:"default_tags"`
+	FieldMapping    *IncomingWebhookFieldMapping `json:"field_mapping"` // Generic integrations only
+}
+
+// IncomingWebhookFieldMapping points alert fields at JSONPath-style paths in
+// the webhook payload, e.g. {"message": "$.title", "priority": "$.data.level"}
+type IncomingWebhookFieldMapping struct {
+	Message        string            `json:"message"`
+	Description    string            `json:"description"`
+	Priority       string            `json:"priority"`
+	PriorityValues map[string]string `json:"priority_values" binding:"omitempty,dive,oneof=P1 P2 P3 P4 P5"`
+	Tags           string            `json:"tags"`
+	DedupKey       string            `json:"dedup_key"`
+	Source         string            `json:"source"`
 }
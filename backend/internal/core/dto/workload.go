@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+// WorkloadResponse is what is on the authenticated user's plate: the unclosed
+// alerts assigned to them, the unresolved incidents they respond to and the
+// schedules they are on call for right now. Lists are capped at
+// domain.MaxListLimit; the totals count everything.
+type WorkloadResponse struct {
+	Alerts          []*domain.Alert          `json:"alerts"`
+	AlertsTotal     int                      `json:"alerts_total"`
+	Incidents       []*domain.Incident       `json:"incidents"`
+	IncidentsTotal  int                      `json:"incidents_total"`
+	OnCall          bool                     `json:"on_call"`
+	OnCallSchedules []*domain.ScheduleOnCall `json:"on_call_schedules"`
+}
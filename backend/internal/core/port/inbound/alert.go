@@ -2,6 +2,7 @@ package inbound
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,11 +14,20 @@ import (
 type AlertService interface {
 	CreateAlert(ctx context.Context, orgID uuid.UUID, req *dto.CreateAlertRequest) (*domain.Alert, error)
 	GetAlert(ctx context.Context, id, orgID uuid.UUID) (*domain.Alert, error)
+	GetOnCallAtCreation(ctx context.Context, id, orgID uuid.UUID) ([]*domain.ScheduleOnCall, error)
 	UpdateAlert(ctx context.Context, id, orgID uuid.UUID, req *dto.UpdateAlertRequest) (*domain.Alert, error)
+	BulkUpdateTags(ctx context.Context, orgID uuid.UUID, req *dto.BulkTagAlertsRequest) ([]*domain.Alert, error)
 	DeleteAlert(ctx context.Context, id, orgID uuid.UUID) error
 	ListAlerts(ctx context.Context, orgID uuid.UUID, req *dto.ListAlertsRequest) (*dto.ListAlertsResponse, error)
+	GroupAlerts(ctx context.Context, orgID uuid.UUID, req *dto.GroupAlertsRequest) (*dto.GroupAlertsResponse, error)
+	ExportAlertsCSV(ctx context.Context, orgID uuid.UUID, req *dto.ListAlertsRequest, w io.Writer) error
 	AcknowledgeAlert(ctx context.Context, id, orgID, userID uuid.UUID) error
+	AcknowledgeWithToken(ctx context.Context, token string) (*domain.Alert, error)
+	UnacknowledgeAlert(ctx context.Context, id, orgID, userID uuid.UUID) error
 	CloseAlert(ctx context.Context, id, orgID, userID uuid.UUID, reason string) error
-	SnoozeAlert(ctx context.Context, id, orgID uuid.UUID, until time.Time) error
-	AssignAlert(ctx context.Context, id, orgID uuid.UUID, userID, teamID *uuid.UUID) error
+	ResolveAlertByDedupKey(ctx context.Context, orgID uuid.UUID, dedupKey string) (*domain.Alert, error)
+	SnoozeAlert(ctx context.Context, id, orgID, userID uuid.UUID, until time.Time, reason *string) error
+	AssignAlert(ctx context.Context, id, orgID, assignedBy uuid.UUID, req *dto.AssignAlertRequest) error
+	AddNote(ctx context.Context, alertID, orgID, userID uuid.UUID, req *dto.AddNoteRequest) (*domain.AlertNote, error)
+	ListNotes(ctx context.Context, alertID, orgID uuid.UUID) ([]*domain.AlertNoteWithUser, error)
 }
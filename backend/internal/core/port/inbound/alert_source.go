@@ -0,0 +1,17 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+)
+
+type AlertSourceService interface {
+	CreateSource(ctx context.Context, orgID uuid.UUID, req *dto.CreateAlertSourceRequest) (*domain.RegisteredSource, error)
+	UpdateSource(ctx context.Context, id, orgID uuid.UUID, req *dto.UpdateAlertSourceRequest) (*domain.RegisteredSource, error)
+	DeleteSource(ctx context.Context, id, orgID uuid.UUID) error
+	ListSources(ctx context.Context, orgID uuid.UUID) ([]*domain.RegisteredSourceWithCount, error)
+}
@@ -10,8 +10,13 @@ import (
 )
 
 type AuthService interface {
-	Register(ctx context.Context, req *dto.RegisterRequest) (*dto.AuthResponse, error)
-	Login(ctx context.Context, req *dto.LoginRequest) (*dto.AuthResponse, error)
-	RefreshToken(ctx context.Context, refreshToken string) (*dto.AuthResponse, error)
+	Register(ctx context.Context, req *dto.RegisterRequest, ipAddress, userAgent string) (*dto.AuthResponse, error)
+	Login(ctx context.Context, req *dto.LoginRequest, ipAddress, userAgent string) (*dto.AuthResponse, error)
+	RefreshToken(ctx context.Context, refreshToken, ipAddress, userAgent string) (*dto.AuthResponse, error)
+	SwitchOrganization(ctx context.Context, userID, sessionID, orgID uuid.UUID, ipAddress, userAgent string) (*dto.AuthResponse, error)
+	ListOrganizations(ctx context.Context, userID, currentOrgID uuid.UUID) ([]*dto.OrganizationMembershipResponse, error)
+	ListSessions(ctx context.Context, userID, currentSessionID uuid.UUID) ([]*dto.SessionResponse, error)
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID) (int, error)
 	GetMe(ctx context.Context, userID uuid.UUID) (*domain.User, error)
 }
@@ -0,0 +1,16 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+)
+
+type AvailabilityService interface {
+	AddUnavailability(ctx context.Context, userID uuid.UUID, req *dto.AddUnavailabilityRequest) (*domain.UserUnavailability, error)
+	ListUnavailability(ctx context.Context, userID uuid.UUID) ([]*domain.UserUnavailability, error)
+	RemoveUnavailability(ctx context.Context, userID, id uuid.UUID) error
+}
@@ -2,6 +2,7 @@ package inbound
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -15,6 +16,7 @@ type DNDService interface {
 	AddOverride(ctx context.Context, userID uuid.UUID, req *dto.AddDNDOverrideRequest) (*domain.UserDNDSettings, error)
 	RemoveOverride(ctx context.Context, userID uuid.UUID, index int) (*domain.UserDNDSettings, error)
 	IsInDNDMode(ctx context.Context, userID uuid.UUID, priority domain.AlertPriority) (bool, error)
+	IsInDND(ctx context.Context, userID uuid.UUID, at time.Time, priority domain.AlertPriority) (*domain.DNDDecision, error)
 	CleanExpiredOverrides(ctx context.Context, userID uuid.UUID) error
 	DeleteSettings(ctx context.Context, userID uuid.UUID) error
 }
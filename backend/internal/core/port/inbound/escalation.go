@@ -2,6 +2,7 @@ package inbound
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -16,15 +17,18 @@ type EscalationService interface {
 	UpdatePolicy(ctx context.Context, id uuid.UUID, req *dto.UpdateEscalationPolicyRequest) (*domain.EscalationPolicy, error)
 	DeletePolicy(ctx context.Context, id uuid.UUID) error
 	ListPolicies(ctx context.Context, orgID uuid.UUID, page, pageSize int) ([]*domain.EscalationPolicy, error)
+	ApplyPolicyConfig(ctx context.Context, orgID, id uuid.UUID, req *dto.EscalationPolicyConfigRequest) (*domain.EscalationPolicyWithRules, error)
+	ClonePolicy(ctx context.Context, orgID, id uuid.UUID, req *dto.CloneEscalationPolicyRequest) (*domain.EscalationPolicyWithRules, error)
+	PreviewPolicy(ctx context.Context, orgID, id uuid.UUID, at time.Time) (*dto.EscalationPolicyPreview, error)
 	CreateRule(ctx context.Context, policyID uuid.UUID, req *dto.CreateEscalationRuleRequest) (*domain.EscalationRule, error)
 	GetRule(ctx context.Context, id uuid.UUID) (*domain.EscalationRule, error)
 	UpdateRule(ctx context.Context, id uuid.UUID, req *dto.UpdateEscalationRuleRequest) (*domain.EscalationRule, error)
 	DeleteRule(ctx context.Context, id uuid.UUID) error
 	ListRules(ctx context.Context, policyID uuid.UUID) ([]*domain.EscalationRule, error)
-	AddTarget(ctx context.Context, ruleID uuid.UUID, req *dto.AddEscalationTargetRequest) (*domain.EscalationTarget, error)
+	AddTarget(ctx context.Context, orgID, ruleID uuid.UUID, req *dto.AddEscalationTargetRequest) (*domain.EscalationTarget, error)
 	RemoveTarget(ctx context.Context, id uuid.UUID) error
 	ListTargets(ctx context.Context, ruleID uuid.UUID) ([]*domain.EscalationTarget, error)
 	StartEscalation(ctx context.Context, alertID, orgID uuid.UUID) error
-	ProcessPendingEscalations(ctx context.Context) error
+	ProcessPendingEscalations(ctx context.Context, limit int) error
 	StopEscalation(ctx context.Context, alertID uuid.UUID) error
 }
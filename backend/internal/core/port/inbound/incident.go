@@ -2,6 +2,7 @@ package inbound
 
 import (
 	"context"
+	"io"
 
 	"github.com/google/uuid"
 
@@ -16,13 +17,17 @@ type IncidentService interface {
 	UpdateIncident(ctx context.Context, id, orgID, userID uuid.UUID, req *dto.UpdateIncidentRequest) (*domain.Incident, error)
 	DeleteIncident(ctx context.Context, id, orgID uuid.UUID) error
 	ListIncidents(ctx context.Context, orgID uuid.UUID, req *dto.ListIncidentsRequest) (*dto.ListIncidentsResponse, error)
-	AddResponder(ctx context.Context, incidentID uuid.UUID, userID uuid.UUID, req *dto.AddResponderRequest) (*domain.IncidentResponder, error)
+	ExportIncidentsCSV(ctx context.Context, orgID uuid.UUID, req *dto.ListIncidentsRequest, w io.Writer) error
+	AddResponder(ctx context.Context, incidentID, orgID, userID uuid.UUID, req *dto.AddResponderRequest) (*domain.IncidentResponder, error)
 	RemoveResponder(ctx context.Context, incidentID, orgID, responderUserID, actionUserID uuid.UUID) error
-	UpdateResponderRole(ctx context.Context, incidentID, orgID, responderUserID uuid.UUID, req *dto.UpdateResponderRoleRequest) error
+	UpdateResponderRole(ctx context.Context, incidentID, orgID, responderUserID, actionUserID uuid.UUID, req *dto.UpdateResponderRoleRequest) error
 	ListResponders(ctx context.Context, incidentID, orgID uuid.UUID) ([]*domain.ResponderWithUser, error)
 	AddNote(ctx context.Context, incidentID, orgID, userID uuid.UUID, req *dto.AddNoteRequest) (*domain.IncidentTimelineEvent, error)
-	GetTimeline(ctx context.Context, incidentID, orgID uuid.UUID) ([]*domain.TimelineEventWithUser, error)
+	UpdateNote(ctx context.Context, incidentID, orgID, eventID, userID uuid.UUID, isAdmin bool, req *dto.UpdateNoteRequest) (*domain.IncidentTimelineEvent, error)
+	DeleteNote(ctx context.Context, incidentID, orgID, eventID, userID uuid.UUID, isAdmin bool) error
+	GetTimeline(ctx context.Context, incidentID, orgID uuid.UUID, eventTypes []domain.TimelineEventType) ([]*domain.TimelineEventWithUser, error)
 	LinkAlert(ctx context.Context, incidentID, orgID, userID uuid.UUID, req *dto.LinkAlertRequest) (*domain.IncidentAlert, error)
 	UnlinkAlert(ctx context.Context, incidentID, orgID, alertID, userID uuid.UUID) error
 	ListAlerts(ctx context.Context, incidentID, orgID uuid.UUID) ([]*domain.IncidentAlertWithDetails, error)
+	SuggestAlerts(ctx context.Context, incidentID, orgID uuid.UUID) ([]*dto.SuggestedAlert, error)
 }
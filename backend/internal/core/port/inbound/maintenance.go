@@ -0,0 +1,18 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+)
+
+type MaintenanceWindowService interface {
+	CreateWindow(ctx context.Context, orgID, userID uuid.UUID, req *dto.CreateMaintenanceWindowRequest) (*domain.MaintenanceWindow, error)
+	GetWindow(ctx context.Context, id, orgID uuid.UUID) (*domain.MaintenanceWindow, error)
+	UpdateWindow(ctx context.Context, id, orgID uuid.UUID, req *dto.UpdateMaintenanceWindowRequest) (*domain.MaintenanceWindow, error)
+	DeleteWindow(ctx context.Context, id, orgID uuid.UUID) error
+	ListWindows(ctx context.Context, orgID uuid.UUID, page, pageSize int) ([]*domain.MaintenanceWindow, error)
+}
@@ -2,6 +2,7 @@ package inbound
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -15,6 +16,7 @@ type NotificationService interface {
 	ListChannels(ctx context.Context, orgID uuid.UUID) ([]domain.NotificationChannel, error)
 	UpdateChannel(ctx context.Context, id uuid.UUID, req *dto.UpdateNotificationChannelRequest) (*domain.NotificationChannel, error)
 	DeleteChannel(ctx context.Context, id uuid.UUID) error
+	TestChannel(ctx context.Context, id, orgID uuid.UUID, req *dto.TestNotificationChannelRequest) (*dto.TestNotificationChannelResponse, error)
 	CreatePreference(ctx context.Context, userID uuid.UUID, req *dto.CreateUserNotificationPreferenceRequest) (*domain.UserNotificationPreference, error)
 	GetPreference(ctx context.Context, id uuid.UUID) (*domain.UserNotificationPreference, error)
 	ListUserPreferences(ctx context.Context, userID uuid.UUID) ([]domain.UserNotificationPreference, error)
@@ -22,8 +24,9 @@ type NotificationService interface {
 	DeletePreference(ctx context.Context, id uuid.UUID) error
 	SendNotification(ctx context.Context, orgID uuid.UUID, req *dto.SendNotificationRequest) (*domain.NotificationLog, error)
 	ProcessPendingNotifications(ctx context.Context, limit int) error
+	RetryFailedNotifications(ctx context.Context, now time.Time, limit int) error
 	GetLog(ctx context.Context, id uuid.UUID) (*domain.NotificationLog, error)
-	ListLogs(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]domain.NotificationLog, error)
+	ListLogs(ctx context.Context, orgID uuid.UUID, req *dto.ListNotificationLogsRequest) (*dto.ListNotificationLogsResponse, error)
 	ListLogsByAlert(ctx context.Context, alertID uuid.UUID) ([]domain.NotificationLog, error)
 	ListLogsByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.NotificationLog, error)
 }
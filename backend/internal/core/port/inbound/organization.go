@@ -0,0 +1,17 @@
+package inbound
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+)
+
+type OrganizationService interface {
+	ExportOrganization(ctx context.Context, orgID uuid.UUID, w io.Writer) error
+	GetSettings(ctx context.Context, orgID uuid.UUID) (map[string]interface{}, error)
+	UpdateSettings(ctx context.Context, orgID uuid.UUID, req *dto.UpdateOrganizationSettingsRequest) (map[string]interface{}, error)
+	ImportOrganization(ctx context.Context, orgID uuid.UUID, archive *dto.OrganizationExport) (*dto.OrganizationImportResponse, error)
+}
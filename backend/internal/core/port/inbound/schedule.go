@@ -17,6 +17,7 @@ type ScheduleService interface {
 	UpdateSchedule(ctx context.Context, id uuid.UUID, req *dto.UpdateScheduleRequest) (*domain.Schedule, error)
 	DeleteSchedule(ctx context.Context, id uuid.UUID) error
 	ListSchedules(ctx context.Context, orgID uuid.UUID, page, pageSize int) ([]*domain.Schedule, error)
+	ApplyScheduleConfig(ctx context.Context, orgID, id uuid.UUID, req *dto.ScheduleConfigRequest) (*domain.ScheduleWithParticipants, error)
 	CreateRotation(ctx context.Context, scheduleID uuid.UUID, req *dto.CreateRotationRequest) (*domain.ScheduleRotation, error)
 	GetRotation(ctx context.Context, id uuid.UUID) (*domain.ScheduleRotation, error)
 	UpdateRotation(ctx context.Context, id uuid.UUID, req *dto.UpdateRotationRequest) (*domain.ScheduleRotation, error)
@@ -26,10 +27,14 @@ type ScheduleService interface {
 	RemoveParticipant(ctx context.Context, rotationID, userID uuid.UUID) error
 	ListParticipants(ctx context.Context, rotationID uuid.UUID) ([]*domain.ParticipantWithUser, error)
 	ReorderParticipants(ctx context.Context, rotationID uuid.UUID, req *dto.ReorderParticipantsRequest) error
-	CreateOverride(ctx context.Context, scheduleID uuid.UUID, req *dto.CreateOverrideRequest) (*domain.ScheduleOverride, error)
+	SetParticipants(ctx context.Context, orgID, scheduleID, rotationID uuid.UUID, req *dto.SetParticipantsRequest) ([]*domain.ParticipantWithUser, error)
+	CreateOverride(ctx context.Context, scheduleID uuid.UUID, req *dto.CreateOverrideRequest) (*domain.ScheduleOverride, []string, error)
 	GetOverride(ctx context.Context, id uuid.UUID) (*domain.ScheduleOverride, error)
 	UpdateOverride(ctx context.Context, id uuid.UUID, req *dto.UpdateOverrideRequest) (*domain.ScheduleOverride, error)
 	DeleteOverride(ctx context.Context, id uuid.UUID) error
 	ListOverrides(ctx context.Context, scheduleID uuid.UUID, start, end time.Time) ([]*domain.ScheduleOverride, error)
 	GetOnCallUser(ctx context.Context, scheduleID uuid.UUID, at time.Time) (*domain.OnCallUser, error)
+	ListOnCall(ctx context.Context, orgID uuid.UUID, at time.Time) ([]*domain.ScheduleOnCall, error)
+	PreviewOnCall(ctx context.Context, req *dto.PreviewOnCallRequest) ([]*domain.OnCallUser, error)
+	GetCoverage(ctx context.Context, orgID, scheduleID uuid.UUID, start, end time.Time) (*domain.ScheduleCoverage, error)
 }
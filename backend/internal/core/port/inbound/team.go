@@ -14,11 +14,12 @@ type TeamService interface {
 	GetTeam(ctx context.Context, id uuid.UUID) (*domain.Team, error)
 	GetTeamWithMembers(ctx context.Context, id uuid.UUID) (*dto.TeamWithMembers, error)
 	UpdateTeam(ctx context.Context, id uuid.UUID, req *dto.UpdateTeamRequest) (*domain.Team, error)
-	DeleteTeam(ctx context.Context, id uuid.UUID) error
+	DeleteTeam(ctx context.Context, id uuid.UUID, force bool) error
 	ListTeams(ctx context.Context, orgID uuid.UUID, page, pageSize int) ([]*domain.Team, error)
 	AddMember(ctx context.Context, teamID uuid.UUID, req *dto.AddTeamMemberRequest) error
 	AddMemberOrInvite(ctx context.Context, teamID, orgID, inviterID uuid.UUID, req *dto.InviteMemberRequest) (*dto.InvitationResponse, error)
 	AcceptInvitation(ctx context.Context, token string, userID uuid.UUID) error
+	RedeemInvitation(ctx context.Context, req *dto.AcceptInvitationRequest, callerID *uuid.UUID) (*dto.AcceptInvitationResponse, error)
 	DeclineInvitation(ctx context.Context, token string) error
 	GetPendingInvitations(ctx context.Context, email string) ([]*domain.TeamInvitationWithDetails, error)
 	ListTeamInvitations(ctx context.Context, teamID uuid.UUID) ([]*domain.TeamInvitation, error)
@@ -10,6 +10,11 @@ import (
 )
 
 type UserService interface {
-	ListOrganizationUsers(ctx context.Context, orgID uuid.UUID) ([]*domain.UserWithOrganization, error)
+	ListOrganizationUsers(ctx context.Context, orgID uuid.UUID, req *dto.ListUsersRequest) (*dto.ListUsersResponse, error)
+	DeactivateUser(ctx context.Context, orgID, userID, actorID uuid.UUID) (*domain.UserDeactivation, error)
+	ReactivateUser(ctx context.Context, orgID, userID uuid.UUID) (*domain.User, error)
+	UnlockUser(ctx context.Context, orgID, userID uuid.UUID) error
+	ListUserSessions(ctx context.Context, orgID, userID uuid.UUID) ([]*dto.SessionResponse, error)
+	RevokeUserSessions(ctx context.Context, orgID, userID uuid.UUID) (int, error)
 	UpdateProfile(ctx context.Context, userID uuid.UUID, req *dto.UpdateProfileRequest) (*domain.User, error)
 }
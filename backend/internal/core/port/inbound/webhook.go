@@ -15,12 +15,16 @@ type WebhookService interface {
 	ListEndpoints(ctx context.Context, orgID uuid.UUID) ([]*domain.WebhookEndpoint, error)
 	UpdateEndpoint(ctx context.Context, id, orgID uuid.UUID, req *dto.UpdateWebhookEndpointRequest) (*domain.WebhookEndpoint, error)
 	DeleteEndpoint(ctx context.Context, id, orgID uuid.UUID) error
+	RotateEndpointSecret(ctx context.Context, id, orgID uuid.UUID) (*domain.WebhookEndpoint, error)
 	TriggerWebhooks(ctx context.Context, orgID uuid.UUID, eventType string, data map[string]interface{})
-	ProcessPendingDeliveries(ctx context.Context) error
+	ProcessPendingDeliveries(ctx context.Context, limit int) error
 	ListDeliveries(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]*domain.WebhookDelivery, error)
 	CreateIncomingToken(ctx context.Context, orgID uuid.UUID, req *dto.CreateIncomingWebhookTokenRequest) (*domain.IncomingWebhookToken, error)
 	GetIncomingTokenByToken(ctx context.Context, token string) (*domain.IncomingWebhookToken, error)
 	ListIncomingTokens(ctx context.Context, orgID uuid.UUID) ([]*domain.IncomingWebhookToken, error)
+	RotateIncomingToken(ctx context.Context, id, orgID uuid.UUID) (*domain.IncomingWebhookToken, error)
 	UpdateIncomingTokenUsage(ctx context.Context, tokenID uuid.UUID) error
 	DeleteIncomingToken(ctx context.Context, id, orgID uuid.UUID) error
+	RecordIncomingRequest(ctx context.Context, request *domain.IncomingWebhookRequest) error
+	ListIncomingRequests(ctx context.Context, tokenID, orgID uuid.UUID) ([]*domain.IncomingWebhookRequest, error)
 }
@@ -0,0 +1,13 @@
+package inbound
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+)
+
+type WorkloadService interface {
+	GetWorkload(ctx context.Context, orgID, userID uuid.UUID) (*dto.WorkloadResponse, error)
+}
@@ -0,0 +1,21 @@
+package outbound
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+type AlertAckTokenRepository interface {
+	Create(ctx context.Context, token *domain.AlertAckToken) error
+	// Consume marks the token used and returns it. It returns
+	// domain.ErrNotFound unless the token exists, is unused and hasn't
+	// expired.
+	Consume(ctx context.Context, id uuid.UUID) (*domain.AlertAckToken, error)
+	// DeleteSpent removes up to limit tokens that were used or expired
+	// before now, returning how many were removed
+	DeleteSpent(ctx context.Context, now time.Time, limit int) (int, error)
+}
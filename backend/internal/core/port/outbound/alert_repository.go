@@ -10,15 +10,27 @@ import (
 )
 
 type AlertRepository interface {
-	Create(ctx context.Context, alert *domain.Alert) error
+	Create(ctx context.Context, alert *domain.Alert, events ...*domain.OutboxEvent) error
 	GetByID(ctx context.Context, id, orgID uuid.UUID) (*domain.Alert, error)
 	Update(ctx context.Context, alert *domain.Alert) error
 	Delete(ctx context.Context, id, orgID uuid.UUID) error
 	List(ctx context.Context, filter *domain.AlertFilter) ([]*domain.Alert, int, error)
+	GroupOpenAlerts(ctx context.Context, grouping *domain.AlertGrouping) ([]*domain.AlertGroup, error)
 	Acknowledge(ctx context.Context, id, orgID, userID uuid.UUID) error
+	Unacknowledge(ctx context.Context, id, orgID uuid.UUID, escalation *domain.AlertEscalationEvent, note *domain.AlertNote) error
 	Close(ctx context.Context, id, orgID, userID uuid.UUID, reason string) error
-	Snooze(ctx context.Context, id, orgID uuid.UUID, until time.Time) error
-	Assign(ctx context.Context, id, orgID uuid.UUID, userID, teamID *uuid.UUID) error
+	Snooze(ctx context.Context, id, orgID, userID uuid.UUID, until time.Time, reason *string) error
+	ReopenExpiredSnoozes(ctx context.Context, now time.Time, limit int) ([]*domain.Alert, error)
+	GetStaleOpenAlerts(ctx context.Context, orgID uuid.UUID, priorities []domain.AlertPriority, untouchedSince time.Time, limit int) ([]*domain.Alert, error)
+	ListSuggestionCandidates(ctx context.Context, orgID, incidentID uuid.UUID, since, until time.Time, tags, sources []string, limit int) ([]*domain.Alert, error)
+	AutoClose(ctx context.Context, id, orgID uuid.UUID, reason string, untouchedSince time.Time) (bool, error)
+	PurgeClosedAlerts(ctx context.Context, orgID uuid.UUID, closedBefore time.Time, exempt []domain.AlertPriority, archive bool, limit int) (int, error)
+	CountPurgeableAlerts(ctx context.Context, orgID uuid.UUID, closedBefore time.Time, exempt []domain.AlertPriority) (int, error)
+	CloseFromSource(ctx context.Context, id, orgID uuid.UUID, reason string) (bool, error)
+	Assign(ctx context.Context, id, orgID uuid.UUID, assignment *domain.AlertAssignment) error
+	UpdateTags(ctx context.Context, orgID uuid.UUID, ids []uuid.UUID, add, remove []string) ([]*domain.Alert, error)
 	FindByDedupKey(ctx context.Context, orgID uuid.UUID, dedupKey string) (*domain.Alert, error)
 	IncrementDedupCount(ctx context.Context, id uuid.UUID) error
+	AddNote(ctx context.Context, note *domain.AlertNote) error
+	ListNotes(ctx context.Context, alertID, orgID uuid.UUID) ([]*domain.AlertNoteWithUser, error)
 }
@@ -0,0 +1,21 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+type AlertSourceRepository interface {
+	Create(ctx context.Context, source *domain.RegisteredSource) error
+	GetByID(ctx context.Context, id, orgID uuid.UUID) (*domain.RegisteredSource, error)
+	Update(ctx context.Context, source *domain.RegisteredSource) error
+	Delete(ctx context.Context, id, orgID uuid.UUID) error
+	List(ctx context.Context, orgID uuid.UUID) ([]*domain.RegisteredSource, error)
+	ListWithCounts(ctx context.Context, orgID uuid.UUID) ([]*domain.RegisteredSourceWithCount, error)
+	// FindByKey returns the source whose name or alias matches the
+	// normalized key, or domain.ErrAlertSourceNotFound
+	FindByKey(ctx context.Context, orgID uuid.UUID, key string) (*domain.RegisteredSource, error)
+}
@@ -0,0 +1,19 @@
+package outbound
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+type UserAvailabilityRepository interface {
+	Create(ctx context.Context, period *domain.UserUnavailability) error
+	ListByUser(ctx context.Context, userID uuid.UUID, endingAfter time.Time) ([]*domain.UserUnavailability, error)
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+	// UnavailableAt returns the users among userIDs that are unavailable at
+	// the given time, keyed by user ID
+	UnavailableAt(ctx context.Context, userIDs []uuid.UUID, at time.Time) (map[uuid.UUID]*domain.UserUnavailability, error)
+}
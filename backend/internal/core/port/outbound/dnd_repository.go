@@ -14,4 +14,6 @@ type DNDSettingsRepository interface {
 	Update(ctx context.Context, settings *domain.UserDNDSettings) error
 	Delete(ctx context.Context, userID uuid.UUID) error
 	Upsert(ctx context.Context, settings *domain.UserDNDSettings) error
+	AppendOverride(ctx context.Context, defaults *domain.UserDNDSettings, override domain.DNDOverride) (*domain.UserDNDSettings, error)
+	RemoveOverride(ctx context.Context, userID uuid.UUID, index int) (*domain.UserDNDSettings, error)
 }
@@ -13,6 +13,8 @@ type EmailVerificationRepository interface {
 	GetByEmail(ctx context.Context, email string) (*domain.EmailVerification, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.EmailVerification, error)
 	MarkVerified(ctx context.Context, id uuid.UUID) error
+	ClaimAttempt(ctx context.Context, id uuid.UUID, maxAttempts int) (int, error)
+	RecordSendResult(ctx context.Context, id uuid.UUID, attempts int, sendErr *string) error
 	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
 	DeleteExpired(ctx context.Context) error
 }
@@ -15,6 +15,7 @@ type EscalationPolicyRepository interface {
 	Update(ctx context.Context, policy *domain.EscalationPolicy) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]*domain.EscalationPolicy, error)
+	ListWithConditions(ctx context.Context, orgID uuid.UUID) ([]*domain.EscalationPolicy, error)
 	GetWithRules(ctx context.Context, id uuid.UUID) (*domain.EscalationPolicyWithRules, error)
 	CreateRule(ctx context.Context, rule *domain.EscalationRule) error
 	GetRule(ctx context.Context, id uuid.UUID) (*domain.EscalationRule, error)
@@ -24,8 +25,12 @@ type EscalationPolicyRepository interface {
 	AddTarget(ctx context.Context, target *domain.EscalationTarget) error
 	RemoveTarget(ctx context.Context, id uuid.UUID) error
 	ListTargets(ctx context.Context, ruleID uuid.UUID) ([]*domain.EscalationTarget, error)
+	ApplyConfig(ctx context.Context, config *domain.EscalationPolicyWithRules) error
+	CreateWithRules(ctx context.Context, config *domain.EscalationPolicyWithRules) error
 	CreateEvent(ctx context.Context, event *domain.AlertEscalationEvent) error
 	GetLatestEvent(ctx context.Context, alertID uuid.UUID) (*domain.AlertEscalationEvent, error)
 	UpdateEvent(ctx context.Context, event *domain.AlertEscalationEvent) error
-	ListPendingEscalations(ctx context.Context, before time.Time) ([]*domain.AlertEscalationEvent, error)
+	RecordNotifiedStep(ctx context.Context, event *domain.AlertEscalationEvent) (bool, error)
+	ClaimPendingEscalations(ctx context.Context, before time.Time, limit int) ([]*domain.AlertEscalationEvent, error)
+	CountPendingEscalations(ctx context.Context, before time.Time) (int, error)
 }
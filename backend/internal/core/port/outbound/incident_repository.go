@@ -12,14 +12,22 @@ type IncidentRepository interface {
 	Create(ctx context.Context, incident *domain.Incident) error
 	GetByID(ctx context.Context, id, orgID uuid.UUID) (*domain.Incident, error)
 	Update(ctx context.Context, incident *domain.Incident) error
+	SetWarRoom(ctx context.Context, id, orgID uuid.UUID, room *domain.WarRoom) error
+	SetIssueLink(ctx context.Context, id, orgID uuid.UUID, link *domain.IssueLink) error
+	SetStatuspageIncident(ctx context.Context, id, orgID uuid.UUID, statuspageIncidentID string) error
 	Delete(ctx context.Context, id, orgID uuid.UUID) error
 	List(ctx context.Context, filter *domain.IncidentFilter) ([]*domain.Incident, int, error)
 	AddResponder(ctx context.Context, responder *domain.IncidentResponder) error
 	RemoveResponder(ctx context.Context, incidentID, orgID, userID uuid.UUID) error
 	UpdateResponderRole(ctx context.Context, incidentID, orgID, userID uuid.UUID, role domain.ResponderRole) error
+	AddCommander(ctx context.Context, responder *domain.IncidentResponder) ([]uuid.UUID, error)
+	PromoteToCommander(ctx context.Context, incidentID, orgID, userID uuid.UUID) ([]uuid.UUID, error)
 	ListResponders(ctx context.Context, incidentID, orgID uuid.UUID) ([]*domain.ResponderWithUser, error)
 	AddTimelineEvent(ctx context.Context, event *domain.IncidentTimelineEvent) error
-	GetTimeline(ctx context.Context, incidentID, orgID uuid.UUID) ([]*domain.TimelineEventWithUser, error)
+	GetTimeline(ctx context.Context, incidentID, orgID uuid.UUID, eventTypes []domain.TimelineEventType) ([]*domain.TimelineEventWithUser, error)
+	GetTimelineEvent(ctx context.Context, eventID, incidentID, orgID uuid.UUID) (*domain.IncidentTimelineEvent, error)
+	UpdateTimelineEventDescription(ctx context.Context, event *domain.IncidentTimelineEvent) error
+	DeleteTimelineEvent(ctx context.Context, eventID, incidentID uuid.UUID) error
 	LinkAlert(ctx context.Context, link *domain.IncidentAlert) error
 	UnlinkAlert(ctx context.Context, incidentID, orgID, alertID uuid.UUID) error
 	ListAlerts(ctx context.Context, incidentID, orgID uuid.UUID) ([]*domain.IncidentAlertWithDetails, error)
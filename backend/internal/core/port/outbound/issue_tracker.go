@@ -0,0 +1,13 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+// IssueTracker opens an issue for follow-up work on an incident in the
+// organization's Jira project.
+type IssueTracker interface {
+	CreateIssue(ctx context.Context, settings domain.JiraSettings, incident *domain.Incident) (*domain.IssueLink, error)
+}
@@ -0,0 +1,31 @@
+package outbound
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+type LoginAttemptRepository interface {
+	Create(ctx context.Context, attempt *domain.LoginAttempt) error
+	// CountIPFailures counts the logins from the IP address that failed on
+	// invalid credentials since the given time
+	CountIPFailures(ctx context.Context, ipAddress string, since time.Time) (int, error)
+	// GetLockout returns domain.ErrNotFound when the account has no failed
+	// logins since its last successful one
+	GetLockout(ctx context.Context, userID uuid.UUID) (*domain.LoginLockout, error)
+	// ClaimAttempt counts a login against the account before its password is
+	// checked and returns the new number of consecutive attempts. Attempts
+	// older than since are forgotten first. While the account is locked, or
+	// maxFailures attempts are already counted, it returns
+	// domain.ErrLoginLocked instead; the check and the increment are atomic,
+	// so concurrent logins can't get past the limit.
+	ClaimAttempt(ctx context.Context, userID uuid.UUID, since time.Time, maxFailures int) (int, error)
+	// Lock locks the account until the given time and clears its failures
+	Lock(ctx context.Context, userID uuid.UUID, until time.Time) error
+	// ResetLockout clears the account's attempts and any lock
+	ResetLockout(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,19 @@
+package outbound
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+type MaintenanceWindowRepository interface {
+	Create(ctx context.Context, window *domain.MaintenanceWindow) error
+	GetByID(ctx context.Context, id, orgID uuid.UUID) (*domain.MaintenanceWindow, error)
+	Update(ctx context.Context, window *domain.MaintenanceWindow) error
+	Delete(ctx context.Context, id, orgID uuid.UUID) error
+	List(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]*domain.MaintenanceWindow, error)
+	ListActive(ctx context.Context, orgID uuid.UUID, at time.Time) ([]*domain.MaintenanceWindow, error)
+}
@@ -2,6 +2,7 @@ package outbound
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -22,10 +23,12 @@ type NotificationRepository interface {
 	DeletePreference(ctx context.Context, id uuid.UUID) error
 	CreateLog(ctx context.Context, log *domain.NotificationLog) error
 	GetLogByID(ctx context.Context, id uuid.UUID) (*domain.NotificationLog, error)
-	ListLogs(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]domain.NotificationLog, error)
+	ListLogs(ctx context.Context, filter *domain.NotificationLogFilter) ([]domain.NotificationLog, int, error)
 	ListLogsByAlert(ctx context.Context, alertID uuid.UUID) ([]domain.NotificationLog, error)
 	ListLogsByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.NotificationLog, error)
 	GetPendingNotifications(ctx context.Context, limit int) ([]domain.NotificationLog, error)
 	UpdateLogStatus(ctx context.Context, id uuid.UUID, status domain.NotificationStatus, errorMsg *string) error
+	RecordDeliveryAttempt(ctx context.Context, id uuid.UUID, errorMsg *string, nextRetryAt *time.Time) error
+	GetRetriableFailures(ctx context.Context, now time.Time, limit int) ([]domain.NotificationRetry, error)
 	IsUserInDND(ctx context.Context, userID, channelID uuid.UUID) (bool, error)
 }
@@ -12,5 +12,6 @@ type AlertNotificationSender interface {
 	NotifyAlertCreated(ctx context.Context, alert *domain.Alert) error
 	NotifyAlertAcknowledged(ctx context.Context, alert *domain.Alert, acknowledgedBy uuid.UUID) error
 	NotifyAlertClosed(ctx context.Context, alert *domain.Alert, closedBy uuid.UUID, reason string) error
+	NotifyAlertUnsnoozed(ctx context.Context, alert *domain.Alert) error
 	NotifyAlertEscalated(ctx context.Context, alert *domain.Alert, escalationRule *domain.EscalationRule, targets []domain.EscalationTarget) error
 }
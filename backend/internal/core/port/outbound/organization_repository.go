@@ -17,8 +17,24 @@ type OrganizationRepository interface {
 	List(ctx context.Context, limit, offset int) ([]*domain.Organization, error)
 	AddUser(ctx context.Context, orgID, userID uuid.UUID, role domain.UserRole) error
 	RemoveUser(ctx context.Context, orgID, userID uuid.UUID) error
+	// GetUserRole returns domain.ErrNotFound for members deactivated in the
+	// organization
 	GetUserRole(ctx context.Context, orgID, userID uuid.UUID) (domain.UserRole, error)
+	// GetMembership also finds deactivated members and reports whether the
+	// membership is active
+	GetMembership(ctx context.Context, orgID, userID uuid.UUID) (domain.UserRole, bool, error)
+	SetMemberActive(ctx context.Context, orgID, userID uuid.UUID, active bool) error
 	UpdateUserRole(ctx context.Context, orgID, userID uuid.UUID, role domain.UserRole) error
 	ListUsers(ctx context.Context, orgID uuid.UUID) ([]*domain.UserWithOrganization, error)
+	SearchUsers(ctx context.Context, filter *domain.UserFilter) ([]*domain.UserWithOrganization, int, error)
+	// ListUserOrganizations and ListUserMemberships leave out organizations
+	// the user was deactivated in
 	ListUserOrganizations(ctx context.Context, userID uuid.UUID) ([]*domain.Organization, error)
+	ListUserMemberships(ctx context.Context, userID uuid.UUID) ([]*domain.OrganizationMembership, error)
+}
+
+// OrganizationImportRepository persists an imported organization configuration.
+// Import must create everything or nothing.
+type OrganizationImportRepository interface {
+	Import(ctx context.Context, data *domain.OrganizationImport) error
 }
@@ -0,0 +1,18 @@
+package outbound
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+// OutboxRepository reads back the events repositories record alongside their
+// writes
+type OutboxRepository interface {
+	ClaimUnpublished(ctx context.Context, createdBefore time.Time, limit int) ([]*domain.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id uuid.UUID) error
+	MarkPublishFailed(ctx context.Context, id uuid.UUID) error
+}
@@ -21,14 +21,23 @@ type ScheduleRepository interface {
 	UpdateRotation(ctx context.Context, rotation *domain.ScheduleRotation) error
 	DeleteRotation(ctx context.Context, id uuid.UUID) error
 	ListRotations(ctx context.Context, scheduleID uuid.UUID) ([]*domain.ScheduleRotation, error)
+	ListRotationsByTeam(ctx context.Context, teamID uuid.UUID) ([]*domain.ScheduleRotation, error)
 	AddParticipant(ctx context.Context, participant *domain.ScheduleRotationParticipant) error
 	RemoveParticipant(ctx context.Context, rotationID, userID uuid.UUID) error
 	ListParticipants(ctx context.Context, rotationID uuid.UUID) ([]*domain.ParticipantWithUser, error)
 	ReorderParticipants(ctx context.Context, rotationID uuid.UUID, userIDs []uuid.UUID) error
+	// SetParticipants replaces the participants of a rotation with userIDs in
+	// a single transaction, numbering their positions from 0.
+	SetParticipants(ctx context.Context, rotationID uuid.UUID, userIDs []uuid.UUID) error
 	CreateOverride(ctx context.Context, override *domain.ScheduleOverride) error
 	GetOverride(ctx context.Context, id uuid.UUID) (*domain.ScheduleOverride, error)
 	UpdateOverride(ctx context.Context, override *domain.ScheduleOverride) error
 	DeleteOverride(ctx context.Context, id uuid.UUID) error
 	ListOverrides(ctx context.Context, scheduleID uuid.UUID, start, end time.Time) ([]*domain.ScheduleOverride, error)
 	GetOnCallUser(ctx context.Context, scheduleID uuid.UUID, at time.Time) (*domain.OnCallUser, error)
+	ApplyConfig(ctx context.Context, config *domain.ScheduleConfig) error
+	ListAllRotations(ctx context.Context, limit, offset int) ([]*domain.ScheduleRotation, error)
+	// ClaimHandoff records a handoff notification unless the rotation already has
+	// one for the same or a later shift. It reports whether the claim succeeded.
+	ClaimHandoff(ctx context.Context, handoff *domain.ScheduleHandoff) (bool, error)
 }
@@ -0,0 +1,37 @@
+package outbound
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *domain.Session) error
+	// Rotate replaces the session's refresh token hash and records its use.
+	// It returns domain.ErrNotFound unless the session is active and
+	// currently holds oldHash. An empty ipAddress or userAgent keeps the
+	// stored value.
+	Rotate(ctx context.Context, id uuid.UUID, oldHash, newHash, ipAddress, userAgent string, expiresAt time.Time) error
+	// SwitchOrganization moves the user's active session to another
+	// organization and replaces its refresh token hash. It returns
+	// domain.ErrNotFound unless the user has the active session.
+	SwitchOrganization(ctx context.Context, userID, id, orgID uuid.UUID, newHash string, expiresAt time.Time) error
+	// ListActive returns the user's unrevoked, unexpired sessions, most
+	// recently used first
+	ListActive(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error)
+	// ListActiveInOrganization is ListActive limited to sessions signed in to
+	// the organization
+	ListActiveInOrganization(ctx context.Context, userID, orgID uuid.UUID) ([]*domain.Session, error)
+	// Revoke returns domain.ErrNotFound unless the user has the active session
+	Revoke(ctx context.Context, userID, id uuid.UUID) error
+	// RevokeAll revokes every active session of the user and returns how many
+	// there were
+	RevokeAll(ctx context.Context, userID uuid.UUID) (int, error)
+	// RevokeAllInOrganization is RevokeAll limited to sessions signed in to
+	// the organization
+	RevokeAllInOrganization(ctx context.Context, userID, orgID uuid.UUID) (int, error)
+}
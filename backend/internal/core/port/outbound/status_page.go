@@ -0,0 +1,15 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+// StatuspagePublisher mirrors incidents on the organization's public status
+// page. OpenIncident returns the ID of the status page incident, which later
+// status updates refer to.
+type StatuspagePublisher interface {
+	OpenIncident(ctx context.Context, settings domain.StatuspageSettings, incident *domain.Incident) (string, error)
+	UpdateIncident(ctx context.Context, settings domain.StatuspageSettings, statuspageIncidentID string, incident *domain.Incident) error
+}
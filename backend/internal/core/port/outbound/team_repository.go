@@ -13,6 +13,8 @@ type TeamRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Team, error)
 	Update(ctx context.Context, team *domain.Team) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	ForceDelete(ctx context.Context, id uuid.UUID) error
+	ListDependencies(ctx context.Context, id uuid.UUID) ([]domain.TeamDependency, error)
 	List(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]*domain.Team, error)
 	AddMember(ctx context.Context, teamID, userID uuid.UUID, role domain.TeamRole) error
 	RemoveMember(ctx context.Context, teamID, userID uuid.UUID) error
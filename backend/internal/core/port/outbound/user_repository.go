@@ -16,4 +16,9 @@ type UserRepository interface {
 	Update(ctx context.Context, user *domain.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, limit, offset int) ([]*domain.User, error)
+	// Deactivate disables the user's membership of the organization and
+	// removes their team, rotation and upcoming override memberships in it.
+	// Their other organizations are not affected. Open alerts assigned
+	// to them are unassigned and noted on behalf of actorID.
+	Deactivate(ctx context.Context, orgID, userID, actorID uuid.UUID, note string) (*domain.UserDeactivation, error)
 }
@@ -0,0 +1,14 @@
+package outbound
+
+import (
+	"context"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+// WarRoomCreator opens a chat channel dedicated to an incident and posts its
+// summary there. A returned room is usable even when err reports that posting
+// the summary failed.
+type WarRoomCreator interface {
+	CreateWarRoom(ctx context.Context, botToken string, incident *domain.Incident) (*domain.WarRoom, error)
+}
@@ -9,3 +9,9 @@ import (
 type WebhookDispatcher interface {
 	TriggerWebhooks(ctx context.Context, orgID uuid.UUID, eventType string, data map[string]interface{})
 }
+
+// WebhookEnqueuer stores webhook deliveries before returning, for callers that
+// need to know the event has been handed over
+type WebhookEnqueuer interface {
+	EnqueueWebhooks(ctx context.Context, orgID uuid.UUID, eventType string, data map[string]interface{}) error
+}
@@ -2,6 +2,7 @@ package outbound
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -13,14 +14,21 @@ type WebhookRepository interface {
 	GetEndpointByID(ctx context.Context, id uuid.UUID) (*domain.WebhookEndpoint, error)
 	ListEndpoints(ctx context.Context, orgID uuid.UUID) ([]*domain.WebhookEndpoint, error)
 	UpdateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error
+	UpdateEndpointSecret(ctx context.Context, id, orgID uuid.UUID, secret string) error
 	DeleteEndpoint(ctx context.Context, id, orgID uuid.UUID) error
 	CreateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
 	UpdateDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
-	GetPendingDeliveries(ctx context.Context, limit int) ([]*domain.WebhookDelivery, error)
+	ClaimPendingDeliveries(ctx context.Context, limit int) ([]*domain.WebhookDelivery, error)
+	CountPendingDeliveries(ctx context.Context) (int, error)
+	GetPendingRetryAt(ctx context.Context, endpointID uuid.UUID, createdBefore time.Time) (*time.Time, error)
 	ListDeliveries(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]*domain.WebhookDelivery, error)
 	CreateIncomingToken(ctx context.Context, token *domain.IncomingWebhookToken) error
 	GetIncomingTokenByToken(ctx context.Context, token string) (*domain.IncomingWebhookToken, error)
+	GetIncomingTokenByID(ctx context.Context, id, orgID uuid.UUID) (*domain.IncomingWebhookToken, error)
 	ListIncomingTokens(ctx context.Context, orgID uuid.UUID) ([]*domain.IncomingWebhookToken, error)
+	UpdateIncomingTokenValue(ctx context.Context, id, orgID uuid.UUID, token string) error
 	UpdateIncomingTokenUsage(ctx context.Context, id uuid.UUID) error
 	DeleteIncomingToken(ctx context.Context, id, orgID uuid.UUID) error
+	CreateIncomingRequest(ctx context.Context, request *domain.IncomingWebhookRequest, keep int) error
+	ListIncomingRequests(ctx context.Context, tokenID, orgID uuid.UUID) ([]*domain.IncomingWebhookRequest, error)
 }
@@ -2,32 +2,139 @@ package service
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 
 	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/dto"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/logger"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/prommetrics"
 )
 
+// MaintenanceMatcher finds the active maintenance window suppressing an alert
+type MaintenanceMatcher interface {
+	MatchingWindow(ctx context.Context, alert *domain.Alert, at time.Time) (*domain.MaintenanceWindow, error)
+}
+
+// EscalationPolicySelector decides the escalation policy for a new alert
+type EscalationPolicySelector interface {
+	SelectEscalationPolicy(ctx context.Context, alert *domain.Alert) (*uuid.UUID, error)
+}
+
+// OnCallResolver looks up schedules and who was on call for them
+type OnCallResolver interface {
+	GetSchedule(ctx context.Context, id uuid.UUID) (*domain.Schedule, error)
+	GetOnCallUser(ctx context.Context, scheduleID uuid.UUID, at time.Time) (*domain.OnCallUser, error)
+}
+
+// SourceNormalizer maps an alert source to its canonical name
+type SourceNormalizer interface {
+	NormalizeSource(ctx context.Context, orgID uuid.UUID, source string) (string, error)
+}
+
+// OutboxPublisher publishes outbox events once the write that recorded them
+// has committed
+type OutboxPublisher interface {
+	Publish(ctx context.Context, event *domain.OutboxEvent) error
+}
+
 type AlertService struct {
-	alertRepo   outbound.AlertRepository
-	notifier    outbound.AlertNotificationSender
-	broadcaster outbound.EventBroadcaster
-	dispatcher  outbound.WebhookDispatcher
+	alertRepo      outbound.AlertRepository
+	orgRepo        outbound.OrganizationRepository
+	maintenance    MaintenanceMatcher
+	policySelector EscalationPolicySelector
+	policyRepo     outbound.EscalationPolicyRepository
+	onCall         OnCallResolver
+	notifier       outbound.AlertNotificationSender
+	broadcaster    outbound.EventBroadcaster
+	dispatcher     outbound.WebhookDispatcher
+	outbox         OutboxPublisher
+	sources        SourceNormalizer
+	ackTokenRepo   outbound.AlertAckTokenRepository
+	ackTokenUsers  outbound.UserRepository
+	ackTokenConfig AckTokenConfig
+	logger         *zap.Logger
 }
 
-func NewAlertService(alertRepo outbound.AlertRepository, notifier outbound.AlertNotificationSender, broadcaster outbound.EventBroadcaster, dispatcher outbound.WebhookDispatcher) *AlertService {
+// AckTokenConfig signs acknowledge link tokens and bounds how long they work
+type AckTokenConfig struct {
+	Secret string
+	TTL    time.Duration
+}
+
+func NewAlertService(alertRepo outbound.AlertRepository, notifier outbound.AlertNotificationSender, broadcaster outbound.EventBroadcaster, dispatcher outbound.WebhookDispatcher, logger *zap.Logger) *AlertService {
 	return &AlertService{
 		alertRepo:   alertRepo,
 		notifier:    notifier,
 		broadcaster: broadcaster,
 		dispatcher:  dispatcher,
+		logger:      logger,
 	}
 }
 
+// SetOrganizationRepo sets the organization repository (optional dependency).
+// Without it alerts are never auto-closed.
+func (s *AlertService) SetOrganizationRepo(repo outbound.OrganizationRepository) {
+	s.orgRepo = repo
+}
+
+// SetMaintenanceMatcher sets the maintenance window matcher (optional dependency).
+// Without it alerts are never suppressed by maintenance windows.
+func (s *AlertService) SetMaintenanceMatcher(matcher MaintenanceMatcher) {
+	s.maintenance = matcher
+}
+
+// SetEscalationPolicyRepo sets the escalation policy repository (optional dependency).
+// Without it alerts cannot be assigned an escalation policy.
+func (s *AlertService) SetEscalationPolicyRepo(repo outbound.EscalationPolicyRepository) {
+	s.policyRepo = repo
+}
+
+// SetEscalationPolicySelector sets the escalation policy selector (optional dependency).
+// Without it new alerts only get an explicitly assigned policy.
+func (s *AlertService) SetEscalationPolicySelector(selector EscalationPolicySelector) {
+	s.policySelector = selector
+}
+
+// SetOnCallResolver sets the on-call resolver (optional dependency).
+// Without it the on-call users at alert creation cannot be looked up.
+func (s *AlertService) SetOnCallResolver(resolver OnCallResolver) {
+	s.onCall = resolver
+}
+
+// SetOutboxPublisher sets the outbox publisher (optional dependency).
+// Without it alert.created events are emitted directly after the insert and
+// are lost if the process stops in between.
+func (s *AlertService) SetOutboxPublisher(publisher OutboxPublisher) {
+	s.outbox = publisher
+}
+
+// SetSourceNormalizer sets the alert source normalizer (optional dependency).
+// Without it alerts keep the source they were created with.
+func (s *AlertService) SetSourceNormalizer(normalizer SourceNormalizer) {
+	s.sources = normalizer
+}
+
+// SetAckTokens sets the acknowledge link token repository and the users the
+// tokens act for (optional dependency). Without it tokens can't be issued or
+// used. Tokens are also refused until the organization repository is set, as
+// it's needed to check the user is still a member.
+func (s *AlertService) SetAckTokens(repo outbound.AlertAckTokenRepository, users outbound.UserRepository, config AckTokenConfig) {
+	s.ackTokenRepo = repo
+	s.ackTokenUsers = users
+	s.ackTokenConfig = config
+}
+
 func (s *AlertService) CreateAlert(ctx context.Context, orgID uuid.UUID, req *dto.CreateAlertRequest) (*domain.Alert, error) {
 	// Validate priority
 	priority := domain.AlertPriority(req.Priority)
@@ -35,6 +142,16 @@ func (s *AlertService) CreateAlert(ctx context.Context, orgID uuid.UUID, req *dt
 		return nil, fmt.Errorf("invalid priority: %s", req.Priority)
 	}
 
+	tags, err := domain.NormalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := s.normalizeSource(ctx, orgID, req.Source)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check for deduplication
 	if req.DedupKey != nil && *req.DedupKey != "" {
 		existingAlert, err := s.alertRepo.FindByDedupKey(ctx, orgID, *req.DedupKey)
@@ -54,6 +171,8 @@ func (s *AlertService) CreateAlert(ctx context.Context, orgID uuid.UUID, req *dt
 				return nil, fmt.Errorf("failed to get updated alert: %w", err)
 			}
 
+			prommetrics.AlertsDeduplicatedTotal.Inc()
+
 			// Broadcast WebSocket event for dedup
 			if s.broadcaster != nil {
 				s.broadcaster.BroadcastAlertEvent(domain.WSEventAlertUpdated, orgID, updatedAlert)
@@ -63,12 +182,7 @@ func (s *AlertService) CreateAlert(ctx context.Context, orgID uuid.UUID, req *dt
 		}
 	}
 
-	// Initialize tags and custom fields if nil
-	tags := req.Tags
-	if tags == nil {
-		tags = []string{}
-	}
-
+	// Initialize custom fields if nil
 	customFields := req.CustomFields
 	if customFields == nil {
 		customFields = make(map[string]interface{})
@@ -76,29 +190,58 @@ func (s *AlertService) CreateAlert(ctx context.Context, orgID uuid.UUID, req *dt
 
 	now := time.Now()
 	alert := &domain.Alert{
-		ID:                uuid.New(),
-		OrganizationID:    orgID,
-		Source:            req.Source,
-		SourceID:          req.SourceID,
-		Priority:          priority,
-		Status:            domain.AlertStatusOpen,
-		Message:           req.Message,
-		Description:       req.Description,
-		Tags:              tags,
-		CustomFields:      customFields,
-		EscalationLevel:   0,
-		DedupKey:          req.DedupKey,
-		DedupCount:        1,
-		FirstOccurrenceAt: &now,
-		LastOccurrenceAt:  &now,
-	}
-
-	if err := s.alertRepo.Create(ctx, alert); err != nil {
+		ID:                 uuid.New(),
+		OrganizationID:     orgID,
+		Source:             source,
+		SourceID:           req.SourceID,
+		Priority:           priority,
+		Status:             domain.AlertStatusOpen,
+		Message:            req.Message,
+		Description:        req.Description,
+		Tags:               tags,
+		CustomFields:       customFields,
+		EscalationLevel:    0,
+		DedupKey:           req.DedupKey,
+		EscalationPolicyID: req.EscalationPolicyID,
+		DedupCount:         1,
+		FirstOccurrenceAt:  &now,
+		LastOccurrenceAt:   &now,
+	}
+
+	if s.policySelector != nil {
+		policyID, err := s.policySelector.SelectEscalationPolicy(ctx, alert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select escalation policy: %w", err)
+		}
+		alert.EscalationPolicyID = policyID
+	}
+
+	// Alerts matching an active maintenance window are recorded but not paged
+	if s.maintenance != nil {
+		window, err := s.maintenance.MatchingWindow(ctx, alert, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check maintenance windows: %w", err)
+		}
+		if window != nil {
+			alert.MaintenanceWindowID = &window.ID
+		}
+	}
+
+	// With an outbox the created event is stored alongside the alert, so it
+	// can't be lost after the commit or emitted for an insert that failed
+	var events []*domain.OutboxEvent
+	if s.outbox != nil {
+		events = append(events, domain.NewAlertOutboxEvent(domain.WebhookEventAlertCreated, alert, alertCreatedData(alert, now)))
+	}
+
+	if err := s.alertRepo.Create(ctx, alert, events...); err != nil {
 		return nil, fmt.Errorf("failed to create alert: %w", err)
 	}
 
+	prommetrics.AlertsCreatedTotal.WithLabelValues(string(alert.Priority)).Inc()
+
 	// Send notification for new alert (async, don't fail if notification fails)
-	if s.notifier != nil {
+	if s.notifier != nil && alert.MaintenanceWindowID == nil {
 		go func() {
 			if err := s.notifier.NotifyAlertCreated(context.Background(), alert); err != nil {
 				// Log error but don't fail alert creation
@@ -107,6 +250,16 @@ func (s *AlertService) CreateAlert(ctx context.Context, orgID uuid.UUID, req *dt
 		}()
 	}
 
+	if s.outbox != nil {
+		for _, event := range events {
+			if err := s.outbox.Publish(ctx, event); err != nil {
+				// Left for the outbox worker to publish
+				fmt.Printf("Failed to publish alert creation event: %v\n", err)
+			}
+		}
+		return alert, nil
+	}
+
 	// Broadcast WebSocket event
 	if s.broadcaster != nil {
 		s.broadcaster.BroadcastAlertEvent(domain.WSEventAlertCreated, orgID, alert)
@@ -114,21 +267,28 @@ func (s *AlertService) CreateAlert(ctx context.Context, orgID uuid.UUID, req *dt
 
 	// Trigger webhooks
 	if s.dispatcher != nil {
-		s.dispatcher.TriggerWebhooks(ctx, orgID, "alert.created", map[string]interface{}{
-			"alert_id":    alert.ID.String(),
-			"source":      alert.Source,
-			"priority":    string(alert.Priority),
-			"status":      string(alert.Status),
-			"message":     alert.Message,
-			"description": alert.Description,
-			"tags":        alert.Tags,
-			"created_at":  alert.CreatedAt,
-		})
+		s.dispatcher.TriggerWebhooks(ctx, orgID, "alert.created", alertCreatedData(alert, alert.CreatedAt))
 	}
 
 	return alert, nil
 }
 
+// alertCreatedData is the webhook payload of an alert.created event. The
+// outbox event is built before the insert assigns created_at, so the creation
+// time is passed in.
+func alertCreatedData(alert *domain.Alert, createdAt time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"alert_id":    alert.ID.String(),
+		"source":      alert.Source,
+		"priority":    string(alert.Priority),
+		"status":      string(alert.Status),
+		"message":     alert.Message,
+		"description": alert.Description,
+		"tags":        alert.Tags,
+		"created_at":  createdAt,
+	}
+}
+
 func (s *AlertService) GetAlert(ctx context.Context, id, orgID uuid.UUID) (*domain.Alert, error) {
 	alert, err := s.alertRepo.GetByID(ctx, id, orgID)
 	if err != nil {
@@ -138,11 +298,61 @@ func (s *AlertService) GetAlert(ctx context.Context, id, orgID uuid.UUID) (*doma
 	return alert, nil
 }
 
+// GetOnCallAtCreation resolves who was on call, at the moment the alert was
+// created, for every schedule targeted by the alert's escalation policy.
+// Schedules are listed once, in rule order; OnCall is nil for a schedule that
+// had no one on call.
+func (s *AlertService) GetOnCallAtCreation(ctx context.Context, id, orgID uuid.UUID) ([]*domain.ScheduleOnCall, error) {
+	if s.policyRepo == nil || s.onCall == nil {
+		return nil, fmt.Errorf("on-call lookups are not configured")
+	}
+
+	alert, err := s.alertRepo.GetByID(ctx, id, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: alert", domain.ErrNotFound)
+	}
+
+	result := []*domain.ScheduleOnCall{}
+	if alert.EscalationPolicyID == nil {
+		return result, nil
+	}
+
+	policy, err := s.policyRepo.GetWithRules(ctx, *alert.EscalationPolicyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get escalation policy: %w", err)
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for _, rule := range policy.Rules {
+		for _, target := range rule.Targets {
+			if target.TargetType != domain.EscalationTargetTypeSchedule || seen[target.TargetID] {
+				continue
+			}
+			seen[target.TargetID] = true
+
+			schedule, err := s.onCall.GetSchedule(ctx, target.TargetID)
+			if err != nil {
+				// The schedule has been deleted since
+				continue
+			}
+
+			entry := &domain.ScheduleOnCall{Schedule: schedule}
+			if onCall, err := s.onCall.GetOnCallUser(ctx, schedule.ID, alert.CreatedAt); err == nil {
+				entry.OnCall = onCall
+			}
+			result = append(result, entry)
+		}
+	}
+
+	return result, nil
+}
+
 func (s *AlertService) UpdateAlert(ctx context.Context, id, orgID uuid.UUID, req *dto.UpdateAlertRequest) (*domain.Alert, error) {
 	alert, err := s.alertRepo.GetByID(ctx, id, orgID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get alert: %w", err)
 	}
+	before := domain.AlertSnapshot(alert)
 
 	// Update fields if provided
 	if req.Priority != nil {
@@ -161,8 +371,16 @@ func (s *AlertService) UpdateAlert(ctx context.Context, id, orgID uuid.UUID, req
 		alert.Description = req.Description
 	}
 
-	if req.Tags != nil {
-		alert.Tags = req.Tags
+	if req.Tags != nil || len(req.AddTags) > 0 || len(req.RemoveTags) > 0 {
+		tags := alert.Tags
+		if req.Tags != nil {
+			tags = req.Tags
+		}
+		tags, err := domain.ApplyTagChanges(tags, req.AddTags, req.RemoveTags)
+		if err != nil {
+			return nil, err
+		}
+		alert.Tags = tags
 	}
 
 	if req.CustomFields != nil {
@@ -180,7 +398,8 @@ func (s *AlertService) UpdateAlert(ctx context.Context, id, orgID uuid.UUID, req
 
 	// Trigger webhooks
 	if s.dispatcher != nil {
-		s.dispatcher.TriggerWebhooks(ctx, alert.OrganizationID, "alert.updated", map[string]interface{}{
+		after := domain.AlertSnapshot(alert)
+		s.dispatcher.TriggerWebhooks(ctx, alert.OrganizationID, domain.WebhookEventAlertUpdated, map[string]interface{}{
 			"alert_id":    alert.ID.String(),
 			"source":      alert.Source,
 			"priority":    string(alert.Priority),
@@ -189,12 +408,70 @@ func (s *AlertService) UpdateAlert(ctx context.Context, id, orgID uuid.UUID, req
 			"description": alert.Description,
 			"tags":        alert.Tags,
 			"updated_at":  alert.UpdatedAt,
+			"alert":       after,
+			"changes":     domain.WebhookChanges(before, after),
 		})
 	}
 
 	return alert, nil
 }
 
+// BulkUpdateTags adds and removes tags on several alerts in one transaction.
+// Either every alert is updated or none is.
+func (s *AlertService) BulkUpdateTags(ctx context.Context, orgID uuid.UUID, req *dto.BulkTagAlertsRequest) ([]*domain.Alert, error) {
+	add, err := domain.NormalizeTags(req.Add)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.IDs))
+	seen := make(map[uuid.UUID]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	// Snapshot the alerts first so the update webhooks can carry what changed
+	before := make(map[uuid.UUID]map[string]interface{}, len(ids))
+	if s.dispatcher != nil {
+		for _, id := range ids {
+			if alert, err := s.alertRepo.GetByID(ctx, id, orgID); err == nil {
+				before[id] = domain.AlertSnapshot(alert)
+			}
+		}
+	}
+
+	alerts, err := s.alertRepo.UpdateTags(ctx, orgID, ids, add, req.Remove)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update tags: %w", err)
+	}
+
+	for _, alert := range alerts {
+		if s.broadcaster != nil {
+			s.broadcaster.BroadcastAlertEvent(domain.WSEventAlertUpdated, orgID, alert)
+		}
+		if s.dispatcher != nil {
+			after := domain.AlertSnapshot(alert)
+			s.dispatcher.TriggerWebhooks(ctx, orgID, domain.WebhookEventAlertUpdated, map[string]interface{}{
+				"alert_id":    alert.ID.String(),
+				"source":      alert.Source,
+				"priority":    string(alert.Priority),
+				"status":      string(alert.Status),
+				"message":     alert.Message,
+				"description": alert.Description,
+				"tags":        alert.Tags,
+				"updated_at":  alert.UpdatedAt,
+				"alert":       after,
+				"changes":     domain.WebhookChanges(before[alert.ID], after),
+			})
+		}
+	}
+
+	return alerts, nil
+}
+
 func (s *AlertService) DeleteAlert(ctx context.Context, id, orgID uuid.UUID) error {
 	if err := s.alertRepo.Delete(ctx, id, orgID); err != nil {
 		return fmt.Errorf("failed to delete alert: %w", err)
@@ -204,6 +481,84 @@ func (s *AlertService) DeleteAlert(ctx context.Context, id, orgID uuid.UUID) err
 }
 
 func (s *AlertService) ListAlerts(ctx context.Context, orgID uuid.UUID, req *dto.ListAlertsRequest) (*dto.ListAlertsResponse, error) {
+	if err := s.normalizeSourceFilter(ctx, orgID, req); err != nil {
+		return nil, err
+	}
+
+	// Set defaults
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	filter := alertFilter(orgID, req)
+	filter.Limit = pageSize
+	filter.Offset = (page - 1) * pageSize
+
+	alerts, total, err := s.alertRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alerts: %w", err)
+	}
+
+	return &dto.ListAlertsResponse{
+		Alerts:   alerts,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// GroupAlerts clusters the organization's open alerts for triage, largest
+// groups first
+func (s *AlertService) GroupAlerts(ctx context.Context, orgID uuid.UUID, req *dto.GroupAlertsRequest) (*dto.GroupAlertsResponse, error) {
+	limit := req.Limit
+	if limit < 1 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	groups, err := s.alertRepo.GroupOpenAlerts(ctx, &domain.AlertGrouping{
+		OrganizationID: orgID,
+		By:             domain.AlertGroupBy(req.By),
+		TagKey:         req.Tag,
+		PrefixLength:   req.PrefixLength,
+		Limit:          limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to group alerts: %w", err)
+	}
+
+	response := &dto.GroupAlertsResponse{
+		By:     req.By,
+		Groups: make([]*dto.AlertGroup, 0, len(groups)),
+	}
+	for _, group := range groups {
+		response.Groups = append(response.Groups, &dto.AlertGroup{
+			Key:             group.Key,
+			Count:           group.Count,
+			HighestPriority: group.HighestPriority.String(),
+			FirstAlertAt:    group.FirstAlertAt,
+			LastAlertAt:     group.LastAlertAt,
+			Representatives: group.Representatives,
+		})
+	}
+
+	return response, nil
+}
+
+// alertFilter builds the repository filter for a list request, ignoring
+// unknown statuses and priorities. Paging is left to the caller.
+func alertFilter(orgID uuid.UUID, req *dto.ListAlertsRequest) *domain.AlertFilter {
 	// Parse status filters
 	var statuses []domain.AlertStatus
 	for _, statusStr := range req.Status {
@@ -222,23 +577,7 @@ func (s *AlertService) ListAlerts(ctx context.Context, orgID uuid.UUID, req *dto
 		}
 	}
 
-	// Set defaults
-	page := req.Page
-	if page < 1 {
-		page = 1
-	}
-
-	pageSize := req.PageSize
-	if pageSize < 1 {
-		pageSize = 20
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-
-	offset := (page - 1) * pageSize
-
-	filter := &domain.AlertFilter{
+	return &domain.AlertFilter{
 		OrganizationID: orgID,
 		Status:         statuses,
 		Priority:       priorities,
@@ -246,21 +585,50 @@ func (s *AlertService) ListAlerts(ctx context.Context, orgID uuid.UUID, req *dto
 		AssignedToTeam: req.AssignedToTeam,
 		Source:         req.Source,
 		Search:         req.Search,
-		Limit:          pageSize,
-		Offset:         offset,
 	}
+}
 
-	alerts, total, err := s.alertRepo.List(ctx, filter)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list alerts: %w", err)
+// alertCSVHeader is the column layout of ExportAlertsCSV
+var alertCSVHeader = []string{
+	"id", "message", "priority", "status", "source",
+	"created_at", "acknowledged_at", "closed_at",
+	"time_to_acknowledge_seconds", "time_to_close_seconds",
+}
+
+// ExportAlertsCSV writes the alerts matching the list filters to w as CSV,
+// newest first. Paging fields of req are ignored; every match is exported.
+func (s *AlertService) ExportAlertsCSV(ctx context.Context, orgID uuid.UUID, req *dto.ListAlertsRequest, w io.Writer) error {
+	if err := s.normalizeSourceFilter(ctx, orgID, req); err != nil {
+		return err
 	}
+	filter := alertFilter(orgID, req)
 
-	return &dto.ListAlertsResponse{
-		Alerts:   alerts,
-		Total:    total,
-		Page:     page,
-		PageSize: pageSize,
-	}, nil
+	err := writeCSVPages(ctx, w, alertCSVHeader, func(limit int, after *domain.ListCursor) ([]*domain.Alert, error) {
+		filter.Limit = limit
+		filter.Offset = 0
+		filter.After = after
+		alerts, _, err := s.alertRepo.List(ctx, filter)
+		return alerts, err
+	}, func(alert *domain.Alert) domain.ListCursor {
+		return domain.ListCursor{CreatedAt: alert.CreatedAt, ID: alert.ID}
+	}, func(alert *domain.Alert) []string {
+		return []string{
+			alert.ID.String(),
+			csvText(alert.Message),
+			string(alert.Priority),
+			string(alert.Status),
+			csvText(alert.Source),
+			csvTime(&alert.CreatedAt),
+			csvTime(alert.AcknowledgedAt),
+			csvTime(alert.ClosedAt),
+			csvDuration(alert.CreatedAt, alert.AcknowledgedAt),
+			csvDuration(alert.CreatedAt, alert.ClosedAt),
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export alerts: %w", err)
+	}
+	return nil
 }
 
 func (s *AlertService) AcknowledgeAlert(ctx context.Context, id, orgID, userID uuid.UUID) error {
@@ -268,6 +636,8 @@ func (s *AlertService) AcknowledgeAlert(ctx context.Context, id, orgID, userID u
 		return fmt.Errorf("failed to acknowledge alert: %w", err)
 	}
 
+	prommetrics.AlertsAcknowledgedTotal.Inc()
+
 	// Send notification for acknowledged alert (async)
 	if s.notifier != nil {
 		go func() {
@@ -304,11 +674,195 @@ func (s *AlertService) AcknowledgeAlert(ctx context.Context, id, orgID, userID u
 	return nil
 }
 
+// IssueAckToken returns a token that acknowledges the alert on behalf of the
+// user. The token is the ID of its stored record and a signature over it, so
+// forged tokens are rejected without a lookup.
+func (s *AlertService) IssueAckToken(ctx context.Context, alert *domain.Alert, userID uuid.UUID) (string, error) {
+	if s.ackTokenRepo == nil {
+		return "", fmt.Errorf("ack tokens are not configured")
+	}
+
+	token := &domain.AlertAckToken{
+		ID:             uuid.New(),
+		AlertID:        alert.ID,
+		OrganizationID: alert.OrganizationID,
+		UserID:         userID,
+		ExpiresAt:      time.Now().Add(s.ackTokenConfig.TTL),
+	}
+	if err := s.ackTokenRepo.Create(ctx, token); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token.ID[:]) + "." + s.signAckToken(token.ID), nil
+}
+
+// AcknowledgeWithToken acknowledges the alert the token was issued for,
+// attributed to the user it was sent to. A token works once, even if the
+// alert can't be acknowledged anymore or the user may no longer act for the
+// organization.
+func (s *AlertService) AcknowledgeWithToken(ctx context.Context, token string) (*domain.Alert, error) {
+	if s.ackTokenRepo == nil || s.ackTokenUsers == nil || s.orgRepo == nil {
+		return nil, domain.ErrInvalidAckToken
+	}
+
+	encodedID, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, domain.ErrInvalidAckToken
+	}
+	rawID, err := base64.RawURLEncoding.DecodeString(encodedID)
+	if err != nil {
+		return nil, domain.ErrInvalidAckToken
+	}
+	id, err := uuid.FromBytes(rawID)
+	if err != nil {
+		return nil, domain.ErrInvalidAckToken
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signAckToken(id))) {
+		return nil, domain.ErrInvalidAckToken
+	}
+
+	ackToken, err := s.ackTokenRepo.Consume(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidAckToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAckTokenUser(ctx, ackToken); err != nil {
+		return nil, err
+	}
+
+	if err := s.AcknowledgeAlert(ctx, ackToken.AlertID, ackToken.OrganizationID, ackToken.UserID); err != nil {
+		return nil, err
+	}
+
+	return s.alertRepo.GetByID(ctx, ackToken.AlertID, ackToken.OrganizationID)
+}
+
+// checkAckTokenUser rejects a token whose user was deactivated or removed
+// from the organization after the link was sent
+func (s *AlertService) checkAckTokenUser(ctx context.Context, token *domain.AlertAckToken) error {
+	user, err := s.ackTokenUsers.GetByID(ctx, token.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get ack token user: %w", err)
+	}
+	if !user.IsActive {
+		return domain.ErrInvalidAckToken
+	}
+
+	_, err = s.orgRepo.GetUserRole(ctx, token.OrganizationID, token.UserID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidAckToken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get ack token user role: %w", err)
+	}
+	return nil
+}
+
+func (s *AlertService) signAckToken(id uuid.UUID) string {
+	h := hmac.New(sha256.New, []byte(s.ackTokenConfig.Secret))
+	h.Write(id[:])
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+// normalizeSource returns the canonical name of an alert source
+func (s *AlertService) normalizeSource(ctx context.Context, orgID uuid.UUID, source string) (string, error) {
+	if s.sources == nil {
+		return source, nil
+	}
+	normalized, err := s.sources.NormalizeSource(ctx, orgID, source)
+	if err != nil {
+		return "", fmt.Errorf("failed to normalize alert source: %w", err)
+	}
+	return normalized, nil
+}
+
+// normalizeSourceFilter makes a source filter match alerts created with any
+// spelling of a registered source, which were stored under its name
+func (s *AlertService) normalizeSourceFilter(ctx context.Context, orgID uuid.UUID, req *dto.ListAlertsRequest) error {
+	if req.Source == nil {
+		return nil
+	}
+	source, err := s.normalizeSource(ctx, orgID, *req.Source)
+	if err != nil {
+		return err
+	}
+	req.Source = &source
+	return nil
+}
+
+// UnacknowledgeAlert returns an acknowledged alert to open, e.g. when the
+// condition recurs. Escalation restarts from the first rule of the alert's
+// policy and a note records who reopened it.
+func (s *AlertService) UnacknowledgeAlert(ctx context.Context, id, orgID, userID uuid.UUID) error {
+	alert, err := s.alertRepo.GetByID(ctx, id, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to get alert: %w", err)
+	}
+	if alert.Status != domain.AlertStatusAcknowledged {
+		return fmt.Errorf("%w: alert is %s", domain.ErrAlertNotAcked, alert.Status)
+	}
+
+	var escalation *domain.AlertEscalationEvent
+	if alert.EscalationPolicyID != nil && s.policyRepo != nil {
+		policy, err := s.policyRepo.GetWithRules(ctx, *alert.EscalationPolicyID)
+		if err != nil {
+			return fmt.Errorf("failed to get escalation policy: %w", err)
+		}
+		escalation = domain.NewEscalationEvent(id, policy, time.Now())
+	}
+
+	noteText := "Alert unacknowledged and reopened"
+	if escalation != nil {
+		noteText += "; escalation restarted from the first rule"
+	}
+	note := &domain.AlertNote{
+		ID:      uuid.New(),
+		AlertID: id,
+		UserID:  &userID,
+		Note:    noteText,
+	}
+
+	if err := s.alertRepo.Unacknowledge(ctx, id, orgID, escalation, note); err != nil {
+		return fmt.Errorf("failed to unacknowledge alert: %w", err)
+	}
+
+	// Broadcast WebSocket event and trigger webhooks
+	if s.broadcaster != nil || s.dispatcher != nil {
+		before := domain.AlertSnapshot(alert)
+		alert, err := s.alertRepo.GetByID(ctx, id, orgID)
+		if err == nil {
+			if s.broadcaster != nil {
+				s.broadcaster.BroadcastAlertEvent(domain.WSEventAlertUpdated, alert.OrganizationID, alert)
+			}
+			if s.dispatcher != nil {
+				after := domain.AlertSnapshot(alert)
+				s.dispatcher.TriggerWebhooks(ctx, alert.OrganizationID, domain.WebhookEventAlertUpdated, map[string]interface{}{
+					"alert_id":          alert.ID.String(),
+					"source":            alert.Source,
+					"priority":          string(alert.Priority),
+					"status":            string(alert.Status),
+					"message":           alert.Message,
+					"unacknowledged_by": userID.String(),
+					"alert":             after,
+					"changes":           domain.WebhookChanges(before, after),
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
 func (s *AlertService) CloseAlert(ctx context.Context, id, orgID, userID uuid.UUID, reason string) error {
 	if err := s.alertRepo.Close(ctx, id, orgID, userID, reason); err != nil {
 		return fmt.Errorf("failed to close alert: %w", err)
 	}
 
+	prommetrics.AlertsClosedTotal.Inc()
+
 	// Send notification for closed alert (async)
 	if s.notifier != nil {
 		go func() {
@@ -346,26 +900,372 @@ func (s *AlertService) CloseAlert(ctx context.Context, id, orgID, userID uuid.UU
 	return nil
 }
 
-func (s *AlertService) SnoozeAlert(ctx context.Context, id, orgID uuid.UUID, until time.Time) error {
+func (s *AlertService) SnoozeAlert(ctx context.Context, id, orgID, userID uuid.UUID, until time.Time, reason *string) error {
 	if until.Before(time.Now()) {
 		return fmt.Errorf("snooze time must be in the future")
 	}
 
-	if err := s.alertRepo.Snooze(ctx, id, orgID, until); err != nil {
+	if reason != nil {
+		trimmed := strings.TrimSpace(*reason)
+		reason = &trimmed
+		if trimmed == "" {
+			reason = nil
+		}
+	}
+
+	if err := s.alertRepo.Snooze(ctx, id, orgID, userID, until, reason); err != nil {
 		return fmt.Errorf("failed to snooze alert: %w", err)
 	}
 
+	// Broadcast WebSocket event and trigger webhooks
+	if s.broadcaster != nil || s.dispatcher != nil {
+		alert, err := s.alertRepo.GetByID(ctx, id, orgID)
+		if err == nil {
+			if s.broadcaster != nil {
+				s.broadcaster.BroadcastAlertEvent(domain.WSEventAlertSnoozed, alert.OrganizationID, alert)
+			}
+			if s.dispatcher != nil {
+				s.dispatcher.TriggerWebhooks(ctx, alert.OrganizationID, domain.WebhookEventAlertSnoozed, map[string]interface{}{
+					"alert_id":      alert.ID.String(),
+					"source":        alert.Source,
+					"priority":      string(alert.Priority),
+					"status":        string(alert.Status),
+					"message":       alert.Message,
+					"snoozed_at":    alert.UpdatedAt,
+					"snoozed_until": alert.SnoozedUntil,
+					"snoozed_by":    userID.String(),
+					"snooze_reason": alert.SnoozeReason,
+				})
+			}
+		}
+	}
+
 	return nil
 }
 
-func (s *AlertService) AssignAlert(ctx context.Context, id, orgID uuid.UUID, userID, teamID *uuid.UUID) error {
-	if userID == nil && teamID == nil {
+// ProcessExpiredSnoozes reopens snoozed alerts whose snooze has ended and
+// tells the user who snoozed each one. Alerts are reopened in batches of
+// batchSize until none are left.
+func (s *AlertService) ProcessExpiredSnoozes(ctx context.Context, now time.Time, batchSize int) error {
+	for {
+		alerts, err := s.alertRepo.ReopenExpiredSnoozes(ctx, now, batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to reopen snoozed alerts: %w", err)
+		}
+
+		for _, alert := range alerts {
+			if s.broadcaster != nil {
+				s.broadcaster.BroadcastAlertEvent(domain.WSEventAlertUpdated, alert.OrganizationID, alert)
+			}
+			if s.notifier != nil {
+				if err := s.notifier.NotifyAlertUnsnoozed(ctx, alert); err != nil {
+					fmt.Printf("Failed to send alert unsnooze notification: %v\n", err)
+				}
+			}
+		}
+
+		if len(alerts) < batchSize {
+			return nil
+		}
+	}
+}
+
+// AutoCloseStaleAlerts closes open alerts that have gone untouched longer
+// than their organization's auto-close policy allows. Organizations and
+// alerts are read in pages of batchSize.
+func (s *AlertService) AutoCloseStaleAlerts(ctx context.Context, now time.Time, batchSize int) error {
+	if s.orgRepo == nil {
+		return nil // Organization repository not configured
+	}
+
+	var errs []error
+	for offset := 0; ; offset += batchSize {
+		orgs, err := s.orgRepo.List(ctx, batchSize, offset)
+		if err != nil {
+			return fmt.Errorf("failed to list organizations: %w", err)
+		}
+
+		for _, org := range orgs {
+			if err := s.autoCloseOrganization(ctx, org, now, batchSize); err != nil {
+				errs = append(errs, fmt.Errorf("organization %s: %w", org.ID, err))
+			}
+		}
+
+		if len(orgs) < batchSize {
+			break
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *AlertService) autoCloseOrganization(ctx context.Context, org *domain.Organization, now time.Time, batchSize int) error {
+	policy := org.AlertAutoClosePolicy()
+	if !policy.Enabled() {
+		return nil
+	}
+
+	cutoff := now.Add(-time.Duration(policy.AfterHours) * time.Hour)
+	for {
+		alerts, err := s.alertRepo.GetStaleOpenAlerts(ctx, org.ID, policy.Priorities, cutoff, batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, alert := range alerts {
+			closed, err := s.alertRepo.AutoClose(ctx, alert.ID, org.ID, domain.AutoCloseReason, cutoff)
+			if err != nil {
+				return err
+			}
+			if !closed {
+				continue // Touched since it was read
+			}
+
+			prommetrics.AlertsClosedTotal.Inc()
+			s.announceClose(ctx, alert, domain.AutoCloseReason)
+		}
+
+		if len(alerts) < batchSize {
+			return nil
+		}
+	}
+}
+
+// ApplyRetention removes closed alerts older than their organization's
+// retention policy allows, archiving them first unless the policy says
+// otherwise, and deletes acknowledge link tokens that can no longer be used.
+// Organizations are read and records removed in pages of batchSize.
+func (s *AlertService) ApplyRetention(ctx context.Context, now time.Time, batchSize int) error {
+	var errs []error
+	if err := s.purgeSpentAckTokens(ctx, now, batchSize); err != nil {
+		errs = append(errs, err)
+	}
+
+	if s.orgRepo == nil {
+		return errors.Join(errs...) // Organization repository not configured
+	}
+
+	for offset := 0; ; offset += batchSize {
+		orgs, err := s.orgRepo.List(ctx, batchSize, offset)
+		if err != nil {
+			return fmt.Errorf("failed to list organizations: %w", err)
+		}
+
+		for _, org := range orgs {
+			if err := s.applyOrganizationRetention(ctx, org, now, batchSize); err != nil {
+				errs = append(errs, fmt.Errorf("organization %s: %w", org.ID, err))
+			}
+		}
+
+		if len(orgs) < batchSize {
+			break
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// purgeSpentAckTokens deletes acknowledge link tokens that were used or have
+// expired
+func (s *AlertService) purgeSpentAckTokens(ctx context.Context, now time.Time, batchSize int) error {
+	if s.ackTokenRepo == nil {
+		return nil
+	}
+
+	for {
+		deleted, err := s.ackTokenRepo.DeleteSpent(ctx, now, batchSize)
+		if err != nil {
+			return err
+		}
+		if deleted < batchSize {
+			return nil
+		}
+	}
+}
+
+func (s *AlertService) applyOrganizationRetention(ctx context.Context, org *domain.Organization, now time.Time, batchSize int) error {
+	policy := org.AlertRetentionPolicy()
+	if !policy.Enabled() {
+		return nil
+	}
+
+	cutoff := now.AddDate(0, 0, -policy.AfterDays)
+	if policy.DryRun {
+		count, err := s.alertRepo.CountPurgeableAlerts(ctx, org.ID, cutoff, policy.ExemptPriorities)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			logger.WithContext(ctx, s.logger).Info("Alert retention dry run",
+				zap.String("org_id", org.ID.String()),
+				zap.Int("count", count),
+				zap.Bool("archive", policy.Archive),
+			)
+		}
+		return nil
+	}
+
+	for {
+		removed, err := s.alertRepo.PurgeClosedAlerts(ctx, org.ID, cutoff, policy.ExemptPriorities, policy.Archive, batchSize)
+		if err != nil {
+			return err
+		}
+		if removed < batchSize {
+			return nil
+		}
+	}
+}
+
+// ResolveAlertByDedupKey closes the open alert with the given dedup key
+// because its monitoring source reported it resolved. It returns nil when
+// there is no such alert.
+func (s *AlertService) ResolveAlertByDedupKey(ctx context.Context, orgID uuid.UUID, dedupKey string) (*domain.Alert, error) {
+	alert, err := s.alertRepo.FindByDedupKey(ctx, orgID, dedupKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find alert: %w", err)
+	}
+	if alert == nil {
+		return nil, nil
+	}
+
+	closed, err := s.alertRepo.CloseFromSource(ctx, alert.ID, orgID, domain.SourceResolvedReason)
+	if err != nil {
+		return nil, err
+	}
+	if !closed {
+		return nil, nil // Closed concurrently
+	}
+
+	prommetrics.AlertsClosedTotal.Inc()
+	s.announceClose(ctx, alert, domain.SourceResolvedReason)
+
+	return alert, nil
+}
+
+// announceClose broadcasts and dispatches webhooks for an alert closed without
+// a closing user
+func (s *AlertService) announceClose(ctx context.Context, alert *domain.Alert, reason string) {
+	if s.broadcaster == nil && s.dispatcher == nil {
+		return
+	}
+
+	closed, err := s.alertRepo.GetByID(ctx, alert.ID, alert.OrganizationID)
+	if err != nil {
+		return
+	}
+	if s.broadcaster != nil {
+		s.broadcaster.BroadcastAlertEvent(domain.WSEventAlertClosed, closed.OrganizationID, closed)
+	}
+	if s.dispatcher != nil {
+		s.dispatcher.TriggerWebhooks(ctx, closed.OrganizationID, "alert.closed", map[string]interface{}{
+			"alert_id":     closed.ID.String(),
+			"source":       closed.Source,
+			"priority":     string(closed.Priority),
+			"status":       string(closed.Status),
+			"message":      closed.Message,
+			"closed_at":    closed.ClosedAt,
+			"close_reason": reason,
+		})
+	}
+}
+
+// Notes
+
+func (s *AlertService) AddNote(ctx context.Context, alertID, orgID, userID uuid.UUID, req *dto.AddNoteRequest) (*domain.AlertNote, error) {
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		return nil, domain.ErrEmptyAlertNote
+	}
+
+	if _, err := s.alertRepo.GetByID(ctx, alertID, orgID); err != nil {
+		return nil, fmt.Errorf("failed to get alert: %w", err)
+	}
+
+	alertNote := &domain.AlertNote{
+		ID:      uuid.New(),
+		AlertID: alertID,
+		UserID:  &userID,
+		Note:    note,
+	}
+
+	if err := s.alertRepo.AddNote(ctx, alertNote); err != nil {
+		return nil, fmt.Errorf("failed to add note: %w", err)
+	}
+
+	return alertNote, nil
+}
+
+func (s *AlertService) ListNotes(ctx context.Context, alertID, orgID uuid.UUID) ([]*domain.AlertNoteWithUser, error) {
+	if _, err := s.alertRepo.GetByID(ctx, alertID, orgID); err != nil {
+		return nil, fmt.Errorf("failed to get alert: %w", err)
+	}
+
+	notes, err := s.alertRepo.ListNotes(ctx, alertID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notes: %w", err)
+	}
+
+	return notes, nil
+}
+
+// AssignAlert assigns an alert to a user and/or team. Assigning one leaves
+// the other in place unless the request clears it. An escalation policy in
+// the request is applied in the same transaction and escalation restarts from
+// its first rule.
+func (s *AlertService) AssignAlert(ctx context.Context, id, orgID, assignedBy uuid.UUID, req *dto.AssignAlertRequest) error {
+	if req.UserID == nil && req.TeamID == nil {
 		return fmt.Errorf("must assign to either a user or a team")
 	}
+	if (req.ClearUser && req.UserID != nil) || (req.ClearTeam && req.TeamID != nil) {
+		return fmt.Errorf("cannot both assign and clear the same assignee")
+	}
+
+	assignment := &domain.AlertAssignment{
+		UserID:    req.UserID,
+		TeamID:    req.TeamID,
+		ClearUser: req.ClearUser,
+		ClearTeam: req.ClearTeam,
+	}
+
+	if req.EscalationPolicyID != nil {
+		if s.policyRepo == nil {
+			return fmt.Errorf("escalation policies cannot be assigned")
+		}
+		policy, err := s.policyRepo.GetWithRules(ctx, *req.EscalationPolicyID)
+		if err != nil || policy.OrganizationID != orgID {
+			return domain.ErrEscalationPolicyNotFound
+		}
+		assignment.EscalationPolicyID = &policy.ID
+		assignment.Escalation = domain.NewEscalationEvent(id, policy, time.Now())
+	}
 
-	if err := s.alertRepo.Assign(ctx, id, orgID, userID, teamID); err != nil {
+	if err := s.alertRepo.Assign(ctx, id, orgID, assignment); err != nil {
 		return fmt.Errorf("failed to assign alert: %w", err)
 	}
 
+	// Broadcast WebSocket event and trigger webhooks
+	if s.broadcaster != nil || s.dispatcher != nil {
+		alert, err := s.alertRepo.GetByID(ctx, id, orgID)
+		if err == nil {
+			if s.broadcaster != nil {
+				s.broadcaster.BroadcastAlertEvent(domain.WSEventAlertAssigned, alert.OrganizationID, alert)
+			}
+			if s.dispatcher != nil {
+				s.dispatcher.TriggerWebhooks(ctx, alert.OrganizationID, domain.WebhookEventAlertAssigned, map[string]interface{}{
+					"alert_id":             alert.ID.String(),
+					"source":               alert.Source,
+					"priority":             string(alert.Priority),
+					"status":               string(alert.Status),
+					"message":              alert.Message,
+					"assigned_at":          alert.UpdatedAt,
+					"assigned_by":          assignedBy.String(),
+					"assigned_to_user_id":  alert.AssignedToUserID,
+					"assigned_to_team_id":  alert.AssignedToTeamID,
+					"escalation_policy_id": alert.EscalationPolicyID,
+				})
+			}
+		}
+	}
+
 	return nil
 }
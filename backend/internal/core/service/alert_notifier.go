@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,6 +13,11 @@ import (
 	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
 )
 
+// AckTokenIssuer issues the tokens behind acknowledge links
+type AckTokenIssuer interface {
+	IssueAckToken(ctx context.Context, alert *domain.Alert, userID uuid.UUID) (string, error)
+}
+
 // AlertNotifier handles sending notifications for alert events
 type AlertNotifier struct {
 	notificationService *NotificationService
@@ -19,6 +25,9 @@ type AlertNotifier struct {
 	teamRepo            outbound.TeamRepository
 	scheduleService     *ScheduleService
 	dndService          *DNDService
+	orgRepo             outbound.OrganizationRepository
+	ackTokens           AckTokenIssuer
+	ackBaseURL          string
 }
 
 func NewAlertNotifier(
@@ -37,6 +46,19 @@ func NewAlertNotifier(
 	}
 }
 
+// SetOrganizationRepo sets the organization repository (optional dependency).
+// Without it notifications use the built-in templates.
+func (n *AlertNotifier) SetOrganizationRepo(repo outbound.OrganizationRepository) {
+	n.orgRepo = repo
+}
+
+// SetAckLinks adds acknowledge links to escalation pages (optional
+// dependency). baseURL is the public URL of the API the links point at.
+func (n *AlertNotifier) SetAckLinks(issuer AckTokenIssuer, baseURL string) {
+	n.ackTokens = issuer
+	n.ackBaseURL = strings.TrimRight(baseURL, "/")
+}
+
 // NotifyAlertCreated sends notifications when a new alert is created
 func (n *AlertNotifier) NotifyAlertCreated(ctx context.Context, alert *domain.Alert) error {
 	// For now, this is a placeholder that can be expanded in future phases
@@ -59,7 +81,63 @@ func (n *AlertNotifier) NotifyAlertClosed(ctx context.Context, alert *domain.Ale
 	return nil
 }
 
-// NotifyAlertEscalated sends notifications when an alert escalates
+// NotifyAlertUnsnoozed tells the user who snoozed an alert that the snooze
+// expired and the alert is open again
+func (n *AlertNotifier) NotifyAlertUnsnoozed(ctx context.Context, alert *domain.Alert) error {
+	if n.notificationService == nil || alert.SnoozedBy == nil {
+		return nil
+	}
+
+	user, err := n.userRepo.GetByID(ctx, *alert.SnoozedBy)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	channel, err := n.notificationService.PreferredChannel(ctx, alert.OrganizationID, user.ID)
+	if err != nil {
+		return err
+	}
+	if channel == nil {
+		return nil // No channel to notify through
+	}
+
+	data := domain.NotificationTemplateData{
+		Event:       "Snooze expired",
+		Alert:       alert,
+		Description: getDescriptionOrDefault(alert.Description),
+		Note:        "The alert you snoozed has reopened.",
+	}
+	if alert.SnoozeReason != nil {
+		data.Note += fmt.Sprintf(" Snooze reason: %s", *alert.SnoozeReason)
+	}
+	subject, message := n.renderNotification(n.organization(ctx, alert.OrganizationID), channel.ChannelType, data)
+
+	// Send notification (errors are logged in the notification service)
+	_, _ = n.notificationService.SendNotification(ctx, alert.OrganizationID, &dto.SendNotificationRequest{
+		ChannelID: channel.ID,
+		UserID:    &user.ID,
+		AlertID:   &alert.ID,
+		Recipient: user.Email,
+		Subject:   &subject,
+		Message:   message,
+	})
+
+	return nil
+}
+
+// NotifyAlertEscalated sends notifications when an alert escalates. Targets
+// are notified together:
+//   - a user is paged through every enabled channel, or just their primary
+//     one for alerts below the priority they set for it
+//   - a schedule pages whoever is on call, logging unavailable participants
+//     that were skipped
+//   - a team pages all of its active members at once, each through their
+//     preferred channel so a team page doesn't multiply across channels
+//
+// A target's channel override takes precedence over preferences. Recipients
+// in Do Not Disturb are logged as suppressed rather than paged. Each channel
+// gets the message rendered from the organization's template for its type,
+// with a link acknowledging the alert for the recipient when configured.
 func (n *AlertNotifier) NotifyAlertEscalated(
 	ctx context.Context,
 	alert *domain.Alert,
@@ -81,57 +159,47 @@ func (n *AlertNotifier) NotifyAlertEscalated(
 		return nil
 	}
 
-	// Build the notification message
-	subject := fmt.Sprintf("[%s] Alert Escalated: %s", alert.Priority, alert.Message)
-	message := fmt.Sprintf(
-		"Alert ID: %s\nPriority: %s\nStatus: %s\nMessage: %s\n\nEscalation Level: %d\n\n%s",
-		alert.ID,
-		alert.Priority,
-		alert.Status,
-		alert.Message,
-		alert.EscalationLevel,
-		getDescriptionOrDefault(alert.Description),
-	)
+	// Messages are rendered per channel type from the organization's templates
+	org := n.organization(ctx, alert.OrganizationID)
+	data := domain.NotificationTemplateData{
+		Event:           "Alert Escalated",
+		Alert:           alert,
+		Description:     getDescriptionOrDefault(alert.Description),
+		EscalationLevel: alert.EscalationLevel,
+	}
 
 	// Send notifications to each target
 	for _, target := range targets {
-		recipients, err := n.resolveEscalationTarget(ctx, target)
+		recipients, err := n.resolveEscalationTarget(ctx, target, time.Now())
 		if err != nil {
 			// Log error but continue with other targets
 			continue
 		}
 
-		// Check if target has notification channel override
-		targetChannelConfig, _ := target.ParseNotificationChannels()
-		var targetChannelTypes []string
-		if targetChannelConfig != nil && len(targetChannelConfig.Channels) > 0 {
-			targetChannelTypes = targetChannelConfig.Channels
-		}
-
 		for _, recipient := range recipients {
-			// Check if user is in DND mode
-			if n.dndService != nil {
-				inDND, err := n.dndService.IsInDNDMode(ctx, recipient.UserID, alert.Priority)
-				if err == nil && inDND {
-					// User is in DND mode, skip notification
-					continue
-				}
+			// Users can ask for a single channel for less urgent alerts
+			if !recipient.PreferredChannelOnly && !recipient.Unavailable && n.primaryChannelOnly(ctx, recipient.UserID, alert.Priority) {
+				recipient.PreferredChannelOnly = true
 			}
 
-			// Send through appropriate channels
-			for _, channel := range channels {
-				if !channel.IsEnabled {
-					continue
+			// Check if user is in DND mode; suppressed pages are still logged
+			var dnd *domain.DNDDecision
+			if n.dndService != nil && !recipient.Unavailable {
+				decision, err := n.dndService.IsInDND(ctx, recipient.UserID, time.Now(), alert.Priority)
+				if err == nil && decision.InDND {
+					dnd = decision
 				}
+			}
 
-				// If target has specific channel override, only use those channels
-				if len(targetChannelTypes) > 0 {
-					if !containsChannelType(targetChannelTypes, string(channel.ChannelType)) {
-						continue
-					}
-				}
+			recipientData := data
+			if !recipient.Unavailable && dnd == nil {
+				recipientData.AckURL = n.ackURL(ctx, alert, recipient.UserID)
+			}
 
+			// Send through appropriate channels
+			for _, channel := range n.recipientChannels(ctx, alert.OrganizationID, channels, target, recipient) {
 				recipientAddr := recipient.ContactInfo
+				subject, message := n.renderNotification(org, channel.ChannelType, recipientData)
 
 				// Construct notification request
 				req := &dto.SendNotificationRequest{
@@ -143,6 +211,15 @@ func (n *AlertNotifier) NotifyAlertEscalated(
 					Message:   message,
 				}
 
+				if recipient.Unavailable {
+					_, _ = n.notificationService.LogSkippedUnavailable(ctx, alert.OrganizationID, req)
+					continue
+				}
+				if dnd != nil {
+					_, _ = n.notificationService.LogSuppressed(ctx, alert.OrganizationID, req, dnd.Reason)
+					continue
+				}
+
 				// Send notification (errors are logged in the notification service)
 				_, _ = n.notificationService.SendNotification(ctx, alert.OrganizationID, req)
 			}
@@ -154,14 +231,111 @@ func (n *AlertNotifier) NotifyAlertEscalated(
 
 // RecipientInfo contains user contact information for notifications
 type RecipientInfo struct {
-	UserID      uuid.UUID
-	ContactInfo string // email, slack user id, etc.
+	UserID               uuid.UUID
+	ContactInfo          string // email, slack user id, etc.
+	Unavailable          bool   // Skipped in a rotation; logged but not paged
+	PreferredChannelOnly bool   // Paged through the user's preferred channel only
+}
+
+// PreviewEscalationTargets expands targets to the users they would page at
+// the given time and the channels each would be paged through. Do Not Disturb
+// and primary-channel-only preferences aren't applied since they depend on the
+// priority of the alert.
+func (n *AlertNotifier) PreviewEscalationTargets(ctx context.Context, orgID uuid.UUID, targets []*domain.EscalationTarget, at time.Time) ([]*dto.EscalationPreviewRecipient, error) {
+	var channels []domain.NotificationChannel
+	if n.notificationService != nil {
+		var err error
+		if channels, err = n.notificationService.ListChannels(ctx, orgID); err != nil {
+			return nil, fmt.Errorf("failed to list notification channels: %w", err)
+		}
+	}
+
+	previews := []*dto.EscalationPreviewRecipient{}
+	for _, target := range targets {
+		recipients, err := n.resolveEscalationTarget(ctx, *target, at)
+		if err != nil {
+			// Pages skip targets that can't be resolved, and so does the preview
+			continue
+		}
+
+		for _, recipient := range recipients {
+			preview := &dto.EscalationPreviewRecipient{
+				TargetID:     target.TargetID,
+				TargetType:   target.TargetType.String(),
+				ScheduleMode: string(target.ScheduleMode),
+				UserID:       recipient.UserID,
+				Email:        recipient.ContactInfo,
+				Unavailable:  recipient.Unavailable,
+				Channels:     []*dto.EscalationPreviewChannel{},
+			}
+			for _, channel := range n.recipientChannels(ctx, orgID, channels, *target, recipient) {
+				preview.Channels = append(preview.Channels, &dto.EscalationPreviewChannel{
+					ID:          channel.ID,
+					Name:        channel.Name,
+					ChannelType: string(channel.ChannelType),
+				})
+			}
+			previews = append(previews, preview)
+		}
+	}
+
+	return previews, nil
 }
 
-// resolveEscalationTarget resolves an escalation target to actual recipients
+// recipientChannels returns the channels a recipient of target is paged
+// through: every enabled channel, or just their preferred one for team
+// members, narrowed to the target's channel override when it has one
+func (n *AlertNotifier) recipientChannels(
+	ctx context.Context,
+	orgID uuid.UUID,
+	channels []domain.NotificationChannel,
+	target domain.EscalationTarget,
+	recipient RecipientInfo,
+) []domain.NotificationChannel {
+	if len(channels) == 0 {
+		return nil
+	}
+
+	// Check if target has notification channel override
+	targetChannelConfig, _ := target.ParseNotificationChannels()
+	var targetChannelTypes []string
+	if targetChannelConfig != nil && len(targetChannelConfig.Channels) > 0 {
+		targetChannelTypes = targetChannelConfig.Channels
+	}
+
+	candidates := channels
+	if recipient.PreferredChannelOnly && len(targetChannelTypes) == 0 {
+		preferred, err := n.notificationService.PreferredChannel(ctx, orgID, recipient.UserID)
+		if err != nil || preferred == nil {
+			return nil
+		}
+		candidates = []domain.NotificationChannel{*preferred}
+	}
+
+	var result []domain.NotificationChannel
+	for _, channel := range candidates {
+		if !channel.IsEnabled {
+			continue
+		}
+
+		// If target has specific channel override, only use those channels
+		if len(targetChannelTypes) > 0 {
+			if !containsChannelType(targetChannelTypes, string(channel.ChannelType)) {
+				continue
+			}
+		}
+
+		result = append(result, channel)
+	}
+	return result
+}
+
+// resolveEscalationTarget resolves an escalation target to the recipients it
+// pages at the given time
 func (n *AlertNotifier) resolveEscalationTarget(
 	ctx context.Context,
 	target domain.EscalationTarget,
+	at time.Time,
 ) ([]RecipientInfo, error) {
 	var recipients []RecipientInfo
 
@@ -183,20 +357,29 @@ func (n *AlertNotifier) resolveEscalationTarget(
 			return nil, fmt.Errorf("failed to list team members: %w", err)
 		}
 
+		// Every active member is paged, deactivated accounts are left out
 		for _, member := range teamMembers {
+			if !member.IsActive {
+				continue
+			}
 			recipients = append(recipients, RecipientInfo{
-				UserID:      member.ID,
-				ContactInfo: member.Email,
+				UserID:               member.ID,
+				ContactInfo:          member.Email,
+				PreferredChannelOnly: true,
 			})
 		}
 
 	case domain.EscalationTargetTypeSchedule:
-		// Get on-call user for this schedule at current time
 		if n.scheduleService == nil {
 			return nil, fmt.Errorf("schedule service not configured")
 		}
 
-		onCallUser, err := n.scheduleService.GetOnCallUser(ctx, target.TargetID, time.Now())
+		if target.ScheduleMode == domain.ScheduleTargetModeAllMembers {
+			return n.resolveScheduleMembers(ctx, target.TargetID)
+		}
+
+		// Get on-call user for this schedule at that time
+		onCallUser, err := n.scheduleService.GetOnCallUser(ctx, target.TargetID, at)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get on-call user: %w", err)
 		}
@@ -209,12 +392,116 @@ func (n *AlertNotifier) resolveEscalationTarget(
 					ContactInfo: user.Email,
 				})
 			}
+
+			// Record who was passed over so the handoff is visible in the logs
+			for _, skippedID := range onCallUser.SkippedUserIDs {
+				skipped, err := n.userRepo.GetByID(ctx, skippedID)
+				if err != nil {
+					continue
+				}
+				recipients = append(recipients, RecipientInfo{
+					UserID:      skipped.ID,
+					ContactInfo: skipped.Email,
+					Unavailable: true,
+				})
+			}
+		}
+	}
+
+	return recipients, nil
+}
+
+// resolveScheduleMembers returns every active participant of the schedule's
+// rotations, whether or not they are on call. Users in several rotations are
+// paged once.
+func (n *AlertNotifier) resolveScheduleMembers(ctx context.Context, scheduleID uuid.UUID) ([]RecipientInfo, error) {
+	rotations, err := n.scheduleService.ListRotations(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var recipients []RecipientInfo
+	seen := make(map[uuid.UUID]bool)
+	for _, rotation := range rotations {
+		participants, err := n.scheduleService.ListParticipants(ctx, rotation.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, participant := range participants {
+			if !participant.User.IsActive || seen[participant.UserID] {
+				continue
+			}
+			seen[participant.UserID] = true
+			recipients = append(recipients, RecipientInfo{
+				UserID:      participant.UserID,
+				ContactInfo: participant.User.Email,
+			})
 		}
 	}
 
 	return recipients, nil
 }
 
+// primaryChannelOnly reports whether the user asked to be paged through their
+// primary channel only for alerts of this priority
+func (n *AlertNotifier) primaryChannelOnly(ctx context.Context, userID uuid.UUID, priority domain.AlertPriority) bool {
+	user, err := n.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return false
+	}
+	return user.PrimaryChannelOnly(priority)
+}
+
+// ackURL returns a link acknowledging the alert for the user, or an empty
+// string when links aren't configured or the token can't be issued. One link
+// is shared by all of the recipient's channels.
+func (n *AlertNotifier) ackURL(ctx context.Context, alert *domain.Alert, userID uuid.UUID) string {
+	if n.ackTokens == nil {
+		return ""
+	}
+	token, err := n.ackTokens.IssueAckToken(ctx, alert, userID)
+	if err != nil {
+		fmt.Printf("Failed to issue ack token for alert %s: %v\n", alert.ID, err)
+		return ""
+	}
+	return n.ackBaseURL + "/api/v1/alerts/ack/" + token
+}
+
+// organization returns the organization whose templates notifications are
+// rendered with, or nil when it can't be loaded
+func (n *AlertNotifier) organization(ctx context.Context, orgID uuid.UUID) *domain.Organization {
+	if n.orgRepo == nil {
+		return nil
+	}
+	org, err := n.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		fmt.Printf("Failed to load organization %s for notification templates: %v\n", orgID, err)
+		return nil
+	}
+	return org
+}
+
+// renderNotification renders the subject and message for a channel type with
+// the organization's template, falling back to the built-in template if the
+// organization is unknown or its template fails to render
+func (n *AlertNotifier) renderNotification(org *domain.Organization, channelType domain.ChannelType, data domain.NotificationTemplateData) (string, string) {
+	if org != nil {
+		subject, message, err := org.NotificationTemplate(channelType).Render(data)
+		if err == nil {
+			return subject, message
+		}
+		fmt.Printf("Failed to render %s notification template: %v\n", channelType, err)
+	}
+
+	builtin, ok := domain.DefaultNotificationTemplates[channelType]
+	if !ok {
+		builtin = domain.DefaultNotificationTemplates[domain.ChannelTypeWebhook]
+	}
+	// Built-in templates always render
+	subject, message, _ := builtin.Render(data)
+	return subject, message
+}
+
 func getDescriptionOrDefault(description *string) string {
 	if description != nil {
 		return *description
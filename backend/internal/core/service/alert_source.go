@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
+)
+
+// maxSourceNameLength matches the alert_sources.name column
+const maxSourceNameLength = 255
+
+// AlertSourceService manages the organization's registry of known alert
+// sources and maps the source of incoming alerts to its canonical name
+type AlertSourceService struct {
+	sourceRepo outbound.AlertSourceRepository
+}
+
+func NewAlertSourceService(sourceRepo outbound.AlertSourceRepository) *AlertSourceService {
+	return &AlertSourceService{
+		sourceRepo: sourceRepo,
+	}
+}
+
+func (s *AlertSourceService) CreateSource(ctx context.Context, orgID uuid.UUID, req *dto.CreateAlertSourceRequest) (*domain.RegisteredSource, error) {
+	source := &domain.RegisteredSource{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		Name:           req.Name,
+		Aliases:        req.Aliases,
+	}
+
+	if err := s.validateSource(ctx, source); err != nil {
+		return nil, err
+	}
+
+	if err := s.sourceRepo.Create(ctx, source); err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+func (s *AlertSourceService) UpdateSource(ctx context.Context, id, orgID uuid.UUID, req *dto.UpdateAlertSourceRequest) (*domain.RegisteredSource, error) {
+	source, err := s.sourceRepo.GetByID(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		source.Name = *req.Name
+	}
+	if req.Aliases != nil {
+		source.Aliases = req.Aliases
+	}
+
+	if err := s.validateSource(ctx, source); err != nil {
+		return nil, err
+	}
+
+	if err := s.sourceRepo.Update(ctx, source); err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+func (s *AlertSourceService) DeleteSource(ctx context.Context, id, orgID uuid.UUID) error {
+	return s.sourceRepo.Delete(ctx, id, orgID)
+}
+
+// ListSources returns the registered sources with how many alerts each has
+func (s *AlertSourceService) ListSources(ctx context.Context, orgID uuid.UUID) ([]*domain.RegisteredSourceWithCount, error) {
+	return s.sourceRepo.ListWithCounts(ctx, orgID)
+}
+
+// NormalizeSource returns the canonical name of the registered source that
+// source is a name or alias of. Unregistered sources are returned unchanged.
+func (s *AlertSourceService) NormalizeSource(ctx context.Context, orgID uuid.UUID, source string) (string, error) {
+	key := domain.NormalizeSourceAlias(source)
+	if key == "" {
+		return source, nil
+	}
+
+	registered, err := s.sourceRepo.FindByKey(ctx, orgID, key)
+	if errors.Is(err, domain.ErrAlertSourceNotFound) {
+		return source, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return registered.Name, nil
+}
+
+// validateSource trims the name, normalizes and dedupes the aliases, and
+// checks that no spelling is already claimed by another source
+func (s *AlertSourceService) validateSource(ctx context.Context, source *domain.RegisteredSource) error {
+	source.Name = strings.TrimSpace(source.Name)
+	if source.Name == "" {
+		return fmt.Errorf("%w: name is required", domain.ErrInvalidAlertSource)
+	}
+	if utf8.RuneCountInString(source.Name) > maxSourceNameLength {
+		return fmt.Errorf("%w: name is longer than %d characters", domain.ErrInvalidAlertSource, maxSourceNameLength)
+	}
+
+	nameKey := domain.NormalizeSourceAlias(source.Name)
+	aliases := make([]string, 0, len(source.Aliases))
+	seen := map[string]bool{nameKey: true}
+	for _, alias := range source.Aliases {
+		alias = domain.NormalizeSourceAlias(alias)
+		if alias == "" || seen[alias] {
+			continue
+		}
+		seen[alias] = true
+		aliases = append(aliases, alias)
+	}
+	source.Aliases = aliases
+
+	existing, err := s.sourceRepo.List(ctx, source.OrganizationID)
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if other.ID == source.ID {
+			continue
+		}
+		for _, key := range other.Keys() {
+			if seen[key] {
+				return fmt.Errorf("%w: %q is used by %s", domain.ErrAlertSourceConflict, key, other.Name)
+			}
+		}
+	}
+
+	return nil
+}
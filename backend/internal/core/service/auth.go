@@ -3,11 +3,12 @@ package service
 import (
 	"context"
 	crypto_rand "crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
-	"unicode"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -17,6 +18,7 @@ import (
 	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/dto"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/logger"
 )
 
 // AuthConfig holds narrow JWT configuration for the auth service,
@@ -24,8 +26,14 @@ import (
 type AuthConfig struct {
 	JWTSecret        string
 	JWTRefreshSecret string
-	AccessTTLMinutes int
-	RefreshTTLDays   int
+	AccessTTL        time.Duration
+	RefreshTTL       time.Duration
+	// PasswordPolicy applies to every password a user sets. The zero value
+	// falls back to domain.DefaultPasswordPolicy.
+	PasswordPolicy domain.PasswordPolicy
+	// LoginPolicy limits failed logins once a login attempt repository is
+	// set. The zero value falls back to domain.DefaultLoginPolicy.
+	LoginPolicy domain.LoginPolicy
 }
 
 // Claims defines JWT claims locally to break the circular dependency
@@ -35,6 +43,7 @@ type Claims struct {
 	Email          string    `json:"email"`
 	OrganizationID uuid.UUID `json:"organization_id"`
 	Role           string    `json:"role"`
+	SessionID      uuid.UUID `json:"session_id"`
 	jwt.RegisteredClaims
 }
 
@@ -44,6 +53,8 @@ type AuthService struct {
 	config                   AuthConfig
 	emailVerificationService *EmailVerificationService
 	tokenRevoker             outbound.TokenRevoker
+	loginAttemptRepo         outbound.LoginAttemptRepository
+	sessionRepo              outbound.SessionRepository
 	logger                   *zap.Logger
 }
 
@@ -55,6 +66,12 @@ func NewAuthService(
 	tokenRevoker outbound.TokenRevoker,
 	logger *zap.Logger,
 ) *AuthService {
+	if cfg.PasswordPolicy.MinLength == 0 {
+		cfg.PasswordPolicy = domain.DefaultPasswordPolicy()
+	}
+	if cfg.LoginPolicy.MaxAccountFailures == 0 {
+		cfg.LoginPolicy = domain.DefaultLoginPolicy()
+	}
 	return &AuthService{
 		userRepo:                 userRepo,
 		orgRepo:                  orgRepo,
@@ -65,7 +82,21 @@ func NewAuthService(
 	}
 }
 
-func (s *AuthService) Register(ctx context.Context, req *dto.RegisterRequest) (*dto.AuthResponse, error) {
+// SetLoginAttemptRepo enables recording login attempts and locking out
+// accounts and IP addresses that fail too often
+func (s *AuthService) SetLoginAttemptRepo(repo outbound.LoginAttemptRepository) {
+	s.loginAttemptRepo = repo
+}
+
+// SetSessionRepo enables tracking signed-in devices so their refresh tokens
+// can be listed and revoked
+func (s *AuthService) SetSessionRepo(repo outbound.SessionRepository) {
+	s.sessionRepo = repo
+}
+
+// Register creates the user with a new organization they own and signs them
+// in. ipAddress and userAgent identify the client for the new session.
+func (s *AuthService) Register(ctx context.Context, req *dto.RegisterRequest, ipAddress, userAgent string) (*dto.AuthResponse, error) {
 	// Check if user already exists
 	existingUser, _ := s.userRepo.GetByEmail(ctx, req.Email)
 	if existingUser != nil {
@@ -78,7 +109,7 @@ func (s *AuthService) Register(ctx context.Context, req *dto.RegisterRequest) (*
 	}
 
 	// Validate password strength
-	if err := validatePassword(req.Password); err != nil {
+	if err := s.config.PasswordPolicy.Check(req.Password); err != nil {
 		return nil, err
 	}
 
@@ -133,26 +164,22 @@ func (s *AuthService) Register(ctx context.Context, req *dto.RegisterRequest) (*
 		return nil, fmt.Errorf("failed to add user to organization: %w", err)
 	}
 
-	// Generate tokens
-	accessToken, err := s.generateAccessToken(user.ID, user.Email, org.ID, string(domain.RoleOwner))
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
-	}
-
-	refreshToken, err := s.generateRefreshToken(user.ID, user.Email, org.ID, string(domain.RoleOwner))
+	// Start a session and generate its tokens
+	accessToken, refreshToken, err := s.startSession(ctx, user, org.ID, string(domain.RoleOwner), ipAddress, userAgent)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+		return nil, err
 	}
 
 	// Clear password hash before returning
 	user.PasswordHash = ""
 
-	// Send verification email if email verification is enabled
+	// Send verification email if email verification is enabled. A delivery
+	// failure doesn't fail registration, the code is kept and can be resent.
+	verificationEmailFailed := false
 	if emailVerificationEnabled {
 		if err := s.emailVerificationService.CreateAndSendOTP(ctx, user.ID, user.Email, user.Username); err != nil {
-			// Log error but don't fail registration
-			// In production, you might want to handle this differently
-			s.logger.Warn("Failed to send verification email", zap.Error(err))
+			logger.WithContext(ctx, s.logger).Warn("Failed to send verification email", zap.Error(err))
+			verificationEmailFailed = true
 		}
 	}
 
@@ -162,51 +189,134 @@ func (s *AuthService) Register(ctx context.Context, req *dto.RegisterRequest) (*
 		AccessToken:               accessToken,
 		RefreshToken:              refreshToken,
 		RequiresEmailVerification: emailVerificationEnabled,
+		VerificationEmailFailed:   verificationEmailFailed,
+	}, nil
+}
+
+// RegisterInvitedUser creates an account for a team invitee and adds it to the
+// inviting organization as a member. The invitation token proves ownership of
+// the email, so the account starts out verified.
+func (s *AuthService) RegisterInvitedUser(ctx context.Context, orgID uuid.UUID, email, username, password, fullName string) (*dto.AuthResponse, error) {
+	existingUser, _ := s.userRepo.GetByEmail(ctx, email)
+	if existingUser != nil {
+		return nil, fmt.Errorf("registration failed, please try again")
+	}
+
+	existingUser, _ = s.userRepo.GetByUsername(ctx, username)
+	if existingUser != nil {
+		return nil, fmt.Errorf("registration failed, please try again")
+	}
+
+	if err := s.config.PasswordPolicy.Check(password); err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("organization not found")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := &domain.User{
+		ID:                      uuid.New(),
+		Email:                   email,
+		Username:                username,
+		PasswordHash:            string(hashedPassword),
+		FullName:                &fullName,
+		Timezone:                "UTC",
+		NotificationPreferences: make(map[string]interface{}),
+		IsActive:                true,
+		EmailVerified:           true,
+	}
+
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	if err := s.orgRepo.AddUser(ctx, org.ID, user.ID, domain.RoleMember); err != nil {
+		s.userRepo.Delete(ctx, user.ID)
+		return nil, fmt.Errorf("failed to add user to organization: %w", err)
+	}
+
+	accessToken, refreshToken, err := s.startSession(ctx, user, org.ID, string(domain.RoleMember), "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	user.PasswordHash = ""
+
+	return &dto.AuthResponse{
+		User:         user,
+		Organization: org,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
 	}, nil
 }
 
-func (s *AuthService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.AuthResponse, error) {
+// Login authenticates the user. ipAddress and userAgent identify the client
+// for throttling, the login audit trail and the new session.
+func (s *AuthService) Login(ctx context.Context, req *dto.LoginRequest, ipAddress, userAgent string) (*dto.AuthResponse, error) {
+	attempt := &domain.LoginAttempt{
+		ID:        uuid.New(),
+		Email:     req.Email,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	}
+
+	if err := s.checkIPThrottle(ctx, ipAddress); err != nil {
+		s.recordLoginAttempt(ctx, attempt, domain.LoginFailureIPThrottled)
+		return nil, err
+	}
+
 	// Get user by email
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
+		s.recordLoginAttempt(ctx, attempt, domain.LoginFailureInvalidCredentials)
 		return nil, fmt.Errorf("invalid email or password")
 	}
+	attempt.UserID = &user.ID
+
+	// A locked account is refused even with the right password
+	attempts, err := s.claimAccountAttempt(ctx, user.ID)
+	if err != nil {
+		s.recordLoginAttempt(ctx, attempt, domain.LoginFailureAccountLocked)
+		return nil, err
+	}
 
 	// Check if user is active
 	if !user.IsActive {
+		s.recordLoginAttempt(ctx, attempt, domain.LoginFailureAccountDisabled)
 		return nil, fmt.Errorf("user account is disabled")
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		s.recordLoginAttempt(ctx, attempt, domain.LoginFailureInvalidCredentials)
+		s.recordAccountFailure(ctx, user.ID, attempts)
 		return nil, fmt.Errorf("invalid email or password")
 	}
-
-	// Get user's organizations
-	orgs, err := s.orgRepo.ListUserOrganizations(ctx, user.ID)
-	if err != nil || len(orgs) == 0 {
-		return nil, fmt.Errorf("user has no organizations")
+	if s.loginAttemptRepo != nil {
+		if err := s.loginAttemptRepo.ResetLockout(ctx, user.ID); err != nil {
+			logger.WithContext(ctx, s.logger).Warn("Failed to reset login failures", zap.Error(err))
+		}
 	}
 
-	// Use the first organization (in production, let user select)
-	org := orgs[0]
-
-	// Get user's role in the organization
-	role, err := s.orgRepo.GetUserRole(ctx, org.ID, user.ID)
+	org, role, err := s.selectOrganization(ctx, user.ID, req.OrganizationID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user role: %w", err)
+		return nil, err
 	}
 
-	// Generate tokens
-	accessToken, err := s.generateAccessToken(user.ID, user.Email, org.ID, string(role))
+	// Start a session and generate its tokens
+	accessToken, refreshToken, err := s.startSession(ctx, user, org.ID, string(role), ipAddress, userAgent)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+		return nil, err
 	}
 
-	refreshToken, err := s.generateRefreshToken(user.ID, user.Email, org.ID, string(role))
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
-	}
+	s.recordLoginAttempt(ctx, attempt, "")
 
 	// Clear password hash before returning
 	user.PasswordHash = ""
@@ -223,7 +333,130 @@ func (s *AuthService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.Au
 	}, nil
 }
 
-func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*dto.AuthResponse, error) {
+// selectOrganization returns the organization to sign the user in to and their
+// role in it. Without a requested organization the most recently created one
+// is used.
+func (s *AuthService) selectOrganization(ctx context.Context, userID uuid.UUID, orgID *uuid.UUID) (*domain.Organization, domain.UserRole, error) {
+	if orgID == nil {
+		orgs, err := s.orgRepo.ListUserOrganizations(ctx, userID)
+		if err != nil || len(orgs) == 0 {
+			return nil, "", fmt.Errorf("user has no organizations")
+		}
+		orgID = &orgs[0].ID
+	}
+
+	role, err := s.membershipRole(ctx, *orgID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, *orgID)
+	if err != nil {
+		return nil, "", fmt.Errorf("organization not found")
+	}
+
+	return org, role, nil
+}
+
+// membershipRole returns the user's role in the organization, or
+// domain.ErrNotOrganizationMember when they don't belong to it
+func (s *AuthService) membershipRole(ctx context.Context, orgID, userID uuid.UUID) (domain.UserRole, error) {
+	role, err := s.orgRepo.GetUserRole(ctx, orgID, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", domain.ErrNotOrganizationMember
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get user role: %w", err)
+	}
+	return role, nil
+}
+
+// checkIPThrottle refuses logins from an IP address that recently failed too
+// often. The refusal lasts at most one failure window.
+func (s *AuthService) checkIPThrottle(ctx context.Context, ipAddress string) error {
+	if s.loginAttemptRepo == nil || ipAddress == "" {
+		return nil
+	}
+
+	policy := s.config.LoginPolicy
+	now := time.Now()
+	failures, err := s.loginAttemptRepo.CountIPFailures(ctx, ipAddress, now.Add(-policy.FailureWindow))
+	if err != nil {
+		logger.WithContext(ctx, s.logger).Warn("Failed to count login failures", zap.Error(err))
+		return nil
+	}
+	if failures >= policy.MaxIPFailures {
+		return &domain.LoginLockedError{Until: now.Add(policy.FailureWindow)}
+	}
+	return nil
+}
+
+// claimAccountAttempt counts the login against the account before its
+// password is checked and returns the number of consecutive attempts. It
+// returns a LoginLockedError while the account is locked, or while other
+// logins have already used up the remaining attempts.
+func (s *AuthService) claimAccountAttempt(ctx context.Context, userID uuid.UUID) (int, error) {
+	if s.loginAttemptRepo == nil {
+		return 0, nil
+	}
+
+	policy := s.config.LoginPolicy
+	now := time.Now()
+	attempts, err := s.loginAttemptRepo.ClaimAttempt(ctx, userID, now.Add(-policy.FailureWindow), policy.MaxAccountFailures)
+	if errors.Is(err, domain.ErrLoginLocked) {
+		until := now
+		if lockout, err := s.loginAttemptRepo.GetLockout(ctx, userID); err == nil && lockout.Locked(now) {
+			until = *lockout.LockedUntil
+		}
+		return 0, &domain.LoginLockedError{Until: until}
+	}
+	if err != nil {
+		logger.WithContext(ctx, s.logger).Warn("Failed to record login attempt", zap.Error(err))
+		return 0, nil
+	}
+	return attempts, nil
+}
+
+// recordAccountFailure locks the account once a wrong password used up the
+// policy's last attempt
+func (s *AuthService) recordAccountFailure(ctx context.Context, userID uuid.UUID, attempts int) {
+	if s.loginAttemptRepo == nil {
+		return
+	}
+
+	policy := s.config.LoginPolicy
+	if attempts < policy.MaxAccountFailures {
+		return
+	}
+
+	if err := s.loginAttemptRepo.Lock(ctx, userID, time.Now().Add(policy.LockoutDuration)); err != nil {
+		logger.WithContext(ctx, s.logger).Warn("Failed to lock account", zap.Error(err))
+		return
+	}
+	logger.WithContext(ctx, s.logger).Warn("Account locked after repeated failed logins",
+		zap.String("user_id", userID.String()), zap.Int("failures", attempts))
+}
+
+// recordLoginAttempt stores the attempt in the login audit trail. An empty
+// failureReason records a successful login.
+func (s *AuthService) recordLoginAttempt(ctx context.Context, attempt *domain.LoginAttempt, failureReason string) {
+	if s.loginAttemptRepo == nil {
+		return
+	}
+
+	attempt.Succeeded = failureReason == ""
+	if !attempt.Succeeded {
+		attempt.FailureReason = &failureReason
+	}
+	if err := s.loginAttemptRepo.Create(ctx, attempt); err != nil {
+		logger.WithContext(ctx, s.logger).Warn("Failed to record login attempt", zap.Error(err))
+	}
+}
+
+// RefreshToken exchanges a refresh token for a new token pair. With sessions
+// tracked, each refresh token can be used once and only while its session
+// hasn't been revoked.
+func (s *AuthService) RefreshToken(ctx context.Context, refreshToken, ipAddress, userAgent string) (*dto.AuthResponse, error) {
 	// Parse refresh token
 	token, err := jwt.ParseWithClaims(refreshToken, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -246,11 +479,18 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*d
 		return nil, fmt.Errorf("refresh token expired")
 	}
 
+	if s.sessionRepo != nil && claims.SessionID == uuid.Nil {
+		return nil, fmt.Errorf("invalid refresh token")
+	}
+
 	// Get user
 	user, err := s.userRepo.GetByID(ctx, claims.UserID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found")
 	}
+	if !user.IsActive {
+		return nil, fmt.Errorf("user account is disabled")
+	}
 
 	// Get organization
 	org, err := s.orgRepo.GetByID(ctx, claims.OrganizationID)
@@ -258,20 +498,33 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*d
 		return nil, fmt.Errorf("organization not found")
 	}
 
+	// The user may have left the organization or changed roles since the
+	// token was issued
+	role, err := s.membershipRole(ctx, org.ID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Revoke the old refresh token to prevent reuse
 	if s.tokenRevoker != nil && claims.ExpiresAt != nil {
 		s.tokenRevoker.Revoke(refreshToken, claims.ExpiresAt.Time)
 	}
 
 	// Generate new tokens
-	accessToken, err := s.generateAccessToken(user.ID, user.Email, org.ID, claims.Role)
+	accessToken, newRefreshToken, err := s.generateTokens(user, org.ID, string(role), claims.SessionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+		return nil, err
 	}
 
-	newRefreshToken, err := s.generateRefreshToken(user.ID, user.Email, org.ID, claims.Role)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	if s.sessionRepo != nil {
+		err := s.sessionRepo.Rotate(ctx, claims.SessionID, hashToken(refreshToken), hashToken(newRefreshToken),
+			ipAddress, userAgent, time.Now().Add(s.config.RefreshTTL))
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("refresh token has been revoked")
+		}
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Clear password hash before returning
@@ -285,6 +538,134 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*d
 	}, nil
 }
 
+// SwitchOrganization issues tokens for another organization of the user. The
+// session keeps its identity and the refresh token it held stops working.
+func (s *AuthService) SwitchOrganization(ctx context.Context, userID, sessionID, orgID uuid.UUID, ipAddress, userAgent string) (*dto.AuthResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	if !user.IsActive {
+		return nil, fmt.Errorf("user account is disabled")
+	}
+
+	org, role, err := s.selectOrganization(ctx, user.ID, &orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var accessToken, refreshToken string
+	if s.sessionRepo == nil || sessionID == uuid.Nil {
+		// Tokens issued before sessions were tracked get a new session
+		accessToken, refreshToken, err = s.startSession(ctx, user, org.ID, string(role), ipAddress, userAgent)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		accessToken, refreshToken, err = s.generateTokens(user, org.ID, string(role), sessionID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.sessionRepo.SwitchOrganization(ctx, user.ID, sessionID, org.ID, hashToken(refreshToken), time.Now().Add(s.config.RefreshTTL))
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Clear password hash before returning
+	user.PasswordHash = ""
+
+	return &dto.AuthResponse{
+		User:         user,
+		Organization: org,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// ListOrganizations returns the organizations the user belongs to, marking the
+// one the request was scoped to as current
+func (s *AuthService) ListOrganizations(ctx context.Context, userID, currentOrgID uuid.UUID) ([]*dto.OrganizationMembershipResponse, error) {
+	memberships, err := s.orgRepo.ListUserMemberships(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.OrganizationMembershipResponse, 0, len(memberships))
+	for _, m := range memberships {
+		responses = append(responses, &dto.OrganizationMembershipResponse{
+			ID:       m.ID,
+			Name:     m.Name,
+			Slug:     m.Slug,
+			Plan:     m.Plan,
+			Role:     string(m.Role),
+			JoinedAt: m.JoinedAt,
+			Current:  m.ID == currentOrgID,
+		})
+	}
+	return responses, nil
+}
+
+// ListSessions returns the user's active sessions, marking the one the
+// request was made from as current
+func (s *AuthService) ListSessions(ctx context.Context, userID, currentSessionID uuid.UUID) ([]*dto.SessionResponse, error) {
+	if s.sessionRepo == nil {
+		return nil, fmt.Errorf("sessions are not configured")
+	}
+
+	sessions, err := s.sessionRepo.ListActive(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sessionResponses(sessions, currentSessionID), nil
+}
+
+// sessionResponses converts sessions, marking currentSessionID as current
+func sessionResponses(sessions []*domain.Session, currentSessionID uuid.UUID) []*dto.SessionResponse {
+	responses := make([]*dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, &dto.SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+	return responses
+}
+
+// RevokeSession signs the user out of one session. Its refresh token stops
+// working; access tokens already issued to it stay valid until they expire.
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if s.sessionRepo == nil {
+		return fmt.Errorf("sessions are not configured")
+	}
+
+	if err := s.sessionRepo.Revoke(ctx, userID, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAllSessions signs the user out everywhere and returns how many
+// sessions were revoked
+func (s *AuthService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	if s.sessionRepo == nil {
+		return 0, fmt.Errorf("sessions are not configured")
+	}
+
+	return s.sessionRepo.RevokeAll(ctx, userID)
+}
+
 func (s *AuthService) GetMe(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -297,14 +678,56 @@ func (s *AuthService) GetMe(ctx context.Context, userID uuid.UUID) (*domain.User
 	return user, nil
 }
 
-func (s *AuthService) generateAccessToken(userID uuid.UUID, email string, orgID uuid.UUID, role string) (string, error) {
+// startSession generates the tokens for a new session and records the session
+// so it can be listed and revoked
+func (s *AuthService) startSession(ctx context.Context, user *domain.User, orgID uuid.UUID, role, ipAddress, userAgent string) (string, string, error) {
+	sessionID := uuid.New()
+	accessToken, refreshToken, err := s.generateTokens(user, orgID, role, sessionID)
+	if err != nil {
+		return "", "", err
+	}
+
+	if s.sessionRepo != nil {
+		session := &domain.Session{
+			ID:               sessionID,
+			UserID:           user.ID,
+			OrganizationID:   orgID,
+			RefreshTokenHash: hashToken(refreshToken),
+			UserAgent:        userAgent,
+			IPAddress:        ipAddress,
+			ExpiresAt:        time.Now().Add(s.config.RefreshTTL),
+		}
+		if err := s.sessionRepo.Create(ctx, session); err != nil {
+			return "", "", err
+		}
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+func (s *AuthService) generateTokens(user *domain.User, orgID uuid.UUID, role string, sessionID uuid.UUID) (string, string, error) {
+	accessToken, err := s.generateAccessToken(user.ID, user.Email, orgID, role, sessionID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err := s.generateRefreshToken(user.ID, user.Email, orgID, role, sessionID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+func (s *AuthService) generateAccessToken(userID uuid.UUID, email string, orgID uuid.UUID, role string, sessionID uuid.UUID) (string, error) {
 	claims := &Claims{
 		UserID:         userID,
 		Email:          email,
 		OrganizationID: orgID,
 		Role:           role,
+		SessionID:      sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(s.config.AccessTTLMinutes) * time.Minute)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.config.AccessTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
@@ -313,14 +736,17 @@ func (s *AuthService) generateAccessToken(userID uuid.UUID, email string, orgID
 	return token.SignedString([]byte(s.config.JWTSecret))
 }
 
-func (s *AuthService) generateRefreshToken(userID uuid.UUID, email string, orgID uuid.UUID, role string) (string, error) {
+func (s *AuthService) generateRefreshToken(userID uuid.UUID, email string, orgID uuid.UUID, role string, sessionID uuid.UUID) (string, error) {
 	claims := &Claims{
 		UserID:         userID,
 		Email:          email,
 		OrganizationID: orgID,
 		Role:           role,
+		SessionID:      sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Duration(s.config.RefreshTTLDays) * 24 * time.Hour)),
+			// A unique ID keeps tokens issued within the same second apart
+			ID:        uuid.New().String(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.config.RefreshTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
@@ -329,6 +755,13 @@ func (s *AuthService) generateRefreshToken(userID uuid.UUID, email string, orgID
 	return token.SignedString([]byte(s.config.JWTRefreshSecret))
 }
 
+// hashToken returns the hex SHA-256 of a token, the form refresh tokens are
+// stored in
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 func generateSlug(name string) string {
 	slug := strings.ToLower(name)
 	slug = strings.ReplaceAll(slug, " ", "-")
@@ -338,38 +771,3 @@ func generateSlug(name string) string {
 	slug = fmt.Sprintf("%s-%s", slug, hex.EncodeToString(b))
 	return slug
 }
-
-func validatePassword(password string) error {
-	if len(password) < 10 {
-		return fmt.Errorf("password must be at least 10 characters long")
-	}
-
-	var hasUpper, hasLower, hasDigit, hasSpecial bool
-	for _, ch := range password {
-		switch {
-		case unicode.IsUpper(ch):
-			hasUpper = true
-		case unicode.IsLower(ch):
-			hasLower = true
-		case unicode.IsDigit(ch):
-			hasDigit = true
-		case unicode.IsPunct(ch) || unicode.IsSymbol(ch):
-			hasSpecial = true
-		}
-	}
-
-	if !hasUpper {
-		return fmt.Errorf("password must contain at least one uppercase letter")
-	}
-	if !hasLower {
-		return fmt.Errorf("password must contain at least one lowercase letter")
-	}
-	if !hasDigit {
-		return fmt.Errorf("password must contain at least one digit")
-	}
-	if !hasSpecial {
-		return fmt.Errorf("password must contain at least one special character")
-	}
-
-	return nil
-}
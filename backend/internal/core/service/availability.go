@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
+)
+
+// AvailabilityService manages the periods users are unavailable for paging
+type AvailabilityService struct {
+	availabilityRepo outbound.UserAvailabilityRepository
+}
+
+func NewAvailabilityService(availabilityRepo outbound.UserAvailabilityRepository) *AvailabilityService {
+	return &AvailabilityService{availabilityRepo: availabilityRepo}
+}
+
+// AddUnavailability marks the user unavailable between req.Start and req.End
+func (s *AvailabilityService) AddUnavailability(ctx context.Context, userID uuid.UUID, req *dto.AddUnavailabilityRequest) (*domain.UserUnavailability, error) {
+	if !req.End.After(req.Start) {
+		return nil, fmt.Errorf("%w: end must be after start", domain.ErrInvalidUnavailability)
+	}
+	if !req.End.After(time.Now()) {
+		return nil, fmt.Errorf("%w: end must be in the future", domain.ErrInvalidUnavailability)
+	}
+
+	reason := req.Reason
+	if reason != nil {
+		trimmed := strings.TrimSpace(*reason)
+		reason = &trimmed
+		if trimmed == "" {
+			reason = nil
+		}
+	}
+
+	period := &domain.UserUnavailability{
+		ID:        uuid.New(),
+		UserID:    userID,
+		StartTime: req.Start,
+		EndTime:   req.End,
+		Reason:    reason,
+	}
+
+	if err := s.availabilityRepo.Create(ctx, period); err != nil {
+		return nil, err
+	}
+
+	return period, nil
+}
+
+// ListUnavailability returns the user's current and upcoming unavailability
+func (s *AvailabilityService) ListUnavailability(ctx context.Context, userID uuid.UUID) ([]*domain.UserUnavailability, error) {
+	return s.availabilityRepo.ListByUser(ctx, userID, time.Now())
+}
+
+// RemoveUnavailability deletes one of the user's unavailability periods
+func (s *AvailabilityService) RemoveUnavailability(ctx context.Context, userID, id uuid.UUID) error {
+	return s.availabilityRepo.Delete(ctx, id, userID)
+}
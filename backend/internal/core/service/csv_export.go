@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+// writeCSVPages writes header followed by one row per record, fetching records
+// exportPageSize at a time and flushing after every page so large exports are
+// streamed rather than built in memory. Each page is fetched after the cursor
+// of the previous page's last record, so rows created mid-export don't shift
+// later pages.
+func writeCSVPages[T any](ctx context.Context, w io.Writer, header []string, fetch func(limit int, after *domain.ListCursor) ([]T, error), cursor func(T) domain.ListCursor, row func(T) []string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	var after *domain.ListCursor
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		records, err := fetch(exportPageSize, after)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			if err := cw.Write(row(record)); err != nil {
+				return err
+			}
+		}
+
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+		if f, ok := w.(interface{ Flush() }); ok {
+			f.Flush()
+		}
+		if len(records) < exportPageSize {
+			return nil
+		}
+		last := cursor(records[len(records)-1])
+		after = &last
+	}
+}
+
+// csvText guards free-text cells against being evaluated as formulas when the
+// file is opened in a spreadsheet
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// csvTime formats an optional timestamp as RFC3339 in UTC
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// csvDuration is the whole number of seconds from start to end, or empty while
+// end hasn't happened yet
+func csvDuration(start time.Time, end *time.Time) string {
+	if end == nil {
+		return ""
+	}
+	return strconv.FormatInt(int64(end.Sub(start)/time.Second), 10)
+}
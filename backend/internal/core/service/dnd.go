@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -70,19 +71,10 @@ func (s *DNDService) UpdateSettings(ctx context.Context, userID uuid.UUID, req *
 		// Validate schedule format
 		var schedule domain.DNDSchedule
 		if err := json.Unmarshal(req.Schedule, &schedule); err != nil {
-			return nil, fmt.Errorf("invalid schedule format: %w", err)
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidDNDSchedule, err)
 		}
-		// Validate timezone
-		if schedule.Timezone != "" {
-			if _, err := time.LoadLocation(schedule.Timezone); err != nil {
-				return nil, fmt.Errorf("invalid timezone: %w", err)
-			}
-		}
-		// Validate days
-		for _, slot := range schedule.Weekly {
-			if !domain.IsValidDay(slot.Day) {
-				return nil, fmt.Errorf("invalid day: %s", slot.Day)
-			}
+		if err := validateDNDSchedule(&schedule); err != nil {
+			return nil, err
 		}
 		settings.Schedule = req.Schedule
 	}
@@ -106,47 +98,66 @@ func (s *DNDService) UpdateSettings(ctx context.Context, userID uuid.UUID, req *
 	return settings, nil
 }
 
-// AddOverride adds a temporary DND override
-func (s *DNDService) AddOverride(ctx context.Context, userID uuid.UUID, req *dto.AddDNDOverrideRequest) (*domain.UserDNDSettings, error) {
-	settings, err := s.dndRepo.GetByUserID(ctx, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get DND settings: %w", err)
+// validateDNDSchedule checks the timezone and every weekly slot. A slot whose
+// end is before its start is an overnight window (e.g. 22:00 to 08:00).
+func validateDNDSchedule(schedule *domain.DNDSchedule) error {
+	if schedule.Timezone != "" {
+		if _, err := time.LoadLocation(schedule.Timezone); err != nil {
+			return fmt.Errorf("%w: unknown timezone %q", domain.ErrInvalidDNDSchedule, schedule.Timezone)
+		}
 	}
 
-	if settings == nil {
-		// Create settings with the override
-		settings = &domain.UserDNDSettings{
-			ID:              uuid.New(),
-			UserID:          userID,
-			Enabled:         true, // Enable DND when adding override
-			Schedule:        json.RawMessage("{}"),
-			AllowP1Override: true,
+	for i, slot := range schedule.Weekly {
+		if !domain.IsValidDay(slot.Day) {
+			return fmt.Errorf("%w: slot %d: invalid day %q", domain.ErrInvalidDNDSchedule, i, slot.Day)
+		}
+		if !isValidClockTime(slot.Start) {
+			return fmt.Errorf("%w: slot %d: start %q must be in HH:MM format", domain.ErrInvalidDNDSchedule, i, slot.Start)
+		}
+		if !isValidClockTime(slot.End) {
+			return fmt.Errorf("%w: slot %d: end %q must be in HH:MM format", domain.ErrInvalidDNDSchedule, i, slot.End)
+		}
+		if slot.Start == slot.End {
+			return fmt.Errorf("%w: slot %d: start and end must differ", domain.ErrInvalidDNDSchedule, i)
 		}
 	}
 
-	// Parse existing overrides
-	overrides, err := settings.ParseOverrides()
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse existing overrides: %w", err)
+	return nil
+}
+
+// isValidClockTime reports whether value is a zero-padded 24-hour HH:MM time.
+// Quiet hours are compared as strings, so "9:00" must be rejected.
+func isValidClockTime(value string) bool {
+	t, err := time.Parse("15:04", value)
+	return err == nil && t.Format("15:04") == value
+}
+
+// AddOverride adds a temporary DND override
+func (s *DNDService) AddOverride(ctx context.Context, userID uuid.UUID, req *dto.AddDNDOverrideRequest) (*domain.UserDNDSettings, error) {
+	if req.Start.IsZero() || req.End.IsZero() {
+		return nil, fmt.Errorf("%w: start and end are required", domain.ErrInvalidDNDOverride)
+	}
+	if !req.End.After(req.Start) {
+		return nil, fmt.Errorf("%w: end time must be after start time", domain.ErrInvalidDNDOverride)
 	}
 
-	// Add new override
-	newOverride := domain.DNDOverride{
-		Start:  req.Start,
-		End:    req.End,
-		Reason: req.Reason,
+	// Defaults for users without settings: adding an override enables DND
+	defaults := &domain.UserDNDSettings{
+		ID:              uuid.New(),
+		UserID:          userID,
+		Enabled:         true,
+		Schedule:        json.RawMessage("{}"),
+		AllowP1Override: true,
 	}
-	overrides = append(overrides, newOverride)
 
-	// Marshal back to JSON
-	overridesJSON, err := json.Marshal(overrides)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal overrides: %w", err)
+	override := domain.DNDOverride{
+		Start:  req.Start.UTC(),
+		End:    req.End.UTC(),
+		Reason: req.Reason,
 	}
-	settings.Overrides = overridesJSON
 
-	// Save
-	if err := s.dndRepo.Upsert(ctx, settings); err != nil {
+	settings, err := s.dndRepo.AppendOverride(ctx, defaults, override)
+	if err != nil {
 		return nil, fmt.Errorf("failed to save DND settings: %w", err)
 	}
 
@@ -155,37 +166,15 @@ func (s *DNDService) AddOverride(ctx context.Context, userID uuid.UUID, req *dto
 
 // RemoveOverride removes a DND override by index
 func (s *DNDService) RemoveOverride(ctx context.Context, userID uuid.UUID, index int) (*domain.UserDNDSettings, error) {
-	settings, err := s.dndRepo.GetByUserID(ctx, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get DND settings: %w", err)
+	if index < 0 {
+		return nil, domain.ErrDNDOverrideNotFound
 	}
 
-	if settings == nil {
-		return nil, fmt.Errorf("no DND settings found")
+	settings, err := s.dndRepo.RemoveOverride(ctx, userID, index)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrDNDOverrideNotFound
 	}
-
-	// Parse existing overrides
-	overrides, err := settings.ParseOverrides()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse existing overrides: %w", err)
-	}
-
-	if index < 0 || index >= len(overrides) {
-		return nil, fmt.Errorf("invalid override index")
-	}
-
-	// Remove override at index
-	overrides = append(overrides[:index], overrides[index+1:]...)
-
-	// Marshal back to JSON
-	overridesJSON, err := json.Marshal(overrides)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal overrides: %w", err)
-	}
-	settings.Overrides = overridesJSON
-
-	// Save
-	if err := s.dndRepo.Update(ctx, settings); err != nil {
 		return nil, fmt.Errorf("failed to save DND settings: %w", err)
 	}
 
@@ -196,72 +185,80 @@ func (s *DNDService) RemoveOverride(ctx context.Context, userID uuid.UUID, index
 // Returns true if the user should not be notified
 // If priority is P1 and AllowP1Override is true, returns false (allow notification)
 func (s *DNDService) IsInDNDMode(ctx context.Context, userID uuid.UUID, priority domain.AlertPriority) (bool, error) {
+	decision, err := s.IsInDND(ctx, userID, time.Now(), priority)
+	if err != nil {
+		return false, err
+	}
+	return decision.InDND, nil
+}
+
+// IsInDND decides whether a user may be paged at the given time for an alert of
+// the given priority. Override periods are checked before the weekly schedule;
+// AllowP1Override lets P1 alerts through either.
+func (s *DNDService) IsInDND(ctx context.Context, userID uuid.UUID, at time.Time, priority domain.AlertPriority) (*domain.DNDDecision, error) {
 	settings, err := s.dndRepo.GetByUserID(ctx, userID)
 	if err != nil {
-		return false, fmt.Errorf("failed to get DND settings: %w", err)
+		return nil, fmt.Errorf("failed to get DND settings: %w", err)
 	}
 
-	// No settings or DND disabled
-	if settings == nil || !settings.Enabled {
-		return false, nil
+	return evaluateDND(settings, at, priority)
+}
+
+func evaluateDND(settings *domain.UserDNDSettings, at time.Time, priority domain.AlertPriority) (*domain.DNDDecision, error) {
+	if settings == nil {
+		return &domain.DNDDecision{Reason: domain.DNDReasonNotConfigured}, nil
+	}
+	if !settings.Enabled {
+		return &domain.DNDDecision{Reason: domain.DNDReasonDisabled}, nil
 	}
 
 	// Check if P1 alerts bypass DND
 	if priority == domain.PriorityP1 && settings.AllowP1Override {
-		return false, nil
+		return &domain.DNDDecision{Reason: domain.DNDReasonP1Override}, nil
 	}
 
-	now := time.Now()
-
 	// First check overrides (temporary DND periods)
 	overrides, err := settings.ParseOverrides()
 	if err != nil {
-		return false, fmt.Errorf("failed to parse overrides: %w", err)
+		return nil, fmt.Errorf("failed to parse overrides: %w", err)
 	}
 
 	for _, override := range overrides {
-		if now.After(override.Start) && now.Before(override.End) {
-			return true, nil // Currently in an override period
+		if at.After(override.Start) && at.Before(override.End) {
+			return &domain.DNDDecision{InDND: true, Reason: domain.DNDReasonOverridePeriod}, nil
 		}
 	}
 
 	// Check weekly schedule
 	schedule, err := settings.ParseSchedule()
 	if err != nil {
-		return false, fmt.Errorf("failed to parse schedule: %w", err)
-	}
-
-	if len(schedule.Weekly) == 0 {
-		return false, nil // No schedule defined
+		return nil, fmt.Errorf("failed to parse schedule: %w", err)
 	}
 
-	// Load timezone
 	loc := time.UTC
 	if schedule.Timezone != "" {
-		var err error
 		loc, err = time.LoadLocation(schedule.Timezone)
 		if err != nil {
-			return false, fmt.Errorf("invalid timezone: %w", err)
+			return nil, fmt.Errorf("invalid timezone: %w", err)
 		}
 	}
 
-	// Get current time in the user's timezone
-	localNow := now.In(loc)
-	currentDay := getDayName(localNow.Weekday())
-	currentTimeStr := localNow.Format("15:04")
+	// Check the time in the user's timezone against each DND slot
+	local := at.In(loc)
+	currentDay := getDayName(local.Weekday())
+	currentTimeStr := local.Format("15:04")
 
-	// Check if current time falls within any DND slot
 	for _, slot := range schedule.Weekly {
 		if slot.Day != currentDay {
 			continue
 		}
 
 		if isTimeInRange(currentTimeStr, slot.Start, slot.End) {
-			return true, nil
+			return &domain.DNDDecision{InDND: true, Reason: domain.DNDReasonWeeklySchedule}, nil
 		}
 	}
 
-	return false, nil
+	return &domain.DNDDecision{Reason: domain.DNDReasonOutsideWindow}, nil
 }
 
 // CleanExpiredOverrides removes overrides that have ended
@@ -3,39 +3,57 @@ package service
 import (
 	"context"
 	"crypto/rand"
+	"crypto/subtle"
 	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 
 	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/logger"
 )
 
 const (
 	OTPLength     = 6
 	OTPExpiration = 10 * time.Minute
+
+	// otpSendAttempts bounds how often a code is handed to the email provider
+	// before the failure is reported back to the caller
+	otpSendAttempts   = 3
+	otpSendRetryDelay = 250 * time.Millisecond
 )
 
 type EmailVerificationService struct {
 	verificationRepo outbound.EmailVerificationRepository
 	userRepo         outbound.UserRepository
 	emailService     *EmailService
+	environment      string
+	logger           *zap.Logger
 }
 
 func NewEmailVerificationService(
 	verificationRepo outbound.EmailVerificationRepository,
 	userRepo outbound.UserRepository,
 	emailService *EmailService,
+	logger *zap.Logger,
 ) *EmailVerificationService {
 	return &EmailVerificationService{
 		verificationRepo: verificationRepo,
 		userRepo:         userRepo,
 		emailService:     emailService,
+		logger:           logger,
 	}
 }
 
+// SetEnvironment sets the deployment environment. Outside production, codes
+// that can't be emailed are written to the server log instead.
+func (s *EmailVerificationService) SetEnvironment(env string) {
+	s.environment = env
+}
+
 func (s *EmailVerificationService) IsEmailServiceConfigured() bool {
 	return s.emailService != nil && s.emailService.IsConfigured()
 }
@@ -78,17 +96,71 @@ func (s *EmailVerificationService) CreateAndSendOTP(ctx context.Context, userID
 		return fmt.Errorf("failed to create verification record: %w", err)
 	}
 
-	// Send email if configured
-	if s.IsEmailServiceConfigured() {
-		if err := s.emailService.SendOTPEmail(email, otp, username); err != nil {
-			return fmt.Errorf("failed to send OTP email: %w", err)
-		}
+	if !s.IsEmailServiceConfigured() {
+		s.logFallbackOTP(ctx, email, otp)
+		return nil
+	}
+
+	attempts, sendErr := s.sendOTPEmail(ctx, email, otp, username)
+
+	var lastError *string
+	if sendErr != nil {
+		msg := sendErr.Error()
+		lastError = &msg
+	}
+	// Recorded even if the client has gone, so the resend cooldown is right
+	if err := s.verificationRepo.RecordSendResult(context.WithoutCancel(ctx), verification.ID, attempts, lastError); err != nil {
+		logger.WithContext(ctx, s.logger).Warn("Failed to record OTP send result", zap.String("email", email), zap.Error(err))
+	}
+
+	if sendErr != nil {
+		s.logFallbackOTP(ctx, email, otp)
+		return fmt.Errorf("%w: %v", domain.ErrOTPDeliveryFailed, sendErr)
 	}
 
 	return nil
 }
 
-// VerifyOTP verifies the OTP and marks the email as verified
+// sendOTPEmail hands the code to the email provider, retrying transient
+// failures with a linear backoff. It gives up early once ctx is done and
+// returns the number of attempts made.
+func (s *EmailVerificationService) sendOTPEmail(ctx context.Context, email, otp, username string) (int, error) {
+	var err error
+	for attempt := 1; attempt <= otpSendAttempts; attempt++ {
+		if err = s.emailService.SendOTPEmail(email, otp, username); err == nil {
+			return attempt, nil
+		}
+		if attempt == otpSendAttempts {
+			break
+		}
+
+		timer := time.NewTimer(time.Duration(attempt) * otpSendRetryDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, err
+		case <-timer.C:
+		}
+	}
+	return otpSendAttempts, err
+}
+
+// logFallbackOTP writes an undeliverable code to the server log so local and
+// staging sign-ups aren't blocked on email. Codes are never logged in production.
+func (s *EmailVerificationService) logFallbackOTP(ctx context.Context, email, otp string) {
+	if s.environment == "" || s.environment == "production" {
+		return
+	}
+	logger.WithContext(ctx, s.logger).Info("Email delivery unavailable, logging verification code",
+		zap.String("email", email),
+		zap.String("otp", otp),
+		zap.String("env", s.environment),
+	)
+}
+
+// VerifyOTP verifies the OTP and marks the email as verified. After
+// domain.OTPMaxAttempts wrong guesses the code is locked until a new one is
+// requested.
 func (s *EmailVerificationService) VerifyOTP(ctx context.Context, email, otp string) error {
 	// Get the verification record
 	verification, err := s.verificationRepo.GetByEmail(ctx, email)
@@ -101,9 +173,24 @@ func (s *EmailVerificationService) VerifyOTP(ctx context.Context, email, otp str
 		return fmt.Errorf("OTP has expired")
 	}
 
+	// A locked code can't be verified, even with the right value
+	if verification.Locked() {
+		return domain.ErrOTPLocked
+	}
+
+	// Every guess uses up an attempt before it is checked, so concurrent
+	// guesses can't exceed the limit; a correct one resets the count below
+	attempts, err := s.verificationRepo.ClaimAttempt(ctx, verification.ID, domain.OTPMaxAttempts)
+	if err != nil {
+		return err
+	}
+
 	// Check if OTP matches
-	if verification.OTP != otp {
-		return fmt.Errorf("invalid OTP")
+	if subtle.ConstantTimeCompare([]byte(verification.OTP), []byte(otp)) != 1 {
+		if attempts >= domain.OTPMaxAttempts {
+			return domain.ErrOTPLocked
+		}
+		return fmt.Errorf("invalid OTP, %d attempts remaining", domain.OTPMaxAttempts-attempts)
 	}
 
 	// Mark verification as verified
@@ -125,7 +212,9 @@ func (s *EmailVerificationService) VerifyOTP(ctx context.Context, email, otp str
 	return nil
 }
 
-// ResendOTP creates a new OTP and sends it
+// ResendOTP creates a new OTP and sends it. Resends are held back for
+// domain.OTPResendCooldown after a delivered code, and only briefly after a
+// failed delivery.
 func (s *EmailVerificationService) ResendOTP(ctx context.Context, email string) error {
 	// Get user by email
 	user, err := s.userRepo.GetByEmail(ctx, email)
@@ -138,6 +227,12 @@ func (s *EmailVerificationService) ResendOTP(ctx context.Context, email string)
 		return fmt.Errorf("email already verified")
 	}
 
+	if pending, err := s.verificationRepo.GetByUserID(ctx, user.ID); err == nil {
+		if wait := time.Until(pending.ResendAvailableAt()); wait > 0 {
+			return fmt.Errorf("%w, try again in %d seconds", domain.ErrOTPResendCooldown, int(wait.Seconds())+1)
+		}
+	}
+
 	// Create and send new OTP
 	return s.CreateAndSendOTP(ctx, user.ID, email, user.Username)
 }
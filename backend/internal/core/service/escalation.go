@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -12,24 +13,46 @@ import (
 	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
 )
 
+// EscalationTargetPreviewer expands escalation targets to the users they would
+// page at a given time
+type EscalationTargetPreviewer interface {
+	PreviewEscalationTargets(ctx context.Context, orgID uuid.UUID, targets []*domain.EscalationTarget, at time.Time) ([]*dto.EscalationPreviewRecipient, error)
+}
+
 type EscalationService struct {
 	escalationRepo outbound.EscalationPolicyRepository
 	alertRepo      outbound.AlertRepository
+	orgRepo        outbound.OrganizationRepository
+	teamRepo       outbound.TeamRepository
+	scheduleRepo   outbound.ScheduleRepository
 	notifier       outbound.AlertNotificationSender
+	previewer      EscalationTargetPreviewer
 }
 
 func NewEscalationService(
 	escalationRepo outbound.EscalationPolicyRepository,
 	alertRepo outbound.AlertRepository,
+	orgRepo outbound.OrganizationRepository,
+	teamRepo outbound.TeamRepository,
+	scheduleRepo outbound.ScheduleRepository,
 	notifier outbound.AlertNotificationSender,
 ) *EscalationService {
 	return &EscalationService{
 		escalationRepo: escalationRepo,
 		alertRepo:      alertRepo,
+		orgRepo:        orgRepo,
+		teamRepo:       teamRepo,
+		scheduleRepo:   scheduleRepo,
 		notifier:       notifier,
 	}
 }
 
+// SetTargetPreviewer sets the target previewer (optional dependency).
+// Without it policies cannot be previewed.
+func (s *EscalationService) SetTargetPreviewer(previewer EscalationTargetPreviewer) {
+	s.previewer = previewer
+}
+
 // Policy CRUD
 
 func (s *EscalationService) CreatePolicy(ctx context.Context, orgID uuid.UUID, req *dto.CreateEscalationPolicyRequest) (*domain.EscalationPolicy, error) {
@@ -40,6 +63,15 @@ func (s *EscalationService) CreatePolicy(ctx context.Context, orgID uuid.UUID, r
 		Description:    req.Description,
 		RepeatEnabled:  req.RepeatEnabled,
 		RepeatCount:    req.RepeatCount,
+		Conditions:     req.Conditions,
+		BusinessHours:  req.BusinessHours,
+	}
+
+	if err := validatePolicyConditions(policy); err != nil {
+		return nil, err
+	}
+	if err := s.normalizeBusinessHours(ctx, policy); err != nil {
+		return nil, err
 	}
 
 	if err := s.escalationRepo.Create(ctx, policy); err != nil {
@@ -85,6 +117,19 @@ func (s *EscalationService) UpdatePolicy(ctx context.Context, id uuid.UUID, req
 	if req.RepeatCount != nil {
 		policy.RepeatCount = req.RepeatCount
 	}
+	if req.Conditions != nil {
+		policy.Conditions = req.Conditions
+	}
+	if req.BusinessHours != nil {
+		policy.BusinessHours = req.BusinessHours
+	}
+
+	if err := validatePolicyConditions(policy); err != nil {
+		return nil, err
+	}
+	if err := s.normalizeBusinessHours(ctx, policy); err != nil {
+		return nil, err
+	}
 
 	if err := s.escalationRepo.Update(ctx, policy); err != nil {
 		return nil, fmt.Errorf("failed to update escalation policy: %w", err)
@@ -123,14 +168,62 @@ func (s *EscalationService) ListPolicies(ctx context.Context, orgID uuid.UUID, p
 	return policies, nil
 }
 
+func validatePolicyConditions(policy *domain.EscalationPolicy) error {
+	if _, err := policy.ParseConditions(); err != nil {
+		return fmt.Errorf("%w: invalid conditions format: %v", domain.ErrInvalidEscalationPolicy, err)
+	}
+	return nil
+}
+
+// normalizeBusinessHours validates the policy's business hours, giving them
+// the organization's default timezone when they don't name one
+func (s *EscalationService) normalizeBusinessHours(ctx context.Context, policy *domain.EscalationPolicy) error {
+	hours, err := policy.ParseBusinessHours()
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidBusinessHours, err)
+	}
+	if hours == nil {
+		policy.BusinessHours = nil
+		return nil
+	}
+
+	if hours.Timezone == "" {
+		hours.Timezone = domain.FallbackTimezone
+		if s.orgRepo != nil {
+			if org, err := s.orgRepo.GetByID(ctx, policy.OrganizationID); err == nil {
+				hours.Timezone = org.DefaultTimezone()
+			}
+		}
+	}
+	if err := hours.Validate(); err != nil {
+		return err
+	}
+
+	policy.BusinessHours, err = json.Marshal(hours)
+	return err
+}
+
 // Rule CRUD
 
 func (s *EscalationService) CreateRule(ctx context.Context, policyID uuid.UUID, req *dto.CreateEscalationRuleRequest) (*domain.EscalationRule, error) {
+	hours, err := domain.ParseEscalationRuleHours(req.Hours)
+	if err != nil {
+		return nil, err
+	}
+	minPriority, err := domain.ParseEscalationRuleMinPriority(req.MinPriority)
+	if err != nil {
+		return nil, err
+	}
+
 	rule := &domain.EscalationRule{
-		ID:              uuid.New(),
-		PolicyID:        policyID,
-		Position:        req.Position,
-		EscalationDelay: req.EscalationDelay,
+		ID:               uuid.New(),
+		PolicyID:         policyID,
+		Position:         req.Position,
+		EscalationDelay:  req.EscalationDelay,
+		DelaySeconds:     req.DelaySeconds,
+		Hours:            hours,
+		MinPriority:      minPriority,
+		OnlyIfUnassigned: req.OnlyIfUnassigned,
 	}
 
 	if err := s.escalationRepo.CreateRule(ctx, rule); err != nil {
@@ -160,6 +253,24 @@ func (s *EscalationService) UpdateRule(ctx context.Context, id uuid.UUID, req *d
 	}
 	if req.EscalationDelay != nil {
 		rule.EscalationDelay = *req.EscalationDelay
+		// A delay in minutes replaces an earlier one in seconds
+		rule.DelaySeconds = nil
+	}
+	if req.DelaySeconds != nil {
+		rule.DelaySeconds = req.DelaySeconds
+	}
+	if req.Hours != nil {
+		if rule.Hours, err = domain.ParseEscalationRuleHours(*req.Hours); err != nil {
+			return nil, err
+		}
+	}
+	if req.MinPriority != nil {
+		if rule.MinPriority, err = domain.ParseEscalationRuleMinPriority(*req.MinPriority); err != nil {
+			return nil, err
+		}
+	}
+	if req.OnlyIfUnassigned != nil {
+		rule.OnlyIfUnassigned = *req.OnlyIfUnassigned
 	}
 
 	if err := s.escalationRepo.UpdateRule(ctx, rule); err != nil {
@@ -188,17 +299,38 @@ func (s *EscalationService) ListRules(ctx context.Context, policyID uuid.UUID) (
 
 // Target CRUD
 
-func (s *EscalationService) AddTarget(ctx context.Context, ruleID uuid.UUID, req *dto.AddEscalationTargetRequest) (*domain.EscalationTarget, error) {
+// AddTarget adds a target to a rule of one of the organization's policies. The
+// target must exist and belong to the same organization, otherwise the rule
+// would page nobody.
+func (s *EscalationService) AddTarget(ctx context.Context, orgID, ruleID uuid.UUID, req *dto.AddEscalationTargetRequest) (*domain.EscalationTarget, error) {
 	targetType := domain.EscalationTargetType(req.TargetType)
 	if err := targetType.Validate(); err != nil {
 		return nil, err
 	}
+	scheduleMode, err := domain.ParseScheduleTargetMode(targetType, req.ScheduleMode)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := s.escalationRepo.GetRule(ctx, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: no escalation rule %s", domain.ErrEscalationPolicyNotFound, ruleID)
+	}
+	policy, err := s.escalationRepo.GetByID(ctx, rule.PolicyID)
+	if err != nil || policy.OrganizationID != orgID {
+		return nil, domain.ErrEscalationPolicyNotFound
+	}
+
+	if err := s.validateTarget(ctx, orgID, targetType, req.TargetID); err != nil {
+		return nil, err
+	}
 
 	target := &domain.EscalationTarget{
 		ID:                   uuid.New(),
 		RuleID:               ruleID,
 		TargetType:           targetType,
 		TargetID:             req.TargetID,
+		ScheduleMode:         scheduleMode,
 		NotificationChannels: req.NotificationChannels,
 	}
 
@@ -209,6 +341,28 @@ func (s *EscalationService) AddTarget(ctx context.Context, ruleID uuid.UUID, req
 	return target, nil
 }
 
+// validateTarget checks that the user, team or schedule a target points at
+// exists in the organization
+func (s *EscalationService) validateTarget(ctx context.Context, orgID uuid.UUID, targetType domain.EscalationTargetType, targetID uuid.UUID) error {
+	found := false
+	switch targetType {
+	case domain.EscalationTargetTypeUser:
+		_, err := s.orgRepo.GetUserRole(ctx, orgID, targetID)
+		found = err == nil
+	case domain.EscalationTargetTypeTeam:
+		team, err := s.teamRepo.GetByID(ctx, targetID)
+		found = err == nil && team.OrganizationID == orgID
+	case domain.EscalationTargetTypeSchedule:
+		schedule, err := s.scheduleRepo.GetByID(ctx, targetID)
+		found = err == nil && schedule.OrganizationID == orgID
+	}
+
+	if !found {
+		return fmt.Errorf("%w: %s %s", domain.ErrEscalationTargetNotFound, targetType, targetID)
+	}
+	return nil
+}
+
 func (s *EscalationService) RemoveTarget(ctx context.Context, id uuid.UUID) error {
 	if err := s.escalationRepo.RemoveTarget(ctx, id); err != nil {
 		return fmt.Errorf("failed to remove escalation target: %w", err)
@@ -226,6 +380,212 @@ func (s *EscalationService) ListTargets(ctx context.Context, ruleID uuid.UUID) (
 	return targets, nil
 }
 
+// Declarative config
+
+// ApplyPolicyConfig reconciles a policy, its rules and their targets with the
+// desired state in req. Everything is applied in a single transaction.
+func (s *EscalationService) ApplyPolicyConfig(ctx context.Context, orgID, id uuid.UUID, req *dto.EscalationPolicyConfigRequest) (*domain.EscalationPolicyWithRules, error) {
+	policy, err := s.escalationRepo.GetByID(ctx, id)
+	if err != nil || policy.OrganizationID != orgID {
+		return nil, fmt.Errorf("escalation policy not found")
+	}
+
+	existing, err := s.escalationRepo.ListRules(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list escalation rules: %w", err)
+	}
+	existingByPosition := make(map[int]*domain.EscalationRule, len(existing))
+	for _, rule := range existing {
+		existingByPosition[rule.Position] = rule
+	}
+
+	policy.Name = req.Name
+	policy.Description = req.Description
+	policy.RepeatEnabled = req.RepeatEnabled
+	policy.RepeatCount = req.RepeatCount
+	policy.Conditions = req.Conditions
+	policy.BusinessHours = req.BusinessHours
+	if err := validatePolicyConditions(policy); err != nil {
+		return nil, err
+	}
+	if err := s.normalizeBusinessHours(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	config := &domain.EscalationPolicyWithRules{EscalationPolicy: *policy}
+	positions := make(map[int]bool, len(req.Rules))
+	for _, desired := range req.Rules {
+		if positions[desired.Position] {
+			return nil, fmt.Errorf("escalation rule position %d is listed more than once", desired.Position)
+		}
+		positions[desired.Position] = true
+
+		hours, err := domain.ParseEscalationRuleHours(desired.Hours)
+		if err != nil {
+			return nil, fmt.Errorf("escalation rule at position %d: %w", desired.Position, err)
+		}
+		minPriority, err := domain.ParseEscalationRuleMinPriority(desired.MinPriority)
+		if err != nil {
+			return nil, fmt.Errorf("escalation rule at position %d: %w", desired.Position, err)
+		}
+
+		rule := &domain.EscalationRuleWithTargets{
+			EscalationRule: domain.EscalationRule{
+				ID:               uuid.New(),
+				PolicyID:         id,
+				Position:         desired.Position,
+				EscalationDelay:  desired.EscalationDelay,
+				DelaySeconds:     desired.DelaySeconds,
+				Hours:            hours,
+				MinPriority:      minPriority,
+				OnlyIfUnassigned: desired.OnlyIfUnassigned,
+			},
+		}
+		// Keep the identity of existing rules so pending escalation events stay attached
+		if current, ok := existingByPosition[desired.Position]; ok {
+			rule.ID = current.ID
+		}
+
+		for _, t := range desired.Targets {
+			targetType := domain.EscalationTargetType(t.TargetType)
+			if err := targetType.Validate(); err != nil {
+				return nil, fmt.Errorf("escalation rule at position %d: %w", desired.Position, err)
+			}
+			scheduleMode, err := domain.ParseScheduleTargetMode(targetType, t.ScheduleMode)
+			if err != nil {
+				return nil, fmt.Errorf("escalation rule at position %d: %w", desired.Position, err)
+			}
+			if err := s.validateTarget(ctx, orgID, targetType, t.TargetID); err != nil {
+				return nil, fmt.Errorf("escalation rule at position %d: %w", desired.Position, err)
+			}
+			rule.Targets = append(rule.Targets, &domain.EscalationTarget{
+				ID:                   uuid.New(),
+				RuleID:               rule.ID,
+				TargetType:           targetType,
+				TargetID:             t.TargetID,
+				ScheduleMode:         scheduleMode,
+				NotificationChannels: t.NotificationChannels,
+			})
+		}
+
+		config.Rules = append(config.Rules, rule)
+	}
+
+	if err := s.escalationRepo.ApplyConfig(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to apply escalation policy config: %w", err)
+	}
+
+	return config, nil
+}
+
+// ClonePolicy copies a policy with its rules and targets into new rows of the
+// same organization. Targets keep pointing at the same users, teams and schedules.
+func (s *EscalationService) ClonePolicy(ctx context.Context, orgID, id uuid.UUID, req *dto.CloneEscalationPolicyRequest) (*domain.EscalationPolicyWithRules, error) {
+	source, err := s.escalationRepo.GetWithRules(ctx, id)
+	if err != nil || source.OrganizationID != orgID {
+		return nil, domain.ErrEscalationPolicyNotFound
+	}
+
+	name := source.Name + " (copy)"
+	if req.Name != nil {
+		name = *req.Name
+	}
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", domain.ErrInvalidEscalationPolicy)
+	}
+
+	clone := &domain.EscalationPolicyWithRules{
+		EscalationPolicy: domain.EscalationPolicy{
+			ID:             uuid.New(),
+			OrganizationID: orgID,
+			Name:           name,
+			Description:    source.Description,
+			RepeatEnabled:  source.RepeatEnabled,
+			RepeatCount:    source.RepeatCount,
+			Conditions:     source.Conditions,
+			BusinessHours:  source.BusinessHours,
+		},
+	}
+	for _, sourceRule := range source.Rules {
+		rule := &domain.EscalationRuleWithTargets{
+			EscalationRule: domain.EscalationRule{
+				ID:               uuid.New(),
+				PolicyID:         clone.ID,
+				Position:         sourceRule.Position,
+				EscalationDelay:  sourceRule.EscalationDelay,
+				DelaySeconds:     sourceRule.DelaySeconds,
+				Hours:            sourceRule.Hours,
+				MinPriority:      sourceRule.MinPriority,
+				OnlyIfUnassigned: sourceRule.OnlyIfUnassigned,
+			},
+		}
+		for _, sourceTarget := range sourceRule.Targets {
+			rule.Targets = append(rule.Targets, &domain.EscalationTarget{
+				ID:                   uuid.New(),
+				RuleID:               rule.ID,
+				TargetType:           sourceTarget.TargetType,
+				TargetID:             sourceTarget.TargetID,
+				ScheduleMode:         sourceTarget.ScheduleMode,
+				NotificationChannels: sourceTarget.NotificationChannels,
+			})
+		}
+		clone.Rules = append(clone.Rules, rule)
+	}
+
+	if err := s.escalationRepo.CreateWithRules(ctx, clone); err != nil {
+		return nil, fmt.Errorf("failed to clone escalation policy: %w", err)
+	}
+
+	return clone, nil
+}
+
+// PreviewPolicy expands every rule of the policy to the users its targets
+// would page for an alert escalating from at, and when they would be paged
+func (s *EscalationService) PreviewPolicy(ctx context.Context, orgID, id uuid.UUID, at time.Time) (*dto.EscalationPolicyPreview, error) {
+	policy, err := s.escalationRepo.GetWithRules(ctx, id)
+	if err != nil || policy.OrganizationID != orgID {
+		return nil, domain.ErrEscalationPolicyNotFound
+	}
+	if s.previewer == nil {
+		return nil, fmt.Errorf("escalation previews are not configured")
+	}
+
+	preview := &dto.EscalationPolicyPreview{
+		PolicyID:      policy.ID,
+		At:            at,
+		RepeatEnabled: policy.RepeatEnabled,
+		RepeatCount:   policy.RepeatCount,
+		Rules:         make([]*dto.EscalationRulePreview, 0, len(policy.Rules)),
+	}
+	if hours, err := policy.ParseBusinessHours(); err == nil && hours != nil {
+		inBusinessHours := hours.Contains(at)
+		preview.InBusinessHours = &inBusinessHours
+	}
+	offsets := policy.PageOffsets(at)
+	for i, rule := range policy.Rules {
+		recipients, err := s.previewer.PreviewEscalationTargets(ctx, orgID, rule.Targets, at)
+		if err != nil {
+			return nil, fmt.Errorf("failed to preview targets: %w", err)
+		}
+
+		rulePreview := &dto.EscalationRulePreview{
+			RuleID:       rule.ID,
+			Position:     rule.Position,
+			Hours:        string(rule.Hours),
+			Applies:      policy.AppliesAt(&rule.EscalationRule, at),
+			DelaySeconds: int(rule.Delay() / time.Second),
+			Recipients:   recipients,
+		}
+		if offsets[i] != nil {
+			seconds := int(*offsets[i] / time.Second)
+			rulePreview.PageAfterSeconds = &seconds
+		}
+		preview.Rules = append(preview.Rules, rulePreview)
+	}
+
+	return preview, nil
+}
+
 // Escalation logic
 
 func (s *EscalationService) StartEscalation(ctx context.Context, alertID, orgID uuid.UUID) error {
@@ -245,23 +605,10 @@ func (s *EscalationService) StartEscalation(ctx context.Context, alertID, orgID
 		return fmt.Errorf("failed to get escalation policy: %w", err)
 	}
 
-	if len(policy.Rules) == 0 {
-		return nil // No rules to escalate
-	}
-
 	// Create initial escalation event
-	firstRule := policy.Rules[0]
-	nextEscalationTime := time.Now().Add(time.Duration(firstRule.EscalationDelay) * time.Minute)
-
-	event := &domain.AlertEscalationEvent{
-		ID:               uuid.New(),
-		AlertID:          alertID,
-		PolicyID:         policy.ID,
-		RuleID:           &firstRule.ID,
-		EventType:        domain.EscalationEventTriggered,
-		CurrentLevel:     0,
-		RepeatCount:      0,
-		NextEscalationAt: &nextEscalationTime,
+	event := domain.NewEscalationEvent(alertID, policy, time.Now())
+	if event == nil {
+		return nil // No rules to escalate
 	}
 
 	if err := s.escalationRepo.CreateEvent(ctx, event); err != nil {
@@ -271,11 +618,11 @@ func (s *EscalationService) StartEscalation(ctx context.Context, alertID, orgID
 	return nil
 }
 
-func (s *EscalationService) ProcessPendingEscalations(ctx context.Context) error {
-	// Get all escalations that should be triggered now
-	events, err := s.escalationRepo.ListPendingEscalations(ctx, time.Now())
+func (s *EscalationService) ProcessPendingEscalations(ctx context.Context, limit int) error {
+	// Claim escalations that should be triggered now, oldest first
+	events, err := s.escalationRepo.ClaimPendingEscalations(ctx, time.Now(), limit)
 	if err != nil {
-		return fmt.Errorf("failed to list pending escalations: %w", err)
+		return fmt.Errorf("failed to claim pending escalations: %w", err)
 	}
 
 	for _, event := range events {
@@ -295,13 +642,36 @@ func (s *EscalationService) processEscalation(ctx context.Context, event *domain
 		return fmt.Errorf("failed to get policy: %w", err)
 	}
 
-	// Check if there are more rules to escalate to
-	nextLevel := event.CurrentLevel + 1
+	// The alert may have been handled after the event was claimed
+	alert, err := s.alertRepo.GetByID(ctx, event.AlertID, policy.OrganizationID)
+	if err != nil {
+		return fmt.Errorf("failed to get alert: %w", err)
+	}
+	if halted, eventType := escalationHaltedBy(alert.Status); halted {
+		event.EventType = eventType
+		event.NextEscalationAt = nil
+		if err := s.escalationRepo.UpdateEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to update event: %w", err)
+		}
+		return nil
+	}
+	if alert.Status != domain.AlertStatusOpen {
+		// Snoozed; the claim lease expires and escalation resumes once it reopens
+		return nil
+	}
+
+	// Check if there are more rules to escalate to. Rules limited to business
+	// or after hours are skipped when they don't apply right now, and rules
+	// whose conditions the alert no longer meets, say because it was
+	// downgraded or assigned, are skipped too.
+	now := time.Now()
+	nextLevel := policy.NextRuleFor(alert, event.CurrentLevel, now)
+	firstLevel := policy.NextRuleFor(alert, -1, now)
 
-	if nextLevel < len(policy.Rules) {
+	if nextLevel >= 0 {
 		// Move to next rule
 		nextRule := policy.Rules[nextLevel]
-		nextEscalationTime := time.Now().Add(time.Duration(nextRule.EscalationDelay) * time.Minute)
+		nextEscalationTime := now.Add(nextRule.Delay())
 
 		event.CurrentLevel = nextLevel
 		event.RuleID = &nextRule.ID
@@ -316,14 +686,14 @@ func (s *EscalationService) processEscalation(ctx context.Context, event *domain
 			fmt.Printf("Failed to send escalation notifications for alert %s: %v\n", event.AlertID, err)
 		}
 
-	} else if policy.RepeatEnabled {
+	} else if policy.RepeatEnabled && firstLevel >= 0 {
 		// Check if we should repeat
 		if policy.RepeatCount == nil || event.RepeatCount < *policy.RepeatCount {
 			// Restart from first rule
-			firstRule := policy.Rules[0]
-			nextEscalationTime := time.Now().Add(time.Duration(firstRule.EscalationDelay) * time.Minute)
+			firstRule := policy.Rules[firstLevel]
+			nextEscalationTime := now.Add(firstRule.Delay())
 
-			event.CurrentLevel = 0
+			event.CurrentLevel = firstLevel
 			event.RuleID = &firstRule.ID
 			event.RepeatCount++
 			event.NextEscalationAt = &nextEscalationTime
@@ -346,7 +716,7 @@ func (s *EscalationService) processEscalation(ctx context.Context, event *domain
 			}
 		}
 	} else {
-		// No more rules and no repeat, mark as completed
+		// No more rules that apply and no repeat, mark as completed
 		event.EventType = domain.EscalationEventCompleted
 		event.NextEscalationAt = nil
 
@@ -358,6 +728,18 @@ func (s *EscalationService) processEscalation(ctx context.Context, event *domain
 	return nil
 }
 
+// escalationHaltedBy reports whether an alert in the given status should no
+// longer escalate, and the event type to record.
+func escalationHaltedBy(status domain.AlertStatus) (bool, domain.EscalationEventType) {
+	switch status {
+	case domain.AlertStatusAcknowledged:
+		return true, domain.EscalationEventAcknowledged
+	case domain.AlertStatusClosed:
+		return true, domain.EscalationEventStopped
+	}
+	return false, ""
+}
+
 func (s *EscalationService) sendEscalationNotifications(ctx context.Context, event *domain.AlertEscalationEvent, rule *domain.EscalationRuleWithTargets, orgID uuid.UUID) error {
 	// Only send notifications if notifier is configured
 	if s.notifier == nil {
@@ -395,6 +777,16 @@ func (s *EscalationService) sendEscalationNotifications(ctx context.Context, eve
 		targetValues[i] = *t
 	}
 
+	// A step processed again, after its claim lapsed mid-way or by a worker
+	// racing a restart, must not page the same targets twice
+	first, err := s.escalationRepo.RecordNotifiedStep(ctx, event)
+	if err != nil {
+		return err
+	}
+	if !first {
+		return nil
+	}
+
 	// Send notifications to all targets
 	if err := s.notifier.NotifyAlertEscalated(ctx, alert, &rule.EscalationRule, targetValues); err != nil {
 		return fmt.Errorf("failed to send notifications: %w", err)
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
+)
+
+// handoffLookback bounds how long after a shift starts its handoff is still
+// announced, so a fresh deployment does not notify every shift in progress.
+const handoffLookback = time.Hour
+
+// HandoffNotifier tells users when a rotation hands off to them
+type HandoffNotifier struct {
+	scheduleRepo        outbound.ScheduleRepository
+	userRepo            outbound.UserRepository
+	scheduleService     *ScheduleService
+	notificationService *NotificationService
+}
+
+func NewHandoffNotifier(
+	scheduleRepo outbound.ScheduleRepository,
+	userRepo outbound.UserRepository,
+	scheduleService *ScheduleService,
+	notificationService *NotificationService,
+) *HandoffNotifier {
+	return &HandoffNotifier{
+		scheduleRepo:        scheduleRepo,
+		userRepo:            userRepo,
+		scheduleService:     scheduleService,
+		notificationService: notificationService,
+	}
+}
+
+// ProcessHandoffs notifies the incoming on-call user of every rotation whose
+// current shift started within the lookback window and has not been announced
+// yet. Rotations are read in pages of batchSize.
+func (n *HandoffNotifier) ProcessHandoffs(ctx context.Context, now time.Time, batchSize int) error {
+	var errs []error
+	for offset := 0; ; offset += batchSize {
+		rotations, err := n.scheduleRepo.ListAllRotations(ctx, batchSize, offset)
+		if err != nil {
+			return fmt.Errorf("failed to list rotations: %w", err)
+		}
+
+		for _, rotation := range rotations {
+			if err := n.processRotation(ctx, rotation, now); err != nil {
+				errs = append(errs, fmt.Errorf("rotation %s: %w", rotation.ID, err))
+			}
+		}
+
+		if len(rotations) < batchSize {
+			break
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (n *HandoffNotifier) processRotation(ctx context.Context, rotation *domain.ScheduleRotation, now time.Time) error {
+	shiftStart, shiftEnd, ok := shiftBounds(rotation, now)
+	if !ok || now.Sub(shiftStart) > handoffLookback {
+		return nil
+	}
+
+	participants, err := n.scheduleRepo.ListParticipants(ctx, rotation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list participants: %w", err)
+	}
+
+	onCall, err := n.scheduleService.rotationOnCall(ctx, rotation, participants, now)
+	if err != nil {
+		return fmt.Errorf("failed to resolve on-call user: %w", err)
+	}
+	if onCall == nil {
+		return nil
+	}
+
+	claimed, err := n.scheduleRepo.ClaimHandoff(ctx, &domain.ScheduleHandoff{
+		RotationID: rotation.ID,
+		UserID:     onCall.UserID,
+		ShiftStart: shiftStart,
+		ShiftEnd:   shiftEnd,
+	})
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	schedule, err := n.scheduleRepo.GetByID(ctx, rotation.ScheduleID)
+	if err != nil {
+		return fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	return n.notify(ctx, schedule, rotation, onCall, shiftEnd)
+}
+
+// notify sends the handoff message through the user's preferred channel,
+// falling back to the organization's first enabled email channel.
+func (n *HandoffNotifier) notify(
+	ctx context.Context,
+	schedule *domain.Schedule,
+	rotation *domain.ScheduleRotation,
+	onCall *domain.OnCallUser,
+	shiftEnd time.Time,
+) error {
+	if n.notificationService == nil {
+		return nil // Notification service not configured
+	}
+
+	user, err := n.userRepo.GetByID(ctx, onCall.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	channel, err := n.notificationService.PreferredChannel(ctx, schedule.OrganizationID, user.ID)
+	if err != nil {
+		return err
+	}
+	if channel == nil {
+		return nil // No channel to notify through
+	}
+
+	subject := fmt.Sprintf("You are now on-call for %s", schedule.Name)
+	message := fmt.Sprintf(
+		"You are now on-call for %s (%s) until %s.",
+		schedule.Name,
+		rotation.Name,
+		shiftEnd.UTC().Format(time.RFC1123),
+	)
+
+	// Send notification (errors are logged in the notification service)
+	_, _ = n.notificationService.SendNotification(ctx, schedule.OrganizationID, &dto.SendNotificationRequest{
+		ChannelID: channel.ID,
+		UserID:    &user.ID,
+		Recipient: user.Email,
+		Subject:   &subject,
+		Message:   message,
+	})
+
+	return nil
+}
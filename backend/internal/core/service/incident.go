@@ -3,6 +3,10 @@ package service
 import (
 	"context"
 	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,13 +14,34 @@ import (
 	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/dto"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/prommetrics"
 )
 
+// AlertCloser closes alerts, announcing each closure like a manual close
+type AlertCloser interface {
+	CloseAlert(ctx context.Context, id, orgID, userID uuid.UUID, reason string) error
+}
+
 type IncidentService struct {
 	incidentRepo outbound.IncidentRepository
+	alertRepo    outbound.AlertRepository
+	orgRepo      outbound.OrganizationRepository
+	warRooms     outbound.WarRoomCreator
+	issues       outbound.IssueTracker
+	statuspage   outbound.StatuspagePublisher
+	dispatcher   outbound.WebhookDispatcher
 	broadcaster  outbound.EventBroadcaster
+	policyRepo   outbound.EscalationPolicyRepository
+	onCall       OnCallResolver
+	alertCloser  AlertCloser
+
+	integrations sync.WaitGroup
 }
 
+// incidentIntegrationTimeout bounds how long the integrations of a new
+// incident may take in the background
+const incidentIntegrationTimeout = 30 * time.Second
+
 func NewIncidentService(incidentRepo outbound.IncidentRepository, broadcaster outbound.EventBroadcaster) *IncidentService {
 	return &IncidentService{
 		incidentRepo: incidentRepo,
@@ -24,6 +49,62 @@ func NewIncidentService(incidentRepo outbound.IncidentRepository, broadcaster ou
 	}
 }
 
+// SetAlertRepo sets the alert repository (optional dependency).
+// Without it alert notes cannot be copied into the timeline when linking.
+func (s *IncidentService) SetAlertRepo(repo outbound.AlertRepository) {
+	s.alertRepo = repo
+}
+
+// SetOrganizationRepo sets the organization repository (optional dependency).
+// Without it organization integration settings are not read, so no
+// integrations run for new incidents.
+func (s *IncidentService) SetOrganizationRepo(repo outbound.OrganizationRepository) {
+	s.orgRepo = repo
+}
+
+// SetWarRoomCreator sets the war room creator (optional dependency).
+// Without it no Slack channels are opened for new incidents.
+func (s *IncidentService) SetWarRoomCreator(creator outbound.WarRoomCreator) {
+	s.warRooms = creator
+}
+
+// SetIssueTracker sets the issue tracker (optional dependency).
+// Without it no Jira issues are created for new incidents.
+func (s *IncidentService) SetIssueTracker(tracker outbound.IssueTracker) {
+	s.issues = tracker
+}
+
+// SetStatuspagePublisher sets the Statuspage publisher (optional dependency).
+// Without it incidents are not published to the organization's status page.
+func (s *IncidentService) SetStatuspagePublisher(publisher outbound.StatuspagePublisher) {
+	s.statuspage = publisher
+}
+
+// SetWebhookDispatcher sets the webhook dispatcher (optional dependency).
+// Without it incident events are not sent to outgoing webhooks.
+func (s *IncidentService) SetWebhookDispatcher(dispatcher outbound.WebhookDispatcher) {
+	s.dispatcher = dispatcher
+}
+
+// SetEscalationPolicyRepo sets the escalation policy repository (optional dependency).
+// Without it the schedules an alert escalates to cannot be looked up, so no
+// on-call responder is added to incidents created from it.
+func (s *IncidentService) SetEscalationPolicyRepo(repo outbound.EscalationPolicyRepository) {
+	s.policyRepo = repo
+}
+
+// SetOnCallResolver sets the on-call resolver (optional dependency).
+// Without it no on-call responder is added to incidents created from an alert.
+func (s *IncidentService) SetOnCallResolver(resolver OnCallResolver) {
+	s.onCall = resolver
+}
+
+// SetAlertCloser sets the alert closer (optional dependency).
+// Without it linked alerts stay open when an incident is resolved.
+func (s *IncidentService) SetAlertCloser(closer AlertCloser) {
+	s.alertCloser = closer
+}
+
 // Incident CRUD
 
 func (s *IncidentService) CreateIncident(ctx context.Context, orgID, userID uuid.UUID, req *dto.CreateIncidentRequest) (*domain.Incident, error) {
@@ -39,6 +120,17 @@ func (s *IncidentService) CreateIncident(ctx context.Context, orgID, userID uuid
 		return nil, fmt.Errorf("invalid priority: %s", req.Priority)
 	}
 
+	var alert *domain.Alert
+	if req.AlertID != nil {
+		if s.alertRepo == nil {
+			return nil, fmt.Errorf("alert lookups are not configured")
+		}
+		var err error
+		if alert, err = s.alertRepo.GetByID(ctx, *req.AlertID, orgID); err != nil {
+			return nil, fmt.Errorf("failed to get alert: %w", err)
+		}
+	}
+
 	incident := &domain.Incident{
 		ID:               uuid.New(),
 		OrganizationID:   orgID,
@@ -56,6 +148,8 @@ func (s *IncidentService) CreateIncident(ctx context.Context, orgID, userID uuid
 		return nil, fmt.Errorf("failed to create incident: %w", err)
 	}
 
+	prommetrics.IncidentsCreatedTotal.WithLabelValues(string(incident.Severity)).Inc()
+
 	// Add timeline event for creation
 	timelineEvent := &domain.IncidentTimelineEvent{
 		ID:          uuid.New(),
@@ -77,9 +171,243 @@ func (s *IncidentService) CreateIncident(ctx context.Context, orgID, userID uuid
 		s.broadcaster.BroadcastIncidentTimelineEvent(orgID, incident.ID, timelineEvent)
 	}
 
+	if alert != nil {
+		if _, err := s.LinkAlert(ctx, incident.ID, orgID, userID, &dto.LinkAlertRequest{AlertID: alert.ID}); err != nil {
+			fmt.Printf("Failed to link alert to new incident: %v\n", err)
+		}
+		s.addOnCallResponder(ctx, incident, alert)
+	}
+
+	// Integrations call third-party APIs, so they run after the response on
+	// a copy; what they open is stored on the incident as it completes
+	integrated := *incident
+	s.integrations.Add(1)
+	go func() {
+		defer s.integrations.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), incidentIntegrationTimeout)
+		defer cancel()
+		s.runIntegrations(ctx, &integrated)
+	}()
+
 	return incident, nil
 }
 
+// WaitForIntegrations blocks until the integrations of incidents created so
+// far have finished, or ctx is done
+func (s *IncidentService) WaitForIntegrations(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.integrations.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// addOnCallResponder adds whoever is on call for the first schedule the
+// alert's escalation policy pages, in rule order, as a responder when the
+// organization has enabled it. It is best effort like the integrations.
+func (s *IncidentService) addOnCallResponder(ctx context.Context, incident *domain.Incident, alert *domain.Alert) {
+	if s.orgRepo == nil || s.policyRepo == nil || s.onCall == nil || alert.EscalationPolicyID == nil {
+		return
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, incident.OrganizationID)
+	if err != nil {
+		fmt.Printf("Failed to load organization settings: %v\n", err)
+		return
+	}
+	if !org.IncidentAutoAssignOnCall() {
+		return
+	}
+
+	policy, err := s.policyRepo.GetWithRules(ctx, *alert.EscalationPolicyID)
+	if err != nil {
+		fmt.Printf("Failed to get escalation policy: %v\n", err)
+		return
+	}
+
+	onCall, schedule := s.firstOnCall(ctx, policy, time.Now())
+	if onCall == nil {
+		return
+	}
+
+	responder := &domain.IncidentResponder{
+		ID:         uuid.New(),
+		IncidentID: incident.ID,
+		UserID:     onCall.UserID,
+		Role:       domain.ResponderRoleResponder,
+	}
+	if err := s.incidentRepo.AddResponder(ctx, responder); err != nil {
+		fmt.Printf("Failed to add on-call responder: %v\n", err)
+		return
+	}
+
+	event := &domain.IncidentTimelineEvent{
+		ID:          uuid.New(),
+		IncidentID:  incident.ID,
+		EventType:   domain.TimelineEventResponderAdded,
+		Description: fmt.Sprintf("On-call responder for %s added automatically", schedule.Name),
+		Metadata: map[string]interface{}{
+			"responder_user_id": onCall.UserID.String(),
+			"role":              domain.ResponderRoleResponder,
+			"schedule_id":       schedule.ID.String(),
+			"alert_id":          alert.ID.String(),
+		},
+	}
+	if err := s.incidentRepo.AddTimelineEvent(ctx, event); err != nil {
+		fmt.Printf("Failed to add timeline event: %v\n", err)
+		return
+	}
+
+	if s.broadcaster != nil {
+		s.broadcaster.BroadcastIncidentTimelineEvent(incident.OrganizationID, incident.ID, event)
+	}
+}
+
+// firstOnCall returns who is on call at the given time for the first schedule
+// targeted by the policy, skipping schedules that have been deleted or have
+// no one on call
+func (s *IncidentService) firstOnCall(ctx context.Context, policy *domain.EscalationPolicyWithRules, at time.Time) (*domain.OnCallUser, *domain.Schedule) {
+	for _, rule := range policy.Rules {
+		for _, target := range rule.Targets {
+			if target.TargetType != domain.EscalationTargetTypeSchedule {
+				continue
+			}
+			schedule, err := s.onCall.GetSchedule(ctx, target.TargetID)
+			if err != nil {
+				continue
+			}
+			if onCall, err := s.onCall.GetOnCallUser(ctx, schedule.ID, at); err == nil && onCall != nil {
+				return onCall, schedule
+			}
+		}
+	}
+	return nil, nil
+}
+
+// runIntegrations runs the organization's outbound integrations for a new
+// incident. They are best effort: failures are logged and never fail incident
+// creation, which doesn't wait for them.
+func (s *IncidentService) runIntegrations(ctx context.Context, incident *domain.Incident) {
+	if s.orgRepo == nil || (s.warRooms == nil && s.issues == nil && s.statuspage == nil) {
+		return
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, incident.OrganizationID)
+	if err != nil {
+		fmt.Printf("Failed to load integration settings: %v\n", err)
+		return
+	}
+
+	s.openWarRoom(ctx, org, incident)
+	s.createIssue(ctx, org, incident)
+	s.openStatuspageIncident(ctx, org, incident)
+}
+
+// openWarRoom opens a Slack channel for the incident when the organization has
+// a war room configured and the severity reaches its threshold
+func (s *IncidentService) openWarRoom(ctx context.Context, org *domain.Organization, incident *domain.Incident) {
+	if s.warRooms == nil {
+		return
+	}
+	settings := org.SlackWarRoom()
+	if !settings.Enabled() || !incident.Severity.AtLeast(settings.MinSeverity) {
+		return
+	}
+
+	room, err := s.warRooms.CreateWarRoom(ctx, settings.BotToken, incident)
+	if err != nil {
+		fmt.Printf("Failed to open war room for incident %s: %v\n", incident.ID, err)
+	}
+	if room == nil {
+		return
+	}
+
+	if err := s.incidentRepo.SetWarRoom(ctx, incident.ID, incident.OrganizationID, room); err != nil {
+		fmt.Printf("Failed to store war room for incident %s: %v\n", incident.ID, err)
+		return
+	}
+	incident.SlackChannelID = &room.ChannelID
+	incident.SlackChannelURL = &room.URL
+}
+
+// createIssue creates a Jira issue for the incident when the organization has
+// Jira configured for its severity, and links the issue back on the incident
+func (s *IncidentService) createIssue(ctx context.Context, org *domain.Organization, incident *domain.Incident) {
+	if s.issues == nil {
+		return
+	}
+	settings := org.Jira()
+	if !settings.Enabled() || !settings.Applies(incident.Severity) {
+		return
+	}
+
+	link, err := s.issues.CreateIssue(ctx, settings, incident)
+	if err != nil {
+		fmt.Printf("Failed to create Jira issue for incident %s: %v\n", incident.ID, err)
+		return
+	}
+
+	if err := s.incidentRepo.SetIssueLink(ctx, incident.ID, incident.OrganizationID, link); err != nil {
+		fmt.Printf("Failed to store Jira issue %s for incident %s: %v\n", link.Key, incident.ID, err)
+		return
+	}
+	incident.JiraIssueKey = &link.Key
+	incident.JiraIssueURL = &link.URL
+}
+
+// openStatuspageIncident publishes the incident to the organization's status
+// page when its severity reaches the configured threshold
+func (s *IncidentService) openStatuspageIncident(ctx context.Context, org *domain.Organization, incident *domain.Incident) {
+	if s.statuspage == nil {
+		return
+	}
+	settings := org.Statuspage()
+	if !settings.Enabled() || !incident.Severity.AtLeast(settings.MinSeverity) {
+		return
+	}
+
+	statuspageID, err := s.statuspage.OpenIncident(ctx, settings, incident)
+	if err != nil {
+		fmt.Printf("Failed to publish incident %s to Statuspage: %v\n", incident.ID, err)
+		return
+	}
+
+	if err := s.incidentRepo.SetStatuspageIncident(ctx, incident.ID, incident.OrganizationID, statuspageID); err != nil {
+		fmt.Printf("Failed to store Statuspage incident for incident %s: %v\n", incident.ID, err)
+		return
+	}
+	incident.StatuspageIncidentID = &statuspageID
+}
+
+// syncStatuspage posts the incident's new status to its Statuspage incident.
+// It is best effort: failures are logged and never fail the update.
+func (s *IncidentService) syncStatuspage(ctx context.Context, incident *domain.Incident) {
+	if s.statuspage == nil || s.orgRepo == nil || incident.StatuspageIncidentID == nil {
+		return
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, incident.OrganizationID)
+	if err != nil {
+		fmt.Printf("Failed to load Statuspage settings: %v\n", err)
+		return
+	}
+	settings := org.Statuspage()
+	if !settings.Enabled() {
+		return
+	}
+
+	if err := s.statuspage.UpdateIncident(ctx, settings, *incident.StatuspageIncidentID, incident); err != nil {
+		fmt.Printf("Failed to update Statuspage incident for incident %s: %v\n", incident.ID, err)
+	}
+}
+
 func (s *IncidentService) GetIncident(ctx context.Context, id, orgID uuid.UUID) (*domain.Incident, error) {
 	incident, err := s.incidentRepo.GetByID(ctx, id, orgID)
 	if err != nil {
@@ -103,6 +431,11 @@ func (s *IncidentService) UpdateIncident(ctx context.Context, id, orgID, userID
 	if err != nil {
 		return nil, fmt.Errorf("failed to get incident: %w", err)
 	}
+	before := domain.IncidentSnapshot(incident)
+
+	resolved := false
+	statusChanged := false
+	var previousSeverity domain.IncidentSeverity
 
 	// Update fields if provided
 	if req.Title != nil {
@@ -123,6 +456,7 @@ func (s *IncidentService) UpdateIncident(ctx context.Context, id, orgID, userID
 
 		// Add timeline event for severity change
 		if oldSeverity != severity {
+			previousSeverity = oldSeverity
 			timelineEvent := &domain.IncidentTimelineEvent{
 				ID:          uuid.New(),
 				IncidentID:  incident.ID,
@@ -150,6 +484,7 @@ func (s *IncidentService) UpdateIncident(ctx context.Context, id, orgID, userID
 		if status == domain.IncidentStatusResolved && oldStatus != domain.IncidentStatusResolved {
 			now := time.Now()
 			incident.ResolvedAt = &now
+			resolved = true
 
 			// Add timeline event for resolution
 			timelineEvent := &domain.IncidentTimelineEvent{
@@ -165,6 +500,7 @@ func (s *IncidentService) UpdateIncident(ctx context.Context, id, orgID, userID
 
 		// Add timeline event for status change
 		if oldStatus != status {
+			statusChanged = true
 			timelineEvent := &domain.IncidentTimelineEvent{
 				ID:          uuid.New(),
 				IncidentID:  incident.ID,
@@ -196,14 +532,91 @@ func (s *IncidentService) UpdateIncident(ctx context.Context, id, orgID, userID
 		return nil, fmt.Errorf("failed to update incident: %w", err)
 	}
 
+	if resolved {
+		prommetrics.IncidentsResolvedTotal.Inc()
+		if s.shouldCloseLinkedAlerts(ctx, incident, req.CloseLinkedAlerts) {
+			s.closeLinkedAlerts(ctx, incident, userID)
+		}
+	}
+
 	// Broadcast WebSocket event
 	if s.broadcaster != nil {
 		s.broadcaster.BroadcastIncidentEvent(domain.WSEventIncidentUpdated, incident.OrganizationID, incident)
 	}
 
+	if s.dispatcher != nil {
+		after := domain.IncidentSnapshot(incident)
+		s.dispatcher.TriggerWebhooks(ctx, incident.OrganizationID, domain.WebhookEventIncidentUpdated, map[string]interface{}{
+			"incident_id": incident.ID.String(),
+			"title":       incident.Title,
+			"status":      string(incident.Status),
+			"severity":    string(incident.Severity),
+			"updated_at":  incident.UpdatedAt,
+			"incident":    after,
+			"changes":     domain.WebhookChanges(before, after),
+		})
+	}
+
+	if previousSeverity != "" && s.dispatcher != nil {
+		s.dispatcher.TriggerWebhooks(ctx, incident.OrganizationID, domain.WebhookEventIncidentSeverityChanged, map[string]interface{}{
+			"incident_id":  incident.ID.String(),
+			"title":        incident.Title,
+			"status":       string(incident.Status),
+			"old_severity": string(previousSeverity),
+			"new_severity": string(incident.Severity),
+			"updated_at":   incident.UpdatedAt,
+		})
+	}
+
+	if statusChanged {
+		s.syncStatuspage(ctx, incident)
+	}
+
 	return incident, nil
 }
 
+// shouldCloseLinkedAlerts reports whether resolving the incident closes its
+// linked alerts: as requested, or else as the organization's setting says
+func (s *IncidentService) shouldCloseLinkedAlerts(ctx context.Context, incident *domain.Incident, requested *bool) bool {
+	if requested != nil {
+		return *requested
+	}
+	if s.orgRepo == nil {
+		return false
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, incident.OrganizationID)
+	if err != nil {
+		fmt.Printf("Failed to load organization settings: %v\n", err)
+		return false
+	}
+	return org.IncidentCloseLinkedAlerts()
+}
+
+// closeLinkedAlerts closes the incident's linked alerts that are still open,
+// acknowledged or snoozed on behalf of the user resolving it
+func (s *IncidentService) closeLinkedAlerts(ctx context.Context, incident *domain.Incident, userID uuid.UUID) {
+	if s.alertCloser == nil {
+		return
+	}
+
+	links, err := s.incidentRepo.ListAlerts(ctx, incident.ID, incident.OrganizationID)
+	if err != nil {
+		fmt.Printf("Failed to list linked alerts: %v\n", err)
+		return
+	}
+
+	reason := fmt.Sprintf("incident %q resolved", incident.Title)
+	for _, link := range links {
+		if link.Alert.Status == domain.AlertStatusClosed {
+			continue
+		}
+		if err := s.alertCloser.CloseAlert(ctx, link.AlertID, incident.OrganizationID, userID, reason); err != nil {
+			fmt.Printf("Failed to close linked alert %s: %v\n", link.AlertID, err)
+		}
+	}
+}
+
 func (s *IncidentService) DeleteIncident(ctx context.Context, id, orgID uuid.UUID) error {
 	if err := s.incidentRepo.Delete(ctx, id, orgID); err != nil {
 		return fmt.Errorf("failed to delete incident: %w", err)
@@ -213,6 +626,40 @@ func (s *IncidentService) DeleteIncident(ctx context.Context, id, orgID uuid.UUI
 }
 
 func (s *IncidentService) ListIncidents(ctx context.Context, orgID uuid.UUID, req *dto.ListIncidentsRequest) (*dto.ListIncidentsResponse, error) {
+	// Set defaults
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	filter := incidentFilter(orgID, req)
+	filter.Limit = pageSize
+	filter.Offset = (page - 1) * pageSize
+
+	incidents, total, err := s.incidentRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
+	}
+
+	return &dto.ListIncidentsResponse{
+		Incidents: incidents,
+		Total:     total,
+		Page:      page,
+		PageSize:  pageSize,
+	}, nil
+}
+
+// incidentFilter builds the repository filter for a list request, ignoring
+// unknown statuses and severities. Paging is left to the caller.
+func incidentFilter(orgID uuid.UUID, req *dto.ListIncidentsRequest) *domain.IncidentFilter {
 	// Parse status filters
 	var statuses []domain.IncidentStatus
 	for _, statusStr := range req.Status {
@@ -231,52 +678,63 @@ func (s *IncidentService) ListIncidents(ctx context.Context, orgID uuid.UUID, re
 		}
 	}
 
-	// Set defaults
-	page := req.Page
-	if page < 1 {
-		page = 1
-	}
-
-	pageSize := req.PageSize
-	if pageSize < 1 {
-		pageSize = 20
-	}
-	if pageSize > 100 {
-		pageSize = 100
-	}
-
-	offset := (page - 1) * pageSize
-
-	filter := &domain.IncidentFilter{
+	return &domain.IncidentFilter{
 		OrganizationID:   orgID,
 		Status:           statuses,
 		Severity:         severities,
 		AssignedToTeamID: req.AssignedToTeamID,
 		Search:           req.Search,
-		Limit:            pageSize,
-		Offset:           offset,
 	}
+}
 
-	incidents, total, err := s.incidentRepo.List(ctx, filter)
+// incidentCSVHeader is the column layout of ExportIncidentsCSV
+var incidentCSVHeader = []string{
+	"id", "title", "severity", "priority", "status",
+	"started_at", "resolved_at", "created_at", "duration_seconds",
+}
+
+// ExportIncidentsCSV writes the incidents matching the list filters to w as
+// CSV, newest first. Paging fields of req are ignored; every match is exported.
+func (s *IncidentService) ExportIncidentsCSV(ctx context.Context, orgID uuid.UUID, req *dto.ListIncidentsRequest, w io.Writer) error {
+	filter := incidentFilter(orgID, req)
+
+	err := writeCSVPages(ctx, w, incidentCSVHeader, func(limit int, after *domain.ListCursor) ([]*domain.Incident, error) {
+		filter.Limit = limit
+		filter.Offset = 0
+		filter.After = after
+		incidents, _, err := s.incidentRepo.List(ctx, filter)
+		return incidents, err
+	}, func(incident *domain.Incident) domain.ListCursor {
+		return domain.ListCursor{CreatedAt: incident.CreatedAt, ID: incident.ID}
+	}, func(incident *domain.Incident) []string {
+		return []string{
+			incident.ID.String(),
+			csvText(incident.Title),
+			string(incident.Severity),
+			string(incident.Priority),
+			string(incident.Status),
+			csvTime(&incident.StartedAt),
+			csvTime(incident.ResolvedAt),
+			csvTime(&incident.CreatedAt),
+			csvDuration(incident.StartedAt, incident.ResolvedAt),
+		}
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list incidents: %w", err)
+		return fmt.Errorf("failed to export incidents: %w", err)
 	}
-
-	return &dto.ListIncidentsResponse{
-		Incidents: incidents,
-		Total:     total,
-		Page:      page,
-		PageSize:  pageSize,
-	}, nil
+	return nil
 }
 
 // Responder management
 
-func (s *IncidentService) AddResponder(ctx context.Context, incidentID uuid.UUID, userID uuid.UUID, req *dto.AddResponderRequest) (*domain.IncidentResponder, error) {
+func (s *IncidentService) AddResponder(ctx context.Context, incidentID, orgID, userID uuid.UUID, req *dto.AddResponderRequest) (*domain.IncidentResponder, error) {
 	role := domain.ResponderRole(req.Role)
 	if !role.IsValid() {
 		return nil, fmt.Errorf("invalid responder role: %s", req.Role)
 	}
+	if err := s.checkIncident(ctx, incidentID, orgID); err != nil {
+		return nil, err
+	}
 
 	responder := &domain.IncidentResponder{
 		ID:         uuid.New(),
@@ -285,7 +743,14 @@ func (s *IncidentService) AddResponder(ctx context.Context, incidentID uuid.UUID
 		Role:       role,
 	}
 
-	if err := s.incidentRepo.AddResponder(ctx, responder); err != nil {
+	// An incident has a single commander; assigning a new one hands off from the current one
+	var demoted []uuid.UUID
+	if role == domain.ResponderRoleIncidentCommander {
+		var err error
+		if demoted, err = s.incidentRepo.AddCommander(ctx, responder); err != nil {
+			return nil, fmt.Errorf("failed to add responder: %w", err)
+		}
+	} else if err := s.incidentRepo.AddResponder(ctx, responder); err != nil {
 		return nil, fmt.Errorf("failed to add responder: %w", err)
 	}
 
@@ -305,6 +770,7 @@ func (s *IncidentService) AddResponder(ctx context.Context, incidentID uuid.UUID
 	if err := s.incidentRepo.AddTimelineEvent(ctx, timelineEvent); err != nil {
 		fmt.Printf("Failed to add timeline event: %v\n", err)
 	}
+	s.recordCommanderHandoff(ctx, incidentID, userID, req.UserID, demoted)
 
 	return responder, nil
 }
@@ -333,12 +799,21 @@ func (s *IncidentService) RemoveResponder(ctx context.Context, incidentID, orgID
 	return nil
 }
 
-func (s *IncidentService) UpdateResponderRole(ctx context.Context, incidentID, orgID, responderUserID uuid.UUID, req *dto.UpdateResponderRoleRequest) error {
+func (s *IncidentService) UpdateResponderRole(ctx context.Context, incidentID, orgID, responderUserID, actionUserID uuid.UUID, req *dto.UpdateResponderRoleRequest) error {
 	role := domain.ResponderRole(req.Role)
 	if !role.IsValid() {
 		return fmt.Errorf("invalid responder role: %s", req.Role)
 	}
 
+	if role == domain.ResponderRoleIncidentCommander {
+		demoted, err := s.incidentRepo.PromoteToCommander(ctx, incidentID, orgID, responderUserID)
+		if err != nil {
+			return fmt.Errorf("failed to update responder role: %w", err)
+		}
+		s.recordCommanderHandoff(ctx, incidentID, actionUserID, responderUserID, demoted)
+		return nil
+	}
+
 	if err := s.incidentRepo.UpdateResponderRole(ctx, incidentID, orgID, responderUserID, role); err != nil {
 		return fmt.Errorf("failed to update responder role: %w", err)
 	}
@@ -346,7 +821,33 @@ func (s *IncidentService) UpdateResponderRole(ctx context.Context, incidentID, o
 	return nil
 }
 
+// recordCommanderHandoff adds a timeline event for each previous commander
+// demoted in favour of the new one
+func (s *IncidentService) recordCommanderHandoff(ctx context.Context, incidentID, actionUserID, commanderUserID uuid.UUID, demoted []uuid.UUID) {
+	for _, previous := range demoted {
+		timelineEvent := &domain.IncidentTimelineEvent{
+			ID:          uuid.New(),
+			IncidentID:  incidentID,
+			EventType:   domain.TimelineEventCommanderChanged,
+			UserID:      &actionUserID,
+			Description: "Incident commander handed off",
+			Metadata: map[string]interface{}{
+				"previous_commander_user_id": previous.String(),
+				"commander_user_id":          commanderUserID.String(),
+			},
+		}
+
+		if err := s.incidentRepo.AddTimelineEvent(ctx, timelineEvent); err != nil {
+			fmt.Printf("Failed to add timeline event: %v\n", err)
+		}
+	}
+}
+
 func (s *IncidentService) ListResponders(ctx context.Context, incidentID, orgID uuid.UUID) ([]*domain.ResponderWithUser, error) {
+	if err := s.checkIncident(ctx, incidentID, orgID); err != nil {
+		return nil, err
+	}
+
 	responders, err := s.incidentRepo.ListResponders(ctx, incidentID, orgID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list responders: %w", err)
@@ -355,9 +856,22 @@ func (s *IncidentService) ListResponders(ctx context.Context, incidentID, orgID
 	return responders, nil
 }
 
+// checkIncident returns ErrNotFound unless the incident belongs to the
+// organization, so sub-resources of other organizations' incidents stay hidden
+func (s *IncidentService) checkIncident(ctx context.Context, incidentID, orgID uuid.UUID) error {
+	if _, err := s.incidentRepo.GetByID(ctx, incidentID, orgID); err != nil {
+		return fmt.Errorf("failed to get incident: %w", err)
+	}
+	return nil
+}
+
 // Timeline management
 
 func (s *IncidentService) AddNote(ctx context.Context, incidentID, orgID, userID uuid.UUID, req *dto.AddNoteRequest) (*domain.IncidentTimelineEvent, error) {
+	if err := s.checkIncident(ctx, incidentID, orgID); err != nil {
+		return nil, err
+	}
+
 	event := &domain.IncidentTimelineEvent{
 		ID:          uuid.New(),
 		IncidentID:  incidentID,
@@ -382,8 +896,68 @@ func (s *IncidentService) AddNote(ctx context.Context, incidentID, orgID, userID
 	return event, nil
 }
 
-func (s *IncidentService) GetTimeline(ctx context.Context, incidentID, orgID uuid.UUID) ([]*domain.TimelineEventWithUser, error) {
-	timeline, err := s.incidentRepo.GetTimeline(ctx, incidentID, orgID)
+// UpdateNote changes the text of a note on the incident timeline. Only the
+// note's author or an organization admin may edit it.
+func (s *IncidentService) UpdateNote(ctx context.Context, incidentID, orgID, eventID, userID uuid.UUID, isAdmin bool, req *dto.UpdateNoteRequest) (*domain.IncidentTimelineEvent, error) {
+	event, err := s.editableNote(ctx, incidentID, orgID, eventID, userID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	event.Description = req.Note
+	if err := s.incidentRepo.UpdateTimelineEventDescription(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to update note: %w", err)
+	}
+
+	return event, nil
+}
+
+// DeleteNote removes a note from the incident timeline. Only the note's author
+// or an organization admin may delete it.
+func (s *IncidentService) DeleteNote(ctx context.Context, incidentID, orgID, eventID, userID uuid.UUID, isAdmin bool) error {
+	if _, err := s.editableNote(ctx, incidentID, orgID, eventID, userID, isAdmin); err != nil {
+		return err
+	}
+
+	if err := s.incidentRepo.DeleteTimelineEvent(ctx, eventID, incidentID); err != nil {
+		return fmt.Errorf("failed to delete note: %w", err)
+	}
+
+	return nil
+}
+
+// editableNote loads a timeline event and checks that it is a note the user
+// is allowed to change. System-generated events are never editable.
+func (s *IncidentService) editableNote(ctx context.Context, incidentID, orgID, eventID, userID uuid.UUID, isAdmin bool) (*domain.IncidentTimelineEvent, error) {
+	event, err := s.incidentRepo.GetTimelineEvent(ctx, eventID, incidentID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timeline event: %w", err)
+	}
+
+	if event.EventType != domain.TimelineEventNoteAdded {
+		return nil, domain.ErrTimelineEventNotNote
+	}
+	if !isAdmin && (event.UserID == nil || *event.UserID != userID) {
+		return nil, domain.ErrNotNoteAuthor
+	}
+
+	return event, nil
+}
+
+// GetTimeline returns the incident's timeline, oldest first. When eventTypes
+// is non-empty only events of those types are returned.
+func (s *IncidentService) GetTimeline(ctx context.Context, incidentID, orgID uuid.UUID, eventTypes []domain.TimelineEventType) ([]*domain.TimelineEventWithUser, error) {
+	for _, eventType := range eventTypes {
+		if !eventType.IsValid() {
+			return nil, fmt.Errorf("%w: %s", domain.ErrInvalidTimelineEventType, eventType)
+		}
+	}
+
+	if err := s.checkIncident(ctx, incidentID, orgID); err != nil {
+		return nil, err
+	}
+
+	timeline, err := s.incidentRepo.GetTimeline(ctx, incidentID, orgID, eventTypes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get timeline: %w", err)
 	}
@@ -394,6 +968,15 @@ func (s *IncidentService) GetTimeline(ctx context.Context, incidentID, orgID uui
 // Alert linking
 
 func (s *IncidentService) LinkAlert(ctx context.Context, incidentID, orgID, userID uuid.UUID, req *dto.LinkAlertRequest) (*domain.IncidentAlert, error) {
+	if err := s.checkIncident(ctx, incidentID, orgID); err != nil {
+		return nil, err
+	}
+	if s.alertRepo != nil {
+		if _, err := s.alertRepo.GetByID(ctx, req.AlertID, orgID); err != nil {
+			return nil, fmt.Errorf("failed to get alert: %w", err)
+		}
+	}
+
 	link := &domain.IncidentAlert{
 		ID:             uuid.New(),
 		IncidentID:     incidentID,
@@ -405,47 +988,97 @@ func (s *IncidentService) LinkAlert(ctx context.Context, incidentID, orgID, user
 		return nil, fmt.Errorf("failed to link alert: %w", err)
 	}
 
-	// Add timeline event
-	timelineEvent := &domain.IncidentTimelineEvent{
-		ID:          uuid.New(),
-		IncidentID:  incidentID,
-		EventType:   domain.TimelineEventAlertLinked,
-		UserID:      &userID,
-		Description: "Alert linked to incident",
-		Metadata: map[string]interface{}{
-			"alert_id": req.AlertID.String(),
-		},
-	}
+	s.recordAlertLinkEvent(ctx, domain.TimelineEventAlertLinked, incidentID, orgID, req.AlertID, userID)
 
-	if err := s.incidentRepo.AddTimelineEvent(ctx, timelineEvent); err != nil {
-		fmt.Printf("Failed to add timeline event: %v\n", err)
+	if req.CopyNotes {
+		if err := s.copyAlertNotes(ctx, incidentID, orgID, req.AlertID); err != nil {
+			fmt.Printf("Failed to copy alert notes: %v\n", err)
+		}
 	}
 
 	return link, nil
 }
 
+// copyAlertNotes adds each note of the alert to the incident timeline as a
+// note event attributed to the note's author
+func (s *IncidentService) copyAlertNotes(ctx context.Context, incidentID, orgID, alertID uuid.UUID) error {
+	if s.alertRepo == nil {
+		return nil // Alert repository not configured
+	}
+
+	notes, err := s.alertRepo.ListNotes(ctx, alertID, orgID)
+	if err != nil {
+		return err
+	}
+
+	for _, note := range notes {
+		event := &domain.IncidentTimelineEvent{
+			ID:          uuid.New(),
+			IncidentID:  incidentID,
+			EventType:   domain.TimelineEventNoteAdded,
+			UserID:      note.UserID,
+			Description: note.Note,
+			Metadata: map[string]interface{}{
+				"alert_id":      alertID.String(),
+				"alert_note_id": note.ID.String(),
+				"noted_at":      note.CreatedAt,
+			},
+		}
+		if err := s.incidentRepo.AddTimelineEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to add timeline event: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (s *IncidentService) UnlinkAlert(ctx context.Context, incidentID, orgID, alertID, userID uuid.UUID) error {
 	if err := s.incidentRepo.UnlinkAlert(ctx, incidentID, orgID, alertID); err != nil {
 		return fmt.Errorf("failed to unlink alert: %w", err)
 	}
 
-	// Add timeline event
-	timelineEvent := &domain.IncidentTimelineEvent{
+	s.recordAlertLinkEvent(ctx, domain.TimelineEventAlertUnlinked, incidentID, orgID, alertID, userID)
+
+	return nil
+}
+
+// recordAlertLinkEvent adds an alert_linked or alert_unlinked event to the
+// incident timeline, naming the alert by its message when it can be looked up
+func (s *IncidentService) recordAlertLinkEvent(ctx context.Context, eventType domain.TimelineEventType, incidentID, orgID, alertID, userID uuid.UUID) {
+	description := "Alert linked to incident"
+	if eventType == domain.TimelineEventAlertUnlinked {
+		description = "Alert unlinked from incident"
+	}
+	metadata := map[string]interface{}{
+		"alert_id": alertID.String(),
+	}
+
+	if s.alertRepo != nil {
+		alert, err := s.alertRepo.GetByID(ctx, alertID, orgID)
+		if err != nil {
+			fmt.Printf("Failed to get alert for timeline event: %v\n", err)
+		} else {
+			description = fmt.Sprintf("%s: %s", description, alert.Message)
+			metadata["alert_message"] = alert.Message
+		}
+	}
+
+	event := &domain.IncidentTimelineEvent{
 		ID:          uuid.New(),
 		IncidentID:  incidentID,
-		EventType:   domain.TimelineEventAlertUnlinked,
+		EventType:   eventType,
 		UserID:      &userID,
-		Description: "Alert unlinked from incident",
-		Metadata: map[string]interface{}{
-			"alert_id": alertID.String(),
-		},
+		Description: description,
+		Metadata:    metadata,
 	}
-
-	if err := s.incidentRepo.AddTimelineEvent(ctx, timelineEvent); err != nil {
+	if err := s.incidentRepo.AddTimelineEvent(ctx, event); err != nil {
 		fmt.Printf("Failed to add timeline event: %v\n", err)
+		return
 	}
 
-	return nil
+	if s.broadcaster != nil {
+		s.broadcaster.BroadcastIncidentTimelineEvent(orgID, incidentID, event)
+	}
 }
 
 func (s *IncidentService) ListAlerts(ctx context.Context, incidentID, orgID uuid.UUID) ([]*domain.IncidentAlertWithDetails, error) {
@@ -456,3 +1089,76 @@ func (s *IncidentService) ListAlerts(ctx context.Context, incidentID, orgID uuid
 
 	return alerts, nil
 }
+
+// SuggestAlerts ranks the unclosed alerts created while the incident was
+// running, or shortly before it started, by how many tags and sources they
+// share with the alerts already linked to it. Alerts sharing nothing are left
+// out, so an incident without linked alerts gets no suggestions.
+func (s *IncidentService) SuggestAlerts(ctx context.Context, incidentID, orgID uuid.UUID) ([]*dto.SuggestedAlert, error) {
+	incident, err := s.incidentRepo.GetByID(ctx, incidentID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+	if s.alertRepo == nil {
+		return nil, fmt.Errorf("alert lookups are not configured")
+	}
+
+	linked, err := s.incidentRepo.ListAlerts(ctx, incidentID, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alerts: %w", err)
+	}
+
+	tags := make(map[string]bool)
+	sources := make(map[string]bool)
+	for _, link := range linked {
+		for _, tag := range link.Alert.Tags {
+			tags[tag] = true
+		}
+		sources[link.Alert.Source] = true
+	}
+	if len(tags) == 0 && len(sources) == 0 {
+		return []*dto.SuggestedAlert{}, nil
+	}
+
+	until := time.Now()
+	if incident.ResolvedAt != nil {
+		until = *incident.ResolvedAt
+	}
+	candidates, err := s.alertRepo.ListSuggestionCandidates(ctx, orgID, incidentID,
+		incident.StartedAt.Add(-domain.SuggestedAlertLookback), until,
+		slices.Sorted(maps.Keys(tags)), slices.Sorted(maps.Keys(sources)),
+		domain.MaxListLimit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list suggestion candidates: %w", err)
+	}
+
+	suggestions := make([]*dto.SuggestedAlert, 0, len(candidates))
+	for _, alert := range candidates {
+		suggestion := &dto.SuggestedAlert{
+			Alert:      alert,
+			SharedTags: []string{},
+			SameSource: sources[alert.Source],
+		}
+		for _, tag := range alert.Tags {
+			if tags[tag] {
+				suggestion.SharedTags = append(suggestion.SharedTags, tag)
+			}
+		}
+		suggestion.Score = len(suggestion.SharedTags)
+		if suggestion.SameSource {
+			suggestion.Score++
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	// Candidates come newest first, so equal scores stay in that order
+	slices.SortStableFunc(suggestions, func(a, b *dto.SuggestedAlert) int {
+		return b.Score - a.Score
+	})
+	if len(suggestions) > domain.SuggestedAlertLimit {
+		suggestions = suggestions[:domain.SuggestedAlertLimit]
+	}
+
+	return suggestions, nil
+}
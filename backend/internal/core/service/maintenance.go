@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
+)
+
+// MaintenanceWindowService manages maintenance windows and decides which
+// alerts they suppress
+type MaintenanceWindowService struct {
+	windowRepo     outbound.MaintenanceWindowRepository
+	routingService *RoutingService
+}
+
+func NewMaintenanceWindowService(windowRepo outbound.MaintenanceWindowRepository, routingService *RoutingService) *MaintenanceWindowService {
+	return &MaintenanceWindowService{
+		windowRepo:     windowRepo,
+		routingService: routingService,
+	}
+}
+
+func (s *MaintenanceWindowService) CreateWindow(ctx context.Context, orgID, userID uuid.UUID, req *dto.CreateMaintenanceWindowRequest) (*domain.MaintenanceWindow, error) {
+	window := &domain.MaintenanceWindow{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		Name:           req.Name,
+		Description:    req.Description,
+		StartsAt:       req.StartsAt,
+		EndsAt:         req.EndsAt,
+		Conditions:     req.Conditions,
+		CreatedBy:      &userID,
+	}
+
+	if err := validateMaintenanceWindow(window); err != nil {
+		return nil, err
+	}
+
+	if err := s.windowRepo.Create(ctx, window); err != nil {
+		return nil, err
+	}
+
+	return window, nil
+}
+
+func (s *MaintenanceWindowService) GetWindow(ctx context.Context, id, orgID uuid.UUID) (*domain.MaintenanceWindow, error) {
+	return s.windowRepo.GetByID(ctx, id, orgID)
+}
+
+func (s *MaintenanceWindowService) UpdateWindow(ctx context.Context, id, orgID uuid.UUID, req *dto.UpdateMaintenanceWindowRequest) (*domain.MaintenanceWindow, error) {
+	window, err := s.windowRepo.GetByID(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		window.Name = *req.Name
+	}
+	if req.Description != nil {
+		window.Description = req.Description
+	}
+	if req.StartsAt != nil {
+		window.StartsAt = *req.StartsAt
+	}
+	if req.EndsAt != nil {
+		window.EndsAt = *req.EndsAt
+	}
+	if req.Conditions != nil {
+		window.Conditions = req.Conditions
+	}
+
+	if err := validateMaintenanceWindow(window); err != nil {
+		return nil, err
+	}
+
+	if err := s.windowRepo.Update(ctx, window); err != nil {
+		return nil, err
+	}
+
+	return window, nil
+}
+
+func (s *MaintenanceWindowService) DeleteWindow(ctx context.Context, id, orgID uuid.UUID) error {
+	return s.windowRepo.Delete(ctx, id, orgID)
+}
+
+func (s *MaintenanceWindowService) ListWindows(ctx context.Context, orgID uuid.UUID, page, pageSize int) ([]*domain.MaintenanceWindow, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 50
+	}
+	offset := (page - 1) * pageSize
+	return s.windowRepo.List(ctx, orgID, pageSize, offset)
+}
+
+// MatchingWindow returns the first window active at the given time whose
+// conditions match the alert, or nil if none does
+func (s *MaintenanceWindowService) MatchingWindow(ctx context.Context, alert *domain.Alert, at time.Time) (*domain.MaintenanceWindow, error) {
+	windows, err := s.windowRepo.ListActive(ctx, alert.OrganizationID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, window := range windows {
+		conditions, err := window.ParseConditions()
+		if err != nil {
+			// Skip window with invalid conditions
+			continue
+		}
+		if s.routingService.evaluateConditions(alert, conditions) {
+			return window, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func validateMaintenanceWindow(window *domain.MaintenanceWindow) error {
+	if window.Name == "" {
+		return fmt.Errorf("%w: name is required", domain.ErrInvalidMaintenanceWindow)
+	}
+	if !window.EndsAt.After(window.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", domain.ErrInvalidMaintenanceWindow)
+	}
+	if len(window.Conditions) == 0 {
+		window.Conditions = json.RawMessage(`{}`)
+	}
+	if _, err := window.ParseConditions(); err != nil {
+		return fmt.Errorf("%w: invalid conditions format: %v", domain.ErrInvalidMaintenanceWindow, err)
+	}
+	return nil
+}
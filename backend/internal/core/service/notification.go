@@ -4,14 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 
 	providers "github.com/nmn3m/pulsar/backend/internal/adapter/outbound/provider"
 	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/dto"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/logger"
 )
 
 // NotificationProvider defines the interface for sending notifications
@@ -20,12 +23,14 @@ type NotificationProvider interface {
 }
 
 type NotificationService struct {
-	repo outbound.NotificationRepository
+	repo   outbound.NotificationRepository
+	logger *zap.Logger
 }
 
-func NewNotificationService(repo outbound.NotificationRepository) *NotificationService {
+func NewNotificationService(repo outbound.NotificationRepository, log *zap.Logger) *NotificationService {
 	return &NotificationService{
-		repo: repo,
+		repo:   repo,
+		logger: log,
 	}
 }
 
@@ -65,8 +70,20 @@ func (s *NotificationService) createProviderFromChannel(channel *domain.Notifica
 	}
 }
 
-// validateChannelConfig validates a channel configuration for a specific type
-func (s *NotificationService) validateChannelConfig(channelType domain.ChannelType, config json.RawMessage) error {
+// validateChannelConfig validates a channel configuration against the schema
+// of its type: required keys, URL formats and value ranges
+func validateChannelConfig(channelType domain.ChannelType, config json.RawMessage) error {
+	if err := checkChannelConfig(channelType, config); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidChannelConfig, err)
+	}
+	return nil
+}
+
+func checkChannelConfig(channelType domain.ChannelType, config json.RawMessage) error {
+	if len(config) == 0 {
+		return fmt.Errorf("config is required")
+	}
+
 	switch channelType {
 	case domain.ChannelTypeEmail:
 		p := &providers.EmailProvider{}
@@ -93,8 +110,8 @@ func (s *NotificationService) validateChannelConfig(channelType domain.ChannelTy
 
 func (s *NotificationService) CreateChannel(ctx context.Context, orgID uuid.UUID, req *dto.CreateNotificationChannelRequest) (*domain.NotificationChannel, error) {
 	// Validate the provider configuration
-	if err := s.validateChannelConfig(req.ChannelType, req.Config); err != nil {
-		return nil, fmt.Errorf("invalid configuration: %w", err)
+	if err := validateChannelConfig(req.ChannelType, req.Config); err != nil {
+		return nil, err
 	}
 
 	channel := &domain.NotificationChannel{
@@ -139,11 +156,21 @@ func (s *NotificationService) UpdateChannel(ctx context.Context, id uuid.UUID, r
 	}
 
 	if req.Config != nil {
-		// Validate the new configuration
-		if err := s.validateChannelConfig(channel.ChannelType, req.Config); err != nil {
-			return nil, fmt.Errorf("invalid configuration: %w", err)
+		// Credentials left redacted keep their stored value; sending a new
+		// value rotates them
+		config, err := channel.MergeRedactedConfig(req.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge channel config: %w", err)
+		}
+		channel.Config = config
+	}
+
+	// Validate the resulting configuration, since changing only the type can
+	// leave a config that does not fit the new type
+	if req.ChannelType != nil || req.Config != nil {
+		if err := validateChannelConfig(channel.ChannelType, channel.Config); err != nil {
+			return nil, err
 		}
-		channel.Config = req.Config
 	}
 
 	if err := s.repo.UpdateChannel(ctx, channel); err != nil {
@@ -157,6 +184,79 @@ func (s *NotificationService) DeleteChannel(ctx context.Context, id uuid.UUID) e
 	return s.repo.DeleteChannel(ctx, id)
 }
 
+// Canned content for channel test sends
+const (
+	channelTestSubject = "[Pulsar] Test notification"
+	channelTestMessage = "This is a test notification from Pulsar. If you can read it, the channel is configured correctly."
+)
+
+// TestChannel sends a canned notification through the channel, recording it
+// in the notification log flagged as a test. Disabled channels can be tested
+// so they can be checked before being turned on. A failed send is reported in
+// the response rather than as an error.
+func (s *NotificationService) TestChannel(ctx context.Context, id, orgID uuid.UUID, req *dto.TestNotificationChannelRequest) (*dto.TestNotificationChannelResponse, error) {
+	channel, err := s.repo.GetChannelByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if channel.OrganizationID != orgID {
+		return nil, domain.ErrNotFound
+	}
+
+	recipient := strings.TrimSpace(req.Recipient)
+	if channel.ChannelType == domain.ChannelTypeEmail && recipient == "" {
+		return nil, fmt.Errorf("%w: recipient is required to test an email channel", domain.ErrInvalidChannelConfig)
+	}
+
+	subject := channelTestSubject
+	log := &domain.NotificationLog{
+		OrganizationID: orgID,
+		ChannelID:      channel.ID,
+		Recipient:      recipient,
+		Subject:        &subject,
+		Message:        channelTestMessage,
+		Status:         domain.NotificationStatusPending,
+		IsTest:         true,
+	}
+
+	if err := s.repo.CreateLog(ctx, log); err != nil {
+		return nil, fmt.Errorf("failed to create notification log: %w", err)
+	}
+
+	sendErr := s.sendTest(channel, recipient, subject)
+	if sendErr != nil {
+		errMsg := sendErr.Error()
+		if err := s.repo.UpdateLogStatus(ctx, log.ID, domain.NotificationStatusFailed, &errMsg); err != nil {
+			return nil, fmt.Errorf("failed to update notification log: %w", err)
+		}
+	} else if err := s.repo.UpdateLogStatus(ctx, log.ID, domain.NotificationStatusSent, nil); err != nil {
+		return nil, fmt.Errorf("failed to update notification log: %w", err)
+	}
+
+	// Refresh the log to get updated status
+	if refreshed, err := s.repo.GetLogByID(ctx, log.ID); err == nil {
+		log = refreshed
+	}
+
+	resp := &dto.TestNotificationChannelResponse{
+		Success: sendErr == nil,
+		Log:     log,
+	}
+	if sendErr != nil {
+		resp.Error = log.ErrorMessage
+	}
+
+	return resp, nil
+}
+
+func (s *NotificationService) sendTest(channel *domain.NotificationChannel, recipient, subject string) error {
+	provider, err := s.createProviderFromChannel(channel)
+	if err != nil {
+		return fmt.Errorf("failed to create provider: %w", err)
+	}
+	return provider.Send(recipient, subject, channelTestMessage)
+}
+
 // ==================== User Preference Management ====================
 
 func (s *NotificationService) CreatePreference(ctx context.Context, userID uuid.UUID, req *dto.CreateUserNotificationPreferenceRequest) (*domain.UserNotificationPreference, error) {
@@ -252,6 +352,41 @@ func (s *NotificationService) DeletePreference(ctx context.Context, id uuid.UUID
 
 // ==================== Notification Sending ====================
 
+// PreferredChannel returns the first enabled channel among the user's enabled
+// preferences, falling back to the organization's first enabled email channel.
+// It returns nil when neither exists.
+func (s *NotificationService) PreferredChannel(ctx context.Context, orgID, userID uuid.UUID) (*domain.NotificationChannel, error) {
+	channels, err := s.ListChannels(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification channels: %w", err)
+	}
+
+	enabled := make(map[uuid.UUID]*domain.NotificationChannel, len(channels))
+	var fallback *domain.NotificationChannel
+	for i := range channels {
+		channel := &channels[i]
+		if !channel.IsEnabled {
+			continue
+		}
+		enabled[channel.ID] = channel
+		if fallback == nil && channel.ChannelType == domain.ChannelTypeEmail {
+			fallback = channel
+		}
+	}
+
+	preferences, err := s.ListUserPreferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+	for _, pref := range preferences {
+		if channel, ok := enabled[pref.ChannelID]; ok && pref.IsEnabled {
+			return channel, nil
+		}
+	}
+
+	return fallback, nil
+}
+
 func (s *NotificationService) SendNotification(ctx context.Context, orgID uuid.UUID, req *dto.SendNotificationRequest) (*domain.NotificationLog, error) {
 	// Get the channel
 	channel, err := s.repo.GetChannelByID(ctx, req.ChannelID)
@@ -317,15 +452,15 @@ func (s *NotificationService) SendNotification(ctx context.Context, orgID uuid.U
 		subject = *req.Subject
 	}
 
+	// A failed send is retried later by RetryFailedNotifications
 	err = provider.Send(req.Recipient, subject, req.Message)
 	if err != nil {
-		errMsg := err.Error()
-		s.repo.UpdateLogStatus(ctx, log.ID, domain.NotificationStatusFailed, &errMsg)
+		s.recordSendResult(ctx, log, err, domain.NotificationMaxAttempts, time.Now())
 		return log, fmt.Errorf("failed to send notification: %w", err)
 	}
 
 	// Update log status to sent
-	if err := s.repo.UpdateLogStatus(ctx, log.ID, domain.NotificationStatusSent, nil); err != nil {
+	if err := s.recordSendResult(ctx, log, nil, domain.NotificationMaxAttempts, time.Now()); err != nil {
 		return log, fmt.Errorf("notification sent but failed to update log: %w", err)
 	}
 
@@ -335,6 +470,38 @@ func (s *NotificationService) SendNotification(ctx context.Context, orgID uuid.U
 	return log, nil
 }
 
+// LogSuppressed records a notification that was withheld because the recipient
+// was in Do Not Disturb, so paging decisions stay visible in the logs.
+func (s *NotificationService) LogSuppressed(ctx context.Context, orgID uuid.UUID, req *dto.SendNotificationRequest, reason domain.DNDReason) (*domain.NotificationLog, error) {
+	return s.logWithheld(ctx, orgID, req, domain.NotificationStatusSuppressedDND, fmt.Sprintf("suppressed by do not disturb: %s", reason))
+}
+
+// LogSkippedUnavailable records a notification that was withheld because the
+// recipient was unavailable and skipped in a schedule rotation.
+func (s *NotificationService) LogSkippedUnavailable(ctx context.Context, orgID uuid.UUID, req *dto.SendNotificationRequest) (*domain.NotificationLog, error) {
+	return s.logWithheld(ctx, orgID, req, domain.NotificationStatusSkippedUnavailable, "skipped: recipient is unavailable")
+}
+
+func (s *NotificationService) logWithheld(ctx context.Context, orgID uuid.UUID, req *dto.SendNotificationRequest, status domain.NotificationStatus, errMsg string) (*domain.NotificationLog, error) {
+	log := &domain.NotificationLog{
+		OrganizationID: orgID,
+		ChannelID:      req.ChannelID,
+		UserID:         req.UserID,
+		AlertID:        req.AlertID,
+		Recipient:      req.Recipient,
+		Subject:        req.Subject,
+		Message:        req.Message,
+		Status:         status,
+		ErrorMessage:   &errMsg,
+	}
+
+	if err := s.repo.CreateLog(ctx, log); err != nil {
+		return nil, fmt.Errorf("failed to create notification log: %w", err)
+	}
+
+	return log, nil
+}
+
 // ProcessPendingNotifications processes pending notifications (for background workers)
 func (s *NotificationService) ProcessPendingNotifications(ctx context.Context, limit int) error {
 	logs, err := s.repo.GetPendingNotifications(ctx, limit)
@@ -372,31 +539,131 @@ func (s *NotificationService) ProcessPendingNotifications(ctx context.Context, l
 			subject = *log.Subject
 		}
 
-		err = provider.Send(log.Recipient, subject, log.Message)
+		sendErr := provider.Send(log.Recipient, subject, log.Message)
+		s.recordSendResult(ctx, &log, sendErr, domain.NotificationMaxAttempts, time.Now())
+	}
+
+	return nil
+}
+
+// RetryFailedNotifications resends failed notifications whose retry is due,
+// backing off exponentially until they run out of attempts. Notifications
+// whose channel was removed, disabled or misconfigured, or whose alert has
+// been acknowledged, closed or snoozed since, are given up on.
+func (s *NotificationService) RetryFailedNotifications(ctx context.Context, now time.Time, limit int) error {
+	retries, err := s.repo.GetRetriableFailures(ctx, now, limit)
+	if err != nil {
+		return fmt.Errorf("failed to get retriable notifications: %w", err)
+	}
+
+	for i := range retries {
+		retry := &retries[i]
+
+		if retry.AlertHandled() {
+			errMsg := fmt.Sprintf("retry cancelled: alert is %s", *retry.AlertStatus)
+			s.repo.UpdateLogStatus(ctx, retry.ID, domain.NotificationStatusFailed, &errMsg)
+			continue
+		}
+
+		channel, err := s.repo.GetChannelByID(ctx, retry.ChannelID)
 		if err != nil {
-			errMsg := err.Error()
-			s.repo.UpdateLogStatus(ctx, log.ID, domain.NotificationStatusFailed, &errMsg)
+			errMsg := fmt.Sprintf("channel not found: %v", err)
+			s.repo.UpdateLogStatus(ctx, retry.ID, domain.NotificationStatusFailed, &errMsg)
 			continue
 		}
 
-		// Update status to sent
-		s.repo.UpdateLogStatus(ctx, log.ID, domain.NotificationStatusSent, nil)
+		if !channel.IsEnabled {
+			errMsg := "channel is disabled"
+			s.repo.UpdateLogStatus(ctx, retry.ID, domain.NotificationStatusFailed, &errMsg)
+			continue
+		}
+
+		provider, err := s.createProviderFromChannel(channel)
+		if err != nil {
+			errMsg := fmt.Sprintf("failed to create provider: %v", err)
+			s.repo.UpdateLogStatus(ctx, retry.ID, domain.NotificationStatusFailed, &errMsg)
+			continue
+		}
+
+		subject := ""
+		if retry.Subject != nil {
+			subject = *retry.Subject
+		}
+
+		sendErr := provider.Send(retry.Recipient, subject, retry.Message)
+		if err := s.recordSendResult(ctx, &retry.NotificationLog, sendErr, retry.MaxAttempts(), now); err != nil {
+			logger.WithContext(ctx, s.logger).Warn("Failed to record notification retry",
+				zap.String("notification_id", retry.ID.String()),
+				zap.Error(err),
+			)
+		}
 	}
 
 	return nil
 }
 
+// recordSendResult stores the outcome of a send attempt. Failures are
+// scheduled for another attempt until the notification reaches maxAttempts.
+func (s *NotificationService) recordSendResult(ctx context.Context, log *domain.NotificationLog, sendErr error, maxAttempts int, now time.Time) error {
+	if sendErr == nil {
+		return s.repo.RecordDeliveryAttempt(ctx, log.ID, nil, nil)
+	}
+
+	errMsg := sendErr.Error()
+	var nextRetryAt *time.Time
+	if attempts := log.Attempts + 1; attempts < maxAttempts {
+		retryAt := now.Add(domain.NotificationRetryDelay(attempts))
+		nextRetryAt = &retryAt
+	}
+	return s.repo.RecordDeliveryAttempt(ctx, log.ID, &errMsg, nextRetryAt)
+}
+
 // ==================== Notification Logs ====================
 
 func (s *NotificationService) GetLog(ctx context.Context, id uuid.UUID) (*domain.NotificationLog, error) {
 	return s.repo.GetLogByID(ctx, id)
 }
 
-func (s *NotificationService) ListLogs(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]domain.NotificationLog, error) {
-	if limit <= 0 {
-		limit = 50
+// ListLogs returns the organization's notification logs, newest first,
+// filtered by delivery status, channel and time range
+func (s *NotificationService) ListLogs(ctx context.Context, orgID uuid.UUID, req *dto.ListNotificationLogsRequest) (*dto.ListNotificationLogsResponse, error) {
+	filter := &domain.NotificationLogFilter{
+		OrganizationID: orgID,
+		ChannelID:      req.ChannelID,
+		From:           req.From,
+		To:             req.To,
+		Limit:          req.Limit,
+		Offset:         req.Offset,
+	}
+
+	for _, statusStr := range req.Status {
+		status := domain.NotificationStatus(statusStr)
+		if !status.IsValid() {
+			return nil, fmt.Errorf("%w: unknown status %q", domain.ErrInvalidNotificationLogFilter, statusStr)
+		}
+		filter.Status = append(filter.Status, status)
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, fmt.Errorf("%w: from must be before to", domain.ErrInvalidNotificationLogFilter)
+	}
+
+	filter.Limit = domain.ClampLimit(filter.Limit, 50)
+	if filter.Offset < 0 {
+		return nil, domain.ErrNegativeOffset
 	}
-	return s.repo.ListLogs(ctx, orgID, limit, offset)
+
+	logs, total, err := s.repo.ListLogs(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification logs: %w", err)
+	}
+
+	return &dto.ListNotificationLogsResponse{
+		Logs:   logs,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
 }
 
 func (s *NotificationService) ListLogsByAlert(ctx context.Context, alertID uuid.UUID) ([]domain.NotificationLog, error) {
@@ -404,10 +671,10 @@ func (s *NotificationService) ListLogsByAlert(ctx context.Context, alertID uuid.
 }
 
 func (s *NotificationService) ListLogsByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.NotificationLog, error) {
-	if limit <= 0 {
-		limit = 50
+	if offset < 0 {
+		return nil, domain.ErrNegativeOffset
 	}
-	return s.repo.ListLogsByUser(ctx, userID, limit, offset)
+	return s.repo.ListLogsByUser(ctx, userID, domain.ClampLimit(limit, 50), offset)
 }
 
 // UnmarshalChannelConfig is a helper function to unmarshal channel config into a specific struct
@@ -0,0 +1,568 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
+)
+
+// exportPageSize is the number of rows fetched per query while streaming an export.
+const exportPageSize = 500
+
+type OrganizationService struct {
+	orgRepo          outbound.OrganizationRepository
+	teamRepo         outbound.TeamRepository
+	scheduleRepo     outbound.ScheduleRepository
+	escalationRepo   outbound.EscalationPolicyRepository
+	routingRepo      outbound.RoutingRuleRepository
+	notificationRepo outbound.NotificationRepository
+	alertRepo        outbound.AlertRepository
+	incidentRepo     outbound.IncidentRepository
+	importRepo       outbound.OrganizationImportRepository
+}
+
+func NewOrganizationService(
+	orgRepo outbound.OrganizationRepository,
+	teamRepo outbound.TeamRepository,
+	scheduleRepo outbound.ScheduleRepository,
+	escalationRepo outbound.EscalationPolicyRepository,
+	routingRepo outbound.RoutingRuleRepository,
+	notificationRepo outbound.NotificationRepository,
+	alertRepo outbound.AlertRepository,
+	incidentRepo outbound.IncidentRepository,
+	importRepo outbound.OrganizationImportRepository,
+) *OrganizationService {
+	return &OrganizationService{
+		orgRepo:          orgRepo,
+		teamRepo:         teamRepo,
+		scheduleRepo:     scheduleRepo,
+		escalationRepo:   escalationRepo,
+		routingRepo:      routingRepo,
+		notificationRepo: notificationRepo,
+		alertRepo:        alertRepo,
+		incidentRepo:     incidentRepo,
+		importRepo:       importRepo,
+	}
+}
+
+func (s *OrganizationService) GetSettings(ctx context.Context, orgID uuid.UUID) (map[string]interface{}, error) {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return orgSettings(org), nil
+}
+
+func (s *OrganizationService) UpdateSettings(ctx context.Context, orgID uuid.UUID, req *dto.UpdateOrganizationSettingsRequest) (map[string]interface{}, error) {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	if org.Settings == nil {
+		org.Settings = make(map[string]interface{})
+	}
+	if req.OverrideMembershipPolicy != nil {
+		policy := domain.OverrideMembershipPolicy(*req.OverrideMembershipPolicy)
+		if !policy.IsValid() {
+			return nil, fmt.Errorf("invalid override membership policy: %s", policy)
+		}
+		org.Settings[domain.SettingOverrideMembershipPolicy] = string(policy)
+	}
+	if req.DefaultTimezone != nil {
+		if _, err := time.LoadLocation(*req.DefaultTimezone); err != nil || *req.DefaultTimezone == "" {
+			return nil, fmt.Errorf("%w: %s", domain.ErrInvalidTimezone, *req.DefaultTimezone)
+		}
+		org.Settings[domain.SettingDefaultTimezone] = *req.DefaultTimezone
+	}
+	if req.IncidentAutoAssignOnCall != nil {
+		org.Settings[domain.SettingIncidentAutoAssignOnCall] = *req.IncidentAutoAssignOnCall
+	}
+	if req.IncidentCloseLinkedAlerts != nil {
+		org.Settings[domain.SettingIncidentCloseLinkedAlerts] = *req.IncidentCloseLinkedAlerts
+	}
+	if req.AlertAutoClose != nil {
+		policy := domain.AlertAutoClosePolicy{
+			AfterHours: req.AlertAutoClose.AfterHours,
+			Priorities: domain.DefaultAutoClosePriorities,
+		}
+		if req.AlertAutoClose.Priorities != nil {
+			policy.Priorities = make([]domain.AlertPriority, len(req.AlertAutoClose.Priorities))
+			for i, name := range req.AlertAutoClose.Priorities {
+				priority := domain.AlertPriority(name)
+				if !priority.IsValid() {
+					return nil, fmt.Errorf("%w: %s", domain.ErrInvalidPriority, name)
+				}
+				policy.Priorities[i] = priority
+			}
+		}
+		org.Settings[domain.SettingAlertAutoClose] = policy.Settings()
+	}
+	if req.AlertRetention != nil {
+		policy := domain.AlertRetentionPolicy{
+			AfterDays:        req.AlertRetention.AfterDays,
+			Archive:          req.AlertRetention.Archive == nil || *req.AlertRetention.Archive,
+			ExemptPriorities: make([]domain.AlertPriority, len(req.AlertRetention.ExemptPriorities)),
+			DryRun:           req.AlertRetention.DryRun,
+		}
+		for i, name := range req.AlertRetention.ExemptPriorities {
+			priority := domain.AlertPriority(name)
+			if !priority.IsValid() {
+				return nil, fmt.Errorf("%w: %s", domain.ErrInvalidPriority, name)
+			}
+			policy.ExemptPriorities[i] = priority
+		}
+		org.Settings[domain.SettingAlertRetention] = policy.Settings()
+	}
+	if req.SlackWarRoom != nil {
+		warRoom := domain.SlackWarRoomSettings{
+			BotToken:    req.SlackWarRoom.BotToken,
+			MinSeverity: domain.IncidentSeverityCritical,
+		}
+		if warRoom.BotToken == domain.RedactedValue {
+			warRoom.BotToken = org.SlackWarRoom().BotToken
+		}
+		if req.SlackWarRoom.MinSeverity != "" {
+			warRoom.MinSeverity = domain.IncidentSeverity(req.SlackWarRoom.MinSeverity)
+		}
+		org.Settings[domain.SettingSlackWarRoom] = warRoom.Settings(true)
+	}
+	if req.Jira != nil {
+		jira := domain.JiraSettings{
+			BaseURL:     strings.TrimRight(req.Jira.BaseURL, "/"),
+			Email:       req.Jira.Email,
+			APIToken:    req.Jira.APIToken,
+			ProjectKey:  req.Jira.ProjectKey,
+			IssueType:   req.Jira.IssueType,
+			Severities:  []domain.IncidentSeverity{domain.IncidentSeverityCritical, domain.IncidentSeverityHigh},
+			PriorityMap: make(map[domain.IncidentSeverity]string, len(req.Jira.PriorityMap)),
+			Labels:      req.Jira.Labels,
+			Fields:      req.Jira.Fields,
+		}
+		if jira.APIToken == domain.RedactedValue {
+			jira.APIToken = org.Jira().APIToken
+		}
+		if jira.IssueType == "" {
+			jira.IssueType = domain.DefaultJiraIssueType
+		}
+		if req.Jira.Severities != nil {
+			jira.Severities = make([]domain.IncidentSeverity, len(req.Jira.Severities))
+			for i, sev := range req.Jira.Severities {
+				jira.Severities[i] = domain.IncidentSeverity(sev)
+			}
+		}
+		for sev, priority := range req.Jira.PriorityMap {
+			jira.PriorityMap[domain.IncidentSeverity(sev)] = priority
+		}
+		org.Settings[domain.SettingJira] = jira.Settings(true)
+	}
+	if req.Statuspage != nil {
+		statuspage := domain.StatuspageSettings{
+			APIKey:       req.Statuspage.APIKey,
+			PageID:       req.Statuspage.PageID,
+			ComponentIDs: req.Statuspage.ComponentIDs,
+			MinSeverity:  domain.IncidentSeverityCritical,
+		}
+		if statuspage.APIKey == domain.RedactedValue {
+			statuspage.APIKey = org.Statuspage().APIKey
+		}
+		if req.Statuspage.MinSeverity != "" {
+			statuspage.MinSeverity = domain.IncidentSeverity(req.Statuspage.MinSeverity)
+		}
+		org.Settings[domain.SettingStatuspage] = statuspage.Settings(true)
+	}
+	if req.NotificationTemplates != nil {
+		templates := make(map[domain.ChannelType]domain.NotificationTemplate, len(req.NotificationTemplates))
+		for channelType, t := range req.NotificationTemplates {
+			templates[domain.ChannelType(channelType)] = domain.NotificationTemplate{Subject: t.Subject, Body: t.Body}
+		}
+		org.Settings[domain.SettingNotificationTemplates] = domain.NotificationTemplateSettings(templates)
+		for channelType := range templates {
+			if err := org.NotificationTemplate(channelType).Validate(); err != nil {
+				return nil, fmt.Errorf("%s template: %w", channelType, err)
+			}
+		}
+	}
+
+	if err := s.orgRepo.Update(ctx, org); err != nil {
+		return nil, fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	return orgSettings(org), nil
+}
+
+// orgSettings returns the organization's settings with defaults filled in and
+// integration secrets redacted.
+func orgSettings(org *domain.Organization) map[string]interface{} {
+	settings := make(map[string]interface{}, len(org.Settings)+10)
+	for key, value := range org.Settings {
+		settings[key] = value
+	}
+	settings[domain.SettingOverrideMembershipPolicy] = string(org.OverrideMembershipPolicy())
+	settings[domain.SettingDefaultTimezone] = org.DefaultTimezone()
+	settings[domain.SettingIncidentAutoAssignOnCall] = org.IncidentAutoAssignOnCall()
+	settings[domain.SettingIncidentCloseLinkedAlerts] = org.IncidentCloseLinkedAlerts()
+	settings[domain.SettingAlertAutoClose] = org.AlertAutoClosePolicy().Settings()
+	settings[domain.SettingAlertRetention] = org.AlertRetentionPolicy().Settings()
+	settings[domain.SettingSlackWarRoom] = org.SlackWarRoom().Settings(false)
+	settings[domain.SettingJira] = org.Jira().Settings(false)
+	settings[domain.SettingStatuspage] = org.Statuspage().Settings(false)
+	templates := make(map[domain.ChannelType]domain.NotificationTemplate, len(domain.DefaultNotificationTemplates))
+	for channelType := range domain.DefaultNotificationTemplates {
+		templates[channelType] = org.NotificationTemplate(channelType)
+	}
+	settings[domain.SettingNotificationTemplates] = domain.NotificationTemplateSettings(templates)
+	return settings
+}
+
+// ExportOrganization writes the organization's data to w as a JSON document in
+// the dto.OrganizationExport layout. Sections are streamed page by page so the
+// full export is never held in memory; notification channel secrets are redacted.
+func (s *OrganizationService) ExportOrganization(ctx context.Context, orgID uuid.UUID, w io.Writer) error {
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	ew := newExportWriter(w)
+	ew.write("{")
+	ew.field("version", dto.OrganizationExportVersion)
+	ew.field("exported_at", time.Now().UTC())
+	ew.field("organization", dto.ExportedOrganization{
+		ID:   org.ID,
+		Name: org.Name,
+		Slug: org.Slug,
+		Plan: org.Plan,
+	})
+
+	sections := []struct {
+		name   string
+		export func(ctx context.Context, ew *exportWriter, orgID uuid.UUID) error
+	}{
+		{"teams", s.exportTeams},
+		{"schedules", s.exportSchedules},
+		{"escalation_policies", s.exportEscalationPolicies},
+		{"routing_rules", s.exportRoutingRules},
+		{"notification_channels", s.exportNotificationChannels},
+		{"alerts", s.exportAlerts},
+		{"incidents", s.exportIncidents},
+	}
+	for _, section := range sections {
+		ew.beginArray(section.name)
+		if err := section.export(ctx, ew, orgID); err != nil {
+			return fmt.Errorf("failed to export %s: %w", section.name, err)
+		}
+		ew.endArray()
+		if ew.err != nil {
+			return fmt.Errorf("failed to write export: %w", ew.err)
+		}
+	}
+
+	ew.write("}\n")
+	if ew.err != nil {
+		return fmt.Errorf("failed to write export: %w", ew.err)
+	}
+	return nil
+}
+
+func (s *OrganizationService) exportTeams(ctx context.Context, ew *exportWriter, orgID uuid.UUID) error {
+	return exportPages(ctx, ew, func(limit, offset int) ([]*domain.Team, error) {
+		return s.teamRepo.List(ctx, orgID, limit, offset)
+	}, func(team *domain.Team) (interface{}, error) {
+		members, err := s.teamRepo.ListMembers(ctx, team.ID)
+		if err != nil {
+			return nil, err
+		}
+		exported := dto.ExportedTeam{
+			ID:          team.ID,
+			Name:        team.Name,
+			Description: team.Description,
+			Members:     make([]dto.ExportedTeamMember, 0, len(members)),
+		}
+		for _, m := range members {
+			exported.Members = append(exported.Members, dto.ExportedTeamMember{
+				UserID: m.ID,
+				Email:  m.Email,
+				Role:   m.Role,
+			})
+		}
+		return exported, nil
+	})
+}
+
+func (s *OrganizationService) exportSchedules(ctx context.Context, ew *exportWriter, orgID uuid.UUID) error {
+	return exportPages(ctx, ew, func(limit, offset int) ([]*domain.Schedule, error) {
+		return s.scheduleRepo.List(ctx, orgID, limit, offset)
+	}, func(schedule *domain.Schedule) (interface{}, error) {
+		exported := dto.ExportedSchedule{
+			ID:          schedule.ID,
+			TeamID:      schedule.TeamID,
+			Name:        schedule.Name,
+			Description: schedule.Description,
+			Timezone:    schedule.Timezone,
+			Rotations:   []dto.ExportedScheduleRotation{},
+			Overrides:   []dto.ExportedScheduleOverride{},
+		}
+
+		rotations, err := s.scheduleRepo.ListRotations(ctx, schedule.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rotations {
+			participants, err := s.scheduleRepo.ListParticipants(ctx, r.ID)
+			if err != nil {
+				return nil, err
+			}
+			rotation := dto.ExportedScheduleRotation{
+				ID:             r.ID,
+				TeamID:         r.TeamID,
+				Name:           r.Name,
+				RotationType:   r.RotationType.String(),
+				RotationLength: r.RotationLength,
+				StartDate:      r.StartDate.Format("2006-01-02"),
+				StartTime:      r.StartTime.Format("15:04"),
+				HandoffDay:     r.HandoffDay,
+				HandoffTime:    r.HandoffTime.Format("15:04"),
+				Participants:   make([]dto.ExportedParticipant, 0, len(participants)),
+			}
+			if r.EndTime != nil {
+				endTime := r.EndTime.Format("15:04")
+				rotation.EndTime = &endTime
+			}
+			for _, p := range participants {
+				rotation.Participants = append(rotation.Participants, dto.ExportedParticipant{
+					UserID:   p.UserID,
+					Email:    p.User.Email,
+					Position: p.Position,
+				})
+			}
+			exported.Rotations = append(exported.Rotations, rotation)
+		}
+
+		// All overrides, past and future
+		overrides, err := s.scheduleRepo.ListOverrides(ctx, schedule.ID, time.Time{}, time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range overrides {
+			exported.Overrides = append(exported.Overrides, dto.ExportedScheduleOverride{
+				UserID:    o.UserID,
+				StartTime: o.StartTime,
+				EndTime:   o.EndTime,
+				Note:      o.Note,
+			})
+		}
+		return exported, nil
+	})
+}
+
+func (s *OrganizationService) exportEscalationPolicies(ctx context.Context, ew *exportWriter, orgID uuid.UUID) error {
+	return exportPages(ctx, ew, func(limit, offset int) ([]*domain.EscalationPolicy, error) {
+		return s.escalationRepo.List(ctx, orgID, limit, offset)
+	}, func(policy *domain.EscalationPolicy) (interface{}, error) {
+		withRules, err := s.escalationRepo.GetWithRules(ctx, policy.ID)
+		if err != nil {
+			return nil, err
+		}
+		exported := dto.ExportedEscalationPolicy{
+			ID:            policy.ID,
+			Name:          policy.Name,
+			Description:   policy.Description,
+			RepeatEnabled: policy.RepeatEnabled,
+			RepeatCount:   policy.RepeatCount,
+			Conditions:    policy.Conditions,
+			BusinessHours: policy.BusinessHours,
+			Rules:         make([]dto.ExportedEscalationRule, 0, len(withRules.Rules)),
+		}
+		for _, rule := range withRules.Rules {
+			exportedRule := dto.ExportedEscalationRule{
+				Position:         rule.Position,
+				EscalationDelay:  rule.EscalationDelay,
+				DelaySeconds:     rule.DelaySeconds,
+				Hours:            string(rule.Hours),
+				OnlyIfUnassigned: rule.OnlyIfUnassigned,
+				Targets:          make([]dto.ExportedEscalationTarget, 0, len(rule.Targets)),
+			}
+			if rule.MinPriority != nil {
+				exportedRule.MinPriority = string(*rule.MinPriority)
+			}
+			for _, t := range rule.Targets {
+				exportedRule.Targets = append(exportedRule.Targets, dto.ExportedEscalationTarget{
+					TargetType:           t.TargetType.String(),
+					TargetID:             t.TargetID,
+					ScheduleMode:         string(t.ScheduleMode),
+					NotificationChannels: t.NotificationChannels,
+				})
+			}
+			exported.Rules = append(exported.Rules, exportedRule)
+		}
+		return exported, nil
+	})
+}
+
+func (s *OrganizationService) exportRoutingRules(ctx context.Context, ew *exportWriter, orgID uuid.UUID) error {
+	return exportPages(ctx, ew, func(limit, offset int) ([]*domain.AlertRoutingRule, error) {
+		return s.routingRepo.List(ctx, orgID, limit, offset)
+	}, func(rule *domain.AlertRoutingRule) (interface{}, error) {
+		return dto.ExportedRoutingRule{
+			ID:          rule.ID,
+			Name:        rule.Name,
+			Description: rule.Description,
+			Priority:    rule.Priority,
+			Conditions:  rule.Conditions,
+			Actions:     rule.Actions,
+			Enabled:     rule.Enabled,
+		}, nil
+	})
+}
+
+func (s *OrganizationService) exportNotificationChannels(ctx context.Context, ew *exportWriter, orgID uuid.UUID) error {
+	channels, err := s.notificationRepo.ListChannels(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	for i := range channels {
+		ew.item(dto.ExportedNotificationChannel{
+			ID:          channels[i].ID,
+			Name:        channels[i].Name,
+			ChannelType: string(channels[i].ChannelType),
+			IsEnabled:   channels[i].IsEnabled,
+			Config:      channels[i].RedactedConfig(),
+		})
+	}
+	return nil
+}
+
+func (s *OrganizationService) exportAlerts(ctx context.Context, ew *exportWriter, orgID uuid.UUID) error {
+	return exportPages(ctx, ew, func(limit, offset int) ([]*domain.Alert, error) {
+		alerts, _, err := s.alertRepo.List(ctx, &domain.AlertFilter{
+			OrganizationID: orgID,
+			Limit:          limit,
+			Offset:         offset,
+		})
+		return alerts, err
+	}, func(alert *domain.Alert) (interface{}, error) {
+		return alert, nil
+	})
+}
+
+func (s *OrganizationService) exportIncidents(ctx context.Context, ew *exportWriter, orgID uuid.UUID) error {
+	return exportPages(ctx, ew, func(limit, offset int) ([]*domain.Incident, error) {
+		incidents, _, err := s.incidentRepo.List(ctx, &domain.IncidentFilter{
+			OrganizationID: orgID,
+			Limit:          limit,
+			Offset:         offset,
+		})
+		return incidents, err
+	}, func(incident *domain.Incident) (interface{}, error) {
+		return incident, nil
+	})
+}
+
+// exportPages fetches rows exportPageSize at a time, converts each with convert
+// and writes it as an array item, flushing after every page.
+func exportPages[T any](ctx context.Context, ew *exportWriter, fetch func(limit, offset int) ([]T, error), convert func(T) (interface{}, error)) error {
+	for offset := 0; ; offset += exportPageSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rows, err := fetch(exportPageSize, offset)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			item, err := convert(row)
+			if err != nil {
+				return err
+			}
+			ew.item(item)
+		}
+		ew.flush()
+		if ew.err != nil || len(rows) < exportPageSize {
+			return nil
+		}
+	}
+}
+
+// exportWriter streams a JSON object one field or array item at a time. The
+// first write error is kept in err and every later write becomes a no-op.
+type exportWriter struct {
+	w      io.Writer
+	err    error
+	fields int
+	items  int
+}
+
+func newExportWriter(w io.Writer) *exportWriter {
+	return &exportWriter{w: w}
+}
+
+func (e *exportWriter) write(s string) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = io.WriteString(e.w, s)
+}
+
+func (e *exportWriter) encode(v interface{}) {
+	if e.err != nil {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		e.err = err
+		return
+	}
+	_, e.err = e.w.Write(data)
+}
+
+func (e *exportWriter) key(name string) {
+	if e.fields > 0 {
+		e.write(",")
+	}
+	e.encode(name)
+	e.write(":")
+	e.fields++
+}
+
+func (e *exportWriter) field(name string, v interface{}) {
+	e.key(name)
+	e.encode(v)
+}
+
+func (e *exportWriter) beginArray(name string) {
+	e.key(name)
+	e.write("[")
+	e.items = 0
+}
+
+func (e *exportWriter) item(v interface{}) {
+	if e.items > 0 {
+		e.write(",")
+	}
+	e.encode(v)
+	e.items++
+}
+
+func (e *exportWriter) endArray() {
+	e.write("]")
+	e.flush()
+}
+
+// flush pushes buffered output to the client when w supports it (e.g. an
+// http.ResponseWriter), so large exports start arriving immediately.
+func (e *exportWriter) flush() {
+	if f, ok := e.w.(interface{ Flush() }); ok && e.err == nil {
+		f.Flush()
+	}
+}
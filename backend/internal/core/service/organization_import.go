@@ -0,0 +1,497 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+)
+
+// ImportOrganization recreates the teams, schedules, escalation policies, routing
+// rules and notification channels of an export archive in orgID. Every entity
+// gets a fresh ID and references between them are remapped. References to
+// entities outside the archive must resolve within orgID: users by ID or email
+// among the organization's members, teams and schedules by ID. Nothing is
+// written unless the whole archive validates; the insert runs in one transaction.
+func (s *OrganizationService) ImportOrganization(ctx context.Context, orgID uuid.UUID, archive *dto.OrganizationExport) (*dto.OrganizationImportResponse, error) {
+	if archive.Version != dto.OrganizationExportVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", domain.ErrInvalidImport, archive.Version)
+	}
+
+	imp, err := s.newImporter(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := imp.build(archive); err != nil {
+		return nil, err
+	}
+
+	if err := s.importRepo.Import(ctx, imp.data); err != nil {
+		return nil, fmt.Errorf("failed to import organization: %w", err)
+	}
+
+	return &dto.OrganizationImportResponse{
+		Teams:                len(imp.data.Teams),
+		Schedules:            len(imp.data.Schedules),
+		EscalationPolicies:   len(imp.data.EscalationPolicies),
+		RoutingRules:         len(imp.data.RoutingRules),
+		NotificationChannels: len(imp.data.NotificationChannels),
+		IDMap:                imp.ids,
+		DisabledChannels:     imp.disabledChannels,
+	}, nil
+}
+
+// importer resolves archive references against the archive itself and the
+// target organization while building the entities to insert.
+type importer struct {
+	ctx     context.Context
+	s       *OrganizationService
+	orgID   uuid.UUID
+	members map[uuid.UUID]bool
+	emails  map[string]uuid.UUID
+
+	// ids maps archive IDs to newly assigned IDs
+	ids              map[uuid.UUID]uuid.UUID
+	data             *domain.OrganizationImport
+	disabledChannels []uuid.UUID
+}
+
+func (s *OrganizationService) newImporter(ctx context.Context, orgID uuid.UUID) (*importer, error) {
+	users, err := s.orgRepo.ListUsers(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization users: %w", err)
+	}
+
+	imp := &importer{
+		ctx:              ctx,
+		s:                s,
+		orgID:            orgID,
+		members:          make(map[uuid.UUID]bool, len(users)),
+		emails:           make(map[string]uuid.UUID, len(users)),
+		ids:              make(map[uuid.UUID]uuid.UUID),
+		data:             &domain.OrganizationImport{},
+		disabledChannels: []uuid.UUID{},
+	}
+	for _, u := range users {
+		imp.members[u.ID] = true
+		imp.emails[strings.ToLower(u.Email)] = u.ID
+	}
+	return imp, nil
+}
+
+func (imp *importer) build(archive *dto.OrganizationExport) error {
+	// Assign new IDs up front so entities can reference ones defined later in the archive
+	for _, t := range archive.Teams {
+		imp.ids[t.ID] = uuid.New()
+	}
+	for _, sc := range archive.Schedules {
+		imp.ids[sc.ID] = uuid.New()
+	}
+	for _, p := range archive.EscalationPolicies {
+		imp.ids[p.ID] = uuid.New()
+	}
+
+	for _, t := range archive.Teams {
+		if err := imp.addTeam(t); err != nil {
+			return err
+		}
+	}
+	for _, sc := range archive.Schedules {
+		if err := imp.addSchedule(sc); err != nil {
+			return err
+		}
+	}
+	for _, p := range archive.EscalationPolicies {
+		if err := imp.addEscalationPolicy(p); err != nil {
+			return err
+		}
+	}
+	for _, r := range archive.RoutingRules {
+		if err := imp.addRoutingRule(r); err != nil {
+			return err
+		}
+	}
+	for _, c := range archive.NotificationChannels {
+		if err := imp.addNotificationChannel(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (imp *importer) addTeam(t dto.ExportedTeam) error {
+	if t.Name == "" {
+		return fmt.Errorf("%w: team %s has no name", domain.ErrInvalidImport, t.ID)
+	}
+	team := &domain.Team{
+		ID:             imp.ids[t.ID],
+		OrganizationID: imp.orgID,
+		Name:           t.Name,
+		Description:    t.Description,
+	}
+	imp.data.Teams = append(imp.data.Teams, team)
+
+	for _, m := range t.Members {
+		userID, err := imp.resolveUser(m.UserID, m.Email)
+		if err != nil {
+			return fmt.Errorf("team %q: %w", t.Name, err)
+		}
+		role := domain.TeamRole(m.Role)
+		if role != domain.TeamRoleLead && role != domain.TeamRoleMember {
+			return fmt.Errorf("%w: team %q: invalid member role %q", domain.ErrInvalidImport, t.Name, m.Role)
+		}
+		imp.data.TeamMembers = append(imp.data.TeamMembers, &domain.TeamMember{
+			TeamID: team.ID,
+			UserID: userID,
+			Role:   role.String(),
+		})
+	}
+	return nil
+}
+
+func (imp *importer) addSchedule(sc dto.ExportedSchedule) error {
+	if sc.Name == "" {
+		return fmt.Errorf("%w: schedule %s has no name", domain.ErrInvalidImport, sc.ID)
+	}
+	schedule := &domain.Schedule{
+		ID:             imp.ids[sc.ID],
+		OrganizationID: imp.orgID,
+		Name:           sc.Name,
+		Description:    sc.Description,
+		Timezone:       sc.Timezone,
+	}
+	if schedule.Timezone == "" {
+		schedule.Timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(schedule.Timezone); err != nil {
+		return fmt.Errorf("%w: schedule %q: %v", domain.ErrInvalidImport, sc.Name, domain.ErrInvalidTimezone)
+	}
+	if sc.TeamID != nil {
+		teamID, err := imp.resolveTeam(*sc.TeamID)
+		if err != nil {
+			return fmt.Errorf("schedule %q: %w", sc.Name, err)
+		}
+		schedule.TeamID = &teamID
+	}
+	imp.data.Schedules = append(imp.data.Schedules, schedule)
+
+	for _, r := range sc.Rotations {
+		rotation, err := imp.newRotation(schedule.ID, r)
+		if err != nil {
+			return fmt.Errorf("schedule %q: %w", sc.Name, err)
+		}
+		if r.TeamID != nil {
+			teamID, err := imp.resolveTeam(*r.TeamID)
+			if err != nil {
+				return fmt.Errorf("schedule %q rotation %q: %w", sc.Name, r.Name, err)
+			}
+			rotation.TeamID = &teamID
+		}
+		imp.data.Rotations = append(imp.data.Rotations, rotation)
+		imp.ids[r.ID] = rotation.ID
+
+		for _, p := range r.Participants {
+			userID, err := imp.resolveUser(p.UserID, p.Email)
+			if err != nil {
+				return fmt.Errorf("schedule %q rotation %q: %w", sc.Name, r.Name, err)
+			}
+			imp.data.Participants = append(imp.data.Participants, &domain.ScheduleRotationParticipant{
+				ID:         uuid.New(),
+				RotationID: rotation.ID,
+				UserID:     userID,
+				Position:   p.Position,
+			})
+		}
+	}
+
+	for _, o := range sc.Overrides {
+		userID, err := imp.resolveUser(o.UserID, "")
+		if err != nil {
+			return fmt.Errorf("schedule %q override: %w", sc.Name, err)
+		}
+		if !o.EndTime.After(o.StartTime) {
+			return fmt.Errorf("%w: schedule %q: override must end after it starts", domain.ErrInvalidImport, sc.Name)
+		}
+		imp.data.Overrides = append(imp.data.Overrides, &domain.ScheduleOverride{
+			ID:         uuid.New(),
+			ScheduleID: schedule.ID,
+			UserID:     userID,
+			StartTime:  o.StartTime,
+			EndTime:    o.EndTime,
+			Note:       o.Note,
+		})
+	}
+	return nil
+}
+
+func (imp *importer) newRotation(scheduleID uuid.UUID, r dto.ExportedScheduleRotation) (*domain.ScheduleRotation, error) {
+	rotationType := domain.RotationType(r.RotationType)
+	if err := rotationType.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: rotation %q: %v", domain.ErrInvalidImport, r.Name, err)
+	}
+	if err := rotationType.ValidateLength(r.RotationLength); err != nil {
+		return nil, fmt.Errorf("%w: rotation %q: %v", domain.ErrInvalidImport, r.Name, err)
+	}
+
+	startDate, err := time.Parse("2006-01-02", r.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: rotation %q: invalid start_date", domain.ErrInvalidImport, r.Name)
+	}
+	startTime, err := time.Parse("15:04", r.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: rotation %q: invalid start_time", domain.ErrInvalidImport, r.Name)
+	}
+	handoffTime, err := time.Parse("15:04", r.HandoffTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: rotation %q: invalid handoff_time", domain.ErrInvalidImport, r.Name)
+	}
+
+	var endTime *time.Time
+	if r.EndTime != nil {
+		t, err := time.Parse("15:04", *r.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: rotation %q: invalid end_time", domain.ErrInvalidImport, r.Name)
+		}
+		endTime = &t
+	}
+
+	return &domain.ScheduleRotation{
+		ID:             uuid.New(),
+		ScheduleID:     scheduleID,
+		Name:           r.Name,
+		RotationType:   rotationType,
+		RotationLength: r.RotationLength,
+		StartDate:      startDate,
+		StartTime:      startTime,
+		EndTime:        endTime,
+		HandoffDay:     r.HandoffDay,
+		HandoffTime:    handoffTime,
+	}, nil
+}
+
+func (imp *importer) addEscalationPolicy(p dto.ExportedEscalationPolicy) error {
+	if p.Name == "" {
+		return fmt.Errorf("%w: escalation policy %s has no name", domain.ErrInvalidImport, p.ID)
+	}
+	policy := &domain.EscalationPolicy{
+		ID:             imp.ids[p.ID],
+		OrganizationID: imp.orgID,
+		Name:           p.Name,
+		Description:    p.Description,
+		RepeatEnabled:  p.RepeatEnabled,
+		RepeatCount:    p.RepeatCount,
+		Conditions:     p.Conditions,
+		BusinessHours:  p.BusinessHours,
+	}
+	if _, err := policy.ParseConditions(); err != nil {
+		return fmt.Errorf("%w: escalation policy %q: invalid conditions: %v", domain.ErrInvalidImport, p.Name, err)
+	}
+	if hours, err := policy.ParseBusinessHours(); err != nil {
+		return fmt.Errorf("%w: escalation policy %q: %v", domain.ErrInvalidImport, p.Name, err)
+	} else if hours != nil {
+		if err := hours.Validate(); err != nil {
+			return fmt.Errorf("%w: escalation policy %q: %v", domain.ErrInvalidImport, p.Name, err)
+		}
+	}
+	imp.data.EscalationPolicies = append(imp.data.EscalationPolicies, policy)
+
+	for _, r := range p.Rules {
+		hours, err := domain.ParseEscalationRuleHours(r.Hours)
+		if err != nil {
+			return fmt.Errorf("%w: escalation policy %q: %v", domain.ErrInvalidImport, p.Name, err)
+		}
+		minPriority, err := domain.ParseEscalationRuleMinPriority(r.MinPriority)
+		if err != nil {
+			return fmt.Errorf("%w: escalation policy %q: %v", domain.ErrInvalidImport, p.Name, err)
+		}
+		rule := &domain.EscalationRule{
+			ID:               uuid.New(),
+			PolicyID:         policy.ID,
+			Position:         r.Position,
+			EscalationDelay:  r.EscalationDelay,
+			DelaySeconds:     r.DelaySeconds,
+			Hours:            hours,
+			MinPriority:      minPriority,
+			OnlyIfUnassigned: r.OnlyIfUnassigned,
+		}
+		imp.data.EscalationRules = append(imp.data.EscalationRules, rule)
+
+		for _, t := range r.Targets {
+			targetType := domain.EscalationTargetType(t.TargetType)
+			if err := targetType.Validate(); err != nil {
+				return fmt.Errorf("%w: escalation policy %q: %v", domain.ErrInvalidImport, p.Name, err)
+			}
+			scheduleMode, err := domain.ParseScheduleTargetMode(targetType, t.ScheduleMode)
+			if err != nil {
+				return fmt.Errorf("%w: escalation policy %q: %v", domain.ErrInvalidImport, p.Name, err)
+			}
+
+			var targetID uuid.UUID
+			switch targetType {
+			case domain.EscalationTargetTypeUser:
+				targetID, err = imp.resolveUser(t.TargetID, "")
+			case domain.EscalationTargetTypeTeam:
+				targetID, err = imp.resolveTeam(t.TargetID)
+			case domain.EscalationTargetTypeSchedule:
+				targetID, err = imp.resolveSchedule(t.TargetID)
+			}
+			if err != nil {
+				return fmt.Errorf("escalation policy %q: %w", p.Name, err)
+			}
+
+			imp.data.EscalationTargets = append(imp.data.EscalationTargets, &domain.EscalationTarget{
+				ID:                   uuid.New(),
+				RuleID:               rule.ID,
+				TargetType:           targetType,
+				TargetID:             targetID,
+				ScheduleMode:         scheduleMode,
+				NotificationChannels: t.NotificationChannels,
+			})
+		}
+	}
+	return nil
+}
+
+func (imp *importer) addRoutingRule(r dto.ExportedRoutingRule) error {
+	if r.Name == "" {
+		return fmt.Errorf("%w: routing rule %s has no name", domain.ErrInvalidImport, r.ID)
+	}
+	rule := &domain.AlertRoutingRule{
+		ID:             uuid.New(),
+		OrganizationID: imp.orgID,
+		Name:           r.Name,
+		Description:    r.Description,
+		Priority:       r.Priority,
+		Conditions:     r.Conditions,
+		Actions:        r.Actions,
+		Enabled:        r.Enabled,
+	}
+	if _, err := rule.ParseConditions(); err != nil {
+		return fmt.Errorf("%w: routing rule %q: invalid conditions", domain.ErrInvalidImport, r.Name)
+	}
+	actions, err := rule.ParseActions()
+	if err != nil {
+		return fmt.Errorf("%w: routing rule %q: invalid actions", domain.ErrInvalidImport, r.Name)
+	}
+
+	if actions.AssignTeamID != nil {
+		teamID, err := imp.resolveTeam(*actions.AssignTeamID)
+		if err != nil {
+			return fmt.Errorf("routing rule %q: %w", r.Name, err)
+		}
+		actions.AssignTeamID = &teamID
+	}
+	if actions.AssignUserID != nil {
+		userID, err := imp.resolveUser(*actions.AssignUserID, "")
+		if err != nil {
+			return fmt.Errorf("routing rule %q: %w", r.Name, err)
+		}
+		actions.AssignUserID = &userID
+	}
+	if actions.AssignEscalationPolicyID != nil {
+		policyID, err := imp.resolveEscalationPolicy(*actions.AssignEscalationPolicyID)
+		if err != nil {
+			return fmt.Errorf("routing rule %q: %w", r.Name, err)
+		}
+		actions.AssignEscalationPolicyID = &policyID
+	}
+
+	rule.Actions, err = json.Marshal(actions)
+	if err != nil {
+		return fmt.Errorf("failed to marshal routing actions: %w", err)
+	}
+
+	imp.ids[r.ID] = rule.ID
+	imp.data.RoutingRules = append(imp.data.RoutingRules, rule)
+	return nil
+}
+
+func (imp *importer) addNotificationChannel(c dto.ExportedNotificationChannel) error {
+	channelType := domain.ChannelType(c.ChannelType)
+	switch channelType {
+	case domain.ChannelTypeEmail, domain.ChannelTypeSlack, domain.ChannelTypeTeams, domain.ChannelTypeWebhook:
+	default:
+		return fmt.Errorf("%w: notification channel %q: unsupported channel type %q", domain.ErrInvalidImport, c.Name, c.ChannelType)
+	}
+	if c.Name == "" || len(c.Config) == 0 {
+		return fmt.Errorf("%w: notification channel %s needs a name and config", domain.ErrInvalidImport, c.ID)
+	}
+
+	channel := &domain.NotificationChannel{
+		ID:             uuid.New(),
+		OrganizationID: imp.orgID,
+		Name:           c.Name,
+		ChannelType:    channelType,
+		IsEnabled:      c.IsEnabled,
+		Config:         c.Config,
+	}
+	// Exports redact credentials; such channels can't send until they are re-entered.
+	// Other configs must pass the same checks as channels created through the API.
+	if bytes.Contains(c.Config, []byte(domain.RedactedValue)) {
+		channel.IsEnabled = false
+		imp.disabledChannels = append(imp.disabledChannels, channel.ID)
+	} else if err := validateChannelConfig(channelType, c.Config); err != nil {
+		return fmt.Errorf("%w: notification channel %q: %v", domain.ErrInvalidImport, c.Name, err)
+	}
+
+	imp.ids[c.ID] = channel.ID
+	imp.data.NotificationChannels = append(imp.data.NotificationChannels, channel)
+	return nil
+}
+
+// resolveUser finds a member of the target organization by ID, falling back to email.
+func (imp *importer) resolveUser(id uuid.UUID, email string) (uuid.UUID, error) {
+	if imp.members[id] {
+		return id, nil
+	}
+	if email != "" {
+		if userID, ok := imp.emails[strings.ToLower(email)]; ok {
+			return userID, nil
+		}
+		return uuid.Nil, fmt.Errorf("%w: user %s is not a member of this organization", domain.ErrInvalidImport, email)
+	}
+	return uuid.Nil, fmt.Errorf("%w: user %s is not a member of this organization", domain.ErrInvalidImport, id)
+}
+
+// resolveTeam maps a team from the archive or accepts an existing team of the organization.
+func (imp *importer) resolveTeam(id uuid.UUID) (uuid.UUID, error) {
+	if newID, ok := imp.ids[id]; ok {
+		return newID, nil
+	}
+	team, err := imp.s.teamRepo.GetByID(imp.ctx, id)
+	if err == nil && team.OrganizationID == imp.orgID {
+		return team.ID, nil
+	}
+	return uuid.Nil, fmt.Errorf("%w: references missing team %s", domain.ErrInvalidImport, id)
+}
+
+// resolveSchedule maps a schedule from the archive or accepts an existing schedule of the organization.
+func (imp *importer) resolveSchedule(id uuid.UUID) (uuid.UUID, error) {
+	if newID, ok := imp.ids[id]; ok {
+		return newID, nil
+	}
+	schedule, err := imp.s.scheduleRepo.GetByID(imp.ctx, id)
+	if err == nil && schedule.OrganizationID == imp.orgID {
+		return schedule.ID, nil
+	}
+	return uuid.Nil, fmt.Errorf("%w: references missing schedule %s", domain.ErrInvalidImport, id)
+}
+
+// resolveEscalationPolicy maps a policy from the archive or accepts an existing policy of the organization.
+func (imp *importer) resolveEscalationPolicy(id uuid.UUID) (uuid.UUID, error) {
+	if newID, ok := imp.ids[id]; ok {
+		return newID, nil
+	}
+	policy, err := imp.s.escalationRepo.GetByID(imp.ctx, id)
+	if err == nil && policy.OrganizationID == imp.orgID {
+		return policy.ID, nil
+	}
+	return uuid.Nil, fmt.Errorf("%w: references missing escalation policy %s", domain.ErrInvalidImport, id)
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/logger"
+)
+
+// Outbox events younger than this are left to the request that wrote them,
+// which publishes them as soon as its transaction commits. Events whose
+// publishing failed are relayed without waiting.
+const outboxRelayGrace = time.Minute
+
+// outboxWSEvents maps the outbox event types that are also pushed to
+// WebSocket clients to their WebSocket event
+var outboxWSEvents = map[string]domain.WSEventType{
+	domain.WebhookEventAlertCreated: domain.WSEventAlertCreated,
+}
+
+// OutboxRelay publishes outbox events to WebSocket clients and webhooks and
+// marks them published. Events are published at least once: one that was
+// published but not yet marked when the process stopped is published again.
+type OutboxRelay struct {
+	outboxRepo  outbound.OutboxRepository
+	alertRepo   outbound.AlertRepository
+	broadcaster outbound.EventBroadcaster
+	webhooks    outbound.WebhookEnqueuer
+	logger      *zap.Logger
+}
+
+func NewOutboxRelay(outboxRepo outbound.OutboxRepository, alertRepo outbound.AlertRepository, broadcaster outbound.EventBroadcaster, webhooks outbound.WebhookEnqueuer, log *zap.Logger) *OutboxRelay {
+	return &OutboxRelay{
+		outboxRepo:  outboxRepo,
+		alertRepo:   alertRepo,
+		broadcaster: broadcaster,
+		webhooks:    webhooks,
+		logger:      log,
+	}
+}
+
+func (r *OutboxRelay) log(ctx context.Context) *zap.Logger {
+	return logger.WithContext(ctx, r.logger)
+}
+
+// Publish emits a committed event and marks it published. The event stays
+// unpublished if its webhooks can't be stored, and is marked failed so that
+// ProcessPending retries it on its next run rather than after the grace
+// period, keeping it close to the events that follow it.
+func (r *OutboxRelay) Publish(ctx context.Context, event *domain.OutboxEvent) error {
+	if err := r.publish(ctx, event); err != nil {
+		if markErr := r.outboxRepo.MarkPublishFailed(ctx, event.ID); markErr != nil {
+			r.log(ctx).Error("Failed to mark outbox event failed",
+				zap.String("event_id", event.ID.String()),
+				zap.Error(markErr),
+			)
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *OutboxRelay) publish(ctx context.Context, event *domain.OutboxEvent) error {
+	if wsEvent, ok := outboxWSEvents[event.EventType]; ok && event.AlertID != nil && r.broadcaster != nil {
+		alert, err := r.alertRepo.GetByID(ctx, *event.AlertID, event.OrganizationID)
+		switch {
+		case errors.Is(err, domain.ErrAlertNotFound):
+			// Deleted since; there is nothing left to show on dashboards
+		case err != nil:
+			return fmt.Errorf("failed to get alert: %w", err)
+		default:
+			r.broadcaster.BroadcastAlertEvent(wsEvent, event.OrganizationID, alert)
+		}
+	}
+
+	if r.webhooks != nil {
+		if err := r.webhooks.EnqueueWebhooks(ctx, event.OrganizationID, event.EventType, event.Payload); err != nil {
+			return err
+		}
+	}
+
+	return r.outboxRepo.MarkPublished(ctx, event.ID)
+}
+
+// ProcessPending publishes up to limit events that weren't published when
+// they were written, e.g. because the process stopped right after the commit
+func (r *OutboxRelay) ProcessPending(ctx context.Context, limit int) error {
+	events, err := r.outboxRepo.ClaimUnpublished(ctx, time.Now().Add(-outboxRelayGrace), limit)
+	if err != nil {
+		return err
+	}
+
+	r.log(ctx).Debug("Publishing pending outbox events", zap.Int("count", len(events)))
+
+	for _, event := range events {
+		if err := r.Publish(ctx, event); err != nil {
+			r.log(ctx).Error("Failed to publish outbox event",
+				zap.String("event_id", event.ID.String()),
+				zap.String("event_type", event.EventType),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
@@ -17,6 +17,7 @@ import (
 
 type RoutingService struct {
 	routingRepo outbound.RoutingRuleRepository
+	policyRepo  outbound.EscalationPolicyRepository
 }
 
 func NewRoutingService(routingRepo outbound.RoutingRuleRepository) *RoutingService {
@@ -25,6 +26,13 @@ func NewRoutingService(routingRepo outbound.RoutingRuleRepository) *RoutingServi
 	}
 }
 
+// SetEscalationPolicyRepo sets the escalation policy repository (optional dependency).
+// Without it policies are never selected by their own conditions and explicit
+// assignments are not checked against the organization.
+func (s *RoutingService) SetEscalationPolicyRepo(repo outbound.EscalationPolicyRepository) {
+	s.policyRepo = repo
+}
+
 // CreateRule creates a new routing rule
 func (s *RoutingService) CreateRule(ctx context.Context, orgID uuid.UUID, req *dto.CreateRoutingRuleRequest) (*domain.AlertRoutingRule, error) {
 	// Validate conditions JSON
@@ -161,6 +169,64 @@ func (s *RoutingService) ApplyRouting(ctx context.Context, orgID uuid.UUID, aler
 	return nil, nil
 }
 
+// SelectEscalationPolicy decides the escalation policy for a new alert.
+// Precedence, first match wins:
+//  1. a policy assigned explicitly on the alert
+//  2. the policy assigned by the first routing rule matching the alert
+//  3. the most specific policy whose own conditions match the alert, the
+//     oldest such policy on a tie
+//
+// It returns nil when no policy applies.
+func (s *RoutingService) SelectEscalationPolicy(ctx context.Context, alert *domain.Alert) (*uuid.UUID, error) {
+	if alert.EscalationPolicyID != nil {
+		if s.policyRepo != nil {
+			policy, err := s.policyRepo.GetByID(ctx, *alert.EscalationPolicyID)
+			if err != nil || policy.OrganizationID != alert.OrganizationID {
+				return nil, domain.ErrEscalationPolicyNotFound
+			}
+		}
+		return alert.EscalationPolicyID, nil
+	}
+
+	actions, err := s.ApplyRouting(ctx, alert.OrganizationID, alert)
+	if err != nil {
+		return nil, err
+	}
+	if actions != nil && actions.AssignEscalationPolicyID != nil {
+		return actions.AssignEscalationPolicyID, nil
+	}
+
+	if s.policyRepo == nil {
+		return nil, nil
+	}
+
+	policies, err := s.policyRepo.ListWithConditions(ctx, alert.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list escalation policies: %w", err)
+	}
+
+	var best *domain.EscalationPolicy
+	bestSpecificity := -1
+	for _, policy := range policies {
+		conditions, err := policy.ParseConditions()
+		if err != nil || conditions == nil {
+			// Skip policy with invalid conditions
+			continue
+		}
+		// Policies are sorted oldest first, so only a strictly more
+		// specific policy replaces the current best
+		if specificity := conditions.Specificity(); specificity > bestSpecificity && s.evaluateConditions(alert, conditions) {
+			best = policy
+			bestSpecificity = specificity
+		}
+	}
+
+	if best == nil {
+		return nil, nil
+	}
+	return &best.ID, nil
+}
+
 // evaluateConditions evaluates if the alert matches the routing conditions
 func (s *RoutingService) evaluateConditions(alert *domain.Alert, conditions *domain.RoutingConditions) bool {
 	if len(conditions.Conditions) == 0 {
@@ -219,15 +285,18 @@ func (s *RoutingService) evaluateCondition(alert *domain.Alert, condition *domai
 func (s *RoutingService) evaluateTagsCondition(tags []string, condition *domain.RoutingCondition) bool {
 	switch condition.Operator {
 	case "contains":
+		// Alert tags are stored normalized, so match the rule's tag the same way
+		want := domain.NormalizeTag(condition.Value)
 		for _, tag := range tags {
-			if tag == condition.Value {
+			if tag == want {
 				return true
 			}
 		}
 		return false
 	case "not_contains":
+		want := domain.NormalizeTag(condition.Value)
 		for _, tag := range tags {
-			if tag == condition.Value {
+			if tag == want {
 				return false
 			}
 		}
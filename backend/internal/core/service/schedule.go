@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,9 +14,15 @@ import (
 	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
 )
 
+// maxPreviewWindow bounds the range of an on-call preview.
+const maxPreviewWindow = 366 * 24 * time.Hour
+
 type ScheduleService struct {
 	scheduleRepo outbound.ScheduleRepository
 	userRepo     outbound.UserRepository
+	orgRepo      outbound.OrganizationRepository
+	teamRepo     outbound.TeamRepository
+	availability outbound.UserAvailabilityRepository
 }
 
 func NewScheduleService(scheduleRepo outbound.ScheduleRepository, userRepo outbound.UserRepository) *ScheduleService {
@@ -24,12 +32,37 @@ func NewScheduleService(scheduleRepo outbound.ScheduleRepository, userRepo outbo
 	}
 }
 
+// SetOrganizationRepo sets the organization repository (optional dependency).
+// Without it the override membership policy is not enforced.
+func (s *ScheduleService) SetOrganizationRepo(repo outbound.OrganizationRepository) {
+	s.orgRepo = repo
+}
+
+// SetTeamRepo sets the team repository (optional dependency)
+func (s *ScheduleService) SetTeamRepo(repo outbound.TeamRepository) {
+	s.teamRepo = repo
+}
+
+// SetAvailabilityRepo sets the user availability repository (optional dependency).
+// Without it unavailable users are not skipped when resolving who is on call.
+func (s *ScheduleService) SetAvailabilityRepo(repo outbound.UserAvailabilityRepository) {
+	s.availability = repo
+}
+
 // Schedule CRUD
 
 func (s *ScheduleService) CreateSchedule(ctx context.Context, orgID uuid.UUID, req *dto.CreateScheduleRequest) (*domain.Schedule, error) {
 	timezone := req.Timezone
 	if timezone == "" {
-		timezone = "UTC"
+		timezone = s.defaultTimezone(ctx, orgID)
+	}
+	if err := validateTimezone(timezone); err != nil {
+		return nil, err
+	}
+	if req.TeamID != nil {
+		if err := s.validateTeam(ctx, orgID, *req.TeamID); err != nil {
+			return nil, err
+		}
 	}
 
 	schedule := &domain.Schedule{
@@ -48,6 +81,29 @@ func (s *ScheduleService) CreateSchedule(ctx context.Context, orgID uuid.UUID, r
 	return schedule, nil
 }
 
+// defaultTimezone returns the timezone given to the organization's schedules
+// when none is requested
+func (s *ScheduleService) defaultTimezone(ctx context.Context, orgID uuid.UUID) string {
+	if s.orgRepo == nil {
+		return domain.FallbackTimezone
+	}
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return domain.FallbackTimezone
+	}
+	return org.DefaultTimezone()
+}
+
+func validateTimezone(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: timezone is required", domain.ErrInvalidTimezone)
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("%w: %s", domain.ErrInvalidTimezone, name)
+	}
+	return nil
+}
+
 func (s *ScheduleService) GetSchedule(ctx context.Context, id uuid.UUID) (*domain.Schedule, error) {
 	schedule, err := s.scheduleRepo.GetByID(ctx, id)
 	if err != nil {
@@ -79,9 +135,15 @@ func (s *ScheduleService) UpdateSchedule(ctx context.Context, id uuid.UUID, req
 		schedule.Description = req.Description
 	}
 	if req.Timezone != nil {
+		if err := validateTimezone(*req.Timezone); err != nil {
+			return nil, err
+		}
 		schedule.Timezone = *req.Timezone
 	}
 	if req.TeamID != nil {
+		if err := s.validateTeam(ctx, schedule.OrganizationID, *req.TeamID); err != nil {
+			return nil, err
+		}
 		schedule.TeamID = req.TeamID
 	}
 
@@ -122,14 +184,161 @@ func (s *ScheduleService) ListSchedules(ctx context.Context, orgID uuid.UUID, pa
 	return schedules, nil
 }
 
+// Declarative config
+
+// ApplyScheduleConfig reconciles a schedule, its rotations and their participants
+// with the desired state in req. Everything is applied in a single transaction.
+func (s *ScheduleService) ApplyScheduleConfig(ctx context.Context, orgID, id uuid.UUID, req *dto.ScheduleConfigRequest) (*domain.ScheduleWithParticipants, error) {
+	schedule, err := s.scheduleRepo.GetByID(ctx, id)
+	if err != nil || schedule.OrganizationID != orgID {
+		return nil, fmt.Errorf("schedule not found")
+	}
+
+	existing, err := s.scheduleRepo.ListRotations(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rotations: %w", err)
+	}
+	existingByID := make(map[uuid.UUID]*domain.ScheduleRotation, len(existing))
+	existingByName := make(map[string]*domain.ScheduleRotation, len(existing))
+	for _, rotation := range existing {
+		existingByID[rotation.ID] = rotation
+		existingByName[rotation.Name] = rotation
+	}
+
+	if s.orgRepo == nil {
+		return nil, fmt.Errorf("organization membership checks are not configured")
+	}
+	if req.TeamID != nil {
+		if err := s.validateTeam(ctx, orgID, *req.TeamID); err != nil {
+			return nil, err
+		}
+	}
+
+	schedule.Name = req.Name
+	schedule.Description = req.Description
+	schedule.TeamID = req.TeamID
+	schedule.Timezone = req.Timezone
+	if schedule.Timezone == "" {
+		schedule.Timezone = s.defaultTimezone(ctx, orgID)
+	}
+	if err := validateTimezone(schedule.Timezone); err != nil {
+		return nil, err
+	}
+
+	config := &domain.ScheduleConfig{Schedule: schedule}
+	seen := make(map[uuid.UUID]bool, len(req.Rotations))
+	knownUsers := make(map[uuid.UUID]bool)
+	for i := range req.Rotations {
+		desired := &req.Rotations[i]
+
+		rotation, err := newRotation(id, &desired.CreateRotationRequest)
+		if err != nil {
+			return nil, fmt.Errorf("rotation %q: %w", desired.Name, err)
+		}
+		if rotation.TeamID != nil {
+			if err := s.validateRotationTeam(ctx, id, *rotation.TeamID); err != nil {
+				return nil, fmt.Errorf("rotation %q: %w", desired.Name, err)
+			}
+		}
+
+		// Keep the identity of existing rotations so updates don't churn IDs
+		if desired.ID != nil {
+			current, ok := existingByID[*desired.ID]
+			if !ok {
+				return nil, fmt.Errorf("rotation %s does not belong to this schedule", *desired.ID)
+			}
+			rotation.ID = current.ID
+		} else if current, ok := existingByName[desired.Name]; ok {
+			rotation.ID = current.ID
+		}
+		if seen[rotation.ID] {
+			return nil, fmt.Errorf("rotation %q is listed more than once", desired.Name)
+		}
+		seen[rotation.ID] = true
+
+		participants := make(map[uuid.UUID]bool, len(desired.Participants))
+		for _, userID := range desired.Participants {
+			if participants[userID] {
+				return nil, fmt.Errorf("rotation %q: user %s is listed more than once", desired.Name, userID)
+			}
+			participants[userID] = true
+
+			if !knownUsers[userID] {
+				if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+					return nil, fmt.Errorf("rotation %q: user %s not found", desired.Name, userID)
+				}
+				if _, err := s.orgRepo.GetUserRole(ctx, orgID, userID); err != nil {
+					return nil, fmt.Errorf("rotation %q: user %s is not a member of the organization", desired.Name, userID)
+				}
+				knownUsers[userID] = true
+			}
+		}
+
+		config.Rotations = append(config.Rotations, &domain.ScheduleRotationConfig{
+			ScheduleRotation: rotation,
+			UserIDs:          desired.Participants,
+		})
+	}
+
+	if err := s.scheduleRepo.ApplyConfig(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to apply schedule config: %w", err)
+	}
+
+	result := &domain.ScheduleWithParticipants{
+		Schedule:  *schedule,
+		Rotations: make([]*domain.RotationWithParticipants, 0, len(config.Rotations)),
+	}
+	for _, rotation := range config.Rotations {
+		participants, err := s.scheduleRepo.ListParticipants(ctx, rotation.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list participants: %w", err)
+		}
+		result.Rotations = append(result.Rotations, &domain.RotationWithParticipants{
+			ScheduleRotation: *rotation.ScheduleRotation,
+			Participants:     participants,
+		})
+	}
+
+	return result, nil
+}
+
 // Rotation CRUD
 
 func (s *ScheduleService) CreateRotation(ctx context.Context, scheduleID uuid.UUID, req *dto.CreateRotationRequest) (*domain.ScheduleRotation, error) {
+	rotation, err := newRotation(scheduleID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if rotation.TeamID != nil {
+		if err := s.validateRotationTeam(ctx, scheduleID, *rotation.TeamID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.scheduleRepo.CreateRotation(ctx, rotation); err != nil {
+		return nil, fmt.Errorf("failed to create rotation: %w", err)
+	}
+
+	if rotation.TeamID != nil {
+		if err := s.addTeamMembersToRotation(ctx, rotation); err != nil {
+			return nil, err
+		}
+	}
+
+	return rotation, nil
+}
+
+// newRotation builds a rotation from a create request, applying default times.
+func newRotation(scheduleID uuid.UUID, req *dto.CreateRotationRequest) (*domain.ScheduleRotation, error) {
 	// Validate rotation type
 	rotationType := domain.RotationType(req.RotationType)
 	if err := rotationType.Validate(); err != nil {
 		return nil, err
 	}
+	if err := rotationType.ValidateLength(req.RotationLength); err != nil {
+		return nil, err
+	}
 
 	// Parse start date
 	startDate, err := time.Parse("2006-01-02", req.StartDate)
@@ -167,10 +376,7 @@ func (s *ScheduleService) CreateRotation(ctx context.Context, scheduleID uuid.UU
 		EndTime:        endTime,
 		HandoffDay:     req.HandoffDay,
 		HandoffTime:    handoffTime,
-	}
-
-	if err := s.scheduleRepo.CreateRotation(ctx, rotation); err != nil {
-		return nil, fmt.Errorf("failed to create rotation: %w", err)
+		TeamID:         req.TeamID,
 	}
 
 	return rotation, nil
@@ -204,6 +410,11 @@ func (s *ScheduleService) UpdateRotation(ctx context.Context, id uuid.UUID, req
 	if req.RotationLength != nil {
 		rotation.RotationLength = *req.RotationLength
 	}
+	// Check the combination, as changing only the type can leave the existing
+	// length out of range
+	if err := rotation.RotationType.ValidateLength(rotation.RotationLength); err != nil {
+		return nil, err
+	}
 	if req.StartDate != nil {
 		startDate, err := time.Parse("2006-01-02", *req.StartDate)
 		if err != nil {
@@ -235,11 +446,24 @@ func (s *ScheduleService) UpdateRotation(ctx context.Context, id uuid.UUID, req
 		}
 		rotation.HandoffTime = handoffTime
 	}
+	teamChanged := req.TeamID != nil && (rotation.TeamID == nil || *rotation.TeamID != *req.TeamID)
+	if teamChanged {
+		if err := s.validateRotationTeam(ctx, rotation.ScheduleID, *req.TeamID); err != nil {
+			return nil, err
+		}
+		rotation.TeamID = req.TeamID
+	}
 
 	if err := s.scheduleRepo.UpdateRotation(ctx, rotation); err != nil {
 		return nil, fmt.Errorf("failed to update rotation: %w", err)
 	}
 
+	if teamChanged {
+		if err := s.addTeamMembersToRotation(ctx, rotation); err != nil {
+			return nil, err
+		}
+	}
+
 	return rotation, nil
 }
 
@@ -308,28 +532,223 @@ func (s *ScheduleService) ReorderParticipants(ctx context.Context, rotationID uu
 	return nil
 }
 
+// SetParticipants replaces the participants of a rotation with the users in
+// req, in order. Every user must exist and belong to the schedule's
+// organization; nothing is changed if any of them doesn't.
+func (s *ScheduleService) SetParticipants(ctx context.Context, orgID, scheduleID, rotationID uuid.UUID, req *dto.SetParticipantsRequest) ([]*domain.ParticipantWithUser, error) {
+	if s.orgRepo == nil {
+		return nil, fmt.Errorf("organization membership checks are not configured")
+	}
+
+	schedule, err := s.scheduleRepo.GetByID(ctx, scheduleID)
+	if err != nil || schedule.OrganizationID != orgID {
+		return nil, fmt.Errorf("%w: schedule", domain.ErrNotFound)
+	}
+	rotation, err := s.scheduleRepo.GetRotation(ctx, rotationID)
+	if err != nil || rotation.ScheduleID != scheduleID {
+		return nil, fmt.Errorf("%w: rotation", domain.ErrNotFound)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.UserIDs))
+	for _, userID := range req.UserIDs {
+		if seen[userID] {
+			return nil, fmt.Errorf("user %s is listed more than once", userID)
+		}
+		seen[userID] = true
+
+		if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+			return nil, fmt.Errorf("user %s not found", userID)
+		}
+		if _, err := s.orgRepo.GetUserRole(ctx, orgID, userID); err != nil {
+			return nil, fmt.Errorf("user %s is not a member of the organization", userID)
+		}
+	}
+
+	if err := s.scheduleRepo.SetParticipants(ctx, rotationID, req.UserIDs); err != nil {
+		return nil, fmt.Errorf("failed to set participants: %w", err)
+	}
+
+	participants, err := s.scheduleRepo.ListParticipants(ctx, rotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list participants: %w", err)
+	}
+
+	return participants, nil
+}
+
+// Team-backed rotations
+
+// validateRotationTeam checks that a team backing a rotation belongs to the
+// schedule's organization.
+func (s *ScheduleService) validateRotationTeam(ctx context.Context, scheduleID, teamID uuid.UUID) error {
+	if s.teamRepo == nil {
+		return fmt.Errorf("team-backed rotations are not configured")
+	}
+
+	schedule, err := s.scheduleRepo.GetByID(ctx, scheduleID)
+	if err != nil {
+		return fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	return s.validateTeam(ctx, schedule.OrganizationID, teamID)
+}
+
+// validateTeam checks that the team belongs to the organization
+func (s *ScheduleService) validateTeam(ctx context.Context, orgID, teamID uuid.UUID) error {
+	if s.teamRepo == nil {
+		return fmt.Errorf("team-backed schedules are not configured")
+	}
+
+	team, err := s.teamRepo.GetByID(ctx, teamID)
+	if err != nil || team.OrganizationID != orgID {
+		return fmt.Errorf("team not found")
+	}
+
+	return nil
+}
+
+// addTeamMembersToRotation appends the backing team's members that are not yet
+// participants to the end of the rotation.
+func (s *ScheduleService) addTeamMembersToRotation(ctx context.Context, rotation *domain.ScheduleRotation) error {
+	members, err := s.teamRepo.ListMembers(ctx, *rotation.TeamID)
+	if err != nil {
+		return fmt.Errorf("failed to list team members: %w", err)
+	}
+
+	for _, member := range members {
+		if err := s.appendParticipant(ctx, rotation.ID, member.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appendParticipant adds the user after the last participant of the rotation.
+// Users that already participate are left in place.
+func (s *ScheduleService) appendParticipant(ctx context.Context, rotationID, userID uuid.UUID) error {
+	participants, err := s.scheduleRepo.ListParticipants(ctx, rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to list participants: %w", err)
+	}
+
+	position := 0
+	for _, participant := range participants {
+		if participant.UserID == userID {
+			return nil
+		}
+		if participant.Position >= position {
+			position = participant.Position + 1
+		}
+	}
+
+	err = s.scheduleRepo.AddParticipant(ctx, &domain.ScheduleRotationParticipant{
+		ID:         uuid.New(),
+		RotationID: rotationID,
+		UserID:     userID,
+		Position:   position,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add participant: %w", err)
+	}
+
+	return nil
+}
+
+// removeParticipant removes the user from the rotation and renumbers the
+// remaining participants so positions stay contiguous. On-call is derived from
+// the participant list, so a removed on-call user is replaced immediately.
+func (s *ScheduleService) removeParticipant(ctx context.Context, rotationID, userID uuid.UUID) error {
+	participants, err := s.scheduleRepo.ListParticipants(ctx, rotationID)
+	if err != nil {
+		return fmt.Errorf("failed to list participants: %w", err)
+	}
+
+	remaining := make([]uuid.UUID, 0, len(participants))
+	found := false
+	for _, participant := range participants {
+		if participant.UserID == userID {
+			found = true
+			continue
+		}
+		remaining = append(remaining, participant.UserID)
+	}
+	if !found {
+		return nil
+	}
+
+	if err := s.scheduleRepo.RemoveParticipant(ctx, rotationID, userID); err != nil {
+		return fmt.Errorf("failed to remove participant: %w", err)
+	}
+	if err := s.scheduleRepo.ReorderParticipants(ctx, rotationID, remaining); err != nil {
+		return fmt.Errorf("failed to renumber participants: %w", err)
+	}
+
+	return nil
+}
+
+// TeamMemberAdded appends a new team member to every rotation backed by the team.
+func (s *ScheduleService) TeamMemberAdded(ctx context.Context, teamID, userID uuid.UUID) error {
+	rotations, err := s.scheduleRepo.ListRotationsByTeam(ctx, teamID)
+	if err != nil {
+		return fmt.Errorf("failed to list team rotations: %w", err)
+	}
+
+	for _, rotation := range rotations {
+		if err := s.appendParticipant(ctx, rotation.ID, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TeamMemberRemoved removes a former team member from every rotation backed by the team.
+func (s *ScheduleService) TeamMemberRemoved(ctx context.Context, teamID, userID uuid.UUID) error {
+	rotations, err := s.scheduleRepo.ListRotationsByTeam(ctx, teamID)
+	if err != nil {
+		return fmt.Errorf("failed to list team rotations: %w", err)
+	}
+
+	for _, rotation := range rotations {
+		if err := s.removeParticipant(ctx, rotation.ID, userID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Overrides
 
-func (s *ScheduleService) CreateOverride(ctx context.Context, scheduleID uuid.UUID, req *dto.CreateOverrideRequest) (*domain.ScheduleOverride, error) {
+// CreateOverride creates a schedule override. Depending on the organization's
+// override membership policy, a user who is neither a participant of the
+// schedule nor a member of its team is rejected or reported in the warnings.
+func (s *ScheduleService) CreateOverride(ctx context.Context, scheduleID uuid.UUID, req *dto.CreateOverrideRequest) (*domain.ScheduleOverride, []string, error) {
 	// Verify user exists
 	_, err := s.userRepo.GetByID(ctx, req.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, nil, fmt.Errorf("user not found")
 	}
 
 	// Parse times
 	startTime, err := time.Parse(time.RFC3339, req.StartTime)
 	if err != nil {
-		return nil, fmt.Errorf("invalid start_time format: %w", err)
+		return nil, nil, fmt.Errorf("invalid start_time format: %w", err)
 	}
 
 	endTime, err := time.Parse(time.RFC3339, req.EndTime)
 	if err != nil {
-		return nil, fmt.Errorf("invalid end_time format: %w", err)
+		return nil, nil, fmt.Errorf("invalid end_time format: %w", err)
 	}
 
 	if endTime.Before(startTime) || endTime.Equal(startTime) {
-		return nil, fmt.Errorf("end_time must be after start_time")
+		return nil, nil, fmt.Errorf("end_time must be after start_time")
+	}
+
+	warnings, err := s.checkOverrideMembership(ctx, scheduleID, req.UserID)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	override := &domain.ScheduleOverride{
@@ -342,10 +761,82 @@ func (s *ScheduleService) CreateOverride(ctx context.Context, scheduleID uuid.UU
 	}
 
 	if err := s.scheduleRepo.CreateOverride(ctx, override); err != nil {
-		return nil, fmt.Errorf("failed to create override: %w", err)
+		return nil, nil, fmt.Errorf("failed to create override: %w", err)
 	}
 
-	return override, nil
+	return override, warnings, nil
+}
+
+// checkOverrideMembership applies the organization's override membership
+// policy to the override user, returning warnings in warn mode.
+func (s *ScheduleService) checkOverrideMembership(ctx context.Context, scheduleID, userID uuid.UUID) ([]string, error) {
+	if s.orgRepo == nil {
+		return nil, nil
+	}
+
+	schedule, err := s.scheduleRepo.GetByID(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, schedule.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	policy := org.OverrideMembershipPolicy()
+	if policy == domain.OverrideMembershipOff {
+		return nil, nil
+	}
+
+	member, err := s.isScheduleMember(ctx, schedule, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member {
+		return nil, nil
+	}
+	if policy == domain.OverrideMembershipStrict {
+		return nil, domain.ErrOverrideNotMember
+	}
+
+	return []string{domain.ErrOverrideNotMember.Error()}, nil
+}
+
+// isScheduleMember reports whether the user participates in one of the
+// schedule's rotations or belongs to the schedule's team.
+func (s *ScheduleService) isScheduleMember(ctx context.Context, schedule *domain.Schedule, userID uuid.UUID) (bool, error) {
+	rotations, err := s.scheduleRepo.ListRotations(ctx, schedule.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list rotations: %w", err)
+	}
+	for _, rotation := range rotations {
+		participants, err := s.scheduleRepo.ListParticipants(ctx, rotation.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to list participants: %w", err)
+		}
+		for _, participant := range participants {
+			if participant.UserID == userID {
+				return true, nil
+			}
+		}
+	}
+
+	if schedule.TeamID == nil || s.teamRepo == nil {
+		return false, nil
+	}
+
+	members, err := s.teamRepo.ListMembers(ctx, *schedule.TeamID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list team members: %w", err)
+	}
+	for _, member := range members {
+		if member.ID == userID {
+			return true, nil
+		}
+	}
+
+	return false, nil
 }
 
 func (s *ScheduleService) GetOverride(ctx context.Context, id uuid.UUID) (*domain.ScheduleOverride, error) {
@@ -422,26 +913,18 @@ func (s *ScheduleService) ListOverrides(ctx context.Context, scheduleID uuid.UUI
 
 // On-call calculation
 
+// GetOnCallUser resolves who is on call for a schedule at the given time.
+// Users who are unavailable then are passed over: an unavailable override
+// falls back to the rotation, and an unavailable rotation participant hands
+// over to the next one in order.
 func (s *ScheduleService) GetOnCallUser(ctx context.Context, scheduleID uuid.UUID, at time.Time) (*domain.OnCallUser, error) {
 	// First, check for overrides
-	overrides, err := s.scheduleRepo.ListOverrides(ctx, scheduleID, at, at.Add(1*time.Second))
+	override, skipped, err := s.overrideOnCall(ctx, scheduleID, at)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check overrides: %w", err)
+		return nil, err
 	}
-
-	if len(overrides) > 0 {
-		// Return the most recent override
-		override := overrides[0]
-		user, _ := s.userRepo.GetByID(ctx, override.UserID)
-
-		return &domain.OnCallUser{
-			UserID:     override.UserID,
-			User:       user,
-			ScheduleID: scheduleID,
-			StartTime:  override.StartTime,
-			EndTime:    override.EndTime,
-			IsOverride: true,
-		}, nil
+	if override != nil {
+		return override, nil
 	}
 
 	// No override, calculate from rotation
@@ -451,7 +934,7 @@ func (s *ScheduleService) GetOnCallUser(ctx context.Context, scheduleID uuid.UUI
 	}
 
 	if len(rotations) == 0 {
-		return nil, fmt.Errorf("no rotations configured for schedule")
+		return nil, fmt.Errorf("%w: no rotations configured for schedule", domain.ErrNoOnCall)
 	}
 
 	// Use the first rotation for simplicity (in production, you'd handle multiple rotations)
@@ -463,35 +946,427 @@ func (s *ScheduleService) GetOnCallUser(ctx context.Context, scheduleID uuid.UUI
 	}
 
 	if len(participants) == 0 {
-		return nil, fmt.Errorf("no participants in rotation")
+		return nil, fmt.Errorf("%w: no participants in rotation", domain.ErrNoOnCall)
+	}
+	if rotation.RotationLength < 1 {
+		return nil, fmt.Errorf("%w: rotation has an invalid length of %d", domain.ErrNoOnCall, rotation.RotationLength)
+	}
+	if at.Before(rotation.StartDate) {
+		return nil, fmt.Errorf("%w: rotation starts on %s", domain.ErrNoOnCall, rotation.StartDate.Format("2006-01-02"))
 	}
 
 	// Calculate who is on-call based on rotation type
 	onCallUser := s.calculateOnCallFromRotation(rotation, participants, at)
 	if onCallUser == nil {
-		return nil, fmt.Errorf("could not determine on-call user")
+		return nil, fmt.Errorf("%w: could not determine on-call user", domain.ErrNoOnCall)
+	}
+
+	onCallUser, err = s.skipUnavailable(ctx, onCallUser, participants, at)
+	if err != nil {
+		return nil, err
 	}
 
 	onCallUser.ScheduleID = scheduleID
 	onCallUser.IsOverride = false
+	onCallUser.SkippedUserIDs = append(skipped, onCallUser.SkippedUserIDs...)
 
 	return onCallUser, nil
 }
 
+// overrideOnCall returns the user an override puts on call for the schedule
+// at the given time. When the override user is unavailable it returns nil and
+// the user as skipped, so the rotation decides instead.
+func (s *ScheduleService) overrideOnCall(ctx context.Context, scheduleID uuid.UUID, at time.Time) (*domain.OnCallUser, []uuid.UUID, error) {
+	overrides, err := s.scheduleRepo.ListOverrides(ctx, scheduleID, at, at.Add(1*time.Second))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check overrides: %w", err)
+	}
+	if len(overrides) == 0 {
+		return nil, nil, nil
+	}
+
+	// Use the most recent override
+	override := overrides[0]
+	unavailable, err := s.unavailableUsers(ctx, []uuid.UUID{override.UserID}, at)
+	if err != nil {
+		return nil, nil, err
+	}
+	if unavailable[override.UserID] != nil {
+		return nil, []uuid.UUID{override.UserID}, nil
+	}
+
+	user, _ := s.userRepo.GetByID(ctx, override.UserID)
+	return &domain.OnCallUser{
+		UserID:     override.UserID,
+		User:       user,
+		ScheduleID: scheduleID,
+		StartTime:  override.StartTime,
+		EndTime:    override.EndTime,
+		IsOverride: true,
+	}, nil, nil
+}
+
+// rotationOnCall resolves who covers the rotation at the given time the way
+// GetOnCallUser does: an override of the schedule wins, and an unavailable
+// participant hands the shift to the next available one. It returns nil when
+// nobody is on call.
+func (s *ScheduleService) rotationOnCall(
+	ctx context.Context,
+	rotation *domain.ScheduleRotation,
+	participants []*domain.ParticipantWithUser,
+	at time.Time,
+) (*domain.OnCallUser, error) {
+	override, skipped, err := s.overrideOnCall(ctx, rotation.ScheduleID, at)
+	if err != nil {
+		return nil, err
+	}
+	if override != nil {
+		return override, nil
+	}
+
+	onCall := s.calculateOnCallFromRotation(rotation, participants, at)
+	if onCall == nil {
+		return nil, nil
+	}
+
+	onCall, err = s.skipUnavailable(ctx, onCall, participants, at)
+	if errors.Is(err, domain.ErrNoOnCall) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	onCall.ScheduleID = rotation.ScheduleID
+	onCall.SkippedUserIDs = append(skipped, onCall.SkippedUserIDs...)
+	return onCall, nil
+}
+
+// unavailableUsers returns which of userIDs are unavailable at the given time
+func (s *ScheduleService) unavailableUsers(ctx context.Context, userIDs []uuid.UUID, at time.Time) (map[uuid.UUID]*domain.UserUnavailability, error) {
+	if s.availability == nil {
+		return map[uuid.UUID]*domain.UserUnavailability{}, nil
+	}
+
+	unavailable, err := s.availability.UnavailableAt(ctx, userIDs, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check availability: %w", err)
+	}
+	return unavailable, nil
+}
+
+// skipUnavailable hands the shift to the next available participant, in
+// rotation order, when the computed on-call user is unavailable
+func (s *ScheduleService) skipUnavailable(
+	ctx context.Context,
+	onCall *domain.OnCallUser,
+	participants []*domain.ParticipantWithUser,
+	at time.Time,
+) (*domain.OnCallUser, error) {
+	userIDs := make([]uuid.UUID, len(participants))
+	first := 0
+	for i, participant := range participants {
+		userIDs[i] = participant.UserID
+		if participant.UserID == onCall.UserID {
+			first = i
+		}
+	}
+
+	unavailable, err := s.unavailableUsers(ctx, userIDs, at)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range participants {
+		participant := participants[(first+i)%len(participants)]
+		if unavailable[participant.UserID] == nil {
+			onCall.UserID = participant.UserID
+			onCall.User = &participant.User
+			return onCall, nil
+		}
+		onCall.SkippedUserIDs = append(onCall.SkippedUserIDs, participant.UserID)
+	}
+
+	return nil, fmt.Errorf("%w: no available participants in rotation", domain.ErrNoOnCall)
+}
+
+// ListOnCall resolves the on-call user of every schedule in the organization.
+// Schedules with nobody on call are returned with a nil OnCall; any other
+// failure to resolve a schedule fails the whole call.
+func (s *ScheduleService) ListOnCall(ctx context.Context, orgID uuid.UUID, at time.Time) ([]*domain.ScheduleOnCall, error) {
+	const pageSize = 100
+
+	result := []*domain.ScheduleOnCall{}
+	for offset := 0; ; offset += pageSize {
+		schedules, err := s.scheduleRepo.List(ctx, orgID, pageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list schedules: %w", err)
+		}
+
+		for _, schedule := range schedules {
+			entry := &domain.ScheduleOnCall{Schedule: schedule}
+			onCall, err := s.GetOnCallUser(ctx, schedule.ID, at)
+			if err != nil && !errors.Is(err, domain.ErrNoOnCall) {
+				return nil, fmt.Errorf("failed to resolve on-call user for schedule %s: %w", schedule.ID, err)
+			}
+			entry.OnCall = onCall
+			result = append(result, entry)
+		}
+
+		if len(schedules) < pageSize {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// PreviewOnCall computes the shifts a rotation would produce between the requested
+// times without persisting anything.
+func (s *ScheduleService) PreviewOnCall(ctx context.Context, req *dto.PreviewOnCallRequest) ([]*domain.OnCallUser, error) {
+	rotation, err := newRotation(uuid.Nil, &req.Rotation)
+	if err != nil {
+		return nil, err
+	}
+	if rotation.RotationLength < 1 {
+		return nil, fmt.Errorf("rotation_length must be positive")
+	}
+
+	start, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start_time format: %w", err)
+	}
+	end, err := time.Parse(time.RFC3339, req.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end_time format: %w", err)
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("end_time must be after start_time")
+	}
+	if end.Sub(start) > maxPreviewWindow {
+		return nil, fmt.Errorf("preview range must not exceed 366 days")
+	}
+
+	participants := make([]*domain.ParticipantWithUser, 0, len(req.Participants))
+	for i, userID := range req.Participants {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("user %s not found", userID)
+		}
+		participants = append(participants, &domain.ParticipantWithUser{
+			ScheduleRotationParticipant: domain.ScheduleRotationParticipant{
+				UserID:   userID,
+				Position: i,
+			},
+			User: *user,
+		})
+	}
+
+	return s.calculateShifts(rotation, participants, start, end), nil
+}
+
+// GetCoverage walks the computed shifts of every rotation and the overrides of
+// a schedule between start and end, and reports gaps with no one on call and
+// overlaps with several people on call. An override replaces the rotations
+// for as long as it lasts.
+func (s *ScheduleService) GetCoverage(ctx context.Context, orgID, scheduleID uuid.UUID, start, end time.Time) (*domain.ScheduleCoverage, error) {
+	if !end.After(start) {
+		return nil, fmt.Errorf("%w: end must be after start", domain.ErrInvalidTimeRange)
+	}
+	if end.Sub(start) > maxPreviewWindow {
+		return nil, fmt.Errorf("%w: coverage window must not exceed 366 days", domain.ErrInvalidTimeRange)
+	}
+
+	schedule, err := s.scheduleRepo.GetByID(ctx, scheduleID)
+	if err != nil || schedule.OrganizationID != orgID {
+		return nil, domain.ErrNotFound
+	}
+
+	rotations, err := s.scheduleRepo.ListRotations(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rotations: %w", err)
+	}
+
+	var shifts []*domain.OnCallUser
+	for _, rotation := range rotations {
+		participants, err := s.scheduleRepo.ListParticipants(ctx, rotation.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get participants: %w", err)
+		}
+		shifts = append(shifts, s.calculateShifts(rotation, participants, start, end)...)
+	}
+
+	overrides, err := s.scheduleRepo.ListOverrides(ctx, scheduleID, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overrides: %w", err)
+	}
+	overrideShifts := make([]*domain.OnCallUser, len(overrides))
+	for i, override := range overrides {
+		overrideShifts[i] = &domain.OnCallUser{
+			UserID:     override.UserID,
+			StartTime:  override.StartTime,
+			EndTime:    override.EndTime,
+			IsOverride: true,
+		}
+	}
+
+	// Every shift boundary inside the window starts a new segment in which
+	// the set of on-call users is constant
+	bounds := []time.Time{start, end}
+	for _, shift := range append(shifts, overrideShifts...) {
+		for _, t := range []time.Time{shift.StartTime, shift.EndTime} {
+			if t.After(start) && t.Before(end) {
+				bounds = append(bounds, t)
+			}
+		}
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i].Before(bounds[j]) })
+
+	coverage := &domain.ScheduleCoverage{
+		ScheduleID: scheduleID,
+		Start:      start,
+		End:        end,
+		Gaps:       []domain.CoverageGap{},
+		Overlaps:   []domain.CoverageOverlap{},
+	}
+	for i := 0; i+1 < len(bounds); i++ {
+		from, to := bounds[i], bounds[i+1]
+		if !to.After(from) {
+			continue
+		}
+
+		onCall := usersOnCall(overrideShifts, from)
+		if len(onCall) == 0 {
+			onCall = usersOnCall(shifts, from)
+		}
+
+		switch {
+		case len(onCall) == 0:
+			if last := len(coverage.Gaps) - 1; last >= 0 && coverage.Gaps[last].End.Equal(from) {
+				coverage.Gaps[last].End = to
+			} else {
+				coverage.Gaps = append(coverage.Gaps, domain.CoverageGap{Start: from, End: to})
+			}
+		case len(onCall) > 1:
+			if last := len(coverage.Overlaps) - 1; last >= 0 && coverage.Overlaps[last].End.Equal(from) &&
+				sameUsers(coverage.Overlaps[last].UserIDs, onCall) {
+				coverage.Overlaps[last].End = to
+			} else {
+				coverage.Overlaps = append(coverage.Overlaps, domain.CoverageOverlap{Start: from, End: to, UserIDs: onCall})
+			}
+		}
+	}
+
+	return coverage, nil
+}
+
+// usersOnCall returns the distinct users whose shift covers at, sorted so
+// that sets can be compared
+func usersOnCall(shifts []*domain.OnCallUser, at time.Time) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool)
+	var users []uuid.UUID
+	for _, shift := range shifts {
+		if at.Before(shift.StartTime) || !at.Before(shift.EndTime) || seen[shift.UserID] {
+			continue
+		}
+		seen[shift.UserID] = true
+		users = append(users, shift.UserID)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].String() < users[j].String() })
+	return users
+}
+
+func sameUsers(a, b []uuid.UUID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// calculateShifts walks a rotation day by day between from and to, merging
+// consecutive days with the same on-call user into a single shift.
+func (s *ScheduleService) calculateShifts(
+	rotation *domain.ScheduleRotation,
+	participants []*domain.ParticipantWithUser,
+	from, to time.Time,
+) []*domain.OnCallUser {
+	shifts := []*domain.OnCallUser{}
+
+	t := from
+	if t.Before(rotation.StartDate) {
+		t = rotation.StartDate
+	}
+	for t.Before(to) {
+		daysSinceStart := int(t.Sub(rotation.StartDate).Hours() / 24)
+		next := rotation.StartDate.Add(time.Duration(daysSinceStart+1) * 24 * time.Hour)
+		if next.After(to) {
+			next = to
+		}
+
+		onCall := s.calculateOnCallFromRotation(rotation, participants, t)
+		if onCall != nil {
+			last := len(shifts) - 1
+			if last >= 0 && shifts[last].UserID == onCall.UserID && shifts[last].EndTime.Equal(t) {
+				shifts[last].EndTime = next
+			} else {
+				shifts = append(shifts, &domain.OnCallUser{
+					UserID:    onCall.UserID,
+					User:      onCall.User,
+					StartTime: t,
+					EndTime:   next,
+				})
+			}
+		}
+
+		t = next
+	}
+
+	return shifts
+}
+
+// shiftBounds returns the boundaries of the rotation shift containing at, using
+// the same day-based periods as calculateOnCallFromRotation.
+func shiftBounds(rotation *domain.ScheduleRotation, at time.Time) (start, end time.Time, ok bool) {
+	if rotation.RotationLength < 1 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	daysSinceStart := int(at.Sub(rotation.StartDate).Hours() / 24)
+	if daysSinceStart < 0 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	periodDays := rotation.RotationLength
+	if rotation.RotationType == domain.RotationTypeWeekly {
+		periodDays *= 7
+	}
+
+	start = rotation.StartDate.AddDate(0, 0, (daysSinceStart/periodDays)*periodDays)
+	return start, start.AddDate(0, 0, periodDays), true
+}
+
 func (s *ScheduleService) calculateOnCallFromRotation(
 	rotation *domain.ScheduleRotation,
 	participants []*domain.ParticipantWithUser,
 	at time.Time,
 ) *domain.OnCallUser {
-	if len(participants) == 0 {
+	// Degenerate rotations have no one on call rather than breaking the
+	// index math below
+	if len(participants) == 0 || rotation.RotationLength < 1 {
 		return nil
 	}
 
-	// Calculate days since rotation start
-	daysSinceStart := int(at.Sub(rotation.StartDate).Hours() / 24)
-	if daysSinceStart < 0 {
-		return nil // Before rotation starts
+	// Checked on the time rather than the day count, which truncates towards
+	// zero and would put the day before the start on call
+	if at.Before(rotation.StartDate) {
+		return nil
 	}
+	daysSinceStart := int(at.Sub(rotation.StartDate).Hours() / 24)
 
 	// Calculate which participant based on rotation type
 	var participantIndex int
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,11 +15,30 @@ import (
 	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
 )
 
+// invitationTTL is how long a team invitation can be accepted after it was sent
+const invitationTTL = 7 * 24 * time.Hour
+
 type TeamService struct {
 	teamRepo       outbound.TeamRepository
 	userRepo       outbound.UserRepository
 	invitationRepo outbound.TeamInvitationRepository
 	emailService   EmailServiceInterface
+	membershipSync TeamMembershipSync
+
+	orgRepo          outbound.OrganizationRepository
+	invitationSignup InvitationSignup
+}
+
+// InvitationSignup creates accounts for invitees that don't have one yet
+type InvitationSignup interface {
+	RegisterInvitedUser(ctx context.Context, orgID uuid.UUID, email, username, password, fullName string) (*dto.AuthResponse, error)
+}
+
+// TeamMembershipSync is notified after team membership changes, e.g. to keep
+// team-backed rotations in sync
+type TeamMembershipSync interface {
+	TeamMemberAdded(ctx context.Context, teamID, userID uuid.UUID) error
+	TeamMemberRemoved(ctx context.Context, teamID, userID uuid.UUID) error
 }
 
 // EmailServiceInterface defines the interface for sending emails
@@ -38,6 +58,22 @@ func (s *TeamService) SetInvitationRepo(repo outbound.TeamInvitationRepository)
 	s.invitationRepo = repo
 }
 
+// SetMembershipSync sets the membership sync hook (optional dependency)
+func (s *TeamService) SetMembershipSync(sync TeamMembershipSync) {
+	s.membershipSync = sync
+}
+
+// SetOrganizationRepo sets the organization repository used to add invitees
+// to the inviting organization (optional dependency)
+func (s *TeamService) SetOrganizationRepo(repo outbound.OrganizationRepository) {
+	s.orgRepo = repo
+}
+
+// SetInvitationSignup sets the account creator for new invitees (optional dependency)
+func (s *TeamService) SetInvitationSignup(signup InvitationSignup) {
+	s.invitationSignup = signup
+}
+
 // SetEmailService sets the email service (optional dependency)
 func (s *TeamService) SetEmailService(emailSvc EmailServiceInterface) {
 	s.emailService = emailSvc
@@ -105,8 +141,27 @@ func (s *TeamService) UpdateTeam(ctx context.Context, id uuid.UUID, req *dto.Upd
 	return team, nil
 }
 
-func (s *TeamService) DeleteTeam(ctx context.Context, id uuid.UUID) error {
-	if err := s.teamRepo.Delete(ctx, id); err != nil {
+// DeleteTeam deletes a team. A team that is still referenced by schedules or
+// escalation targets is only deleted when force is set, in which case the
+// schedules are detached and the escalation targets removed.
+func (s *TeamService) DeleteTeam(ctx context.Context, id uuid.UUID, force bool) error {
+	dependencies, err := s.teamRepo.ListDependencies(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if len(dependencies) == 0 {
+		if err := s.teamRepo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete team: %w", err)
+		}
+		return nil
+	}
+
+	if !force {
+		return &domain.TeamInUseError{Dependencies: dependencies}
+	}
+
+	if err := s.teamRepo.ForceDelete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete team: %w", err)
 	}
 
@@ -151,7 +206,7 @@ func (s *TeamService) AddMember(ctx context.Context, teamID uuid.UUID, req *dto.
 		if err := s.teamRepo.AddMember(ctx, teamID, *req.UserID, role); err != nil {
 			return fmt.Errorf("failed to add team member: %w", err)
 		}
-		return nil
+		return s.memberAdded(ctx, teamID, *req.UserID)
 	}
 
 	// If email is provided, try to find the user
@@ -162,7 +217,7 @@ func (s *TeamService) AddMember(ctx context.Context, teamID uuid.UUID, req *dto.
 			if err := s.teamRepo.AddMember(ctx, teamID, user.ID, role); err != nil {
 				return fmt.Errorf("failed to add team member: %w", err)
 			}
-			return nil
+			return s.memberAdded(ctx, teamID, user.ID)
 		}
 		// User not found - return error suggesting to use invite endpoint
 		return fmt.Errorf("user not found with email %s, use invite endpoint to send invitation", req.Email)
@@ -186,6 +241,9 @@ func (s *TeamService) AddMemberOrInvite(ctx context.Context, teamID, orgID, invi
 		if err := s.teamRepo.AddMember(ctx, teamID, user.ID, role); err != nil {
 			return nil, fmt.Errorf("failed to add team member: %w", err)
 		}
+		if err := s.memberAdded(ctx, teamID, user.ID); err != nil {
+			return nil, err
+		}
 		return &dto.InvitationResponse{
 			UserAdded: true,
 			Invited:   false,
@@ -200,13 +258,19 @@ func (s *TeamService) AddMemberOrInvite(ctx context.Context, teamID, orgID, invi
 
 	// Check for existing pending invitation
 	existingInvite, _ := s.invitationRepo.GetByEmailAndTeam(ctx, req.Email, teamID)
-	if existingInvite != nil && existingInvite.IsValid() {
-		return &dto.InvitationResponse{
-			UserAdded:  false,
-			Invited:    true,
-			Invitation: existingInvite,
-			Message:    "Invitation already sent to this email",
-		}, nil
+	if existingInvite != nil {
+		if existingInvite.IsValid() {
+			return &dto.InvitationResponse{
+				UserAdded:  false,
+				Invited:    true,
+				Invitation: existingInvite,
+				Message:    "Invitation already sent to this email",
+			}, nil
+		}
+		// Only one pending invitation may exist per email and team
+		if err := s.expireInvitation(ctx, existingInvite); err != nil {
+			return nil, err
+		}
 	}
 
 	// Generate invitation token
@@ -226,7 +290,7 @@ func (s *TeamService) AddMemberOrInvite(ctx context.Context, teamID, orgID, invi
 		Token:          token,
 		Status:         domain.InvitationStatusPending,
 		InvitedByID:    inviterID,
-		ExpiresAt:      time.Now().Add(7 * 24 * time.Hour), // 7 days expiry
+		ExpiresAt:      time.Now().Add(invitationTTL),
 	}
 
 	if err := s.invitationRepo.Create(ctx, invitation); err != nil {
@@ -261,33 +325,135 @@ func (s *TeamService) AddMemberOrInvite(ctx context.Context, teamID, orgID, invi
 
 // AcceptInvitation accepts a team invitation
 func (s *TeamService) AcceptInvitation(ctx context.Context, token string, userID uuid.UUID) error {
+	invitation, err := s.validInvitation(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.acceptInvitation(ctx, invitation.TeamInvitation, userID)
+	return err
+}
+
+// RedeemInvitation accepts an invitation on behalf of the invitee identified by
+// the token. An authenticated caller must own the invited email; an invitee
+// without an account gets one in the inviting organization.
+func (s *TeamService) RedeemInvitation(ctx context.Context, req *dto.AcceptInvitationRequest, callerID *uuid.UUID) (*dto.AcceptInvitationResponse, error) {
+	invitation, err := s.validInvitation(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.AcceptInvitationResponse{TeamID: invitation.TeamID}
+
+	var user *domain.User
+	if callerID != nil {
+		user, err = s.userRepo.GetByID(ctx, *callerID)
+		if err != nil {
+			return nil, fmt.Errorf("user not found")
+		}
+		if !strings.EqualFold(user.Email, invitation.Email) {
+			return nil, domain.ErrInvitationEmailMismatch
+		}
+	} else {
+		user, _ = s.userRepo.GetByEmail(ctx, invitation.Email)
+		if user != nil {
+			return nil, domain.ErrInvitationLoginRequired
+		}
+		if s.invitationSignup == nil {
+			return nil, fmt.Errorf("invitation signup not configured")
+		}
+		if req.Username == "" || req.Password == "" {
+			return nil, fmt.Errorf("username and password are required to create an account")
+		}
+		auth, err := s.invitationSignup.RegisterInvitedUser(ctx, invitation.OrganizationID, invitation.Email, req.Username, req.Password, req.FullName)
+		if err != nil {
+			return nil, err
+		}
+		user = auth.User
+		resp.Auth = auth
+	}
+
+	if err := s.joinOrganization(ctx, invitation.OrganizationID, user.ID); err != nil {
+		return nil, err
+	}
+
+	resp.AlreadyMember, err = s.acceptInvitation(ctx, invitation.TeamInvitation, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// validInvitation looks up an invitation by token and checks it can still be accepted
+func (s *TeamService) validInvitation(ctx context.Context, token string) (*domain.TeamInvitationWithDetails, error) {
 	if s.invitationRepo == nil {
-		return fmt.Errorf("invitation feature not configured")
+		return nil, fmt.Errorf("invitation feature not configured")
 	}
 
 	invitation, err := s.invitationRepo.GetByToken(ctx, token)
 	if err != nil {
-		return fmt.Errorf("invitation not found")
+		return nil, fmt.Errorf("invitation not found")
 	}
 
 	if !invitation.IsValid() {
-		if invitation.IsExpired() {
-			return fmt.Errorf("invitation has expired")
+		if invitation.Status == domain.InvitationStatusExpired || invitation.IsExpired() {
+			if err := s.expireInvitation(ctx, invitation.TeamInvitation); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("invitation has expired")
+		}
+		return nil, fmt.Errorf("invitation is no longer valid")
+	}
+
+	return invitation, nil
+}
+
+// acceptInvitation adds the user to the invitation's team and marks it accepted.
+// It reports whether the user already was a member of the team.
+func (s *TeamService) acceptInvitation(ctx context.Context, invitation *domain.TeamInvitation, userID uuid.UUID) (bool, error) {
+	members, err := s.teamRepo.ListMembers(ctx, invitation.TeamID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list team members: %w", err)
+	}
+
+	alreadyMember := false
+	for _, member := range members {
+		if member.ID == userID {
+			alreadyMember = true
+			break
 		}
-		return fmt.Errorf("invitation is no longer valid")
 	}
 
-	// Add user to team
-	if err := s.teamRepo.AddMember(ctx, invitation.TeamID, userID, invitation.Role); err != nil {
-		return fmt.Errorf("failed to add team member: %w", err)
+	if !alreadyMember {
+		if err := s.teamRepo.AddMember(ctx, invitation.TeamID, userID, invitation.Role); err != nil {
+			return false, fmt.Errorf("failed to add team member: %w", err)
+		}
+		if err := s.memberAdded(ctx, invitation.TeamID, userID); err != nil {
+			return false, err
+		}
 	}
 
 	// Update invitation status
 	invitation.Status = domain.InvitationStatusAccepted
-	if err := s.invitationRepo.Update(ctx, invitation.TeamInvitation); err != nil {
-		return fmt.Errorf("failed to update invitation: %w", err)
+	if err := s.invitationRepo.Update(ctx, invitation); err != nil {
+		return false, fmt.Errorf("failed to update invitation: %w", err)
 	}
 
+	return alreadyMember, nil
+}
+
+// joinOrganization adds the user to the organization as a member unless they already belong to it
+func (s *TeamService) joinOrganization(ctx context.Context, orgID, userID uuid.UUID) error {
+	if s.orgRepo == nil {
+		return nil
+	}
+	if _, err := s.orgRepo.GetUserRole(ctx, orgID, userID); err == nil {
+		return nil
+	}
+	if err := s.orgRepo.AddUser(ctx, orgID, userID, domain.RoleMember); err != nil {
+		return fmt.Errorf("failed to add user to organization: %w", err)
+	}
 	return nil
 }
 
@@ -323,9 +489,35 @@ func (s *TeamService) ListTeamInvitations(ctx context.Context, teamID uuid.UUID)
 	if s.invitationRepo == nil {
 		return nil, fmt.Errorf("invitation feature not configured")
 	}
+	if err := s.ExpireInvitations(ctx); err != nil {
+		return nil, err
+	}
 	return s.invitationRepo.ListByTeam(ctx, teamID)
 }
 
+// ExpireInvitations marks pending invitations past their expiry as expired
+func (s *TeamService) ExpireInvitations(ctx context.Context) error {
+	if s.invitationRepo == nil {
+		return nil
+	}
+	if err := s.invitationRepo.ExpireOldInvitations(ctx); err != nil {
+		return fmt.Errorf("failed to expire invitations: %w", err)
+	}
+	return nil
+}
+
+// expireInvitation marks a single stale invitation as expired
+func (s *TeamService) expireInvitation(ctx context.Context, invitation *domain.TeamInvitation) error {
+	if invitation.Status == domain.InvitationStatusExpired {
+		return nil
+	}
+	invitation.Status = domain.InvitationStatusExpired
+	if err := s.invitationRepo.Update(ctx, invitation); err != nil {
+		return fmt.Errorf("failed to expire invitation: %w", err)
+	}
+	return nil
+}
+
 // CancelInvitation cancels a pending invitation
 func (s *TeamService) CancelInvitation(ctx context.Context, invitationID uuid.UUID) error {
 	if s.invitationRepo == nil {
@@ -345,8 +537,15 @@ func (s *TeamService) ResendInvitation(ctx context.Context, invitationID uuid.UU
 		return fmt.Errorf("invitation not found")
 	}
 
-	if invitation.Status != domain.InvitationStatusPending {
-		return fmt.Errorf("can only resend pending invitations")
+	if invitation.Status != domain.InvitationStatusPending && invitation.Status != domain.InvitationStatusExpired {
+		return fmt.Errorf("can only resend pending or expired invitations")
+	}
+
+	// Resending restarts the acceptance window
+	invitation.Status = domain.InvitationStatusPending
+	invitation.ExpiresAt = time.Now().Add(invitationTTL)
+	if err := s.invitationRepo.Update(ctx, invitation); err != nil {
+		return fmt.Errorf("failed to update invitation: %w", err)
 	}
 
 	// Send invitation email
@@ -374,6 +573,25 @@ func (s *TeamService) RemoveMember(ctx context.Context, teamID, userID uuid.UUID
 		return fmt.Errorf("failed to remove team member: %w", err)
 	}
 
+	if s.membershipSync != nil {
+		if err := s.membershipSync.TeamMemberRemoved(ctx, teamID, userID); err != nil {
+			return fmt.Errorf("team member removed but failed to sync rotations: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// memberAdded propagates a new team member to the membership sync hook.
+func (s *TeamService) memberAdded(ctx context.Context, teamID, userID uuid.UUID) error {
+	if s.membershipSync == nil {
+		return nil
+	}
+
+	if err := s.membershipSync.TeamMemberAdded(ctx, teamID, userID); err != nil {
+		return fmt.Errorf("team member added but failed to sync rotations: %w", err)
+	}
+
 	return nil
 }
 
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -12,18 +14,175 @@ import (
 )
 
 type UserService struct {
-	orgRepo  outbound.OrganizationRepository
-	userRepo outbound.UserRepository
+	orgRepo          outbound.OrganizationRepository
+	userRepo         outbound.UserRepository
+	loginAttemptRepo outbound.LoginAttemptRepository
+	sessionRepo      outbound.SessionRepository
 }
 
 func NewUserService(orgRepo outbound.OrganizationRepository, userRepo outbound.UserRepository) *UserService {
 	return &UserService{orgRepo: orgRepo, userRepo: userRepo}
 }
 
-func (s *UserService) ListOrganizationUsers(ctx context.Context, orgID uuid.UUID) ([]*domain.UserWithOrganization, error) {
-	return s.orgRepo.ListUsers(ctx, orgID)
+// SetLoginAttemptRepo enables unlocking accounts locked after failed logins
+func (s *UserService) SetLoginAttemptRepo(repo outbound.LoginAttemptRepository) {
+	s.loginAttemptRepo = repo
 }
 
+func (s *UserService) ListOrganizationUsers(ctx context.Context, orgID uuid.UUID, req *dto.ListUsersRequest) (*dto.ListUsersResponse, error) {
+	// Set defaults; pickers without paging still get a full first page
+	page := req.Page
+	if page < 1 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 100
+	}
+
+	var search *string
+	if req.Search != nil {
+		if trimmed := strings.TrimSpace(*req.Search); trimmed != "" {
+			search = &trimmed
+		}
+	}
+
+	filter := &domain.UserFilter{
+		OrganizationID: orgID,
+		Search:         search,
+		TeamID:         req.TeamID,
+		IsActive:       req.IsActive,
+		Limit:          pageSize,
+		Offset:         (page - 1) * pageSize,
+	}
+
+	users, total, err := s.orgRepo.SearchUsers(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	if users == nil {
+		users = []*domain.UserWithOrganization{}
+	}
+
+	return &dto.ListUsersResponse{
+		Users:    users,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// DeactivateUser disables a user who left the organization. They can no longer
+// sign in to it and are removed from its teams, rotations and upcoming
+// overrides; open alerts assigned to them are unassigned with a note. Anything
+// they authored stays in place, and their other organizations are unaffected.
+func (s *UserService) DeactivateUser(ctx context.Context, orgID, userID, actorID uuid.UUID) (*domain.UserDeactivation, error) {
+	if userID == actorID {
+		return nil, domain.ErrCannotDeactivateSelf
+	}
+
+	role, _, err := s.orgRepo.GetMembership(ctx, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrNotFound, err)
+	}
+	if role == domain.RoleOwner {
+		return nil, domain.ErrCannotDeactivateOwner
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrNotFound, err)
+	}
+
+	note := fmt.Sprintf("Unassigned because %s was deactivated", user.Username)
+	deactivation, err := s.userRepo.Deactivate(ctx, orgID, userID, actorID, note)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deactivate user: %w", err)
+	}
+
+	return deactivation, nil
+}
+
+// ReactivateUser restores a deactivated user's ability to sign in to the
+// organization. Team and rotation memberships removed on deactivation are not
+// restored.
+func (s *UserService) ReactivateUser(ctx context.Context, orgID, userID uuid.UUID) (*domain.User, error) {
+	_, active, err := s.orgRepo.GetMembership(ctx, orgID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrNotFound, err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrNotFound, err)
+	}
+
+	if !active {
+		if err := s.orgRepo.SetMemberActive(ctx, orgID, userID, true); err != nil {
+			return nil, fmt.Errorf("failed to reactivate user: %w", err)
+		}
+	}
+
+	return user, nil
+}
+
+// SetSessionRepo enables listing and revoking other users' sessions
+func (s *UserService) SetSessionRepo(repo outbound.SessionRepository) {
+	s.sessionRepo = repo
+}
+
+// ListUserSessions returns a member's active sessions signed in to the
+// organization. Sessions in their other organizations are not shown.
+func (s *UserService) ListUserSessions(ctx context.Context, orgID, userID uuid.UUID) ([]*dto.SessionResponse, error) {
+	if _, _, err := s.orgRepo.GetMembership(ctx, orgID, userID); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrNotFound, err)
+	}
+	if s.sessionRepo == nil {
+		return nil, fmt.Errorf("sessions are not configured")
+	}
+
+	sessions, err := s.sessionRepo.ListActiveInOrganization(ctx, userID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sessionResponses(sessions, uuid.Nil), nil
+}
+
+// RevokeUserSessions signs a member out of every session in the organization
+// and returns how many sessions were revoked. Sessions in their other
+// organizations are left alone.
+func (s *UserService) RevokeUserSessions(ctx context.Context, orgID, userID uuid.UUID) (int, error) {
+	if _, _, err := s.orgRepo.GetMembership(ctx, orgID, userID); err != nil {
+		return 0, fmt.Errorf("%w: %v", domain.ErrNotFound, err)
+	}
+	if s.sessionRepo == nil {
+		return 0, fmt.Errorf("sessions are not configured")
+	}
+
+	return s.sessionRepo.RevokeAllInOrganization(ctx, userID, orgID)
+}
+
+// UnlockUser lifts a lockout caused by repeated failed logins and clears the
+// user's failed attempts
+func (s *UserService) UnlockUser(ctx context.Context, orgID, userID uuid.UUID) error {
+	if _, err := s.orgRepo.GetUserRole(ctx, orgID, userID); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrNotFound, err)
+	}
+	if s.loginAttemptRepo == nil {
+		return fmt.Errorf("login lockouts are not configured")
+	}
+
+	if err := s.loginAttemptRepo.ResetLockout(ctx, userID); err != nil {
+		return fmt.Errorf("failed to unlock user: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateProfile updates the user's own contact details. Email and role are
+// not editable here.
 func (s *UserService) UpdateProfile(ctx context.Context, userID uuid.UUID, req *dto.UpdateProfileRequest) (*domain.User, error) {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -31,14 +190,39 @@ func (s *UserService) UpdateProfile(ctx context.Context, userID uuid.UUID, req *
 	}
 
 	if req.FullName != nil {
-		user.FullName = req.FullName
+		user.FullName = optionalString(*req.FullName)
 	}
 	if req.Phone != nil {
-		user.Phone = req.Phone
+		// An empty phone clears it
+		phone := strings.TrimSpace(*req.Phone)
+		if phone != "" && !domain.IsValidPhone(phone) {
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidPhone, phone)
+		}
+		user.Phone = optionalString(phone)
 	}
 	if req.Timezone != nil {
+		if *req.Timezone == "" {
+			return nil, domain.ErrInvalidTimezone
+		}
+		if _, err := time.LoadLocation(*req.Timezone); err != nil {
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidTimezone, *req.Timezone)
+		}
 		user.Timezone = *req.Timezone
 	}
+	if req.PrimaryChannelOnlyBelow != nil {
+		if user.NotificationPreferences == nil {
+			user.NotificationPreferences = make(map[string]interface{})
+		}
+		priority := domain.AlertPriority(*req.PrimaryChannelOnlyBelow)
+		switch {
+		case priority == "":
+			delete(user.NotificationPreferences, domain.UserPrefPrimaryChannelOnlyBelow)
+		case priority.IsValid():
+			user.NotificationPreferences[domain.UserPrefPrimaryChannelOnlyBelow] = priority.String()
+		default:
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidPriority, priority)
+		}
+	}
 
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		return nil, fmt.Errorf("failed to update profile: %w", err)
@@ -46,3 +230,12 @@ func (s *UserService) UpdateProfile(ctx context.Context, userID uuid.UUID, req *
 
 	return user, nil
 }
+
+// optionalString trims s and returns nil when nothing is left
+func optionalString(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -11,8 +11,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -20,31 +23,85 @@ import (
 	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/dto"
 	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/jsonpath"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/logger"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/requestid"
 	"github.com/nmn3m/pulsar/backend/internal/pkg/urlvalidation"
 )
 
+// defaultDeliveryConcurrency is how many endpoints the delivery worker sends
+// to at once unless configured otherwise
+const defaultDeliveryConcurrency = 10
+
 type WebhookService struct {
-	webhookRepo outbound.WebhookRepository
-	logger      *zap.Logger
-	httpClient  *http.Client
+	webhookRepo         outbound.WebhookRepository
+	logger              *zap.Logger
+	httpClient          *http.Client
+	deliveryConcurrency int
+
+	endpointsMu sync.Mutex
+	endpoints   map[uuid.UUID]*endpointQueue
+}
+
+// endpointQueue serializes what this process sends to one endpoint. Immediate
+// deliveries wait in pending and are drained by a single goroutine, and sendMu
+// is held around every send, immediate or from the worker.
+type endpointQueue struct {
+	sendMu sync.Mutex
+
+	mu       sync.Mutex
+	pending  []queuedDelivery
+	draining bool
+}
+
+type queuedDelivery struct {
+	ctx      context.Context
+	endpoint *domain.WebhookEndpoint
+	delivery *domain.WebhookDelivery
+	payload  *domain.WebhookPayload
 }
 
-func NewWebhookService(webhookRepo outbound.WebhookRepository, logger *zap.Logger) *WebhookService {
+func NewWebhookService(webhookRepo outbound.WebhookRepository, log *zap.Logger) *WebhookService {
 	return &WebhookService{
 		webhookRepo: webhookRepo,
-		logger:      logger,
+		logger:      log,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		deliveryConcurrency: defaultDeliveryConcurrency,
+		endpoints:           make(map[uuid.UUID]*endpointQueue),
+	}
+}
+
+// SetDeliveryConcurrency caps how many endpoints ProcessPendingDeliveries
+// sends to at once. Deliveries to the same endpoint are always sent one at a
+// time, in the order they were created.
+func (s *WebhookService) SetDeliveryConcurrency(n int) {
+	if n > 0 {
+		s.deliveryConcurrency = n
 	}
 }
 
+// SetTransport sets the HTTP transport deliveries are sent through (optional
+// dependency). Without it the default transport is used.
+func (s *WebhookService) SetTransport(transport http.RoundTripper) {
+	s.httpClient.Transport = transport
+}
+
+// log returns the service logger annotated with the request ID from ctx
+func (s *WebhookService) log(ctx context.Context) *zap.Logger {
+	return logger.WithContext(ctx, s.logger)
+}
+
 // Endpoint Management
 
 func (s *WebhookService) CreateEndpoint(ctx context.Context, orgID uuid.UUID, req *dto.CreateWebhookEndpointRequest) (*domain.WebhookEndpoint, error) {
 	if err := urlvalidation.ValidateWebhookURL(req.URL); err != nil {
 		return nil, fmt.Errorf("invalid webhook URL: %w", err)
 	}
+	if err := validateWebhookEvents(req.Events); err != nil {
+		return nil, err
+	}
 
 	// Generate a secure secret for HMAC signing
 	secret, err := generateSecret()
@@ -59,6 +116,13 @@ func (s *WebhookService) CreateEndpoint(ctx context.Context, orgID uuid.UUID, re
 		URL:               req.URL,
 		Secret:            secret,
 		Enabled:           req.Enabled,
+		Headers:           req.Headers,
+		TimeoutSeconds:    getIntOrDefault(req.TimeoutSeconds, 30),
+		MaxRetries:        getIntOrDefault(req.MaxRetries, 3),
+		RetryDelaySeconds: getIntOrDefault(req.RetryDelaySeconds, 60),
+	}
+
+	flags := &domain.WebhookEndpoint{
 		AlertCreated:      req.AlertCreated,
 		AlertUpdated:      req.AlertUpdated,
 		AlertAcknowledged: req.AlertAcknowledged,
@@ -67,11 +131,8 @@ func (s *WebhookService) CreateEndpoint(ctx context.Context, orgID uuid.UUID, re
 		IncidentCreated:   req.IncidentCreated,
 		IncidentUpdated:   req.IncidentUpdated,
 		IncidentResolved:  req.IncidentResolved,
-		Headers:           req.Headers,
-		TimeoutSeconds:    getIntOrDefault(req.TimeoutSeconds, 30),
-		MaxRetries:        getIntOrDefault(req.MaxRetries, 3),
-		RetryDelaySeconds: getIntOrDefault(req.RetryDelaySeconds, 60),
 	}
+	endpoint.SetEvents(append(flags.EventsFromFlags(), req.Events...))
 
 	if endpoint.Headers == nil {
 		endpoint.Headers = make(map[string]string)
@@ -115,29 +176,26 @@ func (s *WebhookService) UpdateEndpoint(ctx context.Context, id, orgID uuid.UUID
 	if req.Enabled != nil {
 		endpoint.Enabled = *req.Enabled
 	}
-	if req.AlertCreated != nil {
-		endpoint.AlertCreated = *req.AlertCreated
-	}
-	if req.AlertUpdated != nil {
-		endpoint.AlertUpdated = *req.AlertUpdated
-	}
-	if req.AlertAcknowledged != nil {
-		endpoint.AlertAcknowledged = *req.AlertAcknowledged
-	}
-	if req.AlertClosed != nil {
-		endpoint.AlertClosed = *req.AlertClosed
-	}
-	if req.AlertEscalated != nil {
-		endpoint.AlertEscalated = *req.AlertEscalated
-	}
-	if req.IncidentCreated != nil {
-		endpoint.IncidentCreated = *req.IncidentCreated
-	}
-	if req.IncidentUpdated != nil {
-		endpoint.IncidentUpdated = *req.IncidentUpdated
-	}
-	if req.IncidentResolved != nil {
-		endpoint.IncidentResolved = *req.IncidentResolved
+	if req.Events != nil {
+		if err := validateWebhookEvents(req.Events); err != nil {
+			return nil, err
+		}
+		endpoint.SetEvents(req.Events)
+	}
+	flags := map[string]*bool{
+		domain.WebhookEventAlertCreated:      req.AlertCreated,
+		domain.WebhookEventAlertUpdated:      req.AlertUpdated,
+		domain.WebhookEventAlertAcknowledged: req.AlertAcknowledged,
+		domain.WebhookEventAlertClosed:       req.AlertClosed,
+		domain.WebhookEventAlertEscalated:    req.AlertEscalated,
+		domain.WebhookEventIncidentCreated:   req.IncidentCreated,
+		domain.WebhookEventIncidentUpdated:   req.IncidentUpdated,
+		domain.WebhookEventIncidentResolved:  req.IncidentResolved,
+	}
+	for eventType, subscribed := range flags {
+		if subscribed != nil {
+			endpoint.Subscribe(eventType, *subscribed)
+		}
 	}
 	if req.Headers != nil {
 		endpoint.Headers = req.Headers
@@ -163,53 +221,188 @@ func (s *WebhookService) DeleteEndpoint(ctx context.Context, id, orgID uuid.UUID
 	return s.webhookRepo.DeleteEndpoint(ctx, id, orgID)
 }
 
+// RotateEndpointSecret replaces the endpoint's signing secret and returns the
+// endpoint with the new secret. Deliveries are signed with it from then on.
+func (s *WebhookService) RotateEndpointSecret(ctx context.Context, id, orgID uuid.UUID) (*domain.WebhookEndpoint, error) {
+	endpoint, err := s.webhookRepo.GetEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.OrganizationID != orgID {
+		return nil, domain.ErrNotFound
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	if err := s.webhookRepo.UpdateEndpointSecret(ctx, id, orgID, secret); err != nil {
+		return nil, err
+	}
+
+	endpoint.Secret = secret
+	return endpoint, nil
+}
+
+// validateWebhookEvents rejects event types endpoints cannot subscribe to
+func validateWebhookEvents(events []string) error {
+	for _, eventType := range events {
+		if !domain.IsWebhookEventType(eventType) {
+			return fmt.Errorf("%w: %q", domain.ErrUnknownWebhookEvent, eventType)
+		}
+	}
+	return nil
+}
+
 // Webhook Delivery
 
 func (s *WebhookService) TriggerWebhooks(ctx context.Context, orgID uuid.UUID, eventType string, data map[string]interface{}) {
-	// Run asynchronously to not block the caller
+	// Run asynchronously to not block the caller, keeping the request ID so
+	// deliveries can be correlated with the request that caused them
+	ctx = requestid.Detach(ctx)
 	go func() {
-		endpoints, err := s.webhookRepo.ListEndpoints(context.Background(), orgID)
-		if err != nil {
-			s.logger.Error("Failed to list webhook endpoints", zap.Error(err))
-			return
+		if err := s.EnqueueWebhooks(ctx, orgID, eventType, data); err != nil {
+			s.log(ctx).Error("Failed to enqueue webhooks", zap.Error(err))
 		}
+	}()
+}
 
-		for _, endpoint := range endpoints {
-			if !endpoint.Enabled {
-				continue
-			}
+// EnqueueWebhooks stores a delivery for every enabled endpoint subscribed to
+// the event and attempts them in the background, behind any delivery already
+// queued for the same endpoint. Once it returns nil the
+// stored deliveries are retried by the worker, so callers that must not lose
+// the event can wait for it; on error some endpoints may already have a
+// delivery and calling again can deliver the event to them twice.
+func (s *WebhookService) EnqueueWebhooks(ctx context.Context, orgID uuid.UUID, eventType string, data map[string]interface{}) error {
+	endpoints, err := s.webhookRepo.ListEndpoints(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
 
-			if !endpoint.ShouldTriggerEvent(eventType) {
-				continue
-			}
+	var queued []queuedDelivery
+	var firstErr error
 
-			payload := &domain.WebhookPayload{
-				EventType:      eventType,
-				EventID:        uuid.New().String(),
-				OrganizationID: orgID.String(),
-				Timestamp:      time.Now(),
-				Data:           data,
-			}
+	for _, endpoint := range endpoints {
+		if !endpoint.Enabled {
+			continue
+		}
 
-			delivery := &domain.WebhookDelivery{
-				ID:                uuid.New(),
-				WebhookEndpointID: endpoint.ID,
-				OrganizationID:    orgID,
-				EventType:         eventType,
-				Payload:           data,
-				Status:            domain.WebhookDeliveryPending,
-				Attempts:          0,
-			}
+		if !endpoint.ShouldTriggerEvent(eventType) {
+			continue
+		}
 
-			if err := s.webhookRepo.CreateDelivery(context.Background(), delivery); err != nil {
-				s.logger.Error("Failed to create webhook delivery", zap.Error(err))
-				continue
+		payload := &domain.WebhookPayload{
+			EventType:      eventType,
+			EventID:        uuid.New().String(),
+			OrganizationID: orgID.String(),
+			Timestamp:      time.Now(),
+			Data:           data,
+		}
+
+		delivery := &domain.WebhookDelivery{
+			ID:                uuid.New(),
+			WebhookEndpointID: endpoint.ID,
+			OrganizationID:    orgID,
+			EventType:         eventType,
+			Payload:           data,
+			Status:            domain.WebhookDeliveryProcessing,
+			Attempts:          0,
+		}
+
+		if err := s.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+			s.log(ctx).Error("Failed to create webhook delivery", zap.Error(err))
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to create webhook delivery: %w", err)
 			}
+			continue
+		}
+		queued = append(queued, queuedDelivery{endpoint: endpoint, delivery: delivery, payload: payload})
+	}
+
+	// Attempt immediate delivery
+	ctx = requestid.Detach(ctx)
+	for _, q := range queued {
+		q.ctx = ctx
+		s.queueDelivery(q)
+	}
+
+	return firstErr
+}
+
+// endpointQueue returns the queue for an endpoint, creating it on first use
+func (s *WebhookService) endpointQueue(endpointID uuid.UUID) *endpointQueue {
+	s.endpointsMu.Lock()
+	defer s.endpointsMu.Unlock()
+
+	queue, ok := s.endpoints[endpointID]
+	if !ok {
+		queue = &endpointQueue{}
+		s.endpoints[endpointID] = queue
+	}
+	return queue
+}
 
-			// Attempt immediate delivery
-			s.deliverWebhook(context.Background(), endpoint, delivery, payload)
+// queueDelivery appends a delivery to its endpoint's queue and starts draining
+// the queue unless a goroutine already is.
+func (s *WebhookService) queueDelivery(q queuedDelivery) {
+	queue := s.endpointQueue(q.endpoint.ID)
+
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+
+	queue.pending = append(queue.pending, q)
+	if !queue.draining {
+		queue.draining = true
+		go s.drainQueue(queue)
+	}
+}
+
+// drainQueue sends an endpoint's queued deliveries one at a time. A delivery
+// created after one the worker still has to retry is held back until the same
+// time, and so is everything behind a delivery that gets scheduled for a retry
+// here, so neither can overtake it.
+func (s *WebhookService) drainQueue(queue *endpointQueue) {
+	for {
+		queue.mu.Lock()
+		if len(queue.pending) == 0 {
+			queue.draining = false
+			queue.mu.Unlock()
+			return
 		}
-	}()
+		q := queue.pending[0]
+		queue.pending = queue.pending[1:]
+		queue.mu.Unlock()
+
+		queue.sendMu.Lock()
+		retryAt, err := s.webhookRepo.GetPendingRetryAt(q.ctx, q.endpoint.ID, q.delivery.CreatedAt)
+		if err != nil {
+			s.log(q.ctx).Error("Failed to check pending webhook deliveries", zap.Error(err))
+			retryAt = &q.delivery.CreatedAt
+		}
+		if retryAt != nil {
+			q.delivery.NextRetryAt = retryAt
+			s.releaseDelivery(q.ctx, q.delivery)
+		} else {
+			s.deliverWebhook(q.ctx, q.endpoint, q.delivery, q.payload)
+		}
+		queue.sendMu.Unlock()
+
+		if q.delivery.Status != domain.WebhookDeliveryPending {
+			continue
+		}
+
+		queue.mu.Lock()
+		held := queue.pending
+		queue.pending = nil
+		queue.mu.Unlock()
+
+		for _, h := range held {
+			h.delivery.NextRetryAt = q.delivery.NextRetryAt
+			s.releaseDelivery(h.ctx, h.delivery)
+		}
+	}
 }
 
 func (s *WebhookService) deliverWebhook(ctx context.Context, endpoint *domain.WebhookEndpoint, delivery *domain.WebhookDelivery, payload *domain.WebhookPayload) {
@@ -220,14 +413,14 @@ func (s *WebhookService) deliverWebhook(ctx context.Context, endpoint *domain.We
 	// Serialize payload
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
-		s.logger.Error("Failed to serialize webhook payload", zap.Error(err))
+		s.log(ctx).Error("Failed to serialize webhook payload", zap.Error(err))
 		s.markDeliveryFailed(ctx, delivery, "Failed to serialize payload: "+err.Error())
 		return
 	}
 
 	// Validate URL at delivery time (defense against DNS rebinding)
 	if err := urlvalidation.ValidateWebhookURL(endpoint.URL); err != nil {
-		s.logger.Error("Webhook URL failed validation at delivery time", zap.Error(err))
+		s.log(ctx).Error("Webhook URL failed validation at delivery time", zap.Error(err))
 		s.markDeliveryFailed(ctx, delivery, "URL validation failed: "+err.Error())
 		return
 	}
@@ -235,7 +428,7 @@ func (s *WebhookService) deliverWebhook(ctx context.Context, endpoint *domain.We
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(ctx, "POST", endpoint.URL, bytes.NewReader(payloadBytes))
 	if err != nil {
-		s.logger.Error("Failed to create HTTP request", zap.Error(err))
+		s.log(ctx).Error("Failed to create HTTP request", zap.Error(err))
 		s.markDeliveryFailed(ctx, delivery, "Failed to create request: "+err.Error())
 		return
 	}
@@ -257,16 +450,20 @@ func (s *WebhookService) deliverWebhook(ctx context.Context, endpoint *domain.We
 	req.Header.Set("X-Pulsar-Signature", signature)
 	req.Header.Set("X-Pulsar-Event", payload.EventType)
 	req.Header.Set("X-Pulsar-Delivery", delivery.ID.String())
+	if id := requestid.FromContext(ctx); id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
 
 	// Set custom timeout
 	client := &http.Client{
-		Timeout: time.Duration(endpoint.TimeoutSeconds) * time.Second,
+		Transport: s.httpClient.Transport,
+		Timeout:   time.Duration(endpoint.TimeoutSeconds) * time.Second,
 	}
 
 	// Send request
 	resp, err := client.Do(req)
 	if err != nil {
-		s.logger.Error("Failed to send webhook",
+		s.log(ctx).Error("Failed to send webhook",
 			zap.String("url", endpoint.URL),
 			zap.Error(err),
 		)
@@ -278,7 +475,7 @@ func (s *WebhookService) deliverWebhook(ctx context.Context, endpoint *domain.We
 	// Read response body (limit to 1MB)
 	responseBody, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
 	if err != nil {
-		s.logger.Warn("Failed to read webhook response body", zap.Error(err))
+		s.log(ctx).Warn("Failed to read webhook response body", zap.Error(err))
 	}
 
 	responseBodyStr := string(responseBody)
@@ -292,10 +489,10 @@ func (s *WebhookService) deliverWebhook(ctx context.Context, endpoint *domain.We
 		delivery.ErrorMessage = nil
 
 		if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
-			s.logger.Error("Failed to update webhook delivery", zap.Error(err))
+			s.log(ctx).Error("Failed to update webhook delivery", zap.Error(err))
 		}
 
-		s.logger.Info("Webhook delivered successfully",
+		s.log(ctx).Info("Webhook delivered successfully",
 			zap.String("endpoint", endpoint.Name),
 			zap.String("url", endpoint.URL),
 			zap.Int("status", resp.StatusCode),
@@ -312,14 +509,15 @@ func (s *WebhookService) handleDeliveryError(ctx context.Context, endpoint *doma
 	} else {
 		// Schedule retry
 		nextRetry := time.Now().Add(time.Duration(endpoint.RetryDelaySeconds) * time.Second)
+		delivery.Status = domain.WebhookDeliveryPending
 		delivery.NextRetryAt = &nextRetry
 		delivery.ErrorMessage = &errMsg
 
 		if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
-			s.logger.Error("Failed to update webhook delivery", zap.Error(err))
+			s.log(ctx).Error("Failed to update webhook delivery", zap.Error(err))
 		}
 
-		s.logger.Warn("Webhook delivery failed, will retry",
+		s.log(ctx).Warn("Webhook delivery failed, will retry",
 			zap.String("endpoint", endpoint.Name),
 			zap.Int("attempt", delivery.Attempts),
 			zap.Int("max_retries", endpoint.MaxRetries),
@@ -335,42 +533,90 @@ func (s *WebhookService) markDeliveryFailed(ctx context.Context, delivery *domai
 	delivery.ErrorMessage = &errMsg
 
 	if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
-		s.logger.Error("Failed to update webhook delivery", zap.Error(err))
+		s.log(ctx).Error("Failed to update webhook delivery", zap.Error(err))
 	}
 
-	s.logger.Error("Webhook delivery permanently failed",
+	s.log(ctx).Error("Webhook delivery permanently failed",
 		zap.String("delivery_id", delivery.ID.String()),
 		zap.Int("attempts", delivery.Attempts),
 		zap.String("error", errMsg),
 	)
 }
 
-// Background worker to process pending deliveries
-func (s *WebhookService) ProcessPendingDeliveries(ctx context.Context) error {
-	deliveries, err := s.webhookRepo.GetPendingDeliveries(ctx, 100)
+// ProcessPendingDeliveries claims due deliveries and sends them. Endpoints are
+// worked on in parallel, up to the delivery concurrency, while the deliveries
+// of one endpoint are sent one at a time in the order they were created, so
+// a receiver never sees an event before the ones that preceded it.
+func (s *WebhookService) ProcessPendingDeliveries(ctx context.Context, limit int) error {
+	deliveries, err := s.webhookRepo.ClaimPendingDeliveries(ctx, limit)
 	if err != nil {
 		return err
 	}
 
-	s.logger.Debug("Processing pending webhook deliveries", zap.Int("count", len(deliveries)))
+	s.log(ctx).Debug("Processing pending webhook deliveries", zap.Int("count", len(deliveries)))
+
+	// The claim doesn't return rows in any particular order
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+	})
 
+	var endpointIDs []uuid.UUID
+	byEndpoint := make(map[uuid.UUID][]*domain.WebhookDelivery)
 	for _, delivery := range deliveries {
-		endpoint, err := s.webhookRepo.GetEndpointByID(ctx, delivery.WebhookEndpointID)
-		if err != nil {
-			s.logger.Error("Failed to get webhook endpoint",
-				zap.String("endpoint_id", delivery.WebhookEndpointID.String()),
-				zap.Error(err),
-			)
-			continue
+		if _, ok := byEndpoint[delivery.WebhookEndpointID]; !ok {
+			endpointIDs = append(endpointIDs, delivery.WebhookEndpointID)
 		}
+		byEndpoint[delivery.WebhookEndpointID] = append(byEndpoint[delivery.WebhookEndpointID], delivery)
+	}
 
-		if !endpoint.Enabled {
-			s.logger.Debug("Skipping delivery for disabled endpoint",
-				zap.String("endpoint", endpoint.Name),
-			)
-			continue
+	sem := make(chan struct{}, s.deliveryConcurrency)
+	var wg sync.WaitGroup
+	for _, endpointID := range endpointIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(queue []*domain.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			s.deliverInOrder(ctx, endpointID, queue)
+		}(byEndpoint[endpointID])
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// deliverInOrder sends the deliveries of one endpoint sequentially, never at
+// the same time as an immediate delivery to it. When one of them is scheduled
+// for a retry, the rest are held back until the same time so they can't
+// overtake it.
+func (s *WebhookService) deliverInOrder(ctx context.Context, endpointID uuid.UUID, queue []*domain.WebhookDelivery) {
+	sendMu := &s.endpointQueue(endpointID).sendMu
+	sendMu.Lock()
+	defer sendMu.Unlock()
+
+	endpoint, err := s.webhookRepo.GetEndpointByID(ctx, endpointID)
+	if err != nil {
+		s.log(ctx).Error("Failed to get webhook endpoint",
+			zap.String("endpoint_id", endpointID.String()),
+			zap.Error(err),
+		)
+		for _, delivery := range queue {
+			s.releaseDelivery(ctx, delivery)
 		}
+		return
+	}
 
+	if !endpoint.Enabled {
+		s.log(ctx).Debug("Skipping delivery for disabled endpoint",
+			zap.String("endpoint", endpoint.Name),
+		)
+		for _, delivery := range queue {
+			s.releaseDelivery(ctx, delivery)
+		}
+		return
+	}
+
+	for i, delivery := range queue {
 		payload := &domain.WebhookPayload{
 			EventType:      delivery.EventType,
 			EventID:        delivery.ID.String(),
@@ -380,27 +626,65 @@ func (s *WebhookService) ProcessPendingDeliveries(ctx context.Context) error {
 		}
 
 		s.deliverWebhook(ctx, endpoint, delivery, payload)
+
+		if delivery.Status == domain.WebhookDeliveryPending {
+			for _, held := range queue[i+1:] {
+				held.NextRetryAt = delivery.NextRetryAt
+				s.releaseDelivery(ctx, held)
+			}
+			return
+		}
 	}
+}
 
-	return nil
+// releaseDelivery returns a claimed delivery to the pending queue without
+// counting an attempt.
+func (s *WebhookService) releaseDelivery(ctx context.Context, delivery *domain.WebhookDelivery) {
+	delivery.Status = domain.WebhookDeliveryPending
+	if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		s.log(ctx).Error("Failed to release webhook delivery", zap.Error(err))
+	}
 }
 
 // Delivery logs
 
 func (s *WebhookService) ListDeliveries(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]*domain.WebhookDelivery, error) {
-	if limit <= 0 || limit > 100 {
-		limit = 20
-	}
 	if offset < 0 {
-		offset = 0
+		return nil, domain.ErrNegativeOffset
 	}
 
-	return s.webhookRepo.ListDeliveries(ctx, orgID, limit, offset)
+	return s.webhookRepo.ListDeliveries(ctx, orgID, domain.ClampLimit(limit, 20), offset)
 }
 
 // Incoming Webhooks
 
 func (s *WebhookService) CreateIncomingToken(ctx context.Context, orgID uuid.UUID, req *dto.CreateIncomingWebhookTokenRequest) (*domain.IncomingWebhookToken, error) {
+	integrationType := domain.IncomingWebhookIntegrationType(req.IntegrationType)
+
+	var fieldMapping *domain.IncomingWebhookFieldMapping
+	if req.FieldMapping != nil {
+		if integrationType != domain.IncomingWebhookGeneric {
+			return nil, fmt.Errorf("%w: only generic integrations support field mapping", domain.ErrInvalidFieldMapping)
+		}
+		fieldMapping = &domain.IncomingWebhookFieldMapping{
+			Message:        req.FieldMapping.Message,
+			Description:    req.FieldMapping.Description,
+			Priority:       req.FieldMapping.Priority,
+			PriorityValues: req.FieldMapping.PriorityValues,
+			Tags:           req.FieldMapping.Tags,
+			DedupKey:       req.FieldMapping.DedupKey,
+			Source:         req.FieldMapping.Source,
+		}
+		for field, path := range fieldMapping.Paths() {
+			if path == "" {
+				continue
+			}
+			if _, err := jsonpath.Parse(path); err != nil {
+				return nil, fmt.Errorf("%w: %s: %v", domain.ErrInvalidFieldMapping, field, err)
+			}
+		}
+	}
+
 	// Generate a secure token
 	tokenStr, err := generateSecret()
 	if err != nil {
@@ -418,9 +702,10 @@ func (s *WebhookService) CreateIncomingToken(ctx context.Context, orgID uuid.UUI
 		Name:            req.Name,
 		Token:           tokenStr,
 		Enabled:         true,
-		IntegrationType: domain.IncomingWebhookIntegrationType(req.IntegrationType),
+		IntegrationType: integrationType,
 		DefaultPriority: defaultPriority,
 		DefaultTags:     req.DefaultTags,
+		FieldMapping:    fieldMapping,
 		RequestCount:    0,
 	}
 
@@ -447,10 +732,89 @@ func (s *WebhookService) UpdateIncomingTokenUsage(ctx context.Context, tokenID u
 	return s.webhookRepo.UpdateIncomingTokenUsage(ctx, tokenID)
 }
 
+// RotateIncomingToken replaces the token string of an incoming webhook token,
+// keeping its integration settings, and returns it with the new token. The old
+// token stops working immediately.
+func (s *WebhookService) RotateIncomingToken(ctx context.Context, id, orgID uuid.UUID) (*domain.IncomingWebhookToken, error) {
+	token, err := s.webhookRepo.GetIncomingTokenByID(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenStr, err := generateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	if err := s.webhookRepo.UpdateIncomingTokenValue(ctx, id, orgID, tokenStr); err != nil {
+		return nil, err
+	}
+
+	token.Token = tokenStr
+	return token, nil
+}
+
 func (s *WebhookService) DeleteIncomingToken(ctx context.Context, id, orgID uuid.UUID) error {
 	return s.webhookRepo.DeleteIncomingToken(ctx, id, orgID)
 }
 
+// sensitiveIncomingHeaders are redacted before incoming requests are logged
+var sensitiveIncomingHeaders = map[string]bool{
+	"Authorization":       true,
+	"Cookie":              true,
+	"Proxy-Authorization": true,
+	"X-Api-Key":           true,
+}
+
+// RecordIncomingRequest adds a received request to its token's request log,
+// redacting credentials and truncating large bodies. Bodies are stored as
+// text, so invalid UTF-8 is replaced and NUL bytes dropped. Only the latest
+// IncomingWebhookRequestRetention requests per token are kept.
+func (s *WebhookService) RecordIncomingRequest(ctx context.Context, request *domain.IncomingWebhookRequest) error {
+	request.ID = uuid.New()
+	if request.ReceivedAt.IsZero() {
+		request.ReceivedAt = time.Now()
+	}
+
+	headers := make(map[string]string, len(request.Headers))
+	for name, value := range request.Headers {
+		if sensitiveIncomingHeaders[http.CanonicalHeaderKey(name)] {
+			value = domain.RedactedValue
+		}
+		headers[name] = value
+	}
+	request.Headers = headers
+
+	body := strings.ReplaceAll(strings.ToValidUTF8(request.Body, "\uFFFD"), "\x00", "")
+	if len(body) > domain.IncomingWebhookRequestBodyLimit {
+		// Cut before the rune that crosses the limit
+		cut := domain.IncomingWebhookRequestBodyLimit
+		for cut > 0 && !utf8.RuneStart(body[cut]) {
+			cut--
+		}
+		body = body[:cut]
+		request.BodyTruncated = true
+	}
+	request.Body = body
+	if request.AlertIDs == nil {
+		request.AlertIDs = []uuid.UUID{}
+	}
+	if request.ResolvedAlertIDs == nil {
+		request.ResolvedAlertIDs = []uuid.UUID{}
+	}
+
+	return s.webhookRepo.CreateIncomingRequest(ctx, request, domain.IncomingWebhookRequestRetention)
+}
+
+// ListIncomingRequests returns the recent requests received by a token,
+// newest first
+func (s *WebhookService) ListIncomingRequests(ctx context.Context, tokenID, orgID uuid.UUID) ([]*domain.IncomingWebhookRequest, error) {
+	if _, err := s.webhookRepo.GetIncomingTokenByID(ctx, tokenID, orgID); err != nil {
+		return nil, err
+	}
+	return s.webhookRepo.ListIncomingRequests(ctx, tokenID, orgID)
+}
+
 // Helper functions
 
 func generateSecret() (string, error) {
@@ -2,6 +2,7 @@ package service
 
 import (
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -11,19 +12,85 @@ import (
 
 // WebSocketService manages WebSocket connections and message broadcasting
 type WebSocketService struct {
-	hub    *domain.WSHub
-	logger *zap.Logger
-	mu     sync.RWMutex
+	hub     *domain.WSHub
+	logger  *zap.Logger
+	mu      sync.RWMutex
+	buffers map[uuid.UUID]*eventBuffer
 }
 
 // NewWebSocketService creates a new WebSocket service
 func NewWebSocketService(logger *zap.Logger) *WebSocketService {
 	return &WebSocketService{
-		hub:    domain.NewWSHub(),
-		logger: logger,
+		hub:     domain.NewWSHub(),
+		logger:  logger,
+		buffers: make(map[uuid.UUID]*eventBuffer),
 	}
 }
 
+// eventBuffer is a fixed-size ring of the most recent events broadcast to an
+// organization
+type eventBuffer struct {
+	events []*domain.WSMessage
+	head   int
+	count  int
+
+	// evictedAt is the timestamp of the newest event pushed out of the ring
+	evictedAt *time.Time
+}
+
+func newEventBuffer(size int) *eventBuffer {
+	return &eventBuffer{events: make([]*domain.WSMessage, size)}
+}
+
+// push adds a message, overwriting the oldest one once the ring is full
+func (b *eventBuffer) push(message *domain.WSMessage) {
+	if b.count < len(b.events) {
+		b.events[(b.head+b.count)%len(b.events)] = message
+		b.count++
+		return
+	}
+
+	evictedAt := b.events[b.head].Timestamp
+	b.evictedAt = &evictedAt
+	b.events[b.head] = message
+	b.head = (b.head + 1) % len(b.events)
+}
+
+// after returns the buffered events newer than the given cursor, oldest first.
+// It returns false when events after the cursor may already have been evicted,
+// in which case the client has to resync from scratch.
+func (b *eventBuffer) after(lastEventID string, since *time.Time) ([]*domain.WSMessage, bool) {
+	if b == nil {
+		// Nothing was broadcast since startup, so an event id can't be ours
+		return nil, lastEventID == ""
+	}
+
+	ordered := make([]*domain.WSMessage, b.count)
+	for i := 0; i < b.count; i++ {
+		ordered[i] = b.events[(b.head+i)%len(b.events)]
+	}
+
+	if lastEventID != "" {
+		for i, message := range ordered {
+			if message.ID == lastEventID {
+				return ordered[i+1:], true
+			}
+		}
+		return nil, false
+	}
+
+	if b.evictedAt != nil && b.evictedAt.After(*since) {
+		return nil, false
+	}
+	var missed []*domain.WSMessage
+	for _, message := range ordered {
+		if message.Timestamp.After(*since) {
+			missed = append(missed, message)
+		}
+	}
+	return missed, true
+}
+
 // GetHub returns the WebSocket hub
 func (s *WebSocketService) GetHub() *domain.WSHub {
 	return s.hub
@@ -78,6 +145,46 @@ func (s *WebSocketService) registerClient(client *domain.WSClient) {
 	default:
 		close(client.Send)
 		delete(s.hub.Clients[client.OrganizationID], client)
+		return
+	}
+
+	if client.LastEventID != "" || client.Since != nil {
+		s.replayMissedEvents(client)
+	}
+}
+
+// replayMissedEvents sends a reconnecting client the buffered events it missed,
+// or a resync hint when they are no longer buffered. It runs on the hub
+// goroutine before any further broadcast, so live events follow in order.
+func (s *WebSocketService) replayMissedEvents(client *domain.WSClient) {
+	missed, ok := s.buffers[client.OrganizationID].after(client.LastEventID, client.Since)
+	if !ok {
+		missed = []*domain.WSMessage{domain.NewWSMessage(
+			domain.WSEventResync,
+			client.OrganizationID,
+			map[string]interface{}{
+				"message": "Missed events are no longer available, reload the current state",
+			},
+		)}
+	}
+
+	s.logger.Debug("Replaying missed WebSocket events",
+		zap.String("client_id", client.ID.String()),
+		zap.Int("event_count", len(missed)),
+		zap.Bool("resync", !ok),
+	)
+
+	for _, message := range missed {
+		select {
+		case client.Send <- message:
+		default:
+			close(client.Send)
+			delete(s.hub.Clients[client.OrganizationID], client)
+			s.logger.Warn("Client send channel full during replay, disconnecting",
+				zap.String("client_id", client.ID.String()),
+			)
+			return
+		}
 	}
 }
 
@@ -106,6 +213,8 @@ func (s *WebSocketService) unregisterClient(client *domain.WSClient) {
 
 // broadcastMessage broadcasts a message to all clients in an organization
 func (s *WebSocketService) broadcastMessage(message *domain.WSMessage) {
+	s.recordMessage(message)
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -139,6 +248,20 @@ func (s *WebSocketService) broadcastMessage(message *domain.WSMessage) {
 	}
 }
 
+// recordMessage keeps a message in its organization's replay buffer, whether or
+// not anyone is connected to receive it live
+func (s *WebSocketService) recordMessage(message *domain.WSMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buffer, ok := s.buffers[message.OrganizationID]
+	if !ok {
+		buffer = newEventBuffer(domain.WSReplayBufferSize)
+		s.buffers[message.OrganizationID] = buffer
+	}
+	buffer.push(message)
+}
+
 // BroadcastAlertEvent broadcasts an alert-related event
 func (s *WebSocketService) BroadcastAlertEvent(eventType domain.WSEventType, orgID uuid.UUID, alert *domain.Alert) {
 	payload := map[string]interface{}{
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/internal/core/port/outbound"
+)
+
+// OnCallLister resolves who is on call for every schedule of an organization
+type OnCallLister interface {
+	ListOnCall(ctx context.Context, orgID uuid.UUID, at time.Time) ([]*domain.ScheduleOnCall, error)
+}
+
+// WorkloadService gathers a user's alerts, incidents and on-call shifts for a
+// personal dashboard
+type WorkloadService struct {
+	alertRepo    outbound.AlertRepository
+	incidentRepo outbound.IncidentRepository
+	onCall       OnCallLister
+}
+
+func NewWorkloadService(alertRepo outbound.AlertRepository, incidentRepo outbound.IncidentRepository, onCall OnCallLister) *WorkloadService {
+	return &WorkloadService{
+		alertRepo:    alertRepo,
+		incidentRepo: incidentRepo,
+		onCall:       onCall,
+	}
+}
+
+func (s *WorkloadService) GetWorkload(ctx context.Context, orgID, userID uuid.UUID) (*dto.WorkloadResponse, error) {
+	alerts, alertsTotal, err := s.alertRepo.List(ctx, &domain.AlertFilter{
+		OrganizationID: orgID,
+		Status:         []domain.AlertStatus{domain.AlertStatusOpen, domain.AlertStatusAcknowledged, domain.AlertStatusSnoozed},
+		AssignedToUser: &userID,
+		Limit:          domain.MaxListLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assigned alerts: %w", err)
+	}
+
+	incidents, incidentsTotal, err := s.incidentRepo.List(ctx, &domain.IncidentFilter{
+		OrganizationID: orgID,
+		Status: []domain.IncidentStatus{
+			domain.IncidentStatusInvestigating,
+			domain.IncidentStatusIdentified,
+			domain.IncidentStatusMonitoring,
+		},
+		ResponderUserID: &userID,
+		Limit:           domain.MaxListLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
+	}
+
+	schedules, err := s.onCall.ListOnCall(ctx, orgID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve on-call: %w", err)
+	}
+	onCall := []*domain.ScheduleOnCall{}
+	for _, entry := range schedules {
+		if entry.OnCall != nil && entry.OnCall.UserID == userID {
+			onCall = append(onCall, entry)
+		}
+	}
+
+	if alerts == nil {
+		alerts = []*domain.Alert{}
+	}
+	if incidents == nil {
+		incidents = []*domain.Incident{}
+	}
+
+	return &dto.WorkloadResponse{
+		Alerts:          alerts,
+		AlertsTotal:     alertsTotal,
+		Incidents:       incidents,
+		IncidentsTotal:  incidentsTotal,
+		OnCall:          len(onCall) > 0,
+		OnCallSchedules: onCall,
+	}, nil
+}
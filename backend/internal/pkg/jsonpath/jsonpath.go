@@ -0,0 +1,104 @@
+// Package jsonpath resolves a small JSONPath subset against decoded JSON.
+// Paths are dot-separated member names with optional array indexes, such as
+// "$.data.level", "$.alerts[0].labels.severity" or "$['key.with.dots']". The
+// leading "$" is optional.
+package jsonpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// segment is one step of a path: a member name, or an array index when
+// isIndex is set
+type segment struct {
+	name    string
+	index   int
+	isIndex bool
+}
+
+// Path is a parsed path
+type Path struct {
+	raw      string
+	segments []segment
+}
+
+// Parse parses a path
+func Parse(raw string) (Path, error) {
+	path := Path{raw: raw}
+	rest := strings.TrimSpace(raw)
+	rest = strings.TrimPrefix(rest, "$")
+	if rest == "" {
+		return path, nil
+	}
+	if rest[0] != '.' && rest[0] != '[' {
+		rest = "." + rest
+	}
+
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return Path{}, fmt.Errorf("invalid path %q: empty member name", raw)
+			}
+			path.segments = append(path.segments, segment{name: rest[:end]})
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end == -1 {
+				return Path{}, fmt.Errorf("invalid path %q: unclosed bracket", raw)
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				path.segments = append(path.segments, segment{name: inner[1 : len(inner)-1]})
+				continue
+			}
+			index, err := strconv.Atoi(inner)
+			if err != nil || index < 0 {
+				return Path{}, fmt.Errorf("invalid path %q: bad index %q", raw, inner)
+			}
+			path.segments = append(path.segments, segment{index: index, isIndex: true})
+		default:
+			return Path{}, fmt.Errorf("invalid path %q: unexpected %q", raw, rest[0])
+		}
+	}
+
+	return path, nil
+}
+
+func (p Path) String() string {
+	return p.raw
+}
+
+// Lookup returns the value at the path in a document decoded with
+// encoding/json into interface{}. It reports false when any step is missing.
+func (p Path) Lookup(doc interface{}) (interface{}, bool) {
+	current := doc
+	for _, seg := range p.segments {
+		if seg.isIndex {
+			items, ok := current.([]interface{})
+			if !ok || seg.index >= len(items) {
+				return nil, false
+			}
+			current = items[seg.index]
+			continue
+		}
+
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = object[seg.name]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
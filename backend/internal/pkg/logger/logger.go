@@ -1,10 +1,14 @@
 package logger
 
 import (
+	"context"
+
 	"go.opentelemetry.io/contrib/bridges/otelzap"
 	"go.opentelemetry.io/otel/log/global"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"github.com/nmn3m/pulsar/backend/internal/pkg/requestid"
 )
 
 // New creates a new Zap logger
@@ -61,3 +65,12 @@ func NewWithOTEL(env string) (*zap.Logger, error) {
 
 	return logger, nil
 }
+
+// WithContext returns l annotated with the request ID carried by ctx, so log
+// lines from handlers, services and async work can be correlated.
+func WithContext(ctx context.Context, l *zap.Logger) *zap.Logger {
+	if id := requestid.FromContext(ctx); id != "" {
+		return l.With(zap.String("request_id", id))
+	}
+	return l
+}
@@ -0,0 +1,137 @@
+package prommetrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "pulsar"
+
+// Registry holds all Pulsar operational metrics. A dedicated registry keeps
+// the output independent of anything else registered on the global default.
+var Registry = prometheus.NewRegistry()
+
+// HTTP metrics
+var (
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Total number of HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+// Domain metrics
+var (
+	AlertsCreatedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "alerts_created_total",
+		Help:      "Total number of alerts created, by priority.",
+	}, []string{"priority"})
+
+	AlertsDeduplicatedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "alerts_deduplicated_total",
+		Help:      "Total number of incoming alerts merged into an existing alert by dedup key.",
+	})
+
+	AlertsAcknowledgedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "alerts_acknowledged_total",
+		Help:      "Total number of alerts acknowledged.",
+	})
+
+	AlertsClosedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "alerts_closed_total",
+		Help:      "Total number of alerts closed.",
+	})
+
+	IncidentsCreatedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "incidents_created_total",
+		Help:      "Total number of incidents created, by severity.",
+	}, []string{"severity"})
+
+	IncidentsResolvedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "incidents_resolved_total",
+		Help:      "Total number of incidents resolved.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestsTotal,
+		HTTPRequestDuration,
+		AlertsCreatedTotal,
+		AlertsDeduplicatedTotal,
+		AlertsAcknowledgedTotal,
+		AlertsClosedTotal,
+		IncidentsCreatedTotal,
+		IncidentsResolvedTotal,
+	)
+}
+
+// QueueDepthFunc returns the current number of items waiting in a queue.
+type QueueDepthFunc func(ctx context.Context) (int, error)
+
+// QueueDepth is a gauge holding the last observed length of a queue. It is
+// updated by Refresh rather than on scrape, so scrapes never hit the database.
+type QueueDepth struct {
+	gauge prometheus.Gauge
+	fn    QueueDepthFunc
+}
+
+// RegisterQueueDepth exposes a gauge for the queue measured by fn. The gauge
+// reads zero until the first Refresh.
+func RegisterQueueDepth(name, help string, fn QueueDepthFunc) (*QueueDepth, error) {
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      name,
+		Help:      help,
+	})
+	if err := Registry.Register(gauge); err != nil {
+		return nil, err
+	}
+	return &QueueDepth{gauge: gauge, fn: fn}, nil
+}
+
+// Refresh measures the queue and updates the gauge. Errors are reported as -1
+// so a broken query is visible instead of silently reading as an empty queue.
+func (q *QueueDepth) Refresh(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	n, err := q.fn(ctx)
+	if err != nil {
+		q.gauge.Set(-1)
+		return err
+	}
+	q.gauge.Set(float64(n))
+	return nil
+}
+
+// RegisterDBStats exposes connection pool statistics for db.
+func RegisterDBStats(db *sql.DB) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, namespace))
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
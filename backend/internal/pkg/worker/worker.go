@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Task is a single iteration of a background worker.
+type Task func(ctx context.Context) error
+
+// Locker provides mutual exclusion across instances. TryWithLock runs fn only
+// if the named lock could be acquired and reports whether it ran.
+type Locker interface {
+	TryWithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error)
+}
+
+// Exclusive wraps task so that only one instance sharing locker runs it at a
+// time. Instances that fail to take the lock skip the iteration; if the holder
+// dies its lock is released and another instance picks up on its next tick.
+func Exclusive(locker Locker, name string, task Task) Task {
+	return func(ctx context.Context) error {
+		_, err := locker.TryWithLock(ctx, "worker:"+name, task)
+		return err
+	}
+}
+
+// Group runs periodic background workers and drains them on shutdown.
+//
+// Shutdown stops workers from starting new iterations and waits for any
+// in-flight iteration to finish. The context passed to a task is only
+// cancelled if the shutdown deadline expires before the task returns.
+type Group struct {
+	logger *zap.Logger
+	wg     sync.WaitGroup
+
+	stopCtx context.Context
+	stop    context.CancelFunc
+	runCtx  context.Context
+	abort   context.CancelFunc
+}
+
+// NewGroup creates an empty worker group.
+func NewGroup(logger *zap.Logger) *Group {
+	stopCtx, stop := context.WithCancel(context.Background())
+	runCtx, abort := context.WithCancel(context.Background())
+	return &Group{
+		logger:  logger,
+		stopCtx: stopCtx,
+		stop:    stop,
+		runCtx:  runCtx,
+		abort:   abort,
+	}
+}
+
+// Go starts a worker that runs task every interval until the group is shut down.
+func (g *Group) Go(name string, interval time.Duration, task Task) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		g.logger.Info("Worker started", zap.String("worker", name), zap.Duration("interval", interval))
+
+		for {
+			select {
+			case <-ticker.C:
+				// Re-check so a tick racing with shutdown doesn't start a new iteration
+				if g.stopCtx.Err() != nil {
+					g.logger.Info("Worker stopped", zap.String("worker", name))
+					return
+				}
+				if err := task(g.runCtx); err != nil {
+					g.logger.Error("Worker iteration failed", zap.String("worker", name), zap.Error(err))
+				}
+			case <-g.stopCtx.Done():
+				g.logger.Info("Worker stopped", zap.String("worker", name))
+				return
+			}
+		}
+	}()
+}
+
+// Shutdown signals all workers to stop and waits for in-flight iterations to
+// finish. If ctx expires first, running tasks have their context cancelled and
+// ctx.Err() is returned.
+func (g *Group) Shutdown(ctx context.Context) error {
+	g.stop()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		g.abort()
+		return nil
+	case <-ctx.Done():
+		g.abort()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,8 @@
+-- Last handoff notification sent per rotation, used to notify each shift once
+CREATE TABLE IF NOT EXISTS schedule_handoff_notifications (
+    rotation_id UUID PRIMARY KEY REFERENCES schedule_rotations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    shift_start TIMESTAMP NOT NULL,
+    shift_end TIMESTAMP NOT NULL,
+    notified_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
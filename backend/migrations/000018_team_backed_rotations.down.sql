@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_schedule_rotations_team_id;
+
+ALTER TABLE schedule_rotations DROP COLUMN IF EXISTS team_id;
@@ -0,0 +1,4 @@
+-- Rotations can be backed by a team so their participants follow team membership
+ALTER TABLE schedule_rotations ADD COLUMN IF NOT EXISTS team_id UUID REFERENCES teams(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_schedule_rotations_team_id ON schedule_rotations(team_id);
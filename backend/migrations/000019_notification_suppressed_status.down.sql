@@ -0,0 +1,5 @@
+DELETE FROM notification_logs WHERE status = 'suppressed_dnd';
+
+ALTER TABLE notification_logs DROP CONSTRAINT IF EXISTS valid_notification_status;
+ALTER TABLE notification_logs ADD CONSTRAINT valid_notification_status
+    CHECK (status IN ('pending', 'sent', 'failed'));
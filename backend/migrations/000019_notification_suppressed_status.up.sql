@@ -0,0 +1,4 @@
+-- Notifications withheld because the recipient is in Do Not Disturb are logged too
+ALTER TABLE notification_logs DROP CONSTRAINT IF EXISTS valid_notification_status;
+ALTER TABLE notification_logs ADD CONSTRAINT valid_notification_status
+    CHECK (status IN ('pending', 'sent', 'failed', 'suppressed_dnd'));
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_alerts_snooze_expiry;
+
+ALTER TABLE alerts DROP COLUMN IF EXISTS snooze_reason;
+ALTER TABLE alerts DROP COLUMN IF EXISTS snoozed_by;
@@ -0,0 +1,5 @@
+-- Record who snoozed an alert and why, so the snoozer can be told when it reopens
+ALTER TABLE alerts ADD COLUMN snoozed_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE alerts ADD COLUMN snooze_reason TEXT;
+
+CREATE INDEX idx_alerts_snooze_expiry ON alerts(snoozed_until) WHERE status = 'snoozed';
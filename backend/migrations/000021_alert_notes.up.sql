@@ -0,0 +1,10 @@
+-- Investigation notes attached to alerts
+CREATE TABLE IF NOT EXISTS alert_notes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    alert_id UUID NOT NULL REFERENCES alerts(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    note TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_alert_notes_alert_id ON alert_notes(alert_id, created_at);
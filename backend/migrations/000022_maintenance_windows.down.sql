@@ -0,0 +1,3 @@
+ALTER TABLE alerts DROP COLUMN IF EXISTS maintenance_window_id;
+
+DROP TABLE IF EXISTS maintenance_windows;
@@ -0,0 +1,24 @@
+-- Maintenance windows suppress paging for matching alerts during planned work
+CREATE TABLE IF NOT EXISTS maintenance_windows (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    conditions JSONB NOT NULL DEFAULT '{}',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT valid_maintenance_window CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_maintenance_windows_org_time ON maintenance_windows(organization_id, starts_at, ends_at);
+
+CREATE TRIGGER update_maintenance_windows_updated_at
+    BEFORE UPDATE ON maintenance_windows
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Alerts suppressed by a window keep a reference to it
+ALTER TABLE alerts ADD COLUMN maintenance_window_id UUID REFERENCES maintenance_windows(id) ON DELETE SET NULL;
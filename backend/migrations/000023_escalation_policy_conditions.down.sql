@@ -0,0 +1 @@
+ALTER TABLE escalation_policies DROP COLUMN IF EXISTS conditions;
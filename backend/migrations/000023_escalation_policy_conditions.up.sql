@@ -0,0 +1,3 @@
+-- Let escalation policies declare the alerts they apply to. NULL means the
+-- policy is only assigned explicitly or by a routing rule.
+ALTER TABLE escalation_policies ADD COLUMN conditions JSONB;
@@ -0,0 +1,3 @@
+-- Flag notification logs produced by channel test sends so they can be told
+-- apart from real pages and left out of metrics
+ALTER TABLE notification_logs ADD COLUMN is_test BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,16 @@
+DELETE FROM incident_timeline WHERE event_type = 'commander_changed';
+
+ALTER TABLE incident_timeline DROP CONSTRAINT IF EXISTS incident_timeline_event_type_check;
+ALTER TABLE incident_timeline ADD CONSTRAINT incident_timeline_event_type_check CHECK (event_type IN (
+    'created',
+    'status_changed',
+    'severity_changed',
+    'responder_added',
+    'responder_removed',
+    'note_added',
+    'alert_linked',
+    'alert_unlinked',
+    'resolved'
+));
+
+DROP INDEX IF EXISTS idx_incident_responders_one_commander;
@@ -0,0 +1,30 @@
+-- An incident has at most one incident commander. Keep the earliest commander
+-- of incidents that already have several and demote the others.
+UPDATE incident_responders ir
+SET role = 'responder'
+WHERE ir.role = 'incident_commander'
+  AND EXISTS (
+      SELECT 1 FROM incident_responders other
+      WHERE other.incident_id = ir.incident_id
+        AND other.role = 'incident_commander'
+        AND (other.added_at, other.id) < (ir.added_at, ir.id)
+  );
+
+CREATE UNIQUE INDEX idx_incident_responders_one_commander
+    ON incident_responders(incident_id)
+    WHERE role = 'incident_commander';
+
+-- Record commander handoffs on the timeline
+ALTER TABLE incident_timeline DROP CONSTRAINT IF EXISTS incident_timeline_event_type_check;
+ALTER TABLE incident_timeline ADD CONSTRAINT incident_timeline_event_type_check CHECK (event_type IN (
+    'created',
+    'status_changed',
+    'severity_changed',
+    'responder_added',
+    'responder_removed',
+    'note_added',
+    'alert_linked',
+    'alert_unlinked',
+    'resolved',
+    'commander_changed'
+));
@@ -0,0 +1,2 @@
+ALTER TABLE incidents DROP COLUMN IF EXISTS slack_channel_url;
+ALTER TABLE incidents DROP COLUMN IF EXISTS slack_channel_id;
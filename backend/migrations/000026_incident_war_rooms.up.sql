@@ -0,0 +1,3 @@
+-- Slack channel opened as a war room for the incident
+ALTER TABLE incidents ADD COLUMN slack_channel_id TEXT;
+ALTER TABLE incidents ADD COLUMN slack_channel_url TEXT;
@@ -0,0 +1,2 @@
+ALTER TABLE incidents DROP COLUMN IF EXISTS jira_issue_url;
+ALTER TABLE incidents DROP COLUMN IF EXISTS jira_issue_key;
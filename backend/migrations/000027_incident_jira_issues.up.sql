@@ -0,0 +1,3 @@
+-- Jira issue opened for follow-up work on the incident
+ALTER TABLE incidents ADD COLUMN jira_issue_key TEXT;
+ALTER TABLE incidents ADD COLUMN jira_issue_url TEXT;
@@ -0,0 +1 @@
+ALTER TABLE incidents DROP COLUMN IF EXISTS statuspage_incident_id;
@@ -0,0 +1,2 @@
+-- Statuspage incident mirroring the incident on the public status page
+ALTER TABLE incidents ADD COLUMN statuspage_incident_id TEXT;
@@ -0,0 +1 @@
+ALTER TABLE incoming_webhook_tokens DROP COLUMN IF EXISTS field_mapping;
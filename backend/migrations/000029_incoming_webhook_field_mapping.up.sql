@@ -0,0 +1,2 @@
+-- Where generic incoming webhooks find alert fields in arbitrary payloads
+ALTER TABLE incoming_webhook_tokens ADD COLUMN field_mapping JSONB;
@@ -0,0 +1,18 @@
+-- Recent raw requests received by incoming webhook tokens, kept for debugging
+-- integrations. Only the latest requests per token are retained.
+CREATE TABLE IF NOT EXISTS incoming_webhook_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token_id UUID NOT NULL REFERENCES incoming_webhook_tokens(id) ON DELETE CASCADE,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    headers JSONB NOT NULL DEFAULT '{}',
+    body TEXT NOT NULL DEFAULT '',
+    body_truncated BOOLEAN NOT NULL DEFAULT false,
+    success BOOLEAN NOT NULL,
+    error TEXT,
+    alerts_received INTEGER NOT NULL DEFAULT 0,
+    alert_ids JSONB NOT NULL DEFAULT '[]',
+    resolved_alert_ids JSONB NOT NULL DEFAULT '[]',
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_incoming_webhook_requests_token_received ON incoming_webhook_requests(token_id, received_at DESC);
@@ -0,0 +1 @@
+ALTER TABLE webhook_endpoints DROP COLUMN IF EXISTS events;
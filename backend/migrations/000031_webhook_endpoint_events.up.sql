@@ -0,0 +1,14 @@
+-- Event types each outgoing webhook endpoint subscribes to. The boolean
+-- columns are kept in sync for backward compatibility.
+ALTER TABLE webhook_endpoints ADD COLUMN events JSONB NOT NULL DEFAULT '[]';
+
+UPDATE webhook_endpoints SET events = to_jsonb(array_remove(ARRAY[
+    CASE WHEN alert_created THEN 'alert.created' END,
+    CASE WHEN alert_updated THEN 'alert.updated' END,
+    CASE WHEN alert_acknowledged THEN 'alert.acknowledged' END,
+    CASE WHEN alert_closed THEN 'alert.closed' END,
+    CASE WHEN alert_escalated THEN 'alert.escalated' END,
+    CASE WHEN incident_created THEN 'incident.created' END,
+    CASE WHEN incident_updated THEN 'incident.updated' END,
+    CASE WHEN incident_resolved THEN 'incident.resolved' END
+], NULL));
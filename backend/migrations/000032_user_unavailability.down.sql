@@ -0,0 +1,7 @@
+DELETE FROM notification_logs WHERE status = 'skipped_unavailable';
+
+ALTER TABLE notification_logs DROP CONSTRAINT IF EXISTS valid_notification_status;
+ALTER TABLE notification_logs ADD CONSTRAINT valid_notification_status
+    CHECK (status IN ('pending', 'sent', 'failed', 'suppressed_dnd'));
+
+DROP TABLE IF EXISTS user_unavailability;
@@ -0,0 +1,18 @@
+-- Periods when a user cannot be paged at all (vacation, leave); schedules
+-- skip them and put the next participant on call
+CREATE TABLE IF NOT EXISTS user_unavailability (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT valid_unavailability_period CHECK (end_time > start_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_unavailability_user_end ON user_unavailability(user_id, end_time);
+
+-- Pages withheld from unavailable users are logged too
+ALTER TABLE notification_logs DROP CONSTRAINT IF EXISTS valid_notification_status;
+ALTER TABLE notification_logs ADD CONSTRAINT valid_notification_status
+    CHECK (status IN ('pending', 'sent', 'failed', 'suppressed_dnd', 'skipped_unavailable'));
@@ -0,0 +1,4 @@
+ALTER TABLE email_verifications
+    DROP COLUMN IF EXISTS last_send_error,
+    DROP COLUMN IF EXISTS last_sent_at,
+    DROP COLUMN IF EXISTS send_attempts;
@@ -0,0 +1,6 @@
+-- Track delivery of verification codes so provider failures are visible and
+-- resends can be rate limited
+ALTER TABLE email_verifications
+    ADD COLUMN IF NOT EXISTS send_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_sent_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS last_send_error TEXT;
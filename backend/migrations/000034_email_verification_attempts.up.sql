@@ -0,0 +1,2 @@
+-- Count wrong guesses per verification code so it can be locked after too many
+ALTER TABLE email_verifications ADD COLUMN IF NOT EXISTS failed_attempts INTEGER NOT NULL DEFAULT 0;
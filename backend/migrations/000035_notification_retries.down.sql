@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_notification_logs_next_retry_at;
+
+ALTER TABLE notification_logs
+    DROP COLUMN IF EXISTS next_retry_at,
+    DROP COLUMN IF EXISTS attempts;
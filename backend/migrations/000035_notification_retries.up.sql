@@ -0,0 +1,10 @@
+-- Failed notifications are retried by a background worker with backoff.
+-- next_retry_at is cleared once a notification is sent or given up on.
+ALTER TABLE notification_logs
+    ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN next_retry_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE notification_logs SET attempts = 1 WHERE status IN ('sent', 'failed');
+
+CREATE INDEX idx_notification_logs_next_retry_at ON notification_logs(next_retry_at)
+    WHERE status = 'failed' AND next_retry_at IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE escalation_rules
+    DROP COLUMN IF EXISTS delay_seconds;
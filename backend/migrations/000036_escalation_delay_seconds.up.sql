@@ -0,0 +1,4 @@
+-- Sub-minute escalation delays. When set, delay_seconds takes precedence over
+-- escalation_delay, which stays in whole minutes.
+ALTER TABLE escalation_rules
+    ADD COLUMN delay_seconds INTEGER CHECK (delay_seconds >= 0);
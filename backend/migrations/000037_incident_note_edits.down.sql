@@ -0,0 +1,2 @@
+ALTER TABLE incident_timeline
+    DROP COLUMN IF EXISTS edited_at;
@@ -0,0 +1,4 @@
+-- Notes can be corrected after the fact. edited_at marks a timeline event
+-- whose description was changed since it was recorded.
+ALTER TABLE incident_timeline
+    ADD COLUMN edited_at TIMESTAMPTZ;
@@ -0,0 +1,19 @@
+-- Events recorded in the same transaction as the change that raised them and
+-- published to WebSocket clients and webhooks once that change has committed
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    alert_id UUID,
+    payload JSONB NOT NULL DEFAULT '{}',
+
+    -- A relay holds the row from claimed_at until it publishes it or the
+    -- claim lapses
+    claimed_at TIMESTAMP WITH TIME ZONE,
+    published_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_event_outbox_alert_id ON event_outbox(alert_id);
+CREATE INDEX idx_event_outbox_unpublished ON event_outbox(created_at) WHERE published_at IS NULL;
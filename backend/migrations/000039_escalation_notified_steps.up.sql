@@ -0,0 +1,13 @@
+-- Escalation steps whose targets have been paged, so a step processed twice
+-- (e.g. after a worker lost its claim) doesn't page them again. A step is a
+-- rule within one repeat cycle of an escalation.
+CREATE TABLE IF NOT EXISTS alert_escalation_notified_steps (
+    escalation_event_id UUID NOT NULL REFERENCES alert_escalation_events(id) ON DELETE CASCADE,
+    alert_id UUID NOT NULL REFERENCES alerts(id) ON DELETE CASCADE,
+    rule_id UUID NOT NULL,
+    repeat_count INTEGER NOT NULL,
+    notified_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (escalation_event_id, rule_id, repeat_count)
+);
+
+CREATE INDEX idx_alert_escalation_notified_steps_alert_id ON alert_escalation_notified_steps(alert_id);
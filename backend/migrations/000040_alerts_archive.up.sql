@@ -0,0 +1,10 @@
+-- Closed alerts removed from the alerts table by an organization's retention
+-- policy. Rows keep the alert's columns as they were when it was archived.
+CREATE TABLE IF NOT EXISTS alerts_archive (LIKE alerts INCLUDING DEFAULTS);
+
+ALTER TABLE alerts_archive
+    ADD PRIMARY KEY (id),
+    ADD FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
+CREATE INDEX idx_alerts_archive_org_closed_at ON alerts_archive(organization_id, closed_at);
@@ -0,0 +1,2 @@
+ALTER TABLE escalation_targets DROP CONSTRAINT IF EXISTS valid_schedule_mode;
+ALTER TABLE escalation_targets DROP COLUMN IF EXISTS schedule_mode;
@@ -0,0 +1,10 @@
+-- Who a schedule target pages: the current on-call user, or every participant
+-- of the schedule's rotations. NULL for user and team targets.
+ALTER TABLE escalation_targets ADD COLUMN IF NOT EXISTS schedule_mode VARCHAR(20);
+
+UPDATE escalation_targets SET schedule_mode = 'on_call' WHERE target_type = 'schedule' AND schedule_mode IS NULL;
+
+ALTER TABLE escalation_targets ADD CONSTRAINT valid_schedule_mode CHECK (
+    (target_type = 'schedule' AND schedule_mode IN ('on_call', 'all_members'))
+    OR (target_type <> 'schedule' AND schedule_mode IS NULL)
+);
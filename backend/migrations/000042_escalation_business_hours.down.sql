@@ -0,0 +1,3 @@
+ALTER TABLE escalation_rules DROP CONSTRAINT IF EXISTS valid_rule_hours;
+ALTER TABLE escalation_rules DROP COLUMN IF EXISTS hours;
+ALTER TABLE escalation_policies DROP COLUMN IF EXISTS business_hours;
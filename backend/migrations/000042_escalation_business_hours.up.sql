@@ -0,0 +1,9 @@
+-- Weekly business-hours window of an escalation policy. Rules can be limited
+-- to business hours or to after hours; NULL means every rule always applies.
+ALTER TABLE escalation_policies ADD COLUMN IF NOT EXISTS business_hours JSONB;
+
+ALTER TABLE escalation_rules ADD COLUMN IF NOT EXISTS hours VARCHAR(20) NOT NULL DEFAULT 'always';
+
+ALTER TABLE escalation_rules ADD CONSTRAINT valid_rule_hours CHECK (
+    hours IN ('always', 'business_hours', 'after_hours')
+);
@@ -0,0 +1,13 @@
+-- Acknowledge links sent with escalation notifications. A token is scoped to
+-- one alert and the user it was sent to, expires, and works once.
+CREATE TABLE IF NOT EXISTS alert_ack_tokens (
+    id UUID PRIMARY KEY,
+    alert_id UUID NOT NULL REFERENCES alerts(id) ON DELETE CASCADE,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_ack_tokens_alert ON alert_ack_tokens(alert_id);
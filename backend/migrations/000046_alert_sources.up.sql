@@ -0,0 +1,17 @@
+-- Registry of known alert sources. Alerts arriving with one of a source's
+-- aliases are filed under its canonical name.
+CREATE TABLE IF NOT EXISTS alert_sources (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_alert_sources_org_name ON alert_sources(organization_id, LOWER(name));
+
+CREATE TRIGGER update_alert_sources_updated_at
+    BEFORE UPDATE ON alert_sources
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,3 @@
+ALTER TABLE escalation_rules DROP CONSTRAINT IF EXISTS valid_rule_min_priority;
+ALTER TABLE escalation_rules DROP COLUMN IF EXISTS only_if_unassigned;
+ALTER TABLE escalation_rules DROP COLUMN IF EXISTS min_priority;
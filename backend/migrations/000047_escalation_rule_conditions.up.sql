@@ -0,0 +1,8 @@
+-- Conditions checked when escalation reaches a rule; the rule is skipped when
+-- the alert no longer meets them. NULL min_priority matches every priority.
+ALTER TABLE escalation_rules ADD COLUMN IF NOT EXISTS min_priority VARCHAR(10);
+ALTER TABLE escalation_rules ADD COLUMN IF NOT EXISTS only_if_unassigned BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE escalation_rules ADD CONSTRAINT valid_rule_min_priority CHECK (
+    min_priority IS NULL OR min_priority IN ('P1', 'P2', 'P3', 'P4', 'P5')
+);
@@ -0,0 +1,5 @@
+UPDATE users u
+SET is_active = false
+WHERE EXISTS (SELECT 1 FROM organization_users ou WHERE ou.user_id = u.id AND NOT ou.is_active);
+
+ALTER TABLE organization_users DROP COLUMN IF EXISTS is_active;
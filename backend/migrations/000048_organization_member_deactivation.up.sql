@@ -0,0 +1,13 @@
+-- Deactivation applies to a single organization. Users deactivated while it
+-- disabled the whole account stay deactivated in each of their organizations.
+ALTER TABLE organization_users ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT true;
+
+UPDATE organization_users ou
+SET is_active = false
+FROM users u
+WHERE u.id = ou.user_id AND u.is_active = false;
+
+UPDATE users u
+SET is_active = true
+WHERE u.is_active = false
+  AND EXISTS (SELECT 1 FROM organization_users ou WHERE ou.user_id = u.id);
@@ -0,0 +1,3 @@
+-- Set when publishing an event failed, so the relay retries it right away
+-- instead of waiting for the request that wrote it
+ALTER TABLE event_outbox ADD COLUMN IF NOT EXISTS publish_failed_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,277 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/tests/integration/testutils"
+)
+
+// registerSource registers an alert source through the API
+func registerSource(t *testing.T, client *testutils.TestClient, name string, aliases ...string) *domain.RegisteredSource {
+	t.Helper()
+	resp := client.Post("/api/v1/sources", map[string]interface{}{
+		"name":    name,
+		"aliases": aliases,
+	})
+	client.AssertStatus(resp, http.StatusCreated)
+
+	var source domain.RegisteredSource
+	client.ParseJSON(resp, &source)
+	return &source
+}
+
+func createAlertFromSource(t *testing.T, orgID uuid.UUID, source string) *domain.Alert {
+	t.Helper()
+	alert, err := testServer.AlertService.CreateAlert(context.Background(), orgID, &dto.CreateAlertRequest{
+		Source:   source,
+		Priority: "P3",
+		Message:  fmt.Sprintf("Alert from %s", source),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+	return alert
+}
+
+// ============================================================================
+// POST /api/v1/sources
+// ============================================================================
+
+func TestAlertSources_Create_NormalizesAliases(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	source := registerSource(t, client, " Prometheus ", "PROM", "prom", " prometheus ", "")
+
+	if source.Name != "Prometheus" {
+		t.Errorf("Expected trimmed name Prometheus, got %q", source.Name)
+	}
+	if fmt.Sprint(source.Aliases) != "[prom]" {
+		t.Errorf("Expected aliases lowercased and deduplicated to [prom], got %v", source.Aliases)
+	}
+	if source.OrganizationID != user.Organization.ID {
+		t.Errorf("Expected source in organization %s, got %s", user.Organization.ID, source.OrganizationID)
+	}
+}
+
+func TestAlertSources_Create_ConflictingAlias(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	registerSource(t, client, "Prometheus", "prom")
+
+	resp := client.Post("/api/v1/sources", map[string]interface{}{
+		"name":    "Alertmanager",
+		"aliases": []string{"PROM"},
+	})
+	client.AssertStatus(resp, http.StatusConflict)
+
+	resp = client.Post("/api/v1/sources", map[string]interface{}{
+		"name": "prometheus",
+	})
+	client.AssertStatus(resp, http.StatusConflict)
+}
+
+func TestAlertSources_Create_MissingName(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Post("/api/v1/sources", map[string]interface{}{
+		"name": "   ",
+	})
+	client.AssertStatus(resp, http.StatusBadRequest)
+}
+
+// ============================================================================
+// Source normalization at alert creation
+// ============================================================================
+
+func TestAlertSources_AliasNormalization(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	registerSource(t, client, "Prometheus", "prom")
+
+	for _, tc := range []struct {
+		source string
+		want   string
+	}{
+		{"prom", "Prometheus"},
+		{"PROM", "Prometheus"},
+		{"prometheus", "Prometheus"},
+		{" Prometheus ", "Prometheus"},
+		{"Grafana", "Grafana"},
+	} {
+		resp := client.Post("/api/v1/alerts", map[string]interface{}{
+			"source":   tc.source,
+			"priority": "P3",
+			"message":  "Disk filling up",
+		})
+		client.AssertStatus(resp, http.StatusCreated)
+
+		var alert domain.Alert
+		client.ParseJSON(resp, &alert)
+		if alert.Source != tc.want {
+			t.Errorf("Expected source %q to be filed as %q, got %q", tc.source, tc.want, alert.Source)
+		}
+	}
+
+	// Other organizations' registries don't apply
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	alert := createAlertFromSource(t, other.Organization.ID, "prom")
+	if alert.Source != "prom" {
+		t.Errorf("Expected another organization's alert to keep source prom, got %q", alert.Source)
+	}
+}
+
+func TestAlertSources_FilterByAlias(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	registerSource(t, client, "Prometheus", "prom")
+
+	createAlertFromSource(t, user.Organization.ID, "prom")
+	createAlertFromSource(t, user.Organization.ID, "Prometheus")
+	createAlertFromSource(t, user.Organization.ID, "Grafana")
+
+	resp := client.Get("/api/v1/alerts?source=PROM")
+	client.AssertStatus(resp, http.StatusOK)
+
+	var result dto.ListAlertsResponse
+	client.ParseJSON(resp, &result)
+	if result.Total != 2 {
+		t.Errorf("Expected 2 alerts filed under Prometheus, got %d", result.Total)
+	}
+	for _, alert := range result.Alerts {
+		if alert.Source != "Prometheus" {
+			t.Errorf("Expected only Prometheus alerts, got %q", alert.Source)
+		}
+	}
+}
+
+// ============================================================================
+// GET /api/v1/sources
+// ============================================================================
+
+func TestAlertSources_List_WithCounts(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	// Created before the alias was registered, still counted under it
+	createAlertFromSource(t, orgID, "prom")
+
+	registerSource(t, client, "Prometheus", "prom", "alertmanager")
+	registerSource(t, client, "Datadog")
+
+	createAlertFromSource(t, orgID, "PROM")
+	createAlertFromSource(t, orgID, "alertmanager")
+	createAlertFromSource(t, orgID, "custom-script")
+
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	createAlertFromSource(t, other.Organization.ID, "prom")
+
+	resp := client.Get("/api/v1/sources")
+	client.AssertStatus(resp, http.StatusOK)
+
+	var result struct {
+		Sources []domain.RegisteredSourceWithCount `json:"sources"`
+	}
+	client.ParseJSON(resp, &result)
+
+	if len(result.Sources) != 2 {
+		t.Fatalf("Expected 2 registered sources, got %d", len(result.Sources))
+	}
+	if result.Sources[0].Name != "Datadog" || result.Sources[1].Name != "Prometheus" {
+		t.Errorf("Expected sources ordered by name, got %s, %s", result.Sources[0].Name, result.Sources[1].Name)
+	}
+	if result.Sources[0].AlertCount != 0 {
+		t.Errorf("Expected no Datadog alerts, got %d", result.Sources[0].AlertCount)
+	}
+	if result.Sources[1].AlertCount != 3 {
+		t.Errorf("Expected 3 Prometheus alerts, got %d", result.Sources[1].AlertCount)
+	}
+}
+
+// ============================================================================
+// PATCH /api/v1/sources/:id, DELETE /api/v1/sources/:id
+// ============================================================================
+
+func TestAlertSources_UpdateAndDelete(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	source := registerSource(t, client, "Prometheus", "prom")
+
+	resp := client.Patch(fmt.Sprintf("/api/v1/sources/%s", source.ID), map[string]interface{}{
+		"aliases": []string{"prom-eu", "prom-us"},
+	})
+	client.AssertStatus(resp, http.StatusOK)
+
+	if alert := createAlertFromSource(t, user.Organization.ID, "PROM-EU"); alert.Source != "Prometheus" {
+		t.Errorf("Expected new alias to normalize to Prometheus, got %q", alert.Source)
+	}
+	if alert := createAlertFromSource(t, user.Organization.ID, "prom"); alert.Source != "prom" {
+		t.Errorf("Expected removed alias to be left alone, got %q", alert.Source)
+	}
+
+	resp = client.Delete(fmt.Sprintf("/api/v1/sources/%s", source.ID))
+	client.AssertStatus(resp, http.StatusOK)
+
+	resp = client.Delete(fmt.Sprintf("/api/v1/sources/%s", source.ID))
+	client.AssertStatus(resp, http.StatusNotFound)
+
+	if alert := createAlertFromSource(t, user.Organization.ID, "prom-eu"); alert.Source != "prom-eu" {
+		t.Errorf("Expected a deleted source to stop normalizing, got %q", alert.Source)
+	}
+}
+
+func TestAlertSources_Update_OtherOrganization(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(owner.AccessToken)
+	source := registerSource(t, client, "Prometheus")
+
+	outsider, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(outsider.AccessToken)
+
+	resp := client.Patch(fmt.Sprintf("/api/v1/sources/%s", source.ID), map[string]interface{}{
+		"name": "Hijacked",
+	})
+	client.AssertStatus(resp, http.StatusNotFound)
+}
@@ -4,8 +4,17 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/outbound/postgres"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/tests/integration/testutils"
 )
 
 // ============================================================================
@@ -83,6 +92,63 @@ func TestAlerts_Create_MissingRequiredFields(t *testing.T) {
 	client.ExpectStatus(resp, http.StatusBadRequest)
 }
 
+func TestAlerts_Create_NormalizesTags(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Post("/api/v1/alerts", map[string]interface{}{
+		"source":   "api",
+		"priority": "P2",
+		"message":  "Tagged alert",
+		"tags":     []string{"production", " Production ", "PRODUCTION", "", "  ", "Database"},
+	})
+	client.ExpectStatus(resp, http.StatusCreated)
+
+	var alert domain.Alert
+	client.ParseJSON(resp, &alert)
+
+	expected := []string{"production", "database"}
+	if fmt.Sprint(alert.Tags) != fmt.Sprint(expected) {
+		t.Errorf("Expected tags %v, got %v", expected, alert.Tags)
+	}
+}
+
+func TestAlerts_Create_TooManyTags(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	tags := make([]string, 0, domain.MaxAlertTags+1)
+	for i := 0; i < domain.MaxAlertTags; i++ {
+		tags = append(tags, fmt.Sprintf("tag-%d", i))
+	}
+
+	reqBody := map[string]interface{}{
+		"source":   "api",
+		"priority": "P2",
+		"message":  "Tagged alert",
+		"tags":     tags,
+	}
+	resp := client.Post("/api/v1/alerts", reqBody)
+	client.ExpectStatus(resp, http.StatusCreated)
+
+	reqBody["tags"] = append(tags, "one-too-many")
+	resp = client.Post("/api/v1/alerts", reqBody)
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	// Duplicates don't count towards the cap
+	reqBody["tags"] = append(tags, "TAG-0")
+	resp = client.Post("/api/v1/alerts", reqBody)
+	client.ExpectStatus(resp, http.StatusCreated)
+}
+
 // ============================================================================
 // GET /api/v1/alerts
 // ============================================================================
@@ -176,10 +242,10 @@ func TestAlerts_List_Unauthorized(t *testing.T) {
 }
 
 // ============================================================================
-// GET /api/v1/alerts/:id
+// GET /api/v1/alerts/export.csv
 // ============================================================================
 
-func TestAlerts_Get_Success(t *testing.T) {
+func TestAlerts_ExportCSV_Success(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -187,114 +253,217 @@ func TestAlerts_Get_Success(t *testing.T) {
 	user, _ := testFixtures.CreateUniqueUser(ctx)
 	client.SetAuthToken(user.AccessToken)
 
-	alert, err := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
-	if err != nil {
-		t.Fatalf("Failed to create alert: %v", err)
+	testFixtures.CreateAlert(ctx, user.Organization.ID, "Still open")
+	closed, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Disk full")
+	if err := testServer.AlertService.CloseAlert(ctx, closed.ID, user.Organization.ID, user.User.ID, "fixed"); err != nil {
+		t.Fatalf("Failed to close alert: %v", err)
 	}
 
-	resp := client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
-	client.AssertStatus(resp, http.StatusOK)
-
-	var result map[string]interface{}
-	client.ParseJSON(resp, &result)
+	rows := getCSV(t, client, "/api/v1/alerts/export.csv?status=closed")
+	expectedHeader := "id,message,priority,status,source,created_at,acknowledged_at,closed_at,time_to_acknowledge_seconds,time_to_close_seconds"
+	if header := strings.Join(rows[0], ","); header != expectedHeader {
+		t.Errorf("Expected header %q, got %q", expectedHeader, header)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected only the closed alert, got %d rows", len(rows)-1)
+	}
 
-	if result["id"] != alert.ID.String() {
-		t.Errorf("Expected alert ID %s, got %v", alert.ID, result["id"])
+	row := rows[1]
+	if row[0] != closed.ID.String() || row[1] != "Disk full" || row[3] != "closed" {
+		t.Errorf("Expected the closed alert, got %v", row)
+	}
+	if row[7] == "" || row[9] == "" {
+		t.Errorf("Expected closed_at and time to close to be set, got %q and %q", row[7], row[9])
+	}
+	if row[6] != "" || row[8] != "" {
+		t.Errorf("Expected no acknowledgement, got %q and %q", row[6], row[8])
 	}
 }
 
-func TestAlerts_Get_NotFound(t *testing.T) {
-	cleanDatabase(t)
-	ctx := context.Background()
+func TestAlerts_ExportCSV_Unauthorized(t *testing.T) {
 	client := newTestClient(t)
 
-	user, _ := testFixtures.CreateUniqueUser(ctx)
-	client.SetAuthToken(user.AccessToken)
-
-	resp := client.Get("/api/v1/alerts/00000000-0000-0000-0000-000000000000")
-	client.ExpectStatus(resp, http.StatusNotFound)
+	resp := client.Get("/api/v1/alerts/export.csv")
+	client.ExpectStatus(resp, http.StatusUnauthorized)
 }
 
-func TestAlerts_Get_InvalidUUID(t *testing.T) {
+func TestAlerts_ListAfterCursor_PagesAcrossSharedTimestamps(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
-	client := newTestClient(t)
 
 	user, _ := testFixtures.CreateUniqueUser(ctx)
-	client.SetAuthToken(user.AccessToken)
+	for i := 0; i < 5; i++ {
+		testFixtures.CreateAlert(ctx, user.Organization.ID, fmt.Sprintf("Alert %d", i))
+	}
+	// Rows sharing created_at are ordered by id, so no page boundary can split them ambiguously
+	if _, err := testDB.ExecContext(ctx, `UPDATE alerts SET created_at = '2024-01-02T12:00:00Z' WHERE organization_id = $1`, user.Organization.ID); err != nil {
+		t.Fatalf("Failed to backdate alerts: %v", err)
+	}
 
-	resp := client.Get("/api/v1/alerts/invalid-uuid")
-	client.ExpectStatus(resp, http.StatusBadRequest)
+	repo := postgres.NewAlertRepository(&postgres.DB{DB: testDB.DB})
+	filter := &domain.AlertFilter{OrganizationID: user.Organization.ID, Limit: 2}
+	seen := map[uuid.UUID]bool{}
+	for page := 0; page < 5; page++ {
+		alerts, total, err := repo.List(ctx, filter)
+		if err != nil {
+			t.Fatalf("Failed to list alerts: %v", err)
+		}
+		if total != 5 {
+			t.Errorf("Expected a total of 5 regardless of cursor, got %d", total)
+		}
+		for _, alert := range alerts {
+			if seen[alert.ID] {
+				t.Fatalf("Alert %s returned on more than one page", alert.ID)
+			}
+			seen[alert.ID] = true
+		}
+		if len(alerts) < filter.Limit {
+			break
+		}
+		last := alerts[len(alerts)-1]
+		filter.After = &domain.ListCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	if len(seen) != 5 {
+		t.Errorf("Expected every alert exactly once, got %d", len(seen))
+	}
 }
 
 // ============================================================================
-// PATCH /api/v1/alerts/:id
+// GET /api/v1/alerts/grouped
 // ============================================================================
 
-func TestAlerts_Update_Success(t *testing.T) {
+// createGroupableAlert creates an open alert with the fields alerts are grouped by
+func createGroupableAlert(t *testing.T, orgID uuid.UUID, source, priority string, tags []string, dedupKey *string) *domain.Alert {
+	t.Helper()
+	alert, err := testServer.AlertService.CreateAlert(context.Background(), orgID, &dto.CreateAlertRequest{
+		Source:   source,
+		Priority: priority,
+		Message:  fmt.Sprintf("%s alert", source),
+		Tags:     tags,
+		DedupKey: dedupKey,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+	return alert
+}
+
+// getAlertGroups fetches the grouped view and indexes the groups by key
+func getAlertGroups(t *testing.T, client *testutils.TestClient, query string) ([]*dto.AlertGroup, map[string]*dto.AlertGroup) {
+	t.Helper()
+
+	resp := client.Get("/api/v1/alerts/grouped?" + query)
+	client.ExpectStatus(resp, http.StatusOK)
+	var result dto.GroupAlertsResponse
+	client.ParseJSON(resp, &result)
+
+	byKey := make(map[string]*dto.AlertGroup, len(result.Groups))
+	for _, group := range result.Groups {
+		byKey[group.Key] = group
+	}
+	return result.Groups, byKey
+}
+
+func TestAlerts_Grouped_BySource(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
 
 	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
 	client.SetAuthToken(user.AccessToken)
-
-	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
-
-	reqBody := map[string]interface{}{
-		"priority": "P1",
+	orgID := user.Organization.ID
+
+	createGroupableAlert(t, orgID, "prometheus", "P3", nil, nil)
+	urgent := createGroupableAlert(t, orgID, "prometheus", "P2", nil, nil)
+	createGroupableAlert(t, orgID, "prometheus", "P3", nil, nil)
+	newest := createGroupableAlert(t, orgID, "prometheus", "P3", nil, nil)
+	createGroupableAlert(t, orgID, "grafana", "P1", nil, nil)
+
+	// Closed alerts and other organizations' alerts are left out
+	closed := createGroupableAlert(t, orgID, "prometheus", "P1", nil, nil)
+	if err := testServer.AlertService.CloseAlert(ctx, closed.ID, orgID, user.User.ID, "fixed"); err != nil {
+		t.Fatalf("Failed to close alert: %v", err)
 	}
+	createGroupableAlert(t, other.Organization.ID, "prometheus", "P1", nil, nil)
 
-	resp := client.Patch(fmt.Sprintf("/api/v1/alerts/%s", alert.ID), reqBody)
-	client.AssertStatus(resp, http.StatusOK)
+	groups, byKey := getAlertGroups(t, client, "by=source")
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(groups))
+	}
+	if groups[0].Key != "prometheus" {
+		t.Errorf("Expected the largest group first, got %q", groups[0].Key)
+	}
 
-	var result map[string]interface{}
-	client.ParseJSON(resp, &result)
+	prometheus := byKey["prometheus"]
+	if prometheus.Count != 4 || prometheus.HighestPriority != "P2" {
+		t.Errorf("Expected 4 prometheus alerts at P2, got %d at %s", prometheus.Count, prometheus.HighestPriority)
+	}
+	if len(prometheus.Representatives) != domain.AlertGroupRepresentatives {
+		t.Fatalf("Expected %d representatives, got %d", domain.AlertGroupRepresentatives, len(prometheus.Representatives))
+	}
+	if prometheus.Representatives[0].ID != urgent.ID || prometheus.Representatives[1].ID != newest.ID {
+		t.Errorf("Expected the P2 alert then the newest P3 alert as representatives")
+	}
 
-	if result["priority"] != "P1" {
-		t.Errorf("Expected priority 'P1', got %v", result["priority"])
+	if grafana := byKey["grafana"]; grafana == nil || grafana.Count != 1 || grafana.HighestPriority != "P1" {
+		t.Errorf("Expected one P1 grafana alert, got %+v", grafana)
 	}
 }
 
-func TestAlerts_Update_NotFound(t *testing.T) {
+func TestAlerts_Grouped_ByTag(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
 
 	user, _ := testFixtures.CreateUniqueUser(ctx)
 	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
 
-	reqBody := map[string]interface{}{
-		"priority": "P1",
+	createGroupableAlert(t, orgID, "prometheus", "P3", []string{"service:api", "team:sre"}, nil)
+	createGroupableAlert(t, orgID, "grafana", "P2", []string{"region:eu", "service:api"}, nil)
+	database := createGroupableAlert(t, orgID, "prometheus", "P1", []string{"service:db"}, nil)
+	untagged := createGroupableAlert(t, orgID, "prometheus", "P4", []string{"team:sre"}, nil)
+
+	groups, byKey := getAlertGroups(t, client, "by=tag&tag=service")
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %d", len(groups))
 	}
 
-	resp := client.Patch("/api/v1/alerts/00000000-0000-0000-0000-000000000000", reqBody)
-	client.ExpectStatus(resp, http.StatusBadRequest) // API returns 400 for not found errors
+	if api := byKey["api"]; api == nil || api.Count != 2 || api.HighestPriority != "P2" {
+		t.Errorf("Expected two service:api alerts at P2, got %+v", api)
+	}
+	if db := byKey["db"]; db == nil || db.Count != 1 || db.Representatives[0].ID != database.ID {
+		t.Errorf("Expected the service:db alert in its own group, got %+v", db)
+	}
+	if rest := byKey[""]; rest == nil || rest.Count != 1 || rest.Representatives[0].ID != untagged.ID {
+		t.Errorf("Expected the alert without a service tag in the empty group, got %+v", rest)
+	}
 }
 
-// ============================================================================
-// DELETE /api/v1/alerts/:id
-// ============================================================================
-
-func TestAlerts_Delete_Success(t *testing.T) {
+func TestAlerts_Grouped_ByFingerprintPrefix(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
 
 	user, _ := testFixtures.CreateUniqueUser(ctx)
 	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
 
-	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
-
-	resp := client.Delete(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
-	client.AssertStatus(resp, http.StatusOK)
+	for _, key := range []string{"disk-web-1", "disk-web-2", "cpu-web-1"} {
+		createGroupableAlert(t, orgID, "prometheus", "P3", nil, &key)
+	}
 
-	// Verify it's deleted
-	resp = client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
-	client.ExpectStatus(resp, http.StatusNotFound)
+	_, byKey := getAlertGroups(t, client, "by=fingerprint&prefix_length=4")
+	if disk := byKey["disk"]; disk == nil || disk.Count != 2 {
+		t.Errorf("Expected two alerts with the disk prefix, got %+v", disk)
+	}
+	if cpu := byKey["cpu-"]; cpu == nil || cpu.Count != 1 {
+		t.Errorf("Expected one alert with the cpu- prefix, got %+v", cpu)
+	}
 }
 
-func TestAlerts_Delete_NotFound(t *testing.T) {
+func TestAlerts_Grouped_InvalidRequest(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -302,15 +471,17 @@ func TestAlerts_Delete_NotFound(t *testing.T) {
 	user, _ := testFixtures.CreateUniqueUser(ctx)
 	client.SetAuthToken(user.AccessToken)
 
-	resp := client.Delete("/api/v1/alerts/00000000-0000-0000-0000-000000000000")
-	client.ExpectStatus(resp, http.StatusBadRequest) // API returns 400 for not found errors
+	for _, query := range []string{"", "by=team", "by=tag", "by=fingerprint&prefix_length=-1"} {
+		resp := client.Get("/api/v1/alerts/grouped?" + query)
+		client.ExpectStatus(resp, http.StatusBadRequest)
+	}
 }
 
 // ============================================================================
-// POST /api/v1/alerts/:id/acknowledge
+// GET /api/v1/alerts/:id
 // ============================================================================
 
-func TestAlerts_Acknowledge_Success(t *testing.T) {
+func TestAlerts_Get_Success(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -318,22 +489,23 @@ func TestAlerts_Acknowledge_Success(t *testing.T) {
 	user, _ := testFixtures.CreateUniqueUser(ctx)
 	client.SetAuthToken(user.AccessToken)
 
-	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+	alert, err := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
 
-	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/acknowledge", alert.ID), nil)
+	resp := client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
 	client.AssertStatus(resp, http.StatusOK)
 
-	// Verify status changed
-	resp = client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
 	var result map[string]interface{}
 	client.ParseJSON(resp, &result)
 
-	if result["status"] != "acknowledged" {
-		t.Errorf("Expected status 'acknowledged', got %v", result["status"])
+	if result["id"] != alert.ID.String() {
+		t.Errorf("Expected alert ID %s, got %v", alert.ID, result["id"])
 	}
 }
 
-func TestAlerts_Acknowledge_NotFound(t *testing.T) {
+func TestAlerts_Get_NotFound(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -341,62 +513,100 @@ func TestAlerts_Acknowledge_NotFound(t *testing.T) {
 	user, _ := testFixtures.CreateUniqueUser(ctx)
 	client.SetAuthToken(user.AccessToken)
 
-	resp := client.Post("/api/v1/alerts/00000000-0000-0000-0000-000000000000/acknowledge", nil)
-	client.ExpectStatus(resp, http.StatusBadRequest) // API returns 400 for not found errors
+	resp := client.Get("/api/v1/alerts/00000000-0000-0000-0000-000000000000")
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+func TestAlerts_Get_InvalidUUID(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Get("/api/v1/alerts/invalid-uuid")
+	client.ExpectStatus(resp, http.StatusBadRequest)
 }
 
 // ============================================================================
-// POST /api/v1/alerts/:id/close
+// GET /api/v1/alerts/:id/oncall-at-creation
 // ============================================================================
 
-func TestAlerts_Close_Success(t *testing.T) {
+func TestAlerts_OnCallAtCreation_ReturnsHistoricalUser(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
 
-	user, _ := testFixtures.CreateUniqueUser(ctx)
-	client.SetAuthToken(user.AccessToken)
+	first, _ := testFixtures.CreateUniqueUser(ctx)
+	second, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(first.AccessToken)
+	orgID := first.Organization.ID
 
-	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+	// Daily rotation from 2024-01-01: first on the 1st, second on the 2nd
+	schedule := createTwoPersonRotation(t, ctx, orgID, []uuid.UUID{first.User.ID, second.User.ID})
 
-	reqBody := map[string]string{
-		"reason": "Resolved by test",
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, orgID, "Primary")
+	rule, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: 1, EscalationDelay: 5})
+	if err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+	if _, err := testServer.EscalationService.AddTarget(ctx, orgID, rule.ID, &dto.AddEscalationTargetRequest{TargetType: "schedule", TargetID: schedule.ID}); err != nil {
+		t.Fatalf("Failed to add target: %v", err)
 	}
 
-	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/close", alert.ID), reqBody)
-	client.AssertStatus(resp, http.StatusOK)
+	alert, err := testServer.AlertService.CreateAlert(ctx, orgID, &dto.CreateAlertRequest{
+		Source:             "test",
+		Priority:           "P2",
+		Message:            "Replication lag",
+		EscalationPolicyID: &policy.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+	if _, err := testDB.ExecContext(ctx, `UPDATE alerts SET created_at = '2024-01-02T12:00:00Z' WHERE id = $1`, alert.ID); err != nil {
+		t.Fatalf("Failed to backdate alert: %v", err)
+	}
 
-	// Verify status changed
-	resp = client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
-	var result map[string]interface{}
+	resp := client.Get(fmt.Sprintf("/api/v1/alerts/%s/oncall-at-creation", alert.ID))
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var result struct {
+		OnCall []domain.ScheduleOnCall `json:"oncall"`
+	}
 	client.ParseJSON(resp, &result)
 
-	if result["status"] != "closed" {
-		t.Errorf("Expected status 'closed', got %v", result["status"])
+	if len(result.OnCall) != 1 {
+		t.Fatalf("Expected 1 schedule, got %d", len(result.OnCall))
+	}
+	entry := result.OnCall[0]
+	if entry.Schedule == nil || entry.Schedule.ID != schedule.ID {
+		t.Fatalf("Expected schedule %s, got %+v", schedule.ID, entry.Schedule)
+	}
+	if entry.OnCall == nil || entry.OnCall.UserID != second.User.ID {
+		t.Errorf("Expected %s to have been on call when the alert fired, got %+v", second.User.ID, entry.OnCall)
 	}
 }
 
-func TestAlerts_Close_NotFound(t *testing.T) {
+func TestAlerts_OnCallAtCreation_NotFound(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
 
 	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	alert, _ := testFixtures.CreateAlert(ctx, other.Organization.ID, "Elsewhere")
 	client.SetAuthToken(user.AccessToken)
 
-	reqBody := map[string]string{
-		"reason": "Test reason",
-	}
-
-	resp := client.Post("/api/v1/alerts/00000000-0000-0000-0000-000000000000/close", reqBody)
-	client.ExpectStatus(resp, http.StatusBadRequest) // API returns 400 for not found errors
+	resp := client.Get(fmt.Sprintf("/api/v1/alerts/%s/oncall-at-creation", alert.ID))
+	client.ExpectStatus(resp, http.StatusNotFound)
 }
 
 // ============================================================================
-// POST /api/v1/alerts/:id/snooze
+// PATCH /api/v1/alerts/:id
 // ============================================================================
 
-func TestAlerts_Snooze_Success(t *testing.T) {
+func TestAlerts_Update_Success(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -406,25 +616,22 @@ func TestAlerts_Snooze_Success(t *testing.T) {
 
 	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
 
-	snoozeUntil := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
-	reqBody := map[string]string{
-		"until": snoozeUntil,
+	reqBody := map[string]interface{}{
+		"priority": "P1",
 	}
 
-	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/snooze", alert.ID), reqBody)
+	resp := client.Patch(fmt.Sprintf("/api/v1/alerts/%s", alert.ID), reqBody)
 	client.AssertStatus(resp, http.StatusOK)
 
-	// Verify status changed
-	resp = client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
 	var result map[string]interface{}
 	client.ParseJSON(resp, &result)
 
-	if result["status"] != "snoozed" {
-		t.Errorf("Expected status 'snoozed', got %v", result["status"])
+	if result["priority"] != "P1" {
+		t.Errorf("Expected priority 'P1', got %v", result["priority"])
 	}
 }
 
-func TestAlerts_Snooze_NotFound(t *testing.T) {
+func TestAlerts_Update_NotFound(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -432,19 +639,15 @@ func TestAlerts_Snooze_NotFound(t *testing.T) {
 	user, _ := testFixtures.CreateUniqueUser(ctx)
 	client.SetAuthToken(user.AccessToken)
 
-	reqBody := map[string]string{
-		"until": time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339),
+	reqBody := map[string]interface{}{
+		"priority": "P1",
 	}
 
-	resp := client.Post("/api/v1/alerts/00000000-0000-0000-0000-000000000000/snooze", reqBody)
+	resp := client.Patch("/api/v1/alerts/00000000-0000-0000-0000-000000000000", reqBody)
 	client.ExpectStatus(resp, http.StatusBadRequest) // API returns 400 for not found errors
 }
 
-// ============================================================================
-// POST /api/v1/alerts/:id/assign
-// ============================================================================
-
-func TestAlerts_Assign_ToUser_Success(t *testing.T) {
+func TestAlerts_Update_NormalizesTags(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -454,24 +657,21 @@ func TestAlerts_Assign_ToUser_Success(t *testing.T) {
 
 	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
 
-	reqBody := map[string]string{
-		"user_id": user.User.ID.String(),
-	}
-
-	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/assign", alert.ID), reqBody)
-	client.AssertStatus(resp, http.StatusOK)
+	resp := client.Patch(fmt.Sprintf("/api/v1/alerts/%s", alert.ID), map[string]interface{}{
+		"tags": []string{" Staging", "staging ", "API"},
+	})
+	client.ExpectStatus(resp, http.StatusOK)
 
-	// Verify assignment
-	resp = client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
-	var result map[string]interface{}
-	client.ParseJSON(resp, &result)
+	var updated domain.Alert
+	client.ParseJSON(resp, &updated)
 
-	if result["assigned_to_user_id"] != user.User.ID.String() {
-		t.Errorf("Expected assigned_to_user_id %s, got %v", user.User.ID, result["assigned_to_user_id"])
+	expected := []string{"staging", "api"}
+	if fmt.Sprint(updated.Tags) != fmt.Sprint(expected) {
+		t.Errorf("Expected tags %v, got %v", expected, updated.Tags)
 	}
 }
 
-func TestAlerts_Assign_ToTeam_Success(t *testing.T) {
+func TestAlerts_Update_OmittedTagsArePreserved(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -479,17 +679,625 @@ func TestAlerts_Assign_ToTeam_Success(t *testing.T) {
 	user, _ := testFixtures.CreateUniqueUser(ctx)
 	client.SetAuthToken(user.AccessToken)
 
-	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Test Team")
 	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
 
-	reqBody := map[string]string{
-		"team_id": team.ID.String(),
+	for _, body := range []map[string]interface{}{
+		{"priority": "P1"},
+		{"message": "Renamed", "tags": nil},
+	} {
+		resp := client.Patch(fmt.Sprintf("/api/v1/alerts/%s", alert.ID), body)
+		client.ExpectStatus(resp, http.StatusOK)
+
+		var updated domain.Alert
+		client.ParseJSON(resp, &updated)
+		if got := fmt.Sprint(updated.Tags); got != "[test]" {
+			t.Errorf("Expected %v to keep the tags, got %s", body, got)
+		}
 	}
 
-	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/assign", alert.ID), reqBody)
-	client.AssertStatus(resp, http.StatusOK)
+	// An empty list still clears them on purpose
+	resp := client.Patch(fmt.Sprintf("/api/v1/alerts/%s", alert.ID), map[string]interface{}{"tags": []string{}})
+	client.ExpectStatus(resp, http.StatusOK)
+	var cleared domain.Alert
+	client.ParseJSON(resp, &cleared)
+	if len(cleared.Tags) != 0 {
+		t.Errorf("Expected an empty list to clear the tags, got %v", cleared.Tags)
+	}
+}
 
-	// Verify assignment
+func TestAlerts_Update_AddAndRemoveTags(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+	path := fmt.Sprintf("/api/v1/alerts/%s", alert.ID)
+
+	steps := []struct {
+		body     map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{"add_tags": []string{"Database", "team:sre"}}, "[test database team:sre]"},
+		{map[string]interface{}{"remove_tags": []string{" TEST"}, "priority": "P2"}, "[database team:sre]"},
+		{map[string]interface{}{"add_tags": []string{"database", "region:eu"}, "remove_tags": []string{"team:sre"}}, "[database region:eu]"},
+		{map[string]interface{}{"remove_tags": []string{"missing"}}, "[database region:eu]"},
+		{map[string]interface{}{"tags": []string{"api"}, "add_tags": []string{"staging"}}, "[api staging]"},
+	}
+	for _, step := range steps {
+		resp := client.Patch(path, step.body)
+		client.ExpectStatus(resp, http.StatusOK)
+
+		var updated domain.Alert
+		client.ParseJSON(resp, &updated)
+		if got := fmt.Sprint(updated.Tags); got != step.expected {
+			t.Errorf("After %v: expected tags %s, got %s", step.body, step.expected, got)
+		}
+	}
+
+	tooMany := make([]string, 0, domain.MaxAlertTags)
+	for i := 0; i < domain.MaxAlertTags; i++ {
+		tooMany = append(tooMany, fmt.Sprintf("tag-%d", i))
+	}
+	resp := client.Patch(path, map[string]interface{}{"add_tags": tooMany})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+// ============================================================================
+// POST /api/v1/alerts/tags
+// ============================================================================
+
+// bulkTagAlerts applies tag changes to the alerts and returns them by ID
+func bulkTagAlerts(t *testing.T, client *testutils.TestClient, body map[string]interface{}) map[uuid.UUID]domain.Alert {
+	t.Helper()
+
+	resp := client.Post("/api/v1/alerts/tags", body)
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var result struct {
+		Alerts []domain.Alert `json:"alerts"`
+	}
+	client.ParseJSON(resp, &result)
+
+	byID := make(map[uuid.UUID]domain.Alert, len(result.Alerts))
+	for _, alert := range result.Alerts {
+		byID[alert.ID] = alert
+	}
+	return byID
+}
+
+func TestAlerts_BulkTags_AddAndRemove(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	first, _ := testFixtures.CreateUniqueAlert(ctx, user.Organization.ID)
+	second, _ := testFixtures.CreateUniqueAlert(ctx, user.Organization.ID)
+	untouched, _ := testFixtures.CreateUniqueAlert(ctx, user.Organization.ID)
+
+	alerts := bulkTagAlerts(t, client, map[string]interface{}{
+		"ids": []uuid.UUID{first.ID, second.ID},
+		"add": []string{"Incident-123", "database"},
+	})
+	if len(alerts) != 2 {
+		t.Fatalf("Expected 2 alerts, got %d", len(alerts))
+	}
+	for _, id := range []uuid.UUID{first.ID, second.ID} {
+		if got := fmt.Sprint(alerts[id].Tags); got != "[test incident-123 database]" {
+			t.Errorf("Expected alert %s to be tagged, got %s", id, got)
+		}
+	}
+
+	alerts = bulkTagAlerts(t, client, map[string]interface{}{
+		"ids":    []uuid.UUID{first.ID, second.ID},
+		"remove": []string{"TEST", "database"},
+	})
+	for _, id := range []uuid.UUID{first.ID, second.ID} {
+		if got := fmt.Sprint(alerts[id].Tags); got != "[incident-123]" {
+			t.Errorf("Expected tags to be removed from alert %s, got %s", id, got)
+		}
+	}
+
+	other, err := testServer.AlertService.GetAlert(ctx, untouched.ID, user.Organization.ID)
+	if err != nil {
+		t.Fatalf("Failed to get alert: %v", err)
+	}
+	if got := fmt.Sprint(other.Tags); got != "[test]" {
+		t.Errorf("Expected alerts outside the request to keep their tags, got %s", got)
+	}
+}
+
+func TestAlerts_BulkTags_AlreadyPresentIsNoOp(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	// The fixture alerts are already tagged "test"
+	first, _ := testFixtures.CreateUniqueAlert(ctx, user.Organization.ID)
+	second, _ := testFixtures.CreateUniqueAlert(ctx, user.Organization.ID)
+
+	alerts := bulkTagAlerts(t, client, map[string]interface{}{
+		"ids": []uuid.UUID{first.ID, second.ID},
+		"add": []string{" Test "},
+	})
+	for _, original := range []*domain.Alert{first, second} {
+		alert := alerts[original.ID]
+		if got := fmt.Sprint(alert.Tags); got != "[test]" {
+			t.Errorf("Expected the tag not to be duplicated on alert %s, got %s", original.ID, got)
+		}
+		if !alert.UpdatedAt.Equal(original.UpdatedAt) {
+			t.Errorf("Expected unchanged alert %s not to be written", original.ID)
+		}
+	}
+
+	// Listing an existing tag alongside a new one only adds the new one
+	alerts = bulkTagAlerts(t, client, map[string]interface{}{
+		"ids": []uuid.UUID{first.ID},
+		"add": []string{"test", "incident-123"},
+	})
+	if got := fmt.Sprint(alerts[first.ID].Tags); got != "[test incident-123]" {
+		t.Errorf("Expected only the new tag to be added, got %s", got)
+	}
+}
+
+func TestAlerts_BulkTags_AllOrNothing(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	ours, _ := testFixtures.CreateUniqueAlert(ctx, user.Organization.ID)
+	theirs, _ := testFixtures.CreateUniqueAlert(ctx, other.Organization.ID)
+
+	resp := client.Post("/api/v1/alerts/tags", map[string]interface{}{
+		"ids": []uuid.UUID{ours.ID, theirs.ID},
+		"add": []string{"incident-123"},
+	})
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	alert, err := testServer.AlertService.GetAlert(ctx, ours.ID, user.Organization.ID)
+	if err != nil {
+		t.Fatalf("Failed to get alert: %v", err)
+	}
+	if got := fmt.Sprint(alert.Tags); got != "[test]" {
+		t.Errorf("Expected no alert to be tagged when one is missing, got %s", got)
+	}
+
+	resp = client.Post("/api/v1/alerts/tags", map[string]interface{}{
+		"ids": []uuid.UUID{},
+		"add": []string{"incident-123"},
+	})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+// ============================================================================
+// DELETE /api/v1/alerts/:id
+// ============================================================================
+
+func TestAlerts_Delete_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+
+	resp := client.Delete(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
+	client.AssertStatus(resp, http.StatusOK)
+
+	// Verify it's deleted
+	resp = client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+func TestAlerts_Delete_NotFound(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Delete("/api/v1/alerts/00000000-0000-0000-0000-000000000000")
+	client.ExpectStatus(resp, http.StatusBadRequest) // API returns 400 for not found errors
+}
+
+// ============================================================================
+// POST /api/v1/alerts/:id/acknowledge
+// ============================================================================
+
+func TestAlerts_Acknowledge_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/acknowledge", alert.ID), nil)
+	client.AssertStatus(resp, http.StatusOK)
+
+	// Verify status changed
+	resp = client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
+	var result map[string]interface{}
+	client.ParseJSON(resp, &result)
+
+	if result["status"] != "acknowledged" {
+		t.Errorf("Expected status 'acknowledged', got %v", result["status"])
+	}
+}
+
+func TestAlerts_Acknowledge_NotFound(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Post("/api/v1/alerts/00000000-0000-0000-0000-000000000000/acknowledge", nil)
+	client.ExpectStatus(resp, http.StatusBadRequest) // API returns 400 for not found errors
+}
+
+// ============================================================================
+// POST /api/v1/alerts/ack/:token
+// ============================================================================
+
+var ackURLPattern = regexp.MustCompile(`/api/v1/alerts/ack/([A-Za-z0-9_-]+\.[A-Za-z0-9_-]+)`)
+
+// pageForAckToken pages the user about the alert and returns the token of the
+// acknowledge link in the notification
+func pageForAckToken(t *testing.T, ctx context.Context, alert *domain.Alert, userID uuid.UUID) string {
+	t.Helper()
+
+	targets := []domain.EscalationTarget{
+		{TargetType: domain.EscalationTargetTypeUser, TargetID: userID},
+	}
+	if err := testServer.AlertNotifier.NotifyAlertEscalated(ctx, alert, &domain.EscalationRule{}, targets); err != nil {
+		t.Fatalf("Failed to notify escalation: %v", err)
+	}
+
+	logs, err := testServer.NotificationService.ListLogsByAlert(ctx, alert.ID)
+	if err != nil {
+		t.Fatalf("Failed to list notification logs: %v", err)
+	}
+	for _, log := range logs {
+		if match := ackURLPattern.FindStringSubmatch(log.Message); match != nil {
+			return match[1]
+		}
+	}
+	t.Fatalf("Expected an acknowledge link in the notification, got %d logs without one", len(logs))
+	return ""
+}
+
+func TestAlerts_AckToken_AcknowledgesForNotifiedUser(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	responder, _ := testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, owner.Organization.ID, responder.User.ID)
+	createFlakyWebhookChannel(t, ctx, owner.Organization.ID, 0)
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, owner.Organization.ID, "Checkout Policy")
+	if _, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: 1, EscalationDelay: 5}); err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+	alert, _ := testFixtures.CreateAlert(ctx, owner.Organization.ID, "Checkout errors")
+
+	client.SetAuthToken(owner.AccessToken)
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/assign", alert.ID), map[string]interface{}{
+		"escalation_policy_id": policy.ID,
+	})
+	client.ExpectStatus(resp, http.StatusOK)
+	resp.Body.Close()
+
+	token := pageForAckToken(t, ctx, alert, responder.User.ID)
+
+	// The link works without signing in
+	client.ClearAuthToken()
+	resp = client.Post("/api/v1/alerts/ack/"+token, nil)
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var acked domain.Alert
+	client.ParseJSON(resp, &acked)
+	if acked.Status != domain.AlertStatusAcknowledged {
+		t.Errorf("Expected the alert to be acknowledged, got %s", acked.Status)
+	}
+	if acked.AcknowledgedBy == nil || *acked.AcknowledgedBy != responder.User.ID {
+		t.Errorf("Expected the acknowledgement attributed to %s, got %v", responder.User.ID, acked.AcknowledgedBy)
+	}
+
+	var running int
+	err := testDB.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM alert_escalation_events WHERE alert_id = $1 AND event_type = 'triggered'
+	`, alert.ID).Scan(&running)
+	if err != nil {
+		t.Fatalf("Failed to count escalation events: %v", err)
+	}
+	if running != 0 {
+		t.Errorf("Expected escalation to be halted, got %d running escalations", running)
+	}
+}
+
+func TestAlerts_AckToken_RejectsReusedExpiredAndForgedTokens(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	createFlakyWebhookChannel(t, ctx, user.Organization.ID, 0)
+
+	// Reused
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Disk full")
+	token := pageForAckToken(t, ctx, alert, user.User.ID)
+
+	resp := client.Post("/api/v1/alerts/ack/"+token, nil)
+	client.ExpectStatus(resp, http.StatusOK)
+	resp.Body.Close()
+
+	if err := testServer.AlertService.UnacknowledgeAlert(ctx, alert.ID, user.Organization.ID, user.User.ID); err != nil {
+		t.Fatalf("Failed to reopen alert: %v", err)
+	}
+	resp = client.Post("/api/v1/alerts/ack/"+token, nil)
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+	resp.Body.Close()
+
+	// Expired
+	expiring, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Queue backlog")
+	token = pageForAckToken(t, ctx, expiring, user.User.ID)
+	if _, err := testDB.ExecContext(ctx, `UPDATE alert_ack_tokens SET expires_at = NOW() - INTERVAL '1 minute' WHERE alert_id = $1`, expiring.ID); err != nil {
+		t.Fatalf("Failed to expire token: %v", err)
+	}
+	resp = client.Post("/api/v1/alerts/ack/"+token, nil)
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+	resp.Body.Close()
+
+	// Forged: another token's ID with a made-up signature
+	id, _, _ := strings.Cut(token, ".")
+	resp = client.Post("/api/v1/alerts/ack/"+id+".c2lnbmF0dXJl", nil)
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+	resp.Body.Close()
+
+	client.SetAuthToken(user.AccessToken)
+	resp = client.Get(fmt.Sprintf("/api/v1/alerts/%s", expiring.ID))
+	client.ExpectStatus(resp, http.StatusOK)
+	var result domain.Alert
+	client.ParseJSON(resp, &result)
+	if result.Status != domain.AlertStatusOpen {
+		t.Errorf("Expected the alert to stay open, got %s", result.Status)
+	}
+}
+
+func TestAlerts_AckToken_RejectsUsersWhoLostAccess(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	orgID := owner.Organization.ID
+
+	for name, revoke := range map[string]string{
+		"deactivated": `UPDATE users SET is_active = false WHERE id = $1`,
+		"removed":     `UPDATE organization_users SET is_active = false WHERE user_id = $1`,
+	} {
+		responder, _ := testFixtures.CreateUniqueUser(ctx)
+		joinOrganization(t, ctx, orgID, responder.User.ID)
+
+		alert, _ := testFixtures.CreateAlert(ctx, orgID, "Checkout errors "+name)
+		token, err := testServer.AlertService.IssueAckToken(ctx, alert, responder.User.ID)
+		if err != nil {
+			t.Fatalf("Failed to issue ack token: %v", err)
+		}
+		if _, err := testDB.ExecContext(ctx, revoke, responder.User.ID); err != nil {
+			t.Fatalf("Failed to revoke %s user: %v", name, err)
+		}
+
+		resp := client.Post("/api/v1/alerts/ack/"+token, nil)
+		client.ExpectStatus(resp, http.StatusUnauthorized)
+		resp.Body.Close()
+
+		result, err := testServer.AlertService.GetAlert(ctx, alert.ID, orgID)
+		if err != nil {
+			t.Fatalf("Failed to get alert: %v", err)
+		}
+		if result.Status != domain.AlertStatusOpen {
+			t.Errorf("Expected the alert to stay open for a %s user, got %s", name, result.Status)
+		}
+	}
+}
+
+// ============================================================================
+// POST /api/v1/alerts/:id/close
+// ============================================================================
+
+func TestAlerts_Close_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+
+	reqBody := map[string]string{
+		"reason": "Resolved by test",
+	}
+
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/close", alert.ID), reqBody)
+	client.AssertStatus(resp, http.StatusOK)
+
+	// Verify status changed
+	resp = client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
+	var result map[string]interface{}
+	client.ParseJSON(resp, &result)
+
+	if result["status"] != "closed" {
+		t.Errorf("Expected status 'closed', got %v", result["status"])
+	}
+}
+
+func TestAlerts_Close_NotFound(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	reqBody := map[string]string{
+		"reason": "Test reason",
+	}
+
+	resp := client.Post("/api/v1/alerts/00000000-0000-0000-0000-000000000000/close", reqBody)
+	client.ExpectStatus(resp, http.StatusBadRequest) // API returns 400 for not found errors
+}
+
+// ============================================================================
+// POST /api/v1/alerts/:id/snooze
+// ============================================================================
+
+func TestAlerts_Snooze_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+
+	snoozeUntil := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	reqBody := map[string]string{
+		"until": snoozeUntil,
+	}
+
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/snooze", alert.ID), reqBody)
+	client.AssertStatus(resp, http.StatusOK)
+
+	// Verify status changed
+	resp = client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
+	var result map[string]interface{}
+	client.ParseJSON(resp, &result)
+
+	if result["status"] != "snoozed" {
+		t.Errorf("Expected status 'snoozed', got %v", result["status"])
+	}
+}
+
+func TestAlerts_Snooze_StoresReason(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+
+	reqBody := map[string]string{
+		"until":  time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		"reason": "  Known issue, fix deploying  ",
+	}
+
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/snooze", alert.ID), reqBody)
+	client.AssertStatus(resp, http.StatusOK)
+
+	resp = client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
+	var result domain.Alert
+	client.ParseJSON(resp, &result)
+
+	if result.SnoozeReason == nil || *result.SnoozeReason != "Known issue, fix deploying" {
+		t.Errorf("Expected trimmed snooze reason, got %v", result.SnoozeReason)
+	}
+	if result.SnoozedBy == nil || *result.SnoozedBy != user.User.ID {
+		t.Errorf("Expected snoozed by %s, got %v", user.User.ID, result.SnoozedBy)
+	}
+}
+
+func TestAlerts_Snooze_NotFound(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	reqBody := map[string]string{
+		"until": time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339),
+	}
+
+	resp := client.Post("/api/v1/alerts/00000000-0000-0000-0000-000000000000/snooze", reqBody)
+	client.ExpectStatus(resp, http.StatusBadRequest) // API returns 400 for not found errors
+}
+
+// ============================================================================
+// POST /api/v1/alerts/:id/assign
+// ============================================================================
+
+func TestAlerts_Assign_ToUser_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+
+	reqBody := map[string]string{
+		"user_id": user.User.ID.String(),
+	}
+
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/assign", alert.ID), reqBody)
+	client.AssertStatus(resp, http.StatusOK)
+
+	// Verify assignment
+	resp = client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
+	var result map[string]interface{}
+	client.ParseJSON(resp, &result)
+
+	if result["assigned_to_user_id"] != user.User.ID.String() {
+		t.Errorf("Expected assigned_to_user_id %s, got %v", user.User.ID, result["assigned_to_user_id"])
+	}
+}
+
+func TestAlerts_Assign_ToTeam_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Test Team")
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+
+	reqBody := map[string]string{
+		"team_id": team.ID.String(),
+	}
+
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/assign", alert.ID), reqBody)
+	client.AssertStatus(resp, http.StatusOK)
+
+	// Verify assignment
 	resp = client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
 	var result map[string]interface{}
 	client.ParseJSON(resp, &result)
@@ -514,3 +1322,243 @@ func TestAlerts_Assign_NotFound(t *testing.T) {
 	resp := client.Post("/api/v1/alerts/00000000-0000-0000-0000-000000000000/assign", reqBody)
 	client.ExpectStatus(resp, http.StatusBadRequest) // API returns 400 for not found errors
 }
+
+func TestAlerts_Assign_TeamAndEscalationPolicy(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Database Team")
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, user.Organization.ID, "Database Policy")
+	firstRule, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: 1, EscalationDelay: 5})
+	if err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+	if _, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: 2, EscalationDelay: 10}); err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+
+	assign := map[string]interface{}{
+		"team_id":              team.ID,
+		"escalation_policy_id": policy.ID,
+	}
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/assign", alert.ID), assign)
+	client.AssertStatus(resp, http.StatusOK)
+
+	// Let escalation advance past the first rule, then assign again
+	if _, err := testDB.ExecContext(ctx, `UPDATE alert_escalation_events SET current_level = 1 WHERE alert_id = $1`, alert.ID); err != nil {
+		t.Fatalf("Failed to advance escalation: %v", err)
+	}
+	if _, err := testDB.ExecContext(ctx, `UPDATE alerts SET escalation_level = 1, last_escalated_at = NOW() WHERE id = $1`, alert.ID); err != nil {
+		t.Fatalf("Failed to advance alert: %v", err)
+	}
+
+	resp = client.Post(fmt.Sprintf("/api/v1/alerts/%s/assign", alert.ID), assign)
+	client.AssertStatus(resp, http.StatusOK)
+
+	resp = client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
+	client.AssertStatus(resp, http.StatusOK)
+	var updated domain.Alert
+	client.ParseJSON(resp, &updated)
+
+	if updated.AssignedToTeamID == nil || *updated.AssignedToTeamID != team.ID {
+		t.Errorf("Expected team %s, got %v", team.ID, updated.AssignedToTeamID)
+	}
+	if updated.EscalationPolicyID == nil || *updated.EscalationPolicyID != policy.ID {
+		t.Errorf("Expected escalation policy %s, got %v", policy.ID, updated.EscalationPolicyID)
+	}
+	if updated.EscalationLevel != 0 || updated.LastEscalatedAt != nil {
+		t.Errorf("Expected escalation level reset, got level %d escalated at %v", updated.EscalationLevel, updated.LastEscalatedAt)
+	}
+
+	// Only the re-armed escalation is running, from the first rule
+	var triggered, stopped int
+	err = testDB.QueryRowContext(ctx, `
+		SELECT COUNT(*) FILTER (WHERE event_type = 'triggered'), COUNT(*) FILTER (WHERE event_type = 'stopped')
+		FROM alert_escalation_events WHERE alert_id = $1
+	`, alert.ID).Scan(&triggered, &stopped)
+	if err != nil {
+		t.Fatalf("Failed to count escalation events: %v", err)
+	}
+	if triggered != 1 || stopped != 1 {
+		t.Fatalf("Expected 1 triggered and 1 stopped escalation event, got %d and %d", triggered, stopped)
+	}
+
+	var level int
+	var ruleID uuid.UUID
+	var nextEscalationAt time.Time
+	err = testDB.QueryRowContext(ctx, `
+		SELECT current_level, rule_id, next_escalation_at
+		FROM alert_escalation_events WHERE alert_id = $1 AND event_type = 'triggered'
+	`, alert.ID).Scan(&level, &ruleID, &nextEscalationAt)
+	if err != nil {
+		t.Fatalf("Failed to get escalation event: %v", err)
+	}
+	if level != 0 || ruleID != firstRule.ID {
+		t.Errorf("Expected escalation at rule 1 (%s), got level %d rule %s", firstRule.ID, level, ruleID)
+	}
+	if nextEscalationAt.Before(time.Now().Add(4 * time.Minute)) {
+		t.Errorf("Expected next escalation about 5 minutes out, got %v", nextEscalationAt)
+	}
+}
+
+func TestAlerts_Assign_KeepsUnrelatedFields(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Test Team")
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, user.Organization.ID, "Test Policy")
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+
+	getAlert := func() *domain.Alert {
+		resp := client.Get(fmt.Sprintf("/api/v1/alerts/%s", alert.ID))
+		client.AssertStatus(resp, http.StatusOK)
+		var result domain.Alert
+		client.ParseJSON(resp, &result)
+		return &result
+	}
+
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/assign", alert.ID), map[string]interface{}{
+		"team_id":              team.ID,
+		"escalation_policy_id": policy.ID,
+	})
+	client.AssertStatus(resp, http.StatusOK)
+
+	t.Run("user assignment keeps team and policy", func(t *testing.T) {
+		resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/assign", alert.ID), map[string]interface{}{
+			"user_id": user.User.ID,
+		})
+		client.ExpectStatus(resp, http.StatusOK)
+
+		updated := getAlert()
+		if updated.AssignedToUserID == nil || *updated.AssignedToUserID != user.User.ID {
+			t.Errorf("Expected user %s, got %v", user.User.ID, updated.AssignedToUserID)
+		}
+		if updated.AssignedToTeamID == nil || *updated.AssignedToTeamID != team.ID {
+			t.Errorf("Expected team %s to be kept, got %v", team.ID, updated.AssignedToTeamID)
+		}
+		if updated.EscalationPolicyID == nil || *updated.EscalationPolicyID != policy.ID {
+			t.Errorf("Expected policy %s to be kept, got %v", policy.ID, updated.EscalationPolicyID)
+		}
+		if updated.Message != "Test Alert" || updated.Status != domain.AlertStatusOpen {
+			t.Errorf("Expected message and status untouched, got %q %s", updated.Message, updated.Status)
+		}
+	})
+
+	t.Run("clear_team removes the team", func(t *testing.T) {
+		resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/assign", alert.ID), map[string]interface{}{
+			"user_id":    user.User.ID,
+			"clear_team": true,
+		})
+		client.ExpectStatus(resp, http.StatusOK)
+
+		updated := getAlert()
+		if updated.AssignedToTeamID != nil {
+			t.Errorf("Expected team to be cleared, got %v", updated.AssignedToTeamID)
+		}
+		if updated.AssignedToUserID == nil || *updated.AssignedToUserID != user.User.ID {
+			t.Errorf("Expected user %s to be kept, got %v", user.User.ID, updated.AssignedToUserID)
+		}
+	})
+
+	t.Run("policy from another organization is rejected", func(t *testing.T) {
+		other, _ := testFixtures.CreateUniqueUser(ctx)
+		otherPolicy, _ := testFixtures.CreateUniqueEscalationPolicy(ctx, other.Organization.ID)
+
+		resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/assign", alert.ID), map[string]interface{}{
+			"team_id":              team.ID,
+			"escalation_policy_id": otherPolicy.ID,
+		})
+		client.ExpectStatus(resp, http.StatusBadRequest)
+
+		updated := getAlert()
+		if updated.AssignedToTeamID != nil {
+			t.Errorf("Expected a rejected assignment not to apply the team, got %v", updated.AssignedToTeamID)
+		}
+	})
+}
+
+// ============================================================================
+// POST/GET /api/v1/alerts/:id/notes
+// ============================================================================
+
+func TestAlerts_Notes_AddAndList(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+
+	for _, text := range []string{"Checked the dashboards", "Restarted the worker"} {
+		resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/notes", alert.ID), map[string]string{"note": text})
+		client.AssertStatus(resp, http.StatusCreated)
+
+		var note domain.AlertNote
+		client.ParseJSON(resp, &note)
+		if note.UserID == nil || *note.UserID != user.User.ID {
+			t.Errorf("Expected note authored by %s, got %v", user.User.ID, note.UserID)
+		}
+		if note.CreatedAt.IsZero() {
+			t.Error("Expected note to have a timestamp")
+		}
+	}
+
+	resp := client.Get(fmt.Sprintf("/api/v1/alerts/%s/notes", alert.ID))
+	client.AssertStatus(resp, http.StatusOK)
+
+	var notes []domain.AlertNoteWithUser
+	client.ParseJSON(resp, &notes)
+
+	if len(notes) != 2 {
+		t.Fatalf("Expected 2 notes, got %d", len(notes))
+	}
+	if notes[0].Note != "Checked the dashboards" || notes[1].Note != "Restarted the worker" {
+		t.Errorf("Expected notes oldest first, got %q, %q", notes[0].Note, notes[1].Note)
+	}
+	if notes[0].User == nil || notes[0].User.Username != user.User.Username {
+		t.Errorf("Expected author %s on listed note, got %+v", user.User.Username, notes[0].User)
+	}
+}
+
+func TestAlerts_Notes_EmptyNote(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/notes", alert.ID), map[string]string{"note": "   "})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+func TestAlerts_Notes_OtherOrganization(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	outsider, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(outsider.AccessToken)
+
+	alert, _ := testFixtures.CreateAlert(ctx, owner.Organization.ID, "Test Alert")
+
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/notes", alert.ID), map[string]string{"note": "Sneaky"})
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	resp = client.Get(fmt.Sprintf("/api/v1/alerts/%s/notes", alert.ID))
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
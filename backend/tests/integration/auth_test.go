@@ -2,8 +2,20 @@ package integration
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/tests/integration/testutils"
 )
 
 // ============================================================================
@@ -102,6 +114,65 @@ func TestAuth_Register_InvalidEmail(t *testing.T) {
 	client.ExpectStatus(resp, http.StatusBadRequest)
 }
 
+func TestAuth_Register_WeakPasswordRejected(t *testing.T) {
+	cleanDatabase(t)
+	client := newTestClient(t)
+
+	reqBody := map[string]string{
+		"email":             "weak@example.com",
+		"username":          "weakuser",
+		"password":          "password",
+		"full_name":         "Weak User",
+		"organization_name": "Weak Org",
+	}
+
+	resp := client.Post("/api/v1/auth/register", reqBody)
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	var result map[string]string
+	client.ParseJSON(resp, &result)
+	for _, requirement := range []string{
+		"at least 10 characters",
+		"uppercase letter",
+		"digit",
+		"special character",
+		"commonly used password",
+	} {
+		if !strings.Contains(result["error"], requirement) {
+			t.Errorf("Expected error to mention %q, got %q", requirement, result["error"])
+		}
+	}
+	if strings.Contains(result["error"], "lowercase letter") {
+		t.Errorf("Expected error not to mention the lowercase requirement it meets, got %q", result["error"])
+	}
+
+	// Meeting every character class doesn't make a common password acceptable
+	reqBody["password"] = "Password123!"
+	resp = client.Post("/api/v1/auth/register", reqBody)
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	client.ParseJSON(resp, &result)
+	if !strings.Contains(result["error"], "commonly used password") {
+		t.Errorf("Expected error to reject the common password, got %q", result["error"])
+	}
+}
+
+func TestAuth_Register_CompliantPasswordAccepted(t *testing.T) {
+	cleanDatabase(t)
+	client := newTestClient(t)
+
+	reqBody := map[string]string{
+		"email":             "compliant@example.com",
+		"username":          "compliantuser",
+		"password":          "DemoPass123!",
+		"full_name":         "Compliant User",
+		"organization_name": "Compliant Org",
+	}
+
+	resp := client.Post("/api/v1/auth/register", reqBody)
+	client.ExpectStatus(resp, http.StatusCreated)
+}
+
 // ============================================================================
 // POST /api/v1/auth/login
 // ============================================================================
@@ -182,6 +253,172 @@ func TestAuth_Login_MissingCredentials(t *testing.T) {
 	client.ExpectStatus(resp, http.StatusBadRequest)
 }
 
+// failLogins makes count logins for email with a wrong password, each
+// expected to be rejected as invalid credentials
+func failLogins(t *testing.T, email string, count int) {
+	t.Helper()
+
+	for i := 0; i < count; i++ {
+		loginWith(t, email, "WrongPassword123!", http.StatusUnauthorized)
+	}
+}
+
+func TestAuth_Login_LockoutAfterRepeatedFailures(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, err := testFixtures.CreateUser(ctx, "login@example.com", "loginuser", "Login Org")
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	failLogins(t, "login@example.com", 5)
+
+	// Locked now, even with the right password
+	resp := client.Post("/api/v1/auth/login", map[string]string{
+		"email":    "login@example.com",
+		"password": "TestPassword123!",
+	})
+	client.ExpectStatus(resp, http.StatusTooManyRequests)
+	if resp.Header.Get("Retry-After") == "" {
+		t.Error("Expected a Retry-After header")
+	}
+
+	rows, err := testDB.QueryContext(ctx,
+		`SELECT failure_reason, COUNT(*) FROM login_attempts WHERE user_id = $1 GROUP BY failure_reason`, user.User.ID)
+	if err != nil {
+		t.Fatalf("Failed to query login attempts: %v", err)
+	}
+	defer rows.Close()
+
+	failures := make(map[string]int)
+	for rows.Next() {
+		var reason string
+		var count int
+		if err := rows.Scan(&reason, &count); err != nil {
+			t.Fatalf("Failed to scan login attempt: %v", err)
+		}
+		failures[reason] = count
+	}
+	if failures["invalid_credentials"] != 5 || failures["account_locked"] != 1 {
+		t.Errorf("Expected 5 invalid_credentials and 1 account_locked attempts, got %v", failures)
+	}
+}
+
+func TestAuth_Login_SuccessResetsFailures(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	if _, err := testFixtures.CreateUser(ctx, "login@example.com", "loginuser", "Login Org"); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	success := map[string]string{
+		"email":    "login@example.com",
+		"password": "TestPassword123!",
+	}
+
+	failLogins(t, "login@example.com", 4)
+	resp := client.Post("/api/v1/auth/login", success)
+	client.ExpectStatus(resp, http.StatusOK)
+
+	// The counter starts over, so four more failures still don't lock
+	failLogins(t, "login@example.com", 4)
+	resp = client.Post("/api/v1/auth/login", success)
+	client.ExpectStatus(resp, http.StatusOK)
+}
+
+func TestAuth_Login_ThrottlesIPAfterRepeatedFailures(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	if _, err := testFixtures.CreateUser(ctx, "login@example.com", "loginuser", "Login Org"); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	// Spread over many accounts so no single one is locked
+	for i := 0; i < 20; i++ {
+		failLogins(t, fmt.Sprintf("guess%d@example.com", i), 1)
+	}
+
+	resp := client.Post("/api/v1/auth/login", map[string]string{
+		"email":    "login@example.com",
+		"password": "TestPassword123!",
+	})
+	client.ExpectStatus(resp, http.StatusTooManyRequests)
+}
+
+func TestAuth_Login_SpoofedForwardedForDoesNotEscapeIPThrottle(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	if _, err := testFixtures.CreateUser(ctx, "login@example.com", "loginuser", "Login Org"); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	// Each guess claims a different client IP, but no proxy is trusted
+	client := newTestClient(t)
+	for i := 0; i < 20; i++ {
+		client.SetHeader("X-Forwarded-For", fmt.Sprintf("198.51.100.%d", i+1))
+		resp := client.Post("/api/v1/auth/login", map[string]string{
+			"email":    fmt.Sprintf("guess%d@example.com", i),
+			"password": "WrongPassword123!",
+		})
+		client.ExpectStatus(resp, http.StatusUnauthorized)
+	}
+
+	client.SetHeader("X-Forwarded-For", "198.51.100.200")
+	resp := client.Post("/api/v1/auth/login", map[string]string{
+		"email":    "login@example.com",
+		"password": "TestPassword123!",
+	})
+	client.ExpectStatus(resp, http.StatusTooManyRequests)
+}
+
+func TestAuth_Login_ConcurrentFailuresRespectLockout(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	if _, err := testFixtures.CreateUser(ctx, "login@example.com", "loginuser", "Login Org"); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	guesses := 15
+	errs := make(chan error, guesses)
+	var wg sync.WaitGroup
+	for i := 0; i < guesses; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := testServer.AuthService.Login(ctx, &dto.LoginRequest{
+				Email:    "login@example.com",
+				Password: "WrongPassword123!",
+			}, "192.0.2.1", "test")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var checked int
+	for err := range errs {
+		if err == nil {
+			t.Fatal("Expected a wrong password to be rejected")
+		}
+		if !errors.Is(err, domain.ErrLoginLocked) {
+			checked++
+		}
+	}
+	if checked != 5 {
+		t.Errorf("Expected 5 passwords to be checked before the lockout, got %d", checked)
+	}
+
+	loginWith(t, "login@example.com", "TestPassword123!", http.StatusTooManyRequests)
+}
+
 // ============================================================================
 // POST /api/v1/auth/refresh
 // ============================================================================
@@ -233,6 +470,149 @@ func TestAuth_RefreshToken_MissingToken(t *testing.T) {
 	client.ExpectStatus(resp, http.StatusBadRequest)
 }
 
+func TestAuth_RefreshToken_SingleUse(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+
+	resp := client.Post("/api/v1/auth/refresh", map[string]string{"refresh_token": user.RefreshToken})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var result dto.AuthResponse
+	client.ParseJSON(resp, &result)
+
+	// The used token was replaced, only the new one works
+	resp = client.Post("/api/v1/auth/refresh", map[string]string{"refresh_token": user.RefreshToken})
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+
+	resp = client.Post("/api/v1/auth/refresh", map[string]string{"refresh_token": result.RefreshToken})
+	client.ExpectStatus(resp, http.StatusOK)
+}
+
+// ============================================================================
+// /api/v1/auth/sessions
+// ============================================================================
+
+// loginTokens logs in with the fixture password and returns the new session's
+// tokens
+func loginTokens(t *testing.T, email string) *dto.AuthResponse {
+	t.Helper()
+	client := newTestClient(t)
+
+	resp := client.Post("/api/v1/auth/login", map[string]string{
+		"email":    email,
+		"password": "TestPassword123!",
+	})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var result dto.AuthResponse
+	client.ParseJSON(resp, &result)
+	return &result
+}
+
+func listSessions(t *testing.T, accessToken string) []dto.SessionResponse {
+	t.Helper()
+	client := newTestClient(t)
+	client.SetAuthToken(accessToken)
+
+	resp := client.Get("/api/v1/auth/sessions")
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var sessions []dto.SessionResponse
+	client.ParseJSON(resp, &sessions)
+	return sessions
+}
+
+func TestAuth_Sessions_List(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	laptop := loginTokens(t, user.User.Email)
+
+	sessions := listSessions(t, laptop.AccessToken)
+	if len(sessions) != 2 {
+		t.Fatalf("Expected the sign-up and login sessions, got %d", len(sessions))
+	}
+
+	current := sessions[0]
+	if !current.Current || sessions[1].Current {
+		t.Fatalf("Expected only the most recently used login session to be current, got %+v", sessions)
+	}
+	if current.IPAddress == "" || current.UserAgent == "" {
+		t.Errorf("Expected the login's client IP and user agent, got %q and %q", current.IPAddress, current.UserAgent)
+	}
+	if current.CreatedAt.IsZero() || current.LastUsedAt.IsZero() || !current.ExpiresAt.After(time.Now()) {
+		t.Errorf("Expected session times to be set, got %+v", current)
+	}
+}
+
+func TestAuth_Sessions_RevokeInvalidatesRefreshToken(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	laptop := loginTokens(t, user.User.Email)
+	phone := loginTokens(t, user.User.Email)
+
+	var phoneSession uuid.UUID
+	for _, session := range listSessions(t, phone.AccessToken) {
+		if session.Current {
+			phoneSession = session.ID
+		}
+	}
+
+	// Someone else can't revoke it
+	client.SetAuthToken(other.AccessToken)
+	resp := client.Delete(fmt.Sprintf("/api/v1/auth/sessions/%s", phoneSession))
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	client.SetAuthToken(laptop.AccessToken)
+	resp = client.Delete(fmt.Sprintf("/api/v1/auth/sessions/%s", phoneSession))
+	client.ExpectStatus(resp, http.StatusOK)
+
+	resp = client.Post("/api/v1/auth/refresh", map[string]string{"refresh_token": phone.RefreshToken})
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+
+	resp = client.Delete(fmt.Sprintf("/api/v1/auth/sessions/%s", phoneSession))
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	// Other sessions are unaffected
+	if sessions := listSessions(t, laptop.AccessToken); len(sessions) != 2 {
+		t.Errorf("Expected the sign-up and laptop sessions to remain, got %d", len(sessions))
+	}
+	resp = client.Post("/api/v1/auth/refresh", map[string]string{"refresh_token": laptop.RefreshToken})
+	client.ExpectStatus(resp, http.StatusOK)
+}
+
+func TestAuth_Sessions_LogOutEverywhere(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	laptop := loginTokens(t, user.User.Email)
+
+	client.SetAuthToken(laptop.AccessToken)
+	resp := client.Delete("/api/v1/auth/sessions")
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var result dto.RevokeSessionsResponse
+	client.ParseJSON(resp, &result)
+	if result.Revoked != 2 {
+		t.Errorf("Expected 2 sessions revoked, got %d", result.Revoked)
+	}
+
+	for _, refreshToken := range []string{user.RefreshToken, laptop.RefreshToken} {
+		resp = client.Post("/api/v1/auth/refresh", map[string]string{"refresh_token": refreshToken})
+		client.ExpectStatus(resp, http.StatusUnauthorized)
+	}
+}
+
 // ============================================================================
 // GET /api/v1/auth/me
 // ============================================================================
@@ -298,3 +678,223 @@ func TestAuth_Logout_Success(t *testing.T) {
 		t.Error("Expected message in response")
 	}
 }
+
+// ============================================================================
+// Token lifetimes
+// ============================================================================
+
+// tokenLifetime returns exp - iat of a JWT without verifying its signature
+func tokenLifetime(t *testing.T, token string) time.Duration {
+	t.Helper()
+
+	claims := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+		t.Fatalf("Failed to parse token: %v", err)
+	}
+	if claims.ExpiresAt == nil || claims.IssuedAt == nil {
+		t.Fatal("Expected token to carry exp and iat")
+	}
+	return claims.ExpiresAt.Sub(claims.IssuedAt.Time)
+}
+
+func TestAuth_TokenLifetimesFollowConfig(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+
+	// The test server is configured with a 15m access and 7 day refresh TTL
+	if got := tokenLifetime(t, user.AccessToken); got != 15*time.Minute {
+		t.Errorf("Expected access token lifetime 15m, got %v", got)
+	}
+	if got := tokenLifetime(t, user.RefreshToken); got != 7*24*time.Hour {
+		t.Errorf("Expected refresh token lifetime 168h, got %v", got)
+	}
+}
+
+// ============================================================================
+// Multiple organizations
+// ============================================================================
+
+func listOrganizations(t *testing.T, client *testutils.TestClient) []dto.OrganizationMembershipResponse {
+	t.Helper()
+
+	resp := client.Get("/api/v1/me/organizations")
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var organizations []dto.OrganizationMembershipResponse
+	client.ParseJSON(resp, &organizations)
+	return organizations
+}
+
+func currentOrganization(t *testing.T, organizations []dto.OrganizationMembershipResponse) dto.OrganizationMembershipResponse {
+	t.Helper()
+
+	for _, org := range organizations {
+		if org.Current {
+			return org
+		}
+	}
+	t.Fatalf("Expected a current organization in %+v", organizations)
+	return dto.OrganizationMembershipResponse{}
+}
+
+func TestAuth_Organizations_ListMemberships(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	consultant, _ := testFixtures.CreateUniqueUser(ctx)
+	customer, _ := testFixtures.CreateUniqueUser(ctx)
+	testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, customer.Organization.ID, consultant.User.ID)
+
+	client.SetAuthToken(consultant.AccessToken)
+	organizations := listOrganizations(t, client)
+	if len(organizations) != 2 {
+		t.Fatalf("Expected 2 organizations, got %d", len(organizations))
+	}
+
+	roles := map[uuid.UUID]string{}
+	for _, org := range organizations {
+		roles[org.ID] = org.Role
+	}
+	if roles[consultant.Organization.ID] != "owner" || roles[customer.Organization.ID] != "member" {
+		t.Errorf("Expected owner of their own organization and member of the client's, got %v", roles)
+	}
+	if current := currentOrganization(t, organizations); current.ID != consultant.Organization.ID {
+		t.Errorf("Expected the token's organization to be current, got %s", current.ID)
+	}
+}
+
+func TestAuth_SwitchOrganization(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	consultant, _ := testFixtures.CreateUniqueUser(ctx)
+	customer, _ := testFixtures.CreateUniqueUser(ctx)
+	outsider, _ := testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, customer.Organization.ID, consultant.User.ID)
+
+	client.SetAuthToken(consultant.AccessToken)
+	resp := client.Post("/api/v1/auth/switch-organization", map[string]string{
+		"organization_id": customer.Organization.ID.String(),
+	})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var switched dto.AuthResponse
+	client.ParseJSON(resp, &switched)
+	if switched.Organization.ID != customer.Organization.ID {
+		t.Fatalf("Expected tokens for organization %s, got %s", customer.Organization.ID, switched.Organization.ID)
+	}
+
+	client.SetAuthToken(switched.AccessToken)
+	if current := currentOrganization(t, listOrganizations(t, client)); current.ID != customer.Organization.ID {
+		t.Errorf("Expected the switched-to organization to be current, got %s", current.ID)
+	}
+
+	// The new token carries the member role of the client organization
+	resp = client.Get(fmt.Sprintf("/api/v1/users/%s/sessions", customer.User.ID))
+	client.ExpectStatus(resp, http.StatusForbidden)
+
+	// The session moved with it, so its old refresh token no longer works
+	resp = client.Post("/api/v1/auth/refresh", map[string]string{"refresh_token": consultant.RefreshToken})
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+
+	resp = client.Post("/api/v1/auth/refresh", map[string]string{"refresh_token": switched.RefreshToken})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var refreshed dto.AuthResponse
+	client.ParseJSON(resp, &refreshed)
+	if refreshed.Organization.ID != customer.Organization.ID {
+		t.Errorf("Expected refreshing to stay in organization %s, got %s", customer.Organization.ID, refreshed.Organization.ID)
+	}
+
+	resp = client.Post("/api/v1/auth/switch-organization", map[string]string{
+		"organization_id": outsider.Organization.ID.String(),
+	})
+	client.ExpectStatus(resp, http.StatusForbidden)
+}
+
+func TestAuth_Login_SelectsOrganization(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	consultant, _ := testFixtures.CreateUniqueUser(ctx)
+	customer, _ := testFixtures.CreateUniqueUser(ctx)
+	outsider, _ := testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, customer.Organization.ID, consultant.User.ID)
+
+	resp := client.Post("/api/v1/auth/login", map[string]string{
+		"email":           consultant.User.Email,
+		"password":        "TestPassword123!",
+		"organization_id": consultant.Organization.ID.String(),
+	})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var result dto.AuthResponse
+	client.ParseJSON(resp, &result)
+	if result.Organization.ID != consultant.Organization.ID {
+		t.Errorf("Expected to sign in to organization %s, got %s", consultant.Organization.ID, result.Organization.ID)
+	}
+
+	resp = client.Post("/api/v1/auth/login", map[string]string{
+		"email":           consultant.User.Email,
+		"password":        "TestPassword123!",
+		"organization_id": outsider.Organization.ID.String(),
+	})
+	client.ExpectStatus(resp, http.StatusForbidden)
+}
+
+func TestAuth_OrganizationHeader_ScopesRequest(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	consultant, _ := testFixtures.CreateUniqueUser(ctx)
+	customer, _ := testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, customer.Organization.ID, consultant.User.ID)
+
+	client.SetAuthToken(consultant.AccessToken)
+	client.SetHeader("X-Organization-ID", customer.Organization.ID.String())
+
+	if current := currentOrganization(t, listOrganizations(t, client)); current.ID != customer.Organization.ID {
+		t.Errorf("Expected the header's organization to be current, got %s", current.ID)
+	}
+
+	resp := client.Get("/api/v1/users")
+	client.ExpectStatus(resp, http.StatusOK)
+	if body := client.ReadBody(resp); !strings.Contains(body, customer.User.Email) {
+		t.Errorf("Expected the client organization's users, got %s", body)
+	}
+
+	// Naming the token's own organization is also fine
+	client.SetHeader("X-Organization-ID", consultant.Organization.ID.String())
+	if current := currentOrganization(t, listOrganizations(t, client)); current.ID != consultant.Organization.ID {
+		t.Errorf("Expected the token's organization to be current, got %s", current.ID)
+	}
+}
+
+func TestAuth_OrganizationHeader_RejectsNonMemberOrganization(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+
+	client.SetAuthToken(user.AccessToken)
+	client.SetHeader("X-Organization-ID", other.Organization.ID.String())
+
+	resp := client.Get("/api/v1/users")
+	client.ExpectStatus(resp, http.StatusForbidden)
+
+	resp = client.Get("/api/v1/me/organizations")
+	client.ExpectStatus(resp, http.StatusForbidden)
+
+	client.SetHeader("X-Organization-ID", "not-a-uuid")
+	resp = client.Get("/api/v1/users")
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
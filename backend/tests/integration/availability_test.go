@@ -0,0 +1,211 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+)
+
+func addUnavailability(t *testing.T, token string, start, end time.Time, expected int) *domain.UserUnavailability {
+	t.Helper()
+	client := newTestClient(t)
+	client.SetAuthToken(token)
+
+	resp := client.Post("/api/v1/users/me/unavailability", map[string]interface{}{
+		"start":  start.UTC().Format(time.RFC3339),
+		"end":    end.UTC().Format(time.RFC3339),
+		"reason": "Vacation",
+	})
+	client.ExpectStatus(resp, expected)
+	if expected != http.StatusCreated {
+		return nil
+	}
+
+	var period domain.UserUnavailability
+	client.ParseJSON(resp, &period)
+	return &period
+}
+
+// createTwoPersonRotation creates a daily rotation with two participants and
+// returns the schedule
+func createTwoPersonRotation(t *testing.T, ctx context.Context, orgID uuid.UUID, participants []uuid.UUID) *domain.Schedule {
+	t.Helper()
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, orgID, "Primary On-Call")
+	rotation, err := testServer.ScheduleService.CreateRotation(ctx, schedule.ID, &dto.CreateRotationRequest{
+		Name:           "Daily",
+		RotationType:   "daily",
+		RotationLength: 1,
+		StartDate:      "2024-01-01",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rotation: %v", err)
+	}
+	for i, userID := range participants {
+		if _, err := testServer.ScheduleService.AddParticipant(ctx, rotation.ID, &dto.AddParticipantRequest{UserID: userID, Position: i}); err != nil {
+			t.Fatalf("Failed to add participant: %v", err)
+		}
+	}
+	return schedule
+}
+
+// ============================================================================
+// /api/v1/users/me/unavailability
+// ============================================================================
+
+func TestAvailability_AddListRemove(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	now := time.Now()
+	period := addUnavailability(t, user.AccessToken, now.Add(time.Hour), now.Add(48*time.Hour), http.StatusCreated)
+	if period.UserID != user.User.ID {
+		t.Errorf("Expected period to belong to %s, got %s", user.User.ID, period.UserID)
+	}
+
+	resp := client.Get("/api/v1/users/me/unavailability")
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var result struct {
+		Unavailability []domain.UserUnavailability `json:"unavailability"`
+	}
+	client.ParseJSON(resp, &result)
+	if len(result.Unavailability) != 1 || result.Unavailability[0].ID != period.ID {
+		t.Fatalf("Expected the created period to be listed, got %+v", result.Unavailability)
+	}
+
+	resp = client.Delete(fmt.Sprintf("/api/v1/users/me/unavailability/%s", period.ID))
+	client.ExpectStatus(resp, http.StatusNoContent)
+
+	resp = client.Delete(fmt.Sprintf("/api/v1/users/me/unavailability/%s", period.ID))
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+func TestAvailability_Add_InvalidPeriod(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	now := time.Now()
+
+	// End before start
+	addUnavailability(t, user.AccessToken, now.Add(48*time.Hour), now.Add(time.Hour), http.StatusBadRequest)
+	// Already over
+	addUnavailability(t, user.AccessToken, now.Add(-48*time.Hour), now.Add(-time.Hour), http.StatusBadRequest)
+}
+
+func TestAvailability_Remove_OtherUsersPeriod(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+
+	now := time.Now()
+	period := addUnavailability(t, owner.AccessToken, now, now.Add(time.Hour), http.StatusCreated)
+
+	client.SetAuthToken(other.AccessToken)
+	resp := client.Delete(fmt.Sprintf("/api/v1/users/me/unavailability/%s", period.ID))
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+// ============================================================================
+// On-call resolution
+// ============================================================================
+
+func TestAvailability_OnCallSkipsUnavailableParticipant(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	first, _ := testFixtures.CreateUniqueUser(ctx)
+	second, _ := testFixtures.CreateUniqueUser(ctx)
+	tokens := map[uuid.UUID]string{
+		first.User.ID:  first.AccessToken,
+		second.User.ID: second.AccessToken,
+	}
+	schedule := createTwoPersonRotation(t, ctx, first.Organization.ID, []uuid.UUID{first.User.ID, second.User.ID})
+
+	before, err := testServer.ScheduleService.GetOnCallUser(ctx, schedule.ID, time.Now())
+	if err != nil {
+		t.Fatalf("Failed to get on-call user: %v", err)
+	}
+
+	now := time.Now()
+	addUnavailability(t, tokens[before.UserID], now.Add(-time.Hour), now.Add(48*time.Hour), http.StatusCreated)
+
+	client.SetAuthToken(first.AccessToken)
+	resp := client.Get(fmt.Sprintf("/api/v1/schedules/%s/oncall", schedule.ID))
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var after domain.OnCallUser
+	client.ParseJSON(resp, &after)
+	if after.UserID == before.UserID {
+		t.Fatalf("Expected unavailable user %s to be skipped", before.UserID)
+	}
+	if len(after.SkippedUserIDs) != 1 || after.SkippedUserIDs[0] != before.UserID {
+		t.Errorf("Expected skipped users [%s], got %v", before.UserID, after.SkippedUserIDs)
+	}
+}
+
+func TestAvailability_EscalationLogsSkippedParticipant(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	first, _ := testFixtures.CreateUniqueUser(ctx)
+	second, _ := testFixtures.CreateUniqueUser(ctx)
+	tokens := map[uuid.UUID]string{
+		first.User.ID:  first.AccessToken,
+		second.User.ID: second.AccessToken,
+	}
+	schedule := createTwoPersonRotation(t, ctx, first.Organization.ID, []uuid.UUID{first.User.ID, second.User.ID})
+	testFixtures.CreateNotificationChannel(ctx, first.Organization.ID, "Email")
+	alert, _ := testFixtures.CreateAlert(ctx, first.Organization.ID, "Disk full")
+
+	onCall, err := testServer.ScheduleService.GetOnCallUser(ctx, schedule.ID, time.Now())
+	if err != nil {
+		t.Fatalf("Failed to get on-call user: %v", err)
+	}
+	now := time.Now()
+	addUnavailability(t, tokens[onCall.UserID], now.Add(-time.Hour), now.Add(48*time.Hour), http.StatusCreated)
+
+	targets := []domain.EscalationTarget{
+		{TargetType: domain.EscalationTargetTypeSchedule, TargetID: schedule.ID},
+	}
+	if err := testServer.AlertNotifier.NotifyAlertEscalated(ctx, alert, &domain.EscalationRule{}, targets); err != nil {
+		t.Fatalf("Failed to notify escalation: %v", err)
+	}
+
+	logs, err := testServer.NotificationService.ListLogsByAlert(ctx, alert.ID)
+	if err != nil {
+		t.Fatalf("Failed to list notification logs: %v", err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("Expected 2 notification logs, got %d", len(logs))
+	}
+
+	for _, log := range logs {
+		if log.UserID == nil {
+			t.Fatal("Expected notification log to record the user")
+		}
+		skipped := log.Status == domain.NotificationStatusSkippedUnavailable
+		if *log.UserID == onCall.UserID && !skipped {
+			t.Errorf("Expected unavailable user to be logged as %s, got %s", domain.NotificationStatusSkippedUnavailable, log.Status)
+		}
+		if *log.UserID != onCall.UserID && skipped {
+			t.Error("Expected the next participant to be paged")
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package integration
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/outbound/postgres"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+// claimConcurrently runs claim from several goroutines at once and returns
+// how many times each id was handed out.
+func claimConcurrently(t *testing.T, workers int, claim func() ([]uuid.UUID, error)) map[uuid.UUID]int {
+	t.Helper()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	counts := make(map[uuid.UUID]int)
+	start := make(chan struct{})
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			ids, err := claim()
+			if err != nil {
+				t.Errorf("Claim failed: %v", err)
+				return
+			}
+			mu.Lock()
+			for _, id := range ids {
+				counts[id]++
+			}
+			mu.Unlock()
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+	return counts
+}
+
+// ============================================================================
+// Webhook delivery claims
+// ============================================================================
+
+func TestClaims_WebhookDeliveries_Disjoint(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	orgID := user.Organization.ID
+	endpoint, err := testFixtures.CreateUniqueWebhookEndpoint(ctx, orgID)
+	if err != nil {
+		t.Fatalf("Failed to create endpoint: %v", err)
+	}
+
+	repo := postgres.NewWebhookRepository(&postgres.DB{DB: testDB.DB})
+
+	const total = 40
+	for i := 0; i < total; i++ {
+		delivery := &domain.WebhookDelivery{
+			ID:                uuid.New(),
+			WebhookEndpointID: endpoint.ID,
+			OrganizationID:    orgID,
+			EventType:         "alert.created",
+			Payload:           map[string]interface{}{"n": i},
+			Status:            domain.WebhookDeliveryPending,
+			CreatedAt:         time.Now(),
+			UpdatedAt:         time.Now(),
+		}
+		if err := repo.CreateDelivery(ctx, delivery); err != nil {
+			t.Fatalf("Failed to create delivery: %v", err)
+		}
+	}
+
+	counts := claimConcurrently(t, 8, func() ([]uuid.UUID, error) {
+		deliveries, err := repo.ClaimPendingDeliveries(ctx, 10)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]uuid.UUID, 0, len(deliveries))
+		for _, d := range deliveries {
+			if d.Status != domain.WebhookDeliveryProcessing {
+				t.Errorf("Expected claimed delivery to be processing, got %s", d.Status)
+			}
+			ids = append(ids, d.ID)
+		}
+		return ids, nil
+	})
+
+	if len(counts) != total {
+		t.Errorf("Expected all %d deliveries claimed, got %d", total, len(counts))
+	}
+	for id, n := range counts {
+		if n != 1 {
+			t.Errorf("Delivery %s claimed %d times", id, n)
+		}
+	}
+
+	// Everything is claimed, so a further fetch must come back empty
+	remaining, err := repo.ClaimPendingDeliveries(ctx, 100)
+	if err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("Expected no deliveries left to claim, got %d", len(remaining))
+	}
+}
+
+// ============================================================================
+// Escalation event claims
+// ============================================================================
+
+func TestClaims_PendingEscalations_Disjoint(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	orgID := user.Organization.ID
+	policy, err := testFixtures.CreateUniqueEscalationPolicy(ctx, orgID)
+	if err != nil {
+		t.Fatalf("Failed to create policy: %v", err)
+	}
+
+	repo := postgres.NewEscalationPolicyRepository(&postgres.DB{DB: testDB.DB})
+
+	const total = 30
+	due := time.Now().Add(-time.Minute)
+	for i := 0; i < total; i++ {
+		alert, err := testFixtures.CreateUniqueAlert(ctx, orgID)
+		if err != nil {
+			t.Fatalf("Failed to create alert: %v", err)
+		}
+		event := &domain.AlertEscalationEvent{
+			ID:               uuid.New(),
+			AlertID:          alert.ID,
+			PolicyID:         policy.ID,
+			EventType:        domain.EscalationEventTriggered,
+			NextEscalationAt: &due,
+		}
+		if err := repo.CreateEvent(ctx, event); err != nil {
+			t.Fatalf("Failed to create escalation event: %v", err)
+		}
+	}
+
+	counts := claimConcurrently(t, 6, func() ([]uuid.UUID, error) {
+		events, err := repo.ClaimPendingEscalations(ctx, time.Now(), 10)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]uuid.UUID, 0, len(events))
+		for _, e := range events {
+			ids = append(ids, e.ID)
+		}
+		return ids, nil
+	})
+
+	if len(counts) != total {
+		t.Errorf("Expected all %d events claimed, got %d", total, len(counts))
+	}
+	for id, n := range counts {
+		if n != 1 {
+			t.Errorf("Escalation event %s claimed %d times", id, n)
+		}
+	}
+
+	remaining, err := repo.ClaimPendingEscalations(ctx, time.Now(), 100)
+	if err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("Expected claimed events to be leased, got %d claimable", len(remaining))
+	}
+}
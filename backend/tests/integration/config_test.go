@@ -0,0 +1,431 @@
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/outbound/postgres"
+	"github.com/nmn3m/pulsar/backend/internal/config"
+	"github.com/nmn3m/pulsar/backend/tests/integration/testutils"
+)
+
+// setRequiredConfigEnv sets the environment variables config.Load requires.
+func setRequiredConfigEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("DATABASE_URL", "postgres://localhost/pulsar_config_test")
+	t.Setenv("JWT_SECRET", "config_test_jwt_secret_at_least_32_characters")
+	t.Setenv("JWT_REFRESH_SECRET", "config_test_refresh_secret_at_least_32_chars")
+}
+
+// ============================================================================
+// Worker configuration
+// ============================================================================
+
+func TestConfig_Workers_Defaults(t *testing.T) {
+	setRequiredConfigEnv(t)
+	for _, key := range []string{
+		"WORKER_ESCALATION_ENABLED", "WORKER_ESCALATION_INTERVAL", "WORKER_ESCALATION_BATCH_SIZE",
+		"WORKER_WEBHOOK_ENABLED", "WORKER_WEBHOOK_INTERVAL", "WORKER_WEBHOOK_BATCH_SIZE",
+		"WORKER_WEBHOOK_CONCURRENCY",
+	} {
+		t.Setenv(key, "")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	// The escalation worker ticks often enough to honor sub-minute delays
+	for name, want := range map[string]struct {
+		w        config.WorkerConfig
+		interval time.Duration
+	}{
+		"escalation": {cfg.Workers.Escalation, 5 * time.Second},
+		"webhook":    {cfg.Workers.Webhook, 30 * time.Second},
+	} {
+		w := want.w
+		if !w.Enabled {
+			t.Errorf("Expected %s worker enabled by default", name)
+		}
+		if w.Interval != want.interval {
+			t.Errorf("Expected %s worker interval %v, got %v", name, want.interval, w.Interval)
+		}
+		if w.BatchSize != 100 {
+			t.Errorf("Expected %s worker batch size 100, got %d", name, w.BatchSize)
+		}
+	}
+	if cfg.Workers.WebhookConcurrency != 10 {
+		t.Errorf("Expected webhook concurrency 10, got %d", cfg.Workers.WebhookConcurrency)
+	}
+}
+
+func TestConfig_Workers_FromEnv(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("WORKER_ESCALATION_ENABLED", "true")
+	t.Setenv("WORKER_ESCALATION_INTERVAL", "5s")
+	t.Setenv("WORKER_ESCALATION_BATCH_SIZE", "25")
+	t.Setenv("WORKER_WEBHOOK_ENABLED", "false")
+	t.Setenv("WORKER_WEBHOOK_INTERVAL", "120")
+	t.Setenv("WORKER_WEBHOOK_BATCH_SIZE", "500")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if cfg.Workers.Escalation.Interval != 5*time.Second {
+		t.Errorf("Expected escalation interval 5s, got %v", cfg.Workers.Escalation.Interval)
+	}
+	if cfg.Workers.Escalation.BatchSize != 25 {
+		t.Errorf("Expected escalation batch size 25, got %d", cfg.Workers.Escalation.BatchSize)
+	}
+	if cfg.Workers.Webhook.Enabled {
+		t.Error("Expected webhook worker to be disabled")
+	}
+	if cfg.Workers.Webhook.Interval != 2*time.Minute {
+		t.Errorf("Expected bare integer interval to be read as seconds, got %v", cfg.Workers.Webhook.Interval)
+	}
+	if cfg.Workers.Webhook.BatchSize != 500 {
+		t.Errorf("Expected webhook batch size 500, got %d", cfg.Workers.Webhook.BatchSize)
+	}
+}
+
+func TestConfig_Workers_InvalidInterval(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("WORKER_ESCALATION_ENABLED", "true")
+	t.Setenv("WORKER_ESCALATION_INTERVAL", "-1s")
+
+	if _, err := config.Load(); err == nil {
+		t.Error("Expected error for non-positive escalation interval")
+	}
+}
+
+func TestConfig_Workers_InvalidWebhookConcurrency(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("WORKER_WEBHOOK_ENABLED", "true")
+	t.Setenv("WORKER_WEBHOOK_CONCURRENCY", "0")
+
+	if _, err := config.Load(); err == nil {
+		t.Error("Expected error for non-positive webhook concurrency")
+	}
+}
+
+func TestConfig_Workers_DisabledSkipsValidation(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("WORKER_WEBHOOK_ENABLED", "false")
+	t.Setenv("WORKER_WEBHOOK_BATCH_SIZE", "0")
+
+	if _, err := config.Load(); err != nil {
+		t.Errorf("Expected disabled worker to skip validation, got %v", err)
+	}
+}
+
+// ============================================================================
+// Database connection pool
+// ============================================================================
+
+func TestConfig_Database_PoolDefaults(t *testing.T) {
+	setRequiredConfigEnv(t)
+	for _, key := range []string{
+		"DB_MAX_OPEN_CONNS", "DB_MAX_IDLE_CONNS", "DB_CONN_MAX_LIFETIME", "DB_CONN_MAX_IDLE_TIME",
+	} {
+		t.Setenv(key, "")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	db := cfg.Database
+	if db.MaxOpenConns != 25 || db.MaxIdleConns != 5 {
+		t.Errorf("Expected 25 open and 5 idle connections, got %d and %d", db.MaxOpenConns, db.MaxIdleConns)
+	}
+	if db.ConnMaxLifetime != 30*time.Minute || db.ConnMaxIdleTime != 5*time.Minute {
+		t.Errorf("Expected 30m lifetime and 5m idle time, got %v and %v", db.ConnMaxLifetime, db.ConnMaxIdleTime)
+	}
+}
+
+func TestConfig_Database_InvalidPool(t *testing.T) {
+	setRequiredConfigEnv(t)
+
+	t.Setenv("DB_MAX_OPEN_CONNS", "0")
+	if _, err := config.Load(); err == nil {
+		t.Error("Expected error for a pool without open connections")
+	}
+
+	t.Setenv("DB_MAX_OPEN_CONNS", "10")
+	t.Setenv("DB_MAX_IDLE_CONNS", "20")
+	if _, err := config.Load(); err == nil {
+		t.Error("Expected error for more idle than open connections")
+	}
+}
+
+func TestDatabase_PoolSettingsApplied(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("DATABASE_URL", testutils.LoadTestConfig().DatabaseURL)
+	t.Setenv("DB_MAX_OPEN_CONNS", "7")
+	t.Setenv("DB_MAX_IDLE_CONNS", "2")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	db, err := postgres.NewDB(&cfg.Database)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer db.Close()
+
+	if got := db.Stats().MaxOpenConnections; got != 7 {
+		t.Errorf("Expected at most 7 open connections, got %d", got)
+	}
+
+	// Hold more connections than may idle, then hand them all back
+	ctx := context.Background()
+	var conns []*sql.Conn
+	for i := 0; i < 4; i++ {
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			t.Fatalf("Failed to get connection: %v", err)
+		}
+		conns = append(conns, conn)
+	}
+	for _, conn := range conns {
+		conn.Close()
+	}
+
+	if idle := db.Stats().Idle; idle != 2 {
+		t.Errorf("Expected 2 idle connections to be kept, got %d", idle)
+	}
+}
+
+// ============================================================================
+// CORS configuration
+// ============================================================================
+
+func TestConfig_CORS_Defaults(t *testing.T) {
+	setRequiredConfigEnv(t)
+	for _, key := range []string{
+		"CORS_ALLOWED_ORIGINS", "CORS_ALLOWED_METHODS", "CORS_ALLOWED_HEADERS",
+		"CORS_EXPOSED_HEADERS", "CORS_ALLOW_CREDENTIALS", "CORS_MAX_AGE",
+	} {
+		t.Setenv(key, "")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if !cfg.CORS.AllowCredentials {
+		t.Error("Expected credentials to be allowed by default")
+	}
+	if cfg.CORS.MaxAge != 2*time.Hour {
+		t.Errorf("Expected default max-age 2h, got %v", cfg.CORS.MaxAge)
+	}
+	headers := strings.Join(cfg.CORS.AllowedHeaders, ",")
+	for _, h := range []string{"X-API-Key", "Idempotency-Key"} {
+		if !strings.Contains(headers, h) {
+			t.Errorf("Expected %s in default allowed headers, got %s", h, headers)
+		}
+	}
+}
+
+func TestConfig_CORS_ListsAreTrimmed(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("CORS_ALLOWED_ORIGINS", " https://a.example.com , https://b.example.com,")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	want := []string{"https://a.example.com", "https://b.example.com"}
+	if strings.Join(cfg.CORS.AllowedOrigins, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected origins %v, got %v", want, cfg.CORS.AllowedOrigins)
+	}
+}
+
+func TestConfig_CORS_WildcardWithCredentialsRejected(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("CORS_ALLOWED_ORIGINS", "*")
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "true")
+
+	if _, err := config.Load(); err == nil {
+		t.Error("Expected error for wildcard origin with credentials")
+	}
+
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "false")
+	if _, err := config.Load(); err != nil {
+		t.Errorf("Expected wildcard origin without credentials to load, got %v", err)
+	}
+}
+
+// ============================================================================
+// Acknowledge links
+// ============================================================================
+
+func TestConfig_AckLinks_Defaults(t *testing.T) {
+	setRequiredConfigEnv(t)
+	for _, key := range []string{"ACK_LINK_SECRET", "ACK_LINK_TTL", "API_PUBLIC_URL"} {
+		t.Setenv(key, "")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.AckLinks.Secret != cfg.JWT.Secret {
+		t.Error("Expected acknowledge links to be signed with JWT_SECRET by default")
+	}
+	if cfg.AckLinks.TTL != 24*time.Hour || cfg.AckLinks.BaseURL != "http://localhost:8080" {
+		t.Errorf("Expected a 24h TTL and the local API URL, got %v and %q", cfg.AckLinks.TTL, cfg.AckLinks.BaseURL)
+	}
+}
+
+func TestConfig_AckLinks_Invalid(t *testing.T) {
+	setRequiredConfigEnv(t)
+
+	t.Setenv("ACK_LINK_SECRET", "too-short")
+	if _, err := config.Load(); err == nil {
+		t.Error("Expected a short ACK_LINK_SECRET to be rejected")
+	}
+
+	t.Setenv("ACK_LINK_SECRET", "")
+	t.Setenv("ACK_LINK_TTL", "0s")
+	if _, err := config.Load(); err == nil {
+		t.Error("Expected a zero ACK_LINK_TTL to be rejected")
+	}
+}
+
+// ============================================================================
+// JWT token lifetimes
+// ============================================================================
+
+func TestConfig_JWT_TTLDefaults(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("JWT_ACCESS_TTL", "")
+	t.Setenv("JWT_REFRESH_TTL", "")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.JWT.AccessTTL != time.Hour || cfg.JWT.RefreshTTL != 7*24*time.Hour {
+		t.Errorf("Expected 1h access and 168h refresh TTL, got %v and %v", cfg.JWT.AccessTTL, cfg.JWT.RefreshTTL)
+	}
+}
+
+func TestConfig_JWT_TTLFromEnv(t *testing.T) {
+	setRequiredConfigEnv(t)
+
+	// Durations
+	t.Setenv("JWT_ACCESS_TTL", "10m")
+	t.Setenv("JWT_REFRESH_TTL", "12h")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.JWT.AccessTTL != 10*time.Minute || cfg.JWT.RefreshTTL != 12*time.Hour {
+		t.Errorf("Expected 10m and 12h, got %v and %v", cfg.JWT.AccessTTL, cfg.JWT.RefreshTTL)
+	}
+
+	// Bare integers keep their historical units: minutes and days
+	t.Setenv("JWT_ACCESS_TTL", "30")
+	t.Setenv("JWT_REFRESH_TTL", "14")
+	cfg, err = config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.JWT.AccessTTL != 30*time.Minute || cfg.JWT.RefreshTTL != 14*24*time.Hour {
+		t.Errorf("Expected 30m and 336h, got %v and %v", cfg.JWT.AccessTTL, cfg.JWT.RefreshTTL)
+	}
+}
+
+func TestConfig_JWT_InvalidTTLRejected(t *testing.T) {
+	cases := []struct {
+		access, refresh, want string
+	}{
+		{"30s", "7", "JWT_ACCESS_TTL"},
+		{"48h", "30", "JWT_ACCESS_TTL"},
+		{"2h", "1h", "JWT_REFRESH_TTL"},
+		{"1h", "1h", "JWT_REFRESH_TTL"},
+		{"1h", "365", "JWT_REFRESH_TTL"},
+	}
+
+	for _, tc := range cases {
+		setRequiredConfigEnv(t)
+		t.Setenv("JWT_ACCESS_TTL", tc.access)
+		t.Setenv("JWT_REFRESH_TTL", tc.refresh)
+
+		_, err := config.Load()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("access=%s refresh=%s: expected %s error, got %v", tc.access, tc.refresh, tc.want, err)
+		}
+	}
+}
+
+// ============================================================================
+// Password policy
+// ============================================================================
+
+func TestConfig_Password_FromEnv(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("PASSWORD_MIN_LENGTH", "14")
+	t.Setenv("PASSWORD_REQUIRE_SPECIAL", "false")
+	t.Setenv("PASSWORD_DENYLIST", "Pulsar2024!, OnCall123!")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if cfg.Password.MinLength != 14 {
+		t.Errorf("Expected min length 14, got %d", cfg.Password.MinLength)
+	}
+	if !cfg.Password.RequireUpper || cfg.Password.RequireSpecial {
+		t.Errorf("Expected upper case required and special characters optional, got %+v", cfg.Password)
+	}
+	if len(cfg.Password.Denylist) != 2 || cfg.Password.Denylist[1] != "OnCall123!" {
+		t.Errorf("Expected two denylisted passwords, got %v", cfg.Password.Denylist)
+	}
+
+	t.Setenv("PASSWORD_MIN_LENGTH", "6")
+	if _, err := config.Load(); err == nil || !strings.Contains(err.Error(), "PASSWORD_MIN_LENGTH") {
+		t.Errorf("Expected PASSWORD_MIN_LENGTH error, got %v", err)
+	}
+}
+
+// ============================================================================
+// Prometheus metrics
+// ============================================================================
+
+func TestConfig_Metrics_TokenRequiredInProduction(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("ENV", "production")
+	t.Setenv("METRICS_TOKEN", "")
+
+	if _, err := config.Load(); err == nil {
+		t.Error("Expected an unauthenticated metrics endpoint to be rejected in production")
+	}
+
+	t.Setenv("METRICS_ENABLED", "false")
+	if _, err := config.Load(); err != nil {
+		t.Errorf("Expected disabled metrics to load without a token, got %v", err)
+	}
+
+	t.Setenv("METRICS_ENABLED", "true")
+	t.Setenv("METRICS_TOKEN", "scrape-token")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Metrics.RefreshInterval != 30*time.Second {
+		t.Errorf("Expected a 30s refresh interval by default, got %v", cfg.Metrics.RefreshInterval)
+	}
+}
@@ -0,0 +1,105 @@
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
+)
+
+// preflight sends a CORS preflight request to a router wrapped with opts.
+func preflight(t *testing.T, opts middleware.CORSOptions, origin string) *http.Response {
+	t.Helper()
+
+	router := gin.New()
+	router.Use(middleware.CORS(opts))
+	router.GET("/api/v1/alerts", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	req, _ := http.NewRequest(http.MethodOptions, server.URL+"/api/v1/alerts", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "X-API-Key, Idempotency-Key")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Preflight request failed: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// ============================================================================
+// CORS preflight
+// ============================================================================
+
+func TestCORS_Preflight_ConfiguredHeaders(t *testing.T) {
+	resp := preflight(t, middleware.CORSOptions{
+		AllowedOrigins:   []string{"https://app.pulsar.test"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-API-Key", "Idempotency-Key"},
+		ExposedHeaders:   []string{"X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}, "https://app.pulsar.test")
+
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("Expected 204 for preflight, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "https://app.pulsar.test" {
+		t.Errorf("Expected allowed origin to be echoed, got %q", got)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("Expected credentials to be allowed, got %q", got)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Methods"); !strings.Contains(got, "POST") {
+		t.Errorf("Expected POST in allowed methods, got %q", got)
+	}
+	allowHeaders := strings.ToLower(resp.Header.Get("Access-Control-Allow-Headers"))
+	for _, h := range []string{"x-api-key", "idempotency-key"} {
+		if !strings.Contains(allowHeaders, h) {
+			t.Errorf("Expected %s in allowed headers, got %q", h, allowHeaders)
+		}
+	}
+	if got := resp.Header.Get("Access-Control-Max-Age"); got != "600" {
+		t.Errorf("Expected max-age 600, got %q", got)
+	}
+}
+
+func TestCORS_Preflight_DisallowedOrigin(t *testing.T) {
+	resp := preflight(t, middleware.CORSOptions{
+		AllowedOrigins: []string{"https://app.pulsar.test"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"X-API-Key", "Idempotency-Key"},
+	}, "https://evil.example.com")
+
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403 for disallowed origin, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Expected no allow-origin header, got %q", got)
+	}
+}
+
+func TestCORS_Preflight_WildcardNeverSendsCredentials(t *testing.T) {
+	resp := preflight(t, middleware.CORSOptions{
+		AllowedOrigins:   []string{"*"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"X-API-Key", "Idempotency-Key"},
+		AllowCredentials: true,
+	}, "https://anywhere.example.com")
+
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Expected wildcard origin, got %q", got)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Expected credentials header to be omitted with wildcard origin, got %q", got)
+	}
+}
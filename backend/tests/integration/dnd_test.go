@@ -0,0 +1,289 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+)
+
+func setDNDSettings(t *testing.T, ctx context.Context, userID uuid.UUID, schedule *domain.DNDSchedule, overrides []domain.DNDOverride, allowP1 bool) {
+	t.Helper()
+
+	enabled := true
+	req := &dto.UpdateDNDSettingsRequest{
+		Enabled:         &enabled,
+		AllowP1Override: &allowP1,
+	}
+	if schedule != nil {
+		req.Schedule, _ = json.Marshal(schedule)
+	}
+	if overrides != nil {
+		req.Overrides, _ = json.Marshal(overrides)
+	}
+
+	if _, err := testServer.DNDService.UpdateSettings(ctx, userID, req); err != nil {
+		t.Fatalf("Failed to update DND settings: %v", err)
+	}
+}
+
+func assertDNDDecision(t *testing.T, ctx context.Context, userID uuid.UUID, at time.Time, priority domain.AlertPriority, inDND bool, reason domain.DNDReason) {
+	t.Helper()
+
+	decision, err := testServer.DNDService.IsInDND(ctx, userID, at, priority)
+	if err != nil {
+		t.Fatalf("Failed to evaluate DND: %v", err)
+	}
+	if decision.InDND != inDND || decision.Reason != reason {
+		t.Errorf("At %s (%s): expected in_dnd=%v reason=%s, got in_dnd=%v reason=%s",
+			at.Format(time.RFC3339), priority, inDND, reason, decision.InDND, decision.Reason)
+	}
+}
+
+// ============================================================================
+// DNDService.IsInDND
+// ============================================================================
+
+func TestDND_IsInDND_WeeknightWindow(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	setDNDSettings(t, ctx, user.User.ID, &domain.DNDSchedule{
+		Timezone: "UTC",
+		Weekly: []domain.DNDTimeSlot{
+			{Day: "wednesday", Start: "22:00", End: "23:59"},
+		},
+	}, nil, false)
+
+	// 2024-01-03 is a Wednesday
+	assertDNDDecision(t, ctx, user.User.ID, time.Date(2024, 1, 3, 22, 30, 0, 0, time.UTC), domain.PriorityP3, true, domain.DNDReasonWeeklySchedule)
+	assertDNDDecision(t, ctx, user.User.ID, time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC), domain.PriorityP3, false, domain.DNDReasonOutsideWindow)
+	assertDNDDecision(t, ctx, user.User.ID, time.Date(2024, 1, 4, 22, 30, 0, 0, time.UTC), domain.PriorityP3, false, domain.DNDReasonOutsideWindow)
+}
+
+func TestDND_IsInDND_P1OverrideBypass(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	setDNDSettings(t, ctx, user.User.ID, &domain.DNDSchedule{
+		Timezone: "UTC",
+		Weekly: []domain.DNDTimeSlot{
+			{Day: "wednesday", Start: "22:00", End: "23:59"},
+		},
+	}, nil, true)
+
+	at := time.Date(2024, 1, 3, 22, 30, 0, 0, time.UTC)
+	assertDNDDecision(t, ctx, user.User.ID, at, domain.PriorityP1, false, domain.DNDReasonP1Override)
+	assertDNDDecision(t, ctx, user.User.ID, at, domain.PriorityP2, true, domain.DNDReasonWeeklySchedule)
+}
+
+func TestDND_IsInDND_OverridePeriod(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	start := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	setDNDSettings(t, ctx, user.User.ID, nil, []domain.DNDOverride{
+		{Start: start, End: start.Add(8 * time.Hour), Reason: "Offsite"},
+	}, false)
+
+	assertDNDDecision(t, ctx, user.User.ID, start.Add(time.Hour), domain.PriorityP2, true, domain.DNDReasonOverridePeriod)
+	assertDNDDecision(t, ctx, user.User.ID, start.Add(9*time.Hour), domain.PriorityP2, false, domain.DNDReasonOutsideWindow)
+}
+
+func TestDND_IsInDND_NotConfigured(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+
+	assertDNDDecision(t, ctx, user.User.ID, time.Now(), domain.PriorityP3, false, domain.DNDReasonNotConfigured)
+}
+
+// ============================================================================
+// AlertNotifier DND handling
+// ============================================================================
+
+func TestDND_EscalationLogsSuppressedNotification(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	testFixtures.CreateNotificationChannel(ctx, user.Organization.ID, "Email")
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Disk full")
+
+	now := time.Now()
+	setDNDSettings(t, ctx, user.User.ID, nil, []domain.DNDOverride{
+		{Start: now.Add(-time.Hour), End: now.Add(time.Hour)},
+	}, false)
+
+	targets := []domain.EscalationTarget{
+		{TargetType: domain.EscalationTargetTypeUser, TargetID: user.User.ID},
+	}
+	if err := testServer.AlertNotifier.NotifyAlertEscalated(ctx, alert, &domain.EscalationRule{}, targets); err != nil {
+		t.Fatalf("Failed to notify escalation: %v", err)
+	}
+
+	logs, err := testServer.NotificationService.ListLogsByAlert(ctx, alert.ID)
+	if err != nil {
+		t.Fatalf("Failed to list notification logs: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("Expected 1 notification log, got %d", len(logs))
+	}
+	if logs[0].Status != domain.NotificationStatusSuppressedDND {
+		t.Errorf("Expected status %s, got %s", domain.NotificationStatusSuppressedDND, logs[0].Status)
+	}
+	if logs[0].ErrorMessage == nil || *logs[0].ErrorMessage == "" {
+		t.Error("Expected the suppression reason to be recorded")
+	}
+}
+
+// ============================================================================
+// PUT /api/v1/users/me/dnd
+// ============================================================================
+
+func putDNDSchedule(t *testing.T, schedule map[string]interface{}, expected int) {
+	t.Helper()
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	reqBody := map[string]interface{}{
+		"enabled":  true,
+		"schedule": schedule,
+	}
+
+	resp := client.Put("/api/v1/users/me/dnd", reqBody)
+	client.ExpectStatus(resp, expected)
+}
+
+func TestDND_UpdateSettings_InvalidDay(t *testing.T) {
+	cleanDatabase(t)
+
+	putDNDSchedule(t, map[string]interface{}{
+		"timezone": "UTC",
+		"weekly":   []map[string]string{{"day": "funday", "start": "22:00", "end": "23:00"}},
+	}, http.StatusBadRequest)
+}
+
+func TestDND_UpdateSettings_BadTime(t *testing.T) {
+	cleanDatabase(t)
+
+	for _, slot := range []map[string]string{
+		{"day": "monday", "start": "25:00", "end": "08:00"},
+		{"day": "monday", "start": "9:00", "end": "17:00"},
+		{"day": "monday", "start": "22:00", "end": "late"},
+		{"day": "monday", "start": "22:00", "end": "22:00"},
+	} {
+		putDNDSchedule(t, map[string]interface{}{
+			"timezone": "UTC",
+			"weekly":   []map[string]string{slot},
+		}, http.StatusBadRequest)
+	}
+}
+
+func TestDND_UpdateSettings_UnknownTimezone(t *testing.T) {
+	cleanDatabase(t)
+
+	putDNDSchedule(t, map[string]interface{}{
+		"timezone": "Mars/Olympus_Mons",
+		"weekly":   []map[string]string{{"day": "monday", "start": "22:00", "end": "23:00"}},
+	}, http.StatusBadRequest)
+}
+
+func TestDND_UpdateSettings_OvernightSlot(t *testing.T) {
+	cleanDatabase(t)
+
+	putDNDSchedule(t, map[string]interface{}{
+		"timezone": "Europe/Berlin",
+		"weekly":   []map[string]string{{"day": "friday", "start": "22:00", "end": "08:00"}},
+	}, http.StatusOK)
+}
+
+// ============================================================================
+// POST /api/v1/users/me/dnd/overrides
+// DELETE /api/v1/users/me/dnd/overrides/:index
+// ============================================================================
+
+func TestDND_AddOverride_InvertedRange(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	start := time.Now().Add(2 * time.Hour).UTC()
+	reqBody := map[string]interface{}{
+		"start": start.Format(time.RFC3339),
+		"end":   start.Add(-time.Hour).Format(time.RFC3339),
+	}
+
+	resp := client.Post("/api/v1/users/me/dnd/overrides", reqBody)
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+func TestDND_AddOverride_Appends(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	start := time.Now().Add(time.Hour).UTC()
+	for i := 0; i < 2; i++ {
+		reqBody := map[string]interface{}{
+			"start":  start.Add(time.Duration(i) * 24 * time.Hour).Format(time.RFC3339),
+			"end":    start.Add(time.Duration(i)*24*time.Hour + time.Hour).Format(time.RFC3339),
+			"reason": "Focus time",
+		}
+		resp := client.Post("/api/v1/users/me/dnd/overrides", reqBody)
+		client.AssertStatus(resp, http.StatusOK)
+	}
+
+	settings, err := testServer.DNDService.GetSettings(ctx, user.User.ID)
+	if err != nil {
+		t.Fatalf("Failed to get DND settings: %v", err)
+	}
+	overrides, _ := settings.ParseOverrides()
+	if len(overrides) != 2 {
+		t.Errorf("Expected 2 overrides, got %d", len(overrides))
+	}
+}
+
+func TestDND_RemoveOverride_InvalidIndex(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	// No settings yet
+	resp := client.Delete("/api/v1/users/me/dnd/overrides/0")
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	start := time.Now().Add(time.Hour)
+	testServer.DNDService.AddOverride(ctx, user.User.ID, &dto.AddDNDOverrideRequest{Start: start, End: start.Add(time.Hour)})
+
+	resp = client.Delete("/api/v1/users/me/dnd/overrides/1")
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	resp = client.Delete("/api/v1/users/me/dnd/overrides/-1")
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	resp = client.Delete("/api/v1/users/me/dnd/overrides/0")
+	client.AssertStatus(resp, http.StatusOK)
+}
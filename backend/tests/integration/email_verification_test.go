@@ -0,0 +1,318 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/outbound/postgres"
+	"github.com/nmn3m/pulsar/backend/internal/config"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/service"
+)
+
+// unverifiedUser creates a user whose email still needs verification
+func unverifiedUser(t *testing.T, ctx context.Context) *domain.User {
+	t.Helper()
+
+	user, err := testFixtures.CreateUniqueUser(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if _, err := testDB.ExecContext(ctx, `UPDATE users SET email_verified = false WHERE id = $1`, user.User.ID); err != nil {
+		t.Fatalf("Failed to mark user unverified: %v", err)
+	}
+	return user.User
+}
+
+// backdateVerification moves the pending code's creation time into the past
+func backdateVerification(t *testing.T, ctx context.Context, userID uuid.UUID, seconds int) {
+	t.Helper()
+
+	if _, err := testDB.ExecContext(ctx,
+		`UPDATE email_verifications SET created_at = created_at - make_interval(secs => $2) WHERE user_id = $1`,
+		userID, seconds,
+	); err != nil {
+		t.Fatalf("Failed to backdate verification: %v", err)
+	}
+}
+
+// failingVerificationService returns a verification service whose SMTP
+// server refuses every connection
+func failingVerificationService() *service.EmailVerificationService {
+	db := &postgres.DB{DB: testDB.DB}
+	emailSvc := service.NewEmailService(
+		&config.EmailConfig{Provider: "smtp"},
+		&config.SMTPConfig{Enabled: true, Host: "127.0.0.1", Port: 1, From: "pulsar@example.com"},
+	)
+	svc := service.NewEmailVerificationService(
+		postgres.NewEmailVerificationRepository(db),
+		postgres.NewUserRepository(db),
+		emailSvc,
+		zap.NewNop(),
+	)
+	svc.SetEnvironment("test")
+	return svc
+}
+
+// ============================================================================
+// /api/v1/auth/resend-otp
+// ============================================================================
+
+func TestEmailVerification_ResendCooldown(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user := unverifiedUser(t, ctx)
+	body := map[string]string{"email": user.Email}
+
+	resp := client.Post("/api/v1/auth/resend-otp", body)
+	client.ExpectStatus(resp, http.StatusOK)
+
+	resp = client.Post("/api/v1/auth/resend-otp", body)
+	client.ExpectStatus(resp, http.StatusTooManyRequests)
+	if resp.Header.Get("Retry-After") == "" {
+		t.Error("Expected a Retry-After header on a rejected resend")
+	}
+
+	backdateVerification(t, ctx, user.ID, int(domain.OTPResendCooldown.Seconds())+1)
+
+	resp = client.Post("/api/v1/auth/resend-otp", body)
+	client.ExpectStatus(resp, http.StatusOK)
+}
+
+func TestEmailVerification_ResentCodeVerifies(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user := unverifiedUser(t, ctx)
+
+	resp := client.Post("/api/v1/auth/resend-otp", map[string]string{"email": user.Email})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	pending, err := testServer.EmailVerification.GetPendingVerification(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Failed to get pending verification: %v", err)
+	}
+
+	resp = client.Post("/api/v1/auth/verify-email", map[string]string{"email": user.Email, "otp": pending.OTP})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	resp = client.Post("/api/v1/auth/resend-otp", map[string]string{"email": user.Email})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+// ============================================================================
+// Delivery failures
+// ============================================================================
+
+func TestEmailVerification_SendFailureIsRecorded(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user := unverifiedUser(t, ctx)
+	svc := failingVerificationService()
+
+	err := svc.ResendOTP(ctx, user.Email)
+	if !errors.Is(err, domain.ErrOTPDeliveryFailed) {
+		t.Fatalf("Expected ErrOTPDeliveryFailed, got %v", err)
+	}
+
+	pending, err := svc.GetPendingVerification(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Expected the code to be kept after a failed send: %v", err)
+	}
+	if pending.SendAttempts != 3 {
+		t.Errorf("Expected 3 send attempts, got %d", pending.SendAttempts)
+	}
+	if pending.LastSendError == nil {
+		t.Error("Expected the send error to be recorded")
+	}
+	if pending.LastSentAt != nil {
+		t.Error("Expected no successful send to be recorded")
+	}
+
+	// The stored code still verifies once the user gets hold of it
+	if err := svc.VerifyOTP(ctx, user.Email, pending.OTP); err != nil {
+		t.Errorf("Expected the undelivered code to verify, got %v", err)
+	}
+}
+
+func TestEmailVerification_FailedSendStopsRetryingWhenRequestEnds(t *testing.T) {
+	cleanDatabase(t)
+
+	user := unverifiedUser(t, context.Background())
+	svc := failingVerificationService()
+
+	// The deadline lands in the first backoff, well before all attempts are made
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := svc.ResendOTP(ctx, user.Email); !errors.Is(err, domain.ErrOTPDeliveryFailed) {
+		t.Fatalf("Expected ErrOTPDeliveryFailed, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the send to give up once the request ended, took %v", elapsed)
+	}
+}
+
+func TestEmailVerification_RetryAfterFailedSend(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user := unverifiedUser(t, ctx)
+	svc := failingVerificationService()
+
+	if err := svc.ResendOTP(ctx, user.Email); !errors.Is(err, domain.ErrOTPDeliveryFailed) {
+		t.Fatalf("Expected ErrOTPDeliveryFailed, got %v", err)
+	}
+
+	// Immediate retries are still rate limited
+	if err := svc.ResendOTP(ctx, user.Email); !errors.Is(err, domain.ErrOTPResendCooldown) {
+		t.Fatalf("Expected ErrOTPResendCooldown, got %v", err)
+	}
+
+	// but a failed send doesn't hold the user back for the full cooldown
+	backdateVerification(t, ctx, user.ID, int(domain.OTPFailedResendCooldown.Seconds())+1)
+	if err := svc.ResendOTP(ctx, user.Email); !errors.Is(err, domain.ErrOTPDeliveryFailed) {
+		t.Fatalf("Expected the retry to reach the provider, got %v", err)
+	}
+}
+
+// ============================================================================
+// /api/v1/auth/verify-email
+// ============================================================================
+
+// requestOTP sends a fresh code to the user and returns it
+func requestOTP(t *testing.T, ctx context.Context, user *domain.User) string {
+	t.Helper()
+	client := newTestClient(t)
+
+	resp := client.Post("/api/v1/auth/resend-otp", map[string]string{"email": user.Email})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	pending, err := testServer.EmailVerification.GetPendingVerification(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Failed to get pending verification: %v", err)
+	}
+	return pending.OTP
+}
+
+// wrongOTP returns a code of the same length that doesn't match otp
+func wrongOTP(otp string) string {
+	if otp == "111111" {
+		return "222222"
+	}
+	return "111111"
+}
+
+func TestEmailVerification_LockoutAfterWrongAttempts(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user := unverifiedUser(t, ctx)
+	otp := requestOTP(t, ctx, user)
+
+	for i := 1; i < domain.OTPMaxAttempts; i++ {
+		resp := client.Post("/api/v1/auth/verify-email", map[string]string{"email": user.Email, "otp": wrongOTP(otp)})
+		client.ExpectStatus(resp, http.StatusBadRequest)
+	}
+	resp := client.Post("/api/v1/auth/verify-email", map[string]string{"email": user.Email, "otp": wrongOTP(otp)})
+	client.ExpectStatus(resp, http.StatusTooManyRequests)
+
+	// The right code no longer works once the code is locked
+	resp = client.Post("/api/v1/auth/verify-email", map[string]string{"email": user.Email, "otp": otp})
+	client.ExpectStatus(resp, http.StatusTooManyRequests)
+
+	// A fresh code unlocks verification
+	backdateVerification(t, ctx, user.ID, int(domain.OTPResendCooldown.Seconds())+1)
+	otp = requestOTP(t, ctx, user)
+
+	resp = client.Post("/api/v1/auth/verify-email", map[string]string{"email": user.Email, "otp": otp})
+	client.ExpectStatus(resp, http.StatusOK)
+}
+
+func TestEmailVerification_ConcurrentGuessesRespectAttemptLimit(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user := unverifiedUser(t, ctx)
+	otp := requestOTP(t, ctx, user)
+
+	guesses := domain.OTPMaxAttempts * 4
+	errs := make(chan error, guesses)
+	var wg sync.WaitGroup
+	for i := 0; i < guesses; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- testServer.EmailVerification.VerifyOTP(ctx, user.Email, wrongOTP(otp))
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var checked int
+	for err := range errs {
+		if err == nil {
+			t.Fatal("Expected a wrong code to be rejected")
+		}
+		if !errors.Is(err, domain.ErrOTPLocked) {
+			checked++
+		}
+	}
+	if checked != domain.OTPMaxAttempts-1 {
+		t.Errorf("Expected %d guesses to be checked before the lock, got %d", domain.OTPMaxAttempts-1, checked)
+	}
+
+	var failedAttempts int
+	if err := testDB.QueryRowContext(ctx,
+		`SELECT failed_attempts FROM email_verifications WHERE user_id = $1`, user.ID,
+	).Scan(&failedAttempts); err != nil {
+		t.Fatalf("Failed to read verification: %v", err)
+	}
+	if failedAttempts != domain.OTPMaxAttempts {
+		t.Errorf("Expected %d attempts to be counted, got %d", domain.OTPMaxAttempts, failedAttempts)
+	}
+
+	if err := testServer.EmailVerification.VerifyOTP(ctx, user.Email, otp); !errors.Is(err, domain.ErrOTPLocked) {
+		t.Errorf("Expected the right code to be rejected once locked, got %v", err)
+	}
+}
+
+func TestEmailVerification_SuccessResetsFailedAttempts(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user := unverifiedUser(t, ctx)
+	otp := requestOTP(t, ctx, user)
+
+	for i := 0; i < domain.OTPMaxAttempts-1; i++ {
+		resp := client.Post("/api/v1/auth/verify-email", map[string]string{"email": user.Email, "otp": wrongOTP(otp)})
+		client.ExpectStatus(resp, http.StatusBadRequest)
+	}
+
+	resp := client.Post("/api/v1/auth/verify-email", map[string]string{"email": user.Email, "otp": otp})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var failedAttempts int
+	if err := testDB.QueryRowContext(ctx,
+		`SELECT failed_attempts FROM email_verifications WHERE user_id = $1`, user.ID,
+	).Scan(&failedAttempts); err != nil {
+		t.Fatalf("Failed to read verification: %v", err)
+	}
+	if failedAttempts != 0 {
+		t.Errorf("Expected failed attempts to be reset, got %d", failedAttempts)
+	}
+}
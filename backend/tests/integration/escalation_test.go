@@ -2,9 +2,23 @@ package integration
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/outbound/postgres"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/internal/core/service"
+	"github.com/nmn3m/pulsar/backend/tests/integration/testutils"
 )
 
 // ============================================================================
@@ -366,7 +380,88 @@ func TestEscalationPolicies_AddTarget_RuleNotFound(t *testing.T) {
 	}
 
 	resp := client.Post(fmt.Sprintf("/api/v1/escalation-policies/%s/rules/00000000-0000-0000-0000-000000000000/targets", policy.ID), reqBody)
-	client.ExpectStatus(resp, http.StatusBadRequest) // API returns 400 for FK violations
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+func TestEscalationPolicies_AddTarget_ValidatesTarget(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Responders")
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Primary")
+	otherTeam, _ := testFixtures.CreateTeam(ctx, other.Organization.ID, "Other Responders")
+	otherSchedule, _ := testFixtures.CreateSchedule(ctx, other.Organization.ID, "Other Primary")
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, user.Organization.ID, "Test Policy")
+	rule, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: 1, EscalationDelay: 5})
+	if err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+	path := fmt.Sprintf("/api/v1/escalation-policies/%s/rules/%s/targets", policy.ID, rule.ID)
+
+	tests := []struct {
+		name       string
+		targetType string
+		targetID   uuid.UUID
+		want       int
+	}{
+		{"user", "user", user.User.ID, http.StatusCreated},
+		{"user in other organization", "user", other.User.ID, http.StatusNotFound},
+		{"nonexistent user", "user", uuid.New(), http.StatusNotFound},
+		{"team", "team", team.ID, http.StatusCreated},
+		{"team in other organization", "team", otherTeam.ID, http.StatusNotFound},
+		{"nonexistent team", "team", uuid.New(), http.StatusNotFound},
+		{"schedule", "schedule", schedule.ID, http.StatusCreated},
+		{"schedule in other organization", "schedule", otherSchedule.ID, http.StatusNotFound},
+		{"nonexistent schedule", "schedule", uuid.New(), http.StatusNotFound},
+		{"id of another type", "schedule", team.ID, http.StatusNotFound},
+		{"invalid type", "channel", user.User.ID, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := client.Post(path, map[string]interface{}{
+				"target_type": tt.targetType,
+				"target_id":   tt.targetID.String(),
+			})
+			client.ExpectStatus(resp, tt.want)
+		})
+	}
+
+	targets, err := testServer.EscalationService.ListTargets(ctx, rule.ID)
+	if err != nil {
+		t.Fatalf("Failed to list targets: %v", err)
+	}
+	if len(targets) != 3 {
+		t.Errorf("Expected only the 3 valid targets to be stored, got %d", len(targets))
+	}
+}
+
+func TestEscalationPolicies_AddTarget_OtherOrganizationRule(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(other.AccessToken)
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, owner.Organization.ID, "Owner Policy")
+	rule, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: 1, EscalationDelay: 5})
+	if err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+
+	resp := client.Post(fmt.Sprintf("/api/v1/escalation-policies/%s/rules/%s/targets", policy.ID, rule.ID), map[string]interface{}{
+		"target_type": "user",
+		"target_id":   other.User.ID.String(),
+	})
+	client.AssertStatus(resp, http.StatusNotFound)
 }
 
 // ============================================================================
@@ -386,3 +481,1535 @@ func TestEscalationPolicies_RemoveTarget_NotFound(t *testing.T) {
 	resp := client.Delete(fmt.Sprintf("/api/v1/escalation-policies/%s/rules/00000000-0000-0000-0000-000000000000/targets/00000000-0000-0000-0000-000000000000", policy.ID))
 	client.ExpectStatus(resp, http.StatusInternalServerError) // API returns 500 for not found errors
 }
+
+// ============================================================================
+// PUT /api/v1/escalation-policies/:id/config
+// ============================================================================
+
+func TestEscalationPolicies_ApplyConfig_Reconciles(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, user.Organization.ID, "Config Policy")
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Responders")
+
+	// Seed a rule that is kept and one that is dropped by the config
+	kept, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: 1, EscalationDelay: 5})
+	if err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+	testServer.EscalationService.AddTarget(ctx, user.Organization.ID, kept.ID, &dto.AddEscalationTargetRequest{TargetType: "user", TargetID: user.User.ID})
+	dropped, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: 3, EscalationDelay: 30})
+	if err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+
+	reqBody := map[string]interface{}{
+		"name":           "Config Policy",
+		"repeat_enabled": true,
+		"conditions": map[string]interface{}{
+			"match":      "all",
+			"conditions": []map[string]interface{}{{"field": "priority", "operator": "equals", "value": "P1"}},
+		},
+		"business_hours": map[string]interface{}{
+			"weekly": []map[string]interface{}{{"day": "monday", "start": "09:00", "end": "17:00"}},
+		},
+		"rules": []map[string]interface{}{
+			{
+				"position":         1,
+				"escalation_delay": 10,
+				"targets": []map[string]interface{}{
+					{"target_type": "team", "target_id": team.ID.String()},
+				},
+			},
+			{
+				"position":         2,
+				"escalation_delay": 20,
+				"targets": []map[string]interface{}{
+					{"target_type": "user", "target_id": user.User.ID.String()},
+				},
+			},
+		},
+	}
+
+	resp := client.Put(fmt.Sprintf("/api/v1/escalation-policies/%s/config", policy.ID), reqBody)
+	client.AssertStatus(resp, http.StatusOK)
+
+	result, err := testServer.EscalationService.GetPolicyWithRules(ctx, policy.ID)
+	if err != nil {
+		t.Fatalf("Failed to get policy: %v", err)
+	}
+	if !result.RepeatEnabled {
+		t.Error("Expected repeat_enabled to be applied")
+	}
+	if conditions, err := result.ParseConditions(); err != nil || conditions == nil || len(conditions.Conditions) != 1 {
+		t.Errorf("Expected conditions to be applied, got %s", result.Conditions)
+	}
+	if hours, err := result.ParseBusinessHours(); err != nil || hours == nil || len(hours.Weekly) != 1 || hours.Timezone == "" {
+		t.Errorf("Expected business hours to be applied with a timezone, got %s", result.BusinessHours)
+	}
+	if len(result.Rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(result.Rules))
+	}
+
+	for _, rule := range result.Rules {
+		if rule.ID == dropped.ID {
+			t.Error("Expected rule at unlisted position to be deleted")
+		}
+		if len(rule.Targets) != 1 {
+			t.Errorf("Expected 1 target at position %d, got %d", rule.Position, len(rule.Targets))
+			continue
+		}
+		switch rule.Position {
+		case 1:
+			if rule.ID != kept.ID {
+				t.Errorf("Expected rule at position 1 to keep ID %s, got %s", kept.ID, rule.ID)
+			}
+			if rule.EscalationDelay != 10 {
+				t.Errorf("Expected delay 10, got %d", rule.EscalationDelay)
+			}
+			if rule.Targets[0].TargetID != team.ID {
+				t.Error("Expected position 1 targets to be replaced by the team")
+			}
+		case 2:
+			if rule.Targets[0].TargetID != user.User.ID {
+				t.Error("Expected position 2 to target the user")
+			}
+		default:
+			t.Errorf("Unexpected rule at position %d", rule.Position)
+		}
+	}
+}
+
+func TestEscalationPolicies_ApplyConfig_DuplicatePosition(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, user.Organization.ID, "Config Policy")
+
+	reqBody := map[string]interface{}{
+		"name": "Config Policy",
+		"rules": []map[string]interface{}{
+			{"position": 1, "escalation_delay": 5},
+			{"position": 1, "escalation_delay": 10},
+		},
+	}
+
+	resp := client.Put(fmt.Sprintf("/api/v1/escalation-policies/%s/config", policy.ID), reqBody)
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+// ============================================================================
+// POST /api/v1/escalation-policies/:id/clone
+// ============================================================================
+
+func TestEscalationPolicies_Clone_CopiesRulesAndTargets(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, user.Organization.ID, "Platform Escalation")
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Responders")
+	_, err := testServer.EscalationService.ApplyPolicyConfig(ctx, user.Organization.ID, policy.ID, &dto.EscalationPolicyConfigRequest{
+		Name:          "Platform Escalation",
+		RepeatEnabled: true,
+		Rules: []dto.EscalationRuleConfig{
+			{Position: 1, EscalationDelay: 5, Targets: []dto.AddEscalationTargetRequest{
+				{TargetType: "user", TargetID: user.User.ID},
+			}},
+			{Position: 2, EscalationDelay: 15, Targets: []dto.AddEscalationTargetRequest{
+				{TargetType: "team", TargetID: team.ID},
+				{TargetType: "user", TargetID: user.User.ID},
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to configure policy: %v", err)
+	}
+	original, _ := testServer.EscalationService.GetPolicyWithRules(ctx, policy.ID)
+
+	resp := client.Post(fmt.Sprintf("/api/v1/escalation-policies/%s/clone", policy.ID), map[string]interface{}{
+		"name": "Payments Escalation",
+	})
+	client.AssertStatus(resp, http.StatusCreated)
+
+	var created domain.EscalationPolicyWithRules
+	client.ParseJSON(resp, &created)
+
+	clone, err := testServer.EscalationService.GetPolicyWithRules(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Failed to get cloned policy: %v", err)
+	}
+	if clone.ID == policy.ID {
+		t.Fatal("Expected the clone to be a new policy")
+	}
+	if clone.Name != "Payments Escalation" || !clone.RepeatEnabled {
+		t.Errorf("Expected clone settings to be copied with the new name, got %q repeat=%v", clone.Name, clone.RepeatEnabled)
+	}
+	if len(clone.Rules) != len(original.Rules) {
+		t.Fatalf("Expected %d rules, got %d", len(original.Rules), len(clone.Rules))
+	}
+	for i, rule := range clone.Rules {
+		source := original.Rules[i]
+		if rule.ID == source.ID {
+			t.Errorf("Expected rule at position %d to get a new ID", rule.Position)
+		}
+		if rule.Position != source.Position || rule.EscalationDelay != source.EscalationDelay {
+			t.Errorf("Expected rule %d/%d, got %d/%d", source.Position, source.EscalationDelay, rule.Position, rule.EscalationDelay)
+		}
+		if len(rule.Targets) != len(source.Targets) {
+			t.Fatalf("Expected %d targets at position %d, got %d", len(source.Targets), rule.Position, len(rule.Targets))
+		}
+		sourceTargets := make(map[string]uuid.UUID, len(source.Targets))
+		for _, target := range source.Targets {
+			sourceTargets[fmt.Sprintf("%s/%s", target.TargetType, target.TargetID)] = target.ID
+		}
+		for _, target := range rule.Targets {
+			sourceID, ok := sourceTargets[fmt.Sprintf("%s/%s", target.TargetType, target.TargetID)]
+			if !ok {
+				t.Errorf("Unexpected target %s %s at position %d", target.TargetType, target.TargetID, rule.Position)
+			}
+			if target.ID == sourceID {
+				t.Errorf("Expected target at position %d to get a new ID", rule.Position)
+			}
+		}
+	}
+
+	// Editing the clone must leave the original untouched
+	if err := testServer.EscalationService.DeleteRule(ctx, clone.Rules[0].ID); err != nil {
+		t.Fatalf("Failed to delete cloned rule: %v", err)
+	}
+	if err := testServer.EscalationService.RemoveTarget(ctx, clone.Rules[1].Targets[0].ID); err != nil {
+		t.Fatalf("Failed to remove cloned target: %v", err)
+	}
+	after, _ := testServer.EscalationService.GetPolicyWithRules(ctx, policy.ID)
+	if len(after.Rules) != 2 || len(after.Rules[1].Targets) != 2 {
+		t.Error("Expected the original policy's rules and targets to be unchanged")
+	}
+}
+
+func TestEscalationPolicies_Clone_DefaultName(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, user.Organization.ID, "Platform Escalation")
+
+	resp := client.Post(fmt.Sprintf("/api/v1/escalation-policies/%s/clone", policy.ID), nil)
+	client.AssertStatus(resp, http.StatusCreated)
+
+	var clone domain.EscalationPolicyWithRules
+	client.ParseJSON(resp, &clone)
+
+	if clone.Name != "Platform Escalation (copy)" {
+		t.Errorf("Expected default name 'Platform Escalation (copy)', got %q", clone.Name)
+	}
+}
+
+func TestEscalationPolicies_Clone_OtherOrganization(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(other.AccessToken)
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, owner.Organization.ID, "Owner Policy")
+
+	resp := client.Post(fmt.Sprintf("/api/v1/escalation-policies/%s/clone", policy.ID), nil)
+	client.AssertStatus(resp, http.StatusNotFound)
+}
+
+// ============================================================================
+// GET /api/v1/escalation-policies/:id/preview
+// ============================================================================
+
+func TestEscalationPolicies_Preview_ExpandsTargets(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	onCall, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+	joinOrganization(t, ctx, orgID, onCall.User.ID)
+
+	channel, _ := testFixtures.CreateUniqueNotificationChannel(ctx, orgID)
+	schedule, _ := testFixtures.CreateUniqueSchedule(ctx, orgID)
+	addRotation(t, ctx, schedule.ID, onCall.User.ID, "2024-01-01")
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, orgID, "Critical Escalation")
+	_, err := testServer.EscalationService.ApplyPolicyConfig(ctx, orgID, policy.ID, &dto.EscalationPolicyConfigRequest{
+		Name: "Critical Escalation",
+		Rules: []dto.EscalationRuleConfig{
+			{Position: 1, EscalationDelay: 5, Targets: []dto.AddEscalationTargetRequest{
+				{TargetType: "user", TargetID: user.User.ID},
+			}},
+			{Position: 2, EscalationDelay: 10, Targets: []dto.AddEscalationTargetRequest{
+				{TargetType: "schedule", TargetID: schedule.ID},
+			}},
+			{Position: 3, EscalationDelay: 15, Targets: []dto.AddEscalationTargetRequest{
+				{TargetType: "user", TargetID: user.User.ID},
+				{TargetType: "schedule", TargetID: schedule.ID},
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to configure policy: %v", err)
+	}
+
+	resp := client.Get(fmt.Sprintf("/api/v1/escalation-policies/%s/preview", policy.ID))
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var preview dto.EscalationPolicyPreview
+	client.ParseJSON(resp, &preview)
+
+	if len(preview.Rules) != 3 {
+		t.Fatalf("Expected 3 rules, got %d", len(preview.Rules))
+	}
+
+	// Escalation waits on the first rule, then pages each later rule as the
+	// previous delay runs out
+	wantPageAfter := []string{"never", "300s", "900s"}
+	wantRecipients := [][]uuid.UUID{
+		{user.User.ID},
+		{onCall.User.ID},
+		{user.User.ID, onCall.User.ID},
+	}
+	for i, rule := range preview.Rules {
+		if got := formatSeconds(rule.PageAfterSeconds); got != wantPageAfter[i] {
+			t.Errorf("Rule %d: expected to be paged after %s, got %s", rule.Position, wantPageAfter[i], got)
+		}
+
+		var recipients []uuid.UUID
+		for _, recipient := range rule.Recipients {
+			recipients = append(recipients, recipient.UserID)
+			if len(recipient.Channels) != 1 || recipient.Channels[0].ID != channel.ID {
+				t.Errorf("Rule %d: expected %s to be paged through channel %s, got %v", rule.Position, recipient.Email, channel.ID, recipient.Channels)
+			}
+		}
+		if !sameUserIDs(recipients, wantRecipients[i]) {
+			t.Errorf("Rule %d: expected recipients %v, got %v", rule.Position, wantRecipients[i], recipients)
+		}
+	}
+	if recipient := preview.Rules[1].Recipients[0]; recipient.TargetType != "schedule" || recipient.TargetID != schedule.ID {
+		t.Errorf("Expected the on-call user to come from schedule %s, got %s %s", schedule.ID, recipient.TargetType, recipient.TargetID)
+	}
+}
+
+func TestEscalationPolicies_Preview_RepeatPagesFirstRule(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, orgID, "Repeating Escalation")
+	_, err := testServer.EscalationService.ApplyPolicyConfig(ctx, orgID, policy.ID, &dto.EscalationPolicyConfigRequest{
+		Name:          "Repeating Escalation",
+		RepeatEnabled: true,
+		Rules: []dto.EscalationRuleConfig{
+			{Position: 1, EscalationDelay: 5, Targets: []dto.AddEscalationTargetRequest{
+				{TargetType: "user", TargetID: user.User.ID},
+			}},
+			{Position: 2, EscalationDelay: 10, Targets: []dto.AddEscalationTargetRequest{
+				{TargetType: "user", TargetID: user.User.ID},
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to configure policy: %v", err)
+	}
+
+	resp := client.Get(fmt.Sprintf("/api/v1/escalation-policies/%s/preview", policy.ID))
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var preview dto.EscalationPolicyPreview
+	client.ParseJSON(resp, &preview)
+
+	if got := formatSeconds(preview.Rules[0].PageAfterSeconds); got != "900s" {
+		t.Errorf("Expected the first rule to be paged when the policy repeats after 900s, got %s", got)
+	}
+}
+
+func TestEscalationPolicies_Preview_OtherOrganization(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(other.AccessToken)
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, owner.Organization.ID, "Owner Policy")
+
+	resp := client.Get(fmt.Sprintf("/api/v1/escalation-policies/%s/preview", policy.ID))
+	client.AssertStatus(resp, http.StatusNotFound)
+}
+
+// sameUserIDs reports whether both lists hold the same users in any order
+func sameUserIDs(a, b []uuid.UUID) bool {
+	byString := func(x, y uuid.UUID) int { return strings.Compare(x.String(), y.String()) }
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.SortFunc(a, byString)
+	slices.SortFunc(b, byString)
+	return slices.Equal(a, b)
+}
+
+// formatSeconds prints an optional number of seconds for test failures
+func formatSeconds(seconds *int) string {
+	if seconds == nil {
+		return "never"
+	}
+	return strconv.Itoa(*seconds) + "s"
+}
+
+// ============================================================================
+// Escalation policy selection for new alerts
+// ============================================================================
+
+// createMatchingPolicy creates a policy through the API that applies to alerts
+// matching all the given field/value pairs
+func createMatchingPolicy(t *testing.T, client *testutils.TestClient, name string, fieldValues ...string) *domain.EscalationPolicy {
+	t.Helper()
+	conditions := []map[string]string{}
+	for i := 0; i+1 < len(fieldValues); i += 2 {
+		conditions = append(conditions, map[string]string{
+			"field":    fieldValues[i],
+			"operator": "equals",
+			"value":    fieldValues[i+1],
+		})
+	}
+
+	resp := client.Post("/api/v1/escalation-policies", map[string]interface{}{
+		"name":       name,
+		"conditions": map[string]interface{}{"match": "all", "conditions": conditions},
+	})
+	client.AssertStatus(resp, http.StatusCreated)
+
+	var policy domain.EscalationPolicy
+	client.ParseJSON(resp, &policy)
+	return &policy
+}
+
+func createAlertFrom(t *testing.T, orgID uuid.UUID, source, priority string, policyID *uuid.UUID) *domain.Alert {
+	t.Helper()
+	alert, err := testServer.AlertService.CreateAlert(context.Background(), orgID, &dto.CreateAlertRequest{
+		Source:             source,
+		Priority:           priority,
+		Message:            "High error rate",
+		EscalationPolicyID: policyID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+	return alert
+}
+
+func assertAlertPolicy(t *testing.T, alert *domain.Alert, want *uuid.UUID) {
+	t.Helper()
+	switch {
+	case want == nil && alert.EscalationPolicyID != nil:
+		t.Errorf("Expected no escalation policy, got %s", alert.EscalationPolicyID)
+	case want != nil && (alert.EscalationPolicyID == nil || *alert.EscalationPolicyID != *want):
+		t.Errorf("Expected escalation policy %s, got %v", want, alert.EscalationPolicyID)
+	}
+}
+
+func TestEscalationPolicies_SelfMatching_SelectsMostSpecificPolicy(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	critical := createMatchingPolicy(t, client, "Critical", "priority", "P1")
+	platform := createMatchingPolicy(t, client, "Platform", "priority", "P1", "source", "prometheus")
+	// A policy without conditions is never selected automatically
+	testFixtures.CreateEscalationPolicy(ctx, orgID, "Manual")
+
+	assertAlertPolicy(t, createAlertFrom(t, orgID, "prometheus", "P1", nil), &platform.ID)
+	assertAlertPolicy(t, createAlertFrom(t, orgID, "grafana", "P1", nil), &critical.ID)
+	assertAlertPolicy(t, createAlertFrom(t, orgID, "prometheus", "P3", nil), nil)
+}
+
+func TestEscalationPolicies_SelfMatching_Precedence(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	platform := createMatchingPolicy(t, client, "Platform", "source", "prometheus")
+	routed, _ := testFixtures.CreateEscalationPolicy(ctx, orgID, "Routed")
+	explicit, _ := testFixtures.CreateEscalationPolicy(ctx, orgID, "Explicit")
+
+	// Policy conditions apply when no routing rule assigns a policy
+	assertAlertPolicy(t, createAlertFrom(t, orgID, "prometheus", "P2", nil), &platform.ID)
+
+	_, err := testDB.Exec(`
+		INSERT INTO alert_routing_rules (id, organization_id, name, priority, conditions, actions, enabled)
+		VALUES ($1, $2, 'Prometheus', 0, $3, $4, true)
+	`, uuid.New(), orgID,
+		`{"match":"all","conditions":[{"field":"source","operator":"equals","value":"prometheus"}]}`,
+		fmt.Sprintf(`{"assign_escalation_policy_id":"%s"}`, routed.ID))
+	if err != nil {
+		t.Fatalf("Failed to create routing rule: %v", err)
+	}
+
+	// A routing rule wins over policy conditions
+	assertAlertPolicy(t, createAlertFrom(t, orgID, "prometheus", "P2", nil), &routed.ID)
+
+	// An explicit assignment wins over both
+	assertAlertPolicy(t, createAlertFrom(t, orgID, "prometheus", "P2", &explicit.ID), &explicit.ID)
+}
+
+func TestEscalationPolicies_SelfMatching_RejectsOtherOrganizationPolicy(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	foreign, _ := testFixtures.CreateEscalationPolicy(ctx, other.Organization.ID, "Foreign")
+
+	_, err := testServer.AlertService.CreateAlert(ctx, user.Organization.ID, &dto.CreateAlertRequest{
+		Source:             "prometheus",
+		Priority:           "P1",
+		Message:            "High error rate",
+		EscalationPolicyID: &foreign.ID,
+	})
+	if !errors.Is(err, domain.ErrEscalationPolicyNotFound) {
+		t.Errorf("Expected ErrEscalationPolicyNotFound, got %v", err)
+	}
+}
+
+func TestEscalationPolicies_Create_InvalidConditions(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Post("/api/v1/escalation-policies", map[string]interface{}{
+		"name":       "Broken",
+		"conditions": []string{"priority=P1"},
+	})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+// ============================================================================
+// Escalation worker
+// ============================================================================
+
+// startEscalatingAlert creates an alert on a three-rule policy and runs the
+// worker once so escalation has moved past the first rule
+func startEscalatingAlert(t *testing.T, ctx context.Context, orgID, userID uuid.UUID) *domain.Alert {
+	t.Helper()
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, orgID, "Paging Policy")
+	for i := 1; i <= 3; i++ {
+		if _, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: i, EscalationDelay: 5}); err != nil {
+			t.Fatalf("Failed to create rule: %v", err)
+		}
+	}
+	alert, _ := testFixtures.CreateAlert(ctx, orgID, "Disk full")
+	if err := testServer.AlertService.AssignAlert(ctx, alert.ID, orgID, userID, &dto.AssignAlertRequest{EscalationPolicyID: &policy.ID}); err != nil {
+		t.Fatalf("Failed to assign escalation policy: %v", err)
+	}
+
+	advanceEscalationWorker(t, ctx, alert.ID)
+	if level, eventType, _ := escalationState(t, ctx, alert.ID); level != 1 || eventType != "triggered" {
+		t.Fatalf("Expected escalation at level 1, got level %d (%s)", level, eventType)
+	}
+
+	return alert
+}
+
+// advanceEscalationWorker makes the alert's escalation due and runs the worker
+func advanceEscalationWorker(t *testing.T, ctx context.Context, alertID uuid.UUID) {
+	t.Helper()
+
+	makeEscalationDue(t, ctx, alertID)
+	if err := testServer.EscalationService.ProcessPendingEscalations(ctx, 10); err != nil {
+		t.Fatalf("Failed to process escalations: %v", err)
+	}
+}
+
+func makeEscalationDue(t *testing.T, ctx context.Context, alertID uuid.UUID) {
+	t.Helper()
+
+	if _, err := testDB.ExecContext(ctx,
+		`UPDATE alert_escalation_events SET next_escalation_at = NOW() - INTERVAL '1 minute' WHERE alert_id = $1 AND event_type = 'triggered'`,
+		alertID,
+	); err != nil {
+		t.Fatalf("Failed to make escalation due: %v", err)
+	}
+}
+
+func escalationState(t *testing.T, ctx context.Context, alertID uuid.UUID) (int, string, *time.Time) {
+	t.Helper()
+
+	var level int
+	var eventType string
+	var nextEscalationAt *time.Time
+	err := testDB.QueryRowContext(ctx, `
+		SELECT current_level, event_type, next_escalation_at
+		FROM alert_escalation_events WHERE alert_id = $1
+		ORDER BY created_at DESC LIMIT 1
+	`, alertID).Scan(&level, &eventType, &nextEscalationAt)
+	if err != nil {
+		t.Fatalf("Failed to get escalation event: %v", err)
+	}
+	return level, eventType, nextEscalationAt
+}
+
+func TestEscalation_SubMinuteDelay(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, orgID, "P1 Paging")
+	path := fmt.Sprintf("/api/v1/escalation-policies/%s/rules", policy.ID)
+
+	// A delay is required in one unit or the other
+	resp := client.Post(path, map[string]interface{}{"position": 1})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	resp = client.Post(path, map[string]interface{}{"position": 1, "delay_seconds": 30})
+	client.ExpectStatus(resp, http.StatusCreated)
+	var rule domain.EscalationRule
+	client.ParseJSON(resp, &rule)
+	if rule.DelaySeconds == nil || *rule.DelaySeconds != 30 {
+		t.Fatalf("Expected a 30 second delay, got %v", rule.DelaySeconds)
+	}
+
+	resp = client.Post(path, map[string]interface{}{"position": 2, "escalation_delay": 5, "delay_seconds": 15})
+	client.ExpectStatus(resp, http.StatusCreated)
+
+	// expectNextEscalation checks the escalation is due delay after a moment
+	// between before and now
+	expectNextEscalation := func(alertID uuid.UUID, level int, before time.Time, delay time.Duration) {
+		t.Helper()
+		gotLevel, _, next := escalationState(t, ctx, alertID)
+		if gotLevel != level || next == nil {
+			t.Fatalf("Expected a pending escalation at level %d, got level %d (%v)", level, gotLevel, next)
+		}
+		earliest := before.Add(delay).Truncate(time.Microsecond)
+		latest := time.Now().Add(delay)
+		if next.Before(earliest) || next.After(latest) {
+			t.Errorf("Expected level %d to escalate between %s and %s, got %s", level, earliest, latest, next)
+		}
+	}
+
+	alert, _ := testFixtures.CreateAlert(ctx, orgID, "Checkout down")
+	before := time.Now()
+	if err := testServer.AlertService.AssignAlert(ctx, alert.ID, orgID, user.User.ID, &dto.AssignAlertRequest{EscalationPolicyID: &policy.ID}); err != nil {
+		t.Fatalf("Failed to assign escalation policy: %v", err)
+	}
+	expectNextEscalation(alert.ID, 0, before, 30*time.Second)
+
+	before = time.Now()
+	advanceEscalationWorker(t, ctx, alert.ID)
+	expectNextEscalation(alert.ID, 1, before, 15*time.Second)
+}
+
+func TestEscalation_AcknowledgeHaltsEscalation(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	alert := startEscalatingAlert(t, ctx, user.Organization.ID, user.User.ID)
+
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/acknowledge", alert.ID), nil)
+	client.ExpectStatus(resp, http.StatusOK)
+
+	level, eventType, next := escalationState(t, ctx, alert.ID)
+	if eventType != string(domain.EscalationEventAcknowledged) || next != nil {
+		t.Fatalf("Expected acknowledged escalation with no next escalation, got %s at %v", eventType, next)
+	}
+
+	// Even a stale due event is not escalated further for an acknowledged alert
+	if _, err := testDB.ExecContext(ctx, `UPDATE alert_escalation_events SET event_type = 'triggered' WHERE alert_id = $1`, alert.ID); err != nil {
+		t.Fatalf("Failed to reset escalation event: %v", err)
+	}
+	advanceEscalationWorker(t, ctx, alert.ID)
+
+	if after, _, _ := escalationState(t, ctx, alert.ID); after != level {
+		t.Errorf("Expected escalation to stay at level %d, got %d", level, after)
+	}
+}
+
+func TestEscalation_CloseStopsEscalation(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	alert := startEscalatingAlert(t, ctx, user.Organization.ID, user.User.ID)
+
+	if err := testServer.AlertService.CloseAlert(ctx, alert.ID, user.Organization.ID, user.User.ID, "fixed"); err != nil {
+		t.Fatalf("Failed to close alert: %v", err)
+	}
+
+	_, eventType, next := escalationState(t, ctx, alert.ID)
+	if eventType != string(domain.EscalationEventStopped) || next != nil {
+		t.Errorf("Expected stopped escalation with no next escalation, got %s at %v", eventType, next)
+	}
+}
+
+func TestEscalation_SnoozedAlertIsNotEscalated(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	alert := startEscalatingAlert(t, ctx, user.Organization.ID, user.User.ID)
+
+	if err := testServer.AlertService.SnoozeAlert(ctx, alert.ID, user.Organization.ID, user.User.ID, time.Now().Add(time.Hour), nil); err != nil {
+		t.Fatalf("Failed to snooze alert: %v", err)
+	}
+	advanceEscalationWorker(t, ctx, alert.ID)
+
+	level, eventType, _ := escalationState(t, ctx, alert.ID)
+	if level != 1 || eventType != "triggered" {
+		t.Errorf("Expected escalation paused at level 1, got level %d (%s)", level, eventType)
+	}
+}
+
+func TestEscalation_UnacknowledgeRearmsEscalation(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	alert := startEscalatingAlert(t, ctx, user.Organization.ID, user.User.ID)
+
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/acknowledge", alert.ID), nil)
+	client.ExpectStatus(resp, http.StatusOK)
+
+	resp = client.Post(fmt.Sprintf("/api/v1/alerts/%s/unacknowledge", alert.ID), nil)
+	client.ExpectStatus(resp, http.StatusOK)
+
+	reopened, _ := testServer.AlertService.GetAlert(ctx, alert.ID, user.Organization.ID)
+	if reopened.Status != domain.AlertStatusOpen || reopened.AcknowledgedBy != nil || reopened.AcknowledgedAt != nil {
+		t.Errorf("Expected an open, unacknowledged alert, got %s", reopened.Status)
+	}
+
+	level, eventType, next := escalationState(t, ctx, alert.ID)
+	if level != 0 || eventType != "triggered" || next == nil {
+		t.Fatalf("Expected escalation re-armed at the first rule, got level %d (%s) next %v", level, eventType, next)
+	}
+
+	notes, _ := testServer.AlertService.ListNotes(ctx, alert.ID, user.Organization.ID)
+	if len(notes) != 1 {
+		t.Errorf("Expected a note recording the unacknowledge, got %d notes", len(notes))
+	}
+
+	// The worker escalates it again
+	advanceEscalationWorker(t, ctx, alert.ID)
+	if level, _, _ := escalationState(t, ctx, alert.ID); level != 1 {
+		t.Errorf("Expected escalation to advance to level 1, got %d", level)
+	}
+}
+
+func TestEscalation_Unacknowledge_RequiresAcknowledgedAlert(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	open, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Still open")
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/unacknowledge", open.ID), nil)
+	client.ExpectStatus(resp, http.StatusConflict)
+
+	closed, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Already fixed")
+	resp = client.Post(fmt.Sprintf("/api/v1/alerts/%s/acknowledge", closed.ID), nil)
+	client.ExpectStatus(resp, http.StatusOK)
+	if err := testServer.AlertService.CloseAlert(ctx, closed.ID, user.Organization.ID, user.User.ID, "fixed"); err != nil {
+		t.Fatalf("Failed to close alert: %v", err)
+	}
+	resp = client.Post(fmt.Sprintf("/api/v1/alerts/%s/unacknowledge", closed.ID), nil)
+	client.ExpectStatus(resp, http.StatusConflict)
+
+	resp = client.Post(fmt.Sprintf("/api/v1/alerts/%s/unacknowledge", uuid.New()), nil)
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+// ============================================================================
+// Escalation notification dedup
+// ============================================================================
+
+// escalationPager records the rules an escalation service pages for
+type escalationPager struct {
+	mu    sync.Mutex
+	rules []uuid.UUID
+}
+
+func (p *escalationPager) NotifyAlertCreated(context.Context, *domain.Alert) error {
+	return nil
+}
+
+func (p *escalationPager) NotifyAlertAcknowledged(context.Context, *domain.Alert, uuid.UUID) error {
+	return nil
+}
+
+func (p *escalationPager) NotifyAlertClosed(context.Context, *domain.Alert, uuid.UUID, string) error {
+	return nil
+}
+
+func (p *escalationPager) NotifyAlertUnsnoozed(context.Context, *domain.Alert) error {
+	return nil
+}
+
+func (p *escalationPager) NotifyAlertEscalated(_ context.Context, _ *domain.Alert, rule *domain.EscalationRule, _ []domain.EscalationTarget) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rules = append(p.rules, rule.ID)
+	return nil
+}
+
+func (p *escalationPager) pages() []uuid.UUID {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.rules)
+}
+
+// pagingEscalationService returns an escalation service whose pages are
+// recorded by the returned pager
+func pagingEscalationService() (*service.EscalationService, *escalationPager) {
+	db := &postgres.DB{DB: testDB.DB}
+	pager := &escalationPager{}
+	svc := service.NewEscalationService(
+		postgres.NewEscalationPolicyRepository(db),
+		postgres.NewAlertRepository(db),
+		postgres.NewOrganizationRepository(db),
+		postgres.NewTeamRepository(db),
+		postgres.NewScheduleRepository(db),
+		pager,
+	)
+	return svc, pager
+}
+
+// startPagingAlert creates an alert on a policy of rules that each page the
+// user and returns it with the rules in order
+func startPagingAlert(t *testing.T, ctx context.Context, orgID, userID uuid.UUID, rules int) (*domain.Alert, []uuid.UUID) {
+	t.Helper()
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, orgID, "Paging Policy")
+	var ruleIDs []uuid.UUID
+	for i := 1; i <= rules; i++ {
+		rule, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: i, EscalationDelay: 5})
+		if err != nil {
+			t.Fatalf("Failed to create rule: %v", err)
+		}
+		if _, err := testServer.EscalationService.AddTarget(ctx, orgID, rule.ID, &dto.AddEscalationTargetRequest{
+			TargetType: string(domain.EscalationTargetTypeUser),
+			TargetID:   userID,
+		}); err != nil {
+			t.Fatalf("Failed to add target: %v", err)
+		}
+		ruleIDs = append(ruleIDs, rule.ID)
+	}
+
+	alert, _ := testFixtures.CreateAlert(ctx, orgID, "Disk full")
+	if err := testServer.AlertService.AssignAlert(ctx, alert.ID, orgID, userID, &dto.AssignAlertRequest{EscalationPolicyID: &policy.ID}); err != nil {
+		t.Fatalf("Failed to assign escalation policy: %v", err)
+	}
+	return alert, ruleIDs
+}
+
+func TestEscalation_ProcessingTwiceNotifiesOncePerStep(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	alert, rules := startPagingAlert(t, ctx, user.Organization.ID, user.User.ID, 2)
+	svc, pager := pagingEscalationService()
+
+	makeEscalationDue(t, ctx, alert.ID)
+	for i := 0; i < 2; i++ {
+		if err := svc.ProcessPendingEscalations(ctx, 10); err != nil {
+			t.Fatalf("Failed to process escalations: %v", err)
+		}
+	}
+
+	if pages := pager.pages(); !slices.Equal(pages, rules[1:2]) {
+		t.Errorf("Expected one page for the second rule, got %v", pages)
+	}
+}
+
+func TestEscalation_ReprocessedStepIsNotPagedAgain(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	alert, rules := startPagingAlert(t, ctx, user.Organization.ID, user.User.ID, 3)
+	svc, pager := pagingEscalationService()
+
+	makeEscalationDue(t, ctx, alert.ID)
+	if err := svc.ProcessPendingEscalations(ctx, 10); err != nil {
+		t.Fatalf("Failed to process escalations: %v", err)
+	}
+
+	// Put the escalation back on the first rule, as a second worker sees it
+	// when its claim lapsed while the first was still paging
+	if _, err := testDB.ExecContext(ctx,
+		`UPDATE alert_escalation_events SET current_level = 0, rule_id = $2 WHERE alert_id = $1`,
+		alert.ID, rules[0],
+	); err != nil {
+		t.Fatalf("Failed to rewind escalation: %v", err)
+	}
+	makeEscalationDue(t, ctx, alert.ID)
+	if err := svc.ProcessPendingEscalations(ctx, 10); err != nil {
+		t.Fatalf("Failed to process escalations: %v", err)
+	}
+
+	if pages := pager.pages(); !slices.Equal(pages, rules[1:2]) {
+		t.Fatalf("Expected the second rule to be paged once, got %v", pages)
+	}
+	if level, _, _ := escalationState(t, ctx, alert.ID); level != 1 {
+		t.Errorf("Expected escalation to stay at level 1, got %d", level)
+	}
+
+	// The next step still pages
+	makeEscalationDue(t, ctx, alert.ID)
+	if err := svc.ProcessPendingEscalations(ctx, 10); err != nil {
+		t.Fatalf("Failed to process escalations: %v", err)
+	}
+	if pages := pager.pages(); !slices.Equal(pages, rules[1:3]) {
+		t.Errorf("Expected the third rule to be paged next, got %v", pages)
+	}
+}
+
+// ============================================================================
+// Team escalation targets
+// ============================================================================
+
+func TestEscalation_TeamTargetNotifiesEveryMember(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	orgID := owner.Organization.ID
+	team, _ := testFixtures.CreateUniqueTeam(ctx, orgID)
+
+	// Each member prefers the same channel; the second channel must not be
+	// used for a team page
+	preferred := createFlakyWebhookChannel(t, ctx, orgID, 0)
+	createFlakyWebhookChannel(t, ctx, orgID, 0)
+
+	members := []uuid.UUID{owner.User.ID}
+	for i := 0; i < 2; i++ {
+		user, _ := testFixtures.CreateUniqueUser(ctx)
+		joinOrganization(t, ctx, orgID, user.User.ID)
+		members = append(members, user.User.ID)
+	}
+	for _, userID := range members {
+		userID := userID
+		if err := testServer.TeamService.AddMember(ctx, team.ID, &dto.AddTeamMemberRequest{UserID: &userID, Role: "member"}); err != nil {
+			t.Fatalf("Failed to add team member: %v", err)
+		}
+		if _, err := testServer.NotificationService.CreatePreference(ctx, userID, &dto.CreateUserNotificationPreferenceRequest{
+			ChannelID: preferred.ID,
+			IsEnabled: true,
+		}); err != nil {
+			t.Fatalf("Failed to create notification preference: %v", err)
+		}
+	}
+
+	now := time.Now()
+	inDND := members[2]
+	setDNDSettings(t, ctx, inDND, nil, []domain.DNDOverride{
+		{Start: now.Add(-time.Hour), End: now.Add(time.Hour)},
+	}, false)
+
+	alert, _ := testFixtures.CreateAlert(ctx, orgID, "Checkout errors")
+	targets := []domain.EscalationTarget{
+		{TargetType: domain.EscalationTargetTypeTeam, TargetID: team.ID},
+	}
+	if err := testServer.AlertNotifier.NotifyAlertEscalated(ctx, alert, &domain.EscalationRule{}, targets); err != nil {
+		t.Fatalf("Failed to notify escalation: %v", err)
+	}
+
+	logs, err := testServer.NotificationService.ListLogsByAlert(ctx, alert.ID)
+	if err != nil {
+		t.Fatalf("Failed to list notification logs: %v", err)
+	}
+	if len(logs) != len(members) {
+		t.Fatalf("Expected one notification per member (%d), got %d", len(members), len(logs))
+	}
+
+	sent := 0
+	for _, log := range logs {
+		if log.ChannelID != preferred.ID {
+			t.Errorf("Expected members to be paged through their preferred channel, got %s", log.ChannelID)
+		}
+		switch {
+		case log.UserID != nil && *log.UserID == inDND:
+			if log.Status != domain.NotificationStatusSuppressedDND {
+				t.Errorf("Expected the member in DND to be suppressed, got %s", log.Status)
+			}
+		case log.Status == domain.NotificationStatusSent:
+			sent++
+		}
+	}
+	if sent != len(members)-1 {
+		t.Errorf("Expected %d members to be paged, got %d", len(members)-1, sent)
+	}
+}
+
+func TestEscalation_TeamTargetSkipsDeactivatedMembers(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	orgID := owner.Organization.ID
+	team, _ := testFixtures.CreateUniqueTeam(ctx, orgID)
+	testFixtures.CreateNotificationChannel(ctx, orgID, "Email")
+
+	inactive, _ := testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, orgID, inactive.User.ID)
+	if err := testServer.TeamService.AddMember(ctx, team.ID, &dto.AddTeamMemberRequest{UserID: &inactive.User.ID, Role: "member"}); err != nil {
+		t.Fatalf("Failed to add team member: %v", err)
+	}
+	if _, err := testDB.ExecContext(ctx, `UPDATE users SET is_active = false WHERE id = $1`, inactive.User.ID); err != nil {
+		t.Fatalf("Failed to deactivate user: %v", err)
+	}
+
+	alert, _ := testFixtures.CreateAlert(ctx, orgID, "Checkout errors")
+	targets := []domain.EscalationTarget{
+		{TargetType: domain.EscalationTargetTypeTeam, TargetID: team.ID},
+	}
+	if err := testServer.AlertNotifier.NotifyAlertEscalated(ctx, alert, &domain.EscalationRule{}, targets); err != nil {
+		t.Fatalf("Failed to notify escalation: %v", err)
+	}
+
+	logs, _ := testServer.NotificationService.ListLogsByAlert(ctx, alert.ID)
+	if len(logs) != 0 {
+		t.Errorf("Expected deactivated members not to be paged, got %d notifications", len(logs))
+	}
+}
+
+// ============================================================================
+// Schedule escalation targets
+// ============================================================================
+
+// threePersonSchedule returns a schedule with a daily rotation of three
+// members of the organization, the owner first
+func threePersonSchedule(t *testing.T, ctx context.Context, owner *testutils.TestUser) (*domain.Schedule, []uuid.UUID) {
+	t.Helper()
+
+	members := []uuid.UUID{owner.User.ID}
+	for i := 0; i < 2; i++ {
+		user, _ := testFixtures.CreateUniqueUser(ctx)
+		joinOrganization(t, ctx, owner.Organization.ID, user.User.ID)
+		members = append(members, user.User.ID)
+	}
+
+	schedule, _ := testFixtures.CreateUniqueSchedule(ctx, owner.Organization.ID)
+	rotation, err := testServer.ScheduleService.CreateRotation(ctx, schedule.ID, &dto.CreateRotationRequest{
+		Name:           "Daily",
+		RotationType:   "daily",
+		RotationLength: 1,
+		StartDate:      "2024-01-01",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rotation: %v", err)
+	}
+	for i, userID := range members {
+		if _, err := testServer.ScheduleService.AddParticipant(ctx, rotation.ID, &dto.AddParticipantRequest{UserID: userID, Position: i}); err != nil {
+			t.Fatalf("Failed to add participant: %v", err)
+		}
+	}
+	return schedule, members
+}
+
+// pagedUsers notifies a schedule target for a new alert and returns the users
+// notification logs were written for
+func pagedUsers(t *testing.T, ctx context.Context, orgID uuid.UUID, target domain.EscalationTarget) []uuid.UUID {
+	t.Helper()
+
+	alert, _ := testFixtures.CreateAlert(ctx, orgID, "Checkout errors")
+	if err := testServer.AlertNotifier.NotifyAlertEscalated(ctx, alert, &domain.EscalationRule{}, []domain.EscalationTarget{target}); err != nil {
+		t.Fatalf("Failed to notify escalation: %v", err)
+	}
+
+	logs, err := testServer.NotificationService.ListLogsByAlert(ctx, alert.ID)
+	if err != nil {
+		t.Fatalf("Failed to list notification logs: %v", err)
+	}
+	var users []uuid.UUID
+	for _, log := range logs {
+		if log.UserID != nil {
+			users = append(users, *log.UserID)
+		}
+	}
+	return users
+}
+
+func TestEscalation_ScheduleTargetPagesOnCallByDefault(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(owner.AccessToken)
+	orgID := owner.Organization.ID
+	testFixtures.CreateNotificationChannel(ctx, orgID, "Email")
+	schedule, _ := threePersonSchedule(t, ctx, owner)
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, orgID, "Test Policy")
+	rule, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: 1, EscalationDelay: 5})
+	if err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+	resp := client.Post(fmt.Sprintf("/api/v1/escalation-policies/%s/rules/%s/targets", policy.ID, rule.ID), map[string]interface{}{
+		"target_type": "schedule",
+		"target_id":   schedule.ID.String(),
+	})
+	client.ExpectStatus(resp, http.StatusCreated)
+	var target domain.EscalationTarget
+	client.ParseJSON(resp, &target)
+	if target.ScheduleMode != domain.ScheduleTargetModeOnCall {
+		t.Fatalf("Expected schedule targets to default to %s, got %q", domain.ScheduleTargetModeOnCall, target.ScheduleMode)
+	}
+
+	onCall, err := testServer.ScheduleService.GetOnCallUser(ctx, schedule.ID, time.Now())
+	if err != nil || onCall == nil {
+		t.Fatalf("Failed to get on-call user: %v", err)
+	}
+
+	paged := pagedUsers(t, ctx, orgID, target)
+	if !sameUserIDs(paged, []uuid.UUID{onCall.UserID}) {
+		t.Errorf("Expected only the on-call user %s to be paged, got %v", onCall.UserID, paged)
+	}
+}
+
+func TestEscalation_ScheduleTargetAllMembers(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(owner.AccessToken)
+	orgID := owner.Organization.ID
+	testFixtures.CreateNotificationChannel(ctx, orgID, "Email")
+	schedule, members := threePersonSchedule(t, ctx, owner)
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, orgID, "Test Policy")
+	rule, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: 1, EscalationDelay: 5})
+	if err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+	resp := client.Post(fmt.Sprintf("/api/v1/escalation-policies/%s/rules/%s/targets", policy.ID, rule.ID), map[string]interface{}{
+		"target_type":   "schedule",
+		"target_id":     schedule.ID.String(),
+		"schedule_mode": "all_members",
+	})
+	client.ExpectStatus(resp, http.StatusCreated)
+
+	targets, err := testServer.EscalationService.ListTargets(ctx, rule.ID)
+	if err != nil || len(targets) != 1 {
+		t.Fatalf("Failed to list targets: %v", err)
+	}
+	if targets[0].ScheduleMode != domain.ScheduleTargetModeAllMembers {
+		t.Fatalf("Expected the stored target to be %s, got %q", domain.ScheduleTargetModeAllMembers, targets[0].ScheduleMode)
+	}
+
+	paged := pagedUsers(t, ctx, orgID, *targets[0])
+	if !sameUserIDs(paged, members) {
+		t.Errorf("Expected all %d rotation members to be paged, got %v", len(members), paged)
+	}
+}
+
+func TestEscalationPolicies_AddTarget_InvalidScheduleMode(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Primary")
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, user.Organization.ID, "Test Policy")
+	rule, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: 1, EscalationDelay: 5})
+	if err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+	path := fmt.Sprintf("/api/v1/escalation-policies/%s/rules/%s/targets", policy.ID, rule.ID)
+
+	resp := client.Post(path, map[string]interface{}{
+		"target_type":   "schedule",
+		"target_id":     schedule.ID.String(),
+		"schedule_mode": "everyone",
+	})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	// Only schedule targets have a mode
+	resp = client.Post(path, map[string]interface{}{
+		"target_type":   "user",
+		"target_id":     user.User.ID.String(),
+		"schedule_mode": "all_members",
+	})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+// ============================================================================
+// Business hours
+// ============================================================================
+
+// businessHoursPolicy creates a policy with weekday business hours in New York.
+// Its first rule always applies, then it pages user during business hours and
+// night after hours.
+func businessHoursPolicy(t *testing.T, ctx context.Context, client *testutils.TestClient, orgID, user, night uuid.UUID) *domain.EscalationPolicy {
+	t.Helper()
+
+	var weekly []map[string]string
+	for _, day := range []string{"monday", "tuesday", "wednesday", "thursday", "friday"} {
+		weekly = append(weekly, map[string]string{"day": day, "start": "09:00", "end": "17:00"})
+	}
+	resp := client.Post("/api/v1/escalation-policies", map[string]interface{}{
+		"name":           "Office Hours",
+		"business_hours": map[string]interface{}{"timezone": "America/New_York", "weekly": weekly},
+	})
+	client.ExpectStatus(resp, http.StatusCreated)
+	var policy domain.EscalationPolicy
+	client.ParseJSON(resp, &policy)
+
+	_, err := testServer.EscalationService.ApplyPolicyConfig(ctx, orgID, policy.ID, &dto.EscalationPolicyConfigRequest{
+		Name: "Office Hours",
+		Rules: []dto.EscalationRuleConfig{
+			{Position: 1, EscalationDelay: 5, Targets: []dto.AddEscalationTargetRequest{
+				{TargetType: "user", TargetID: user},
+			}},
+			{Position: 2, EscalationDelay: 30, Hours: "business_hours", Targets: []dto.AddEscalationTargetRequest{
+				{TargetType: "user", TargetID: user},
+			}},
+			{Position: 3, EscalationDelay: 10, Hours: "after_hours", Targets: []dto.AddEscalationTargetRequest{
+				{TargetType: "user", TargetID: night},
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to configure policy: %v", err)
+	}
+	return &policy
+}
+
+func TestEscalationPolicies_BusinessHours_PreviewDependsOnTime(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	night, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+	joinOrganization(t, ctx, orgID, night.User.ID)
+
+	policy := businessHoursPolicy(t, ctx, client, orgID, user.User.ID, night.User.ID)
+
+	tests := []struct {
+		name            string
+		at              string
+		inBusinessHours bool
+		wantApplies     []bool
+		wantPageAfter   []string
+	}{
+		// Wednesday 10:00 in New York
+		{"business hours", "2024-01-03T15:00:00Z", true, []bool{true, true, false}, []string{"never", "300s", "never"}},
+		// Wednesday 08:00 in New York, though business hours in UTC
+		{"after hours", "2024-01-03T13:00:00Z", false, []bool{true, false, true}, []string{"never", "never", "300s"}},
+		// Saturday 10:00 in New York
+		{"weekend", "2024-01-06T15:00:00Z", false, []bool{true, false, true}, []string{"never", "never", "300s"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := client.Get(fmt.Sprintf("/api/v1/escalation-policies/%s/preview?at=%s", policy.ID, tt.at))
+			client.ExpectStatus(resp, http.StatusOK)
+
+			var preview dto.EscalationPolicyPreview
+			client.ParseJSON(resp, &preview)
+
+			if preview.InBusinessHours == nil || *preview.InBusinessHours != tt.inBusinessHours {
+				t.Errorf("Expected in_business_hours %v, got %v", tt.inBusinessHours, preview.InBusinessHours)
+			}
+			if len(preview.Rules) != 3 {
+				t.Fatalf("Expected 3 rules, got %d", len(preview.Rules))
+			}
+			for i, rule := range preview.Rules {
+				if rule.Applies != tt.wantApplies[i] {
+					t.Errorf("Rule %d: expected applies %v, got %v", rule.Position, tt.wantApplies[i], rule.Applies)
+				}
+				if got := formatSeconds(rule.PageAfterSeconds); got != tt.wantPageAfter[i] {
+					t.Errorf("Rule %d: expected to be paged after %s, got %s", rule.Position, tt.wantPageAfter[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestEscalation_AfterHoursSkipsBusinessHoursRules(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	policy := businessHoursPolicy(t, ctx, client, orgID, user.User.ID, user.User.ID)
+
+	// Without any business hours every moment is after hours, whenever the
+	// worker runs
+	resp := client.Patch(fmt.Sprintf("/api/v1/escalation-policies/%s", policy.ID), map[string]interface{}{
+		"business_hours": map[string]interface{}{"timezone": "UTC", "weekly": []interface{}{}},
+	})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	alert, _ := testFixtures.CreateAlert(ctx, orgID, "Disk full")
+	if err := testServer.AlertService.AssignAlert(ctx, alert.ID, orgID, user.User.ID, &dto.AssignAlertRequest{EscalationPolicyID: &policy.ID}); err != nil {
+		t.Fatalf("Failed to assign escalation policy: %v", err)
+	}
+
+	advanceEscalationWorker(t, ctx, alert.ID)
+	if level, eventType, _ := escalationState(t, ctx, alert.ID); level != 2 || eventType != "triggered" {
+		t.Fatalf("Expected escalation to skip to the after-hours rule at level 2, got level %d (%s)", level, eventType)
+	}
+
+	advanceEscalationWorker(t, ctx, alert.ID)
+	if _, eventType, _ := escalationState(t, ctx, alert.ID); eventType != "completed" {
+		t.Errorf("Expected escalation to complete after the last rule, got %s", eventType)
+	}
+}
+
+func TestEscalationPolicies_BusinessHours_DefaultTimezone(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Patch("/api/v1/organizations/settings", map[string]interface{}{"default_timezone": "Europe/Berlin"})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	resp = client.Post("/api/v1/escalation-policies", map[string]interface{}{
+		"name": "Berlin Office",
+		"business_hours": map[string]interface{}{
+			"weekly": []map[string]string{{"day": "monday", "start": "08:00", "end": "18:00"}},
+		},
+	})
+	client.ExpectStatus(resp, http.StatusCreated)
+	var policy domain.EscalationPolicy
+	client.ParseJSON(resp, &policy)
+
+	hours, err := policy.ParseBusinessHours()
+	if err != nil || hours == nil {
+		t.Fatalf("Expected business hours, got %s (%v)", policy.BusinessHours, err)
+	}
+	if hours.Timezone != "Europe/Berlin" {
+		t.Errorf("Expected the organization's timezone, got %q", hours.Timezone)
+	}
+}
+
+func TestEscalationPolicies_BusinessHours_Invalid(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	invalid := []map[string]interface{}{
+		{"timezone": "Mars/Olympus", "weekly": []interface{}{}},
+		{"timezone": "UTC", "weekly": []map[string]string{{"day": "someday", "start": "09:00", "end": "17:00"}}},
+		{"timezone": "UTC", "weekly": []map[string]string{{"day": "monday", "start": "9:00", "end": "17:00"}}},
+		{"timezone": "UTC", "weekly": []map[string]string{{"day": "monday", "start": "22:00", "end": "06:00"}}},
+	}
+	for _, hours := range invalid {
+		resp := client.Post("/api/v1/escalation-policies", map[string]interface{}{
+			"name":           "Broken",
+			"business_hours": hours,
+		})
+		client.ExpectStatus(resp, http.StatusBadRequest)
+	}
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, user.Organization.ID, "Test Policy")
+	resp := client.Post(fmt.Sprintf("/api/v1/escalation-policies/%s/rules", policy.ID), map[string]interface{}{
+		"position":         1,
+		"escalation_delay": 5,
+		"hours":            "lunchtime",
+	})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+// ============================================================================
+// Conditional escalation rules
+// ============================================================================
+
+// startConditionalEscalation creates an unassigned alert with the given
+// priority on a policy of the given rules and starts escalating it on the
+// first one
+func startConditionalEscalation(t *testing.T, ctx context.Context, orgID uuid.UUID, priority string, rules ...dto.CreateEscalationRuleRequest) (*domain.Alert, []uuid.UUID) {
+	t.Helper()
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, orgID, "Conditional Policy")
+	var ruleIDs []uuid.UUID
+	for i := range rules {
+		rules[i].Position = i + 1
+		rule, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &rules[i])
+		if err != nil {
+			t.Fatalf("Failed to create rule: %v", err)
+		}
+		ruleIDs = append(ruleIDs, rule.ID)
+	}
+
+	alert := createAlertFrom(t, orgID, "prometheus", priority, &policy.ID)
+	if err := testServer.EscalationService.StartEscalation(ctx, alert.ID, orgID); err != nil {
+		t.Fatalf("Failed to start escalation: %v", err)
+	}
+	return alert, ruleIDs
+}
+
+func TestEscalation_SkipsRuleBelowMinPriority(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	orgID := user.Organization.ID
+	rules := []dto.CreateEscalationRuleRequest{
+		{EscalationDelay: 5},
+		{EscalationDelay: 5, MinPriority: "P2"},
+		{EscalationDelay: 5},
+	}
+
+	urgent, _ := startConditionalEscalation(t, ctx, orgID, "P1", slices.Clone(rules)...)
+	downgraded, _ := startConditionalEscalation(t, ctx, orgID, "P1", slices.Clone(rules)...)
+
+	p4 := "P4"
+	if _, err := testServer.AlertService.UpdateAlert(ctx, downgraded.ID, orgID, &dto.UpdateAlertRequest{Priority: &p4}); err != nil {
+		t.Fatalf("Failed to downgrade alert: %v", err)
+	}
+
+	advanceEscalationWorker(t, ctx, urgent.ID)
+	if level, eventType, _ := escalationState(t, ctx, urgent.ID); level != 1 || eventType != "triggered" {
+		t.Errorf("Expected a P1 alert to escalate to level 1, got level %d (%s)", level, eventType)
+	}
+
+	advanceEscalationWorker(t, ctx, downgraded.ID)
+	if level, eventType, _ := escalationState(t, ctx, downgraded.ID); level != 2 || eventType != "triggered" {
+		t.Fatalf("Expected the downgraded alert to skip to level 2, got level %d (%s)", level, eventType)
+	}
+
+	advanceEscalationWorker(t, ctx, downgraded.ID)
+	if _, eventType, _ := escalationState(t, ctx, downgraded.ID); eventType != "completed" {
+		t.Errorf("Expected escalation to complete after the last rule, got %s", eventType)
+	}
+}
+
+func TestEscalation_SkipsUnassignedOnlyRuleOnceAssigned(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	orgID := user.Organization.ID
+	alert, rules := startConditionalEscalation(t, ctx, orgID, "P1",
+		dto.CreateEscalationRuleRequest{EscalationDelay: 5},
+		dto.CreateEscalationRuleRequest{EscalationDelay: 5, OnlyIfUnassigned: true},
+	)
+	for _, ruleID := range rules {
+		if _, err := testServer.EscalationService.AddTarget(ctx, orgID, ruleID, &dto.AddEscalationTargetRequest{
+			TargetType: string(domain.EscalationTargetTypeUser),
+			TargetID:   user.User.ID,
+		}); err != nil {
+			t.Fatalf("Failed to add target: %v", err)
+		}
+	}
+
+	if err := testServer.AlertService.AssignAlert(ctx, alert.ID, orgID, user.User.ID, &dto.AssignAlertRequest{UserID: &user.User.ID}); err != nil {
+		t.Fatalf("Failed to assign alert: %v", err)
+	}
+
+	svc, pager := pagingEscalationService()
+	makeEscalationDue(t, ctx, alert.ID)
+	if err := svc.ProcessPendingEscalations(ctx, 10); err != nil {
+		t.Fatalf("Failed to process escalations: %v", err)
+	}
+
+	if _, eventType, _ := escalationState(t, ctx, alert.ID); eventType != "completed" {
+		t.Errorf("Expected escalation to complete without the unassigned-only rule, got %s", eventType)
+	}
+	if pages := pager.pages(); len(pages) != 0 {
+		t.Errorf("Expected no pages for an assigned alert, got %v", pages)
+	}
+}
+
+func TestEscalationPolicies_RuleConditions(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, user.Organization.ID, "Test Policy")
+
+	resp := client.Post(fmt.Sprintf("/api/v1/escalation-policies/%s/rules", policy.ID), map[string]interface{}{
+		"position":           1,
+		"escalation_delay":   5,
+		"min_priority":       "P2",
+		"only_if_unassigned": true,
+	})
+	client.ExpectStatus(resp, http.StatusCreated)
+	var rule domain.EscalationRule
+	client.ParseJSON(resp, &rule)
+	if rule.MinPriority == nil || *rule.MinPriority != domain.PriorityP2 || !rule.OnlyIfUnassigned {
+		t.Errorf("Expected the rule's conditions to be saved, got min priority %v, only if unassigned %v", rule.MinPriority, rule.OnlyIfUnassigned)
+	}
+
+	resp = client.Patch(fmt.Sprintf("/api/v1/escalation-policies/%s/rules/%s", policy.ID, rule.ID), map[string]interface{}{
+		"min_priority": "",
+	})
+	client.ExpectStatus(resp, http.StatusOK)
+	client.ParseJSON(resp, &rule)
+	if rule.MinPriority != nil || !rule.OnlyIfUnassigned {
+		t.Errorf("Expected only the minimum priority to be cleared, got min priority %v, only if unassigned %v", rule.MinPriority, rule.OnlyIfUnassigned)
+	}
+
+	resp = client.Post(fmt.Sprintf("/api/v1/escalation-policies/%s/rules", policy.ID), map[string]interface{}{
+		"position":         2,
+		"escalation_delay": 5,
+		"min_priority":     "P0",
+	})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
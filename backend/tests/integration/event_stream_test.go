@@ -0,0 +1,151 @@
+package integration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+)
+
+// streamEvent is one text/event-stream frame
+type streamEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// readStreamEvents parses frames from an event stream until it is closed
+func readStreamEvents(body io.Reader) <-chan streamEvent {
+	events := make(chan streamEvent)
+	go func() {
+		defer close(events)
+
+		var current streamEvent
+		scanner := bufio.NewScanner(body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if current.Event != "" {
+					events <- current
+				}
+				current = streamEvent{}
+			case strings.HasPrefix(line, "id: "):
+				current.ID = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				current.Event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				current.Data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+	return events
+}
+
+// waitForStreamEvent returns the first event of the given type
+func waitForStreamEvent(t *testing.T, events <-chan streamEvent, eventType domain.WSEventType) streamEvent {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("Stream closed before a %s event arrived", eventType)
+			}
+			if event.Event == string(eventType) {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("Timed out waiting for a %s event", eventType)
+		}
+	}
+}
+
+// ============================================================================
+// GET /api/v1/events/stream
+// ============================================================================
+
+func TestEventStream_ReceivesAlertCreated(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	stream := client.Get("/api/v1/events/stream")
+	defer stream.Body.Close()
+	client.ExpectStatus(stream, http.StatusOK)
+	if contentType := stream.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("Expected text/event-stream, got %q", contentType)
+	}
+	events := readStreamEvents(stream.Body)
+	waitForStreamEvent(t, events, domain.WSEventConnected)
+
+	resp := client.Post("/api/v1/alerts", map[string]interface{}{
+		"source":   "monitoring",
+		"message":  "Streamed alert",
+		"priority": "P2",
+	})
+	client.ExpectStatus(resp, http.StatusCreated)
+	var alert domain.Alert
+	client.ParseJSON(resp, &alert)
+
+	event := waitForStreamEvent(t, events, domain.WSEventAlertCreated)
+	var message domain.WSMessage
+	if err := json.Unmarshal([]byte(event.Data), &message); err != nil {
+		t.Fatalf("Failed to decode event data: %v", err)
+	}
+	if event.ID != message.ID {
+		t.Errorf("Expected the frame id %s to match the event id %s", event.ID, message.ID)
+	}
+	if message.Payload["alert_id"] != alert.ID.String() {
+		t.Errorf("Expected an event for alert %s, got %v", alert.ID, message.Payload["alert_id"])
+	}
+}
+
+func TestEventStream_OnlyStreamsOwnOrganization(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	stream := client.Get("/api/v1/events/stream")
+	defer stream.Body.Close()
+	client.ExpectStatus(stream, http.StatusOK)
+	events := readStreamEvents(stream.Body)
+	waitForStreamEvent(t, events, domain.WSEventConnected)
+
+	if _, err := testFixtures.CreateAlert(ctx, other.Organization.ID, "Someone else's alert"); err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+
+	resp := client.Post("/api/v1/alerts", map[string]interface{}{
+		"source":   "monitoring",
+		"message":  "Our alert",
+		"priority": "P3",
+	})
+	client.ExpectStatus(resp, http.StatusCreated)
+
+	event := waitForStreamEvent(t, events, domain.WSEventAlertCreated)
+	if !strings.Contains(event.Data, "Our alert") {
+		t.Errorf("Expected only our organization's alert, got %s", event.Data)
+	}
+}
+
+func TestEventStream_Unauthorized(t *testing.T) {
+	client := newTestClient(t)
+
+	resp := client.Get("/api/v1/events/stream")
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+}
@@ -2,9 +2,20 @@ package integration
 
 import (
 	"context"
+	"encoding/csv"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/tests/integration/testutils"
 )
 
 // ============================================================================
@@ -73,6 +84,145 @@ func TestIncidents_Create_MissingRequiredFields(t *testing.T) {
 	client.ExpectStatus(resp, http.StatusBadRequest)
 }
 
+// onCallAlert creates an alert whose escalation policy pages a schedule the
+// given user is always on call for
+func onCallAlert(t *testing.T, ctx context.Context, orgID, userID uuid.UUID) *domain.Alert {
+	t.Helper()
+
+	schedule, _ := testFixtures.CreateUniqueSchedule(ctx, orgID)
+	addRotation(t, ctx, schedule.ID, userID, "2024-01-01")
+
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, orgID, "Primary")
+	rule, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: 1, EscalationDelay: 5})
+	if err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+	if _, err := testServer.EscalationService.AddTarget(ctx, orgID, rule.ID, &dto.AddEscalationTargetRequest{TargetType: "schedule", TargetID: schedule.ID}); err != nil {
+		t.Fatalf("Failed to add target: %v", err)
+	}
+
+	alert, err := testServer.AlertService.CreateAlert(ctx, orgID, &dto.CreateAlertRequest{
+		Source:             "test",
+		Priority:           "P1",
+		Message:            "Checkout down",
+		EscalationPolicyID: &policy.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+	return alert
+}
+
+// createIncidentFromAlert declares an incident from the alert over the API
+func createIncidentFromAlert(t *testing.T, client *testutils.TestClient, alertID uuid.UUID) domain.Incident {
+	t.Helper()
+
+	resp := client.Post("/api/v1/incidents", map[string]interface{}{
+		"title":    "Checkout outage",
+		"severity": "critical",
+		"priority": "P1",
+		"alert_id": alertID,
+	})
+	client.ExpectStatus(resp, http.StatusCreated)
+
+	var incident domain.Incident
+	client.ParseJSON(resp, &incident)
+	return incident
+}
+
+func TestIncidents_Create_FromAlertAddsOnCallResponder(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	onCall, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+	joinOrganization(t, ctx, orgID, onCall.User.ID)
+
+	resp := client.Patch("/api/v1/organizations/settings", map[string]interface{}{
+		"incident_auto_assign_on_call": true,
+	})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	alert := onCallAlert(t, ctx, orgID, onCall.User.ID)
+	incident := createIncidentFromAlert(t, client, alert.ID)
+
+	responders, err := testServer.IncidentService.ListResponders(ctx, incident.ID, orgID)
+	if err != nil {
+		t.Fatalf("Failed to list responders: %v", err)
+	}
+	if len(responders) != 1 || responders[0].UserID != onCall.User.ID {
+		t.Fatalf("Expected the on-call user to be the only responder, got %+v", responders)
+	}
+	if responders[0].Role != domain.ResponderRoleResponder {
+		t.Errorf("Expected role %s, got %s", domain.ResponderRoleResponder, responders[0].Role)
+	}
+
+	timeline, err := testServer.IncidentService.GetTimeline(ctx, incident.ID, orgID, []domain.TimelineEventType{domain.TimelineEventResponderAdded})
+	if err != nil {
+		t.Fatalf("Failed to get timeline: %v", err)
+	}
+	if len(timeline) != 1 || timeline[0].UserID != nil {
+		t.Errorf("Expected one automatic responder_added event, got %+v", timeline)
+	}
+
+	alerts, err := testServer.IncidentService.ListAlerts(ctx, incident.ID, orgID)
+	if err != nil {
+		t.Fatalf("Failed to list incident alerts: %v", err)
+	}
+	if len(alerts) != 1 {
+		t.Errorf("Expected the alert to be linked, got %d alerts", len(alerts))
+	}
+}
+
+func TestIncidents_Create_FromAlertSkipsOnCallWhenDisabled(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	alert := onCallAlert(t, ctx, orgID, user.User.ID)
+	incident := createIncidentFromAlert(t, client, alert.ID)
+
+	responders, err := testServer.IncidentService.ListResponders(ctx, incident.ID, orgID)
+	if err != nil {
+		t.Fatalf("Failed to list responders: %v", err)
+	}
+	if len(responders) != 0 {
+		t.Errorf("Expected no responders while auto-assignment is off, got %d", len(responders))
+	}
+
+	// The alert is still linked
+	alerts, _ := testServer.IncidentService.ListAlerts(ctx, incident.ID, orgID)
+	if len(alerts) != 1 {
+		t.Errorf("Expected the alert to be linked, got %d alerts", len(alerts))
+	}
+}
+
+func TestIncidents_Create_FromOtherOrganizationAlert(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	alert, _ := testFixtures.CreateAlert(ctx, other.Organization.ID, "Elsewhere")
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Post("/api/v1/incidents", map[string]interface{}{
+		"title":    "Not ours",
+		"severity": "low",
+		"priority": "P4",
+		"alert_id": alert.ID,
+	})
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
 // ============================================================================
 // GET /api/v1/incidents
 // ============================================================================
@@ -126,6 +276,103 @@ func TestIncidents_List_Empty(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// GET /api/v1/incidents/export.csv
+// ============================================================================
+
+// getCSV fetches path and parses the body as CSV
+func getCSV(t *testing.T, client *testutils.TestClient, path string) [][]string {
+	t.Helper()
+
+	resp := client.Get(path)
+	client.ExpectStatus(resp, http.StatusOK)
+	if contentType := resp.Header.Get("Content-Type"); !strings.HasPrefix(contentType, "text/csv") {
+		t.Fatalf("Expected a CSV response, got %q", contentType)
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(client.ReadBody(resp))).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if len(rows) == 0 {
+		t.Fatal("Expected a header row")
+	}
+	return rows
+}
+
+func TestIncidents_ExportCSV_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	incident, _ := testFixtures.CreateIncident(ctx, user.Organization.ID, user.User.ID, "=Database outage")
+	resolved := "resolved"
+	if _, err := testServer.IncidentService.UpdateIncident(ctx, incident.ID, user.Organization.ID, user.User.ID, &dto.UpdateIncidentRequest{Status: &resolved}); err != nil {
+		t.Fatalf("Failed to resolve incident: %v", err)
+	}
+
+	rows := getCSV(t, client, "/api/v1/incidents/export.csv")
+	expectedHeader := "id,title,severity,priority,status,started_at,resolved_at,created_at,duration_seconds"
+	if header := strings.Join(rows[0], ","); header != expectedHeader {
+		t.Errorf("Expected header %q, got %q", expectedHeader, header)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 1 incident row, got %d", len(rows)-1)
+	}
+
+	row := rows[1]
+	if row[0] != incident.ID.String() {
+		t.Errorf("Expected id %s, got %s", incident.ID, row[0])
+	}
+	// Formula-like text is escaped for spreadsheets
+	if row[1] != "'=Database outage" {
+		t.Errorf("Expected escaped title, got %q", row[1])
+	}
+	if row[2] != "medium" || row[3] != "P3" || row[4] != "resolved" {
+		t.Errorf("Expected medium/P3/resolved, got %s/%s/%s", row[2], row[3], row[4])
+	}
+	startedAt, err := time.Parse(time.RFC3339, row[5])
+	if err != nil || !startedAt.Equal(incident.StartedAt.Truncate(time.Second)) {
+		t.Errorf("Expected started_at %s, got %q", incident.StartedAt.UTC().Format(time.RFC3339), row[5])
+	}
+	if _, err := time.Parse(time.RFC3339, row[6]); err != nil {
+		t.Errorf("Expected resolved_at to be set, got %q", row[6])
+	}
+	if seconds, err := strconv.Atoi(row[8]); err != nil || seconds < 0 {
+		t.Errorf("Expected a duration in seconds, got %q", row[8])
+	}
+}
+
+func TestIncidents_ExportCSV_HonorsFilters(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	testFixtures.CreateIncident(ctx, user.Organization.ID, user.User.ID, "Medium incident")
+	critical, err := testServer.IncidentService.CreateIncident(ctx, user.Organization.ID, user.User.ID, &dto.CreateIncidentRequest{
+		Title:    "Critical incident",
+		Severity: "critical",
+		Priority: "P1",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create incident: %v", err)
+	}
+
+	rows := getCSV(t, client, "/api/v1/incidents/export.csv?severity=critical")
+	if len(rows) != 2 || rows[1][0] != critical.ID.String() {
+		t.Fatalf("Expected only the critical incident, got %v", rows[1:])
+	}
+	if rows[1][8] != "" {
+		t.Errorf("Expected no duration for an open incident, got %q", rows[1][8])
+	}
+}
+
 // ============================================================================
 // GET /api/v1/incidents/:id
 // ============================================================================
@@ -162,7 +409,59 @@ func TestIncidents_Get_NotFound(t *testing.T) {
 	client.SetAuthToken(user.AccessToken)
 
 	resp := client.Get("/api/v1/incidents/00000000-0000-0000-0000-000000000000")
-	client.ExpectStatus(resp, http.StatusInternalServerError) // API returns 500 (schema issue)
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+func TestIncidents_OtherOrganizationDenied(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	incident, _ := testFixtures.CreateIncident(ctx, user.Organization.ID, user.User.ID, "Database outage")
+	if _, err := testServer.IncidentService.AddResponder(ctx, incident.ID, user.Organization.ID, user.User.ID, &dto.AddResponderRequest{UserID: user.User.ID, Role: "responder"}); err != nil {
+		t.Fatalf("Failed to add responder: %v", err)
+	}
+
+	client.SetAuthToken(other.AccessToken)
+
+	resp := client.Get(fmt.Sprintf("/api/v1/incidents/%s", incident.ID))
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	resp = client.Get(fmt.Sprintf("/api/v1/incidents/%s/timeline", incident.ID))
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	resp = client.Get(fmt.Sprintf("/api/v1/incidents/%s/responders", incident.ID))
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	resp = client.Post(fmt.Sprintf("/api/v1/incidents/%s/responders", incident.ID), map[string]interface{}{
+		"user_id": other.User.ID.String(),
+		"role":    "responder",
+	})
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	resp = client.Post(fmt.Sprintf("/api/v1/incidents/%s/notes", incident.ID), map[string]interface{}{
+		"note": "Written from another organization",
+	})
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	otherAlert, _ := testFixtures.CreateAlert(ctx, other.Organization.ID, "Other org alert")
+	resp = client.Post(fmt.Sprintf("/api/v1/incidents/%s/alerts", incident.ID), map[string]interface{}{
+		"alert_id": otherAlert.ID.String(),
+	})
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	// The incident is untouched and still visible to its own organization
+	client.SetAuthToken(user.AccessToken)
+	resp = client.Get(fmt.Sprintf("/api/v1/incidents/%s/responders", incident.ID))
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var responders []domain.ResponderWithUser
+	client.ParseJSON(resp, &responders)
+	if len(responders) != 1 || responders[0].UserID != user.User.ID {
+		t.Errorf("Expected only the original responder, got %+v", responders)
+	}
 }
 
 // ============================================================================
@@ -212,6 +511,126 @@ func TestIncidents_Update_NotFound(t *testing.T) {
 	client.ExpectStatus(resp, http.StatusInternalServerError) // API returns 500 for not found errors
 }
 
+// incidentWithLinkedAlerts creates an incident with an open and an already
+// closed alert linked to it
+func incidentWithLinkedAlerts(t *testing.T, ctx context.Context, user *testutils.TestUser) (*domain.Incident, *domain.Alert, *domain.Alert) {
+	t.Helper()
+	orgID := user.Organization.ID
+
+	incident, _ := testFixtures.CreateIncident(ctx, orgID, user.User.ID, "Checkout outage")
+	open, _ := testFixtures.CreateAlert(ctx, orgID, "Checkout 5xx")
+	closed, _ := testFixtures.CreateAlert(ctx, orgID, "Checkout latency")
+	if err := testServer.AlertService.CloseAlert(ctx, closed.ID, orgID, user.User.ID, "recovered"); err != nil {
+		t.Fatalf("Failed to close alert: %v", err)
+	}
+	for _, alert := range []*domain.Alert{open, closed} {
+		if _, err := testServer.IncidentService.LinkAlert(ctx, incident.ID, orgID, user.User.ID, &dto.LinkAlertRequest{AlertID: alert.ID}); err != nil {
+			t.Fatalf("Failed to link alert: %v", err)
+		}
+	}
+	return incident, open, closed
+}
+
+func TestIncidents_Update_ResolveClosesLinkedAlerts(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	incident, open, closed := incidentWithLinkedAlerts(t, ctx, user)
+	unlinked, _ := testFixtures.CreateAlert(ctx, orgID, "Unrelated")
+
+	resp := client.Patch(fmt.Sprintf("/api/v1/incidents/%s", incident.ID), map[string]interface{}{
+		"status":              "resolved",
+		"close_linked_alerts": true,
+	})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	got, _ := testServer.AlertService.GetAlert(ctx, open.ID, orgID)
+	if got.Status != domain.AlertStatusClosed {
+		t.Fatalf("Expected the linked alert to be closed, got %s", got.Status)
+	}
+	if want := `incident "Checkout outage" resolved`; got.CloseReason == nil || *got.CloseReason != want {
+		t.Errorf("Expected close reason %q, got %v", want, got.CloseReason)
+	}
+	if got.ClosedBy == nil || *got.ClosedBy != user.User.ID {
+		t.Errorf("Expected the alert to be closed by the resolving user, got %v", got.ClosedBy)
+	}
+
+	got, _ = testServer.AlertService.GetAlert(ctx, closed.ID, orgID)
+	if got.CloseReason == nil || *got.CloseReason != "recovered" {
+		t.Errorf("Expected the already closed alert to keep its close reason, got %v", got.CloseReason)
+	}
+
+	got, _ = testServer.AlertService.GetAlert(ctx, unlinked.ID, orgID)
+	if got.Status != domain.AlertStatusOpen {
+		t.Errorf("Expected the unlinked alert to stay open, got %s", got.Status)
+	}
+}
+
+func TestIncidents_Update_ResolveKeepsLinkedAlertsByDefault(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	incident, open, _ := incidentWithLinkedAlerts(t, ctx, user)
+
+	resp := client.Patch(fmt.Sprintf("/api/v1/incidents/%s", incident.ID), map[string]interface{}{
+		"status": "resolved",
+	})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	got, _ := testServer.AlertService.GetAlert(ctx, open.ID, user.Organization.ID)
+	if got.Status != domain.AlertStatusOpen {
+		t.Errorf("Expected the linked alert to stay open, got %s", got.Status)
+	}
+}
+
+func TestIncidents_Update_ResolveFollowsOrganizationSetting(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	resp := client.Patch("/api/v1/organizations/settings", map[string]interface{}{
+		"incident_close_linked_alerts": true,
+	})
+	client.AssertStatus(resp, http.StatusOK)
+
+	incident, open, _ := incidentWithLinkedAlerts(t, ctx, user)
+	resp = client.Patch(fmt.Sprintf("/api/v1/incidents/%s", incident.ID), map[string]interface{}{
+		"status": "resolved",
+	})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	got, _ := testServer.AlertService.GetAlert(ctx, open.ID, orgID)
+	if got.Status != domain.AlertStatusClosed {
+		t.Errorf("Expected the organization setting to close the linked alert, got %s", got.Status)
+	}
+
+	// The request can opt out of the organization's default
+	incident, open, _ = incidentWithLinkedAlerts(t, ctx, user)
+	resp = client.Patch(fmt.Sprintf("/api/v1/incidents/%s", incident.ID), map[string]interface{}{
+		"status":              "resolved",
+		"close_linked_alerts": false,
+	})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	got, _ = testServer.AlertService.GetAlert(ctx, open.ID, orgID)
+	if got.Status != domain.AlertStatusOpen {
+		t.Errorf("Expected the request to keep the linked alert open, got %s", got.Status)
+	}
+}
+
 // ============================================================================
 // DELETE /api/v1/incidents/:id
 // ============================================================================
@@ -315,7 +734,29 @@ func TestIncidents_AddResponder_NotFound(t *testing.T) {
 	}
 
 	resp := client.Post("/api/v1/incidents/00000000-0000-0000-0000-000000000000/responders", reqBody)
-	client.ExpectStatus(resp, http.StatusInternalServerError) // API returns 500 for FK violations
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+func TestIncidents_AddResponder_SecondCommanderDemotesFirst(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	first, _ := testFixtures.CreateUniqueUser(ctx)
+	second, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	incident, _ := testFixtures.CreateIncident(ctx, user.Organization.ID, user.User.ID, "Test Incident")
+	path := fmt.Sprintf("/api/v1/incidents/%s/responders", incident.ID)
+
+	resp := client.Post(path, map[string]interface{}{"user_id": first.User.ID.String(), "role": "incident_commander"})
+	client.AssertStatus(resp, http.StatusCreated)
+	resp = client.Post(path, map[string]interface{}{"user_id": second.User.ID.String(), "role": "incident_commander"})
+	client.AssertStatus(resp, http.StatusCreated)
+
+	assertCommander(t, ctx, incident.ID, user.Organization.ID, second.User.ID)
+	assertCommanderHandoff(t, ctx, incident.ID, user.Organization.ID, first.User.ID, second.User.ID)
 }
 
 // ============================================================================
@@ -358,6 +799,70 @@ func TestIncidents_UpdateResponderRole_NotFound(t *testing.T) {
 	client.ExpectStatus(resp, http.StatusInternalServerError) // API returns 500 for not found errors
 }
 
+func TestIncidents_UpdateResponderRole_PromotionDemotesCommander(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	first, _ := testFixtures.CreateUniqueUser(ctx)
+	second, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	incident, _ := testFixtures.CreateIncident(ctx, user.Organization.ID, user.User.ID, "Test Incident")
+	path := fmt.Sprintf("/api/v1/incidents/%s/responders", incident.ID)
+
+	resp := client.Post(path, map[string]interface{}{"user_id": first.User.ID.String(), "role": "incident_commander"})
+	client.AssertStatus(resp, http.StatusCreated)
+	resp = client.Post(path, map[string]interface{}{"user_id": second.User.ID.String(), "role": "responder"})
+	client.AssertStatus(resp, http.StatusCreated)
+
+	resp = client.Patch(fmt.Sprintf("%s/%s", path, second.User.ID), map[string]interface{}{"role": "incident_commander"})
+	client.AssertStatus(resp, http.StatusOK)
+
+	assertCommander(t, ctx, incident.ID, user.Organization.ID, second.User.ID)
+	assertCommanderHandoff(t, ctx, incident.ID, user.Organization.ID, first.User.ID, second.User.ID)
+}
+
+// assertCommander checks that the given user is the incident's only commander
+func assertCommander(t *testing.T, ctx context.Context, incidentID, orgID, commanderID uuid.UUID) {
+	t.Helper()
+	responders, err := testServer.IncidentService.ListResponders(ctx, incidentID, orgID)
+	if err != nil {
+		t.Fatalf("Failed to list responders: %v", err)
+	}
+	for _, responder := range responders {
+		isCommander := responder.Role == domain.ResponderRoleIncidentCommander
+		if isCommander != (responder.UserID == commanderID) {
+			t.Errorf("Expected only %s to be commander, %s has role %s", commanderID, responder.UserID, responder.Role)
+		}
+	}
+}
+
+// assertCommanderHandoff checks the timeline records a handoff between the two users
+func assertCommanderHandoff(t *testing.T, ctx context.Context, incidentID, orgID, previousID, commanderID uuid.UUID) {
+	t.Helper()
+	timeline, err := testServer.IncidentService.GetTimeline(ctx, incidentID, orgID, nil)
+	if err != nil {
+		t.Fatalf("Failed to get timeline: %v", err)
+	}
+	var handoffs []*domain.TimelineEventWithUser
+	for _, event := range timeline {
+		if event.EventType == domain.TimelineEventCommanderChanged {
+			handoffs = append(handoffs, event)
+		}
+	}
+	if len(handoffs) != 1 {
+		t.Fatalf("Expected 1 commander handoff event, got %d", len(handoffs))
+	}
+	if handoffs[0].Metadata["previous_commander_user_id"] != previousID.String() {
+		t.Errorf("Expected previous commander %s, got %v", previousID, handoffs[0].Metadata["previous_commander_user_id"])
+	}
+	if handoffs[0].Metadata["commander_user_id"] != commanderID.String() {
+		t.Errorf("Expected new commander %s, got %v", commanderID, handoffs[0].Metadata["commander_user_id"])
+	}
+}
+
 // ============================================================================
 // GET /api/v1/incidents/:id/timeline
 // ============================================================================
@@ -393,8 +898,61 @@ func TestIncidents_GetTimeline_NotFound(t *testing.T) {
 	client.SetAuthToken(user.AccessToken)
 
 	resp := client.Get("/api/v1/incidents/00000000-0000-0000-0000-000000000000/timeline")
-	// API returns 200 with empty array for non-existent incidents
-	client.AssertStatus(resp, http.StatusOK)
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+func TestIncidents_GetTimeline_FilterByEventType(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	incident, _ := testFixtures.CreateIncident(ctx, orgID, user.User.ID, "Test Incident")
+	identified := "identified"
+	if _, err := testServer.IncidentService.UpdateIncident(ctx, incident.ID, orgID, user.User.ID, &dto.UpdateIncidentRequest{Status: &identified}); err != nil {
+		t.Fatalf("Failed to update incident: %v", err)
+	}
+	for _, note := range []string{"Looking at the load balancer", "Rolled back the deploy"} {
+		if _, err := testServer.IncidentService.AddNote(ctx, incident.ID, orgID, user.User.ID, &dto.AddNoteRequest{Note: note}); err != nil {
+			t.Fatalf("Failed to add note: %v", err)
+		}
+	}
+
+	getTimeline := func(query string) []domain.TimelineEventWithUser {
+		t.Helper()
+		resp := client.Get(fmt.Sprintf("/api/v1/incidents/%s/timeline%s", incident.ID, query))
+		client.ExpectStatus(resp, http.StatusOK)
+		var timeline []domain.TimelineEventWithUser
+		client.ParseJSON(resp, &timeline)
+		return timeline
+	}
+
+	if all := getTimeline(""); len(all) != 4 {
+		t.Fatalf("Expected 4 events without a filter, got %d", len(all))
+	}
+
+	notes := getTimeline("?event_type=note_added")
+	if len(notes) != 2 {
+		t.Fatalf("Expected 2 notes, got %d", len(notes))
+	}
+	for _, event := range notes {
+		if event.EventType != domain.TimelineEventNoteAdded {
+			t.Errorf("Expected only notes, got %s", event.EventType)
+		}
+	}
+	if notes[0].Description != "Looking at the load balancer" {
+		t.Errorf("Expected notes oldest first, got %q", notes[0].Description)
+	}
+
+	if mixed := getTimeline("?event_type=note_added&event_type=status_changed"); len(mixed) != 3 {
+		t.Errorf("Expected 3 notes and status changes, got %d", len(mixed))
+	}
+
+	resp := client.Get(fmt.Sprintf("/api/v1/incidents/%s/timeline?event_type=bogus", incident.ID))
+	client.ExpectStatus(resp, http.StatusBadRequest)
 }
 
 // ============================================================================
@@ -432,7 +990,133 @@ func TestIncidents_AddNote_NotFound(t *testing.T) {
 	}
 
 	resp := client.Post("/api/v1/incidents/00000000-0000-0000-0000-000000000000/notes", reqBody)
-	client.ExpectStatus(resp, http.StatusInternalServerError) // API returns 500 for not found errors
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+// ============================================================================
+// PATCH/DELETE /api/v1/incidents/:id/notes/:eventId
+// ============================================================================
+
+func TestIncidents_UpdateNote_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	incident, _ := testFixtures.CreateIncident(ctx, user.Organization.ID, user.User.ID, "Test Incident")
+	note, err := testServer.IncidentService.AddNote(ctx, incident.ID, user.Organization.ID, user.User.ID, &dto.AddNoteRequest{Note: "Restarted teh cache"})
+	if err != nil {
+		t.Fatalf("Failed to add note: %v", err)
+	}
+
+	resp := client.Patch(fmt.Sprintf("/api/v1/incidents/%s/notes/%s", incident.ID, note.ID), map[string]interface{}{
+		"note": "Restarted the cache",
+	})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	timeline, err := testServer.IncidentService.GetTimeline(ctx, incident.ID, user.Organization.ID, nil)
+	if err != nil {
+		t.Fatalf("Failed to get timeline: %v", err)
+	}
+	var edited *domain.TimelineEventWithUser
+	for _, event := range timeline {
+		if event.ID == note.ID {
+			edited = event
+		}
+	}
+	if edited == nil {
+		t.Fatal("Expected the note to stay on the timeline")
+	}
+	if edited.Description != "Restarted the cache" {
+		t.Errorf("Expected the corrected text, got %q", edited.Description)
+	}
+	if edited.EditedAt == nil {
+		t.Error("Expected the note to be marked as edited")
+	}
+	if !edited.CreatedAt.Equal(note.CreatedAt) {
+		t.Errorf("Expected the original time %s to be kept, got %s", note.CreatedAt, edited.CreatedAt)
+	}
+}
+
+func TestIncidents_UpdateNote_SystemEventForbidden(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	incident, _ := testFixtures.CreateIncident(ctx, user.Organization.ID, user.User.ID, "Test Incident")
+	timeline, _ := testServer.IncidentService.GetTimeline(ctx, incident.ID, user.Organization.ID, nil)
+	var created *domain.TimelineEventWithUser
+	for _, event := range timeline {
+		if event.EventType == domain.TimelineEventCreated {
+			created = event
+		}
+	}
+	if created == nil {
+		t.Fatal("Expected a created event on the timeline")
+	}
+
+	path := fmt.Sprintf("/api/v1/incidents/%s/notes/%s", incident.ID, created.ID)
+	resp := client.Patch(path, map[string]interface{}{"note": "Rewritten history"})
+	client.ExpectStatus(resp, http.StatusForbidden)
+
+	resp = client.Delete(path)
+	client.ExpectStatus(resp, http.StatusForbidden)
+}
+
+func TestIncidents_UpdateNote_AuthorOrAdminOnly(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	author, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	orgID := author.Organization.ID
+	joinOrganization(t, ctx, orgID, other.User.ID)
+
+	incident, _ := testFixtures.CreateIncident(ctx, orgID, author.User.ID, "Test Incident")
+	note, _ := testServer.IncidentService.AddNote(ctx, incident.ID, orgID, author.User.ID, &dto.AddNoteRequest{Note: "Paged the DBA"})
+
+	_, err := testServer.IncidentService.UpdateNote(ctx, incident.ID, orgID, note.ID, other.User.ID, false, &dto.UpdateNoteRequest{Note: "Not mine"})
+	if !errors.Is(err, domain.ErrNotNoteAuthor) {
+		t.Fatalf("Expected ErrNotNoteAuthor, got %v", err)
+	}
+	if err := testServer.IncidentService.DeleteNote(ctx, incident.ID, orgID, note.ID, other.User.ID, false); !errors.Is(err, domain.ErrNotNoteAuthor) {
+		t.Fatalf("Expected ErrNotNoteAuthor, got %v", err)
+	}
+
+	if _, err := testServer.IncidentService.UpdateNote(ctx, incident.ID, orgID, note.ID, other.User.ID, true, &dto.UpdateNoteRequest{Note: "Paged the on-call DBA"}); err != nil {
+		t.Fatalf("Expected an admin to edit the note, got %v", err)
+	}
+}
+
+func TestIncidents_DeleteNote_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	incident, _ := testFixtures.CreateIncident(ctx, user.Organization.ID, user.User.ID, "Test Incident")
+	note, _ := testServer.IncidentService.AddNote(ctx, incident.ID, user.Organization.ID, user.User.ID, &dto.AddNoteRequest{Note: "Wrong incident"})
+
+	path := fmt.Sprintf("/api/v1/incidents/%s/notes/%s", incident.ID, note.ID)
+	resp := client.Delete(path)
+	client.ExpectStatus(resp, http.StatusOK)
+
+	timeline, _ := testServer.IncidentService.GetTimeline(ctx, incident.ID, user.Organization.ID, nil)
+	for _, event := range timeline {
+		if event.ID == note.ID {
+			t.Fatal("Expected the note to be removed from the timeline")
+		}
+	}
+
+	resp = client.Delete(path)
+	client.ExpectStatus(resp, http.StatusNotFound)
 }
 
 // ============================================================================
@@ -485,6 +1169,51 @@ func TestIncidents_LinkAlert_Success(t *testing.T) {
 	client.AssertStatus(resp, http.StatusCreated) // API returns 201
 }
 
+func TestIncidents_LinkAlert_CopiesNotes(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	incident, _ := testFixtures.CreateIncident(ctx, user.Organization.ID, user.User.ID, "Test Incident")
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Test Alert")
+
+	if _, err := testServer.AlertService.AddNote(ctx, alert.ID, user.Organization.ID, user.User.ID, &dto.AddNoteRequest{Note: "Traced to the cache"}); err != nil {
+		t.Fatalf("Failed to add alert note: %v", err)
+	}
+
+	reqBody := map[string]interface{}{
+		"alert_id":   alert.ID.String(),
+		"copy_notes": true,
+	}
+
+	resp := client.Post(fmt.Sprintf("/api/v1/incidents/%s/alerts", incident.ID), reqBody)
+	client.AssertStatus(resp, http.StatusCreated)
+
+	timeline, err := testServer.IncidentService.GetTimeline(ctx, incident.ID, user.Organization.ID, nil)
+	if err != nil {
+		t.Fatalf("Failed to get timeline: %v", err)
+	}
+
+	var copied *domain.TimelineEventWithUser
+	for _, event := range timeline {
+		if event.EventType == domain.TimelineEventNoteAdded {
+			copied = event
+		}
+	}
+	if copied == nil {
+		t.Fatal("Expected the alert note in the incident timeline")
+	}
+	if copied.Description != "Traced to the cache" {
+		t.Errorf("Expected copied note text, got %q", copied.Description)
+	}
+	if copied.UserID == nil || *copied.UserID != user.User.ID {
+		t.Errorf("Expected copied note attributed to %s, got %v", user.User.ID, copied.UserID)
+	}
+}
+
 func TestIncidents_LinkAlert_NotFound(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
@@ -500,7 +1229,33 @@ func TestIncidents_LinkAlert_NotFound(t *testing.T) {
 	}
 
 	resp := client.Post("/api/v1/incidents/00000000-0000-0000-0000-000000000000/alerts", reqBody)
-	client.ExpectStatus(resp, http.StatusInternalServerError) // API returns 500 for FK violations
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+func TestIncidents_LinkAlert_OtherOrganizationAlert(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	incident, _ := testFixtures.CreateIncident(ctx, user.Organization.ID, user.User.ID, "Test Incident")
+	foreign, _ := testFixtures.CreateAlert(ctx, other.Organization.ID, "Other org alert")
+
+	resp := client.Post(fmt.Sprintf("/api/v1/incidents/%s/alerts", incident.ID), map[string]interface{}{
+		"alert_id": foreign.ID.String(),
+	})
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	alerts, err := testServer.IncidentService.ListAlerts(ctx, incident.ID, user.Organization.ID)
+	if err != nil {
+		t.Fatalf("Failed to get incident alerts: %v", err)
+	}
+	if len(alerts) != 0 {
+		t.Errorf("Expected no linked alerts, got %d", len(alerts))
+	}
 }
 
 // ============================================================================
@@ -520,3 +1275,156 @@ func TestIncidents_UnlinkAlert_NotLinked(t *testing.T) {
 	resp := client.Delete(fmt.Sprintf("/api/v1/incidents/%s/alerts/00000000-0000-0000-0000-000000000000", incident.ID))
 	client.ExpectStatus(resp, http.StatusInternalServerError) // API returns 500 for not found errors
 }
+
+func TestIncidents_LinkUnlinkAlert_RecordsTimeline(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	incident, _ := testFixtures.CreateIncident(ctx, user.Organization.ID, user.User.ID, "Test Incident")
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Disk full on db-1")
+
+	resp := client.Post(fmt.Sprintf("/api/v1/incidents/%s/alerts", incident.ID), map[string]interface{}{
+		"alert_id": alert.ID.String(),
+	})
+	client.ExpectStatus(resp, http.StatusCreated)
+
+	resp = client.Delete(fmt.Sprintf("/api/v1/incidents/%s/alerts/%s", incident.ID, alert.ID))
+	client.ExpectStatus(resp, http.StatusOK)
+
+	timeline, err := testServer.IncidentService.GetTimeline(ctx, incident.ID, user.Organization.ID, nil)
+	if err != nil {
+		t.Fatalf("Failed to get timeline: %v", err)
+	}
+
+	events := make(map[domain.TimelineEventType]*domain.TimelineEventWithUser)
+	for _, event := range timeline {
+		events[event.EventType] = event
+	}
+	for _, eventType := range []domain.TimelineEventType{domain.TimelineEventAlertLinked, domain.TimelineEventAlertUnlinked} {
+		event, ok := events[eventType]
+		if !ok {
+			t.Errorf("Expected a %s timeline event", eventType)
+			continue
+		}
+		if event.UserID == nil || *event.UserID != user.User.ID {
+			t.Errorf("Expected %s to be attributed to %s, got %v", eventType, user.User.ID, event.UserID)
+		}
+		if event.Metadata["alert_id"] != alert.ID.String() {
+			t.Errorf("Expected %s to reference alert %s, got %v", eventType, alert.ID, event.Metadata["alert_id"])
+		}
+		if event.Metadata["alert_message"] != alert.Message {
+			t.Errorf("Expected %s to carry the alert message, got %v", eventType, event.Metadata["alert_message"])
+		}
+		if !strings.Contains(event.Description, alert.Message) {
+			t.Errorf("Expected %s description to name the alert, got %q", eventType, event.Description)
+		}
+	}
+}
+
+// ============================================================================
+// GET /api/v1/incidents/:id/suggested-alerts
+// ============================================================================
+
+// createSourcedAlert creates an alert from source with the given tags
+func createSourcedAlert(t *testing.T, ctx context.Context, orgID uuid.UUID, source, message string, tags ...string) *domain.Alert {
+	t.Helper()
+
+	alert, err := testServer.AlertService.CreateAlert(ctx, orgID, &dto.CreateAlertRequest{
+		Source:   source,
+		Priority: "P3",
+		Message:  message,
+		Tags:     tags,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+	return alert
+}
+
+func TestIncidents_SuggestAlerts_RanksRelatedAlerts(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	incident, _ := testFixtures.CreateIncident(ctx, orgID, user.User.ID, "Database outage")
+	linked := createSourcedAlert(t, ctx, orgID, "prometheus", "Primary down", "db", "prod")
+	if _, err := testServer.IncidentService.LinkAlert(ctx, incident.ID, orgID, user.User.ID, &dto.LinkAlertRequest{AlertID: linked.ID}); err != nil {
+		t.Fatalf("Failed to link alert: %v", err)
+	}
+
+	sameSource := createSourcedAlert(t, ctx, orgID, "prometheus", "Replica lag", "db", "prod")
+	sharedTag := createSourcedAlert(t, ctx, orgID, "datadog", "Slow queries", "db")
+	createSourcedAlert(t, ctx, orgID, "pingdom", "Website slow", "web")
+
+	closed := createSourcedAlert(t, ctx, orgID, "prometheus", "Disk full", "db")
+	if err := testServer.AlertService.CloseAlert(ctx, closed.ID, orgID, user.User.ID, "fixed"); err != nil {
+		t.Fatalf("Failed to close alert: %v", err)
+	}
+
+	old := createSourcedAlert(t, ctx, orgID, "prometheus", "Yesterday's blip", "db")
+	if _, err := testDB.ExecContext(ctx,
+		`UPDATE alerts SET created_at = $2 WHERE id = $1`,
+		old.ID, incident.StartedAt.Add(-domain.SuggestedAlertLookback-time.Minute),
+	); err != nil {
+		t.Fatalf("Failed to backdate alert: %v", err)
+	}
+
+	resp := client.Get(fmt.Sprintf("/api/v1/incidents/%s/suggested-alerts", incident.ID))
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var suggestions []dto.SuggestedAlert
+	client.ParseJSON(resp, &suggestions)
+
+	if len(suggestions) != 2 {
+		t.Fatalf("Expected 2 suggestions, got %d", len(suggestions))
+	}
+	if suggestions[0].Alert.ID != sameSource.ID || suggestions[0].Score != 3 || !suggestions[0].SameSource {
+		t.Errorf("Expected the same-source alert first with score 3, got %s (score %d)", suggestions[0].Alert.Message, suggestions[0].Score)
+	}
+	if suggestions[1].Alert.ID != sharedTag.ID || suggestions[1].Score != 1 || suggestions[1].SameSource {
+		t.Errorf("Expected the shared-tag alert second with score 1, got %s (score %d)", suggestions[1].Alert.Message, suggestions[1].Score)
+	}
+	if len(suggestions[1].SharedTags) != 1 || suggestions[1].SharedTags[0] != "db" {
+		t.Errorf("Expected the shared tags to be [db], got %v", suggestions[1].SharedTags)
+	}
+}
+
+func TestIncidents_SuggestAlerts_IgnoresOtherOrganizations(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	incident, _ := testFixtures.CreateIncident(ctx, orgID, user.User.ID, "Database outage")
+	linked := createSourcedAlert(t, ctx, orgID, "prometheus", "Primary down", "db")
+	if _, err := testServer.IncidentService.LinkAlert(ctx, incident.ID, orgID, user.User.ID, &dto.LinkAlertRequest{AlertID: linked.ID}); err != nil {
+		t.Fatalf("Failed to link alert: %v", err)
+	}
+	createSourcedAlert(t, ctx, other.Organization.ID, "prometheus", "Their primary down", "db")
+
+	resp := client.Get(fmt.Sprintf("/api/v1/incidents/%s/suggested-alerts", incident.ID))
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var suggestions []dto.SuggestedAlert
+	client.ParseJSON(resp, &suggestions)
+	if len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions, got %d", len(suggestions))
+	}
+
+	// Nor can the other organization ask about our incident
+	client.SetAuthToken(other.AccessToken)
+	resp = client.Get(fmt.Sprintf("/api/v1/incidents/%s/suggested-alerts", incident.ID))
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
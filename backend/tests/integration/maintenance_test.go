@@ -0,0 +1,193 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+)
+
+// deployWindowRequest builds a window matching alerts tagged "deploy"
+func deployWindowRequest(startsAt, endsAt time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"name":      "Deploy",
+		"starts_at": startsAt.UTC().Format(time.RFC3339),
+		"ends_at":   endsAt.UTC().Format(time.RFC3339),
+		"conditions": map[string]interface{}{
+			"match": "all",
+			"conditions": []map[string]string{
+				{"field": "tags", "operator": "contains", "value": "deploy"},
+			},
+		},
+	}
+}
+
+func createTaggedAlert(t *testing.T, orgID uuid.UUID, message string, tags ...string) *domain.Alert {
+	t.Helper()
+	alert, err := testServer.AlertService.CreateAlert(context.Background(), orgID, &dto.CreateAlertRequest{
+		Source:   "test",
+		Priority: "P2",
+		Message:  message,
+		Tags:     tags,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+	return alert
+}
+
+// ============================================================================
+// POST /api/v1/maintenance-windows
+// ============================================================================
+
+func TestMaintenanceWindows_Create_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	now := time.Now()
+	resp := client.Post("/api/v1/maintenance-windows", deployWindowRequest(now, now.Add(time.Hour)))
+	client.AssertStatus(resp, http.StatusCreated)
+
+	var window domain.MaintenanceWindow
+	client.ParseJSON(resp, &window)
+	if window.OrganizationID != user.Organization.ID {
+		t.Errorf("Expected window in organization %s, got %s", user.Organization.ID, window.OrganizationID)
+	}
+	if window.CreatedBy == nil || *window.CreatedBy != user.User.ID {
+		t.Errorf("Expected window created by %s, got %v", user.User.ID, window.CreatedBy)
+	}
+}
+
+func TestMaintenanceWindows_Create_EndBeforeStart(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	now := time.Now()
+	resp := client.Post("/api/v1/maintenance-windows", deployWindowRequest(now, now.Add(-time.Hour)))
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+// ============================================================================
+// GET/PATCH/DELETE /api/v1/maintenance-windows/:id
+// ============================================================================
+
+func TestMaintenanceWindows_CRUD(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	now := time.Now()
+	resp := client.Post("/api/v1/maintenance-windows", deployWindowRequest(now, now.Add(time.Hour)))
+	client.AssertStatus(resp, http.StatusCreated)
+	var window domain.MaintenanceWindow
+	client.ParseJSON(resp, &window)
+
+	resp = client.Get("/api/v1/maintenance-windows")
+	client.AssertStatus(resp, http.StatusOK)
+	var list struct {
+		Windows []domain.MaintenanceWindow `json:"windows"`
+	}
+	client.ParseJSON(resp, &list)
+	if len(list.Windows) != 1 {
+		t.Fatalf("Expected 1 window, got %d", len(list.Windows))
+	}
+
+	resp = client.Patch(fmt.Sprintf("/api/v1/maintenance-windows/%s", window.ID), map[string]string{"name": "Database migration"})
+	client.AssertStatus(resp, http.StatusOK)
+
+	resp = client.Get(fmt.Sprintf("/api/v1/maintenance-windows/%s", window.ID))
+	client.AssertStatus(resp, http.StatusOK)
+	var got domain.MaintenanceWindow
+	client.ParseJSON(resp, &got)
+	if got.Name != "Database migration" {
+		t.Errorf("Expected updated name, got %q", got.Name)
+	}
+
+	resp = client.Delete(fmt.Sprintf("/api/v1/maintenance-windows/%s", window.ID))
+	client.AssertStatus(resp, http.StatusOK)
+
+	resp = client.Get(fmt.Sprintf("/api/v1/maintenance-windows/%s", window.ID))
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+func TestMaintenanceWindows_Get_OtherOrganization(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	outsider, _ := testFixtures.CreateUniqueUser(ctx)
+
+	client.SetAuthToken(owner.AccessToken)
+	now := time.Now()
+	resp := client.Post("/api/v1/maintenance-windows", deployWindowRequest(now, now.Add(time.Hour)))
+	client.AssertStatus(resp, http.StatusCreated)
+	var window domain.MaintenanceWindow
+	client.ParseJSON(resp, &window)
+
+	client.SetAuthToken(outsider.AccessToken)
+	resp = client.Get(fmt.Sprintf("/api/v1/maintenance-windows/%s", window.ID))
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+// ============================================================================
+// Alert suppression
+// ============================================================================
+
+func TestMaintenanceWindows_SuppressesMatchingAlertsWhileActive(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	now := time.Now()
+	resp := client.Post("/api/v1/maintenance-windows", deployWindowRequest(now.Add(-time.Minute), now.Add(time.Hour)))
+	client.AssertStatus(resp, http.StatusCreated)
+	var window domain.MaintenanceWindow
+	client.ParseJSON(resp, &window)
+
+	matching := createTaggedAlert(t, orgID, "API latency during deploy", "deploy", "api")
+	other := createTaggedAlert(t, orgID, "Disk full", "storage")
+
+	got, _ := testServer.AlertService.GetAlert(ctx, matching.ID, orgID)
+	if got.MaintenanceWindowID == nil || *got.MaintenanceWindowID != window.ID {
+		t.Errorf("Expected matching alert suppressed by window %s, got %v", window.ID, got.MaintenanceWindowID)
+	}
+
+	got, _ = testServer.AlertService.GetAlert(ctx, other.ID, orgID)
+	if got.MaintenanceWindowID != nil {
+		t.Errorf("Expected non-matching alert to page normally, got window %v", got.MaintenanceWindowID)
+	}
+
+	// End the window; matching alerts page normally again
+	resp = client.Patch(fmt.Sprintf("/api/v1/maintenance-windows/%s", window.ID), map[string]string{
+		"ends_at": time.Now().Add(-time.Second).UTC().Format(time.RFC3339Nano),
+	})
+	client.AssertStatus(resp, http.StatusOK)
+
+	after := createTaggedAlert(t, orgID, "API latency after deploy", "deploy")
+	got, _ = testServer.AlertService.GetAlert(ctx, after.ID, orgID)
+	if got.MaintenanceWindowID != nil {
+		t.Errorf("Expected alert after the window to page normally, got window %v", got.MaintenanceWindowID)
+	}
+}
@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+)
+
+// ============================================================================
+// GET /api/v1/meta/*
+// ============================================================================
+
+func TestMeta_Priorities(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Get("/api/v1/meta/priorities")
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var result struct {
+		Priorities []dto.EnumValue `json:"priorities"`
+	}
+	client.ParseJSON(resp, &result)
+
+	expected := []string{"P1", "P2", "P3", "P4", "P5"}
+	if len(result.Priorities) != len(expected) {
+		t.Fatalf("Expected %d priorities, got %d", len(expected), len(result.Priorities))
+	}
+	for i, priority := range result.Priorities {
+		if priority.Value != expected[i] {
+			t.Errorf("Expected priority %d to be %s, got %s", i, expected[i], priority.Value)
+		}
+		if priority.Label == "" || priority.Color == "" {
+			t.Errorf("Expected a label and color for %s, got %+v", priority.Value, priority)
+		}
+	}
+}
+
+func TestMeta_SeveritiesAndStatuses(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Get("/api/v1/meta/severities")
+	client.ExpectStatus(resp, http.StatusOK)
+	var severities struct {
+		Severities []dto.EnumValue `json:"severities"`
+	}
+	client.ParseJSON(resp, &severities)
+	if len(severities.Severities) != 4 || severities.Severities[0].Value != "critical" {
+		t.Errorf("Expected four severities starting with critical, got %+v", severities.Severities)
+	}
+
+	resp = client.Get("/api/v1/meta/statuses")
+	client.ExpectStatus(resp, http.StatusOK)
+	var statuses struct {
+		AlertStatuses    []dto.EnumValue `json:"alert_statuses"`
+		IncidentStatuses []dto.EnumValue `json:"incident_statuses"`
+	}
+	client.ParseJSON(resp, &statuses)
+	if len(statuses.AlertStatuses) != 4 {
+		t.Errorf("Expected 4 alert statuses, got %d", len(statuses.AlertStatuses))
+	}
+	if len(statuses.IncidentStatuses) != 4 {
+		t.Errorf("Expected 4 incident statuses, got %d", len(statuses.IncidentStatuses))
+	}
+}
+
+func TestMeta_Unauthorized(t *testing.T) {
+	client := newTestClient(t)
+
+	resp := client.Get("/api/v1/meta/priorities")
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+}
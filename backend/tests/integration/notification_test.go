@@ -4,8 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/tests/integration/testutils"
 )
 
 // ============================================================================
@@ -63,6 +75,82 @@ func TestNotifications_CreateChannel_Unauthorized(t *testing.T) {
 	client.ExpectStatus(resp, http.StatusUnauthorized)
 }
 
+func TestNotifications_CreateChannel_ValidSlackConfig(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	reqBody := map[string]interface{}{
+		"name":         "Slack Alerts",
+		"channel_type": "slack",
+		"is_enabled":   true,
+		"config": map[string]interface{}{
+			"webhook_url": "https://hooks.slack.com/services/T000/B000/XXXX",
+			"channel":     "#alerts",
+		},
+	}
+
+	resp := client.Post("/api/v1/notifications/channels", reqBody)
+	client.AssertStatus(resp, http.StatusCreated)
+}
+
+func TestNotifications_CreateChannel_SlackMissingWebhookURL(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	reqBody := map[string]interface{}{
+		"name":         "Slack Alerts",
+		"channel_type": "slack",
+		"is_enabled":   true,
+		"config":       map[string]interface{}{"channel": "#alerts"},
+	}
+
+	resp := client.Post("/api/v1/notifications/channels", reqBody)
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	var result map[string]interface{}
+	client.ParseJSON(resp, &result)
+	if msg, _ := result["error"].(string); !strings.Contains(msg, "webhook_url") {
+		t.Errorf("Expected error to name webhook_url, got %q", msg)
+	}
+}
+
+func TestNotifications_CreateChannel_EmailInvalidPort(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	reqBody := map[string]interface{}{
+		"name":         "Email Notifications",
+		"channel_type": "email",
+		"is_enabled":   true,
+		"config": map[string]interface{}{
+			"smtp_host":    "smtp.example.com",
+			"smtp_port":    70000,
+			"from_address": "alerts@example.com",
+		},
+	}
+
+	resp := client.Post("/api/v1/notifications/channels", reqBody)
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	var result map[string]interface{}
+	client.ParseJSON(resp, &result)
+	if msg, _ := result["error"].(string); !strings.Contains(msg, "smtp_port") {
+		t.Errorf("Expected error to name smtp_port, got %q", msg)
+	}
+}
+
 // ============================================================================
 // GET /api/v1/notifications/channels
 // ============================================================================
@@ -140,6 +228,72 @@ func TestNotifications_GetChannel_Success(t *testing.T) {
 	}
 }
 
+func TestNotifications_GetChannel_RedactsSecrets(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	channel, _ := testFixtures.CreateNotificationChannel(ctx, user.Organization.ID, "Email Channel")
+
+	resp := client.Get(fmt.Sprintf("/api/v1/notifications/channels/%s", channel.ID))
+	client.AssertStatus(resp, http.StatusOK)
+
+	var got domain.NotificationChannel
+	client.ParseJSON(resp, &got)
+
+	config := string(got.Config)
+	if strings.Contains(config, "testpassword") {
+		t.Errorf("Expected smtp_password to be redacted, got config %s", config)
+	}
+	if !strings.Contains(config, domain.RedactedValue) {
+		t.Errorf("Expected config to contain %s, got %s", domain.RedactedValue, config)
+	}
+	if !strings.Contains(config, "smtp.test.com") {
+		t.Errorf("Expected non-secret fields to be kept, got config %s", config)
+	}
+}
+
+func TestNotifications_ListChannels_RedactsSecrets(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Post("/api/v1/notifications/channels", map[string]interface{}{
+		"name":         "Slack Channel",
+		"channel_type": "slack",
+		"is_enabled":   true,
+		"config": map[string]interface{}{
+			"webhook_url": "https://hooks.slack.com/services/T000/B000/SECRET",
+		},
+	})
+	client.AssertStatus(resp, http.StatusCreated)
+
+	resp = client.Get("/api/v1/notifications/channels")
+	client.AssertStatus(resp, http.StatusOK)
+
+	var result struct {
+		Channels []domain.NotificationChannel `json:"channels"`
+	}
+	client.ParseJSON(resp, &result)
+
+	if len(result.Channels) != 1 {
+		t.Fatalf("Expected 1 channel, got %d", len(result.Channels))
+	}
+	config := string(result.Channels[0].Config)
+	if strings.Contains(config, "SECRET") {
+		t.Errorf("Expected webhook_url to be redacted, got config %s", config)
+	}
+	if !strings.Contains(config, domain.RedactedValue) {
+		t.Errorf("Expected config to contain %s, got %s", domain.RedactedValue, config)
+	}
+}
+
 func TestNotifications_GetChannel_NotFound(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
@@ -198,6 +352,177 @@ func TestNotifications_UpdateChannel_NotFound(t *testing.T) {
 	client.ExpectStatus(resp, http.StatusBadRequest) // API returns 400 for not found errors
 }
 
+func TestNotifications_UpdateChannel_TypeChangeRevalidatesConfig(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	channel, _ := testFixtures.CreateNotificationChannel(ctx, user.Organization.ID, "Email Channel")
+
+	// The email config has no webhook_url, so it is not a valid Slack config
+	resp := client.Patch(fmt.Sprintf("/api/v1/notifications/channels/%s", channel.ID), map[string]interface{}{
+		"channel_type": "slack",
+	})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+func TestNotifications_UpdateChannel_KeepsRedactedSecrets(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	channel, _ := testFixtures.CreateNotificationChannel(ctx, user.Organization.ID, "Email Channel")
+
+	// Send back the config as read, with only the host changed
+	resp := client.Patch(fmt.Sprintf("/api/v1/notifications/channels/%s", channel.ID), map[string]interface{}{
+		"config": map[string]interface{}{
+			"smtp_host":     "smtp.changed.com",
+			"smtp_port":     587,
+			"smtp_username": "test@test.com",
+			"smtp_password": domain.RedactedValue,
+			"from_address":  "test@test.com",
+		},
+	})
+	client.AssertStatus(resp, http.StatusOK)
+
+	stored, err := testServer.NotificationService.GetChannel(ctx, channel.ID)
+	if err != nil {
+		t.Fatalf("Failed to get channel: %v", err)
+	}
+	var config map[string]interface{}
+	if err := json.Unmarshal(stored.Config, &config); err != nil {
+		t.Fatalf("Failed to parse stored config: %v", err)
+	}
+	if config["smtp_password"] != "testpassword" {
+		t.Errorf("Expected stored smtp_password to be kept, got %v", config["smtp_password"])
+	}
+	if config["smtp_host"] != "smtp.changed.com" {
+		t.Errorf("Expected smtp_host to be updated, got %v", config["smtp_host"])
+	}
+}
+
+// ============================================================================
+// POST /api/v1/notifications/channels/:id/test
+// ============================================================================
+
+// createStubWebhookChannel creates a webhook channel pointing at a stub
+// provider that answers with the given status and body
+func createStubWebhookChannel(t *testing.T, client *testutils.TestClient, status int, body string) (string, *[]byte) {
+	t.Helper()
+	var received []byte
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(stub.Close)
+
+	resp := client.Post("/api/v1/notifications/channels", map[string]interface{}{
+		"name":         "Stub Webhook",
+		"channel_type": "webhook",
+		"is_enabled":   true,
+		"config":       map[string]interface{}{"url": stub.URL},
+	})
+	client.AssertStatus(resp, http.StatusCreated)
+
+	var channel domain.NotificationChannel
+	client.ParseJSON(resp, &channel)
+	return channel.ID.String(), &received
+}
+
+func TestNotifications_TestChannel_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	channelID, received := createStubWebhookChannel(t, client, http.StatusOK, "ok")
+
+	resp := client.Post(fmt.Sprintf("/api/v1/notifications/channels/%s/test", channelID), nil)
+	client.AssertStatus(resp, http.StatusOK)
+
+	var result dto.TestNotificationChannelResponse
+	client.ParseJSON(resp, &result)
+
+	if !result.Success {
+		t.Errorf("Expected test send to succeed, got error %v", result.Error)
+	}
+	if len(*received) == 0 {
+		t.Error("Expected the stub provider to receive the test notification")
+	}
+	if result.Log == nil || !result.Log.IsTest {
+		t.Fatalf("Expected a notification log flagged as a test, got %+v", result.Log)
+	}
+	if result.Log.Status != domain.NotificationStatusSent {
+		t.Errorf("Expected log status sent, got %s", result.Log.Status)
+	}
+}
+
+func TestNotifications_TestChannel_ProviderFailure(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	channelID, _ := createStubWebhookChannel(t, client, http.StatusForbidden, "invalid_token")
+
+	resp := client.Post(fmt.Sprintf("/api/v1/notifications/channels/%s/test", channelID), nil)
+	client.AssertStatus(resp, http.StatusOK)
+
+	var result dto.TestNotificationChannelResponse
+	client.ParseJSON(resp, &result)
+
+	if result.Success {
+		t.Error("Expected test send to fail")
+	}
+	if result.Error == nil || !strings.Contains(*result.Error, "invalid_token") {
+		t.Errorf("Expected the provider response in the error, got %v", result.Error)
+	}
+	if result.Log == nil || !result.Log.IsTest || result.Log.Status != domain.NotificationStatusFailed {
+		t.Errorf("Expected a failed test log, got %+v", result.Log)
+	}
+}
+
+func TestNotifications_TestChannel_EmailRequiresRecipient(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	channel, _ := testFixtures.CreateNotificationChannel(ctx, user.Organization.ID, "Email Channel")
+
+	resp := client.Post(fmt.Sprintf("/api/v1/notifications/channels/%s/test", channel.ID), nil)
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+func TestNotifications_TestChannel_OtherOrganization(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	outsider, _ := testFixtures.CreateUniqueUser(ctx)
+	channel, _ := testFixtures.CreateNotificationChannel(ctx, owner.Organization.ID, "Email Channel")
+
+	client.SetAuthToken(outsider.AccessToken)
+	resp := client.Post(fmt.Sprintf("/api/v1/notifications/channels/%s/test", channel.ID), map[string]string{
+		"recipient": "outsider@example.com",
+	})
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
 // ============================================================================
 // DELETE /api/v1/notifications/channels/:id
 // ============================================================================
@@ -370,6 +695,123 @@ func TestNotifications_ListLogs_Success(t *testing.T) {
 	client.AssertStatus(resp, http.StatusOK)
 }
 
+// insertNotificationLog records a delivery attempt through a channel
+func insertNotificationLog(t *testing.T, ctx context.Context, orgID, channelID uuid.UUID, status domain.NotificationStatus, createdAt time.Time) uuid.UUID {
+	t.Helper()
+
+	var id uuid.UUID
+	if err := testDB.QueryRowContext(ctx, `
+		INSERT INTO notification_logs (organization_id, channel_id, recipient, message, status, created_at)
+		VALUES ($1, $2, 'oncall@example.com', 'Disk full', $3, $4)
+		RETURNING id`,
+		orgID, channelID, status, createdAt,
+	).Scan(&id); err != nil {
+		t.Fatalf("Failed to insert notification log: %v", err)
+	}
+	return id
+}
+
+func listNotificationLogs(t *testing.T, client *testutils.TestClient, query url.Values) dto.ListNotificationLogsResponse {
+	t.Helper()
+
+	resp := client.Get("/api/v1/notifications/logs?" + query.Encode())
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var result dto.ListNotificationLogsResponse
+	client.ParseJSON(resp, &result)
+	return result
+}
+
+func TestNotifications_ListLogs_Pagination(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	result := listNotificationLogs(t, client, url.Values{"limit": {"1000000"}})
+	if result.Limit != domain.MaxListLimit {
+		t.Errorf("Expected the limit to be clamped to %d, got %d", domain.MaxListLimit, result.Limit)
+	}
+
+	resp := client.Get("/api/v1/notifications/logs?offset=-1")
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+func TestNotifications_ListLogs_FilterByStatus(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	channel, _ := testFixtures.CreateUniqueNotificationChannel(ctx, user.Organization.ID)
+
+	now := time.Now()
+	insertNotificationLog(t, ctx, user.Organization.ID, channel.ID, domain.NotificationStatusSent, now)
+	failed := insertNotificationLog(t, ctx, user.Organization.ID, channel.ID, domain.NotificationStatusFailed, now)
+	insertNotificationLog(t, ctx, user.Organization.ID, channel.ID, domain.NotificationStatusSent, now)
+
+	result := listNotificationLogs(t, client, url.Values{"status": {"failed"}})
+	if result.Total != 1 || len(result.Logs) != 1 || result.Logs[0].ID != failed {
+		t.Fatalf("Expected only the failed log, got %d logs (total %d)", len(result.Logs), result.Total)
+	}
+
+	result = listNotificationLogs(t, client, url.Values{"status": {"failed", "sent"}})
+	if result.Total != 3 {
+		t.Errorf("Expected 3 failed or sent logs, got %d", result.Total)
+	}
+
+	resp := client.Get("/api/v1/notifications/logs?status=bounced")
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+func TestNotifications_ListLogs_FilterByChannelAndDate(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	slack, _ := testFixtures.CreateUniqueNotificationChannel(ctx, user.Organization.ID)
+	email, _ := testFixtures.CreateUniqueNotificationChannel(ctx, user.Organization.ID)
+
+	now := time.Now()
+	today := insertNotificationLog(t, ctx, user.Organization.ID, slack.ID, domain.NotificationStatusFailed, now)
+	insertNotificationLog(t, ctx, user.Organization.ID, slack.ID, domain.NotificationStatusFailed, now.Add(-48*time.Hour))
+	insertNotificationLog(t, ctx, user.Organization.ID, email.ID, domain.NotificationStatusFailed, now)
+
+	result := listNotificationLogs(t, client, url.Values{"channel_id": {slack.ID.String()}})
+	if result.Total != 2 {
+		t.Fatalf("Expected 2 logs for the channel, got %d", result.Total)
+	}
+	for _, log := range result.Logs {
+		if log.ChannelID != slack.ID {
+			t.Errorf("Expected logs for channel %s, got %s", slack.ID, log.ChannelID)
+		}
+	}
+
+	result = listNotificationLogs(t, client, url.Values{
+		"status":     {"failed"},
+		"channel_id": {slack.ID.String()},
+		"from":       {now.Add(-time.Hour).UTC().Format(time.RFC3339)},
+		"to":         {now.Add(time.Hour).UTC().Format(time.RFC3339)},
+	})
+	if result.Total != 1 || result.Logs[0].ID != today {
+		t.Fatalf("Expected only today's failed log for the channel, got %d", result.Total)
+	}
+
+	resp := client.Get("/api/v1/notifications/logs?channel_id=not-a-uuid")
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	resp = client.Get("/api/v1/notifications/logs?" + url.Values{
+		"from": {now.UTC().Format(time.RFC3339)},
+		"to":   {now.Add(-time.Hour).UTC().Format(time.RFC3339)},
+	}.Encode())
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
 // ============================================================================
 // GET /api/v1/notifications/logs/:id
 // ============================================================================
@@ -402,6 +844,28 @@ func TestNotifications_ListLogsByUser_Success(t *testing.T) {
 	client.AssertStatus(resp, http.StatusOK)
 }
 
+func TestNotifications_ListLogsByUser_Pagination(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Get("/api/v1/notifications/logs/user/me?limit=1000000")
+	client.ExpectStatus(resp, http.StatusOK)
+	var result struct {
+		Limit int `json:"limit"`
+	}
+	client.ParseJSON(resp, &result)
+	if result.Limit != domain.MaxListLimit {
+		t.Errorf("Expected the limit to be clamped to %d, got %d", domain.MaxListLimit, result.Limit)
+	}
+
+	resp = client.Get("/api/v1/notifications/logs/user/me?offset=-5")
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
 // ============================================================================
 // GET /api/v1/notifications/logs/alert/:alertId
 // ============================================================================
@@ -419,3 +883,375 @@ func TestNotifications_ListLogsByAlert_Success(t *testing.T) {
 	resp := client.Get(fmt.Sprintf("/api/v1/notifications/logs/alert/%s", alert.ID))
 	client.AssertStatus(resp, http.StatusOK)
 }
+
+// ============================================================================
+// Notification retries
+// ============================================================================
+
+// createFlakyWebhookChannel creates a webhook channel whose stub provider
+// rejects the first failures requests and accepts the rest
+func createFlakyWebhookChannel(t *testing.T, ctx context.Context, orgID uuid.UUID, failures int32) *domain.NotificationChannel {
+	t.Helper()
+	var calls int32
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(stub.Close)
+
+	config, _ := json.Marshal(map[string]interface{}{"url": stub.URL})
+	channel, err := testServer.NotificationService.CreateChannel(ctx, orgID, &dto.CreateNotificationChannelRequest{
+		Name:        "Flaky Webhook",
+		ChannelType: domain.ChannelTypeWebhook,
+		IsEnabled:   true,
+		Config:      config,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create channel: %v", err)
+	}
+	return channel
+}
+
+// sendFailingPage sends a page for the alert that is expected to fail and
+// returns its log
+func sendFailingPage(t *testing.T, ctx context.Context, orgID, channelID, alertID uuid.UUID) *domain.NotificationLog {
+	t.Helper()
+
+	log, err := testServer.NotificationService.SendNotification(ctx, orgID, &dto.SendNotificationRequest{
+		ChannelID: channelID,
+		AlertID:   &alertID,
+		Recipient: "oncall@example.com",
+		Message:   "Disk full",
+	})
+	if err == nil {
+		t.Fatal("Expected the first send to fail")
+	}
+
+	log, err = testServer.NotificationService.GetLog(ctx, log.ID)
+	if err != nil {
+		t.Fatalf("Failed to get notification log: %v", err)
+	}
+	return log
+}
+
+// retryUntilSettled runs the retry worker at each scheduled retry time until
+// the notification is sent or given up on
+func retryUntilSettled(t *testing.T, ctx context.Context, log *domain.NotificationLog) *domain.NotificationLog {
+	t.Helper()
+
+	for i := 0; log.NextRetryAt != nil; i++ {
+		if i > domain.NotificationMaxAttemptsCritical {
+			t.Fatalf("Expected retries to stop, still scheduled after %d attempts", log.Attempts)
+		}
+		if err := testServer.NotificationService.RetryFailedNotifications(ctx, *log.NextRetryAt, 10); err != nil {
+			t.Fatalf("Failed to retry notifications: %v", err)
+		}
+
+		var err error
+		log, err = testServer.NotificationService.GetLog(ctx, log.ID)
+		if err != nil {
+			t.Fatalf("Failed to get notification log: %v", err)
+		}
+	}
+	return log
+}
+
+func TestNotifications_Retry_TransientFailureSucceeds(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	channel := createFlakyWebhookChannel(t, ctx, user.Organization.ID, 1)
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Disk full")
+
+	log := sendFailingPage(t, ctx, user.Organization.ID, channel.ID, alert.ID)
+	if log.Status != domain.NotificationStatusFailed || log.Attempts != 1 {
+		t.Fatalf("Expected a failed first attempt, got %s after %d attempts", log.Status, log.Attempts)
+	}
+	if log.NextRetryAt == nil {
+		t.Fatal("Expected the failure to be scheduled for a retry")
+	}
+
+	// Nothing is retried before the backoff has passed
+	if err := testServer.NotificationService.RetryFailedNotifications(ctx, time.Now(), 10); err != nil {
+		t.Fatalf("Failed to retry notifications: %v", err)
+	}
+	early, _ := testServer.NotificationService.GetLog(ctx, log.ID)
+	if early.Attempts != 1 {
+		t.Fatalf("Expected no retry before %s, got %d attempts", log.NextRetryAt, early.Attempts)
+	}
+
+	log = retryUntilSettled(t, ctx, log)
+	if log.Status != domain.NotificationStatusSent {
+		t.Errorf("Expected the retry to be sent, got %s", log.Status)
+	}
+	if log.Attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", log.Attempts)
+	}
+	if log.ErrorMessage != nil {
+		t.Errorf("Expected the error to be cleared, got %s", *log.ErrorMessage)
+	}
+}
+
+func TestNotifications_Retry_PermanentFailureGivesUp(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	channel := createFlakyWebhookChannel(t, ctx, user.Organization.ID, 1000)
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Disk full")
+
+	log := retryUntilSettled(t, ctx, sendFailingPage(t, ctx, user.Organization.ID, channel.ID, alert.ID))
+	if log.Status != domain.NotificationStatusFailed {
+		t.Errorf("Expected the notification to stay failed, got %s", log.Status)
+	}
+	if log.Attempts != domain.NotificationMaxAttempts {
+		t.Errorf("Expected %d attempts, got %d", domain.NotificationMaxAttempts, log.Attempts)
+	}
+}
+
+func TestNotifications_Retry_CriticalAlertsGetMoreAttempts(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	channel := createFlakyWebhookChannel(t, ctx, user.Organization.ID, 1000)
+	alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Database down")
+	if _, err := testDB.ExecContext(ctx, `UPDATE alerts SET priority = 'P1' WHERE id = $1`, alert.ID); err != nil {
+		t.Fatalf("Failed to raise alert priority: %v", err)
+	}
+
+	log := retryUntilSettled(t, ctx, sendFailingPage(t, ctx, user.Organization.ID, channel.ID, alert.ID))
+	if log.Attempts != domain.NotificationMaxAttemptsCritical {
+		t.Errorf("Expected %d attempts for a P1 page, got %d", domain.NotificationMaxAttemptsCritical, log.Attempts)
+	}
+}
+
+func TestNotifications_Retry_CancelledOnceAlertHandled(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	channel := createFlakyWebhookChannel(t, ctx, user.Organization.ID, 1000)
+
+	for _, status := range []domain.AlertStatus{domain.AlertStatusAcknowledged, domain.AlertStatusClosed, domain.AlertStatusSnoozed} {
+		alert, _ := testFixtures.CreateAlert(ctx, user.Organization.ID, "Disk full")
+		log := sendFailingPage(t, ctx, user.Organization.ID, channel.ID, alert.ID)
+		if log.NextRetryAt == nil {
+			t.Fatal("Expected the failure to be scheduled for a retry")
+		}
+
+		if _, err := testDB.ExecContext(ctx, `UPDATE alerts SET status = $1 WHERE id = $2`, status, alert.ID); err != nil {
+			t.Fatalf("Failed to update alert status: %v", err)
+		}
+
+		if err := testServer.NotificationService.RetryFailedNotifications(ctx, *log.NextRetryAt, 10); err != nil {
+			t.Fatalf("Failed to retry notifications: %v", err)
+		}
+
+		log, err := testServer.NotificationService.GetLog(ctx, log.ID)
+		if err != nil {
+			t.Fatalf("Failed to get notification log: %v", err)
+		}
+		if log.Attempts != 1 {
+			t.Errorf("Expected no retry once the alert is %s, got %d attempts", status, log.Attempts)
+		}
+		if log.Status != domain.NotificationStatusFailed || log.NextRetryAt != nil {
+			t.Errorf("Expected the notification to be given up on once the alert is %s, got %s (next retry %v)", status, log.Status, log.NextRetryAt)
+		}
+	}
+}
+
+// ============================================================================
+// Notification templates
+// ============================================================================
+
+func TestNotifications_Templates_RenderedPerChannelType(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	email, _ := testFixtures.CreateNotificationChannel(ctx, orgID, "Email")
+	terse := createFlakyWebhookChannel(t, ctx, orgID, 0)
+
+	// A terse, SMS-style template for the webhook; email keeps the rich default
+	resp := client.Patch("/api/v1/organizations/settings", map[string]interface{}{
+		"notification_templates": map[string]interface{}{
+			"webhook": map[string]string{"body": "{{.Alert.Priority}} {{truncate 12 .Alert.Message}}"},
+		},
+	})
+	client.AssertStatus(resp, http.StatusOK)
+
+	description := "Primary is refusing connections"
+	alert, err := testServer.AlertService.CreateAlert(ctx, orgID, &dto.CreateAlertRequest{
+		Source:      "monitoring",
+		Priority:    "P2",
+		Message:     "Database connection pool exhausted",
+		Description: &description,
+		Tags:        []string{"db", "prod"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+	targets := []domain.EscalationTarget{
+		{TargetType: domain.EscalationTargetTypeUser, TargetID: user.User.ID},
+	}
+	if err := testServer.AlertNotifier.NotifyAlertEscalated(ctx, alert, &domain.EscalationRule{}, targets); err != nil {
+		t.Fatalf("Failed to notify escalation: %v", err)
+	}
+
+	logs, err := testServer.NotificationService.ListLogsByAlert(ctx, alert.ID)
+	if err != nil {
+		t.Fatalf("Failed to list notification logs: %v", err)
+	}
+	messages := make(map[uuid.UUID]domain.NotificationLog)
+	for _, log := range logs {
+		messages[log.ChannelID] = log
+	}
+	if len(messages) != 2 {
+		t.Fatalf("Expected a notification per channel, got %d", len(logs))
+	}
+
+	if got := messages[terse.ID].Message; got != "P2 Database co…" {
+		t.Errorf("Expected the terse webhook message, got %q", got)
+	}
+	// The subject isn't overridden, so it falls back to the built-in one
+	if subject := messages[terse.ID].Subject; subject == nil || *subject != "[P2] Alert Escalated: Database connection pool exhausted" {
+		t.Errorf("Expected the default subject, got %v", subject)
+	}
+
+	rich := messages[email.ID].Message
+	for _, want := range []string{alert.ID.String(), "Source: monitoring", "Tags: db, prod", description} {
+		if !strings.Contains(rich, want) {
+			t.Errorf("Expected the email to contain %q, got %q", want, rich)
+		}
+	}
+}
+
+func TestNotifications_Templates_Invalid(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	tests := []struct {
+		name      string
+		templates map[string]interface{}
+	}{
+		{"unknown field", map[string]interface{}{"email": map[string]string{"body": "{{.Alert.Nope}}"}}},
+		{"syntax error", map[string]interface{}{"slack": map[string]string{"subject": "{{.Alert.Message"}}},
+		{"unknown channel type", map[string]interface{}{"pager": map[string]string{"body": "{{.Alert.Message}}"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := client.Patch("/api/v1/organizations/settings", map[string]interface{}{
+				"notification_templates": tt.templates,
+			})
+			client.AssertStatus(resp, http.StatusBadRequest)
+		})
+	}
+
+	// Rejected templates aren't stored
+	resp := client.Get("/api/v1/organizations/settings")
+	client.ExpectStatus(resp, http.StatusOK)
+	var result struct {
+		Settings struct {
+			NotificationTemplates map[string]dto.NotificationTemplateSettings `json:"notification_templates"`
+		} `json:"settings"`
+	}
+	client.ParseJSON(resp, &result)
+	for channelType, want := range domain.DefaultNotificationTemplates {
+		if got := result.Settings.NotificationTemplates[string(channelType)]; got.Body != want.Body {
+			t.Errorf("Expected the default %s template, got %q", channelType, got.Body)
+		}
+	}
+}
+
+// ============================================================================
+// Primary channel only below a priority
+// ============================================================================
+
+// escalatedChannels pages the user about an alert of the given priority and
+// returns the channels notifications were logged for
+func escalatedChannels(t *testing.T, ctx context.Context, user *testutils.TestUser, priority string) []uuid.UUID {
+	t.Helper()
+
+	alert := createAlertWithPriority(t, user.Organization.ID, priority, "Checkout errors")
+	targets := []domain.EscalationTarget{
+		{TargetType: domain.EscalationTargetTypeUser, TargetID: user.User.ID},
+	}
+	if err := testServer.AlertNotifier.NotifyAlertEscalated(ctx, alert, &domain.EscalationRule{}, targets); err != nil {
+		t.Fatalf("Failed to notify escalation: %v", err)
+	}
+
+	logs, err := testServer.NotificationService.ListLogsByAlert(ctx, alert.ID)
+	if err != nil {
+		t.Fatalf("Failed to list notification logs: %v", err)
+	}
+	channels := make([]uuid.UUID, len(logs))
+	for i, log := range logs {
+		channels[i] = log.ChannelID
+	}
+	return channels
+}
+
+func TestNotifications_PrimaryChannelOnlyBelowPriority(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	email, _ := testFixtures.CreateNotificationChannel(ctx, orgID, "Email")
+	primary := createFlakyWebhookChannel(t, ctx, orgID, 0)
+	if _, err := testServer.NotificationService.CreatePreference(ctx, user.User.ID, &dto.CreateUserNotificationPreferenceRequest{
+		ChannelID: primary.ID,
+		IsEnabled: true,
+	}); err != nil {
+		t.Fatalf("Failed to create notification preference: %v", err)
+	}
+
+	resp := client.Patch("/api/v1/users/me", map[string]interface{}{"primary_channel_only_below": "P2"})
+	client.AssertStatus(resp, http.StatusOK)
+
+	if got := escalatedChannels(t, ctx, user, "P3"); !sameUserIDs(got, []uuid.UUID{primary.ID}) {
+		t.Errorf("Expected a P3 to page only the primary channel %s, got %v", primary.ID, got)
+	}
+	if got := escalatedChannels(t, ctx, user, "P2"); !sameUserIDs(got, []uuid.UUID{primary.ID, email.ID}) {
+		t.Errorf("Expected a P2 to page every channel, got %v", got)
+	}
+	if got := escalatedChannels(t, ctx, user, "P1"); !sameUserIDs(got, []uuid.UUID{primary.ID, email.ID}) {
+		t.Errorf("Expected a P1 to page every channel, got %v", got)
+	}
+
+	// Clearing the setting pages every channel again
+	resp = client.Patch("/api/v1/users/me", map[string]interface{}{"primary_channel_only_below": ""})
+	client.AssertStatus(resp, http.StatusOK)
+	if got := escalatedChannels(t, ctx, user, "P3"); len(got) != 2 {
+		t.Errorf("Expected a P3 to page both channels once cleared, got %v", got)
+	}
+}
+
+func TestNotifications_PrimaryChannelOnly_InvalidPriority(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Patch("/api/v1/users/me", map[string]interface{}{"primary_channel_only_below": "P9"})
+	client.AssertStatus(resp, http.StatusBadRequest)
+}
@@ -0,0 +1,544 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/outbound/postgres"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/tests/integration/testutils"
+)
+
+// ============================================================================
+// GET /api/v1/organizations/export
+// ============================================================================
+
+func TestOrganizations_Export_ContainsSeededEntities(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, err := testFixtures.CreateUniqueUser(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	orgID := user.Organization.ID
+
+	team, err := testFixtures.CreateUniqueTeam(ctx, orgID)
+	if err != nil {
+		t.Fatalf("Failed to create team: %v", err)
+	}
+	schedule, err := testFixtures.CreateUniqueSchedule(ctx, orgID)
+	if err != nil {
+		t.Fatalf("Failed to create schedule: %v", err)
+	}
+	policy, err := testFixtures.CreateUniqueEscalationPolicy(ctx, orgID)
+	if err != nil {
+		t.Fatalf("Failed to create escalation policy: %v", err)
+	}
+	channel, err := testFixtures.CreateUniqueNotificationChannel(ctx, orgID)
+	if err != nil {
+		t.Fatalf("Failed to create notification channel: %v", err)
+	}
+	alert, err := testFixtures.CreateUniqueAlert(ctx, orgID)
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+	incident, err := testFixtures.CreateUniqueIncident(ctx, orgID, user.User.ID)
+	if err != nil {
+		t.Fatalf("Failed to create incident: %v", err)
+	}
+
+	// Another organization's data must not leak into the export
+	other, err := testFixtures.CreateUniqueUser(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create other user: %v", err)
+	}
+	otherAlert, err := testFixtures.CreateUniqueAlert(ctx, other.Organization.ID)
+	if err != nil {
+		t.Fatalf("Failed to create other alert: %v", err)
+	}
+
+	client.SetAuthToken(user.AccessToken)
+	resp := client.Get("/api/v1/organizations/export")
+	client.AssertStatus(resp, http.StatusOK)
+
+	if cd := resp.Header.Get("Content-Disposition"); !strings.HasPrefix(cd, "attachment") {
+		t.Errorf("Expected attachment Content-Disposition, got %q", cd)
+	}
+
+	var export dto.OrganizationExport
+	client.ParseJSON(resp, &export)
+
+	if export.Version != dto.OrganizationExportVersion {
+		t.Errorf("Expected version %d, got %d", dto.OrganizationExportVersion, export.Version)
+	}
+	if export.Organization.ID != orgID {
+		t.Errorf("Expected organization %s, got %s", orgID, export.Organization.ID)
+	}
+	if len(export.Teams) != 1 || export.Teams[0].ID != team.ID {
+		t.Errorf("Expected exported team %s, got %+v", team.ID, export.Teams)
+	}
+	if len(export.Schedules) != 1 || export.Schedules[0].ID != schedule.ID {
+		t.Errorf("Expected exported schedule %s, got %+v", schedule.ID, export.Schedules)
+	}
+	if len(export.EscalationPolicies) != 1 || export.EscalationPolicies[0].ID != policy.ID {
+		t.Errorf("Expected exported escalation policy %s, got %+v", policy.ID, export.EscalationPolicies)
+	}
+	if len(export.NotificationChannels) != 1 || export.NotificationChannels[0].ID != channel.ID {
+		t.Errorf("Expected exported channel %s, got %+v", channel.ID, export.NotificationChannels)
+	}
+	if len(export.Incidents) != 1 || export.Incidents[0].ID != incident.ID {
+		t.Errorf("Expected exported incident %s, got %+v", incident.ID, export.Incidents)
+	}
+
+	foundAlert := false
+	for _, a := range export.Alerts {
+		if a.ID == otherAlert.ID {
+			t.Error("Export contains another organization's alert")
+		}
+		if a.ID == alert.ID {
+			foundAlert = true
+		}
+	}
+	if !foundAlert {
+		t.Errorf("Expected exported alert %s", alert.ID)
+	}
+}
+
+func TestOrganizations_Export_RedactsSecrets(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, err := testFixtures.CreateUniqueUser(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	// The fixture channel is configured with smtp_password "testpassword"
+	if _, err := testFixtures.CreateUniqueNotificationChannel(ctx, user.Organization.ID); err != nil {
+		t.Fatalf("Failed to create notification channel: %v", err)
+	}
+
+	client.SetAuthToken(user.AccessToken)
+	resp := client.Get("/api/v1/organizations/export")
+	client.AssertStatus(resp, http.StatusOK)
+
+	body := client.ReadBody(resp)
+	if strings.Contains(body, "testpassword") {
+		t.Error("Export leaks the SMTP password")
+	}
+	if strings.Contains(body, "PasswordHash") || strings.Contains(body, "$2a$") {
+		t.Error("Export leaks password hashes")
+	}
+	if !strings.Contains(body, domain.RedactedValue) {
+		t.Error("Expected redacted marker in exported channel config")
+	}
+	if !strings.Contains(body, "smtp.test.com") {
+		t.Error("Expected non-secret channel config to be preserved")
+	}
+}
+
+func TestOrganizations_Export_RequiresAdmin(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, err := testFixtures.CreateUniqueUser(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	// Demote to member and log in again so the token carries the new role
+	if _, err := testDB.ExecContext(ctx,
+		`UPDATE organization_users SET role = 'member' WHERE user_id = $1`, user.User.ID); err != nil {
+		t.Fatalf("Failed to demote user: %v", err)
+	}
+	resp := client.Post("/api/v1/auth/login", map[string]string{
+		"email":    user.User.Email,
+		"password": "TestPassword123!",
+	})
+	client.AssertStatus(resp, http.StatusOK)
+	var login map[string]interface{}
+	client.ParseJSON(resp, &login)
+	token, _ := login["access_token"].(string)
+
+	client.SetAuthToken(token)
+	resp = client.Get("/api/v1/organizations/export")
+	client.AssertStatus(resp, http.StatusForbidden)
+}
+
+func TestOrganizations_Export_Unauthorized(t *testing.T) {
+	client := newTestClient(t)
+
+	resp := client.Get("/api/v1/organizations/export")
+	client.AssertStatus(resp, http.StatusUnauthorized)
+}
+
+// ============================================================================
+// POST /api/v1/organizations/import
+// ============================================================================
+
+// seedImportSource creates a team with a member, a team schedule with a rotation,
+// an escalation policy targeting both, a routing rule and a notification channel.
+func seedImportSource(t *testing.T, ctx context.Context, user *testutils.TestUser) {
+	t.Helper()
+	orgID := user.Organization.ID
+
+	team, err := testFixtures.CreateTeam(ctx, orgID, "Platform")
+	if err != nil {
+		t.Fatalf("Failed to create team: %v", err)
+	}
+	if err := testServer.TeamService.AddMember(ctx, team.ID, &dto.AddTeamMemberRequest{UserID: &user.User.ID, Role: "lead"}); err != nil {
+		t.Fatalf("Failed to add team member: %v", err)
+	}
+
+	schedule, err := testServer.ScheduleService.CreateSchedule(ctx, orgID, &dto.CreateScheduleRequest{
+		TeamID:   &team.ID,
+		Name:     "Platform On-Call",
+		Timezone: "Europe/Berlin",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create schedule: %v", err)
+	}
+	rotation, err := testServer.ScheduleService.CreateRotation(ctx, schedule.ID, &dto.CreateRotationRequest{
+		Name:           "Weekly",
+		RotationType:   "weekly",
+		RotationLength: 1,
+		StartDate:      "2026-01-05",
+		StartTime:      "08:00",
+		HandoffTime:    "09:30",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rotation: %v", err)
+	}
+	if _, err := testServer.ScheduleService.AddParticipant(ctx, rotation.ID, &dto.AddParticipantRequest{UserID: user.User.ID}); err != nil {
+		t.Fatalf("Failed to add participant: %v", err)
+	}
+
+	policy, err := testFixtures.CreateEscalationPolicy(ctx, orgID, "Platform Escalation")
+	if err != nil {
+		t.Fatalf("Failed to create escalation policy: %v", err)
+	}
+	if _, err := testServer.EscalationService.UpdatePolicy(ctx, policy.ID, &dto.UpdateEscalationPolicyRequest{
+		Conditions:    json.RawMessage(`{"match":"all","conditions":[{"field":"priority","operator":"equals","value":"P1"}]}`),
+		BusinessHours: json.RawMessage(`{"timezone":"Europe/Berlin","weekly":[{"day":"monday","start":"09:00","end":"17:00"}]}`),
+	}); err != nil {
+		t.Fatalf("Failed to set escalation policy conditions: %v", err)
+	}
+	rule, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: 1, EscalationDelay: 15})
+	if err != nil {
+		t.Fatalf("Failed to create escalation rule: %v", err)
+	}
+	for _, target := range []*dto.AddEscalationTargetRequest{
+		{TargetType: "schedule", TargetID: schedule.ID},
+		{TargetType: "team", TargetID: team.ID},
+	} {
+		if _, err := testServer.EscalationService.AddTarget(ctx, orgID, rule.ID, target); err != nil {
+			t.Fatalf("Failed to add escalation target: %v", err)
+		}
+	}
+
+	createRoutingRule(t, ctx, orgID, domain.RoutingActions{
+		AssignTeamID:             &team.ID,
+		AssignEscalationPolicyID: &policy.ID,
+	})
+
+	if _, err := testFixtures.CreateNotificationChannel(ctx, orgID, "Ops Email"); err != nil {
+		t.Fatalf("Failed to create notification channel: %v", err)
+	}
+}
+
+func createRoutingRule(t *testing.T, ctx context.Context, orgID uuid.UUID, actions domain.RoutingActions) {
+	t.Helper()
+	actionsJSON, _ := json.Marshal(actions)
+	conditionsJSON, _ := json.Marshal(domain.RoutingConditions{
+		Match:      "all",
+		Conditions: []domain.RoutingCondition{{Field: "source", Operator: "equals", Value: "prometheus"}},
+	})
+	repo := postgres.NewRoutingRuleRepository(&postgres.DB{DB: testDB.DB})
+	err := repo.Create(ctx, &domain.AlertRoutingRule{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		Name:           "Route Prometheus",
+		Priority:       1,
+		Conditions:     conditionsJSON,
+		Actions:        actionsJSON,
+		Enabled:        true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create routing rule: %v", err)
+	}
+}
+
+func exportOrganization(t *testing.T, client *testutils.TestClient, token string) dto.OrganizationExport {
+	t.Helper()
+	client.SetAuthToken(token)
+	resp := client.Get("/api/v1/organizations/export")
+	client.AssertStatus(resp, http.StatusOK)
+
+	var export dto.OrganizationExport
+	client.ParseJSON(resp, &export)
+	return export
+}
+
+func TestOrganizations_Import_RoundTrip(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	source, err := testFixtures.CreateUniqueUser(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create source user: %v", err)
+	}
+	seedImportSource(t, ctx, source)
+	original := exportOrganization(t, client, source.AccessToken)
+
+	target, err := testFixtures.CreateUniqueUser(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create target user: %v", err)
+	}
+	// The source user also belongs to the target organization
+	orgRepo := postgres.NewOrganizationRepository(&postgres.DB{DB: testDB.DB})
+	if err := orgRepo.AddUser(ctx, target.Organization.ID, source.User.ID, domain.RoleMember); err != nil {
+		t.Fatalf("Failed to add source user to target organization: %v", err)
+	}
+
+	client.SetAuthToken(target.AccessToken)
+	resp := client.Post("/api/v1/organizations/import", original)
+	client.AssertStatus(resp, http.StatusCreated)
+
+	var result dto.OrganizationImportResponse
+	client.ParseJSON(resp, &result)
+
+	if result.Teams != 1 || result.Schedules != 1 || result.EscalationPolicies != 1 ||
+		result.RoutingRules != 1 || result.NotificationChannels != 1 {
+		t.Errorf("Unexpected import counts: %+v", result)
+	}
+	if len(result.DisabledChannels) != 1 {
+		t.Errorf("Expected the channel with redacted secrets to be disabled, got %v", result.DisabledChannels)
+	}
+
+	imported := exportOrganization(t, client, target.AccessToken)
+
+	// Teams
+	if len(imported.Teams) != 1 {
+		t.Fatalf("Expected 1 imported team, got %d", len(imported.Teams))
+	}
+	oldTeam, newTeam := original.Teams[0], imported.Teams[0]
+	if newTeam.ID == oldTeam.ID || result.IDMap[oldTeam.ID] != newTeam.ID {
+		t.Errorf("Expected team to get a fresh, mapped ID (old %s, new %s)", oldTeam.ID, newTeam.ID)
+	}
+	if newTeam.Name != oldTeam.Name || len(newTeam.Members) != 1 || newTeam.Members[0].UserID != source.User.ID || newTeam.Members[0].Role != "lead" {
+		t.Errorf("Imported team differs: %+v", newTeam)
+	}
+
+	// Schedules
+	if len(imported.Schedules) != 1 {
+		t.Fatalf("Expected 1 imported schedule, got %d", len(imported.Schedules))
+	}
+	oldSchedule, newSchedule := original.Schedules[0], imported.Schedules[0]
+	if newSchedule.ID != result.IDMap[oldSchedule.ID] {
+		t.Errorf("Expected schedule ID %s, got %s", result.IDMap[oldSchedule.ID], newSchedule.ID)
+	}
+	if newSchedule.TeamID == nil || *newSchedule.TeamID != newTeam.ID {
+		t.Errorf("Expected schedule to reference imported team %s, got %v", newTeam.ID, newSchedule.TeamID)
+	}
+	if newSchedule.Timezone != "Europe/Berlin" || len(newSchedule.Rotations) != 1 {
+		t.Fatalf("Imported schedule differs: %+v", newSchedule)
+	}
+	oldRotation, newRotation := oldSchedule.Rotations[0], newSchedule.Rotations[0]
+	if newRotation.StartDate != oldRotation.StartDate || newRotation.StartTime != oldRotation.StartTime ||
+		newRotation.HandoffTime != oldRotation.HandoffTime || newRotation.RotationType != oldRotation.RotationType {
+		t.Errorf("Imported rotation differs: got %+v, want %+v", newRotation, oldRotation)
+	}
+	if len(newRotation.Participants) != 1 || newRotation.Participants[0].UserID != source.User.ID {
+		t.Errorf("Expected rotation participant %s, got %+v", source.User.ID, newRotation.Participants)
+	}
+
+	// Escalation policies
+	if len(imported.EscalationPolicies) != 1 {
+		t.Fatalf("Expected 1 imported escalation policy, got %d", len(imported.EscalationPolicies))
+	}
+	newPolicy := imported.EscalationPolicies[0]
+	if newPolicy.ID != result.IDMap[original.EscalationPolicies[0].ID] || len(newPolicy.Rules) != 1 {
+		t.Fatalf("Imported escalation policy differs: %+v", newPolicy)
+	}
+	var conditions domain.RoutingConditions
+	if err := json.Unmarshal(newPolicy.Conditions, &conditions); err != nil {
+		t.Fatalf("Failed to parse policy conditions: %v", err)
+	}
+	if len(conditions.Conditions) != 1 || conditions.Conditions[0].Field != "priority" || conditions.Conditions[0].Value != "P1" {
+		t.Errorf("Expected the policy conditions to be imported, got %s", newPolicy.Conditions)
+	}
+	var hours domain.BusinessHours
+	if err := json.Unmarshal(newPolicy.BusinessHours, &hours); err != nil {
+		t.Fatalf("Failed to parse policy business hours: %v", err)
+	}
+	if hours.Timezone != "Europe/Berlin" || len(hours.Weekly) != 1 || hours.Weekly[0].Day != "monday" {
+		t.Errorf("Expected the policy business hours to be imported, got %s", newPolicy.BusinessHours)
+	}
+
+	targets := map[string]uuid.UUID{}
+	for _, target := range newPolicy.Rules[0].Targets {
+		targets[target.TargetType] = target.TargetID
+	}
+	if targets["schedule"] != newSchedule.ID || targets["team"] != newTeam.ID {
+		t.Errorf("Expected escalation targets remapped to %s/%s, got %v", newSchedule.ID, newTeam.ID, targets)
+	}
+
+	// Routing rules
+	if len(imported.RoutingRules) != 1 {
+		t.Fatalf("Expected 1 imported routing rule, got %d", len(imported.RoutingRules))
+	}
+	var actions domain.RoutingActions
+	if err := json.Unmarshal(imported.RoutingRules[0].Actions, &actions); err != nil {
+		t.Fatalf("Failed to parse routing actions: %v", err)
+	}
+	if actions.AssignTeamID == nil || *actions.AssignTeamID != newTeam.ID {
+		t.Errorf("Expected routing rule to assign imported team %s, got %v", newTeam.ID, actions.AssignTeamID)
+	}
+	if actions.AssignEscalationPolicyID == nil || *actions.AssignEscalationPolicyID != newPolicy.ID {
+		t.Errorf("Expected routing rule to use imported policy %s, got %v", newPolicy.ID, actions.AssignEscalationPolicyID)
+	}
+
+	// Notification channels
+	if len(imported.NotificationChannels) != 1 {
+		t.Fatalf("Expected 1 imported channel, got %d", len(imported.NotificationChannels))
+	}
+	if ch := imported.NotificationChannels[0]; ch.Name != "Ops Email" || ch.IsEnabled {
+		t.Errorf("Expected disabled 'Ops Email' channel, got %+v", ch)
+	}
+}
+
+func TestOrganizations_Import_MissingTeamReference(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, err := testFixtures.CreateUniqueUser(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	missingTeam := uuid.New()
+	actions, _ := json.Marshal(domain.RoutingActions{AssignTeamID: &missingTeam})
+	archive := dto.OrganizationExport{
+		Version: dto.OrganizationExportVersion,
+		Schedules: []dto.ExportedSchedule{
+			{ID: uuid.New(), Name: "Should Not Be Created", Timezone: "UTC"},
+		},
+		RoutingRules: []dto.ExportedRoutingRule{{
+			ID:         uuid.New(),
+			Name:       "Broken Rule",
+			Conditions: json.RawMessage(`{"match":"all","conditions":[]}`),
+			Actions:    actions,
+			Enabled:    true,
+		}},
+	}
+
+	client.SetAuthToken(user.AccessToken)
+	resp := client.Post("/api/v1/organizations/import", archive)
+	client.AssertStatus(resp, http.StatusBadRequest)
+
+	var result map[string]interface{}
+	client.ParseJSON(resp, &result)
+	msg, _ := result["error"].(string)
+	if !strings.Contains(msg, "Broken Rule") || !strings.Contains(msg, missingTeam.String()) {
+		t.Errorf("Expected error naming the rule and missing team, got %q", msg)
+	}
+
+	// Nothing from the archive may have been written
+	var count int
+	if err := testDB.GetContext(ctx, &count, `SELECT COUNT(*) FROM schedules WHERE organization_id = $1`, user.Organization.ID); err != nil {
+		t.Fatalf("Failed to count schedules: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected failed import to create nothing, found %d schedules", count)
+	}
+}
+
+func TestOrganizations_Import_UnknownUser(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, err := testFixtures.CreateUniqueUser(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	archive := dto.OrganizationExport{
+		Version: dto.OrganizationExportVersion,
+		Teams: []dto.ExportedTeam{{
+			ID:      uuid.New(),
+			Name:    "Ghosts",
+			Members: []dto.ExportedTeamMember{{UserID: uuid.New(), Email: "nobody@example.com", Role: "member"}},
+		}},
+	}
+
+	client.SetAuthToken(user.AccessToken)
+	resp := client.Post("/api/v1/organizations/import", archive)
+	client.AssertStatus(resp, http.StatusBadRequest)
+}
+
+func TestOrganizations_Import_InvalidChannelConfig(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, err := testFixtures.CreateUniqueUser(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	archive := dto.OrganizationExport{
+		Version: dto.OrganizationExportVersion,
+		NotificationChannels: []dto.ExportedNotificationChannel{{
+			ID:          uuid.New(),
+			Name:        "Broken Webhook",
+			ChannelType: string(domain.ChannelTypeWebhook),
+			IsEnabled:   true,
+			Config:      json.RawMessage(`{"url":"ftp://example.com/hook","method":"POST"}`),
+		}},
+	}
+
+	client.SetAuthToken(user.AccessToken)
+	resp := client.Post("/api/v1/organizations/import", archive)
+	client.AssertStatus(resp, http.StatusBadRequest)
+
+	var result map[string]interface{}
+	client.ParseJSON(resp, &result)
+	if msg, _ := result["error"].(string); !strings.Contains(msg, "Broken Webhook") {
+		t.Errorf("Expected error naming the channel, got %q", msg)
+	}
+
+	var count int
+	if err := testDB.GetContext(ctx, &count, `SELECT COUNT(*) FROM notification_channels WHERE organization_id = $1`, user.Organization.ID); err != nil {
+		t.Fatalf("Failed to count channels: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected failed import to create no channels, found %d", count)
+	}
+}
+
+func TestOrganizations_Import_UnsupportedVersion(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, err := testFixtures.CreateUniqueUser(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	client.SetAuthToken(user.AccessToken)
+	resp := client.Post("/api/v1/organizations/import", dto.OrganizationExport{Version: 99})
+	client.AssertStatus(resp, http.StatusBadRequest)
+}
@@ -0,0 +1,190 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/outbound/postgres"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/service"
+)
+
+// outboxEvents returns the number of outbox rows about the alert, and how
+// many of them are published
+func outboxEvents(t *testing.T, ctx context.Context, alertID uuid.UUID) (total, published int) {
+	t.Helper()
+
+	if err := testDB.QueryRowContext(ctx,
+		`SELECT COUNT(*), COUNT(published_at) FROM event_outbox WHERE alert_id = $1`, alertID,
+	).Scan(&total, &published); err != nil {
+		t.Fatalf("Failed to count outbox events: %v", err)
+	}
+	return total, published
+}
+
+// newOutboxAlert returns an unsaved alert and its alert.created event
+func newOutboxAlert(orgID uuid.UUID) (*domain.Alert, *domain.OutboxEvent) {
+	alert := &domain.Alert{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		Source:         "test",
+		Priority:       domain.PriorityP3,
+		Status:         domain.AlertStatusOpen,
+		Message:        "Outbox alert",
+		Tags:           []string{},
+		CustomFields:   map[string]interface{}{},
+		DedupCount:     1,
+	}
+	event := domain.NewAlertOutboxEvent(domain.WebhookEventAlertCreated, alert, map[string]interface{}{
+		"alert_id": alert.ID.String(),
+	})
+	return alert, event
+}
+
+// ============================================================================
+// Alert creation
+// ============================================================================
+
+func TestOutbox_CreateAlertRecordsOneEvent(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Post("/api/v1/alerts", map[string]interface{}{
+		"source":   "monitoring",
+		"message":  "Outbox alert",
+		"priority": "P2",
+	})
+	client.ExpectStatus(resp, http.StatusCreated)
+	var alert domain.Alert
+	client.ParseJSON(resp, &alert)
+
+	total, published := outboxEvents(t, ctx, alert.ID)
+	if total != 1 {
+		t.Fatalf("Expected exactly one outbox event, got %d", total)
+	}
+	if published != 1 {
+		t.Error("Expected the event to be published once the alert was committed")
+	}
+}
+
+func TestOutbox_FailedInsertRecordsNoEvent(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	repo := postgres.NewAlertRepository(&postgres.DB{DB: testDB.DB})
+
+	// The organization doesn't exist, so the alert insert fails
+	alert, event := newOutboxAlert(uuid.New())
+	if err := repo.Create(ctx, alert, event); err == nil {
+		t.Fatal("Expected the alert insert to fail")
+	}
+
+	if total, _ := outboxEvents(t, ctx, alert.ID); total != 0 {
+		t.Errorf("Expected no outbox event for a failed insert, got %d", total)
+	}
+}
+
+func TestOutbox_DuplicateInsertRecordsNoExtraEvent(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	repo := postgres.NewAlertRepository(&postgres.DB{DB: testDB.DB})
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	alert, err := testFixtures.CreateAlert(ctx, user.Organization.ID, "Outbox alert")
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+
+	duplicate, event := newOutboxAlert(user.Organization.ID)
+	duplicate.ID = alert.ID
+	event.AlertID = &alert.ID
+	if err := repo.Create(ctx, duplicate, event); err == nil {
+		t.Fatal("Expected inserting the same alert twice to fail")
+	}
+
+	if total, _ := outboxEvents(t, ctx, alert.ID); total != 1 {
+		t.Errorf("Expected only the original outbox event, got %d", total)
+	}
+}
+
+// ============================================================================
+// Relay
+// ============================================================================
+
+func TestOutbox_ProcessPendingPublishesLeftoverEvents(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	repo := postgres.NewAlertRepository(&postgres.DB{DB: testDB.DB})
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+
+	// Written but never published, as if the process stopped after the commit
+	stale, staleEvent := newOutboxAlert(user.Organization.ID)
+	if err := repo.Create(ctx, stale, staleEvent); err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+	if _, err := testDB.ExecContext(ctx,
+		`UPDATE event_outbox SET created_at = created_at - INTERVAL '10 minutes' WHERE id = $1`, staleEvent.ID,
+	); err != nil {
+		t.Fatalf("Failed to backdate outbox event: %v", err)
+	}
+
+	// Still in the hands of the request that wrote it
+	fresh, freshEvent := newOutboxAlert(user.Organization.ID)
+	if err := repo.Create(ctx, fresh, freshEvent); err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+
+	if err := testServer.OutboxRelay.ProcessPending(ctx, 10); err != nil {
+		t.Fatalf("Failed to process outbox: %v", err)
+	}
+
+	if _, published := outboxEvents(t, ctx, stale.ID); published != 1 {
+		t.Error("Expected the leftover event to be published")
+	}
+	if _, published := outboxEvents(t, ctx, fresh.ID); published != 0 {
+		t.Error("Expected a recent event to be left to the request that wrote it")
+	}
+}
+
+// failingEnqueuer refuses every webhook, as if the database were unavailable
+type failingEnqueuer struct{}
+
+func (failingEnqueuer) EnqueueWebhooks(context.Context, uuid.UUID, string, map[string]interface{}) error {
+	return errors.New("webhooks unavailable")
+}
+
+func TestOutbox_ProcessPendingRetriesFailedPublishWithoutGrace(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	db := &postgres.DB{DB: testDB.DB}
+	repo := postgres.NewAlertRepository(db)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	alert, event := newOutboxAlert(user.Organization.ID)
+	if err := repo.Create(ctx, alert, event); err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+
+	// The request that wrote the event fails to publish it
+	inline := service.NewOutboxRelay(postgres.NewOutboxRepository(db), repo, nil, failingEnqueuer{}, zap.NewNop())
+	if err := inline.Publish(ctx, event); err == nil {
+		t.Fatal("Expected publishing to fail")
+	}
+
+	if err := testServer.OutboxRelay.ProcessPending(ctx, 10); err != nil {
+		t.Fatalf("Failed to process outbox: %v", err)
+	}
+
+	if _, published := outboxEvents(t, ctx, alert.ID); published != 1 {
+		t.Error("Expected the failed event to be published without waiting out the grace period")
+	}
+}
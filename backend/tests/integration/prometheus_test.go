@@ -0,0 +1,80 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/prommetrics"
+	"github.com/nmn3m/pulsar/backend/tests/integration/testutils"
+)
+
+// ============================================================================
+// GET /metrics (Prometheus)
+// ============================================================================
+
+func TestPrometheus_MetricsEndpoint(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	// Generate some traffic so the request and alert counters have samples
+	resp := client.Post("/api/v1/alerts", map[string]interface{}{
+		"source":   "prometheus-test",
+		"priority": "P2",
+		"message":  "Metrics endpoint test alert",
+	})
+	client.AssertStatus(resp, http.StatusCreated)
+
+	client.ClearAuthToken()
+	resp = client.Get("/metrics")
+	client.AssertStatus(resp, http.StatusOK)
+	body := client.ReadBody(resp)
+
+	expected := []string{
+		"go_goroutines",
+		"pulsar_http_requests_total",
+		"pulsar_http_request_duration_seconds",
+		`pulsar_alerts_created_total{priority="P2"}`,
+		"pulsar_webhook_deliveries_pending",
+		"pulsar_escalations_pending",
+	}
+	for _, name := range expected {
+		if !strings.Contains(body, name) {
+			t.Errorf("Expected metrics output to contain %q", name)
+		}
+	}
+
+	if !strings.Contains(body, `route="/api/v1/alerts"`) {
+		t.Error("Expected HTTP metrics to be labelled with the route template")
+	}
+}
+
+func TestPrometheus_MetricsEndpoint_RequiresToken(t *testing.T) {
+	router := gin.New()
+	router.GET("/metrics", middleware.MetricsAuth("scrape-token"), gin.WrapH(prommetrics.Handler()))
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	client := testutils.NewTestClient(t, server.URL)
+
+	resp := client.Get("/metrics")
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+
+	client.SetAuthToken("wrong-token")
+	resp = client.Get("/metrics")
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+
+	client.SetAuthToken("scrape-token")
+	resp = client.Get("/metrics")
+	client.AssertStatus(resp, http.StatusOK)
+}
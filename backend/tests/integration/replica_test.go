@@ -0,0 +1,101 @@
+package integration
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/outbound/postgres"
+	"github.com/nmn3m/pulsar/backend/internal/config"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/tests/integration/testutils"
+)
+
+// shadowReplicaURL returns a URL for the test database whose connections
+// resolve alerts to a separate table, so queries served by the "replica" can
+// be told apart from those served by the primary
+func shadowReplicaURL(t *testing.T, ctx context.Context) string {
+	t.Helper()
+
+	if _, err := testDB.ExecContext(ctx, `
+		DROP SCHEMA IF EXISTS replica_test CASCADE;
+		CREATE SCHEMA replica_test;
+		CREATE TABLE replica_test.alerts AS SELECT * FROM public.alerts WITH NO DATA;
+	`); err != nil {
+		t.Fatalf("Failed to create replica schema: %v", err)
+	}
+	t.Cleanup(func() {
+		testDB.ExecContext(context.Background(), `DROP SCHEMA IF EXISTS replica_test CASCADE`)
+	})
+
+	u, err := url.Parse(testutils.LoadTestConfig().DatabaseURL)
+	if err != nil {
+		t.Fatalf("Failed to parse database URL: %v", err)
+	}
+	query := u.Query()
+	query.Set("search_path", "replica_test,public")
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// ============================================================================
+// Read replica
+// ============================================================================
+
+func TestReadReplica_ListsReadFromReplica(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	orgID := user.Organization.ID
+
+	db, err := postgres.NewDB(&config.DatabaseConfig{
+		URL:          testutils.LoadTestConfig().DatabaseURL,
+		ReplicaURL:   shadowReplicaURL(t, ctx),
+		MaxOpenConns: 5,
+		MaxIdleConns: 5,
+	})
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer db.Close()
+	repo := postgres.NewAlertRepository(db)
+
+	// The primary and the replica disagree on the alert's message
+	alert, err := testFixtures.CreateAlert(ctx, orgID, "On the primary")
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+	if _, err := testDB.ExecContext(ctx,
+		`INSERT INTO replica_test.alerts SELECT * FROM public.alerts WHERE id = $1`, alert.ID,
+	); err != nil {
+		t.Fatalf("Failed to copy alert to the replica: %v", err)
+	}
+	if _, err := testDB.ExecContext(ctx, `UPDATE replica_test.alerts SET message = 'On the replica'`); err != nil {
+		t.Fatalf("Failed to update replica alert: %v", err)
+	}
+
+	alerts, total, err := repo.List(ctx, &domain.AlertFilter{OrganizationID: orgID, Limit: 10})
+	if err != nil {
+		t.Fatalf("Failed to list alerts: %v", err)
+	}
+	if total != 1 || len(alerts) != 1 || alerts[0].Message != "On the replica" {
+		t.Fatalf("Expected the list to be served by the replica, got %d alerts (total %d)", len(alerts), total)
+	}
+
+	// Point reads stay on the primary
+	got, err := repo.GetByID(ctx, alert.ID, orgID)
+	if err != nil {
+		t.Fatalf("Failed to get alert: %v", err)
+	}
+	if got.Message != "On the primary" {
+		t.Errorf("Expected GetByID to read from the primary, got %q", got.Message)
+	}
+}
+
+func TestReadReplica_WithoutReplicaUsesPrimary(t *testing.T) {
+	db := &postgres.DB{DB: testDB.DB}
+	if db.Reader() != testDB.DB {
+		t.Error("Expected reads to use the primary when no replica is configured")
+	}
+}
@@ -0,0 +1,95 @@
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/logger"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/requestid"
+)
+
+// newRequestIDTestRouter builds a router with the request ID and logging
+// middleware backed by an in-memory log sink.
+func newRequestIDTestRouter(t *testing.T) (*httptest.Server, *observer.ObservedLogs) {
+	t.Helper()
+
+	core, logs := observer.New(zap.InfoLevel)
+	log := zap.New(core)
+
+	router := gin.New()
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Logger(log))
+	router.GET("/ping", func(c *gin.Context) {
+		logger.WithContext(c.Request.Context(), log).Info("handler log")
+		c.JSON(http.StatusOK, gin.H{"request_id": requestid.FromContext(c.Request.Context())})
+	})
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server, logs
+}
+
+// ============================================================================
+// X-Request-ID middleware
+// ============================================================================
+
+func TestRequestID_GeneratedWhenMissing(t *testing.T) {
+	client := newTestClient(t)
+
+	resp := client.Get("/api/v1/health")
+	client.AssertStatus(resp, http.StatusOK)
+
+	if resp.Header.Get("X-Request-ID") == "" {
+		t.Error("Expected X-Request-ID response header")
+	}
+}
+
+func TestRequestID_InboundIDPropagatedToLogs(t *testing.T) {
+	server, logs := newRequestIDTestRouter(t)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/ping", nil)
+	req.Header.Set("X-Request-ID", "req-test-12345")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if got := resp.Header.Get("X-Request-ID"); got != "req-test-12345" {
+		t.Errorf("Expected inbound request ID to be echoed, got %q", got)
+	}
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("Expected handler and access log entries, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		if entry.ContextMap()["request_id"] != "req-test-12345" {
+			t.Errorf("Expected log %q to carry request_id, got %v", entry.Message, entry.ContextMap()["request_id"])
+		}
+	}
+}
+
+func TestRequestID_InvalidInboundIDReplaced(t *testing.T) {
+	server, _ := newRequestIDTestRouter(t)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/ping", nil)
+	req.Header.Set("X-Request-ID", strings.Repeat("a", 500))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	got := resp.Header.Get("X-Request-ID")
+	if got == "" || len(got) > 128 {
+		t.Errorf("Expected oversized request ID to be replaced, got %q", got)
+	}
+}
@@ -2,10 +2,17 @@ package integration
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
 	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/tests/integration/testutils"
 )
 
 // ============================================================================
@@ -40,6 +47,46 @@ func TestSchedules_Create_Success(t *testing.T) {
 	}
 }
 
+func TestSchedules_Create_InheritsOrganizationTimezone(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	// Without a default new schedules are in UTC
+	resp := client.Post("/api/v1/schedules", map[string]interface{}{"name": "Before"})
+	client.AssertStatus(resp, http.StatusCreated)
+	var schedule domain.Schedule
+	client.ParseJSON(resp, &schedule)
+	if schedule.Timezone != "UTC" {
+		t.Errorf("Expected timezone UTC, got %s", schedule.Timezone)
+	}
+
+	resp = client.Patch("/api/v1/organizations/settings", map[string]interface{}{"default_timezone": "Not/AZone"})
+	client.AssertStatus(resp, http.StatusBadRequest)
+	resp = client.Patch("/api/v1/organizations/settings", map[string]interface{}{"default_timezone": "Asia/Tokyo"})
+	client.AssertStatus(resp, http.StatusOK)
+
+	resp = client.Post("/api/v1/schedules", map[string]interface{}{"name": "After"})
+	client.AssertStatus(resp, http.StatusCreated)
+	client.ParseJSON(resp, &schedule)
+	if schedule.Timezone != "Asia/Tokyo" {
+		t.Errorf("Expected the organization default Asia/Tokyo, got %s", schedule.Timezone)
+	}
+
+	// An explicit timezone wins over the default, and must be valid
+	resp = client.Post("/api/v1/schedules", map[string]interface{}{"name": "Explicit", "timezone": "Europe/Berlin"})
+	client.AssertStatus(resp, http.StatusCreated)
+	client.ParseJSON(resp, &schedule)
+	if schedule.Timezone != "Europe/Berlin" {
+		t.Errorf("Expected timezone Europe/Berlin, got %s", schedule.Timezone)
+	}
+	resp = client.Post("/api/v1/schedules", map[string]interface{}{"name": "Invalid", "timezone": "Mars/Olympus"})
+	client.AssertStatus(resp, http.StatusBadRequest)
+}
+
 func TestSchedules_Create_Unauthorized(t *testing.T) {
 	cleanDatabase(t)
 	client := newTestClient(t)
@@ -191,6 +238,291 @@ func TestSchedules_GetOnCall_Success(t *testing.T) {
 	client.ExpectStatus(resp, http.StatusNotFound)
 }
 
+func TestSchedules_GetOnCall_DegenerateRotations(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	// newSchedule creates a schedule with one daily rotation starting on
+	// 2024-01-10, staffed by the given users
+	newSchedule := func(participants ...uuid.UUID) (*domain.Schedule, uuid.UUID) {
+		schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Degenerate")
+		rotation, err := testServer.ScheduleService.CreateRotation(ctx, schedule.ID, &dto.CreateRotationRequest{
+			Name:           "Daily",
+			RotationType:   "daily",
+			RotationLength: 1,
+			StartDate:      "2024-01-10",
+		})
+		if err != nil {
+			t.Fatalf("Failed to create rotation: %v", err)
+		}
+		if len(participants) > 0 {
+			if _, err := testServer.ScheduleService.SetParticipants(ctx, user.Organization.ID, schedule.ID, rotation.ID,
+				&dto.SetParticipantsRequest{UserIDs: participants}); err != nil {
+				t.Fatalf("Failed to set participants: %v", err)
+			}
+		}
+		return schedule, rotation.ID
+	}
+
+	// Lengths the API rejects can still exist in older data
+	setLength := func(rotationID uuid.UUID, length int) {
+		if _, err := testDB.ExecContext(ctx, `UPDATE schedule_rotations SET rotation_length = $2 WHERE id = $1`, rotationID, length); err != nil {
+			t.Fatalf("Failed to set rotation length: %v", err)
+		}
+	}
+
+	empty, _ := newSchedule()
+	zero, zeroRotation := newSchedule(user.User.ID)
+	setLength(zeroRotation, 0)
+	negative, negativeRotation := newSchedule(user.User.ID)
+	setLength(negativeRotation, -3)
+	notStarted, _ := newSchedule(user.User.ID)
+
+	tests := []struct {
+		name     string
+		schedule *domain.Schedule
+		at       string
+	}{
+		{"no participants", empty, "2024-01-15T12:00:00Z"},
+		{"zero length", zero, "2024-01-15T12:00:00Z"},
+		{"negative length", negative, "2024-01-15T12:00:00Z"},
+		{"before start", notStarted, "2024-01-09T12:00:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, _ := time.Parse(time.RFC3339, tt.at)
+			if _, err := testServer.ScheduleService.GetOnCallUser(ctx, tt.schedule.ID, at); !errors.Is(err, domain.ErrNoOnCall) {
+				t.Errorf("Expected ErrNoOnCall, got %v", err)
+			}
+
+			resp := client.Get(fmt.Sprintf("/api/v1/schedules/%s/oncall?at=%s", tt.schedule.ID, tt.at))
+			client.ExpectStatus(resp, http.StatusNotFound)
+		})
+	}
+
+	// The same rotation resolves once it has started
+	at, _ := time.Parse(time.RFC3339, "2024-01-10T12:00:00Z")
+	onCall, err := testServer.ScheduleService.GetOnCallUser(ctx, notStarted.ID, at)
+	if err != nil {
+		t.Fatalf("Expected an on-call user once the rotation started, got %v", err)
+	}
+	if onCall.UserID != user.User.ID {
+		t.Errorf("Expected %s on call, got %s", user.User.ID, onCall.UserID)
+	}
+}
+
+func TestSchedules_OnCall_IndependentOfHostTimezone(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	first, _ := testFixtures.CreateUniqueUser(ctx)
+	second, _ := testFixtures.CreateUniqueUser(ctx)
+	schedule := createTwoPersonRotation(t, ctx, first.Organization.ID, []uuid.UUID{first.User.ID, second.User.ID})
+
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	compute := func() ([]uuid.UUID, *domain.ScheduleCoverage) {
+		var onCall []uuid.UUID
+		for at := start; at.Before(end); at = at.Add(12 * time.Hour) {
+			user, err := testServer.ScheduleService.GetOnCallUser(ctx, schedule.ID, at)
+			if err != nil {
+				t.Fatalf("Failed to get on-call user at %s: %v", at, err)
+			}
+			onCall = append(onCall, user.UserID)
+		}
+		coverage, err := testServer.ScheduleService.GetCoverage(ctx, first.Organization.ID, schedule.ID, start, end)
+		if err != nil {
+			t.Fatalf("Failed to get coverage: %v", err)
+		}
+		return onCall, coverage
+	}
+
+	wantOnCall, wantCoverage := compute()
+
+	// Run the same computations on a host far from UTC
+	local := time.Local
+	time.Local = time.FixedZone("UTC+13:45", 13*3600+45*60)
+	defer func() { time.Local = local }()
+
+	gotOnCall, gotCoverage := compute()
+
+	for i := range wantOnCall {
+		if gotOnCall[i] != wantOnCall[i] {
+			t.Errorf("On-call user %d changed with the host timezone: %s vs %s", i, gotOnCall[i], wantOnCall[i])
+		}
+	}
+	if len(gotCoverage.Gaps) != len(wantCoverage.Gaps) || len(gotCoverage.Overlaps) != len(wantCoverage.Overlaps) {
+		t.Errorf("Coverage changed with the host timezone: %+v vs %+v", gotCoverage, wantCoverage)
+	}
+}
+
+// ============================================================================
+// GET /api/v1/oncall
+// ============================================================================
+
+func TestOnCall_List_MixedSchedules(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	staffed, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Staffed")
+	empty, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Empty")
+
+	rotation, err := testServer.ScheduleService.CreateRotation(ctx, staffed.ID, &dto.CreateRotationRequest{
+		Name:           "Primary",
+		RotationType:   "weekly",
+		RotationLength: 1,
+		StartDate:      "2024-01-01",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rotation: %v", err)
+	}
+	testServer.ScheduleService.AddParticipant(ctx, rotation.ID, &dto.AddParticipantRequest{UserID: user.User.ID})
+
+	resp := client.Get("/api/v1/oncall")
+	client.AssertStatus(resp, http.StatusOK)
+
+	var result struct {
+		OnCall []domain.ScheduleOnCall `json:"oncall"`
+	}
+	client.ParseJSON(resp, &result)
+
+	if len(result.OnCall) != 2 {
+		t.Fatalf("Expected 2 schedules, got %d", len(result.OnCall))
+	}
+
+	for _, entry := range result.OnCall {
+		switch entry.Schedule.ID {
+		case staffed.ID:
+			if entry.OnCall == nil {
+				t.Fatal("Expected an on-call user for the staffed schedule")
+			}
+			if entry.OnCall.UserID != user.User.ID {
+				t.Errorf("Expected %s on call, got %s", user.User.ID, entry.OnCall.UserID)
+			}
+			if entry.OnCall.IsOverride {
+				t.Error("Expected rotation-based on-call, not an override")
+			}
+		case empty.ID:
+			if entry.OnCall != nil {
+				t.Error("Expected null on-call for the schedule without rotations")
+			}
+		default:
+			t.Errorf("Unexpected schedule %s", entry.Schedule.ID)
+		}
+	}
+}
+
+func TestOnCall_List_Unauthorized(t *testing.T) {
+	cleanDatabase(t)
+	client := newTestClient(t)
+
+	resp := client.Get("/api/v1/oncall")
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+}
+
+// ============================================================================
+// POST /api/v1/schedules/preview-oncall
+// ============================================================================
+
+func TestSchedules_PreviewOnCall_MatchesPersistedSchedule(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	rotationReq := dto.CreateRotationRequest{
+		Name:           "Primary",
+		RotationType:   "daily",
+		RotationLength: 2,
+		StartDate:      "2024-01-01",
+	}
+	participants := []uuid.UUID{user.User.ID, other.User.ID}
+
+	// Persist the equivalent schedule to compare against
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Persisted Schedule")
+	rotation, err := testServer.ScheduleService.CreateRotation(ctx, schedule.ID, &rotationReq)
+	if err != nil {
+		t.Fatalf("Failed to create rotation: %v", err)
+	}
+	for i, userID := range participants {
+		if _, err := testServer.ScheduleService.AddParticipant(ctx, rotation.ID, &dto.AddParticipantRequest{UserID: userID, Position: i}); err != nil {
+			t.Fatalf("Failed to add participant: %v", err)
+		}
+	}
+
+	reqBody := map[string]interface{}{
+		"rotation":     rotationReq,
+		"participants": participants,
+		"start_time":   "2024-01-01T00:00:00Z",
+		"end_time":     "2024-01-09T00:00:00Z",
+	}
+
+	resp := client.Post("/api/v1/schedules/preview-oncall", reqBody)
+	client.AssertStatus(resp, http.StatusOK)
+
+	var result struct {
+		Shifts []domain.OnCallUser `json:"shifts"`
+	}
+	client.ParseJSON(resp, &result)
+
+	if len(result.Shifts) != 4 {
+		t.Fatalf("Expected 4 shifts, got %d", len(result.Shifts))
+	}
+
+	for _, shift := range result.Shifts {
+		for _, at := range []time.Time{shift.StartTime, shift.EndTime.Add(-time.Minute)} {
+			onCall, err := testServer.ScheduleService.GetOnCallUser(ctx, schedule.ID, at)
+			if err != nil {
+				t.Fatalf("Failed to get on-call user at %s: %v", at, err)
+			}
+			if onCall.UserID != shift.UserID {
+				t.Errorf("At %s preview has %s on call, persisted schedule has %s", at, shift.UserID, onCall.UserID)
+			}
+		}
+	}
+
+	rotations, _ := testServer.ScheduleService.ListRotations(ctx, schedule.ID)
+	if len(rotations) != 1 {
+		t.Errorf("Expected preview not to persist rotations, got %d", len(rotations))
+	}
+}
+
+func TestSchedules_PreviewOnCall_InvalidRange(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	reqBody := map[string]interface{}{
+		"rotation": map[string]interface{}{
+			"name":            "Primary",
+			"rotation_type":   "weekly",
+			"rotation_length": 1,
+			"start_date":      "2024-01-01",
+		},
+		"participants": []string{user.User.ID.String()},
+		"start_time":   "2024-02-01T00:00:00Z",
+		"end_time":     "2024-01-01T00:00:00Z",
+	}
+
+	resp := client.Post("/api/v1/schedules/preview-oncall", reqBody)
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
 // ============================================================================
 // POST /api/v1/schedules/:id/rotations
 // ============================================================================
@@ -219,6 +551,69 @@ func TestSchedules_CreateRotation_Success(t *testing.T) {
 	client.AssertStatus(resp, http.StatusCreated)
 }
 
+func TestSchedules_CreateRotation_InvalidLength(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Test Schedule")
+
+	tests := []struct {
+		rotationType string
+		length       int
+		expected     int
+	}{
+		{"weekly", 0, http.StatusBadRequest},
+		{"daily", -1, http.StatusBadRequest},
+		{"daily", 90, http.StatusBadRequest},
+		{"daily", 7, http.StatusCreated},
+	}
+	for _, tt := range tests {
+		reqBody := map[string]interface{}{
+			"name":            fmt.Sprintf("%s %d", tt.rotationType, tt.length),
+			"rotation_type":   tt.rotationType,
+			"rotation_length": tt.length,
+			"start_date":      "2024-01-01",
+		}
+		resp := client.Post(fmt.Sprintf("/api/v1/schedules/%s/rotations", schedule.ID), reqBody)
+		client.AssertStatus(resp, tt.expected)
+	}
+}
+
+func TestSchedules_UpdateRotation_InvalidLength(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Test Schedule")
+	rotation, err := testServer.ScheduleService.CreateRotation(ctx, schedule.ID, &dto.CreateRotationRequest{
+		Name:           "Weekly",
+		RotationType:   "weekly",
+		RotationLength: 40,
+		StartDate:      "2024-01-01",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rotation: %v", err)
+	}
+	path := fmt.Sprintf("/api/v1/schedules/%s/rotations/%s", schedule.ID, rotation.ID)
+
+	resp := client.Patch(path, map[string]interface{}{"rotation_length": 0})
+	client.AssertStatus(resp, http.StatusBadRequest)
+
+	// 40 weeks is fine but 40 days is too long for a daily rotation
+	resp = client.Patch(path, map[string]interface{}{"rotation_type": "daily"})
+	client.AssertStatus(resp, http.StatusBadRequest)
+
+	resp = client.Patch(path, map[string]interface{}{"rotation_type": "daily", "rotation_length": 3})
+	client.AssertStatus(resp, http.StatusOK)
+}
+
 // ============================================================================
 // GET /api/v1/schedules/:id/rotations
 // ============================================================================
@@ -325,6 +720,77 @@ func TestSchedules_ListParticipants_NotFound(t *testing.T) {
 	client.AssertStatus(resp, http.StatusOK)
 }
 
+// ============================================================================
+// POST /api/v1/schedules/:id/rotations/:rotationId/participants/bulk
+// ============================================================================
+
+func TestSchedules_SetParticipants_AssignsPositions(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	second, _ := testFixtures.CreateUniqueUser(ctx)
+	third, _ := testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, user.Organization.ID, second.User.ID)
+	joinOrganization(t, ctx, user.Organization.ID, third.User.ID)
+	client.SetAuthToken(user.AccessToken)
+
+	// Start from an existing participant that the bulk set replaces
+	schedule := createTwoPersonRotation(t, ctx, user.Organization.ID, []uuid.UUID{user.User.ID})
+	rotations, _ := testServer.ScheduleService.ListRotations(ctx, schedule.ID)
+	rotationID := rotations[0].ID
+
+	order := []uuid.UUID{third.User.ID, user.User.ID, second.User.ID}
+	resp := client.Post(fmt.Sprintf("/api/v1/schedules/%s/rotations/%s/participants/bulk", schedule.ID, rotationID),
+		map[string]interface{}{"user_ids": order})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var result struct {
+		Participants []domain.ParticipantWithUser `json:"participants"`
+	}
+	client.ParseJSON(resp, &result)
+
+	if len(result.Participants) != len(order) {
+		t.Fatalf("Expected %d participants, got %d", len(order), len(result.Participants))
+	}
+	for i, participant := range result.Participants {
+		if participant.UserID != order[i] || participant.Position != i {
+			t.Errorf("Expected %s at position %d, got %s at %d", order[i], i, participant.UserID, participant.Position)
+		}
+	}
+}
+
+func TestSchedules_SetParticipants_RejectsUnknownUser(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	outsider, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	schedule := createTwoPersonRotation(t, ctx, user.Organization.ID, []uuid.UUID{user.User.ID})
+	rotations, _ := testServer.ScheduleService.ListRotations(ctx, schedule.ID)
+	path := fmt.Sprintf("/api/v1/schedules/%s/rotations/%s/participants/bulk", schedule.ID, rotations[0].ID)
+
+	resp := client.Post(path, map[string]interface{}{"user_ids": []uuid.UUID{user.User.ID, uuid.New()}})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	// A user from another organization is rejected as well
+	resp = client.Post(path, map[string]interface{}{"user_ids": []uuid.UUID{outsider.User.ID}})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	// Nothing was changed
+	participants, err := testServer.ScheduleService.ListParticipants(ctx, rotations[0].ID)
+	if err != nil {
+		t.Fatalf("Failed to list participants: %v", err)
+	}
+	if len(participants) != 1 || participants[0].UserID != user.User.ID {
+		t.Errorf("Expected the original participant to remain, got %+v", participants)
+	}
+}
+
 // ============================================================================
 // POST /api/v1/schedules/:id/overrides
 // ============================================================================
@@ -349,6 +815,129 @@ func TestSchedules_CreateOverride_Success(t *testing.T) {
 	client.AssertStatus(resp, http.StatusCreated)
 }
 
+// setOverrideMembershipPolicy configures the organization's override membership policy.
+func setOverrideMembershipPolicy(t *testing.T, client *testutils.TestClient, policy string) {
+	t.Helper()
+
+	resp := client.Patch("/api/v1/organizations/settings", map[string]interface{}{
+		"override_membership_policy": policy,
+	})
+	client.AssertStatus(resp, http.StatusOK)
+}
+
+func TestSchedules_CreateOverride_StrictAllowsParticipant(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	participant, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	setOverrideMembershipPolicy(t, client, "strict")
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Test Schedule")
+	rotation, err := testServer.ScheduleService.CreateRotation(ctx, schedule.ID, &dto.CreateRotationRequest{
+		Name:           "Primary",
+		RotationType:   "weekly",
+		RotationLength: 1,
+		StartDate:      "2024-01-01",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rotation: %v", err)
+	}
+	testServer.ScheduleService.AddParticipant(ctx, rotation.ID, &dto.AddParticipantRequest{UserID: participant.User.ID})
+
+	reqBody := map[string]interface{}{
+		"user_id":    participant.User.ID.String(),
+		"start_time": time.Now().Add(1 * time.Hour).UTC().Format(time.RFC3339),
+		"end_time":   time.Now().Add(5 * time.Hour).UTC().Format(time.RFC3339),
+	}
+
+	resp := client.Post(fmt.Sprintf("/api/v1/schedules/%s/overrides", schedule.ID), reqBody)
+	client.AssertStatus(resp, http.StatusCreated)
+}
+
+func TestSchedules_CreateOverride_StrictAllowsTeamMember(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	setOverrideMembershipPolicy(t, client, "strict")
+
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Responders")
+	testServer.TeamService.AddMember(ctx, team.ID, &dto.AddTeamMemberRequest{UserID: &user.User.ID, Role: "member"})
+	schedule, err := testServer.ScheduleService.CreateSchedule(ctx, user.Organization.ID, &dto.CreateScheduleRequest{
+		TeamID: &team.ID,
+		Name:   "Team Schedule",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create schedule: %v", err)
+	}
+
+	reqBody := map[string]interface{}{
+		"user_id":    user.User.ID.String(),
+		"start_time": time.Now().Add(1 * time.Hour).UTC().Format(time.RFC3339),
+		"end_time":   time.Now().Add(5 * time.Hour).UTC().Format(time.RFC3339),
+	}
+
+	resp := client.Post(fmt.Sprintf("/api/v1/schedules/%s/overrides", schedule.ID), reqBody)
+	client.AssertStatus(resp, http.StatusCreated)
+}
+
+func TestSchedules_CreateOverride_StrictRejectsNonMember(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	outsider, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	setOverrideMembershipPolicy(t, client, "strict")
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Test Schedule")
+
+	reqBody := map[string]interface{}{
+		"user_id":    outsider.User.ID.String(),
+		"start_time": time.Now().Add(1 * time.Hour).UTC().Format(time.RFC3339),
+		"end_time":   time.Now().Add(5 * time.Hour).UTC().Format(time.RFC3339),
+	}
+
+	resp := client.Post(fmt.Sprintf("/api/v1/schedules/%s/overrides", schedule.ID), reqBody)
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	overrides, _ := testServer.ScheduleService.ListOverrides(ctx, schedule.ID, time.Now(), time.Now().Add(24*time.Hour))
+	if len(overrides) != 0 {
+		t.Errorf("Expected no override to be created, got %d", len(overrides))
+	}
+}
+
+func TestSchedules_CreateOverride_WarnAcceptsNonMember(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	outsider, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	setOverrideMembershipPolicy(t, client, "warn")
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Test Schedule")
+
+	reqBody := map[string]interface{}{
+		"user_id":    outsider.User.ID.String(),
+		"start_time": time.Now().Add(1 * time.Hour).UTC().Format(time.RFC3339),
+		"end_time":   time.Now().Add(5 * time.Hour).UTC().Format(time.RFC3339),
+	}
+
+	resp := client.Post(fmt.Sprintf("/api/v1/schedules/%s/overrides", schedule.ID), reqBody)
+	if resp.Header.Get("Warning") == "" {
+		t.Error("Expected a Warning header for a non-member override")
+	}
+	client.AssertStatus(resp, http.StatusCreated)
+}
+
 // ============================================================================
 // GET /api/v1/schedules/:id/overrides
 // ============================================================================
@@ -435,3 +1024,462 @@ func TestSchedules_DeleteOverride_NotFound(t *testing.T) {
 	resp := client.Delete(fmt.Sprintf("/api/v1/schedules/%s/overrides/00000000-0000-0000-0000-000000000000", schedule.ID))
 	client.ExpectStatus(resp, http.StatusInternalServerError) // API returns 500 for not found errors
 }
+
+// ============================================================================
+// PUT /api/v1/schedules/:id/config
+// ============================================================================
+
+func TestSchedules_ApplyConfig_Reconciles(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	alice, _ := testFixtures.CreateUniqueUser(ctx)
+	bob, _ := testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, user.Organization.ID, alice.User.ID)
+	joinOrganization(t, ctx, user.Organization.ID, bob.User.ID)
+	client.SetAuthToken(user.AccessToken)
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Config Schedule")
+
+	// Seed a rotation that is kept and one that is dropped by the config
+	kept, err := testServer.ScheduleService.CreateRotation(ctx, schedule.ID, &dto.CreateRotationRequest{
+		Name:           "Primary",
+		RotationType:   "weekly",
+		RotationLength: 1,
+		StartDate:      "2024-01-01",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rotation: %v", err)
+	}
+	testServer.ScheduleService.AddParticipant(ctx, kept.ID, &dto.AddParticipantRequest{UserID: user.User.ID})
+	dropped, err := testServer.ScheduleService.CreateRotation(ctx, schedule.ID, &dto.CreateRotationRequest{
+		Name:           "Legacy",
+		RotationType:   "daily",
+		RotationLength: 1,
+		StartDate:      "2024-01-01",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rotation: %v", err)
+	}
+
+	reqBody := map[string]interface{}{
+		"name":     "Config Schedule",
+		"timezone": "Europe/Berlin",
+		"rotations": []map[string]interface{}{
+			{
+				"name":            "Primary",
+				"rotation_type":   "weekly",
+				"rotation_length": 2,
+				"start_date":      "2024-01-01",
+				"participants":    []string{alice.User.ID.String(), bob.User.ID.String()},
+			},
+			{
+				"name":            "Secondary",
+				"rotation_type":   "daily",
+				"rotation_length": 1,
+				"start_date":      "2024-02-01",
+				"participants":    []string{user.User.ID.String()},
+			},
+		},
+	}
+
+	resp := client.Put(fmt.Sprintf("/api/v1/schedules/%s/config", schedule.ID), reqBody)
+	client.AssertStatus(resp, http.StatusOK)
+
+	rotations, err := testServer.ScheduleService.ListRotations(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("Failed to list rotations: %v", err)
+	}
+	if len(rotations) != 2 {
+		t.Fatalf("Expected 2 rotations, got %d", len(rotations))
+	}
+
+	byName := make(map[string]*domain.ScheduleRotation)
+	for _, rotation := range rotations {
+		if rotation.ID == dropped.ID {
+			t.Error("Expected unlisted rotation to be deleted")
+		}
+		byName[rotation.Name] = rotation
+	}
+
+	primary, ok := byName["Primary"]
+	if !ok {
+		t.Fatal("Expected Primary rotation")
+	}
+	if primary.ID != kept.ID {
+		t.Errorf("Expected Primary to keep ID %s, got %s", kept.ID, primary.ID)
+	}
+	if primary.RotationLength != 2 {
+		t.Errorf("Expected Primary rotation length 2, got %d", primary.RotationLength)
+	}
+
+	participants, _ := testServer.ScheduleService.ListParticipants(ctx, primary.ID)
+	if len(participants) != 2 {
+		t.Fatalf("Expected 2 Primary participants, got %d", len(participants))
+	}
+	if participants[0].UserID != alice.User.ID || participants[1].UserID != bob.User.ID {
+		t.Error("Expected Primary participants to be replaced in the given order")
+	}
+
+	secondary, ok := byName["Secondary"]
+	if !ok {
+		t.Fatal("Expected Secondary rotation to be created")
+	}
+	participants, _ = testServer.ScheduleService.ListParticipants(ctx, secondary.ID)
+	if len(participants) != 1 || participants[0].UserID != user.User.ID {
+		t.Error("Expected Secondary to have the configured participant")
+	}
+
+	updated, _ := testServer.ScheduleService.GetSchedule(ctx, schedule.ID)
+	if updated.Timezone != "Europe/Berlin" {
+		t.Errorf("Expected timezone Europe/Berlin, got %s", updated.Timezone)
+	}
+}
+
+func TestSchedules_ApplyConfig_UnknownUserRollsBack(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Config Schedule")
+	testServer.ScheduleService.CreateRotation(ctx, schedule.ID, &dto.CreateRotationRequest{
+		Name:           "Primary",
+		RotationType:   "weekly",
+		RotationLength: 1,
+		StartDate:      "2024-01-01",
+	})
+
+	reqBody := map[string]interface{}{
+		"name": "Renamed",
+		"rotations": []map[string]interface{}{
+			{
+				"name":            "Other",
+				"rotation_type":   "daily",
+				"rotation_length": 1,
+				"start_date":      "2024-01-01",
+				"participants":    []string{uuid.New().String()},
+			},
+		},
+	}
+
+	resp := client.Put(fmt.Sprintf("/api/v1/schedules/%s/config", schedule.ID), reqBody)
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	rotations, _ := testServer.ScheduleService.ListRotations(ctx, schedule.ID)
+	if len(rotations) != 1 || rotations[0].Name != "Primary" {
+		t.Error("Expected existing rotations to be left untouched")
+	}
+}
+
+func TestSchedules_ApplyConfig_RejectsOtherOrganizationMembers(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	outsider, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Config Schedule")
+	foreignTeam, _ := testFixtures.CreateTeam(ctx, outsider.Organization.ID, "Foreign Team")
+
+	resp := client.Put(fmt.Sprintf("/api/v1/schedules/%s/config", schedule.ID), map[string]interface{}{
+		"name": "Config Schedule",
+		"rotations": []map[string]interface{}{
+			{
+				"name":            "Primary",
+				"rotation_type":   "daily",
+				"rotation_length": 1,
+				"start_date":      "2024-01-01",
+				"participants":    []string{user.User.ID.String(), outsider.User.ID.String()},
+			},
+		},
+	})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	resp = client.Put(fmt.Sprintf("/api/v1/schedules/%s/config", schedule.ID), map[string]interface{}{
+		"name":    "Config Schedule",
+		"team_id": foreignTeam.ID.String(),
+	})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	updated, _ := testServer.ScheduleService.GetSchedule(ctx, schedule.ID)
+	if updated.TeamID != nil {
+		t.Errorf("Expected the schedule to keep no team, got %s", updated.TeamID)
+	}
+	rotations, _ := testServer.ScheduleService.ListRotations(ctx, schedule.ID)
+	if len(rotations) != 0 {
+		t.Errorf("Expected no rotations to be created, got %d", len(rotations))
+	}
+}
+
+func TestSchedules_ApplyConfig_OtherOrganization(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, other.Organization.ID, "Foreign Schedule")
+
+	reqBody := map[string]interface{}{
+		"name": "Hijacked",
+	}
+
+	resp := client.Put(fmt.Sprintf("/api/v1/schedules/%s/config", schedule.ID), reqBody)
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+// ============================================================================
+// Team-backed rotations
+// ============================================================================
+
+func TestSchedules_TeamBackedRotation_AddMemberAppendsParticipant(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	newcomer, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Responders")
+	testServer.TeamService.AddMember(ctx, team.ID, &dto.AddTeamMemberRequest{UserID: &user.User.ID, Role: "member"})
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Team Schedule")
+	rotation, err := testServer.ScheduleService.CreateRotation(ctx, schedule.ID, &dto.CreateRotationRequest{
+		Name:           "Primary",
+		RotationType:   "weekly",
+		RotationLength: 1,
+		StartDate:      "2024-01-01",
+		TeamID:         &team.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rotation: %v", err)
+	}
+
+	reqBody := map[string]interface{}{
+		"user_id": newcomer.User.ID.String(),
+		"role":    "member",
+	}
+	resp := client.Post(fmt.Sprintf("/api/v1/teams/%s/members", team.ID), reqBody)
+	client.AssertStatus(resp, http.StatusOK)
+
+	participants, err := testServer.ScheduleService.ListParticipants(ctx, rotation.ID)
+	if err != nil {
+		t.Fatalf("Failed to list participants: %v", err)
+	}
+	if len(participants) != 2 {
+		t.Fatalf("Expected 2 participants, got %d", len(participants))
+	}
+	if participants[0].UserID != user.User.ID || participants[0].Position != 0 {
+		t.Errorf("Expected existing member at position 0, got %s at %d", participants[0].UserID, participants[0].Position)
+	}
+	if participants[1].UserID != newcomer.User.ID || participants[1].Position != 1 {
+		t.Errorf("Expected new member appended at position 1, got %s at %d", participants[1].UserID, participants[1].Position)
+	}
+}
+
+func TestSchedules_TeamBackedRotation_RemoveMemberRemovesParticipant(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Responders")
+	testServer.TeamService.AddMember(ctx, team.ID, &dto.AddTeamMemberRequest{UserID: &user.User.ID, Role: "member"})
+	testServer.TeamService.AddMember(ctx, team.ID, &dto.AddTeamMemberRequest{UserID: &other.User.ID, Role: "member"})
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Team Schedule")
+	rotation, err := testServer.ScheduleService.CreateRotation(ctx, schedule.ID, &dto.CreateRotationRequest{
+		Name:           "Primary",
+		RotationType:   "daily",
+		RotationLength: 1,
+		StartDate:      time.Now().UTC().Format("2006-01-02"),
+		TeamID:         &team.ID,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rotation: %v", err)
+	}
+
+	onCall, err := testServer.ScheduleService.GetOnCallUser(ctx, schedule.ID, time.Now())
+	if err != nil {
+		t.Fatalf("Failed to get on-call user: %v", err)
+	}
+	removed := onCall.UserID
+	remaining := user.User.ID
+	if removed == user.User.ID {
+		remaining = other.User.ID
+	}
+
+	resp := client.Delete(fmt.Sprintf("/api/v1/teams/%s/members/%s", team.ID, removed))
+	client.AssertStatus(resp, http.StatusOK)
+
+	participants, err := testServer.ScheduleService.ListParticipants(ctx, rotation.ID)
+	if err != nil {
+		t.Fatalf("Failed to list participants: %v", err)
+	}
+	if len(participants) != 1 {
+		t.Fatalf("Expected 1 participant, got %d", len(participants))
+	}
+	if participants[0].UserID != remaining || participants[0].Position != 0 {
+		t.Errorf("Expected remaining member at position 0, got %s at %d", participants[0].UserID, participants[0].Position)
+	}
+
+	onCall, err = testServer.ScheduleService.GetOnCallUser(ctx, schedule.ID, time.Now())
+	if err != nil {
+		t.Fatalf("Failed to get on-call user: %v", err)
+	}
+	if onCall.UserID != remaining {
+		t.Errorf("Expected on-call to move to remaining member %s, got %s", remaining, onCall.UserID)
+	}
+}
+
+// ============================================================================
+// /api/v1/schedules/:id/coverage
+// ============================================================================
+
+// addRotation creates a daily rotation with a single participant
+func addRotation(t *testing.T, ctx context.Context, scheduleID, userID uuid.UUID, startDate string) {
+	t.Helper()
+
+	rotation, err := testServer.ScheduleService.CreateRotation(ctx, scheduleID, &dto.CreateRotationRequest{
+		Name:           "Daily " + startDate,
+		RotationType:   "daily",
+		RotationLength: 1,
+		StartDate:      startDate,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rotation: %v", err)
+	}
+	if _, err := testServer.ScheduleService.AddParticipant(ctx, rotation.ID, &dto.AddParticipantRequest{UserID: userID}); err != nil {
+		t.Fatalf("Failed to add participant: %v", err)
+	}
+}
+
+func getCoverage(t *testing.T, client *testutils.TestClient, scheduleID uuid.UUID, start, end string) domain.ScheduleCoverage {
+	t.Helper()
+
+	resp := client.Get(fmt.Sprintf("/api/v1/schedules/%s/coverage?start=%s&end=%s", scheduleID, start, end))
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var coverage domain.ScheduleCoverage
+	client.ParseJSON(resp, &coverage)
+	return coverage
+}
+
+func TestSchedules_Coverage_ReportsGap(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	// The rotation only starts two days into the window
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Gappy")
+	addRotation(t, ctx, schedule.ID, user.User.ID, "2024-01-03")
+
+	coverage := getCoverage(t, client, schedule.ID, "2024-01-01T00:00:00Z", "2024-01-08T00:00:00Z")
+
+	if len(coverage.Overlaps) != 0 {
+		t.Errorf("Expected no overlaps, got %+v", coverage.Overlaps)
+	}
+	if len(coverage.Gaps) != 1 {
+		t.Fatalf("Expected 1 gap, got %+v", coverage.Gaps)
+	}
+	gap := coverage.Gaps[0]
+	wantStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	wantEnd := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	if !gap.Start.Equal(wantStart) || !gap.End.Equal(wantEnd) {
+		t.Errorf("Expected gap %s - %s, got %s - %s", wantStart, wantEnd, gap.Start, gap.End)
+	}
+}
+
+func TestSchedules_Coverage_OverrideFillsGap(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Patched")
+	addRotation(t, ctx, schedule.ID, user.User.ID, "2024-01-03")
+	if _, _, err := testServer.ScheduleService.CreateOverride(ctx, schedule.ID, &dto.CreateOverrideRequest{
+		UserID:    user.User.ID,
+		StartTime: "2024-01-01T12:00:00Z",
+		EndTime:   "2024-01-02T00:00:00Z",
+	}); err != nil {
+		t.Fatalf("Failed to create override: %v", err)
+	}
+
+	coverage := getCoverage(t, client, schedule.ID, "2024-01-01T00:00:00Z", "2024-01-08T00:00:00Z")
+
+	if len(coverage.Gaps) != 2 {
+		t.Fatalf("Expected the override to split the gap in two, got %+v", coverage.Gaps)
+	}
+	if !coverage.Gaps[0].End.Equal(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the first gap to end when the override starts, got %s", coverage.Gaps[0].End)
+	}
+	if !coverage.Gaps[1].Start.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the second gap to start when the override ends, got %s", coverage.Gaps[1].Start)
+	}
+}
+
+func TestSchedules_Coverage_ReportsOverlap(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, user.Organization.ID, other.User.ID)
+	client.SetAuthToken(user.AccessToken)
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Doubled")
+	addRotation(t, ctx, schedule.ID, user.User.ID, "2024-01-01")
+	addRotation(t, ctx, schedule.ID, other.User.ID, "2024-01-04")
+
+	coverage := getCoverage(t, client, schedule.ID, "2024-01-01T00:00:00Z", "2024-01-08T00:00:00Z")
+
+	if len(coverage.Gaps) != 0 {
+		t.Errorf("Expected no gaps, got %+v", coverage.Gaps)
+	}
+	if len(coverage.Overlaps) != 1 {
+		t.Fatalf("Expected 1 overlap, got %+v", coverage.Overlaps)
+	}
+	overlap := coverage.Overlaps[0]
+	if !overlap.Start.Equal(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)) || !overlap.End.Equal(time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected overlap from Jan 4 to Jan 8, got %s - %s", overlap.Start, overlap.End)
+	}
+	if len(overlap.UserIDs) != 2 {
+		t.Errorf("Expected both users in the overlap, got %v", overlap.UserIDs)
+	}
+}
+
+func TestSchedules_Coverage_InvalidRange(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	schedule, _ := testFixtures.CreateSchedule(ctx, user.Organization.ID, "Primary")
+
+	resp := client.Get(fmt.Sprintf("/api/v1/schedules/%s/coverage?start=2024-01-08T00:00:00Z&end=2024-01-01T00:00:00Z", schedule.ID))
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	resp = client.Get(fmt.Sprintf("/api/v1/schedules/%s/coverage", uuid.New()))
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
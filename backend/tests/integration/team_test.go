@@ -5,8 +5,13 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/tests/integration/testutils"
 )
 
 // ============================================================================
@@ -239,6 +244,103 @@ func TestTeams_Delete_NotFound(t *testing.T) {
 	client.ExpectStatus(resp, http.StatusBadRequest) // API returns 400 for not found errors
 }
 
+func TestTeams_Delete_BlockedByDependencies(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Test Team")
+	schedule, err := testServer.ScheduleService.CreateSchedule(ctx, user.Organization.ID, &dto.CreateScheduleRequest{
+		TeamID: &team.ID,
+		Name:   "Team Schedule",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create schedule: %v", err)
+	}
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, user.Organization.ID, "Team Policy")
+	rule, err := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: 0, EscalationDelay: 5})
+	if err != nil {
+		t.Fatalf("Failed to create rule: %v", err)
+	}
+	if _, err := testServer.EscalationService.AddTarget(ctx, user.Organization.ID, rule.ID, &dto.AddEscalationTargetRequest{TargetType: "team", TargetID: team.ID}); err != nil {
+		t.Fatalf("Failed to add target: %v", err)
+	}
+
+	resp := client.Delete(fmt.Sprintf("/api/v1/teams/%s", team.ID))
+	client.ExpectStatus(resp, http.StatusConflict)
+
+	var result struct {
+		Dependencies []struct {
+			Type string
+			ID   string
+			Name string
+		} `json:"dependencies"`
+	}
+	client.ParseJSON(resp, &result)
+
+	if len(result.Dependencies) != 2 {
+		t.Fatalf("Expected 2 dependencies, got %d", len(result.Dependencies))
+	}
+	found := map[string]string{}
+	for _, d := range result.Dependencies {
+		found[d.Type] = d.ID
+	}
+	if found["schedule"] != schedule.ID.String() {
+		t.Errorf("Expected schedule %s in dependencies, got %v", schedule.ID, found)
+	}
+	if found["escalation_policy"] != policy.ID.String() {
+		t.Errorf("Expected escalation policy %s in dependencies, got %v", policy.ID, found)
+	}
+
+	// The team must still exist
+	resp = client.Get(fmt.Sprintf("/api/v1/teams/%s", team.ID))
+	client.AssertStatus(resp, http.StatusOK)
+}
+
+func TestTeams_Delete_ForceDetachesDependencies(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Test Team")
+	schedule, err := testServer.ScheduleService.CreateSchedule(ctx, user.Organization.ID, &dto.CreateScheduleRequest{
+		TeamID: &team.ID,
+		Name:   "Team Schedule",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create schedule: %v", err)
+	}
+	policy, _ := testFixtures.CreateEscalationPolicy(ctx, user.Organization.ID, "Team Policy")
+	rule, _ := testServer.EscalationService.CreateRule(ctx, policy.ID, &dto.CreateEscalationRuleRequest{Position: 0, EscalationDelay: 5})
+	testServer.EscalationService.AddTarget(ctx, user.Organization.ID, rule.ID, &dto.AddEscalationTargetRequest{TargetType: "team", TargetID: team.ID})
+
+	resp := client.Delete(fmt.Sprintf("/api/v1/teams/%s?force=true", team.ID))
+	client.AssertStatus(resp, http.StatusOK)
+
+	// The schedule survives without a team
+	updated, err := testServer.ScheduleService.GetSchedule(ctx, schedule.ID)
+	if err != nil {
+		t.Fatalf("Expected schedule to survive forced team delete: %v", err)
+	}
+	if updated.TeamID != nil {
+		t.Errorf("Expected schedule team to be cleared, got %s", *updated.TeamID)
+	}
+
+	targets, err := testServer.EscalationService.ListTargets(ctx, rule.ID)
+	if err != nil {
+		t.Fatalf("Failed to list targets: %v", err)
+	}
+	if len(targets) != 0 {
+		t.Errorf("Expected team escalation target to be removed, got %d targets", len(targets))
+	}
+}
+
 // ============================================================================
 // POST /api/v1/teams/:id/members
 // ============================================================================
@@ -388,3 +490,200 @@ func TestTeams_UpdateMemberRole_Success(t *testing.T) {
 	resp := client.Patch(fmt.Sprintf("/api/v1/teams/%s/members/%s", team.ID, user.User.ID), reqBody)
 	client.AssertStatus(resp, http.StatusOK)
 }
+
+// ============================================================================
+// Team invitations
+// ============================================================================
+
+// inviteNewUser invites an email without an account and registers the invitee.
+func inviteNewUser(t *testing.T, ctx context.Context, inviter *testutils.TestUser, teamID uuid.UUID) (*domain.TeamInvitation, *testutils.TestUser) {
+	t.Helper()
+
+	id := uuid.New().String()[:8]
+	email := fmt.Sprintf("invitee_%s@test.com", id)
+
+	resp, err := testServer.TeamService.AddMemberOrInvite(ctx, teamID, inviter.Organization.ID, inviter.User.ID, &dto.InviteMemberRequest{Email: email})
+	if err != nil {
+		t.Fatalf("Failed to invite: %v", err)
+	}
+	if !resp.Invited {
+		t.Fatalf("Expected an invitation to be created")
+	}
+
+	invitee, err := testFixtures.CreateUser(ctx, email, fmt.Sprintf("invitee_%s", id), fmt.Sprintf("Org_%s", id))
+	if err != nil {
+		t.Fatalf("Failed to create invitee: %v", err)
+	}
+
+	return resp.Invitation, invitee
+}
+
+func expireInvitation(t *testing.T, id uuid.UUID) {
+	t.Helper()
+	if _, err := testDB.Exec(`UPDATE team_invitations SET expires_at = NOW() - INTERVAL '1 hour' WHERE id = $1`, id); err != nil {
+		t.Fatalf("Failed to expire invitation: %v", err)
+	}
+}
+
+func TestTeams_AcceptInvitation_Fresh(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Test Team")
+	invitation, invitee := inviteNewUser(t, ctx, user, team.ID)
+
+	if err := testServer.TeamService.AcceptInvitation(ctx, invitation.Token, invitee.User.ID); err != nil {
+		t.Fatalf("Expected fresh invitation to be accepted: %v", err)
+	}
+
+	members, _ := testServer.TeamService.ListMembers(ctx, team.ID)
+	if len(members) != 1 || members[0].ID != invitee.User.ID {
+		t.Errorf("Expected invitee to be the only team member, got %d members", len(members))
+	}
+}
+
+func TestTeams_AcceptInvitation_ExpiredRejected(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Test Team")
+	invitation, invitee := inviteNewUser(t, ctx, user, team.ID)
+	expireInvitation(t, invitation.ID)
+
+	if err := testServer.TeamService.AcceptInvitation(ctx, invitation.Token, invitee.User.ID); err == nil {
+		t.Fatal("Expected expired invitation to be rejected")
+	}
+
+	invitations, err := testServer.TeamService.ListTeamInvitations(ctx, team.ID)
+	if err != nil {
+		t.Fatalf("Failed to list invitations: %v", err)
+	}
+	if len(invitations) != 1 || invitations[0].Status != domain.InvitationStatusExpired {
+		t.Errorf("Expected the invitation to be marked expired, got %+v", invitations)
+	}
+
+	members, _ := testServer.TeamService.ListMembers(ctx, team.ID)
+	if len(members) != 0 {
+		t.Errorf("Expected no team members, got %d", len(members))
+	}
+}
+
+func TestTeams_ResendInvitation_ExtendsExpiry(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Test Team")
+	invitation, invitee := inviteNewUser(t, ctx, user, team.ID)
+	expireInvitation(t, invitation.ID)
+
+	// Listing marks the invitation expired before it is resent
+	testServer.TeamService.ListTeamInvitations(ctx, team.ID)
+
+	if err := testServer.TeamService.ResendInvitation(ctx, invitation.ID); err != nil {
+		t.Fatalf("Failed to resend invitation: %v", err)
+	}
+
+	invitations, _ := testServer.TeamService.ListTeamInvitations(ctx, team.ID)
+	if len(invitations) != 1 {
+		t.Fatalf("Expected 1 invitation, got %d", len(invitations))
+	}
+	if invitations[0].Status != domain.InvitationStatusPending {
+		t.Errorf("Expected resent invitation to be pending, got %s", invitations[0].Status)
+	}
+	if invitations[0].ExpiresAt.Before(time.Now().Add(6 * 24 * time.Hour)) {
+		t.Errorf("Expected expiry to be extended, got %s", invitations[0].ExpiresAt)
+	}
+
+	if err := testServer.TeamService.AcceptInvitation(ctx, invitation.Token, invitee.User.ID); err != nil {
+		t.Errorf("Expected resent invitation to be accepted: %v", err)
+	}
+}
+
+// ============================================================================
+// POST /api/v1/teams/invitations/accept
+// ============================================================================
+
+func TestTeams_RedeemInvitation_ExistingUser(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Test Team")
+	invitation, invitee := inviteNewUser(t, ctx, user, team.ID)
+	client.SetAuthToken(invitee.AccessToken)
+
+	reqBody := map[string]interface{}{
+		"token": invitation.Token,
+	}
+
+	resp := client.Post("/api/v1/teams/invitations/accept", reqBody)
+	client.AssertStatus(resp, http.StatusOK)
+
+	var result dto.AcceptInvitationResponse
+	client.ParseJSON(resp, &result)
+
+	if result.TeamID != team.ID {
+		t.Errorf("Expected team %s, got %s", team.ID, result.TeamID)
+	}
+	if result.AlreadyMember {
+		t.Error("Expected invitee not to be a member before accepting")
+	}
+
+	members, _ := testServer.TeamService.ListMembers(ctx, team.ID)
+	if len(members) != 1 || members[0].ID != invitee.User.ID {
+		t.Errorf("Expected invitee to be the only team member, got %d members", len(members))
+	}
+
+	// The invitee joins the inviting organization
+	orgUsers, _ := testServer.UserService.ListOrganizationUsers(ctx, user.Organization.ID, &dto.ListUsersRequest{})
+	joined := false
+	for _, u := range orgUsers.Users {
+		if u.ID == invitee.User.ID {
+			joined = true
+		}
+	}
+	if !joined {
+		t.Error("Expected invitee to be added to the inviting organization")
+	}
+
+	// The token can't be redeemed twice
+	resp = client.Post("/api/v1/teams/invitations/accept", reqBody)
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+func TestTeams_RedeemInvitation_WrongEmail(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Test Team")
+	invitation, _ := inviteNewUser(t, ctx, user, team.ID)
+	client.SetAuthToken(other.AccessToken)
+
+	reqBody := map[string]interface{}{
+		"token": invitation.Token,
+	}
+
+	resp := client.Post("/api/v1/teams/invitations/accept", reqBody)
+	client.ExpectStatus(resp, http.StatusForbidden)
+}
+
+func TestTeams_RedeemInvitation_InvalidToken(t *testing.T) {
+	cleanDatabase(t)
+	client := newTestClient(t)
+
+	reqBody := map[string]interface{}{
+		"token":    "not-a-real-token",
+		"username": "newcomer",
+		"password": "TestPassword123!",
+	}
+
+	resp := client.Post("/api/v1/teams/invitations/accept", reqBody)
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
@@ -15,6 +15,7 @@ type TestClient struct {
 	httpClient *http.Client
 	t          *testing.T
 	authToken  string
+	headers    map[string]string
 }
 
 // NewTestClient creates a new test HTTP client
@@ -36,6 +37,14 @@ func (c *TestClient) ClearAuthToken() {
 	c.authToken = ""
 }
 
+// SetHeader sets a header sent with subsequent requests
+func (c *TestClient) SetHeader(key, value string) {
+	if c.headers == nil {
+		c.headers = make(map[string]string)
+	}
+	c.headers[key] = value
+}
+
 // Get performs a GET request
 func (c *TestClient) Get(path string) *http.Response {
 	return c.doRequest("GET", path, nil)
@@ -78,6 +87,11 @@ func (c *TestClient) Delete(path string) *http.Response {
 }
 
 // doRequest performs an HTTP request
+// PostRaw performs a POST request with a raw body, which need not be valid JSON
+func (c *TestClient) PostRaw(path string, body []byte) *http.Response {
+	return c.doRawRequest(http.MethodPost, path, bytes.NewReader(body))
+}
+
 func (c *TestClient) doRequest(method, path string, body interface{}) *http.Response {
 	var reqBody io.Reader
 	if body != nil {
@@ -88,6 +102,10 @@ func (c *TestClient) doRequest(method, path string, body interface{}) *http.Resp
 		reqBody = bytes.NewBuffer(jsonBody)
 	}
 
+	return c.doRawRequest(method, path, reqBody)
+}
+
+func (c *TestClient) doRawRequest(method, path string, reqBody io.Reader) *http.Response {
 	req, err := http.NewRequest(method, c.baseURL+path, reqBody)
 	if err != nil {
 		c.t.Fatalf("Failed to create request: %v", err)
@@ -97,6 +115,9 @@ func (c *TestClient) doRequest(method, path string, body interface{}) *http.Resp
 	if c.authToken != "" {
 		req.Header.Set("Authorization", "Bearer "+c.authToken)
 	}
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -71,25 +71,35 @@ func (tdb *TestDB) TruncateAll(ctx context.Context) error {
 		"incident_timeline",
 		"incident_responders",
 		"incidents",
+		"event_outbox",
 		"webhook_deliveries",
 		"webhook_endpoints",
 		"incoming_webhook_tokens",
 		"notification_logs",
 		"user_notification_preferences",
 		"notification_channels",
+		"alert_escalation_notified_steps",
 		"alert_escalation_events",
 		"escalation_targets",
 		"escalation_rules",
 		"escalation_policies",
+		"schedule_handoff_notifications",
 		"schedule_overrides",
 		"schedule_rotation_participants",
 		"schedule_rotations",
 		"schedules",
 		"alert_routing_rules",
 		"api_keys",
+		"user_sessions",
+		"login_attempts",
+		"login_lockouts",
 		"email_verifications",
 		"user_dnd_settings",
+		"user_unavailability",
 		"team_invitations",
+		"alerts_archive",
+		"alert_ack_tokens",
+		"alert_sources",
 		"alerts",
 		"team_members",
 		"teams",
@@ -116,25 +126,35 @@ func (tdb *TestDB) Reset() error {
 		"incident_timeline",
 		"incident_responders",
 		"incidents",
+		"event_outbox",
 		"webhook_deliveries",
 		"webhook_endpoints",
 		"incoming_webhook_tokens",
 		"notification_logs",
 		"user_notification_preferences",
 		"notification_channels",
+		"alert_escalation_notified_steps",
 		"alert_escalation_events",
 		"escalation_targets",
 		"escalation_rules",
 		"escalation_policies",
+		"schedule_handoff_notifications",
 		"schedule_overrides",
 		"schedule_rotation_participants",
 		"schedule_rotations",
 		"schedules",
 		"alert_routing_rules",
 		"api_keys",
+		"user_sessions",
+		"login_attempts",
+		"login_lockouts",
 		"email_verifications",
 		"user_dnd_settings",
+		"user_unavailability",
 		"team_invitations",
+		"alerts_archive",
+		"alert_ack_tokens",
+		"alert_sources",
 		"alerts",
 		"team_members",
 		"teams",
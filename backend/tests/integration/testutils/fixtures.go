@@ -39,7 +39,7 @@ func (f *TestFixtures) CreateUser(ctx context.Context, email, username, orgName
 		OrganizationName: orgName,
 	}
 
-	resp, err := f.server.AuthService.Register(ctx, req)
+	resp, err := f.server.AuthService.Register(ctx, req, "", "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to register user: %w", err)
 	}
@@ -195,9 +195,14 @@ func (f *TestFixtures) CreateUniqueWebhookEndpoint(ctx context.Context, orgID uu
 
 // CreateIncomingWebhookToken creates an incoming webhook token
 func (f *TestFixtures) CreateIncomingWebhookToken(ctx context.Context, orgID uuid.UUID, name string) (*domain.IncomingWebhookToken, error) {
+	return f.CreateIncomingWebhookTokenOfType(ctx, orgID, name, domain.IncomingWebhookGeneric)
+}
+
+// CreateIncomingWebhookTokenOfType creates an incoming webhook token for the given integration type
+func (f *TestFixtures) CreateIncomingWebhookTokenOfType(ctx context.Context, orgID uuid.UUID, name string, integrationType domain.IncomingWebhookIntegrationType) (*domain.IncomingWebhookToken, error) {
 	req := &dto.CreateIncomingWebhookTokenRequest{
 		Name:            name,
-		IntegrationType: string(domain.IncomingWebhookGeneric),
+		IntegrationType: string(integrationType),
 	}
 	return f.server.WebhookService.CreateIncomingToken(ctx, orgID, req)
 }
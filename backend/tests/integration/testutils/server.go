@@ -1,7 +1,10 @@
 package testutils
 
 import (
+	"context"
+	"fmt"
 	"net/http/httptest"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -9,8 +12,11 @@ import (
 	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/handler"
 	"github.com/nmn3m/pulsar/backend/internal/adapter/inbound/rest/middleware"
 	"github.com/nmn3m/pulsar/backend/internal/adapter/outbound/postgres"
+	"github.com/nmn3m/pulsar/backend/internal/adapter/outbound/provider"
 	"github.com/nmn3m/pulsar/backend/internal/config"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
 	"github.com/nmn3m/pulsar/backend/internal/core/service"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/prommetrics"
 	"github.com/nmn3m/pulsar/backend/internal/pkg/tokenblacklist"
 )
 
@@ -24,6 +30,7 @@ type TestServer struct {
 
 	// Services exposed for direct manipulation in tests
 	AuthService         *service.AuthService
+	EmailVerification   *service.EmailVerificationService
 	AlertService        *service.AlertService
 	TeamService         *service.TeamService
 	ScheduleService     *service.ScheduleService
@@ -31,8 +38,12 @@ type TestServer struct {
 	NotificationService *service.NotificationService
 	IncidentService     *service.IncidentService
 	WebhookService      *service.WebhookService
+	OutboxRelay         *service.OutboxRelay
 	UserService         *service.UserService
 	MetricsService      *service.MetricsService
+	HandoffNotifier     *service.HandoffNotifier
+	DNDService          *service.DNDService
+	AlertNotifier       *service.AlertNotifier
 }
 
 // NewTestServer creates a new test server with all dependencies wired up
@@ -51,8 +62,13 @@ func NewTestServer(testDB *TestDB, testCfg *TestConfig) (*TestServer, error) {
 		JWT: config.JWTConfig{
 			Secret:        testCfg.JWTSecret,
 			RefreshSecret: testCfg.RefreshSecret,
-			AccessTTL:     15,
-			RefreshTTL:    7,
+			AccessTTL:     15 * time.Minute,
+			RefreshTTL:    7 * 24 * time.Hour,
+		},
+		AckLinks: config.AckLinkConfig{
+			Secret:  testCfg.JWTSecret,
+			TTL:     time.Hour,
+			BaseURL: "http://localhost:" + testCfg.ServerPort,
 		},
 		CORS: config.CORSConfig{
 			AllowedOrigins: []string{"*"},
@@ -69,41 +85,115 @@ func NewTestServer(testDB *TestDB, testCfg *TestConfig) (*TestServer, error) {
 	userRepo := postgres.NewUserRepository(db)
 	orgRepo := postgres.NewOrganizationRepository(db)
 	alertRepo := postgres.NewAlertRepository(db)
+	alertAckTokenRepo := postgres.NewAlertAckTokenRepository(db)
 	teamRepo := postgres.NewTeamRepository(db)
 	scheduleRepo := postgres.NewScheduleRepository(db)
 	escalationRepo := postgres.NewEscalationPolicyRepository(db)
-	notificationRepo := postgres.NewNotificationRepository(testDB.DB)
-	incidentRepo := postgres.NewIncidentRepository(testDB.DB)
-	webhookRepo := postgres.NewWebhookRepository(testDB.DB)
-	metricsRepo := postgres.NewMetricsRepository(testDB.DB)
+	notificationRepo := postgres.NewNotificationRepository(db)
+	incidentRepo := postgres.NewIncidentRepository(db)
+	webhookRepo := postgres.NewWebhookRepository(db)
+	outboxRepo := postgres.NewOutboxRepository(db)
+	metricsRepo := postgres.NewMetricsRepository(db.Reader())
 	dndRepo := postgres.NewDNDSettingsRepository(db)
+	availabilityRepo := postgres.NewUserAvailabilityRepository(db)
+	routingRepo := postgres.NewRoutingRuleRepository(db)
+	orgImportRepo := postgres.NewOrganizationImportRepository(db)
+	invitationRepo := postgres.NewTeamInvitationRepo(db)
+	maintenanceRepo := postgres.NewMaintenanceWindowRepository(db)
+	alertSourceRepo := postgres.NewAlertSourceRepository(db)
+	loginAttemptRepo := postgres.NewLoginAttemptRepository(db)
+	sessionRepo := postgres.NewSessionRepository(db)
 
 	// Initialize services
 	bl := tokenblacklist.New()
-	// Email verification is nil for tests (SMTP not configured)
-	var emailVerificationService *service.EmailVerificationService
+	// Email verification runs without a provider in tests (SMTP not configured),
+	// so sign-ups start out verified and codes are only logged
+	emailVerificationService := service.NewEmailVerificationService(
+		postgres.NewEmailVerificationRepository(db),
+		userRepo,
+		service.NewEmailService(&config.EmailConfig{}, &config.SMTPConfig{}),
+		logger,
+	)
+	emailVerificationService.SetEnvironment(cfg.Server.Env)
 	authService := service.NewAuthService(userRepo, orgRepo, service.AuthConfig{
 		JWTSecret:        cfg.JWT.Secret,
 		JWTRefreshSecret: cfg.JWT.RefreshSecret,
-		AccessTTLMinutes: cfg.JWT.AccessTTL,
-		RefreshTTLDays:   cfg.JWT.RefreshTTL,
+		AccessTTL:        cfg.JWT.AccessTTL,
+		RefreshTTL:       cfg.JWT.RefreshTTL,
+		PasswordPolicy: domain.PasswordPolicy{
+			MinLength:      cfg.Password.MinLength,
+			RequireUpper:   cfg.Password.RequireUpper,
+			RequireLower:   cfg.Password.RequireLower,
+			RequireDigit:   cfg.Password.RequireDigit,
+			RequireSpecial: cfg.Password.RequireSpecial,
+			Denylist:       cfg.Password.Denylist,
+		},
+		LoginPolicy: domain.LoginPolicy{
+			MaxAccountFailures: cfg.Login.MaxAccountFailures,
+			MaxIPFailures:      cfg.Login.MaxIPFailures,
+			FailureWindow:      cfg.Login.FailureWindow,
+			LockoutDuration:    cfg.Login.LockoutDuration,
+		},
 	}, emailVerificationService, bl, logger)
+	authService.SetLoginAttemptRepo(loginAttemptRepo)
+	authService.SetSessionRepo(sessionRepo)
 	teamService := service.NewTeamService(teamRepo, userRepo)
+	teamService.SetInvitationRepo(invitationRepo)
+	teamService.SetOrganizationRepo(orgRepo)
+	teamService.SetInvitationSignup(authService)
 	userService := service.NewUserService(orgRepo, userRepo)
+	userService.SetLoginAttemptRepo(loginAttemptRepo)
+	userService.SetSessionRepo(sessionRepo)
 	scheduleService := service.NewScheduleService(scheduleRepo, userRepo)
-	notificationService := service.NewNotificationService(notificationRepo)
+	scheduleService.SetOrganizationRepo(orgRepo)
+	scheduleService.SetTeamRepo(teamRepo)
+	scheduleService.SetAvailabilityRepo(availabilityRepo)
+	teamService.SetMembershipSync(scheduleService)
+	notificationService := service.NewNotificationService(notificationRepo, logger)
 	wsService := service.NewWebSocketService(logger)
 	incidentService := service.NewIncidentService(incidentRepo, wsService)
+	incidentService.SetAlertRepo(alertRepo)
+	incidentService.SetOrganizationRepo(orgRepo)
+	incidentService.SetEscalationPolicyRepo(escalationRepo)
+	incidentService.SetOnCallResolver(scheduleService)
+	incidentService.SetWarRoomCreator(provider.NewSlackWarRoomClient(provider.SlackAPIBaseURL))
+	incidentService.SetIssueTracker(provider.NewJiraClient())
+	incidentService.SetStatuspagePublisher(provider.NewStatuspageClient(provider.StatuspageAPIBaseURL))
 	webhookService := service.NewWebhookService(webhookRepo, logger)
+	incidentService.SetWebhookDispatcher(webhookService)
 	metricsService := service.NewMetricsService(metricsRepo)
+	workloadService := service.NewWorkloadService(alertRepo, incidentRepo, scheduleService)
 	dndService := service.NewDNDService(dndRepo)
+	availabilityService := service.NewAvailabilityService(availabilityRepo)
+	routingService := service.NewRoutingService(routingRepo)
+	routingService.SetEscalationPolicyRepo(escalationRepo)
+	maintenanceService := service.NewMaintenanceWindowService(maintenanceRepo, routingService)
+	alertSourceService := service.NewAlertSourceService(alertSourceRepo)
 
 	// Initialize alert notifier with dependencies
 	alertNotifier := service.NewAlertNotifier(notificationService, userRepo, teamRepo, scheduleService, dndService)
+	alertNotifier.SetOrganizationRepo(orgRepo)
+	handoffNotifier := service.NewHandoffNotifier(scheduleRepo, userRepo, scheduleService, notificationService)
 
 	// Initialize alert and escalation services with notifier
-	alertService := service.NewAlertService(alertRepo, alertNotifier, wsService, webhookService)
-	escalationService := service.NewEscalationService(escalationRepo, alertRepo, alertNotifier)
+	outboxRelay := service.NewOutboxRelay(outboxRepo, alertRepo, wsService, webhookService, logger)
+	alertService := service.NewAlertService(alertRepo, alertNotifier, wsService, webhookService, logger)
+	alertService.SetOutboxPublisher(outboxRelay)
+	alertService.SetOrganizationRepo(orgRepo)
+	alertService.SetMaintenanceMatcher(maintenanceService)
+	alertService.SetSourceNormalizer(alertSourceService)
+	alertService.SetEscalationPolicySelector(routingService)
+	alertService.SetEscalationPolicyRepo(escalationRepo)
+	alertService.SetOnCallResolver(scheduleService)
+	alertService.SetAckTokens(alertAckTokenRepo, userRepo, service.AckTokenConfig{
+		Secret: cfg.AckLinks.Secret,
+		TTL:    cfg.AckLinks.TTL,
+	})
+	alertNotifier.SetAckLinks(alertService, cfg.AckLinks.BaseURL)
+	incidentService.SetAlertCloser(alertService)
+	escalationService := service.NewEscalationService(escalationRepo, alertRepo, orgRepo, teamRepo, scheduleRepo, alertNotifier)
+	escalationService.SetTargetPreviewer(alertNotifier)
+	orgService := service.NewOrganizationService(orgRepo, teamRepo, scheduleRepo, escalationRepo, routingRepo, notificationRepo, alertRepo, incidentRepo, orgImportRepo)
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authService, emailVerificationService, bl)
@@ -114,21 +204,50 @@ func NewTestServer(testDB *TestDB, testCfg *TestConfig) (*TestServer, error) {
 	escalationHandler := handler.NewEscalationHandler(escalationService)
 	notificationHandler := handler.NewNotificationHandler(notificationService)
 	incidentHandler := handler.NewIncidentHandler(incidentService)
+	eventStreamHandler := handler.NewEventStreamHandler(wsService, logger)
+	metaHandler := handler.NewMetaHandler()
+	workloadHandler := handler.NewWorkloadHandler(workloadService)
 	webhookHandler := handler.NewWebhookHandler(webhookService)
 	incomingWebhookHandler := handler.NewIncomingWebhookHandler(webhookService, alertService, logger)
 	metricsHandler := handler.NewMetricsHandler(metricsService)
+	healthHandler := handler.NewHealthHandler(testDB, "test", logger)
+	orgHandler := handler.NewOrganizationHandler(orgService)
+	dndHandler := handler.NewDNDHandler(dndService)
+	availabilityHandler := handler.NewAvailabilityHandler(availabilityService)
+	maintenanceHandler := handler.NewMaintenanceWindowHandler(maintenanceService)
+	alertSourceHandler := handler.NewAlertSourceHandler(alertSourceService)
 
 	// Initialize middleware
 	authMiddleware := middleware.NewAuthMiddleware(cfg.JWT.Secret, bl)
+	authMiddleware.SetMembershipResolver(orgRepo)
 
 	// Setup router
 	router := gin.New()
+	if err := router.SetTrustedProxies(nil); err != nil {
+		return nil, fmt.Errorf("failed to set trusted proxies: %w", err)
+	}
 	router.Use(gin.Recovery())
+	router.Use(middleware.RequestID())
+	router.Use(middleware.PrometheusMiddleware())
+
+	// Prometheus metrics (mirrors main.go)
+	if _, err := prommetrics.RegisterQueueDepth("webhook_deliveries_pending", "Number of webhook deliveries waiting to be sent.", webhookRepo.CountPendingDeliveries); err != nil {
+		return nil, fmt.Errorf("failed to register webhook queue metric: %w", err)
+	}
+	if _, err := prommetrics.RegisterQueueDepth("escalations_pending", "Number of escalation events that are due but not yet processed.", func(ctx context.Context) (int, error) {
+		return escalationRepo.CountPendingEscalations(ctx, time.Now())
+	}); err != nil {
+		return nil, fmt.Errorf("failed to register escalation backlog metric: %w", err)
+	}
+	router.GET("/metrics", gin.WrapH(prommetrics.Handler()))
 
 	// Setup routes (mirrors main.go)
 	setupRoutes(router, authMiddleware, authHandler, alertHandler, teamHandler,
 		userHandler, scheduleHandler, escalationHandler, notificationHandler,
-		incidentHandler, webhookHandler, incomingWebhookHandler, metricsHandler)
+		incidentHandler, eventStreamHandler, metaHandler, workloadHandler, webhookHandler, incomingWebhookHandler, metricsHandler, healthHandler, orgHandler, dndHandler, availabilityHandler, maintenanceHandler, alertSourceHandler)
+
+	// Start WebSocket hub
+	go wsService.Run()
 
 	// Create test server
 	server := httptest.NewServer(router)
@@ -140,6 +259,7 @@ func NewTestServer(testDB *TestDB, testCfg *TestConfig) (*TestServer, error) {
 		Config:              cfg,
 		Logger:              logger,
 		AuthService:         authService,
+		EmailVerification:   emailVerificationService,
 		AlertService:        alertService,
 		TeamService:         teamService,
 		ScheduleService:     scheduleService,
@@ -147,8 +267,12 @@ func NewTestServer(testDB *TestDB, testCfg *TestConfig) (*TestServer, error) {
 		NotificationService: notificationService,
 		IncidentService:     incidentService,
 		WebhookService:      webhookService,
+		OutboxRelay:         outboxRelay,
 		UserService:         userService,
 		MetricsService:      metricsService,
+		HandoffNotifier:     handoffNotifier,
+		DNDService:          dndService,
+		AlertNotifier:       alertNotifier,
 	}, nil
 }
 
@@ -164,13 +288,26 @@ func setupRoutes(
 	escalationHandler *handler.EscalationHandler,
 	notificationHandler *handler.NotificationHandler,
 	incidentHandler *handler.IncidentHandler,
+	eventStreamHandler *handler.EventStreamHandler,
+	metaHandler *handler.MetaHandler,
+	workloadHandler *handler.WorkloadHandler,
 	webhookHandler *handler.WebhookHandler,
 	incomingWebhookHandler *handler.IncomingWebhookHandler,
 	metricsHandler *handler.MetricsHandler,
+	healthHandler *handler.HealthHandler,
+	orgHandler *handler.OrganizationHandler,
+	dndHandler *handler.DNDHandler,
+	availabilityHandler *handler.AvailabilityHandler,
+	maintenanceHandler *handler.MaintenanceWindowHandler,
+	alertSourceHandler *handler.AlertSourceHandler,
 ) {
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
+		// Health checks
+		v1.GET("/health", healthHandler.Live)
+		v1.GET("/health/ready", healthHandler.Ready)
+
 		// Auth routes (public)
 		auth := v1.Group("/auth")
 		{
@@ -178,29 +315,96 @@ func setupRoutes(
 			auth.POST("/login", authHandler.Login)
 			auth.POST("/refresh", authHandler.RefreshToken)
 			auth.POST("/logout", authHandler.Logout)
+			auth.POST("/verify-email", authHandler.VerifyEmail)
+			auth.POST("/resend-otp", authHandler.ResendOTP)
 		}
 
+		v1.POST("/teams/invitations/accept", authMiddleware.OptionalAuth(), teamHandler.AcceptInvitation)
+
 		// Protected routes
 		protected := v1.Group("")
 		protected.Use(authMiddleware.RequireAuth())
 		{
 			protected.GET("/auth/me", authHandler.GetMe)
+			protected.GET("/auth/sessions", authHandler.ListSessions)
+			protected.DELETE("/auth/sessions", authHandler.RevokeAllSessions)
+			protected.DELETE("/auth/sessions/:id", authHandler.RevokeSession)
+			protected.POST("/auth/switch-organization", authHandler.SwitchOrganization)
+			protected.GET("/me/organizations", authHandler.ListOrganizations)
+			protected.PATCH("/auth/me", userHandler.UpdateProfile)
 
 			// User routes
 			protected.GET("/users", userHandler.ListOrganizationUsers)
+			protected.GET("/me/workload", workloadHandler.Get)
+			protected.POST("/users/:id/deactivate", userHandler.DeactivateUser)
+			protected.POST("/users/:id/reactivate", userHandler.ReactivateUser)
+			protected.POST("/users/:id/unlock", userHandler.UnlockUser)
+			protected.GET("/users/:id/sessions", userHandler.ListUserSessions)
+			protected.DELETE("/users/:id/sessions", userHandler.RevokeUserSessions)
+
+			// User DND routes
+			usersDND := protected.Group("/users/me/dnd")
+			{
+				usersDND.GET("", dndHandler.GetDNDSettings)
+				usersDND.PUT("", dndHandler.UpdateDNDSettings)
+				usersDND.DELETE("", dndHandler.DeleteDNDSettings)
+				usersDND.GET("/status", dndHandler.CheckDNDStatus)
+				usersDND.POST("/overrides", dndHandler.AddDNDOverride)
+				usersDND.DELETE("/overrides/:index", dndHandler.RemoveDNDOverride)
+			}
+
+			// User availability routes
+			usersUnavailability := protected.Group("/users/me/unavailability")
+			{
+				usersUnavailability.GET("", availabilityHandler.ListUnavailability)
+				usersUnavailability.POST("", availabilityHandler.AddUnavailability)
+				usersUnavailability.DELETE("/:id", availabilityHandler.RemoveUnavailability)
+			}
+
+			// Maintenance window routes
+			maintenance := protected.Group("/maintenance-windows")
+			{
+				maintenance.GET("", maintenanceHandler.List)
+				maintenance.POST("", maintenanceHandler.Create)
+				maintenance.GET("/:id", maintenanceHandler.Get)
+				maintenance.PATCH("/:id", maintenanceHandler.Update)
+				maintenance.DELETE("/:id", maintenanceHandler.Delete)
+			}
+
+			// Alert source registry routes
+			sources := protected.Group("/sources")
+			{
+				sources.GET("", alertSourceHandler.List)
+				sources.POST("", alertSourceHandler.Create)
+				sources.PATCH("/:id", alertSourceHandler.Update)
+				sources.DELETE("/:id", alertSourceHandler.Delete)
+			}
+
+			// Organization routes
+			protected.GET("/organizations/export", orgHandler.Export)
+			protected.GET("/organizations/settings", orgHandler.GetSettings)
+			protected.PATCH("/organizations/settings", orgHandler.UpdateSettings)
+			protected.POST("/organizations/import", orgHandler.Import)
 
 			// Alert routes
 			alerts := protected.Group("/alerts")
 			{
 				alerts.GET("", alertHandler.List)
+				alerts.GET("/export.csv", alertHandler.Export)
+				alerts.GET("/grouped", alertHandler.Grouped)
 				alerts.POST("", alertHandler.Create)
+				alerts.POST("/tags", alertHandler.BulkTags)
 				alerts.GET("/:id", alertHandler.Get)
+				alerts.GET("/:id/oncall-at-creation", alertHandler.GetOnCallAtCreation)
 				alerts.PATCH("/:id", alertHandler.Update)
 				alerts.DELETE("/:id", alertHandler.Delete)
 				alerts.POST("/:id/acknowledge", alertHandler.Acknowledge)
+				alerts.POST("/:id/unacknowledge", alertHandler.Unacknowledge)
 				alerts.POST("/:id/close", alertHandler.Close)
 				alerts.POST("/:id/snooze", alertHandler.Snooze)
 				alerts.POST("/:id/assign", alertHandler.Assign)
+				alerts.POST("/:id/notes", alertHandler.AddNote)
+				alerts.GET("/:id/notes", alertHandler.ListNotes)
 			}
 
 			// Team routes
@@ -218,14 +422,18 @@ func setupRoutes(
 			}
 
 			// Schedule routes
+			protected.GET("/oncall", scheduleHandler.ListOnCall)
 			schedules := protected.Group("/schedules")
 			{
 				schedules.GET("", scheduleHandler.List)
 				schedules.POST("", scheduleHandler.Create)
+				schedules.POST("/preview-oncall", scheduleHandler.PreviewOnCall)
 				schedules.GET("/:id", scheduleHandler.Get)
 				schedules.PATCH("/:id", scheduleHandler.Update)
 				schedules.DELETE("/:id", scheduleHandler.Delete)
+				schedules.PUT("/:id/config", scheduleHandler.ApplyConfig)
 				schedules.GET("/:id/oncall", scheduleHandler.GetOnCall)
+				schedules.GET("/:id/coverage", scheduleHandler.GetCoverage)
 
 				// Rotation routes
 				schedules.GET("/:id/rotations", scheduleHandler.ListRotations)
@@ -239,6 +447,7 @@ func setupRoutes(
 				schedules.POST("/:id/rotations/:rotationId/participants", scheduleHandler.AddParticipant)
 				schedules.DELETE("/:id/rotations/:rotationId/participants/:userId", scheduleHandler.RemoveParticipant)
 				schedules.PUT("/:id/rotations/:rotationId/participants/reorder", scheduleHandler.ReorderParticipants)
+				schedules.POST("/:id/rotations/:rotationId/participants/bulk", scheduleHandler.SetParticipants)
 
 				// Override routes
 				schedules.GET("/:id/overrides", scheduleHandler.ListOverrides)
@@ -256,6 +465,9 @@ func setupRoutes(
 				escalations.GET("/:id", escalationHandler.Get)
 				escalations.PATCH("/:id", escalationHandler.Update)
 				escalations.DELETE("/:id", escalationHandler.Delete)
+				escalations.PUT("/:id/config", escalationHandler.ApplyConfig)
+				escalations.POST("/:id/clone", escalationHandler.Clone)
+				escalations.GET("/:id/preview", escalationHandler.Preview)
 
 				// Rule routes
 				escalations.GET("/:id/rules", escalationHandler.ListRules)
@@ -279,6 +491,7 @@ func setupRoutes(
 				notifications.GET("/channels/:id", notificationHandler.GetChannel)
 				notifications.PATCH("/channels/:id", notificationHandler.UpdateChannel)
 				notifications.DELETE("/channels/:id", notificationHandler.DeleteChannel)
+				notifications.POST("/channels/:id/test", notificationHandler.TestChannel)
 
 				// User preference routes
 				notifications.GET("/preferences", notificationHandler.ListUserPreferences)
@@ -301,6 +514,7 @@ func setupRoutes(
 			incidents := protected.Group("/incidents")
 			{
 				incidents.GET("", incidentHandler.List)
+				incidents.GET("/export.csv", incidentHandler.Export)
 				incidents.POST("", incidentHandler.Create)
 				incidents.GET("/:id", incidentHandler.GetWithDetails)
 				incidents.PATCH("/:id", incidentHandler.Update)
@@ -315,13 +529,27 @@ func setupRoutes(
 				// Timeline routes
 				incidents.GET("/:id/timeline", incidentHandler.GetTimeline)
 				incidents.POST("/:id/notes", incidentHandler.AddNote)
+				incidents.PATCH("/:id/notes/:eventId", incidentHandler.UpdateNote)
+				incidents.DELETE("/:id/notes/:eventId", incidentHandler.DeleteNote)
 
 				// Alert linking routes
 				incidents.GET("/:id/alerts", incidentHandler.ListAlerts)
+				incidents.GET("/:id/suggested-alerts", incidentHandler.SuggestAlerts)
 				incidents.POST("/:id/alerts", incidentHandler.LinkAlert)
 				incidents.DELETE("/:id/alerts/:alertId", incidentHandler.UnlinkAlert)
 			}
 
+			// Server-Sent Events route
+			protected.GET("/events/stream", eventStreamHandler.Stream)
+
+			// Enum metadata routes
+			meta := protected.Group("/meta")
+			{
+				meta.GET("/priorities", metaHandler.Priorities)
+				meta.GET("/severities", metaHandler.Severities)
+				meta.GET("/statuses", metaHandler.Statuses)
+			}
+
 			// Webhook routes
 			webhooks := protected.Group("/webhooks")
 			{
@@ -330,12 +558,15 @@ func setupRoutes(
 				webhooks.GET("/endpoints/:id", webhookHandler.GetEndpoint)
 				webhooks.PATCH("/endpoints/:id", webhookHandler.UpdateEndpoint)
 				webhooks.DELETE("/endpoints/:id", webhookHandler.DeleteEndpoint)
+				webhooks.POST("/endpoints/:id/rotate-secret", webhookHandler.RotateEndpointSecret)
 
 				webhooks.GET("/deliveries", webhookHandler.ListDeliveries)
 
 				webhooks.GET("/incoming", webhookHandler.ListIncomingTokens)
 				webhooks.POST("/incoming", webhookHandler.CreateIncomingToken)
 				webhooks.DELETE("/incoming/:id", webhookHandler.DeleteIncomingToken)
+				webhooks.GET("/incoming/:id/requests", webhookHandler.ListIncomingRequests)
+				webhooks.POST("/incoming/:id/rotate", webhookHandler.RotateIncomingToken)
 			}
 
 			// Metrics routes
@@ -350,6 +581,9 @@ func setupRoutes(
 			}
 		}
 
+		// Acknowledge links in escalation notifications carry their own token
+		v1.POST("/alerts/ack/:token", alertHandler.AcknowledgeWithToken)
+
 		// Public incoming webhook route (no auth required)
 		v1.POST("/webhook/:token", incomingWebhookHandler.ReceiveWebhook)
 	}
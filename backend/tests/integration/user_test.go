@@ -2,10 +2,49 @@ package integration
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/url"
 	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/tests/integration/testutils"
 )
 
+// joinOrganization adds an existing user to another organization as a member
+func joinOrganization(t *testing.T, ctx context.Context, orgID, userID uuid.UUID) {
+	t.Helper()
+
+	if _, err := testDB.ExecContext(ctx,
+		`INSERT INTO organization_users (organization_id, user_id, role) VALUES ($1, $2, 'member')`,
+		orgID, userID,
+	); err != nil {
+		t.Fatalf("Failed to add user to organization: %v", err)
+	}
+}
+
+func listUsers(t *testing.T, client *testutils.TestClient, query url.Values) dto.ListUsersResponse {
+	t.Helper()
+
+	resp := client.Get("/api/v1/users?" + query.Encode())
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var result dto.ListUsersResponse
+	client.ParseJSON(resp, &result)
+	return result
+}
+
+func userIDs(users []*domain.UserWithOrganization) map[uuid.UUID]bool {
+	ids := make(map[uuid.UUID]bool, len(users))
+	for _, u := range users {
+		ids[u.ID] = true
+	}
+	return ids
+}
+
 // ============================================================================
 // GET /api/v1/users
 // ============================================================================
@@ -38,3 +77,535 @@ func TestUsers_List_Unauthorized(t *testing.T) {
 	resp := client.Get("/api/v1/users")
 	client.ExpectStatus(resp, http.StatusUnauthorized)
 }
+
+func TestUsers_List_Search(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	id := uuid.New().String()[:8]
+	alice, _ := testFixtures.CreateUser(ctx, fmt.Sprintf("alice_%s@example.org", id), fmt.Sprintf("alice_%s", id), "Other Org A")
+	bob, _ := testFixtures.CreateUser(ctx, fmt.Sprintf("bob_%s@test.com", id), fmt.Sprintf("bob_%s", id), "Other Org B")
+	joinOrganization(t, ctx, user.Organization.ID, alice.User.ID)
+	joinOrganization(t, ctx, user.Organization.ID, bob.User.ID)
+	client.SetAuthToken(user.AccessToken)
+
+	// Username match, case-insensitive
+	result := listUsers(t, client, url.Values{"search": {"ALICE_" + id}})
+	if result.Total != 1 || !userIDs(result.Users)[alice.User.ID] {
+		t.Errorf("Expected only alice to match username search, got %d users", result.Total)
+	}
+
+	// Email match
+	result = listUsers(t, client, url.Values{"search": {"example.org"}})
+	if result.Total != 1 || !userIDs(result.Users)[alice.User.ID] {
+		t.Errorf("Expected only alice to match email search, got %d users", result.Total)
+	}
+
+	// Full name match; every fixture user is named "Test User"
+	result = listUsers(t, client, url.Values{"search": {"test user"}})
+	if result.Total != 3 {
+		t.Errorf("Expected all 3 users to match full name search, got %d", result.Total)
+	}
+
+	// Users of other organizations never match
+	outsider, _ := testFixtures.CreateUniqueUser(ctx)
+	result = listUsers(t, client, url.Values{"search": {outsider.User.Username}})
+	if result.Total != 0 {
+		t.Errorf("Expected no matches outside the organization, got %d", result.Total)
+	}
+}
+
+func TestUsers_List_TeamFilter(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	member, _ := testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, user.Organization.ID, member.User.ID)
+	client.SetAuthToken(user.AccessToken)
+
+	team, _ := testFixtures.CreateTeam(ctx, user.Organization.ID, "Platform")
+	if err := testServer.TeamService.AddMember(ctx, team.ID, &dto.AddTeamMemberRequest{UserID: &member.User.ID}); err != nil {
+		t.Fatalf("Failed to add team member: %v", err)
+	}
+
+	result := listUsers(t, client, url.Values{"team_id": {team.ID.String()}})
+	if result.Total != 1 || !userIDs(result.Users)[member.User.ID] {
+		t.Errorf("Expected only the team member, got %d users", result.Total)
+	}
+
+	resp := client.Get("/api/v1/users?team_id=not-a-uuid")
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+func TestUsers_List_ActiveFilterAndPagination(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	for i := 0; i < 2; i++ {
+		other, _ := testFixtures.CreateUniqueUser(ctx)
+		joinOrganization(t, ctx, user.Organization.ID, other.User.ID)
+		if i == 0 {
+			if _, err := testDB.ExecContext(ctx, `UPDATE users SET is_active = false WHERE id = $1`, other.User.ID); err != nil {
+				t.Fatalf("Failed to deactivate user: %v", err)
+			}
+		}
+	}
+	client.SetAuthToken(user.AccessToken)
+
+	result := listUsers(t, client, url.Values{"is_active": {"true"}})
+	if result.Total != 2 {
+		t.Errorf("Expected 2 active users, got %d", result.Total)
+	}
+
+	result = listUsers(t, client, url.Values{"page": {"2"}, "page_size": {"2"}})
+	if result.Total != 3 || len(result.Users) != 1 {
+		t.Errorf("Expected 1 user on page 2 of 3, got %d of %d", len(result.Users), result.Total)
+	}
+	if result.Page != 2 || result.PageSize != 2 {
+		t.Errorf("Expected page 2 with size 2, got page %d size %d", result.Page, result.PageSize)
+	}
+}
+
+// ============================================================================
+// PATCH /api/v1/auth/me
+// ============================================================================
+
+func TestUsers_UpdateProfile_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Patch("/api/v1/auth/me", map[string]interface{}{
+		"full_name": "  Jane Doe ",
+		"phone":     "+14155550123",
+		"timezone":  "Europe/Berlin",
+		"email":     "hijack@test.com",
+		"role":      "owner",
+	})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var updated domain.User
+	client.ParseJSON(resp, &updated)
+	if updated.FullName == nil || *updated.FullName != "Jane Doe" {
+		t.Errorf("Expected full name Jane Doe, got %v", updated.FullName)
+	}
+	if updated.Phone == nil || *updated.Phone != "+14155550123" {
+		t.Errorf("Expected phone +14155550123, got %v", updated.Phone)
+	}
+	if updated.Timezone != "Europe/Berlin" {
+		t.Errorf("Expected timezone Europe/Berlin, got %s", updated.Timezone)
+	}
+	if updated.Email != user.User.Email {
+		t.Errorf("Expected email to stay %s, got %s", user.User.Email, updated.Email)
+	}
+
+	role, err := testServer.UserService.ListOrganizationUsers(ctx, user.Organization.ID, &dto.ListUsersRequest{})
+	if err != nil || len(role.Users) != 1 || role.Users[0].Role != domain.RoleOwner {
+		t.Error("Expected the organization role to be unchanged")
+	}
+
+	// An empty phone clears it
+	resp = client.Patch("/api/v1/auth/me", map[string]interface{}{"phone": ""})
+	client.ExpectStatus(resp, http.StatusOK)
+	client.ParseJSON(resp, &updated)
+	if updated.Phone != nil {
+		t.Errorf("Expected phone to be cleared, got %s", *updated.Phone)
+	}
+}
+
+func TestUsers_UpdateProfile_InvalidFields(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	invalid := []map[string]interface{}{
+		{"timezone": "Mars/Olympus_Mons"},
+		{"timezone": ""},
+		{"phone": "555-0123"},
+		{"phone": "+0123456"},
+		{"phone": "+1234567890123456"},
+	}
+	for _, body := range invalid {
+		resp := client.Patch("/api/v1/auth/me", body)
+		client.ExpectStatus(resp, http.StatusBadRequest)
+	}
+
+	resp := client.Get("/api/v1/auth/me")
+	client.ExpectStatus(resp, http.StatusOK)
+	var current domain.User
+	client.ParseJSON(resp, &current)
+	if current.Timezone != user.User.Timezone || current.Phone != nil {
+		t.Error("Expected rejected updates to leave the profile unchanged")
+	}
+}
+
+func TestUsers_UpdateProfile_Unauthorized(t *testing.T) {
+	cleanDatabase(t)
+	client := newTestClient(t)
+
+	resp := client.Patch("/api/v1/auth/me", map[string]interface{}{"timezone": "UTC"})
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+}
+
+// ============================================================================
+// POST /api/v1/users/:id/deactivate
+// POST /api/v1/users/:id/reactivate
+// ============================================================================
+
+func loginAs(t *testing.T, email string, expected int) {
+	t.Helper()
+	loginWith(t, email, "TestPassword123!", expected)
+}
+
+func loginWith(t *testing.T, email, password string, expected int) {
+	t.Helper()
+	client := newTestClient(t)
+
+	resp := client.Post("/api/v1/auth/login", map[string]interface{}{
+		"email":    email,
+		"password": password,
+	})
+	client.ExpectStatus(resp, expected)
+}
+
+// loginToOrganization signs in to the given organization and returns the
+// tokens when it succeeds
+func loginToOrganization(t *testing.T, email string, orgID uuid.UUID, expected int) *dto.AuthResponse {
+	t.Helper()
+	client := newTestClient(t)
+
+	resp := client.Post("/api/v1/auth/login", map[string]string{
+		"email":           email,
+		"password":        "TestPassword123!",
+		"organization_id": orgID.String(),
+	})
+	client.ExpectStatus(resp, expected)
+
+	var result dto.AuthResponse
+	if expected == http.StatusOK {
+		client.ParseJSON(resp, &result)
+	}
+	return &result
+}
+
+func TestUsers_Deactivate_RemovesMembershipsKeepsHistory(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	admin, _ := testFixtures.CreateUniqueUser(ctx)
+	leaver, _ := testFixtures.CreateUniqueUser(ctx)
+	remaining, _ := testFixtures.CreateUniqueUser(ctx)
+	orgID := admin.Organization.ID
+	joinOrganization(t, ctx, orgID, leaver.User.ID)
+	joinOrganization(t, ctx, orgID, remaining.User.ID)
+
+	team, _ := testFixtures.CreateTeam(ctx, orgID, "Platform")
+	if err := testServer.TeamService.AddMember(ctx, team.ID, &dto.AddTeamMemberRequest{UserID: &leaver.User.ID}); err != nil {
+		t.Fatalf("Failed to add team member: %v", err)
+	}
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, orgID, "Primary")
+	rotation, err := testServer.ScheduleService.CreateRotation(ctx, schedule.ID, &dto.CreateRotationRequest{
+		Name:           "Weekly",
+		RotationType:   "weekly",
+		RotationLength: 1,
+		StartDate:      "2024-01-01",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rotation: %v", err)
+	}
+	for i, userID := range []uuid.UUID{leaver.User.ID, remaining.User.ID} {
+		if _, err := testServer.ScheduleService.AddParticipant(ctx, rotation.ID, &dto.AddParticipantRequest{UserID: userID, Position: i}); err != nil {
+			t.Fatalf("Failed to add participant: %v", err)
+		}
+	}
+
+	alert, _ := testFixtures.CreateAlert(ctx, orgID, "Disk full")
+	if err := testServer.AlertService.AssignAlert(ctx, alert.ID, orgID, admin.User.ID, &dto.AssignAlertRequest{UserID: &leaver.User.ID}); err != nil {
+		t.Fatalf("Failed to assign alert: %v", err)
+	}
+
+	incident, _ := testFixtures.CreateIncident(ctx, orgID, admin.User.ID, "Outage")
+	if _, err := testServer.IncidentService.AddNote(ctx, incident.ID, orgID, leaver.User.ID, &dto.AddNoteRequest{Note: "Restarted the database"}); err != nil {
+		t.Fatalf("Failed to add incident note: %v", err)
+	}
+
+	client.SetAuthToken(admin.AccessToken)
+	resp := client.Post(fmt.Sprintf("/api/v1/users/%s/deactivate", leaver.User.ID), nil)
+	client.ExpectStatus(resp, http.StatusOK)
+
+	var result domain.UserDeactivation
+	client.ParseJSON(resp, &result)
+	if result.TeamsLeft != 1 || result.RotationsLeft != 1 {
+		t.Errorf("Expected 1 team and 1 rotation left, got %d and %d", result.TeamsLeft, result.RotationsLeft)
+	}
+	if len(result.UnassignedAlertIDs) != 1 || result.UnassignedAlertIDs[0] != alert.ID {
+		t.Errorf("Expected alert %s to be unassigned, got %v", alert.ID, result.UnassignedAlertIDs)
+	}
+
+	// Signing in to the organization is blocked, but not to their own
+	loginToOrganization(t, leaver.User.Email, orgID, http.StatusForbidden)
+	loginToOrganization(t, leaver.User.Email, leaver.Organization.ID, http.StatusOK)
+
+	// Rotation participation is removed and the rest renumbered
+	participants, _ := testServer.ScheduleService.ListParticipants(ctx, rotation.ID)
+	if len(participants) != 1 || participants[0].UserID != remaining.User.ID || participants[0].Position != 0 {
+		t.Errorf("Expected only the remaining user at position 0, got %d participants", len(participants))
+	}
+
+	members, _ := testServer.TeamService.ListMembers(ctx, team.ID)
+	if len(members) != 0 {
+		t.Errorf("Expected the team to have no members, got %d", len(members))
+	}
+
+	// The open alert is unassigned and flagged
+	updated, _ := testServer.AlertService.GetAlert(ctx, alert.ID, orgID)
+	if updated.AssignedToUserID != nil {
+		t.Error("Expected the alert to be unassigned")
+	}
+	notes, _ := testServer.AlertService.ListNotes(ctx, alert.ID, orgID)
+	if len(notes) != 1 {
+		t.Errorf("Expected a note flagging the unassigned alert, got %d notes", len(notes))
+	}
+
+	// Authored timeline events are kept
+	timeline, err := testServer.IncidentService.GetTimeline(ctx, incident.ID, orgID, nil)
+	if err != nil {
+		t.Fatalf("Failed to get timeline: %v", err)
+	}
+	authored := false
+	for _, event := range timeline {
+		if event.UserID != nil && *event.UserID == leaver.User.ID {
+			authored = true
+		}
+	}
+	if !authored {
+		t.Error("Expected the deactivated user's timeline note to be kept")
+	}
+}
+
+func TestUsers_Reactivate_RestoresLoginNotMemberships(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	admin, _ := testFixtures.CreateUniqueUser(ctx)
+	leaver, _ := testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, admin.Organization.ID, leaver.User.ID)
+
+	team, _ := testFixtures.CreateTeam(ctx, admin.Organization.ID, "Platform")
+	testServer.TeamService.AddMember(ctx, team.ID, &dto.AddTeamMemberRequest{UserID: &leaver.User.ID})
+
+	client.SetAuthToken(admin.AccessToken)
+	resp := client.Post(fmt.Sprintf("/api/v1/users/%s/deactivate", leaver.User.ID), nil)
+	client.ExpectStatus(resp, http.StatusOK)
+
+	resp = client.Post(fmt.Sprintf("/api/v1/users/%s/reactivate", leaver.User.ID), nil)
+	client.ExpectStatus(resp, http.StatusOK)
+
+	loginToOrganization(t, leaver.User.Email, admin.Organization.ID, http.StatusOK)
+
+	members, _ := testServer.TeamService.ListMembers(ctx, team.ID)
+	if len(members) != 0 {
+		t.Errorf("Expected team membership not to be restored, got %d members", len(members))
+	}
+}
+
+func TestUsers_Deactivate_KeepsOtherOrganizations(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	admin, _ := testFixtures.CreateUniqueUser(ctx)
+	consultant, _ := testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, admin.Organization.ID, consultant.User.ID)
+	inCustomer := loginToOrganization(t, consultant.User.Email, admin.Organization.ID, http.StatusOK)
+
+	client.SetAuthToken(admin.AccessToken)
+	resp := client.Post(fmt.Sprintf("/api/v1/users/%s/deactivate", consultant.User.ID), nil)
+	client.ExpectStatus(resp, http.StatusOK)
+
+	// Listed as inactive in the organization that deactivated them
+	users := listUsers(t, client, url.Values{"is_active": {"false"}})
+	if users.Total != 1 || users.Users[0].ID != consultant.User.ID {
+		t.Errorf("Expected the consultant to be listed as inactive, got %d users", users.Total)
+	}
+
+	// Their session in that organization can't be refreshed or switched to
+	resp = client.Post("/api/v1/auth/refresh", map[string]string{"refresh_token": inCustomer.RefreshToken})
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+
+	client.SetAuthToken(consultant.AccessToken)
+	resp = client.Post("/api/v1/auth/switch-organization", map[string]string{
+		"organization_id": admin.Organization.ID.String(),
+	})
+	client.ExpectStatus(resp, http.StatusForbidden)
+
+	// Their own organization still works
+	orgs := listOrganizations(t, client)
+	if len(orgs) != 1 || orgs[0].ID != consultant.Organization.ID {
+		t.Errorf("Expected only their own organization to be listed, got %d", len(orgs))
+	}
+	resp = client.Post("/api/v1/auth/refresh", map[string]string{"refresh_token": consultant.RefreshToken})
+	client.ExpectStatus(resp, http.StatusOK)
+}
+
+func TestUsers_Deactivate_Rejected(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	outsider, _ := testFixtures.CreateUniqueUser(ctx)
+
+	client.SetAuthToken(owner.AccessToken)
+
+	// Yourself
+	resp := client.Post(fmt.Sprintf("/api/v1/users/%s/deactivate", owner.User.ID), nil)
+	client.ExpectStatus(resp, http.StatusBadRequest)
+
+	// Not in the organization
+	resp = client.Post(fmt.Sprintf("/api/v1/users/%s/deactivate", outsider.User.ID), nil)
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	// The organization owner
+	admin, _ := testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, owner.Organization.ID, admin.User.ID)
+	if _, err := testDB.ExecContext(ctx,
+		`UPDATE organization_users SET role = 'admin' WHERE organization_id = $1 AND user_id = $2`,
+		owner.Organization.ID, admin.User.ID,
+	); err != nil {
+		t.Fatalf("Failed to promote user: %v", err)
+	}
+	if _, err := testServer.UserService.DeactivateUser(ctx, owner.Organization.ID, owner.User.ID, admin.User.ID); err != domain.ErrCannotDeactivateOwner {
+		t.Errorf("Expected %v, got %v", domain.ErrCannotDeactivateOwner, err)
+	}
+}
+
+func TestUsers_Deactivate_RequiresAdmin(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, user.Organization.ID, other.User.ID)
+
+	// Demote the user and log in again so the token carries the member role
+	if _, err := testDB.ExecContext(ctx,
+		`UPDATE organization_users SET role = 'member' WHERE organization_id = $1 AND user_id = $2`,
+		user.Organization.ID, user.User.ID,
+	); err != nil {
+		t.Fatalf("Failed to demote user: %v", err)
+	}
+	resp := client.Post("/api/v1/auth/login", map[string]interface{}{
+		"email":    user.User.Email,
+		"password": "TestPassword123!",
+	})
+	client.ExpectStatus(resp, http.StatusOK)
+	var auth dto.AuthResponse
+	client.ParseJSON(resp, &auth)
+
+	client.SetAuthToken(auth.AccessToken)
+	resp = client.Post(fmt.Sprintf("/api/v1/users/%s/deactivate", other.User.ID), nil)
+	client.ExpectStatus(resp, http.StatusForbidden)
+}
+
+// ============================================================================
+// POST /api/v1/users/:id/unlock
+// ============================================================================
+
+func TestUsers_Unlock_LiftsLoginLockout(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	admin, _ := testFixtures.CreateUniqueUser(ctx)
+	member, _ := testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, admin.Organization.ID, member.User.ID)
+
+	failLogins(t, member.User.Email, 5)
+	loginAs(t, member.User.Email, http.StatusTooManyRequests)
+
+	// Members can't unlock each other
+	client.SetAuthToken(member.AccessToken)
+	resp := client.Post(fmt.Sprintf("/api/v1/users/%s/unlock", admin.User.ID), nil)
+	client.ExpectStatus(resp, http.StatusForbidden)
+
+	client.SetAuthToken(admin.AccessToken)
+	resp = client.Post(fmt.Sprintf("/api/v1/users/%s/unlock", member.User.ID), nil)
+	client.ExpectStatus(resp, http.StatusOK)
+
+	loginAs(t, member.User.Email, http.StatusOK)
+}
+
+func TestUsers_Unlock_OtherOrganization(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	admin, _ := testFixtures.CreateUniqueUser(ctx)
+	outsider, _ := testFixtures.CreateUniqueUser(ctx)
+
+	client.SetAuthToken(admin.AccessToken)
+	resp := client.Post(fmt.Sprintf("/api/v1/users/%s/unlock", outsider.User.ID), nil)
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+// ============================================================================
+// /api/v1/users/:id/sessions
+// ============================================================================
+
+func TestUsers_Sessions_AdminLogsUserOutOfOrganization(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	admin, _ := testFixtures.CreateUniqueUser(ctx)
+	member, _ := testFixtures.CreateUniqueUser(ctx)
+	outsider, _ := testFixtures.CreateUniqueUser(ctx)
+	joinOrganization(t, ctx, admin.Organization.ID, member.User.ID)
+	laptop := loginToOrganization(t, member.User.Email, admin.Organization.ID, http.StatusOK)
+
+	client.SetAuthToken(admin.AccessToken)
+	resp := client.Get(fmt.Sprintf("/api/v1/users/%s/sessions", member.User.ID))
+	client.ExpectStatus(resp, http.StatusOK)
+
+	// The member's session in their own organization isn't listed
+	var sessions []dto.SessionResponse
+	client.ParseJSON(resp, &sessions)
+	if len(sessions) != 1 {
+		t.Fatalf("Expected only the session in the organization, got %d sessions", len(sessions))
+	}
+
+	resp = client.Delete(fmt.Sprintf("/api/v1/users/%s/sessions", outsider.User.ID))
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	resp = client.Delete(fmt.Sprintf("/api/v1/users/%s/sessions", member.User.ID))
+	client.ExpectStatus(resp, http.StatusOK)
+
+	resp = client.Post("/api/v1/auth/refresh", map[string]string{"refresh_token": laptop.RefreshToken})
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+
+	// Their session in their own organization is left alone
+	resp = client.Post("/api/v1/auth/refresh", map[string]string{"refresh_token": member.RefreshToken})
+	client.ExpectStatus(resp, http.StatusOK)
+
+	// Users of other organizations can't see them
+	client.SetAuthToken(outsider.AccessToken)
+	resp = client.Get(fmt.Sprintf("/api/v1/users/%s/sessions", member.User.ID))
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
@@ -2,9 +2,25 @@ package integration
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/outbound/postgres"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/internal/core/service"
+	"github.com/nmn3m/pulsar/backend/tests/integration/testutils"
 )
 
 // ============================================================================
@@ -143,6 +159,41 @@ func TestWebhooks_GetEndpoint_Success(t *testing.T) {
 	}
 }
 
+func TestWebhooks_GetEndpoint_MasksSecret(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	endpoint, _ := testFixtures.CreateWebhookEndpoint(ctx, user.Organization.ID, "Test Webhook", "https://example.com/webhook")
+	masked := domain.MaskSecret(endpoint.Secret)
+
+	resp := client.Get(fmt.Sprintf("/api/v1/webhooks/endpoints/%s", endpoint.ID))
+	client.AssertStatus(resp, http.StatusOK)
+
+	var got domain.WebhookEndpoint
+	client.ParseJSON(resp, &got)
+
+	if got.Secret != masked {
+		t.Errorf("Expected masked secret %q, got %q", masked, got.Secret)
+	}
+
+	resp = client.Get("/api/v1/webhooks/endpoints")
+	client.AssertStatus(resp, http.StatusOK)
+
+	var list []domain.WebhookEndpoint
+	client.ParseJSON(resp, &list)
+
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 endpoint, got %d", len(list))
+	}
+	if list[0].Secret != masked {
+		t.Errorf("Expected masked secret %q in list, got %q", masked, list[0].Secret)
+	}
+}
+
 func TestWebhooks_GetEndpoint_NotFound(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
@@ -201,6 +252,66 @@ func TestWebhooks_UpdateEndpoint_NotFound(t *testing.T) {
 	client.ExpectStatus(resp, http.StatusNotFound)
 }
 
+// ============================================================================
+// POST /api/v1/webhooks/endpoints/:id/rotate-secret
+// ============================================================================
+
+func TestWebhooks_RotateEndpointSecret_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	endpoint, _ := testFixtures.CreateWebhookEndpoint(ctx, user.Organization.ID, "Test Webhook", "https://example.com/webhook")
+
+	resp := client.Post(fmt.Sprintf("/api/v1/webhooks/endpoints/%s/rotate-secret", endpoint.ID), nil)
+	client.AssertStatus(resp, http.StatusOK)
+
+	var rotated domain.WebhookEndpoint
+	client.ParseJSON(resp, &rotated)
+
+	if rotated.Secret == "" || rotated.Secret == endpoint.Secret {
+		t.Fatalf("Expected a new secret, got %q", rotated.Secret)
+	}
+
+	stored, err := testServer.WebhookService.GetEndpoint(ctx, endpoint.ID)
+	if err != nil {
+		t.Fatalf("Failed to get endpoint: %v", err)
+	}
+	if stored.Secret != rotated.Secret {
+		t.Errorf("Expected stored secret to be the rotated one")
+	}
+}
+
+func TestWebhooks_RotateEndpointSecret_RequiresAdmin(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	endpoint, _ := testFixtures.CreateWebhookEndpoint(ctx, user.Organization.ID, "Test Webhook", "https://example.com/webhook")
+
+	// Demote to member and log in again so the token carries the new role
+	if _, err := testDB.ExecContext(ctx,
+		`UPDATE organization_users SET role = 'member' WHERE user_id = $1`, user.User.ID); err != nil {
+		t.Fatalf("Failed to demote user: %v", err)
+	}
+	resp := client.Post("/api/v1/auth/login", map[string]string{
+		"email":    user.User.Email,
+		"password": "TestPassword123!",
+	})
+	client.AssertStatus(resp, http.StatusOK)
+	var login map[string]interface{}
+	client.ParseJSON(resp, &login)
+	token, _ := login["access_token"].(string)
+
+	client.SetAuthToken(token)
+	resp = client.Post(fmt.Sprintf("/api/v1/webhooks/endpoints/%s/rotate-secret", endpoint.ID), nil)
+	client.AssertStatus(resp, http.StatusForbidden)
+}
+
 // ============================================================================
 // DELETE /api/v1/webhooks/endpoints/:id
 // ============================================================================
@@ -236,10 +347,10 @@ func TestWebhooks_DeleteEndpoint_NotFound(t *testing.T) {
 }
 
 // ============================================================================
-// GET /api/v1/webhooks/deliveries
+// Event subscriptions
 // ============================================================================
 
-func TestWebhooks_ListDeliveries_Success(t *testing.T) {
+func TestWebhooks_CreateEndpoint_EventsAndFlags(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -247,34 +358,132 @@ func TestWebhooks_ListDeliveries_Success(t *testing.T) {
 	user, _ := testFixtures.CreateUniqueUser(ctx)
 	client.SetAuthToken(user.AccessToken)
 
-	resp := client.Get("/api/v1/webhooks/deliveries")
+	resp := client.Post("/api/v1/webhooks/endpoints", map[string]interface{}{
+		"name":          "Event Webhook",
+		"url":           "https://example.com/webhook",
+		"enabled":       true,
+		"alert_created": true,
+		"events":        []string{"incident.severity_changed", "alert.closed"},
+	})
+	client.AssertStatus(resp, http.StatusCreated)
+
+	var endpoint domain.WebhookEndpoint
+	client.ParseJSON(resp, &endpoint)
+
+	expected := []string{"alert.created", "alert.closed", "incident.severity_changed"}
+	if strings.Join(endpoint.Events, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected events %v, got %v", expected, endpoint.Events)
+	}
+	if !endpoint.AlertCreated || !endpoint.AlertClosed {
+		t.Errorf("Expected alert_created and alert_closed flags derived from events, got %+v", endpoint)
+	}
+	if endpoint.AlertUpdated {
+		t.Error("Expected alert_updated flag to stay off")
+	}
+
+	// Flags toggle single events without touching the rest
+	resp = client.Patch(fmt.Sprintf("/api/v1/webhooks/endpoints/%s", endpoint.ID), map[string]interface{}{
+		"alert_closed":  false,
+		"alert_updated": true,
+	})
 	client.AssertStatus(resp, http.StatusOK)
+	client.ParseJSON(resp, &endpoint)
 
-	var result map[string]interface{}
-	client.ParseJSON(resp, &result)
+	expected = []string{"alert.created", "alert.updated", "incident.severity_changed"}
+	if strings.Join(endpoint.Events, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected events %v after toggling flags, got %v", expected, endpoint.Events)
+	}
+	if endpoint.AlertClosed || !endpoint.AlertUpdated {
+		t.Errorf("Expected flags to follow the toggled events, got %+v", endpoint)
+	}
+}
 
-	// Handle nil or empty deliveries array
-	deliveriesRaw := result["deliveries"]
-	if deliveriesRaw != nil {
-		deliveries := deliveriesRaw.([]interface{})
-		// Just verify it's an array, can be empty initially
-		_ = deliveries
+func TestWebhooks_UpdateEndpoint_ReplacesEvents(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	endpoint, _ := testFixtures.CreateWebhookEndpoint(ctx, user.Organization.ID, "Event Webhook", "https://example.com/webhook")
+
+	resp := client.Patch(fmt.Sprintf("/api/v1/webhooks/endpoints/%s", endpoint.ID), map[string]interface{}{
+		"events": []string{"alert.snoozed"},
+	})
+	client.AssertStatus(resp, http.StatusOK)
+
+	var updated domain.WebhookEndpoint
+	client.ParseJSON(resp, &updated)
+
+	if len(updated.Events) != 1 || updated.Events[0] != "alert.snoozed" {
+		t.Errorf("Expected events [alert.snoozed], got %v", updated.Events)
+	}
+	if updated.AlertCreated || updated.AlertUpdated {
+		t.Error("Expected flags of unsubscribed events to be cleared")
 	}
 }
 
-func TestWebhooks_ListDeliveries_Unauthorized(t *testing.T) {
+func TestWebhooks_CreateEndpoint_UnknownEvent(t *testing.T) {
 	cleanDatabase(t)
+	ctx := context.Background()
 	client := newTestClient(t)
 
-	resp := client.Get("/api/v1/webhooks/deliveries")
-	client.ExpectStatus(resp, http.StatusUnauthorized)
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Post("/api/v1/webhooks/endpoints", map[string]interface{}{
+		"name":   "Event Webhook",
+		"url":    "https://example.com/webhook",
+		"events": []string{"alert.exploded"},
+	})
+	client.ExpectStatus(resp, http.StatusBadRequest)
 }
 
-// ============================================================================
-// POST /api/v1/webhooks/incoming
-// ============================================================================
+// createSubscribedEndpoint creates an enabled endpoint subscribed to events only
+func createSubscribedEndpoint(t *testing.T, orgID uuid.UUID, events ...string) *domain.WebhookEndpoint {
+	t.Helper()
+	endpoint, err := testServer.WebhookService.CreateEndpoint(context.Background(), orgID, &dto.CreateWebhookEndpointRequest{
+		Name:    "Subscribed Webhook",
+		URL:     "https://example.com/subscribed",
+		Enabled: true,
+		Events:  events,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create endpoint: %v", err)
+	}
+	return endpoint
+}
 
-func TestWebhooks_CreateIncomingToken_Success(t *testing.T) {
+// waitForDelivery waits for the asynchronous delivery of eventType to
+// endpointID and returns its payload
+func waitForDelivery(t *testing.T, endpointID uuid.UUID, eventType string) map[string]interface{} {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var payload []byte
+		err := testDB.QueryRowContext(context.Background(),
+			`SELECT payload FROM webhook_deliveries WHERE webhook_endpoint_id = $1 AND event_type = $2`,
+			endpointID, eventType,
+		).Scan(&payload)
+		if err == nil {
+			var data map[string]interface{}
+			if err := json.Unmarshal(payload, &data); err != nil {
+				t.Fatalf("Failed to decode delivery payload: %v", err)
+			}
+			return data
+		}
+		if err != sql.ErrNoRows {
+			t.Fatalf("Failed to query deliveries: %v", err)
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected a %s delivery for endpoint %s", eventType, endpointID)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestWebhooks_SubscribedEvent_CreatesDelivery(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -282,47 +491,96 @@ func TestWebhooks_CreateIncomingToken_Success(t *testing.T) {
 	user, _ := testFixtures.CreateUniqueUser(ctx)
 	client.SetAuthToken(user.AccessToken)
 
-	reqBody := map[string]interface{}{
-		"name":             "Prometheus Alerts",
-		"integration_type": "generic",
-		"description":      "Token for receiving Prometheus alerts",
+	subscribed := createSubscribedEndpoint(t, user.Organization.ID, domain.WebhookEventIncidentSeverityChanged)
+	other, _ := testFixtures.CreateWebhookEndpoint(ctx, user.Organization.ID, "Alert Webhook", "https://example.com/alerts")
+
+	incident := createIncidentWithSeverity(t, client, "Database latency", "medium")
+	resp := client.Patch(fmt.Sprintf("/api/v1/incidents/%s", incident.ID), map[string]interface{}{
+		"severity": "critical",
+	})
+	client.AssertStatus(resp, http.StatusOK)
+
+	payload := waitForDelivery(t, subscribed.ID, domain.WebhookEventIncidentSeverityChanged)
+	if payload["old_severity"] != "medium" || payload["new_severity"] != "critical" {
+		t.Errorf("Expected severity change medium -> critical, got %v", payload)
 	}
 
-	resp := client.Post("/api/v1/webhooks/incoming", reqBody)
-	client.AssertStatus(resp, http.StatusCreated)
+	var otherDeliveries int
+	err := testDB.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM webhook_deliveries WHERE webhook_endpoint_id = $1`, other.ID,
+	).Scan(&otherDeliveries)
+	if err != nil {
+		t.Fatalf("Failed to count deliveries: %v", err)
+	}
+	if otherDeliveries != 0 {
+		t.Errorf("Expected no deliveries for an unsubscribed endpoint, got %d", otherDeliveries)
+	}
+}
 
-	var result map[string]interface{}
-	client.ParseJSON(resp, &result)
+func TestWebhooks_AlertAssigned_CreatesDelivery(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
 
-	if result["id"] == nil {
-		t.Error("Expected id in response")
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	endpoint := createSubscribedEndpoint(t, user.Organization.ID, domain.WebhookEventAlertAssigned)
+	alert, _ := testFixtures.CreateUniqueAlert(ctx, user.Organization.ID)
+
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/assign", alert.ID), map[string]interface{}{
+		"user_id": user.User.ID,
+	})
+	client.AssertStatus(resp, http.StatusOK)
+
+	payload := waitForDelivery(t, endpoint.ID, domain.WebhookEventAlertAssigned)
+	if payload["alert_id"] != alert.ID.String() {
+		t.Errorf("Expected alert_id %s, got %v", alert.ID, payload["alert_id"])
 	}
-	if result["token"] == nil {
-		t.Error("Expected token in response")
+	if payload["assigned_by"] != user.User.ID.String() {
+		t.Errorf("Expected assigned_by %s, got %v", user.User.ID, payload["assigned_by"])
 	}
-	if result["name"] != "Prometheus Alerts" {
-		t.Errorf("Expected name 'Prometheus Alerts', got %v", result["name"])
+	if payload["assigned_to_user_id"] != user.User.ID.String() {
+		t.Errorf("Expected assigned_to_user_id %s, got %v", user.User.ID, payload["assigned_to_user_id"])
+	}
+	if payload["assigned_at"] == nil {
+		t.Error("Expected assigned_at to be set")
 	}
 }
 
-func TestWebhooks_CreateIncomingToken_Unauthorized(t *testing.T) {
+func TestWebhooks_AlertSnoozed_CreatesDelivery(t *testing.T) {
 	cleanDatabase(t)
+	ctx := context.Background()
 	client := newTestClient(t)
 
-	reqBody := map[string]interface{}{
-		"name":             "Test Token",
-		"integration_type": "generic",
-	}
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
 
-	resp := client.Post("/api/v1/webhooks/incoming", reqBody)
-	client.ExpectStatus(resp, http.StatusUnauthorized)
-}
+	endpoint := createSubscribedEndpoint(t, user.Organization.ID, domain.WebhookEventAlertSnoozed)
+	alert, _ := testFixtures.CreateUniqueAlert(ctx, user.Organization.ID)
 
-// ============================================================================
-// GET /api/v1/webhooks/incoming
-// ============================================================================
+	resp := client.Post(fmt.Sprintf("/api/v1/alerts/%s/snooze", alert.ID), map[string]interface{}{
+		"until":  time.Now().Add(time.Hour).Format(time.RFC3339),
+		"reason": "Deploy in progress",
+	})
+	client.AssertStatus(resp, http.StatusOK)
 
-func TestWebhooks_ListIncomingTokens_Success(t *testing.T) {
+	payload := waitForDelivery(t, endpoint.ID, domain.WebhookEventAlertSnoozed)
+	if payload["alert_id"] != alert.ID.String() {
+		t.Errorf("Expected alert_id %s, got %v", alert.ID, payload["alert_id"])
+	}
+	if payload["snoozed_by"] != user.User.ID.String() {
+		t.Errorf("Expected snoozed_by %s, got %v", user.User.ID, payload["snoozed_by"])
+	}
+	if payload["snooze_reason"] != "Deploy in progress" {
+		t.Errorf("Expected snooze_reason 'Deploy in progress', got %v", payload["snooze_reason"])
+	}
+	if payload["snoozed_until"] == nil || payload["status"] != "snoozed" {
+		t.Errorf("Expected a snoozed alert with snoozed_until, got %v", payload)
+	}
+}
+
+func TestWebhooks_AlertUpdated_IncludesSnapshotAndChanges(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -330,23 +588,52 @@ func TestWebhooks_ListIncomingTokens_Success(t *testing.T) {
 	user, _ := testFixtures.CreateUniqueUser(ctx)
 	client.SetAuthToken(user.AccessToken)
 
-	// Create tokens
-	for i := 0; i < 3; i++ {
-		testFixtures.CreateIncomingWebhookToken(ctx, user.Organization.ID, fmt.Sprintf("Token %d", i))
+	endpoint := createSubscribedEndpoint(t, user.Organization.ID, domain.WebhookEventAlertUpdated)
+	alert, _ := testFixtures.CreateUniqueAlert(ctx, user.Organization.ID)
+	oldPriority := string(alert.Priority)
+	newPriority := "P1"
+	if oldPriority == newPriority {
+		newPriority = "P2"
 	}
 
-	resp := client.Get("/api/v1/webhooks/incoming")
+	resp := client.Patch(fmt.Sprintf("/api/v1/alerts/%s", alert.ID), map[string]interface{}{
+		"priority": newPriority,
+		"message":  alert.Message,
+	})
 	client.AssertStatus(resp, http.StatusOK)
 
-	var result []interface{}
-	client.ParseJSON(resp, &result)
+	payload := waitForDelivery(t, endpoint.ID, domain.WebhookEventAlertUpdated)
 
-	if len(result) != 3 {
-		t.Errorf("Expected 3 tokens, got %d", len(result))
+	snapshot, ok := payload["alert"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected the full alert in the payload, got %v", payload["alert"])
+	}
+	if snapshot["id"] != alert.ID.String() || snapshot["priority"] != newPriority || snapshot["message"] != alert.Message {
+		t.Errorf("Expected the current alert state, got %v", snapshot)
+	}
+
+	changes, ok := payload["changes"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected changes in the payload, got %v", payload["changes"])
+	}
+	priority, ok := changes["priority"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected a priority change, got %v", changes)
+	}
+	if priority["old"] != oldPriority || priority["new"] != newPriority {
+		t.Errorf("Expected priority %s -> %s, got %v", oldPriority, newPriority, priority)
+	}
+	for _, field := range []string{"message", "status", "tags", "updated_at"} {
+		if _, found := changes[field]; found {
+			t.Errorf("Expected no change for unchanged field %s, got %v", field, changes[field])
+		}
+	}
+	if len(changes) != 1 {
+		t.Errorf("Expected only the priority to change, got %v", changes)
 	}
 }
 
-func TestWebhooks_ListIncomingTokens_Empty(t *testing.T) {
+func TestWebhooks_IncidentUpdated_IncludesSnapshotAndChanges(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -354,22 +641,49 @@ func TestWebhooks_ListIncomingTokens_Empty(t *testing.T) {
 	user, _ := testFixtures.CreateUniqueUser(ctx)
 	client.SetAuthToken(user.AccessToken)
 
-	resp := client.Get("/api/v1/webhooks/incoming")
+	endpoint := createSubscribedEndpoint(t, user.Organization.ID, domain.WebhookEventIncidentUpdated)
+	incident := createIncidentWithSeverity(t, client, "Checkout errors", "high")
+
+	resp := client.Patch(fmt.Sprintf("/api/v1/incidents/%s", incident.ID), map[string]interface{}{
+		"title":    "Checkout errors in EU",
+		"severity": "high",
+	})
 	client.AssertStatus(resp, http.StatusOK)
 
-	var result []interface{}
-	client.ParseJSON(resp, &result)
+	payload := waitForDelivery(t, endpoint.ID, domain.WebhookEventIncidentUpdated)
 
-	if len(result) != 0 {
-		t.Errorf("Expected 0 tokens, got %d", len(result))
+	snapshot, ok := payload["incident"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected the full incident in the payload, got %v", payload["incident"])
+	}
+	if snapshot["id"] != incident.ID.String() || snapshot["title"] != "Checkout errors in EU" || snapshot["severity"] != "high" {
+		t.Errorf("Expected the current incident state, got %v", snapshot)
+	}
+
+	changes, ok := payload["changes"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected changes in the payload, got %v", payload["changes"])
+	}
+	title, ok := changes["title"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected a title change, got %v", changes)
+	}
+	if title["old"] != "Checkout errors" || title["new"] != "Checkout errors in EU" {
+		t.Errorf("Expected title change, got %v", title)
+	}
+	if _, found := changes["severity"]; found {
+		t.Errorf("Expected no change for the unchanged severity, got %v", changes["severity"])
+	}
+	if len(changes) != 1 {
+		t.Errorf("Expected only the title to change, got %v", changes)
 	}
 }
 
 // ============================================================================
-// DELETE /api/v1/webhooks/incoming/:id
+// GET /api/v1/webhooks/deliveries
 // ============================================================================
 
-func TestWebhooks_DeleteIncomingToken_Success(t *testing.T) {
+func TestWebhooks_ListDeliveries_Success(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -377,13 +691,22 @@ func TestWebhooks_DeleteIncomingToken_Success(t *testing.T) {
 	user, _ := testFixtures.CreateUniqueUser(ctx)
 	client.SetAuthToken(user.AccessToken)
 
-	token, _ := testFixtures.CreateIncomingWebhookToken(ctx, user.Organization.ID, "Test Token")
-
-	resp := client.Delete(fmt.Sprintf("/api/v1/webhooks/incoming/%s", token.ID))
+	resp := client.Get("/api/v1/webhooks/deliveries")
 	client.AssertStatus(resp, http.StatusOK)
+
+	var result map[string]interface{}
+	client.ParseJSON(resp, &result)
+
+	// Handle nil or empty deliveries array
+	deliveriesRaw := result["deliveries"]
+	if deliveriesRaw != nil {
+		deliveries := deliveriesRaw.([]interface{})
+		// Just verify it's an array, can be empty initially
+		_ = deliveries
+	}
 }
 
-func TestWebhooks_DeleteIncomingToken_NotFound(t *testing.T) {
+func TestWebhooks_ListDeliveries_Pagination(t *testing.T) {
 	cleanDatabase(t)
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -391,41 +714,459 @@ func TestWebhooks_DeleteIncomingToken_NotFound(t *testing.T) {
 	user, _ := testFixtures.CreateUniqueUser(ctx)
 	client.SetAuthToken(user.AccessToken)
 
-	resp := client.Delete("/api/v1/webhooks/incoming/00000000-0000-0000-0000-000000000000")
-	client.ExpectStatus(resp, http.StatusNotFound)
+	resp := client.Get("/api/v1/webhooks/deliveries?limit=1000000")
+	client.ExpectStatus(resp, http.StatusOK)
+	var result struct {
+		Limit int `json:"limit"`
+	}
+	client.ParseJSON(resp, &result)
+	if result.Limit != domain.MaxListLimit {
+		t.Errorf("Expected the limit to be clamped to %d, got %d", domain.MaxListLimit, result.Limit)
+	}
+
+	resp = client.Get("/api/v1/webhooks/deliveries?offset=-1")
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+func TestWebhooks_ListDeliveries_Unauthorized(t *testing.T) {
+	cleanDatabase(t)
+	client := newTestClient(t)
+
+	resp := client.Get("/api/v1/webhooks/deliveries")
+	client.ExpectStatus(resp, http.StatusUnauthorized)
 }
 
 // ============================================================================
-// POST /api/v1/webhook/:token (Public incoming webhook)
+// Webhook delivery worker
 // ============================================================================
 
-func TestWebhooks_ReceiveWebhook_Success(t *testing.T) {
-	cleanDatabase(t)
-	ctx := context.Background()
-	client := newTestClient(t)
+// recordingTransport answers webhook deliveries in-process, recording the
+// order each endpoint received them in and how many were in flight at once
+type recordingTransport struct {
+	delay  time.Duration
+	failFn func(path string, n int) bool
+	// lagFn delays a delivery before it is received
+	lagFn func(n int) time.Duration
+
+	mu                sync.Mutex
+	received          map[string][]int
+	inFlight          map[string]int
+	total             int
+	maxTotal          int
+	maxForAnyEndpoint int
+}
 
-	user, _ := testFixtures.CreateUniqueUser(ctx)
-	client.SetAuthToken(user.AccessToken)
+func newRecordingTransport(delay time.Duration) *recordingTransport {
+	return &recordingTransport{
+		delay:    delay,
+		received: make(map[string][]int),
+		inFlight: make(map[string]int),
+	}
+}
 
-	incomingToken, _ := testFixtures.CreateIncomingWebhookToken(ctx, user.Organization.ID, "Test Token")
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var payload struct {
+		Data struct {
+			N int `json:"n"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+		return nil, err
+	}
+	path := req.URL.Path
 
-	// Clear auth token - this is a public endpoint
-	client.ClearAuthToken()
+	if rt.lagFn != nil {
+		time.Sleep(rt.lagFn(payload.Data.N))
+	}
 
-	// Send generic webhook payload
-	reqBody := map[string]interface{}{
-		"source":   "test-system",
-		"message":  "Test alert from webhook",
-		"priority": "P3",
+	rt.mu.Lock()
+	rt.received[path] = append(rt.received[path], payload.Data.N)
+	rt.inFlight[path]++
+	rt.total++
+	rt.maxForAnyEndpoint = max(rt.maxForAnyEndpoint, rt.inFlight[path])
+	rt.maxTotal = max(rt.maxTotal, rt.total)
+	rt.mu.Unlock()
+
+	time.Sleep(rt.delay)
+
+	rt.mu.Lock()
+	rt.inFlight[path]--
+	rt.total--
+	rt.mu.Unlock()
+
+	status := http.StatusOK
+	if rt.failFn != nil && rt.failFn(path, payload.Data.N) {
+		status = http.StatusInternalServerError
 	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("ok")),
+		Request:    req,
+	}, nil
+}
 
-	resp := client.Post(fmt.Sprintf("/api/v1/webhook/%s", incomingToken.Token), reqBody)
-	client.AssertStatus(resp, http.StatusCreated) // API returns 201 for created alerts
+// newDeliveryWorker returns a webhook service that sends through transport
+func newDeliveryWorker(transport http.RoundTripper, concurrency int) *service.WebhookService {
+	svc := service.NewWebhookService(postgres.NewWebhookRepository(&postgres.DB{DB: testDB.DB}), zap.NewNop())
+	svc.SetTransport(transport)
+	svc.SetDeliveryConcurrency(concurrency)
+	return svc
 }
 
-func TestWebhooks_ReceiveWebhook_InvalidToken(t *testing.T) {
+// insertPendingDeliveries stores count pending deliveries to the endpoint,
+// numbered in the order they were created but inserted newest first
+func insertPendingDeliveries(t *testing.T, endpoint *domain.WebhookEndpoint, count int) []uuid.UUID {
+	t.Helper()
+	base := time.Now().Add(-time.Minute)
+	ids := make([]uuid.UUID, count)
+	for n := count - 1; n >= 0; n-- {
+		ids[n] = uuid.New()
+		createdAt := base.Add(time.Duration(n) * time.Second)
+		_, err := testDB.ExecContext(context.Background(), `
+			INSERT INTO webhook_deliveries (id, webhook_endpoint_id, organization_id, event_type, payload, status, attempts, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, 0, $7, $7)`,
+			ids[n], endpoint.ID, endpoint.OrganizationID, domain.WebhookEventAlertUpdated,
+			fmt.Sprintf(`{"n": %d}`, n), domain.WebhookDeliveryPending, createdAt,
+		)
+		if err != nil {
+			t.Fatalf("Failed to insert delivery: %v", err)
+		}
+	}
+	return ids
+}
+
+func TestWebhooks_DeliveryWorker_OrdersPerEndpointAndParallelizesAcrossEndpoints(t *testing.T) {
 	cleanDatabase(t)
-	client := newTestClient(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	first, err := testFixtures.CreateWebhookEndpoint(ctx, user.Organization.ID, "First", "http://203.0.113.10/first")
+	if err != nil {
+		t.Fatalf("Failed to create endpoint: %v", err)
+	}
+	second, err := testFixtures.CreateWebhookEndpoint(ctx, user.Organization.ID, "Second", "http://203.0.113.10/second")
+	if err != nil {
+		t.Fatalf("Failed to create endpoint: %v", err)
+	}
+	insertPendingDeliveries(t, first, 4)
+	insertPendingDeliveries(t, second, 4)
+
+	transport := newRecordingTransport(100 * time.Millisecond)
+	if err := newDeliveryWorker(transport, 4).ProcessPendingDeliveries(ctx, 100); err != nil {
+		t.Fatalf("Failed to process deliveries: %v", err)
+	}
+
+	for _, path := range []string{"/first", "/second"} {
+		got := transport.received[path]
+		if fmt.Sprint(got) != "[0 1 2 3]" {
+			t.Errorf("Expected %s to receive deliveries in created order, got %v", path, got)
+		}
+	}
+	if transport.maxForAnyEndpoint != 1 {
+		t.Errorf("Expected one delivery in flight per endpoint, got %d", transport.maxForAnyEndpoint)
+	}
+	if transport.maxTotal < 2 {
+		t.Errorf("Expected the endpoints to be delivered to in parallel, got %d in flight at most", transport.maxTotal)
+	}
+
+	var delivered int
+	if err := testDB.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM webhook_deliveries WHERE status = $1`, domain.WebhookDeliverySuccess,
+	).Scan(&delivered); err != nil {
+		t.Fatalf("Failed to count deliveries: %v", err)
+	}
+	if delivered != 8 {
+		t.Errorf("Expected 8 successful deliveries, got %d", delivered)
+	}
+}
+
+func TestWebhooks_DeliveryWorker_ConcurrencyLimit(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	for i := 0; i < 3; i++ {
+		endpoint, err := testFixtures.CreateWebhookEndpoint(ctx, user.Organization.ID,
+			fmt.Sprintf("Endpoint %d", i), fmt.Sprintf("http://203.0.113.10/endpoint-%d", i))
+		if err != nil {
+			t.Fatalf("Failed to create endpoint: %v", err)
+		}
+		insertPendingDeliveries(t, endpoint, 2)
+	}
+
+	transport := newRecordingTransport(50 * time.Millisecond)
+	if err := newDeliveryWorker(transport, 1).ProcessPendingDeliveries(ctx, 100); err != nil {
+		t.Fatalf("Failed to process deliveries: %v", err)
+	}
+
+	if transport.maxTotal != 1 {
+		t.Errorf("Expected at most one delivery in flight with concurrency 1, got %d", transport.maxTotal)
+	}
+	if len(transport.received) != 3 {
+		t.Errorf("Expected all 3 endpoints delivered to, got %v", transport.received)
+	}
+}
+
+func TestWebhooks_DeliveryWorker_RetryHoldsBackLaterDeliveries(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	endpoint, err := testFixtures.CreateWebhookEndpoint(ctx, user.Organization.ID, "Flaky", "http://203.0.113.10/flaky")
+	if err != nil {
+		t.Fatalf("Failed to create endpoint: %v", err)
+	}
+	ids := insertPendingDeliveries(t, endpoint, 3)
+
+	transport := newRecordingTransport(0)
+	transport.failFn = func(_ string, n int) bool { return n == 0 }
+	if err := newDeliveryWorker(transport, 4).ProcessPendingDeliveries(ctx, 100); err != nil {
+		t.Fatalf("Failed to process deliveries: %v", err)
+	}
+
+	if got := transport.received["/flaky"]; fmt.Sprint(got) != "[0]" {
+		t.Errorf("Expected only the failed delivery to be sent, got %v", got)
+	}
+
+	retryAt := make(map[uuid.UUID]time.Time)
+	for n, id := range ids {
+		var status string
+		var attempts int
+		var nextRetryAt sql.NullTime
+		if err := testDB.QueryRowContext(ctx,
+			`SELECT status, attempts, next_retry_at FROM webhook_deliveries WHERE id = $1`, id,
+		).Scan(&status, &attempts, &nextRetryAt); err != nil {
+			t.Fatalf("Failed to get delivery: %v", err)
+		}
+		if status != string(domain.WebhookDeliveryPending) || !nextRetryAt.Valid {
+			t.Errorf("Expected delivery %d pending with a retry time, got %s (%v)", n, status, nextRetryAt)
+		}
+		wantAttempts := 0
+		if n == 0 {
+			wantAttempts = 1
+		}
+		if attempts != wantAttempts {
+			t.Errorf("Expected delivery %d to have %d attempts, got %d", n, wantAttempts, attempts)
+		}
+		retryAt[id] = nextRetryAt.Time
+	}
+	for _, id := range ids[1:] {
+		if !retryAt[id].Equal(retryAt[ids[0]]) {
+			t.Errorf("Expected held deliveries to wait for the retry at %v, got %v", retryAt[ids[0]], retryAt[id])
+		}
+	}
+}
+
+func TestWebhooks_Enqueue_DeliversInOrderPerEndpoint(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	endpoint, err := testFixtures.CreateWebhookEndpoint(ctx, user.Organization.ID, "Ordered", "http://203.0.113.10/ordered")
+	if err != nil {
+		t.Fatalf("Failed to create endpoint: %v", err)
+	}
+
+	// The first event is slow to arrive, so sending both at once would let
+	// the second overtake it
+	transport := newRecordingTransport(0)
+	transport.lagFn = func(n int) time.Duration {
+		if n == 0 {
+			return 200 * time.Millisecond
+		}
+		return 0
+	}
+	svc := newDeliveryWorker(transport, 4)
+
+	for n := 0; n < 2; n++ {
+		data := map[string]interface{}{"n": n}
+		if err := svc.EnqueueWebhooks(ctx, user.Organization.ID, domain.WebhookEventAlertUpdated, data); err != nil {
+			t.Fatalf("Failed to enqueue webhook: %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		transport.mu.Lock()
+		got := fmt.Sprint(transport.received["/ordered"])
+		transport.mu.Unlock()
+		if got == "[0 1]" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the endpoint to receive the events in enqueue order, got %s", got)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	var delivered int
+	if err := testDB.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM webhook_deliveries WHERE webhook_endpoint_id = $1`, endpoint.ID,
+	).Scan(&delivered); err != nil {
+		t.Fatalf("Failed to count deliveries: %v", err)
+	}
+	if delivered != 2 {
+		t.Errorf("Expected 2 deliveries to the endpoint, got %d", delivered)
+	}
+}
+
+// ============================================================================
+// POST /api/v1/webhooks/incoming
+// ============================================================================
+
+func TestWebhooks_CreateIncomingToken_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	reqBody := map[string]interface{}{
+		"name":             "Prometheus Alerts",
+		"integration_type": "generic",
+		"description":      "Token for receiving Prometheus alerts",
+	}
+
+	resp := client.Post("/api/v1/webhooks/incoming", reqBody)
+	client.AssertStatus(resp, http.StatusCreated)
+
+	var result map[string]interface{}
+	client.ParseJSON(resp, &result)
+
+	if result["id"] == nil {
+		t.Error("Expected id in response")
+	}
+	if result["token"] == nil {
+		t.Error("Expected token in response")
+	}
+	if result["name"] != "Prometheus Alerts" {
+		t.Errorf("Expected name 'Prometheus Alerts', got %v", result["name"])
+	}
+}
+
+func TestWebhooks_CreateIncomingToken_Unauthorized(t *testing.T) {
+	cleanDatabase(t)
+	client := newTestClient(t)
+
+	reqBody := map[string]interface{}{
+		"name":             "Test Token",
+		"integration_type": "generic",
+	}
+
+	resp := client.Post("/api/v1/webhooks/incoming", reqBody)
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+}
+
+// ============================================================================
+// GET /api/v1/webhooks/incoming
+// ============================================================================
+
+func TestWebhooks_ListIncomingTokens_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	// Create tokens
+	for i := 0; i < 3; i++ {
+		testFixtures.CreateIncomingWebhookToken(ctx, user.Organization.ID, fmt.Sprintf("Token %d", i))
+	}
+
+	resp := client.Get("/api/v1/webhooks/incoming")
+	client.AssertStatus(resp, http.StatusOK)
+
+	var result []interface{}
+	client.ParseJSON(resp, &result)
+
+	if len(result) != 3 {
+		t.Errorf("Expected 3 tokens, got %d", len(result))
+	}
+}
+
+func TestWebhooks_ListIncomingTokens_Empty(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Get("/api/v1/webhooks/incoming")
+	client.AssertStatus(resp, http.StatusOK)
+
+	var result []interface{}
+	client.ParseJSON(resp, &result)
+
+	if len(result) != 0 {
+		t.Errorf("Expected 0 tokens, got %d", len(result))
+	}
+}
+
+// ============================================================================
+// DELETE /api/v1/webhooks/incoming/:id
+// ============================================================================
+
+func TestWebhooks_DeleteIncomingToken_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	token, _ := testFixtures.CreateIncomingWebhookToken(ctx, user.Organization.ID, "Test Token")
+
+	resp := client.Delete(fmt.Sprintf("/api/v1/webhooks/incoming/%s", token.ID))
+	client.AssertStatus(resp, http.StatusOK)
+}
+
+func TestWebhooks_DeleteIncomingToken_NotFound(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Delete("/api/v1/webhooks/incoming/00000000-0000-0000-0000-000000000000")
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+// ============================================================================
+// POST /api/v1/webhook/:token (Public incoming webhook)
+// ============================================================================
+
+func TestWebhooks_ReceiveWebhook_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	incomingToken, _ := testFixtures.CreateIncomingWebhookToken(ctx, user.Organization.ID, "Test Token")
+
+	// Clear auth token - this is a public endpoint
+	client.ClearAuthToken()
+
+	// Send generic webhook payload
+	reqBody := map[string]interface{}{
+		"source":   "test-system",
+		"message":  "Test alert from webhook",
+		"priority": "P3",
+	}
+
+	resp := client.Post(fmt.Sprintf("/api/v1/webhook/%s", incomingToken.Token), reqBody)
+	client.AssertStatus(resp, http.StatusCreated) // API returns 201 for created alerts
+}
+
+func TestWebhooks_ReceiveWebhook_InvalidToken(t *testing.T) {
+	cleanDatabase(t)
+	client := newTestClient(t)
 
 	reqBody := map[string]interface{}{
 		"message": "Test alert",
@@ -434,3 +1175,927 @@ func TestWebhooks_ReceiveWebhook_InvalidToken(t *testing.T) {
 	resp := client.Post("/api/v1/webhook/invalid-token", reqBody)
 	client.ExpectStatus(resp, http.StatusUnauthorized) // API returns 401 for invalid tokens
 }
+
+// ============================================================================
+// POST /api/v1/webhook/:token (OpsGenie-compatible ingestion)
+// ============================================================================
+
+// receiveOpsGenieAlert posts an OpsGenie Alert API payload and returns the
+// created alert
+func receiveOpsGenieAlert(t *testing.T, client *testutils.TestClient, token string, orgID uuid.UUID, payload map[string]interface{}) *domain.Alert {
+	t.Helper()
+	resp := client.Post(fmt.Sprintf("/api/v1/webhook/%s", token), payload)
+	client.AssertStatus(resp, http.StatusCreated)
+
+	var result struct {
+		AlertIDs []string `json:"alert_ids"`
+	}
+	client.ParseJSON(resp, &result)
+	if len(result.AlertIDs) != 1 {
+		t.Fatalf("Expected 1 alert, got %d", len(result.AlertIDs))
+	}
+
+	alert, err := testServer.AlertService.GetAlert(context.Background(), uuid.MustParse(result.AlertIDs[0]), orgID)
+	if err != nil {
+		t.Fatalf("Failed to get alert: %v", err)
+	}
+	return alert
+}
+
+func TestWebhooks_ReceiveOpsGenie_MapsPayload(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookTokenOfType(ctx, user.Organization.ID, "OpsGenie", domain.IncomingWebhookOpsGenie)
+
+	alert := receiveOpsGenieAlert(t, client, token.Token, user.Organization.ID, map[string]interface{}{
+		"message":     "CPU usage above 90%",
+		"alias":       "cpu-high-web-1",
+		"description": "web-1 has been above 90% for 10 minutes",
+		"priority":    "P2",
+		"source":      "nagios",
+		"entity":      "web-1",
+		"tags":        []string{"web", "cpu"},
+		"details":     map[string]string{"region": "eu-west-1"},
+		"responders":  []map[string]string{{"type": "team", "name": "SRE"}},
+	})
+
+	if alert.Message != "CPU usage above 90%" {
+		t.Errorf("Expected message to be mapped, got %q", alert.Message)
+	}
+	if alert.Priority != domain.PriorityP2 {
+		t.Errorf("Expected priority P2, got %s", alert.Priority)
+	}
+	if alert.Source != "nagios" {
+		t.Errorf("Expected source nagios, got %s", alert.Source)
+	}
+	if alert.DedupKey == nil || *alert.DedupKey != "cpu-high-web-1" {
+		t.Errorf("Expected alias as dedup key, got %v", alert.DedupKey)
+	}
+	if alert.Description == nil || *alert.Description != "web-1 has been above 90% for 10 minutes" {
+		t.Errorf("Expected description to be mapped, got %v", alert.Description)
+	}
+	if alert.CustomFields["region"] != "eu-west-1" || alert.CustomFields["entity"] != "web-1" {
+		t.Errorf("Expected details and entity as custom fields, got %v", alert.CustomFields)
+	}
+	for _, tag := range []string{"opsgenie", "web", "cpu"} {
+		found := false
+		for _, got := range alert.Tags {
+			if got == tag {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected tag %q, got %v", tag, alert.Tags)
+		}
+	}
+}
+
+func TestWebhooks_ReceiveOpsGenie_PriorityMapping(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookTokenOfType(ctx, user.Organization.ID, "OpsGenie", domain.IncomingWebhookOpsGenie)
+
+	tests := []struct {
+		priority string
+		expected domain.AlertPriority
+	}{
+		{"P1", domain.PriorityP1},
+		{"P2", domain.PriorityP2},
+		{"P3", domain.PriorityP3},
+		{"P4", domain.PriorityP4},
+		{"P5", domain.PriorityP5},
+		{"p1", domain.PriorityP1},
+		{"", domain.PriorityP3},
+		{"urgent", domain.PriorityP3},
+	}
+
+	for _, tt := range tests {
+		t.Run("priority_"+tt.priority, func(t *testing.T) {
+			payload := map[string]interface{}{"message": "Disk almost full"}
+			if tt.priority != "" {
+				payload["priority"] = tt.priority
+			}
+
+			alert := receiveOpsGenieAlert(t, client, token.Token, user.Organization.ID, payload)
+			if alert.Priority != tt.expected {
+				t.Errorf("Expected priority %s for %q, got %s", tt.expected, tt.priority, alert.Priority)
+			}
+		})
+	}
+}
+
+func TestWebhooks_ReceiveOpsGenie_AliasDedup(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookTokenOfType(ctx, user.Organization.ID, "OpsGenie", domain.IncomingWebhookOpsGenie)
+
+	payload := map[string]interface{}{"message": "Queue backlog growing", "alias": "queue-backlog"}
+	first := receiveOpsGenieAlert(t, client, token.Token, user.Organization.ID, payload)
+	second := receiveOpsGenieAlert(t, client, token.Token, user.Organization.ID, payload)
+
+	if first.ID != second.ID {
+		t.Fatalf("Expected alerts with the same alias to be deduplicated, got %s and %s", first.ID, second.ID)
+	}
+	if second.DedupCount != 2 {
+		t.Errorf("Expected dedup count 2, got %d", second.DedupCount)
+	}
+
+	other := receiveOpsGenieAlert(t, client, token.Token, user.Organization.ID, map[string]interface{}{
+		"message": "Queue backlog growing",
+		"alias":   "queue-backlog-eu",
+	})
+	if other.ID == first.ID {
+		t.Error("Expected a different alias to create a new alert")
+	}
+}
+
+func TestWebhooks_ReceiveOpsGenie_RequiresMessage(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookTokenOfType(ctx, user.Organization.ID, "OpsGenie", domain.IncomingWebhookOpsGenie)
+
+	resp := client.Post(fmt.Sprintf("/api/v1/webhook/%s", token.Token), map[string]interface{}{"alias": "no-message"})
+	client.ExpectStatus(resp, http.StatusBadRequest)
+}
+
+// ============================================================================
+// POST /api/v1/webhook/:token (Prometheus Alertmanager)
+// ============================================================================
+
+func alertmanagerAlert(status, fingerprint string, labels, annotations map[string]string) map[string]interface{} {
+	return map[string]interface{}{
+		"status":       status,
+		"labels":       labels,
+		"annotations":  annotations,
+		"startsAt":     "2024-01-01T00:00:00Z",
+		"endsAt":       "0001-01-01T00:00:00Z",
+		"generatorURL": "http://prometheus:9090/graph?g0.expr=up",
+		"fingerprint":  fingerprint,
+	}
+}
+
+func TestWebhooks_ReceiveAlertmanager_FiringAndResolved(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookTokenOfType(ctx, user.Organization.ID, "Alertmanager", domain.IncomingWebhookPrometheus)
+	url := fmt.Sprintf("/api/v1/webhook/%s", token.Token)
+
+	diskLabels := map[string]string{"alertname": "DiskFull", "severity": "warning", "instance": "db-1"}
+	diskAnnotations := map[string]string{"summary": "Disk almost full on db-1"}
+
+	// The disk alert fires first on its own
+	resp := client.Post(url, map[string]interface{}{
+		"status": "firing",
+		"alerts": []interface{}{alertmanagerAlert("firing", "fp-disk", diskLabels, diskAnnotations)},
+	})
+	client.AssertStatus(resp, http.StatusCreated)
+	var first struct {
+		AlertIDs []string `json:"alert_ids"`
+	}
+	client.ParseJSON(resp, &first)
+	if len(first.AlertIDs) != 1 {
+		t.Fatalf("Expected 1 alert created, got %d", len(first.AlertIDs))
+	}
+	diskAlertID := uuid.MustParse(first.AlertIDs[0])
+
+	// One batch with a new firing alert and the disk alert resolved
+	resp = client.Post(url, map[string]interface{}{
+		"status": "firing",
+		"alerts": []interface{}{
+			alertmanagerAlert("firing", "fp-api", map[string]string{
+				"alertname": "APIDown",
+				"severity":  "critical",
+				"service":   "api",
+			}, map[string]string{
+				"summary":     "API is down",
+				"description": "No healthy API instances",
+				"runbook_url": "https://runbooks.example.com/api-down",
+			}),
+			alertmanagerAlert("resolved", "fp-disk", diskLabels, diskAnnotations),
+		},
+	})
+	client.AssertStatus(resp, http.StatusCreated)
+
+	var result struct {
+		AlertsCreated    int      `json:"alerts_created"`
+		AlertsResolved   int      `json:"alerts_resolved"`
+		AlertsReceived   int      `json:"alerts_received"`
+		AlertIDs         []string `json:"alert_ids"`
+		ResolvedAlertIDs []string `json:"resolved_alert_ids"`
+	}
+	client.ParseJSON(resp, &result)
+
+	if result.AlertsReceived != 2 || result.AlertsCreated != 1 || result.AlertsResolved != 1 {
+		t.Fatalf("Expected 2 received, 1 created and 1 resolved, got %+v", result)
+	}
+	if result.ResolvedAlertIDs[0] != diskAlertID.String() {
+		t.Errorf("Expected disk alert %s to be resolved, got %s", diskAlertID, result.ResolvedAlertIDs[0])
+	}
+
+	diskAlert, err := testServer.AlertService.GetAlert(ctx, diskAlertID, user.Organization.ID)
+	if err != nil {
+		t.Fatalf("Failed to get disk alert: %v", err)
+	}
+	if diskAlert.Status != domain.AlertStatusClosed {
+		t.Errorf("Expected resolved alert to be closed, got %s", diskAlert.Status)
+	}
+	if diskAlert.CloseReason == nil || *diskAlert.CloseReason != domain.SourceResolvedReason {
+		t.Errorf("Expected close reason %q, got %v", domain.SourceResolvedReason, diskAlert.CloseReason)
+	}
+
+	apiAlert, err := testServer.AlertService.GetAlert(ctx, uuid.MustParse(result.AlertIDs[0]), user.Organization.ID)
+	if err != nil {
+		t.Fatalf("Failed to get API alert: %v", err)
+	}
+	if apiAlert.Status != domain.AlertStatusOpen {
+		t.Errorf("Expected firing alert to be open, got %s", apiAlert.Status)
+	}
+	if apiAlert.Priority != domain.PriorityP1 {
+		t.Errorf("Expected critical severity to map to P1, got %s", apiAlert.Priority)
+	}
+	if apiAlert.Message != "API is down" {
+		t.Errorf("Expected summary as message, got %q", apiAlert.Message)
+	}
+	if apiAlert.DedupKey == nil || *apiAlert.DedupKey != "fp-api" {
+		t.Errorf("Expected fingerprint as dedup key, got %v", apiAlert.DedupKey)
+	}
+	if apiAlert.Description == nil ||
+		!strings.Contains(*apiAlert.Description, "No healthy API instances") ||
+		!strings.Contains(*apiAlert.Description, "runbook_url: https://runbooks.example.com/api-down") {
+		t.Errorf("Expected annotations in the description, got %v", apiAlert.Description)
+	}
+	for _, tag := range []string{"prometheus", "alertname:apidown", "service:api"} {
+		found := false
+		for _, got := range apiAlert.Tags {
+			if got == tag {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected tag %q, got %v", tag, apiAlert.Tags)
+		}
+	}
+
+	// A repeated firing notification deduplicates on the fingerprint
+	resp = client.Post(url, map[string]interface{}{
+		"status": "firing",
+		"alerts": []interface{}{alertmanagerAlert("firing", "fp-api", map[string]string{"alertname": "APIDown"}, nil)},
+	})
+	client.AssertStatus(resp, http.StatusCreated)
+	var repeat struct {
+		AlertIDs []string `json:"alert_ids"`
+	}
+	client.ParseJSON(resp, &repeat)
+	if len(repeat.AlertIDs) != 1 || repeat.AlertIDs[0] != apiAlert.ID.String() {
+		t.Errorf("Expected repeat notification to deduplicate into %s, got %v", apiAlert.ID, repeat.AlertIDs)
+	}
+}
+
+func TestWebhooks_ReceiveAlertmanager_DropsTagsOverLimits(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookTokenOfType(ctx, user.Organization.ID, "Alertmanager", domain.IncomingWebhookPrometheus)
+
+	labels := map[string]string{"alertname": "NoisyLabels", "runbook": strings.Repeat("x", domain.MaxAlertTagLength)}
+	for i := 0; i < domain.MaxAlertTags; i++ {
+		labels[fmt.Sprintf("label_%03d", i)] = "value"
+	}
+
+	result := postIncomingWebhook(t, client, token.Token, map[string]interface{}{
+		"status": "firing",
+		"alerts": []interface{}{alertmanagerAlert("firing", "fp-noisy", labels, nil)},
+	})
+	if result.AlertsCreated != 1 {
+		t.Fatalf("Expected the alert to be created despite its labels, got %+v", result)
+	}
+
+	alert, err := testServer.AlertService.GetAlert(ctx, uuid.MustParse(result.AlertIDs[0]), user.Organization.ID)
+	if err != nil {
+		t.Fatalf("Failed to get alert: %v", err)
+	}
+	if len(alert.Tags) != domain.MaxAlertTags {
+		t.Errorf("Expected tags capped at %d, got %d", domain.MaxAlertTags, len(alert.Tags))
+	}
+	for _, tag := range alert.Tags {
+		if strings.HasPrefix(tag, "runbook:") {
+			t.Errorf("Expected the over-long tag to be dropped, got %q", tag)
+		}
+	}
+}
+
+func TestWebhooks_ReceiveAlertmanager_ResolvedUnknownFingerprint(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookTokenOfType(ctx, user.Organization.ID, "Alertmanager", domain.IncomingWebhookPrometheus)
+
+	resp := client.Post(fmt.Sprintf("/api/v1/webhook/%s", token.Token), map[string]interface{}{
+		"status": "resolved",
+		"alerts": []interface{}{alertmanagerAlert("resolved", "fp-unknown", map[string]string{"alertname": "Gone"}, nil)},
+	})
+	client.AssertStatus(resp, http.StatusCreated)
+
+	var result map[string]interface{}
+	client.ParseJSON(resp, &result)
+	if result["alerts_created"] != float64(0) || result["alerts_resolved"] != float64(0) {
+		t.Errorf("Expected nothing created or resolved, got %v", result)
+	}
+}
+
+// ============================================================================
+// POST /api/v1/webhook/:token (Grafana)
+// ============================================================================
+
+// incomingWebhookResult is the response of the public incoming webhook endpoint
+type incomingWebhookResult struct {
+	AlertsCreated    int      `json:"alerts_created"`
+	AlertsResolved   int      `json:"alerts_resolved"`
+	AlertIDs         []string `json:"alert_ids"`
+	ResolvedAlertIDs []string `json:"resolved_alert_ids"`
+}
+
+func postIncomingWebhook(t *testing.T, client *testutils.TestClient, token string, payload map[string]interface{}) incomingWebhookResult {
+	t.Helper()
+	resp := client.Post(fmt.Sprintf("/api/v1/webhook/%s", token), payload)
+	client.AssertStatus(resp, http.StatusCreated)
+
+	var result incomingWebhookResult
+	client.ParseJSON(resp, &result)
+	return result
+}
+
+func TestWebhooks_ReceiveGrafana_LegacyPayload(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookTokenOfType(ctx, user.Organization.ID, "Grafana", domain.IncomingWebhookGrafana)
+
+	alerting := map[string]interface{}{
+		"title":    "[Alerting] High latency",
+		"ruleId":   7,
+		"ruleName": "High latency",
+		"ruleUrl":  "http://grafana:3000/d/abc",
+		"state":    "alerting",
+		"message":  "p99 latency above 2s",
+		"tags":     map[string]string{"service": "checkout"},
+	}
+	result := postIncomingWebhook(t, client, token.Token, alerting)
+	if result.AlertsCreated != 1 {
+		t.Fatalf("Expected 1 alert created, got %d", result.AlertsCreated)
+	}
+
+	alert, err := testServer.AlertService.GetAlert(ctx, uuid.MustParse(result.AlertIDs[0]), user.Organization.ID)
+	if err != nil {
+		t.Fatalf("Failed to get alert: %v", err)
+	}
+	if alert.Message != "[Alerting] High latency" || alert.Priority != domain.PriorityP2 || alert.Source != "grafana" {
+		t.Errorf("Expected legacy alert mapped from title and state, got %q %s %s", alert.Message, alert.Priority, alert.Source)
+	}
+	if alert.Description == nil || *alert.Description != "p99 latency above 2s" {
+		t.Errorf("Expected message as description, got %v", alert.Description)
+	}
+
+	// The rule returning to ok closes its alert
+	result = postIncomingWebhook(t, client, token.Token, map[string]interface{}{
+		"title":  "[OK] High latency",
+		"ruleId": 7,
+		"state":  "ok",
+	})
+	if result.AlertsCreated != 0 || result.AlertsResolved != 1 || result.ResolvedAlertIDs[0] != alert.ID.String() {
+		t.Fatalf("Expected the rule's alert to be resolved, got %+v", result)
+	}
+
+	alert, _ = testServer.AlertService.GetAlert(ctx, alert.ID, user.Organization.ID)
+	if alert.Status != domain.AlertStatusClosed {
+		t.Errorf("Expected alert to be closed, got %s", alert.Status)
+	}
+}
+
+func TestWebhooks_ReceiveGrafana_UnifiedPayload(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookTokenOfType(ctx, user.Organization.ID, "Grafana", domain.IncomingWebhookGrafana)
+
+	unified := func(alerts ...map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"receiver": "pulsar",
+			"status":   "firing",
+			"orgId":    1,
+			"version":  "1",
+			"groupKey": "{}:{}",
+			"title":    "[FIRING] Grafana alerts",
+			"state":    "alerting",
+			"message":  "Grafana unified alerting notification",
+			"alerts":   alerts,
+		}
+	}
+	cpuAlert := func(status string) map[string]interface{} {
+		return map[string]interface{}{
+			"status":       status,
+			"labels":       map[string]string{"alertname": "HighCPU", "severity": "critical", "host": "web-1"},
+			"annotations":  map[string]string{"summary": "CPU above 95%", "description": "web-1 CPU saturated"},
+			"generatorURL": "http://grafana:3000/alerting/grafana/abc/view",
+			"fingerprint":  "fp-cpu",
+		}
+	}
+
+	result := postIncomingWebhook(t, client, token.Token, unified(
+		cpuAlert("firing"),
+		map[string]interface{}{
+			"status":      "firing",
+			"labels":      map[string]string{"alertname": "LowDisk"},
+			"annotations": map[string]string{},
+			"fingerprint": "fp-disk",
+		},
+	))
+	if result.AlertsCreated != 2 {
+		t.Fatalf("Expected 2 alerts created, got %d", result.AlertsCreated)
+	}
+
+	cpu, err := testServer.AlertService.GetAlert(ctx, uuid.MustParse(result.AlertIDs[0]), user.Organization.ID)
+	if err != nil {
+		t.Fatalf("Failed to get alert: %v", err)
+	}
+	if cpu.Message != "HighCPU" {
+		t.Errorf("Expected rule name as message, got %q", cpu.Message)
+	}
+	if cpu.Priority != domain.PriorityP1 {
+		t.Errorf("Expected critical severity to map to P1, got %s", cpu.Priority)
+	}
+	if cpu.Description == nil ||
+		!strings.Contains(*cpu.Description, "CPU above 95%") ||
+		!strings.Contains(*cpu.Description, "web-1 CPU saturated") {
+		t.Errorf("Expected annotations in the description, got %v", cpu.Description)
+	}
+	if cpu.DedupKey == nil || *cpu.DedupKey != "fp-cpu" {
+		t.Errorf("Expected fingerprint as dedup key, got %v", cpu.DedupKey)
+	}
+
+	disk, _ := testServer.AlertService.GetAlert(ctx, uuid.MustParse(result.AlertIDs[1]), user.Organization.ID)
+	if disk.Priority != domain.PriorityP2 {
+		t.Errorf("Expected firing alert without severity to default to P2, got %s", disk.Priority)
+	}
+
+	// Resolving one alert of the group closes only that alert
+	result = postIncomingWebhook(t, client, token.Token, unified(cpuAlert("resolved")))
+	if result.AlertsResolved != 1 || result.ResolvedAlertIDs[0] != cpu.ID.String() {
+		t.Fatalf("Expected the CPU alert to be resolved, got %+v", result)
+	}
+
+	cpu, _ = testServer.AlertService.GetAlert(ctx, cpu.ID, user.Organization.ID)
+	if cpu.Status != domain.AlertStatusClosed {
+		t.Errorf("Expected resolved alert to be closed, got %s", cpu.Status)
+	}
+	disk, _ = testServer.AlertService.GetAlert(ctx, disk.ID, user.Organization.ID)
+	if disk.Status != domain.AlertStatusOpen {
+		t.Errorf("Expected firing alert to stay open, got %s", disk.Status)
+	}
+}
+
+// ============================================================================
+// POST /api/v1/webhook/:token (generic webhook field mapping)
+// ============================================================================
+
+func createMappedWebhookToken(t *testing.T, client *testutils.TestClient, mapping map[string]interface{}) *domain.IncomingWebhookToken {
+	t.Helper()
+	resp := client.Post("/api/v1/webhooks/incoming", map[string]interface{}{
+		"name":             "Mapped",
+		"integration_type": "generic",
+		"default_priority": "P4",
+		"field_mapping":    mapping,
+	})
+	client.AssertStatus(resp, http.StatusCreated)
+
+	var token domain.IncomingWebhookToken
+	client.ParseJSON(resp, &token)
+	return &token
+}
+
+func TestWebhooks_ReceiveGeneric_FieldMapping(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	token := createMappedWebhookToken(t, client, map[string]interface{}{
+		"message":         "$.title",
+		"description":     "$.data.details.text",
+		"priority":        "$.data.level",
+		"priority_values": map[string]string{"critical": "P1", "warn": "P3"},
+		"tags":            "$.data.labels",
+		"dedup_key":       "$.events[0].id",
+		"source":          "$['origin.system']",
+	})
+	if token.FieldMapping == nil || token.FieldMapping.Message != "$.title" {
+		t.Fatalf("Expected field mapping to be stored, got %+v", token.FieldMapping)
+	}
+
+	client.ClearAuthToken()
+	result := postIncomingWebhook(t, client, token.Token, map[string]interface{}{
+		"title":         "Payment failures spiking",
+		"origin.system": "billing-monitor",
+		"data": map[string]interface{}{
+			"level":   "CRITICAL",
+			"details": map[string]interface{}{"text": "Error rate at 12%"},
+			"labels":  map[string]interface{}{"service": "payments", "region": "us-east-1"},
+		},
+		"events": []map[string]interface{}{{"id": 4711}},
+	})
+	if result.AlertsCreated != 1 {
+		t.Fatalf("Expected 1 alert created, got %d", result.AlertsCreated)
+	}
+
+	alert, err := testServer.AlertService.GetAlert(ctx, uuid.MustParse(result.AlertIDs[0]), user.Organization.ID)
+	if err != nil {
+		t.Fatalf("Failed to get alert: %v", err)
+	}
+	if alert.Message != "Payment failures spiking" {
+		t.Errorf("Expected message from $.title, got %q", alert.Message)
+	}
+	if alert.Description == nil || *alert.Description != "Error rate at 12%" {
+		t.Errorf("Expected description from nested path, got %v", alert.Description)
+	}
+	if alert.Priority != domain.PriorityP1 {
+		t.Errorf("Expected CRITICAL to map to P1, got %s", alert.Priority)
+	}
+	if alert.Source != "billing-monitor" {
+		t.Errorf("Expected source from quoted member, got %q", alert.Source)
+	}
+	if alert.DedupKey == nil || *alert.DedupKey != "4711" {
+		t.Errorf("Expected dedup key from array element, got %v", alert.DedupKey)
+	}
+	for _, tag := range []string{"region:us-east-1", "service:payments", "webhook"} {
+		found := false
+		for _, got := range alert.Tags {
+			if got == tag {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected tag %q, got %v", tag, alert.Tags)
+		}
+	}
+}
+
+func TestWebhooks_ReceiveGeneric_FieldMappingMissingPaths(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	token := createMappedWebhookToken(t, client, map[string]interface{}{
+		"message":   "$.title",
+		"priority":  "$.data.level",
+		"tags":      "$.data.tags",
+		"dedup_key": "$.items[3].id",
+	})
+
+	client.ClearAuthToken()
+	result := postIncomingWebhook(t, client, token.Token, map[string]interface{}{
+		"data":  "not an object",
+		"items": []string{"only one"},
+	})
+	if result.AlertsCreated != 1 {
+		t.Fatalf("Expected an alert despite missing paths, got %d", result.AlertsCreated)
+	}
+
+	alert, err := testServer.AlertService.GetAlert(ctx, uuid.MustParse(result.AlertIDs[0]), user.Organization.ID)
+	if err != nil {
+		t.Fatalf("Failed to get alert: %v", err)
+	}
+	if alert.Message != "Alert from generic webhook" {
+		t.Errorf("Expected default message, got %q", alert.Message)
+	}
+	if alert.Priority != domain.PriorityP4 {
+		t.Errorf("Expected token default priority P4, got %s", alert.Priority)
+	}
+	if alert.DedupKey != nil {
+		t.Errorf("Expected no dedup key, got %s", *alert.DedupKey)
+	}
+	if len(alert.Tags) != 1 || alert.Tags[0] != "webhook" {
+		t.Errorf("Expected only the webhook tag, got %v", alert.Tags)
+	}
+
+	// An unmapped priority value also falls back to the default
+	result = postIncomingWebhook(t, client, token.Token, map[string]interface{}{
+		"title": "Unknown level",
+		"data":  map[string]interface{}{"level": "sev-9"},
+	})
+	alert, _ = testServer.AlertService.GetAlert(ctx, uuid.MustParse(result.AlertIDs[0]), user.Organization.ID)
+	if alert.Priority != domain.PriorityP4 {
+		t.Errorf("Expected token default priority for unknown level, got %s", alert.Priority)
+	}
+}
+
+func TestWebhooks_CreateIncomingToken_InvalidFieldMapping(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	tests := []struct {
+		name            string
+		integrationType string
+		mapping         map[string]interface{}
+	}{
+		{"unclosed bracket", "generic", map[string]interface{}{"message": "$.alerts[0"}},
+		{"bad index", "generic", map[string]interface{}{"message": "$.alerts[first]"}},
+		{"bad priority value", "generic", map[string]interface{}{"priority_values": map[string]string{"critical": "P0"}}},
+		{"non-generic integration", "prometheus", map[string]interface{}{"message": "$.title"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := client.Post("/api/v1/webhooks/incoming", map[string]interface{}{
+				"name":             "Mapped",
+				"integration_type": tt.integrationType,
+				"field_mapping":    tt.mapping,
+			})
+			client.ExpectStatus(resp, http.StatusBadRequest)
+		})
+	}
+}
+
+// ============================================================================
+// GET /api/v1/webhooks/incoming/:id/requests
+// ============================================================================
+
+func listIncomingRequests(t *testing.T, client *testutils.TestClient, tokenID uuid.UUID) []domain.IncomingWebhookRequest {
+	t.Helper()
+	resp := client.Get(fmt.Sprintf("/api/v1/webhooks/incoming/%s/requests", tokenID))
+	client.AssertStatus(resp, http.StatusOK)
+
+	var requests []domain.IncomingWebhookRequest
+	client.ParseJSON(resp, &requests)
+	return requests
+}
+
+func TestWebhooks_IncomingRequests_RecordsFailedParse(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookTokenOfType(ctx, user.Organization.ID, "Alertmanager", domain.IncomingWebhookPrometheus)
+
+	resp := client.PostRaw(fmt.Sprintf("/api/v1/webhook/%s", token.Token), []byte(`{"alerts": [`))
+	client.AssertStatus(resp, http.StatusBadRequest)
+
+	client.SetAuthToken(user.AccessToken)
+	requests := listIncomingRequests(t, client, token.ID)
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 logged request, got %d", len(requests))
+	}
+
+	logged := requests[0]
+	if logged.Success {
+		t.Error("Expected the malformed request to be logged as failed")
+	}
+	if logged.Error == nil || !strings.Contains(*logged.Error, "failed to parse webhook") {
+		t.Errorf("Expected the parse error to be recorded, got %v", logged.Error)
+	}
+	if logged.Body != `{"alerts": [` {
+		t.Errorf("Expected the raw body to be recorded, got %q", logged.Body)
+	}
+	if logged.Headers["Content-Type"] != "application/json" {
+		t.Errorf("Expected request headers to be recorded, got %v", logged.Headers)
+	}
+	if len(logged.AlertIDs) != 0 {
+		t.Errorf("Expected no alerts for a failed parse, got %v", logged.AlertIDs)
+	}
+}
+
+func TestWebhooks_IncomingRequests_RecordsCreatedAlerts(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookToken(ctx, user.Organization.ID, "Generic")
+
+	// Credentials sent to the webhook are not stored
+	client.SetAuthToken("integration-secret")
+	result := postIncomingWebhook(t, client, token.Token, map[string]interface{}{"message": "Backup failed"})
+
+	client.SetAuthToken(user.AccessToken)
+	requests := listIncomingRequests(t, client, token.ID)
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 logged request, got %d", len(requests))
+	}
+
+	logged := requests[0]
+	if !logged.Success || logged.Error != nil {
+		t.Errorf("Expected a successful request, got error %v", logged.Error)
+	}
+	if logged.AlertsReceived != 1 || len(logged.AlertIDs) != 1 || logged.AlertIDs[0].String() != result.AlertIDs[0] {
+		t.Errorf("Expected the created alert %s to be recorded, got %v", result.AlertIDs[0], logged.AlertIDs)
+	}
+	if auth := logged.Headers["Authorization"]; auth != domain.RedactedValue {
+		t.Errorf("Expected Authorization header to be redacted, got %q", auth)
+	}
+}
+
+func TestWebhooks_IncomingRequests_RetainsLatest(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookToken(ctx, user.Organization.ID, "Generic")
+
+	total := domain.IncomingWebhookRequestRetention + 5
+	for i := 0; i < total; i++ {
+		client.PostRaw(fmt.Sprintf("/api/v1/webhook/%s", token.Token), []byte(fmt.Sprintf("request %d", i))).Body.Close()
+	}
+
+	client.SetAuthToken(user.AccessToken)
+	requests := listIncomingRequests(t, client, token.ID)
+	if len(requests) != domain.IncomingWebhookRequestRetention {
+		t.Fatalf("Expected %d logged requests, got %d", domain.IncomingWebhookRequestRetention, len(requests))
+	}
+	if requests[0].Body != fmt.Sprintf("request %d", total-1) {
+		t.Errorf("Expected the newest request first, got %q", requests[0].Body)
+	}
+}
+
+func TestWebhooks_IncomingRequests_TruncatesMultibyteBodyAtRuneBoundary(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookToken(ctx, user.Organization.ID, "Generic")
+
+	// A NUL byte and invalid UTF-8 up front, then two-byte runes that put
+	// the limit in the middle of one
+	body := "\x00\xff" + strings.Repeat("é", domain.IncomingWebhookRequestBodyLimit/2)
+	client.PostRaw(fmt.Sprintf("/api/v1/webhook/%s", token.Token), []byte(body)).Body.Close()
+
+	client.SetAuthToken(user.AccessToken)
+	requests := listIncomingRequests(t, client, token.ID)
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 logged request, got %d", len(requests))
+	}
+
+	logged := requests[0]
+	if !logged.BodyTruncated {
+		t.Error("Expected the body to be marked truncated")
+	}
+	if len(logged.Body) > domain.IncomingWebhookRequestBodyLimit {
+		t.Errorf("Expected at most %d bytes, got %d", domain.IncomingWebhookRequestBodyLimit, len(logged.Body))
+	}
+	if !utf8.ValidString(logged.Body) || strings.Contains(logged.Body, "\x00") {
+		t.Error("Expected the stored body to be valid UTF-8 without NUL bytes")
+	}
+	if !strings.HasPrefix(logged.Body, "\uFFFDéé") || !strings.HasSuffix(logged.Body, "é") {
+		t.Errorf("Expected invalid bytes replaced and whole runes kept, got %q...%q", logged.Body[:8], logged.Body[len(logged.Body)-4:])
+	}
+}
+
+func TestWebhooks_IncomingRequests_OtherOrganization(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookToken(ctx, owner.Organization.ID, "Generic")
+
+	client.SetAuthToken(other.AccessToken)
+	resp := client.Get(fmt.Sprintf("/api/v1/webhooks/incoming/%s/requests", token.ID))
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+// ============================================================================
+// POST /api/v1/webhooks/incoming/:id/rotate
+// ============================================================================
+
+func TestWebhooks_RotateIncomingToken_Success(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Post("/api/v1/webhooks/incoming", map[string]interface{}{
+		"name":             "Prometheus",
+		"integration_type": "generic",
+		"default_priority": "P2",
+		"default_tags":     []string{"team:sre"},
+	})
+	client.AssertStatus(resp, http.StatusCreated)
+	var original domain.IncomingWebhookToken
+	client.ParseJSON(resp, &original)
+
+	resp = client.Post(fmt.Sprintf("/api/v1/webhooks/incoming/%s/rotate", original.ID), nil)
+	client.AssertStatus(resp, http.StatusOK)
+	var rotated domain.IncomingWebhookToken
+	client.ParseJSON(resp, &rotated)
+
+	if rotated.Token == "" || rotated.Token == original.Token {
+		t.Fatalf("Expected a new token, got %q", rotated.Token)
+	}
+	if rotated.ID != original.ID || rotated.Name != original.Name || rotated.IntegrationType != original.IntegrationType ||
+		rotated.DefaultPriority != "P2" || len(rotated.DefaultTags) != 1 || rotated.DefaultTags[0] != "team:sre" {
+		t.Errorf("Expected token settings to be preserved, got %+v", rotated)
+	}
+
+	client.ClearAuthToken()
+	resp = client.Post(fmt.Sprintf("/api/v1/webhook/%s", original.Token), map[string]interface{}{"message": "Replication lag"})
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+
+	result := postIncomingWebhook(t, client, rotated.Token, map[string]interface{}{"message": "Replication lag"})
+	if result.AlertsCreated != 1 {
+		t.Fatalf("Expected the new token to create an alert, got %d", result.AlertsCreated)
+	}
+
+	alert, err := testServer.AlertService.GetAlert(ctx, uuid.MustParse(result.AlertIDs[0]), user.Organization.ID)
+	if err != nil {
+		t.Fatalf("Failed to get alert: %v", err)
+	}
+	found := false
+	for _, tag := range alert.Tags {
+		if tag == "team:sre" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected default tags to apply with the new token, got %v", alert.Tags)
+	}
+}
+
+func TestWebhooks_RotateIncomingToken_NotFound(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	owner, _ := testFixtures.CreateUniqueUser(ctx)
+	other, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookToken(ctx, owner.Organization.ID, "Generic")
+
+	client.SetAuthToken(other.AccessToken)
+	resp := client.Post(fmt.Sprintf("/api/v1/webhooks/incoming/%s/rotate", token.ID), nil)
+	client.ExpectStatus(resp, http.StatusNotFound)
+
+	resp = client.Post("/api/v1/webhooks/incoming/00000000-0000-0000-0000-000000000000/rotate", nil)
+	client.ExpectStatus(resp, http.StatusNotFound)
+}
+
+func TestWebhooks_RotateIncomingToken_RequiresAdmin(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	token, _ := testFixtures.CreateIncomingWebhookToken(ctx, user.Organization.ID, "Generic")
+
+	// Demote to member and log in again so the token carries the new role
+	if _, err := testDB.ExecContext(ctx,
+		`UPDATE organization_users SET role = 'member' WHERE user_id = $1`, user.User.ID); err != nil {
+		t.Fatalf("Failed to demote user: %v", err)
+	}
+	resp := client.Post("/api/v1/auth/login", map[string]string{
+		"email":    user.User.Email,
+		"password": "TestPassword123!",
+	})
+	client.AssertStatus(resp, http.StatusOK)
+	var login map[string]interface{}
+	client.ParseJSON(resp, &login)
+	accessToken, _ := login["access_token"].(string)
+
+	client.SetAuthToken(accessToken)
+	resp = client.Post(fmt.Sprintf("/api/v1/webhooks/incoming/%s/rotate", token.ID), nil)
+	client.ExpectStatus(resp, http.StatusForbidden)
+}
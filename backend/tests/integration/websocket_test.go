@@ -0,0 +1,154 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/service"
+)
+
+// startWebSocketHub returns a running hub that isn't shared with other tests
+func startWebSocketHub() *service.WebSocketService {
+	svc := service.NewWebSocketService(zap.NewNop())
+	go svc.Run()
+	return svc
+}
+
+// connectWSClient registers a client with the hub and consumes its welcome message
+func connectWSClient(t *testing.T, svc *service.WebSocketService, orgID uuid.UUID, lastEventID string) *domain.WSClient {
+	t.Helper()
+
+	client := &domain.WSClient{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		OrganizationID: orgID,
+		Send:           make(chan *domain.WSMessage, 256),
+		LastEventID:    lastEventID,
+	}
+	svc.GetHub().Register <- client
+
+	if msg := receiveWSMessage(t, client); msg.Type != domain.WSEventConnected {
+		t.Fatalf("Expected a %s message, got %s", domain.WSEventConnected, msg.Type)
+	}
+	return client
+}
+
+func receiveWSMessage(t *testing.T, client *domain.WSClient) *domain.WSMessage {
+	t.Helper()
+
+	select {
+	case msg, ok := <-client.Send:
+		if !ok {
+			t.Fatal("Client was disconnected")
+		}
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for a WebSocket message")
+	}
+	return nil
+}
+
+func broadcastTestAlert(svc *service.WebSocketService, orgID uuid.UUID, message string) {
+	svc.BroadcastAlertEvent(domain.WSEventAlertCreated, orgID, &domain.Alert{
+		ID:      uuid.New(),
+		Message: message,
+	})
+}
+
+// ============================================================================
+// Replay on reconnect
+// ============================================================================
+
+func TestWebSocket_ReplaysMissedEventsOnReconnect(t *testing.T) {
+	svc := startWebSocketHub()
+	orgID := uuid.New()
+
+	// A second dashboard stays connected throughout, so we know when the hub
+	// has processed the events sent during the gap
+	watcher := connectWSClient(t, svc, orgID, "")
+	client := connectWSClient(t, svc, orgID, "")
+
+	broadcastTestAlert(svc, orgID, "before gap")
+	last := receiveWSMessage(t, client)
+	receiveWSMessage(t, watcher)
+
+	svc.GetHub().Unregister <- client
+
+	broadcastTestAlert(svc, orgID, "during gap 1")
+	broadcastTestAlert(svc, orgID, "during gap 2")
+	var missed []string
+	for i := 0; i < 2; i++ {
+		missed = append(missed, receiveWSMessage(t, watcher).ID)
+	}
+
+	client = connectWSClient(t, svc, orgID, last.ID)
+	for i, id := range missed {
+		msg := receiveWSMessage(t, client)
+		if msg.ID != id {
+			t.Fatalf("Expected replayed event %d to be %s, got %s (%v)", i, id, msg.ID, msg.Payload["message"])
+		}
+	}
+
+	// Live streaming resumes after the replay
+	broadcastTestAlert(svc, orgID, "after reconnect")
+	if msg := receiveWSMessage(t, client); msg.Payload["message"] != "after reconnect" {
+		t.Errorf("Expected the live event after the replay, got %v", msg.Payload["message"])
+	}
+}
+
+func TestWebSocket_ReplayNotSharedAcrossOrganizations(t *testing.T) {
+	svc := startWebSocketHub()
+	orgID := uuid.New()
+	otherOrgID := uuid.New()
+
+	client := connectWSClient(t, svc, orgID, "")
+	other := connectWSClient(t, svc, otherOrgID, "")
+
+	broadcastTestAlert(svc, orgID, "ours")
+	last := receiveWSMessage(t, client)
+	svc.GetHub().Unregister <- client
+
+	broadcastTestAlert(svc, otherOrgID, "theirs")
+	receiveWSMessage(t, other)
+
+	client = connectWSClient(t, svc, orgID, last.ID)
+	broadcastTestAlert(svc, orgID, "live")
+	if msg := receiveWSMessage(t, client); msg.Payload["message"] != "live" {
+		t.Errorf("Expected no replayed events from another organization, got %v", msg.Payload["message"])
+	}
+}
+
+func TestWebSocket_ResyncWhenEventIsNoLongerBuffered(t *testing.T) {
+	svc := startWebSocketHub()
+	orgID := uuid.New()
+
+	watcher := connectWSClient(t, svc, orgID, "")
+	client := connectWSClient(t, svc, orgID, "")
+
+	broadcastTestAlert(svc, orgID, "before gap")
+	last := receiveWSMessage(t, client)
+	receiveWSMessage(t, watcher)
+
+	svc.GetHub().Unregister <- client
+
+	// Push the last seen event out of the buffer
+	for i := 0; i < domain.WSReplayBufferSize; i++ {
+		broadcastTestAlert(svc, orgID, "during gap")
+		receiveWSMessage(t, watcher)
+	}
+
+	client = connectWSClient(t, svc, orgID, last.ID)
+	if msg := receiveWSMessage(t, client); msg.Type != domain.WSEventResync {
+		t.Errorf("Expected a %s hint, got %s", domain.WSEventResync, msg.Type)
+	}
+
+	// An id the hub has never seen gets the same hint
+	stranger := connectWSClient(t, svc, orgID, uuid.New().String())
+	if msg := receiveWSMessage(t, stranger); msg.Type != domain.WSEventResync {
+		t.Errorf("Expected a %s hint for an unknown id, got %s", domain.WSEventResync, msg.Type)
+	}
+}
@@ -0,0 +1,684 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/nmn3m/pulsar/backend/internal/adapter/outbound/postgres"
+	"github.com/nmn3m/pulsar/backend/internal/config"
+	"github.com/nmn3m/pulsar/backend/internal/core/domain"
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+	"github.com/nmn3m/pulsar/backend/internal/pkg/worker"
+	"github.com/nmn3m/pulsar/backend/tests/integration/testutils"
+)
+
+// ============================================================================
+// Background worker shutdown
+// ============================================================================
+
+func TestWorkers_Shutdown_DrainsInFlightIteration(t *testing.T) {
+	group := worker.NewGroup(zap.NewNop())
+
+	started := make(chan struct{})
+	var iterations, completed int32
+
+	group.Go("test", 10*time.Millisecond, func(ctx context.Context) error {
+		if atomic.AddInt32(&iterations, 1) == 1 {
+			close(started)
+		}
+		select {
+		case <-time.After(200 * time.Millisecond):
+			atomic.AddInt32(&completed, 1)
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := group.Shutdown(ctx); err != nil {
+		t.Fatalf("Expected clean drain, got %v", err)
+	}
+	if got := atomic.LoadInt32(&completed); got != 1 {
+		t.Errorf("Expected in-flight iteration to complete, completed=%d", got)
+	}
+	if got := atomic.LoadInt32(&iterations); got != 1 {
+		t.Errorf("Expected no new iterations after shutdown, got %d", got)
+	}
+}
+
+func TestWorkers_Shutdown_TimeoutCancelsTask(t *testing.T) {
+	group := worker.NewGroup(zap.NewNop())
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+
+	group.Go("test", 10*time.Millisecond, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	})
+
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := group.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected deadline exceeded, got %v", err)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("Expected task context to be cancelled after shutdown timeout")
+	}
+}
+
+func TestWorkers_Shutdown_Idle(t *testing.T) {
+	group := worker.NewGroup(zap.NewNop())
+	group.Go("test", time.Hour, func(ctx context.Context) error { return nil })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := group.Shutdown(ctx); err != nil {
+		t.Fatalf("Expected idle workers to stop immediately, got %v", err)
+	}
+}
+
+// ============================================================================
+// Worker leader election (advisory lock)
+// ============================================================================
+
+// newSecondInstanceLocker opens a separate connection pool so the locker
+// behaves like another API replica pointed at the same database.
+func newSecondInstanceLocker(t *testing.T) *postgres.AdvisoryLocker {
+	t.Helper()
+	db, err := postgres.NewDB(&config.DatabaseConfig{
+		URL:          testutils.LoadTestConfig().DatabaseURL,
+		MaxOpenConns: 5,
+		MaxIdleConns: 5,
+	})
+	if err != nil {
+		t.Fatalf("Failed to open second database pool: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return postgres.NewAdvisoryLocker(db.DB)
+}
+
+func TestWorkers_AdvisoryLock_OnlyOneInstanceProcesses(t *testing.T) {
+	ctx := context.Background()
+	instanceA := postgres.NewAdvisoryLocker(testDB.DB)
+	instanceB := newSecondInstanceLocker(t)
+
+	holding := make(chan struct{})
+	release := make(chan struct{})
+	resultA := make(chan bool, 1)
+
+	go func() {
+		ran, err := instanceA.TryWithLock(ctx, "test-worker", func(ctx context.Context) error {
+			close(holding)
+			<-release
+			return nil
+		})
+		if err != nil {
+			t.Errorf("Instance A lock error: %v", err)
+		}
+		resultA <- ran
+	}()
+
+	<-holding
+
+	ranB, err := instanceB.TryWithLock(ctx, "test-worker", func(ctx context.Context) error {
+		t.Error("Instance B should not run while instance A holds the lock")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Instance B lock error: %v", err)
+	}
+	if ranB {
+		t.Error("Expected instance B to skip while lock is held")
+	}
+
+	close(release)
+	if !<-resultA {
+		t.Error("Expected instance A to run")
+	}
+
+	// Once released, the other instance takes over
+	ranB, err = instanceB.TryWithLock(ctx, "test-worker", func(ctx context.Context) error { return nil })
+	if err != nil {
+		t.Fatalf("Instance B lock error: %v", err)
+	}
+	if !ranB {
+		t.Error("Expected instance B to acquire the lock after instance A released it")
+	}
+}
+
+func TestWorkers_Exclusive_ContendingInstances(t *testing.T) {
+	instances := []worker.Locker{
+		postgres.NewAdvisoryLocker(testDB.DB),
+		newSecondInstanceLocker(t),
+	}
+
+	var active, maxActive, processed int32
+	task := func(ctx context.Context) error {
+		n := atomic.AddInt32(&active, 1)
+		for {
+			m := atomic.LoadInt32(&maxActive)
+			if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&processed, 1)
+		atomic.AddInt32(&active, -1)
+		return nil
+	}
+
+	groups := make([]*worker.Group, len(instances))
+	for i, locker := range instances {
+		groups[i] = worker.NewGroup(zap.NewNop())
+		groups[i].Go("contended", 5*time.Millisecond, worker.Exclusive(locker, "contended", task))
+	}
+
+	time.Sleep(300 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	for _, g := range groups {
+		if err := g.Shutdown(ctx); err != nil {
+			t.Fatalf("Failed to shut down workers: %v", err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&maxActive); got != 1 {
+		t.Errorf("Expected at most one instance processing at a time, saw %d", got)
+	}
+	if atomic.LoadInt32(&processed) == 0 {
+		t.Error("Expected the lock holder to process work")
+	}
+}
+
+// ============================================================================
+// Shift handoff notifications
+// ============================================================================
+
+func TestHandoffNotifier_NotifiesIncomingUserOnce(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	outgoing, _ := testFixtures.CreateUniqueUser(ctx)
+	incoming, _ := testFixtures.CreateUniqueUser(ctx)
+	orgID := outgoing.Organization.ID
+
+	if _, err := testFixtures.CreateNotificationChannel(ctx, orgID, "Email"); err != nil {
+		t.Fatalf("Failed to create channel: %v", err)
+	}
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, orgID, "Primary On-Call")
+	rotation, err := testServer.ScheduleService.CreateRotation(ctx, schedule.ID, &dto.CreateRotationRequest{
+		Name:           "Daily",
+		RotationType:   "daily",
+		RotationLength: 1,
+		StartDate:      "2024-01-01",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rotation: %v", err)
+	}
+	for i, userID := range []uuid.UUID{outgoing.User.ID, incoming.User.ID} {
+		if _, err := testServer.ScheduleService.AddParticipant(ctx, rotation.ID, &dto.AddParticipantRequest{UserID: userID, Position: i}); err != nil {
+			t.Fatalf("Failed to add participant: %v", err)
+		}
+	}
+
+	// Mid-shift, long after the first handoff: nothing to announce. Then cross
+	// the handoff at midnight and run the worker twice.
+	for _, now := range []time.Time{
+		time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 0, 5, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 0, 30, 0, 0, time.UTC),
+	} {
+		if err := testServer.HandoffNotifier.ProcessHandoffs(ctx, now, 100); err != nil {
+			t.Fatalf("Failed to process handoffs at %s: %v", now, err)
+		}
+	}
+
+	logs, err := testServer.NotificationService.ListLogsByUser(ctx, incoming.User.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to list notification logs: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("Expected exactly 1 handoff notification, got %d", len(logs))
+	}
+	if logs[0].Subject == nil || !strings.Contains(*logs[0].Subject, "Primary On-Call") {
+		t.Errorf("Expected subject to name the schedule, got %v", logs[0].Subject)
+	}
+
+	logs, _ = testServer.NotificationService.ListLogsByUser(ctx, outgoing.User.ID, 10, 0)
+	if len(logs) != 0 {
+		t.Errorf("Expected no notification for the outgoing user, got %d", len(logs))
+	}
+}
+
+func TestHandoffNotifier_NotifiesOverrideUser(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	outgoing, _ := testFixtures.CreateUniqueUser(ctx)
+	incoming, _ := testFixtures.CreateUniqueUser(ctx)
+	cover, _ := testFixtures.CreateUniqueUser(ctx)
+	orgID := outgoing.Organization.ID
+	joinOrganization(t, ctx, orgID, cover.User.ID)
+
+	if _, err := testFixtures.CreateNotificationChannel(ctx, orgID, "Email"); err != nil {
+		t.Fatalf("Failed to create channel: %v", err)
+	}
+
+	schedule, _ := testFixtures.CreateSchedule(ctx, orgID, "Primary On-Call")
+	rotation, err := testServer.ScheduleService.CreateRotation(ctx, schedule.ID, &dto.CreateRotationRequest{
+		Name:           "Daily",
+		RotationType:   "daily",
+		RotationLength: 1,
+		StartDate:      "2024-01-01",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create rotation: %v", err)
+	}
+	for i, userID := range []uuid.UUID{outgoing.User.ID, incoming.User.ID} {
+		if _, err := testServer.ScheduleService.AddParticipant(ctx, rotation.ID, &dto.AddParticipantRequest{UserID: userID, Position: i}); err != nil {
+			t.Fatalf("Failed to add participant: %v", err)
+		}
+	}
+
+	// The override covers the incoming participant's whole shift
+	if _, _, err := testServer.ScheduleService.CreateOverride(ctx, schedule.ID, &dto.CreateOverrideRequest{
+		UserID:    cover.User.ID,
+		StartTime: "2024-01-01T23:00:00Z",
+		EndTime:   "2024-01-03T01:00:00Z",
+	}); err != nil {
+		t.Fatalf("Failed to create override: %v", err)
+	}
+
+	if err := testServer.HandoffNotifier.ProcessHandoffs(ctx, time.Date(2024, 1, 2, 0, 5, 0, 0, time.UTC), 100); err != nil {
+		t.Fatalf("Failed to process handoffs: %v", err)
+	}
+
+	logs, err := testServer.NotificationService.ListLogsByUser(ctx, cover.User.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to list notification logs: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Errorf("Expected the override user to get the handoff notification, got %d", len(logs))
+	}
+
+	logs, _ = testServer.NotificationService.ListLogsByUser(ctx, incoming.User.ID, 10, 0)
+	if len(logs) != 0 {
+		t.Errorf("Expected no notification for the replaced participant, got %d", len(logs))
+	}
+}
+
+// ============================================================================
+// Snooze expiry
+// ============================================================================
+
+func TestSnoozeExpiry_ReopensAlertAndNotifiesSnoozer(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	orgID := user.Organization.ID
+
+	if _, err := testFixtures.CreateNotificationChannel(ctx, orgID, "Email"); err != nil {
+		t.Fatalf("Failed to create channel: %v", err)
+	}
+
+	alert, _ := testFixtures.CreateAlert(ctx, orgID, "Disk almost full")
+	until := time.Now().Add(time.Hour)
+	reason := "Cleanup job scheduled"
+	if err := testServer.AlertService.SnoozeAlert(ctx, alert.ID, orgID, user.User.ID, until, &reason); err != nil {
+		t.Fatalf("Failed to snooze alert: %v", err)
+	}
+
+	// Before the snooze ends nothing reopens
+	if err := testServer.AlertService.ProcessExpiredSnoozes(ctx, time.Now(), 100); err != nil {
+		t.Fatalf("Failed to process snoozes: %v", err)
+	}
+	got, _ := testServer.AlertService.GetAlert(ctx, alert.ID, orgID)
+	if got.Status != domain.AlertStatusSnoozed {
+		t.Fatalf("Expected alert to stay snoozed, got %s", got.Status)
+	}
+
+	// Run the worker twice past the expiry; the snoozer hears about it once
+	for i := 0; i < 2; i++ {
+		if err := testServer.AlertService.ProcessExpiredSnoozes(ctx, until.Add(time.Minute), 100); err != nil {
+			t.Fatalf("Failed to process snoozes: %v", err)
+		}
+	}
+
+	got, _ = testServer.AlertService.GetAlert(ctx, alert.ID, orgID)
+	if got.Status != domain.AlertStatusOpen {
+		t.Errorf("Expected alert to reopen, got %s", got.Status)
+	}
+	if got.SnoozedUntil != nil {
+		t.Errorf("Expected snoozed_until to be cleared, got %v", got.SnoozedUntil)
+	}
+
+	logs, err := testServer.NotificationService.ListLogsByUser(ctx, user.User.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to list notification logs: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("Expected exactly 1 unsnooze notification, got %d", len(logs))
+	}
+	if logs[0].AlertID == nil || *logs[0].AlertID != alert.ID {
+		t.Errorf("Expected notification for alert %s, got %v", alert.ID, logs[0].AlertID)
+	}
+	if !strings.Contains(logs[0].Message, reason) {
+		t.Errorf("Expected message to include the snooze reason, got %q", logs[0].Message)
+	}
+}
+
+// ============================================================================
+// Alert auto-close
+// ============================================================================
+
+// backdateAlert makes an alert look untouched for the given duration. The
+// updated_at trigger is paused so the backdated value sticks.
+func backdateAlert(t *testing.T, id uuid.UUID, age time.Duration) {
+	t.Helper()
+	for _, stmt := range []string{
+		`ALTER TABLE alerts DISABLE TRIGGER update_alerts_updated_at`,
+		fmt.Sprintf(`UPDATE alerts SET updated_at = NOW() - INTERVAL '%d seconds' WHERE id = '%s'`, int(age.Seconds()), id),
+		`ALTER TABLE alerts ENABLE TRIGGER update_alerts_updated_at`,
+	} {
+		if _, err := testDB.Exec(stmt); err != nil {
+			t.Fatalf("Failed to backdate alert: %v", err)
+		}
+	}
+}
+
+func createAlertWithPriority(t *testing.T, orgID uuid.UUID, priority, message string) *domain.Alert {
+	t.Helper()
+	alert, err := testServer.AlertService.CreateAlert(context.Background(), orgID, &dto.CreateAlertRequest{
+		Source:   "test",
+		Priority: priority,
+		Message:  message,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create alert: %v", err)
+	}
+	return alert
+}
+
+func TestAlertAutoClose_ClosesStaleLowPriorityAlerts(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	resp := client.Patch("/api/v1/organizations/settings", map[string]interface{}{
+		"alert_auto_close": map[string]interface{}{"after_hours": 24},
+	})
+	client.AssertStatus(resp, http.StatusOK)
+
+	stale := createAlertWithPriority(t, orgID, "P4", "Stale low priority")
+	recent := createAlertWithPriority(t, orgID, "P4", "Recent low priority")
+	critical := createAlertWithPriority(t, orgID, "P1", "Stale critical")
+	backdateAlert(t, stale.ID, 48*time.Hour)
+	backdateAlert(t, critical.ID, 48*time.Hour)
+
+	if err := testServer.AlertService.AutoCloseStaleAlerts(ctx, time.Now(), 100); err != nil {
+		t.Fatalf("Failed to auto-close alerts: %v", err)
+	}
+
+	got, _ := testServer.AlertService.GetAlert(ctx, stale.ID, orgID)
+	if got.Status != domain.AlertStatusClosed {
+		t.Errorf("Expected stale P4 alert to be closed, got %s", got.Status)
+	}
+	if got.CloseReason == nil || *got.CloseReason != domain.AutoCloseReason {
+		t.Errorf("Expected close reason %q, got %v", domain.AutoCloseReason, got.CloseReason)
+	}
+	if got.ClosedBy != nil {
+		t.Errorf("Expected no closing user, got %v", got.ClosedBy)
+	}
+
+	got, _ = testServer.AlertService.GetAlert(ctx, recent.ID, orgID)
+	if got.Status != domain.AlertStatusOpen {
+		t.Errorf("Expected recent P4 alert to stay open, got %s", got.Status)
+	}
+
+	got, _ = testServer.AlertService.GetAlert(ctx, critical.ID, orgID)
+	if got.Status != domain.AlertStatusOpen {
+		t.Errorf("Expected P1 alert to be exempt by default, got %s", got.Status)
+	}
+}
+
+func TestAlertAutoClose_DisabledByDefault(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	orgID := user.Organization.ID
+
+	stale := createAlertWithPriority(t, orgID, "P5", "Stale informational")
+	backdateAlert(t, stale.ID, 30*24*time.Hour)
+
+	if err := testServer.AlertService.AutoCloseStaleAlerts(ctx, time.Now(), 100); err != nil {
+		t.Fatalf("Failed to auto-close alerts: %v", err)
+	}
+
+	got, _ := testServer.AlertService.GetAlert(ctx, stale.ID, orgID)
+	if got.Status != domain.AlertStatusOpen {
+		t.Errorf("Expected alert to stay open without a policy, got %s", got.Status)
+	}
+}
+
+// ============================================================================
+// Alert retention
+// ============================================================================
+
+// closedAlert creates an alert of the given priority that was closed age ago
+func closedAlert(t *testing.T, ctx context.Context, orgID, userID uuid.UUID, priority, message string, age time.Duration) *domain.Alert {
+	t.Helper()
+
+	alert := createAlertWithPriority(t, orgID, priority, message)
+	if err := testServer.AlertService.CloseAlert(ctx, alert.ID, orgID, userID, "resolved"); err != nil {
+		t.Fatalf("Failed to close alert: %v", err)
+	}
+	if _, err := testDB.ExecContext(ctx,
+		`UPDATE alerts SET closed_at = NOW() - make_interval(secs => $2) WHERE id = $1`,
+		alert.ID, int(age.Seconds()),
+	); err != nil {
+		t.Fatalf("Failed to backdate alert: %v", err)
+	}
+	return alert
+}
+
+func setAlertRetention(t *testing.T, client *testutils.TestClient, retention map[string]interface{}) {
+	t.Helper()
+
+	resp := client.Patch("/api/v1/organizations/settings", map[string]interface{}{
+		"alert_retention": retention,
+	})
+	client.AssertStatus(resp, http.StatusOK)
+}
+
+func alertExists(t *testing.T, ctx context.Context, table string, id uuid.UUID) bool {
+	t.Helper()
+
+	var exists bool
+	if err := testDB.QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE id = $1)`, table), id,
+	).Scan(&exists); err != nil {
+		t.Fatalf("Failed to look up alert in %s: %v", table, err)
+	}
+	return exists
+}
+
+func TestAlertRetention_ArchivesOldClosedAlerts(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	setAlertRetention(t, client, map[string]interface{}{
+		"after_days":        30,
+		"exempt_priorities": []string{"P1"},
+	})
+
+	old := closedAlert(t, ctx, orgID, user.User.ID, "P3", "Old closed", 40*24*time.Hour)
+	recent := closedAlert(t, ctx, orgID, user.User.ID, "P3", "Recently closed", 24*time.Hour)
+	exempt := closedAlert(t, ctx, orgID, user.User.ID, "P1", "Old closed critical", 40*24*time.Hour)
+	open := createAlertWithPriority(t, orgID, "P3", "Old but open")
+	backdateAlert(t, open.ID, 40*24*time.Hour)
+
+	linked := closedAlert(t, ctx, orgID, user.User.ID, "P3", "Old closed, part of an incident", 40*24*time.Hour)
+	incident, _ := testFixtures.CreateIncident(ctx, orgID, user.User.ID, "Outage")
+	if _, err := testServer.IncidentService.LinkAlert(ctx, incident.ID, orgID, user.User.ID, &dto.LinkAlertRequest{AlertID: linked.ID}); err != nil {
+		t.Fatalf("Failed to link alert: %v", err)
+	}
+
+	if err := testServer.AlertService.ApplyRetention(ctx, time.Now(), 100); err != nil {
+		t.Fatalf("Failed to apply retention: %v", err)
+	}
+
+	if alertExists(t, ctx, "alerts", old.ID) {
+		t.Error("Expected the old closed alert to be removed")
+	}
+	if !alertExists(t, ctx, "alerts_archive", old.ID) {
+		t.Error("Expected the old closed alert to be archived")
+	}
+	for _, kept := range []*domain.Alert{recent, exempt, open, linked} {
+		if !alertExists(t, ctx, "alerts", kept.ID) {
+			t.Errorf("Expected %q to be kept", kept.Message)
+		}
+		if alertExists(t, ctx, "alerts_archive", kept.ID) {
+			t.Errorf("Expected %q not to be archived", kept.Message)
+		}
+	}
+}
+
+func TestAlertRetention_DeletesWithoutArchive(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	setAlertRetention(t, client, map[string]interface{}{"after_days": 7, "archive": false})
+	old := closedAlert(t, ctx, orgID, user.User.ID, "P5", "Old closed", 8*24*time.Hour)
+
+	if err := testServer.AlertService.ApplyRetention(ctx, time.Now(), 100); err != nil {
+		t.Fatalf("Failed to apply retention: %v", err)
+	}
+
+	if alertExists(t, ctx, "alerts", old.ID) || alertExists(t, ctx, "alerts_archive", old.ID) {
+		t.Error("Expected the old closed alert to be deleted without an archived copy")
+	}
+}
+
+func TestAlertRetention_DryRunKeepsAlerts(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+
+	setAlertRetention(t, client, map[string]interface{}{"after_days": 7, "dry_run": true})
+	old := closedAlert(t, ctx, orgID, user.User.ID, "P5", "Old closed", 8*24*time.Hour)
+
+	if err := testServer.AlertService.ApplyRetention(ctx, time.Now(), 100); err != nil {
+		t.Fatalf("Failed to apply retention: %v", err)
+	}
+
+	if !alertExists(t, ctx, "alerts", old.ID) || alertExists(t, ctx, "alerts_archive", old.ID) {
+		t.Error("Expected a dry run to leave the alert in place")
+	}
+}
+
+func TestAlertRetention_DisabledByDefault(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	old := closedAlert(t, ctx, user.Organization.ID, user.User.ID, "P5", "Ancient closed", 365*24*time.Hour)
+
+	if err := testServer.AlertService.ApplyRetention(ctx, time.Now(), 100); err != nil {
+		t.Fatalf("Failed to apply retention: %v", err)
+	}
+
+	if !alertExists(t, ctx, "alerts", old.ID) {
+		t.Error("Expected alerts to be kept without a retention policy")
+	}
+}
+
+func TestAlertRetention_DeletesSpentAckTokens(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	orgID := user.Organization.ID
+
+	issue := func(message string) string {
+		alert, _ := testFixtures.CreateAlert(ctx, orgID, message)
+		token, err := testServer.AlertService.IssueAckToken(ctx, alert, user.User.ID)
+		if err != nil {
+			t.Fatalf("Failed to issue ack token: %v", err)
+		}
+		return token
+	}
+
+	used := issue("Used link")
+	if _, err := testServer.AlertService.AcknowledgeWithToken(ctx, used); err != nil {
+		t.Fatalf("Failed to use ack token: %v", err)
+	}
+	issue("Expired link")
+	if _, err := testDB.ExecContext(ctx, `
+		UPDATE alert_ack_tokens SET expires_at = NOW() - INTERVAL '1 minute'
+		WHERE alert_id IN (SELECT id FROM alerts WHERE message = 'Expired link')
+	`); err != nil {
+		t.Fatalf("Failed to expire token: %v", err)
+	}
+	live := issue("Live link")
+
+	if err := testServer.AlertService.ApplyRetention(ctx, time.Now(), 1); err != nil {
+		t.Fatalf("Failed to apply retention: %v", err)
+	}
+
+	var remaining int
+	if err := testDB.QueryRowContext(ctx, `SELECT COUNT(*) FROM alert_ack_tokens`).Scan(&remaining); err != nil {
+		t.Fatalf("Failed to count ack tokens: %v", err)
+	}
+	if remaining != 1 {
+		t.Errorf("Expected only the live token to be kept, got %d tokens", remaining)
+	}
+	if _, err := testServer.AlertService.AcknowledgeWithToken(ctx, live); err != nil {
+		t.Errorf("Expected the live token to still work, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nmn3m/pulsar/backend/internal/core/dto"
+)
+
+// ============================================================================
+// GET /api/v1/me/workload
+// ============================================================================
+
+func TestWorkload_Get(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+	orgID := user.Organization.ID
+	userID := user.User.ID
+
+	assigned, _ := testFixtures.CreateAlert(ctx, orgID, "Assigned to me")
+	if err := testServer.AlertService.AssignAlert(ctx, assigned.ID, orgID, userID, &dto.AssignAlertRequest{UserID: &userID}); err != nil {
+		t.Fatalf("Failed to assign alert: %v", err)
+	}
+	testFixtures.CreateAlert(ctx, orgID, "Unassigned")
+
+	responding, _ := testFixtures.CreateIncident(ctx, orgID, userID, "Responding")
+	if _, err := testServer.IncidentService.AddResponder(ctx, responding.ID, orgID, userID, &dto.AddResponderRequest{UserID: userID, Role: "responder"}); err != nil {
+		t.Fatalf("Failed to add responder: %v", err)
+	}
+	testFixtures.CreateIncident(ctx, orgID, userID, "Someone else's")
+
+	schedule, _ := testFixtures.CreateUniqueSchedule(ctx, orgID)
+	addRotation(t, ctx, schedule.ID, userID, "2024-01-01")
+	testFixtures.CreateUniqueSchedule(ctx, orgID)
+
+	resp := client.Get("/api/v1/me/workload")
+	client.ExpectStatus(resp, http.StatusOK)
+	var workload dto.WorkloadResponse
+	client.ParseJSON(resp, &workload)
+
+	if len(workload.Alerts) != 1 || workload.Alerts[0].ID != assigned.ID || workload.AlertsTotal != 1 {
+		t.Errorf("Expected only the assigned alert, got %d (total %d)", len(workload.Alerts), workload.AlertsTotal)
+	}
+	if len(workload.Incidents) != 1 || workload.Incidents[0].ID != responding.ID || workload.IncidentsTotal != 1 {
+		t.Errorf("Expected only the incident being responded to, got %d (total %d)", len(workload.Incidents), workload.IncidentsTotal)
+	}
+	if !workload.OnCall {
+		t.Error("Expected the user to be on call")
+	}
+	if len(workload.OnCallSchedules) != 1 || workload.OnCallSchedules[0].Schedule.ID != schedule.ID {
+		t.Errorf("Expected only schedule %s, got %d schedules", schedule.ID, len(workload.OnCallSchedules))
+	}
+}
+
+func TestWorkload_Get_NothingAssigned(t *testing.T) {
+	cleanDatabase(t)
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	user, _ := testFixtures.CreateUniqueUser(ctx)
+	client.SetAuthToken(user.AccessToken)
+
+	resp := client.Get("/api/v1/me/workload")
+	client.ExpectStatus(resp, http.StatusOK)
+	var workload map[string]interface{}
+	client.ParseJSON(resp, &workload)
+
+	for _, key := range []string{"alerts", "incidents", "on_call_schedules"} {
+		if list, ok := workload[key].([]interface{}); !ok || len(list) != 0 {
+			t.Errorf("Expected an empty %s list, got %v", key, workload[key])
+		}
+	}
+	if workload["on_call"] != false {
+		t.Errorf("Expected on_call false, got %v", workload["on_call"])
+	}
+}
+
+func TestWorkload_Get_Unauthorized(t *testing.T) {
+	client := newTestClient(t)
+
+	resp := client.Get("/api/v1/me/workload")
+	client.ExpectStatus(resp, http.StatusUnauthorized)
+}
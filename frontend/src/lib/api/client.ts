@@ -6,12 +6,25 @@ import type {
   User,
   VerifyEmailRequest,
   ResendOTPRequest,
+  ListUsersParams,
+  ListUsersResponse,
+  UserDeactivation,
+  Session,
+  RevokeSessionsResponse,
+  OrganizationMembership,
 } from '$lib/types/user';
 import type {
+  AddAlertNoteRequest,
   Alert,
+  AlertNote,
+  AlertNoteWithUser,
+  AlertPriority,
   AssignAlertRequest,
+  BulkTagAlertsRequest,
   CloseAlertRequest,
   CreateAlertRequest,
+  GroupAlertsParams,
+  GroupAlertsResponse,
   ListAlertsParams,
   ListAlertsResponse,
   SnoozeAlertRequest,
@@ -25,13 +38,16 @@ import type {
   AddResponderRequest,
   UpdateResponderRoleRequest,
   AddNoteRequest,
+  UpdateNoteRequest,
   LinkAlertRequest,
   ListIncidentsParams,
   ListIncidentsResponse,
   ResponderWithUser,
   TimelineEventWithUser,
+  TimelineEventType,
   IncidentAlertWithDetails,
   IncidentTimelineEvent,
+  SuggestedAlert,
 } from '$lib/types/incident';
 import type {
   Team,
@@ -50,12 +66,15 @@ import type {
   ScheduleRotation,
   ScheduleOverride,
   OnCallUser,
+  ScheduleCoverage,
+  ListScheduleOnCallResponse,
   CreateScheduleRequest,
   UpdateScheduleRequest,
   CreateRotationRequest,
   UpdateRotationRequest,
   AddParticipantRequest,
   ReorderParticipantsRequest,
+  SetParticipantsRequest,
   CreateOverrideRequest,
   UpdateOverrideRequest,
   ListSchedulesResponse,
@@ -70,12 +89,14 @@ import type {
   EscalationTarget,
   CreateEscalationPolicyRequest,
   UpdateEscalationPolicyRequest,
+  CloneEscalationPolicyRequest,
   CreateEscalationRuleRequest,
   UpdateEscalationRuleRequest,
   AddEscalationTargetRequest,
   ListEscalationPoliciesResponse,
   ListEscalationRulesResponse,
   ListEscalationTargetsResponse,
+  EscalationPolicyPreview,
 } from '$lib/types/escalation';
 import type {
   NotificationChannel,
@@ -83,16 +104,20 @@ import type {
   NotificationLog,
   CreateNotificationChannelRequest,
   UpdateNotificationChannelRequest,
+  TestNotificationChannelRequest,
+  TestNotificationChannelResponse,
   CreateUserNotificationPreferenceRequest,
   UpdateUserNotificationPreferenceRequest,
   SendNotificationRequest,
   ListNotificationChannelsResponse,
   ListUserNotificationPreferencesResponse,
+  ListNotificationLogsParams,
   ListNotificationLogsResponse,
 } from '$lib/types/notification';
 import type {
   WebhookEndpoint,
   IncomingWebhookToken,
+  IncomingWebhookRequest,
   CreateWebhookEndpointRequest,
   UpdateWebhookEndpointRequest,
   CreateIncomingWebhookTokenRequest,
@@ -120,12 +145,26 @@ import type {
   UpdateRoutingRuleRequest,
   ReorderRoutingRulesRequest,
 } from '$lib/types/routing';
+import type {
+  MaintenanceWindow,
+  CreateMaintenanceWindowRequest,
+  UpdateMaintenanceWindowRequest,
+} from '$lib/types/maintenance';
+import type {
+  AlertSource,
+  AlertSourceWithCount,
+  CreateAlertSourceRequest,
+  UpdateAlertSourceRequest,
+} from '$lib/types/source';
 import type {
   DNDSettings,
   UpdateDNDSettingsRequest,
   AddDNDOverrideRequest,
   DNDStatusResponse,
 } from '$lib/types/dnd';
+import type { UserUnavailability, AddUnavailabilityRequest } from '$lib/types/availability';
+import type { EnumValue } from '$lib/types/meta';
+import type { Workload } from '$lib/types/workload';
 
 const API_URL = browser
   ? import.meta.env.VITE_API_URL || 'http://localhost:8080'
@@ -300,6 +339,40 @@ class APIClient {
     return this.request<User>('/api/v1/auth/me');
   }
 
+  async listMyOrganizations(): Promise<OrganizationMembership[]> {
+    return this.request<OrganizationMembership[]>('/api/v1/me/organizations');
+  }
+
+  async switchOrganization(organizationId: string): Promise<AuthResponse> {
+    const response = await this.request<AuthResponse>('/api/v1/auth/switch-organization', {
+      method: 'POST',
+      body: JSON.stringify({ organization_id: organizationId }),
+    });
+
+    this.setAccessToken(response.access_token);
+    if (browser) {
+      localStorage.setItem('refresh_token', response.refresh_token);
+    }
+
+    return response;
+  }
+
+  async listSessions(): Promise<Session[]> {
+    return this.request<Session[]>('/api/v1/auth/sessions');
+  }
+
+  async revokeSession(id: string): Promise<{ message: string }> {
+    return this.request<{ message: string }>(`/api/v1/auth/sessions/${id}`, {
+      method: 'DELETE',
+    });
+  }
+
+  async revokeAllSessions(): Promise<RevokeSessionsResponse> {
+    return this.request<RevokeSessionsResponse>('/api/v1/auth/sessions', {
+      method: 'DELETE',
+    });
+  }
+
   async verifyEmail(data: VerifyEmailRequest): Promise<{ message: string }> {
     return this.request<{ message: string }>('/api/v1/auth/verify-email', {
       method: 'POST',
@@ -315,14 +388,65 @@ class APIClient {
   }
 
   // User endpoints
-  async listUsers(): Promise<{ users: User[] }> {
-    return this.request<{ users: User[] }>('/api/v1/users');
+  async listUsers(params?: ListUsersParams): Promise<ListUsersResponse> {
+    const queryParams = new URLSearchParams();
+
+    if (params?.search) {
+      queryParams.append('search', params.search);
+    }
+    if (params?.team_id) {
+      queryParams.append('team_id', params.team_id);
+    }
+    if (params?.is_active !== undefined) {
+      queryParams.append('is_active', params.is_active.toString());
+    }
+    if (params?.page) {
+      queryParams.append('page', params.page.toString());
+    }
+    if (params?.page_size) {
+      queryParams.append('page_size', params.page_size.toString());
+    }
+
+    const query = queryParams.toString();
+    const url = query ? `/api/v1/users?${query}` : '/api/v1/users';
+
+    return this.request<ListUsersResponse>(url);
+  }
+
+  async deactivateUser(id: string): Promise<UserDeactivation> {
+    return this.request<UserDeactivation>(`/api/v1/users/${id}/deactivate`, {
+      method: 'POST',
+    });
+  }
+
+  async reactivateUser(id: string): Promise<User> {
+    return this.request<User>(`/api/v1/users/${id}/reactivate`, {
+      method: 'POST',
+    });
+  }
+
+  async unlockUser(id: string): Promise<{ message: string }> {
+    return this.request<{ message: string }>(`/api/v1/users/${id}/unlock`, {
+      method: 'POST',
+    });
+  }
+
+  async listUserSessions(id: string): Promise<Session[]> {
+    return this.request<Session[]>(`/api/v1/users/${id}/sessions`);
+  }
+
+  async revokeUserSessions(id: string): Promise<RevokeSessionsResponse> {
+    return this.request<RevokeSessionsResponse>(`/api/v1/users/${id}/sessions`, {
+      method: 'DELETE',
+    });
   }
 
   async updateProfile(data: {
     full_name?: string;
     phone?: string;
     timezone?: string;
+    // Less urgent alerts page only the primary channel; '' turns it off
+    primary_channel_only_below?: AlertPriority | '';
   }): Promise<User> {
     return this.request<User>('/api/v1/users/me', {
       method: 'PATCH',
@@ -365,6 +489,21 @@ class APIClient {
     return this.request<ListAlertsResponse>(url);
   }
 
+  async groupAlerts(params: GroupAlertsParams): Promise<GroupAlertsResponse> {
+    const queryParams = new URLSearchParams({ by: params.by });
+    if (params.tag) {
+      queryParams.append('tag', params.tag);
+    }
+    if (params.prefix_length) {
+      queryParams.append('prefix_length', params.prefix_length.toString());
+    }
+    if (params.limit) {
+      queryParams.append('limit', params.limit.toString());
+    }
+
+    return this.request<GroupAlertsResponse>(`/api/v1/alerts/grouped?${queryParams.toString()}`);
+  }
+
   async createAlert(data: CreateAlertRequest): Promise<Alert> {
     return this.request<Alert>('/api/v1/alerts', {
       method: 'POST',
@@ -376,6 +515,10 @@ class APIClient {
     return this.request<Alert>(`/api/v1/alerts/${id}`);
   }
 
+  async getAlertOnCallAtCreation(id: string): Promise<ListScheduleOnCallResponse> {
+    return this.request<ListScheduleOnCallResponse>(`/api/v1/alerts/${id}/oncall-at-creation`);
+  }
+
   async updateAlert(id: string, data: UpdateAlertRequest): Promise<Alert> {
     return this.request<Alert>(`/api/v1/alerts/${id}`, {
       method: 'PATCH',
@@ -383,6 +526,13 @@ class APIClient {
     });
   }
 
+  async bulkTagAlerts(data: BulkTagAlertsRequest): Promise<{ alerts: Alert[] }> {
+    return this.request<{ alerts: Alert[] }>('/api/v1/alerts/tags', {
+      method: 'POST',
+      body: JSON.stringify(data),
+    });
+  }
+
   async deleteAlert(id: string): Promise<void> {
     await this.request(`/api/v1/alerts/${id}`, {
       method: 'DELETE',
@@ -395,6 +545,12 @@ class APIClient {
     });
   }
 
+  async unacknowledgeAlert(id: string): Promise<void> {
+    await this.request(`/api/v1/alerts/${id}/unacknowledge`, {
+      method: 'POST',
+    });
+  }
+
   async closeAlert(id: string, data: CloseAlertRequest): Promise<void> {
     await this.request(`/api/v1/alerts/${id}/close`, {
       method: 'POST',
@@ -416,6 +572,17 @@ class APIClient {
     });
   }
 
+  async addAlertNote(id: string, data: AddAlertNoteRequest): Promise<AlertNote> {
+    return this.request<AlertNote>(`/api/v1/alerts/${id}/notes`, {
+      method: 'POST',
+      body: JSON.stringify(data),
+    });
+  }
+
+  async listAlertNotes(id: string): Promise<AlertNoteWithUser[]> {
+    return this.request<AlertNoteWithUser[]>(`/api/v1/alerts/${id}/notes`);
+  }
+
   // Team endpoints
   async listTeams(page = 1, pageSize = 20): Promise<{ teams: Team[] }> {
     return this.request<{ teams: Team[] }>(`/api/v1/teams?page=${page}&page_size=${pageSize}`);
@@ -532,6 +699,20 @@ class APIClient {
     return this.request<OnCallUser>(`/api/v1/schedules/${scheduleId}/oncall${params}`);
   }
 
+  async getScheduleCoverage(
+    scheduleId: string,
+    start?: string,
+    end?: string
+  ): Promise<ScheduleCoverage> {
+    const params = new URLSearchParams();
+    if (start) params.append('start', start);
+    if (end) params.append('end', end);
+    const queryString = params.toString();
+    return this.request<ScheduleCoverage>(
+      `/api/v1/schedules/${scheduleId}/coverage${queryString ? '?' + queryString : ''}`
+    );
+  }
+
   // Rotation endpoints
   async listRotations(scheduleId: string): Promise<ListRotationsResponse> {
     return this.request<ListRotationsResponse>(`/api/v1/schedules/${scheduleId}/rotations`);
@@ -614,6 +795,20 @@ class APIClient {
     );
   }
 
+  async setParticipants(
+    scheduleId: string,
+    rotationId: string,
+    data: SetParticipantsRequest
+  ): Promise<ListParticipantsResponse> {
+    return this.request<ListParticipantsResponse>(
+      `/api/v1/schedules/${scheduleId}/rotations/${rotationId}/participants/bulk`,
+      {
+        method: 'POST',
+        body: JSON.stringify(data),
+      }
+    );
+  }
+
   // Override endpoints
   async listOverrides(
     scheduleId: string,
@@ -696,6 +891,21 @@ class APIClient {
     });
   }
 
+  async cloneEscalationPolicy(
+    id: string,
+    data: CloneEscalationPolicyRequest = {}
+  ): Promise<EscalationPolicyWithRules> {
+    return this.request<EscalationPolicyWithRules>(`/api/v1/escalation-policies/${id}/clone`, {
+      method: 'POST',
+      body: JSON.stringify(data),
+    });
+  }
+
+  async previewEscalationPolicy(id: string, at?: string): Promise<EscalationPolicyPreview> {
+    const params = at ? `?at=${encodeURIComponent(at)}` : '';
+    return this.request<EscalationPolicyPreview>(`/api/v1/escalation-policies/${id}/preview${params}`);
+  }
+
   // Escalation rule endpoints
   async listEscalationRules(policyId: string): Promise<ListEscalationRulesResponse> {
     return this.request<ListEscalationRulesResponse>(
@@ -802,6 +1012,19 @@ class APIClient {
     });
   }
 
+  async testNotificationChannel(
+    id: string,
+    data: TestNotificationChannelRequest = {}
+  ): Promise<TestNotificationChannelResponse> {
+    return this.request<TestNotificationChannelResponse>(
+      `/api/v1/notifications/channels/${id}/test`,
+      {
+        method: 'POST',
+        body: JSON.stringify(data),
+      }
+    );
+  }
+
   // ==================== User Notification Preferences ====================
 
   async listUserNotificationPreferences(): Promise<ListUserNotificationPreferencesResponse> {
@@ -851,12 +1074,15 @@ class APIClient {
   // ==================== Notification Logs ====================
 
   async listNotificationLogs(
-    limit?: number,
-    offset?: number
+    filters?: ListNotificationLogsParams
   ): Promise<ListNotificationLogsResponse> {
     const params = new URLSearchParams();
-    if (limit) params.append('limit', limit.toString());
-    if (offset) params.append('offset', offset.toString());
+    filters?.status?.forEach((status) => params.append('status', status));
+    if (filters?.channel_id) params.append('channel_id', filters.channel_id);
+    if (filters?.from) params.append('from', filters.from);
+    if (filters?.to) params.append('to', filters.to);
+    if (filters?.limit) params.append('limit', filters.limit.toString());
+    if (filters?.offset) params.append('offset', filters.offset.toString());
 
     const queryString = params.toString();
     const endpoint = queryString
@@ -978,8 +1204,16 @@ class APIClient {
   }
 
   // Incident timeline
-  async getIncidentTimeline(incidentId: string): Promise<TimelineEventWithUser[]> {
-    return this.request<TimelineEventWithUser[]>(`/api/v1/incidents/${incidentId}/timeline`);
+  async getIncidentTimeline(
+    incidentId: string,
+    eventTypes?: TimelineEventType[]
+  ): Promise<TimelineEventWithUser[]> {
+    const queryParams = new URLSearchParams();
+    eventTypes?.forEach((eventType) => queryParams.append('event_type', eventType));
+    const query = queryParams.toString();
+    return this.request<TimelineEventWithUser[]>(
+      `/api/v1/incidents/${incidentId}/timeline${query ? `?${query}` : ''}`
+    );
   }
 
   async addIncidentNote(incidentId: string, data: AddNoteRequest): Promise<IncidentTimelineEvent> {
@@ -989,6 +1223,23 @@ class APIClient {
     });
   }
 
+  async updateIncidentNote(
+    incidentId: string,
+    eventId: string,
+    data: UpdateNoteRequest
+  ): Promise<IncidentTimelineEvent> {
+    return this.request<IncidentTimelineEvent>(`/api/v1/incidents/${incidentId}/notes/${eventId}`, {
+      method: 'PATCH',
+      body: JSON.stringify(data),
+    });
+  }
+
+  async deleteIncidentNote(incidentId: string, eventId: string): Promise<void> {
+    await this.request(`/api/v1/incidents/${incidentId}/notes/${eventId}`, {
+      method: 'DELETE',
+    });
+  }
+
   // Incident alerts
   async listIncidentAlerts(incidentId: string): Promise<IncidentAlertWithDetails[]> {
     return this.request<IncidentAlertWithDetails[]>(`/api/v1/incidents/${incidentId}/alerts`);
@@ -1010,6 +1261,10 @@ class APIClient {
     });
   }
 
+  async listSuggestedIncidentAlerts(incidentId: string): Promise<SuggestedAlert[]> {
+    return this.request<SuggestedAlert[]>(`/api/v1/incidents/${incidentId}/suggested-alerts`);
+  }
+
   // Webhook Endpoints
   async listWebhookEndpoints(): Promise<WebhookEndpoint[]> {
     return this.request<WebhookEndpoint[]>('/api/v1/webhooks/endpoints');
@@ -1042,6 +1297,12 @@ class APIClient {
     });
   }
 
+  async rotateWebhookSecret(id: string): Promise<WebhookEndpoint> {
+    return this.request<WebhookEndpoint>(`/api/v1/webhooks/endpoints/${id}/rotate-secret`, {
+      method: 'POST',
+    });
+  }
+
   // Webhook Deliveries
   async listWebhookDeliveries(
     limit?: number,
@@ -1076,6 +1337,16 @@ class APIClient {
     });
   }
 
+  async rotateIncomingWebhookToken(id: string): Promise<IncomingWebhookToken> {
+    return this.request<IncomingWebhookToken>(`/api/v1/webhooks/incoming/${id}/rotate`, {
+      method: 'POST',
+    });
+  }
+
+  async listIncomingWebhookRequests(id: string): Promise<IncomingWebhookRequest[]> {
+    return this.request<IncomingWebhookRequest[]>(`/api/v1/webhooks/incoming/${id}/requests`);
+  }
+
   // ==================== Metrics ====================
 
   async getDashboardMetrics(filter?: MetricsFilter): Promise<DashboardMetrics> {
@@ -1239,6 +1510,70 @@ class APIClient {
     });
   }
 
+  // ==================== Maintenance Windows ====================
+
+  async listMaintenanceWindows(
+    page = 1,
+    pageSize = 50
+  ): Promise<{ windows: MaintenanceWindow[] }> {
+    return this.request<{ windows: MaintenanceWindow[] }>(
+      `/api/v1/maintenance-windows?page=${page}&page_size=${pageSize}`
+    );
+  }
+
+  async createMaintenanceWindow(data: CreateMaintenanceWindowRequest): Promise<MaintenanceWindow> {
+    return this.request<MaintenanceWindow>('/api/v1/maintenance-windows', {
+      method: 'POST',
+      body: JSON.stringify(data),
+    });
+  }
+
+  async getMaintenanceWindow(id: string): Promise<MaintenanceWindow> {
+    return this.request<MaintenanceWindow>(`/api/v1/maintenance-windows/${id}`);
+  }
+
+  async updateMaintenanceWindow(
+    id: string,
+    data: UpdateMaintenanceWindowRequest
+  ): Promise<MaintenanceWindow> {
+    return this.request<MaintenanceWindow>(`/api/v1/maintenance-windows/${id}`, {
+      method: 'PATCH',
+      body: JSON.stringify(data),
+    });
+  }
+
+  async deleteMaintenanceWindow(id: string): Promise<void> {
+    await this.request(`/api/v1/maintenance-windows/${id}`, {
+      method: 'DELETE',
+    });
+  }
+
+  // ==================== Alert Sources ====================
+
+  async listAlertSources(): Promise<{ sources: AlertSourceWithCount[] }> {
+    return this.request<{ sources: AlertSourceWithCount[] }>('/api/v1/sources');
+  }
+
+  async createAlertSource(data: CreateAlertSourceRequest): Promise<AlertSource> {
+    return this.request<AlertSource>('/api/v1/sources', {
+      method: 'POST',
+      body: JSON.stringify(data),
+    });
+  }
+
+  async updateAlertSource(id: string, data: UpdateAlertSourceRequest): Promise<AlertSource> {
+    return this.request<AlertSource>(`/api/v1/sources/${id}`, {
+      method: 'PATCH',
+      body: JSON.stringify(data),
+    });
+  }
+
+  async deleteAlertSource(id: string): Promise<void> {
+    await this.request(`/api/v1/sources/${id}`, {
+      method: 'DELETE',
+    });
+  }
+
   // ==================== DND (Do Not Disturb) ====================
 
   async getDNDSettings(): Promise<DNDSettings> {
@@ -1275,6 +1610,47 @@ class APIClient {
       method: 'DELETE',
     });
   }
+
+  // ==================== Availability ====================
+
+  async listUnavailability(): Promise<{ unavailability: UserUnavailability[] }> {
+    return this.request<{ unavailability: UserUnavailability[] }>('/api/v1/users/me/unavailability');
+  }
+
+  async addUnavailability(data: AddUnavailabilityRequest): Promise<UserUnavailability> {
+    return this.request<UserUnavailability>('/api/v1/users/me/unavailability', {
+      method: 'POST',
+      body: JSON.stringify(data),
+    });
+  }
+
+  async removeUnavailability(id: string): Promise<void> {
+    await this.request(`/api/v1/users/me/unavailability/${id}`, {
+      method: 'DELETE',
+    });
+  }
+
+  // ==================== Meta ====================
+
+  async getPriorities(): Promise<{ priorities: EnumValue[] }> {
+    return this.request<{ priorities: EnumValue[] }>('/api/v1/meta/priorities');
+  }
+
+  async getSeverities(): Promise<{ severities: EnumValue[] }> {
+    return this.request<{ severities: EnumValue[] }>('/api/v1/meta/severities');
+  }
+
+  async getStatuses(): Promise<{ alert_statuses: EnumValue[]; incident_statuses: EnumValue[] }> {
+    return this.request<{ alert_statuses: EnumValue[]; incident_statuses: EnumValue[] }>(
+      '/api/v1/meta/statuses'
+    );
+  }
+
+  // ==================== Workload ====================
+
+  async getMyWorkload(): Promise<Workload> {
+    return this.request<Workload>('/api/v1/me/workload');
+  }
 }
 
 export const api = new APIClient(API_URL);
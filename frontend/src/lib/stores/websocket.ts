@@ -8,6 +8,8 @@ export type WSEventType =
   | 'alert.acknowledged'
   | 'alert.closed'
   | 'alert.escalated'
+  | 'alert.snoozed'
+  | 'alert.assigned'
   | 'incident.created'
   | 'incident.updated'
   | 'incident.deleted'
@@ -19,7 +21,8 @@ export type WSEventType =
   | 'connection.connected'
   | 'connection.error'
   | 'connection.ping'
-  | 'connection.pong';
+  | 'connection.pong'
+  | 'connection.resync';
 
 export interface WSMessage {
   id: string;
@@ -49,6 +52,7 @@ function createWebSocketStore() {
   let socket: WebSocket | null = null;
   let reconnectTimeout: ReturnType<typeof setTimeout> | null = null;
   let reconnectAttempts = 0;
+  let lastEventId: string | null = null; // replay cursor for reconnects
   const maxReconnectAttempts = 5;
   const reconnectDelay = 3000; // 3 seconds
 
@@ -64,7 +68,11 @@ function createWebSocketStore() {
     // Get token from localStorage
     const token = localStorage.getItem('token');
 
-    return `${protocol}//${host}:${port}/api/v1/ws?token=${token}`;
+    let url = `${protocol}//${host}:${port}/api/v1/ws?token=${token}`;
+    if (lastEventId) {
+      url += `&last_event_id=${encodeURIComponent(lastEventId)}`;
+    }
+    return url;
   }
 
   function connect() {
@@ -95,6 +103,13 @@ function createWebSocketStore() {
         try {
           const message: WSMessage = JSON.parse(event.data);
 
+          // Remember broadcast events so a reconnect can replay what we missed
+          if (!message.type.startsWith('connection.')) {
+            lastEventId = message.id;
+          } else if (message.type === 'connection.resync') {
+            lastEventId = null;
+          }
+
           // Update last message
           update((state) => ({ ...state, lastMessage: message }));
 
@@ -164,6 +179,7 @@ function createWebSocketStore() {
     }
 
     reconnectAttempts = 0;
+    lastEventId = null;
     update((state) => ({ ...state, status: 'disconnected', error: null }));
   }
 
@@ -1,3 +1,5 @@
+import type { User } from './user';
+
 export type AlertPriority = 'P1' | 'P2' | 'P3' | 'P4' | 'P5';
 export type AlertStatus = 'open' | 'acknowledged' | 'closed' | 'snoozed';
 export type AlertSource = 'webhook' | 'api' | 'email' | 'integration' | 'manual';
@@ -29,6 +31,11 @@ export interface Alert {
 
   // Snooze
   snoozed_until?: string;
+  snoozed_by?: string;
+  snooze_reason?: string;
+
+  // Suppression
+  maintenance_window_id?: string;
 
   // Escalation
   escalation_policy_id?: string;
@@ -47,27 +54,42 @@ export interface CreateAlertRequest {
   description?: string;
   tags?: string[];
   custom_fields?: Record<string, unknown>;
+  escalation_policy_id?: string;
 }
 
 export interface UpdateAlertRequest {
   priority?: AlertPriority;
   message?: string;
   description?: string;
-  tags?: string[];
+  tags?: string[]; // replaces the tags; omit to keep them
+  add_tags?: string[];
+  remove_tags?: string[];
   custom_fields?: Record<string, unknown>;
 }
 
+export interface BulkTagAlertsRequest {
+  ids: string[];
+  add?: string[];
+  remove?: string[];
+}
+
 export interface CloseAlertRequest {
   reason: string;
 }
 
 export interface SnoozeAlertRequest {
   until: string; // ISO 8601 date string
+  reason?: string;
 }
 
 export interface AssignAlertRequest {
   user_id?: string;
   team_id?: string;
+  // Assigning a user keeps the current team (and vice versa) unless cleared
+  clear_user?: boolean;
+  clear_team?: boolean;
+  // Switches the escalation policy and restarts escalation from the first rule
+  escalation_policy_id?: string;
 }
 
 export interface ListAlertsParams {
@@ -87,3 +109,43 @@ export interface ListAlertsResponse {
   page: number;
   page_size: number;
 }
+
+export type AlertGroupBy = 'source' | 'tag' | 'fingerprint';
+
+export interface GroupAlertsParams {
+  by: AlertGroupBy;
+  tag?: string; // tag key, e.g. "service" for service:api; required with by=tag
+  prefix_length?: number; // dedup key characters; the whole key when omitted
+  limit?: number;
+}
+
+// Open alerts sharing a key; the key is empty for alerts without the tag or dedup key
+export interface AlertGroup {
+  key: string;
+  count: number;
+  highest_priority: AlertPriority;
+  first_alert_at: string;
+  last_alert_at: string;
+  representatives: Alert[];
+}
+
+export interface GroupAlertsResponse {
+  by: AlertGroupBy;
+  groups: AlertGroup[];
+}
+
+export interface AlertNote {
+  id: string;
+  alert_id: string;
+  user_id?: string;
+  note: string;
+  created_at: string;
+}
+
+export interface AlertNoteWithUser extends AlertNote {
+  user?: User;
+}
+
+export interface AddAlertNoteRequest {
+  note: string;
+}
@@ -0,0 +1,16 @@
+// User availability (PTO / out of office) Types
+
+export interface UserUnavailability {
+  id: string;
+  user_id: string;
+  start_time: string; // ISO date string
+  end_time: string; // ISO date string
+  reason?: string;
+  created_at: string;
+}
+
+export interface AddUnavailabilityRequest {
+  start: string; // ISO date string
+  end: string; // ISO date string
+  reason?: string;
+}
@@ -1,5 +1,25 @@
+import type { AlertPriority } from './alert';
+import type { RoutingConditions } from './routing';
+
 export type EscalationTargetType = 'user' | 'team' | 'schedule';
 
+// Who a schedule target pages: the current on-call user or every rotation member
+export type ScheduleTargetMode = 'on_call' | 'all_members';
+
+// When a rule takes part in escalation, relative to its policy's business hours
+export type EscalationRuleHours = 'always' | 'business_hours' | 'after_hours';
+
+export interface BusinessHoursSlot {
+  day: string; // monday ... sunday
+  start: string; // HH:MM
+  end: string; // HH:MM, exclusive
+}
+
+export interface BusinessHours {
+  weekly: BusinessHoursSlot[];
+  timezone?: string; // defaults to the organization's timezone
+}
+
 export interface EscalationPolicy {
   id: string;
   organization_id: string;
@@ -7,6 +27,8 @@ export interface EscalationPolicy {
   description?: string;
   repeat_enabled: boolean;
   repeat_count?: number;
+  conditions?: RoutingConditions | null;
+  business_hours?: BusinessHours | null;
   created_at: string;
   updated_at: string;
 }
@@ -15,7 +37,12 @@ export interface EscalationRule {
   id: string;
   policy_id: string;
   position: number;
-  escalation_delay: number;
+  escalation_delay: number; // minutes
+  delay_seconds?: number | null; // replaces escalation_delay when set
+  hours: EscalationRuleHours;
+  // Checked when escalation reaches the rule; the rule is skipped when unmet
+  min_priority?: AlertPriority | null;
+  only_if_unassigned: boolean;
   created_at: string;
   updated_at: string;
 }
@@ -25,6 +52,7 @@ export interface EscalationTarget {
   rule_id: string;
   target_type: EscalationTargetType;
   target_id: string;
+  schedule_mode?: ScheduleTargetMode; // schedule targets only
   notification_channels?: TargetNotificationConfig;
   created_at: string;
 }
@@ -50,6 +78,8 @@ export interface CreateEscalationPolicyRequest {
   description?: string;
   repeat_enabled?: boolean;
   repeat_count?: number;
+  conditions?: RoutingConditions;
+  business_hours?: BusinessHours;
 }
 
 export interface UpdateEscalationPolicyRequest {
@@ -57,21 +87,36 @@ export interface UpdateEscalationPolicyRequest {
   description?: string;
   repeat_enabled?: boolean;
   repeat_count?: number;
+  conditions?: RoutingConditions | null; // null removes the conditions
+  business_hours?: BusinessHours | null; // null removes the business hours
+}
+
+export interface CloneEscalationPolicyRequest {
+  name?: string; // defaults to "<original name> (copy)"
 }
 
 export interface CreateEscalationRuleRequest {
   position: number;
-  escalation_delay: number;
+  escalation_delay?: number; // required unless delay_seconds is given
+  delay_seconds?: number;
+  hours?: EscalationRuleHours; // defaults to always
+  min_priority?: AlertPriority; // omitted matches every priority
+  only_if_unassigned?: boolean;
 }
 
 export interface UpdateEscalationRuleRequest {
   position?: number;
   escalation_delay?: number;
+  delay_seconds?: number;
+  hours?: EscalationRuleHours;
+  min_priority?: AlertPriority | ''; // empty string clears it
+  only_if_unassigned?: boolean;
 }
 
 export interface AddEscalationTargetRequest {
   target_type: EscalationTargetType;
   target_id: string;
+  schedule_mode?: ScheduleTargetMode; // defaults to on_call for schedule targets
   notification_channels?: TargetNotificationConfig;
 }
 
@@ -88,3 +133,40 @@ export interface ListEscalationRulesResponse {
 export interface ListEscalationTargetsResponse {
   targets: EscalationTarget[];
 }
+
+// Who a policy would page if an alert started escalating at a given time
+export interface EscalationPolicyPreview {
+  policy_id: string;
+  at: string;
+  repeat_enabled: boolean;
+  repeat_count?: number | null;
+  in_business_hours: boolean | null; // null without business hours
+  rules: EscalationRulePreview[];
+}
+
+export interface EscalationRulePreview {
+  rule_id: string;
+  position: number;
+  hours: EscalationRuleHours;
+  applies: boolean; // false when skipped because of its hours
+  delay_seconds: number;
+  // Seconds after escalation starts; null when the rule is never paged
+  page_after_seconds: number | null;
+  recipients: EscalationPreviewRecipient[];
+}
+
+export interface EscalationPreviewRecipient {
+  target_id: string;
+  target_type: EscalationTargetType;
+  schedule_mode?: ScheduleTargetMode;
+  user_id: string;
+  email: string;
+  unavailable: boolean;
+  channels: EscalationPreviewChannel[];
+}
+
+export interface EscalationPreviewChannel {
+  id: string;
+  name: string;
+  channel_type: string;
+}
@@ -14,7 +14,8 @@ export type TimelineEventType =
   | 'note_added'
   | 'alert_linked'
   | 'alert_unlinked'
-  | 'resolved';
+  | 'resolved'
+  | 'commander_changed';
 
 export interface Incident {
   id: string;
@@ -28,6 +29,11 @@ export interface Incident {
   assigned_to_team_id?: string;
   started_at: string;
   resolved_at?: string;
+  slack_channel_id?: string;
+  slack_channel_url?: string;
+  jira_issue_key?: string;
+  jira_issue_url?: string;
+  statuspage_incident_id?: string;
   created_at: string;
   updated_at: string;
 }
@@ -52,6 +58,7 @@ export interface IncidentTimelineEvent {
   description: string;
   metadata: Record<string, any>;
   created_at: string;
+  edited_at?: string;
 }
 
 export interface TimelineEventWithUser extends IncidentTimelineEvent {
@@ -70,6 +77,14 @@ export interface IncidentAlertWithDetails extends IncidentAlert {
   alert: Alert;
 }
 
+// An unlinked alert that looks related to the incident's linked alerts
+export interface SuggestedAlert {
+  alert: Alert;
+  score: number;
+  shared_tags: string[];
+  same_source: boolean;
+}
+
 export interface IncidentWithDetails extends Incident {
   responders?: ResponderWithUser[];
   alerts?: IncidentAlertWithDetails[];
@@ -83,6 +98,7 @@ export interface CreateIncidentRequest {
   severity: IncidentSeverity;
   priority: AlertPriority;
   assigned_to_team_id?: string;
+  alert_id?: string;
 }
 
 export interface UpdateIncidentRequest {
@@ -92,6 +108,9 @@ export interface UpdateIncidentRequest {
   status?: IncidentStatus;
   priority?: AlertPriority;
   assigned_to_team_id?: string;
+  // Close linked alerts when this update resolves the incident; defaults to
+  // the organization setting
+  close_linked_alerts?: boolean;
 }
 
 export interface AddResponderRequest {
@@ -107,8 +126,13 @@ export interface AddNoteRequest {
   note: string;
 }
 
+export interface UpdateNoteRequest {
+  note: string;
+}
+
 export interface LinkAlertRequest {
   alert_id: string;
+  copy_notes?: boolean;
 }
 
 export interface ListIncidentsParams {
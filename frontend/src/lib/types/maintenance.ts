@@ -0,0 +1,30 @@
+import type { RoutingConditions } from './routing';
+
+export interface MaintenanceWindow {
+  id: string;
+  organization_id: string;
+  name: string;
+  description?: string;
+  starts_at: string;
+  ends_at: string;
+  conditions: RoutingConditions;
+  created_by?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateMaintenanceWindowRequest {
+  name: string;
+  description?: string;
+  starts_at: string;
+  ends_at: string;
+  conditions?: RoutingConditions;
+}
+
+export interface UpdateMaintenanceWindowRequest {
+  name?: string;
+  description?: string;
+  starts_at?: string;
+  ends_at?: string;
+  conditions?: RoutingConditions;
+}
@@ -0,0 +1,7 @@
+// Enum display metadata Types
+
+export interface EnumValue {
+  value: string;
+  label: string;
+  color: string; // hex color
+}
@@ -1,5 +1,10 @@
 export type ChannelType = 'email' | 'slack' | 'teams' | 'webhook';
-export type NotificationStatus = 'pending' | 'sent' | 'failed';
+export type NotificationStatus =
+  | 'pending'
+  | 'sent'
+  | 'failed'
+  | 'suppressed_dnd'
+  | 'skipped_unavailable';
 
 export interface NotificationChannel {
   id: string;
@@ -37,6 +42,9 @@ export interface NotificationLog {
   status: NotificationStatus;
   error_message?: string;
   sent_at?: string;
+  is_test: boolean;
+  attempts: number;
+  next_retry_at?: string; // set while a failed notification waits to be retried
   created_at: string;
 }
 
@@ -55,6 +63,16 @@ export interface UpdateNotificationChannelRequest {
   config?: Record<string, unknown>;
 }
 
+export interface TestNotificationChannelRequest {
+  recipient?: string; // required for email channels
+}
+
+export interface TestNotificationChannelResponse {
+  success: boolean;
+  error?: string;
+  log: NotificationLog;
+}
+
 export interface CreateUserNotificationPreferenceRequest {
   channel_id: string;
   is_enabled?: boolean;
@@ -92,6 +110,15 @@ export interface ListUserNotificationPreferencesResponse {
   total: number;
 }
 
+export interface ListNotificationLogsParams {
+  status?: NotificationStatus[];
+  channel_id?: string;
+  from?: string; // RFC 3339, inclusive
+  to?: string; // RFC 3339, exclusive
+  limit?: number;
+  offset?: number;
+}
+
 export interface ListNotificationLogsResponse {
   logs: NotificationLog[];
   total: number;
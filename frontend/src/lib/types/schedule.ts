@@ -58,6 +58,36 @@ export interface OnCallUser {
   start_time: string;
   end_time: string;
   is_override: boolean;
+  skipped_user_ids?: string[];
+}
+
+// Schedules without anyone on call have a null on_call
+export interface ScheduleOnCall {
+  schedule: Schedule;
+  on_call: OnCallUser | null;
+}
+
+export interface ListScheduleOnCallResponse {
+  oncall: ScheduleOnCall[];
+}
+
+export interface CoverageGap {
+  start: string;
+  end: string;
+}
+
+export interface CoverageOverlap {
+  start: string;
+  end: string;
+  user_ids: string[];
+}
+
+export interface ScheduleCoverage {
+  schedule_id: string;
+  start: string;
+  end: string;
+  gaps: CoverageGap[];
+  overlaps: CoverageOverlap[];
 }
 
 export interface ScheduleWithRotations extends Schedule {
@@ -115,6 +145,10 @@ export interface ReorderParticipantsRequest {
   user_ids: string[];
 }
 
+export interface SetParticipantsRequest {
+  user_ids: string[];
+}
+
 export interface CreateOverrideRequest {
   user_id: string;
   start_time: string;
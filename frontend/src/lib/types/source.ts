@@ -0,0 +1,22 @@
+export interface AlertSource {
+  id: string;
+  organization_id: string;
+  name: string;
+  aliases: string[];
+  created_at: string;
+  updated_at: string;
+}
+
+export interface AlertSourceWithCount extends AlertSource {
+  alert_count: number;
+}
+
+export interface CreateAlertSourceRequest {
+  name: string;
+  aliases?: string[];
+}
+
+export interface UpdateAlertSourceRequest {
+  name?: string;
+  aliases?: string[];
+}
@@ -12,6 +12,29 @@ export interface User {
   updated_at: string;
 }
 
+export interface ListUsersParams {
+  search?: string;
+  team_id?: string;
+  is_active?: boolean;
+  page?: number;
+  page_size?: number;
+}
+
+export interface ListUsersResponse {
+  users: User[];
+  total: number;
+  page: number;
+  page_size: number;
+}
+
+export interface UserDeactivation {
+  user_id: string;
+  teams_left: number;
+  rotations_left: number;
+  overrides_removed: number;
+  unassigned_alert_ids: string[];
+}
+
 export interface Organization {
   id: string;
   name: string;
@@ -28,6 +51,21 @@ export interface AuthResponse {
   access_token: string;
   refresh_token: string;
   requires_email_verification?: boolean;
+  verification_email_failed?: boolean;
+}
+
+export interface Session {
+  id: string;
+  user_agent: string;
+  ip_address: string;
+  created_at: string;
+  last_used_at: string;
+  expires_at: string;
+  current: boolean;
+}
+
+export interface RevokeSessionsResponse {
+  revoked: number;
 }
 
 export interface VerifyEmailRequest {
@@ -50,4 +88,15 @@ export interface RegisterRequest {
 export interface LoginRequest {
   email: string;
   password: string;
+  organization_id?: string;
+}
+
+export interface OrganizationMembership {
+  id: string;
+  name: string;
+  slug: string;
+  plan: string;
+  role: string;
+  joined_at: string;
+  current: boolean;
 }
@@ -1,14 +1,37 @@
 export type WebhookDeliveryStatus = 'pending' | 'success' | 'failed';
 
-export type IncomingWebhookIntegrationType = 'generic' | 'prometheus' | 'grafana' | 'datadog';
+export type WebhookEventType =
+  | 'alert.created'
+  | 'alert.updated'
+  | 'alert.acknowledged'
+  | 'alert.closed'
+  | 'alert.escalated'
+  | 'alert.snoozed'
+  | 'alert.assigned'
+  | 'incident.created'
+  | 'incident.updated'
+  | 'incident.resolved'
+  | 'incident.severity_changed';
+
+export type IncomingWebhookIntegrationType =
+  | 'generic'
+  | 'prometheus'
+  | 'grafana'
+  | 'datadog'
+  | 'opsgenie';
 
 export interface WebhookEndpoint {
   id: string;
   organization_id: string;
   name: string;
   url: string;
+  // Full value only on creation and rotation; masked to its last four characters otherwise
+  secret?: string;
   enabled: boolean;
 
+  // Subscribed event types; the boolean filters below are derived from it
+  events: WebhookEventType[];
+
   // Event filters
   alert_created: boolean;
   alert_updated: boolean;
@@ -57,6 +80,7 @@ export interface IncomingWebhookToken {
   integration_type: IncomingWebhookIntegrationType;
   default_priority: string;
   default_tags: string[];
+  field_mapping?: IncomingWebhookFieldMapping;
   last_used_at?: string;
   request_count: number;
   created_at: string;
@@ -75,6 +99,7 @@ export interface CreateWebhookEndpointRequest {
   incident_created?: boolean;
   incident_updated?: boolean;
   incident_resolved?: boolean;
+  events?: WebhookEventType[];
   headers?: Record<string, string>;
   timeout_seconds?: number;
   max_retries?: number;
@@ -93,17 +118,47 @@ export interface UpdateWebhookEndpointRequest {
   incident_created?: boolean;
   incident_updated?: boolean;
   incident_resolved?: boolean;
+  events?: WebhookEventType[];
   headers?: Record<string, string>;
   timeout_seconds?: number;
   max_retries?: number;
   retry_delay_seconds?: number;
 }
 
+// A raw request received by an incoming webhook token, kept for debugging
+export interface IncomingWebhookRequest {
+  id: string;
+  token_id: string;
+  organization_id: string;
+  headers: Record<string, string>;
+  body: string;
+  body_truncated: boolean;
+  success: boolean;
+  error?: string;
+  alerts_received: number;
+  alert_ids: string[];
+  resolved_alert_ids: string[];
+  received_at: string;
+}
+
+// JSONPath-style paths (e.g. "$.data.level") locating alert fields in
+// generic webhook payloads
+export interface IncomingWebhookFieldMapping {
+  message?: string;
+  description?: string;
+  priority?: string;
+  priority_values?: Record<string, string>;
+  tags?: string;
+  dedup_key?: string;
+  source?: string;
+}
+
 export interface CreateIncomingWebhookTokenRequest {
   name: string;
   integration_type: IncomingWebhookIntegrationType;
   default_priority?: string;
   default_tags?: string[];
+  field_mapping?: IncomingWebhookFieldMapping;
 }
 
 export interface ListWebhookDeliveriesResponse {
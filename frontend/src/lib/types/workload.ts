@@ -0,0 +1,14 @@
+import type { Alert } from './alert';
+import type { Incident } from './incident';
+import type { ScheduleOnCall } from './schedule';
+
+// Workload Types
+
+export interface Workload {
+  alerts: Alert[];
+  alerts_total: number;
+  incidents: Incident[];
+  incidents_total: number;
+  on_call: boolean;
+  on_call_schedules: ScheduleOnCall[];
+}